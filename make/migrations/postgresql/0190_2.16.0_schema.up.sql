@@ -16,3 +16,310 @@ ALTER TABLE robot ALTER COLUMN id TYPE bigint;
 ALTER TABLE robot ALTER COLUMN creator_ref TYPE bigint;
 ALTER TABLE role_permission ALTER COLUMN role_id TYPE bigint;
 ALTER SEQUENCE robot_id_seq AS bigint MAXVALUE 9007199254740991;
+
+/*
+Add the tables for the time-series vulnerability trends of the security hub.
+
+security_snapshot stores the daily aggregates per project, the project_id 0 is for the whole system.
+cve_remediation tracks when a CVE is first seen in a project and when it's remediated,
+it's used to calculate the mean time to remediate the CVEs.
+*/
+CREATE TABLE IF NOT EXISTS security_snapshot (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id bigint NOT NULL,
+    snapshot_date date NOT NULL,
+    critical_cnt bigint NOT NULL DEFAULT 0,
+    high_cnt bigint NOT NULL DEFAULT 0,
+    medium_cnt bigint NOT NULL DEFAULT 0,
+    low_cnt bigint NOT NULL DEFAULT 0,
+    none_cnt bigint NOT NULL DEFAULT 0,
+    unknown_cnt bigint NOT NULL DEFAULT 0,
+    fixable_cnt bigint NOT NULL DEFAULT 0,
+    total_artifact_cnt bigint NOT NULL DEFAULT 0,
+    scanned_cnt bigint NOT NULL DEFAULT 0,
+    exceeding_cnt bigint NOT NULL DEFAULT 0,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_security_snapshot UNIQUE (project_id, snapshot_date)
+);
+
+CREATE TABLE IF NOT EXISTS cve_remediation (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id bigint NOT NULL,
+    cve_id varchar(255) NOT NULL,
+    first_seen date NOT NULL,
+    remediated_date date
+);
+
+/* only one open record is allowed for a CVE in a project */
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cve_remediation_open ON cve_remediation (project_id, cve_id) WHERE remediated_date IS NULL;
+CREATE INDEX IF NOT EXISTS idx_cve_remediation_remediated_date ON cve_remediation (remediated_date);
+
+/*
+the packages listed in the SBOM of the artifacts, indexed to search the artifacts containing a package
+*/
+CREATE TABLE IF NOT EXISTS sbom_package (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    artifact_id bigint NOT NULL,
+    project_id bigint NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL,
+    name varchar(255) NOT NULL,
+    version varchar(255),
+    purl varchar(1024),
+    license varchar(1024),
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_sbom_package_artifact_id ON sbom_package (artifact_id);
+CREATE INDEX IF NOT EXISTS idx_sbom_package_name ON sbom_package (lower(name));
+CREATE INDEX IF NOT EXISTS idx_sbom_package_purl ON sbom_package (purl varchar_pattern_ops);
+
+/*
+custom project roles composed of the rbac policies, the policies are stored in the role_permission
+and permission_policy tables with the role type "projectrole", the role IDs less than 100 are reserved for the built-in roles
+*/
+ALTER TABLE role ALTER COLUMN name TYPE varchar(255);
+ALTER TABLE role ADD COLUMN IF NOT EXISTS description text;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS custom boolean NOT NULL DEFAULT false;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS creation_time timestamp DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS update_time timestamp DEFAULT CURRENT_TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_name ON role (name);
+SELECT setval('role_role_id_seq', GREATEST((SELECT MAX(role_id) FROM role), 99));
+
+/*
+the trust relationships with the external OIDC issuers, the workloads presenting a token issued by the trusted
+issuer and matching the claim conditions are granted the configured permissions without a stored secret
+*/
+CREATE TABLE IF NOT EXISTS federation_trust (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name varchar(255) NOT NULL,
+    description text,
+    issuer varchar(1024) NOT NULL,
+    audience varchar(255) NOT NULL,
+    conditions text,
+    permissions text,
+    disabled boolean NOT NULL DEFAULT false,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_federation_trust_name UNIQUE (name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_federation_trust_issuer ON federation_trust (issuer);
+
+/*
+the previous secret of the robot is still valid in the grace period after refreshing the secret,
+the last used time and source IP are tracked to identify the robots which aren't used anymore
+*/
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS previous_secret varchar(2048);
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS previous_secret_expiresat bigint NOT NULL DEFAULT 0;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS last_used_time timestamp;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS last_used_ip varchar(64);
+
+/*
+the personal access tokens of the users, only the SHA256 digest of the token is stored,
+the token grants the subset of the owner's permissions listed in the permissions column,
+the group_ids column keeps the groups of the owner resolved at login by the LDAP and OIDC auth modes
+*/
+CREATE TABLE IF NOT EXISTS access_token (
+    id SERIAL PRIMARY KEY NOT NULL,
+    user_id int NOT NULL,
+    name varchar(255) NOT NULL,
+    description text,
+    token_hash varchar(64) NOT NULL,
+    permissions text,
+    group_ids text,
+    expiresat bigint NOT NULL DEFAULT -1,
+    last_used_time timestamp,
+    last_used_ip varchar(64),
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES harbor_user(user_id) ON DELETE CASCADE,
+    CONSTRAINT unique_access_token_hash UNIQUE (token_hash),
+    CONSTRAINT unique_access_token_name UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_token_user_id ON access_token (user_id);
+
+/*
+the users and groups provisioned via SCIM, the deactivated users can't login and the group membership
+pushed by the identity provider is maintained in Harbor DB rather than resolved at login
+*/
+ALTER TABLE harbor_user ADD COLUMN IF NOT EXISTS disabled boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS user_group_member (
+    id SERIAL PRIMARY KEY NOT NULL,
+    group_id int NOT NULL,
+    user_id int NOT NULL,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES user_group(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES harbor_user(user_id) ON DELETE CASCADE,
+    CONSTRAINT unique_user_group_member UNIQUE (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_member_user_id ON user_group_member (user_id);
+
+/*
+the artifact count, repository count and artifact size (the max size of a single artifact) quota resources,
+they are unlimited for the existing quotas and the usage is initialized from the artifacts and repositories
+*/
+UPDATE quota SET hard = '{"artifact_count": -1, "repository_count": -1, "artifact_size": -1}'::jsonb || hard
+WHERE reference = 'project';
+
+UPDATE quota_usage SET used = used || jsonb_build_object(
+    'artifact_count', (SELECT COUNT(*) FROM artifact WHERE artifact.project_id = CAST(quota_usage.reference_id AS integer)),
+    'repository_count', (SELECT COUNT(*) FROM repository WHERE repository.project_id = CAST(quota_usage.reference_id AS integer)),
+    'artifact_size', (SELECT COALESCE(MAX(size), 0) FROM artifact WHERE artifact.project_id = CAST(quota_usage.reference_id AS integer))
+)
+WHERE reference = 'project';
+
+/*
+the warning thresholds crossed and the overage state of the quota, it de-duplicates the quota warnings
+and tracks the grace period during which the usage is allowed to exceed the hard limits
+*/
+CREATE TABLE IF NOT EXISTS quota_state (
+    id SERIAL PRIMARY KEY NOT NULL,
+    quota_id int NOT NULL,
+    warning_levels jsonb NOT NULL DEFAULT '{}'::jsonb,
+    overage_start_time timestamp,
+    grace_end_time timestamp,
+    overage_notify_time timestamp,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_quota_state_quota_id UNIQUE (quota_id)
+);
+
+/*
+the project groups, the projects in a group share the storage quota of the group (the quota with reference 'project_group')
+on top of their own quotas, a project belongs to one group at most
+*/
+CREATE TABLE IF NOT EXISTS project_group (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name varchar(255) NOT NULL,
+    description text,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_project_group_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS project_group_member (
+    id SERIAL PRIMARY KEY NOT NULL,
+    group_id int NOT NULL,
+    project_id int NOT NULL,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_project_group_member_project_id UNIQUE (project_id),
+    FOREIGN KEY (group_id) REFERENCES project_group(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES project(project_id) ON DELETE CASCADE
+);
+
+/*
+the job queue of the jobservice with the 'postgresql' pool backend, the jobs are dequeued with "FOR UPDATE SKIP LOCKED",
+the row of a job is removed once the job is done or dead
+*/
+CREATE TABLE IF NOT EXISTS job_service_queue (
+    id BIGSERIAL PRIMARY KEY NOT NULL,
+    job_id varchar(64) NOT NULL,
+    job_name varchar(128) NOT NULL,
+    args text,
+    unique_key varchar(64),
+    priority int NOT NULL DEFAULT 0,
+    fails int NOT NULL DEFAULT 0,
+    last_err text,
+    state varchar(16) NOT NULL DEFAULT 'pending',
+    enqueued_at bigint NOT NULL,
+    run_at bigint NOT NULL,
+    worker_pool_id varchar(64),
+    heartbeat_at bigint,
+    CONSTRAINT unique_job_service_queue_job UNIQUE (job_id, run_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_service_queue_dequeue ON job_service_queue (state, run_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_job_service_queue_unique_key ON job_service_queue (unique_key) WHERE unique_key IS NOT NULL AND state = 'pending';
+
+/* the job stats tracked by the jobservice with the 'postgresql' pool backend */
+CREATE TABLE IF NOT EXISTS job_service_stats (
+    job_id varchar(128) PRIMARY KEY NOT NULL,
+    upstream_job_id varchar(64),
+    status varchar(16) NOT NULL,
+    revision bigint NOT NULL DEFAULT 0,
+    run_at bigint NOT NULL DEFAULT 0,
+    info jsonb NOT NULL,
+    ack jsonb,
+    update_time bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_service_stats_upstream_job_id ON job_service_stats (upstream_job_id, run_at);
+CREATE INDEX IF NOT EXISTS idx_job_service_stats_status ON job_service_stats (status, update_time);
+
+/* the periodic job policies of the jobservice with the 'postgresql' pool backend */
+CREATE TABLE IF NOT EXISTS job_service_periodic_policy (
+    id SERIAL PRIMARY KEY NOT NULL,
+    policy_id varchar(64) NOT NULL,
+    policy text NOT NULL,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_job_service_periodic_policy_policy_id UNIQUE (policy_id)
+);
+
+/* the heartbeats of the worker pools of the jobservice with the 'postgresql' pool backend */
+CREATE TABLE IF NOT EXISTS job_service_worker_pool (
+    pool_id varchar(64) PRIMARY KEY NOT NULL,
+    job_names text,
+    concurrency int NOT NULL DEFAULT 0,
+    started_at bigint NOT NULL,
+    heartbeat_at bigint NOT NULL
+);
+
+/* the workflows chaining the executions, the nodes of a workflow form a tree rooted by the nodes without upstream */
+CREATE TABLE IF NOT EXISTS workflow (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name varchar(255) NOT NULL,
+    status varchar(32) NOT NULL,
+    status_message text,
+    creator varchar(255),
+    start_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    end_time timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_status ON workflow (status);
+
+CREATE TABLE IF NOT EXISTS workflow_node (
+    id SERIAL PRIMARY KEY NOT NULL,
+    workflow_id int NOT NULL,
+    upstream_id int NOT NULL DEFAULT 0,
+    trigger_on varchar(16) NOT NULL,
+    name varchar(255) NOT NULL,
+    vendor_type varchar(64) NOT NULL,
+    parameters text,
+    execution_id int NOT NULL DEFAULT 0,
+    status varchar(32) NOT NULL,
+    status_message text,
+    start_time timestamp,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    end_time timestamp,
+    FOREIGN KEY (workflow_id) REFERENCES workflow(id) ON DELETE CASCADE,
+    CONSTRAINT unique_workflow_node_name UNIQUE (workflow_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_node_workflow_id ON workflow_node (workflow_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_node_status ON workflow_node (status);
+CREATE INDEX IF NOT EXISTS idx_workflow_node_execution_id ON workflow_node (execution_id);
+
+/* the paused executions stop dequeuing their pending tasks while the running ones finish */
+ALTER TABLE execution ADD COLUMN IF NOT EXISTS paused boolean NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_execution_paused ON execution (paused) WHERE paused = true;
+
+/* the maintenance windows during which the selected job types are paused automatically */
+CREATE TABLE IF NOT EXISTS maintenance_window (
+    id SERIAL PRIMARY KEY NOT NULL,
+    name varchar(255) NOT NULL,
+    description text,
+    job_types text NOT NULL,
+    cron varchar(64),
+    duration int NOT NULL DEFAULT 0,
+    start_time timestamp,
+    end_time timestamp,
+    enabled boolean NOT NULL DEFAULT true,
+    creation_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    update_time timestamp DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_maintenance_window_name UNIQUE (name)
+);
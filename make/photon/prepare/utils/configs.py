@@ -89,6 +89,21 @@ def validate(conf: dict, **kwargs):
     if conf.get('core'):
         conf['core'].validate()
 
+    # job queue backend validate
+    if conf.get('job_queue_backend') not in ('redis', 'postgresql'):
+        raise Exception("Error: the backend of the jobservice must be 'redis' or 'postgresql'")
+
+    # job logger settings validate
+    job_logger_settings = conf.get('job_logger_settings') or {}
+    for logger_name, required in {'OBJECT_STORAGE': ['endpoint', 'bucket'], 'LOKI': ['endpoint'], 'OTLP': ['endpoint']}.items():
+        if logger_name not in (conf.get('job_loggers') or []):
+            continue
+        settings = job_logger_settings.get(logger_name.lower()) or {}
+        for key in required:
+            if not settings.get(key):
+                raise Exception("Error: must set {} of the job_logger_settings.{} to enable the {} job logger".format(
+                    key, logger_name.lower(), logger_name))
+
 
 def parse_versions():
     if not versions_file_path.is_file():
@@ -225,11 +240,13 @@ def parse_yaml_config(config_file_path, with_trivy):
     # jobservice config
     js_config = configs.get('jobservice') or {}
     config_dict['max_job_workers'] = js_config["max_job_workers"]
+    config_dict['job_queue_backend'] = js_config.get("backend") or 'redis'
     config_dict['max_job_duration_hours'] = js_config.get("max_job_duration_hours") or 24
     value = config_dict["max_job_duration_hours"]
     if not isinstance(value, int) or value < 24:
         config_dict["max_job_duration_hours"] = 24
     config_dict['job_loggers'] = js_config["job_loggers"]
+    config_dict['job_logger_settings'] = js_config.get("job_logger_settings") or {}
     config_dict['logger_sweeper_duration'] = js_config["logger_sweeper_duration"]
     config_dict['jobservice_secret'] = generate_random_string(16)
 
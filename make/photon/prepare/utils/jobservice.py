@@ -33,8 +33,10 @@ def prepare_job_service(config_dict):
         gid=DEFAULT_GID,
         internal_tls=config_dict['internal_tls'],
         max_job_workers=config_dict['max_job_workers'],
+        job_queue_backend=config_dict['job_queue_backend'],
         max_job_duration_hours=config_dict['max_job_duration_hours'],
         job_loggers=config_dict['job_loggers'],
+        job_logger_settings=config_dict['job_logger_settings'],
         logger_sweeper_duration=config_dict['logger_sweeper_duration'],
         redis_url=config_dict['redis_url_js'],
         level=log_level,
@@ -149,6 +149,26 @@ const (
 	AuthProxyUserNamePrefix = "tokenreview$"
 	CoreConfigPath          = "/api/v2.0/internalconfig"
 	RobotTokenDuration      = "robot_token_duration"
+	// RobotSecretGracePeriod is the hours in which the previous secret of the robot is still valid after refreshing
+	RobotSecretGracePeriod = "robot_secret_grace_period"
+	// RobotExpirationNotifyDays is the days before the expiration of the robot to send the notification
+	RobotExpirationNotifyDays = "robot_expiration_notify_days"
+	// SCIMToken is the bearer token of the identity provider to provision the users and groups via SCIM, empty means SCIM is disabled
+	SCIMToken = "scim_token"
+	// LoginMaxFailures is the login failures of the user within the window to lock the user out, 0 disables the lockout
+	LoginMaxFailures = "login_max_failures"
+	// LoginMaxFailuresPerIP is the login failures from the client IP within the window to lock the IP out, 0 disables the lockout
+	LoginMaxFailuresPerIP = "login_max_failures_per_ip"
+	// LoginFailureWindow is the minutes within which the login failures are counted
+	LoginFailureWindow = "login_failure_window"
+	// LoginLockoutDuration is the minutes for which the user or the client IP is locked out
+	LoginLockoutDuration = "login_lockout_duration"
+	// ExecutionRetentionDays is the days within which the executions are retained by the execution sweeper, 0 disables the time based retention
+	ExecutionRetentionDays = "execution_retention_days"
+	// ExecutionArchiveEnabled is the flag to archive the swept executions and their task logs as system artifacts before deleting them
+	ExecutionArchiveEnabled = "execution_archive_enabled"
+	// ExecutionRetentionCounts is the counts of the executions retained by the execution sweeper for the vendor types
+	ExecutionRetentionCounts = "execution_retention_counts"
 
 	OIDCCallbackPath = "/c/oidc/callback"
 	OIDCLoginPath    = "/c/oidc/login"
@@ -160,8 +180,11 @@ const (
 	NotificationEnable = "notification_enable"
 
 	// Quota setting items for project
-	QuotaPerProjectEnable = "quota_per_project_enable"
-	StoragePerProject     = "storage_per_project"
+	QuotaPerProjectEnable     = "quota_per_project_enable"
+	StoragePerProject         = "storage_per_project"
+	ArtifactCountPerProject   = "artifact_count_per_project"
+	RepositoryCountPerProject = "repository_count_per_project"
+	ArtifactSizePerProject    = "artifact_size_per_project"
 
 	// DefaultGCTimeWindowHours is the reserve blob time window used by GC, default is 2 hours
 	DefaultGCTimeWindowHours = int64(2)
@@ -27,6 +27,7 @@ import (
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/http/modifier/auth"
 	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -46,6 +47,8 @@ var (
 type Client interface {
 	SubmitJob(*models.JobData) (string, error)
 	GetJobLog(uuid string) ([]byte, error)
+	// QueryJobLog gets the log of a job filtered and rendered with the query
+	QueryJobLog(uuid string, q *query.LogQuery) ([]byte, error)
 	PostAction(uuid, action string) error
 	GetExecutions(uuid string) ([]job.Stats, error)
 	// TODO Redirect joblog when we see there's memory issue.
@@ -153,7 +156,16 @@ func (d *DefaultClient) SubmitJob(jd *models.JobData) (string, error) {
 
 // GetJobLog call jobservice API to get the log of a job.  It only accepts the UUID of the job
 func (d *DefaultClient) GetJobLog(uuid string) ([]byte, error) {
+	return d.QueryJobLog(uuid, nil)
+}
+
+// QueryJobLog call jobservice API to get the log entries of a job matching the query.
+// The whole log is returned if the query is nil
+func (d *DefaultClient) QueryJobLog(uuid string, q *query.LogQuery) ([]byte, error) {
 	url := d.endpoint + "/api/v1/jobs/" + uuid + "/log"
+	if values := q.Values(); len(values) > 0 {
+		url += "?" + values.Encode()
+	}
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
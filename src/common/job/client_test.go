@@ -9,6 +9,7 @@ import (
 
 	"github.com/goharbor/harbor/src/common/job/models"
 	"github.com/goharbor/harbor/src/common/job/test"
+	"github.com/goharbor/harbor/src/jobservice/common/query"
 )
 
 var (
@@ -50,6 +51,17 @@ func TestGetJobLog(t *testing.T) {
 	assert.Contains(text, "The content in this file is for mocking the get log api.")
 }
 
+func TestQueryJobLog(t *testing.T) {
+	assert := assert.New(t)
+	b, err := testClient.QueryJobLog(ID, &query.LogQuery{Level: "ERROR", Keyword: "failed"})
+	assert.Nil(err)
+	assert.Equal("level=ERROR&q=failed", string(b))
+
+	b, err = testClient.QueryJobLog(ID, nil)
+	assert.Nil(err)
+	assert.Contains(string(b), "The content in this file is for mocking the get log api.")
+}
+
 func TestGetExecutions(t *testing.T) {
 	assert := assert.New(t)
 	exes, err := testClient.GetExecutions(ID)
@@ -55,6 +55,13 @@ func NewJobServiceServer() *httptest.Server {
 			}
 			rw.Header().Add("Content-Type", "text/plain")
 			rw.WriteHeader(http.StatusOK)
+			// echo the log query back for verifying the query is passed
+			if len(req.URL.RawQuery) > 0 {
+				if _, err := rw.Write([]byte(req.URL.RawQuery)); err != nil {
+					panic(err)
+				}
+				return
+			}
 			f := path.Join(currPath(), "test.log")
 			b, _ := os.ReadFile(f)
 			_, err := rw.Write(b)
@@ -18,4 +18,6 @@ package models
 type AuthModel struct {
 	Principal string
 	Password  string
+	// ClientIP is the IP of the client sending the credentials, the login failures are tracked per client IP as well
+	ClientIP string
 }
@@ -28,9 +28,11 @@ type User struct {
 	Realname        string `json:"realname"`
 	Comment         string `json:"comment"`
 	Deleted         bool   `json:"deleted"`
-	Rolename        string `json:"role_name"`
-	Role            int    `json:"role_id"`
-	SysAdminFlag    bool   `json:"sysadmin_flag"`
+	// Disabled the deactivated user can't login, e.g. the user deprovisioned via SCIM
+	Disabled     bool   `json:"disabled"`
+	Rolename     string `json:"role_name"`
+	Role         int    `json:"role_id"`
+	SysAdminFlag bool   `json:"sysadmin_flag"`
 	// AdminRoleInAuth to store the admin privilege granted by external authentication provider
 	AdminRoleInAuth bool      `json:"admin_role_in_auth"`
 	ResetUUID       string    `json:"reset_uuid"`
@@ -81,6 +81,8 @@ const (
 	ResourceExportCVE          = Resource("export-cve")
 	ResourceJobServiceMonitor  = Resource("jobservice-monitor")
 	ResourceSecurityHub        = Resource("security-hub")
+	ResourceRole               = Resource("role")
+	ResourceFederationTrust    = Resource("federation-trust")
 )
 
 type scope string
@@ -212,6 +214,7 @@ var (
 			{Resource: ResourcePurgeAuditLog, Action: ActionStop},
 
 			{Resource: ResourceJobServiceMonitor, Action: ActionList},
+			{Resource: ResourceJobServiceMonitor, Action: ActionUpdate},
 			{Resource: ResourceJobServiceMonitor, Action: ActionStop},
 
 			{Resource: ResourceScanner, Action: ActionRead},
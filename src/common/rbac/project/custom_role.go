@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	rbac_pkg "github.com/goharbor/harbor/src/pkg/rbac"
+	role_model "github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+// rbacMgr is used to load the policies of the custom roles
+var rbacMgr = rbac_pkg.Mgr
+
+// IsBuiltInRole returns whether the role ID is one of the built-in project roles
+func IsBuiltInRole(roleID int) bool {
+	switch roleID {
+	case common.RoleProjectAdmin,
+		common.RoleMaintainer,
+		common.RoleDeveloper,
+		common.RoleGuest,
+		common.RoleLimitedGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// listCustomRolePolicies lists the policies of the custom roles in the role IDs, the built-in roles are skipped
+func listCustomRolePolicies(ctx context.Context, roleIDs []int) (map[int][]*types.Policy, error) {
+	policies := map[int][]*types.Policy{}
+	for _, roleID := range roleIDs {
+		if IsBuiltInRole(roleID) {
+			continue
+		}
+		permissions, err := rbacMgr.GetPermissionsByRole(ctx, role_model.PermissionRoleType, int64(roleID))
+		if err != nil {
+			return nil, err
+		}
+		// an empty slice rather than nil marks the role as a custom one even if it has no policies
+		rolePolicies := make([]*types.Policy, 0, len(permissions))
+		for _, permission := range permissions {
+			rolePolicies = append(rolePolicies, &types.Policy{
+				Resource: types.Resource(permission.Resource),
+				Action:   types.Action(permission.Action),
+				Effect:   types.Effect(permission.Effect),
+			})
+		}
+		policies[roleID] = rolePolicies
+	}
+	return policies, nil
+}
@@ -47,10 +47,17 @@ func NewBuilderForUser(user *models.User, ctl project.Controller) RBACUserBuilde
 			return nil
 		}
 
+		customRolePolicies, err := listCustomRolePolicies(ctx, roles)
+		if err != nil {
+			log.Errorf("failed to list policies of the custom roles: %v", err)
+			return nil
+		}
+
 		return &rbacUser{
-			project:      p,
-			username:     user.Username,
-			projectRoles: roles,
+			project:            p,
+			username:           user.Username,
+			projectRoles:       roles,
+			customRolePolicies: customRolePolicies,
 		}
 	}
 }
@@ -24,8 +24,12 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	rbac_pkg "github.com/goharbor/harbor/src/pkg/rbac"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	role_model "github.com/goharbor/harbor/src/pkg/role/model"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	"github.com/goharbor/harbor/src/testing/mock"
+	rbactesting "github.com/goharbor/harbor/src/testing/pkg/rbac"
 )
 
 var (
@@ -106,6 +110,35 @@ func TestProjectRoleAccess(t *testing.T) {
 	}
 }
 
+func TestCustomRoleAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	mgr := &rbactesting.Manager{}
+	defer func(m rbac_pkg.Manager) { rbacMgr = m }(rbacMgr)
+	rbacMgr = mgr
+
+	// the CI publisher role is able to push but not to delete
+	mock.OnAnything(mgr, "GetPermissionsByRole").Return([]*rbac_model.UniversalRolePermission{
+		{RoleType: role_model.PermissionRoleType, RoleID: 100, Resource: "repository", Action: "push"},
+		{RoleType: role_model.PermissionRoleType, RoleID: 100, Resource: "repository", Action: "pull"},
+	}, nil)
+
+	ctl := &projecttesting.Controller{}
+	mock.OnAnything(ctl, "Get").Return(private, nil)
+	mock.OnAnything(ctl, "ListRoles").Return([]int{100}, nil)
+
+	user := &models.User{
+		UserID:   1,
+		Username: "username",
+	}
+	evaluator := NewEvaluator(ctl, NewBuilderForUser(user, ctl))
+	resource := NewNamespace(private.ProjectID).Resource(rbac.ResourceRepository)
+	assert.True(evaluator.HasPermission(context.TODO(), resource, rbac.ActionPush))
+	assert.True(evaluator.HasPermission(context.TODO(), resource, rbac.ActionPull))
+	assert.False(evaluator.HasPermission(context.TODO(), resource, rbac.ActionDelete))
+	mgr.AssertCalled(t, "GetPermissionsByRole", mock.Anything, role_model.PermissionRoleType, int64(100))
+}
+
 func BenchmarkProjectEvaluator(b *testing.B) {
 	ctl := &projecttesting.Controller{}
 	mock.OnAnything(ctl, "Get").Return(public, nil)
@@ -15,6 +15,8 @@
 package project
 
 import (
+	"fmt"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
@@ -308,6 +310,8 @@ var (
 type projectRBACRole struct {
 	projectID int64
 	roleID    int
+	// policies of the custom role, nil for the built-in roles
+	customPolicies []*types.Policy
 }
 
 // GetRoleName returns role name for the visitor role
@@ -324,6 +328,10 @@ func (role *projectRBACRole) GetRoleName() string {
 	case common.RoleLimitedGuest:
 		return "limitedGuest"
 	default:
+		if role.customPolicies != nil {
+			// prefix the ID to avoid conflicting with the built-in role names and the usernames
+			return fmt.Sprintf("customRole%d", role.roleID)
+		}
 		return ""
 	}
 }
@@ -337,8 +345,13 @@ func (role *projectRBACRole) GetPolicies() []*types.Policy {
 		return policies
 	}
 
+	rolePolicies := role.customPolicies
+	if rolePolicies == nil {
+		rolePolicies = rolePoliciesMap[roleName]
+	}
+
 	namespace := NewNamespace(role.projectID)
-	for _, policy := range rolePoliciesMap[roleName] {
+	for _, policy := range rolePolicies {
 		policies = append(policies, &types.Policy{
 			Resource: namespace.Resource(policy.Resource),
 			Action:   policy.Action,
@@ -24,6 +24,8 @@ type rbacUser struct {
 	username     string
 	projectRoles []int
 	policies     []*types.Policy
+	// policies of the custom roles in projectRoles, keyed by the role ID
+	customRolePolicies map[int][]*types.Policy
 }
 
 // GetUserName returns username of the visitor
@@ -46,7 +48,11 @@ func (pru *rbacUser) GetPolicies() []*types.Policy {
 func (pru *rbacUser) GetRoles() []types.RBACRole {
 	roles := []types.RBACRole{}
 	for _, roleID := range pru.projectRoles {
-		roles = append(roles, &projectRBACRole{projectID: pru.project.ProjectID, roleID: roleID})
+		roles = append(roles, &projectRBACRole{
+			projectID:      pru.project.ProjectID,
+			roleID:         roleID,
+			customPolicies: pru.customRolePolicies[roleID],
+		})
 	}
 
 	return roles
@@ -0,0 +1,42 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+// RepositoryResource returns the resource of the repository in the project, the repository is the name without the project name,
+// e.g. /project/1/repository/team-a/service-x for the resource "repository" and the repository "team-a/service-x"
+func RepositoryResource(projectID int64, repository string, resource types.Resource) types.Resource {
+	return NewNamespace(projectID).Resource(rbac.RepositoryScopedResource(resource, repository))
+}
+
+// CanAccessRepository returns whether the security context can do the action on the resource of the repository,
+// the permission may be granted for the whole project or only for the repositories matching the name pattern
+func CanAccessRepository(ctx context.Context, secCtx security.Context, projectID int64, repository string,
+	action types.Action, resource types.Resource) bool {
+	if secCtx.Can(ctx, action, NewNamespace(projectID).Resource(resource)) {
+		return true
+	}
+	if len(repository) == 0 || !rbac.IsRepositoryScopedResource(resource) {
+		return false
+	}
+	return secCtx.Can(ctx, action, RepositoryResource(projectID, repository, resource))
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	rbac_pkg "github.com/goharbor/harbor/src/pkg/rbac"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	role_model "github.com/goharbor/harbor/src/pkg/role/model"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	rbactesting "github.com/goharbor/harbor/src/testing/pkg/rbac"
+)
+
+func TestRepositoryResource(t *testing.T) {
+	assert.Equal(t, types.Resource("/project/1/repository/team-a/service-x"), RepositoryResource(1, "team-a/service-x", rbac.ResourceRepository))
+	assert.Equal(t, types.Resource("/project/1/artifact"), RepositoryResource(1, "", rbac.ResourceArtifact))
+}
+
+func TestCanAccessRepository(t *testing.T) {
+	assert := assert.New(t)
+
+	mgr := &rbactesting.Manager{}
+	defer func(m rbac_pkg.Manager) { rbacMgr = m }(rbacMgr)
+	rbacMgr = mgr
+
+	// the role is able to push the repositories of team-a only, and to list the labels of the whole project
+	mock.OnAnything(mgr, "GetPermissionsByRole").Return([]*rbac_model.UniversalRolePermission{
+		{RoleType: role_model.PermissionRoleType, RoleID: 100, Resource: "repository/team-a/*", Action: "push"},
+		{RoleType: role_model.PermissionRoleType, RoleID: 100, Resource: "label", Action: "list"},
+	}, nil)
+
+	ctl := &projecttesting.Controller{}
+	mock.OnAnything(ctl, "Get").Return(private, nil)
+	mock.OnAnything(ctl, "ListRoles").Return([]int{100}, nil)
+
+	user := &models.User{
+		UserID:   1,
+		Username: "username",
+	}
+	evaluator := NewEvaluator(ctl, NewBuilderForUser(user, ctl))
+	secCtx := &securitytesting.Context{}
+	secCtx.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, action types.Action, resource types.Resource) bool {
+			return evaluator.HasPermission(ctx, resource, action)
+		})
+
+	ctx := context.TODO()
+	assert.True(CanAccessRepository(ctx, secCtx, private.ProjectID, "team-a/service-x", rbac.ActionPush, rbac.ResourceRepository))
+	assert.False(CanAccessRepository(ctx, secCtx, private.ProjectID, "team-b/service-x", rbac.ActionPush, rbac.ResourceRepository))
+	assert.False(CanAccessRepository(ctx, secCtx, private.ProjectID, "team-a/service-x", rbac.ActionDelete, rbac.ResourceRepository))
+	// the project level permission isn't granted
+	assert.False(CanAccessRepository(ctx, secCtx, private.ProjectID, "", rbac.ActionPush, rbac.ResourceRepository))
+	// the resources can't be restricted to the repositories are checked at the project level
+	assert.True(CanAccessRepository(ctx, secCtx, private.ProjectID, "team-b/service-x", rbac.ActionList, rbac.ResourceLabel))
+}
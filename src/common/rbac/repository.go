@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+var (
+	// the resources can be restricted to the repositories matching a name pattern
+	repositoryScopedResources = map[Resource]bool{
+		ResourceRepository:       true,
+		ResourceArtifact:         true,
+		ResourceTag:              true,
+		ResourceAccessory:        true,
+		ResourceArtifactAddition: true,
+		ResourceArtifactLabel:    true,
+		ResourceScan:             true,
+		ResourceSBOM:             true,
+	}
+
+	// the repository name pattern is the repository name without the project name, "*" matches any characters
+	repositoryPatternRe = regexp.MustCompile(`^[a-z0-9*]+(?:(?:[._]|__|[-]+|/)[a-z0-9*]+)*$`)
+)
+
+// IsRepositoryScopedResource returns whether the resource can be restricted to the repositories matching a name pattern
+func IsRepositoryScopedResource(resource Resource) bool {
+	return repositoryScopedResources[resource]
+}
+
+// RepositoryScopedResource returns the resource restricted to the repositories matching the pattern,
+// e.g. "repository/team-a/*" for the resource "repository" and the pattern "team-a/*"
+func RepositoryScopedResource(resource Resource, pattern string) Resource {
+	if len(pattern) == 0 {
+		return resource
+	}
+	return Resource(resource.String() + "/" + pattern)
+}
+
+// ParseRepositoryScopedResource splits the resource restricted to the repositories into the resource and the pattern,
+// the resource itself and an empty pattern are returned if it isn't restricted
+func ParseRepositoryScopedResource(resource Resource) (Resource, string) {
+	base, pattern, found := strings.Cut(resource.String(), "/")
+	if !found || len(pattern) == 0 || !IsRepositoryScopedResource(Resource(base)) {
+		return resource, ""
+	}
+	return Resource(base), pattern
+}
+
+// ValidateRepositoryPattern validates the repository name pattern
+func ValidateRepositoryPattern(pattern string) error {
+	if !repositoryPatternRe.MatchString(pattern) {
+		return errors.BadRequestError(nil).WithMessagef("invalid repository pattern %q, it should be the repository name without the project name and can contain the wildcard *", pattern)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryScopedResource(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(ResourceRepository, RepositoryScopedResource(ResourceRepository, ""))
+	assert.Equal(Resource("repository/team-a/*"), RepositoryScopedResource(ResourceRepository, "team-a/*"))
+
+	resource, pattern := ParseRepositoryScopedResource("repository/team-a/*")
+	assert.Equal(ResourceRepository, resource)
+	assert.Equal("team-a/*", pattern)
+
+	resource, pattern = ParseRepositoryScopedResource(ResourceArtifact)
+	assert.Equal(ResourceArtifact, resource)
+	assert.Empty(pattern)
+
+	// the resources can't be restricted to the repositories are kept as they are
+	resource, pattern = ParseRepositoryScopedResource("member/team-a")
+	assert.Equal(Resource("member/team-a"), resource)
+	assert.Empty(pattern)
+}
+
+func TestValidateRepositoryPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, pattern := range []string{"*", "app", "team-a/*", "team-a/service-*", "team_a/app.v1", "*/app"} {
+		assert.Nil(ValidateRepositoryPattern(pattern), pattern)
+	}
+	for _, pattern := range []string{"", "/app", "app/", "Team-A/*", "team a", "team-a//app", "app:latest"} {
+		assert.NotNil(ValidateRepositoryPattern(pattern), pattern)
+	}
+}
@@ -83,6 +83,7 @@ var (
 
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionRead},
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionList},
+		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionUpdate},
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionStop},
 
 		{Resource: rbac.ResourceSecurityHub, Action: rbac.ActionRead},
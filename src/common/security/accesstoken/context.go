@@ -0,0 +1,139 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/permission/evaluator"
+	"github.com/goharbor/harbor/src/pkg/permission/evaluator/admin"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+// ContextName the name of the security context.
+const ContextName = "accesstoken"
+
+// SecurityContext implements security.Context interface for the users authenticated by the personal access tokens,
+// the user can only do the actions permitted by both the token and the user's own permissions
+type SecurityContext struct {
+	user           *models.User
+	token          *model.AccessToken
+	ctl            project.Controller
+	userEvaluator  evaluator.Evaluator
+	tokenEvaluator evaluator.Evaluator
+	once           sync.Once
+}
+
+// NewSecurityContext ...
+func NewSecurityContext(user *models.User, token *model.AccessToken) *SecurityContext {
+	return &SecurityContext{
+		user:  user,
+		token: token,
+		ctl:   project.Ctl,
+	}
+}
+
+// Name returns the name of the security context
+func (s *SecurityContext) Name() string {
+	return ContextName
+}
+
+// IsAuthenticated returns true if the user has been authenticated
+func (s *SecurityContext) IsAuthenticated() bool {
+	return s.user != nil && s.token != nil
+}
+
+// GetUsername returns the username of the token owner
+func (s *SecurityContext) GetUsername() string {
+	if !s.IsAuthenticated() {
+		return ""
+	}
+	return s.user.Username
+}
+
+// User returns the owner of the token
+func (s *SecurityContext) User() *models.User {
+	return s.user
+}
+
+// Token returns the personal access token
+func (s *SecurityContext) Token() *model.AccessToken {
+	return s.token
+}
+
+// IsSysAdmin the token is limited to the project level permissions, so it's never a system admin even owned by one
+func (s *SecurityContext) IsSysAdmin() bool {
+	return false
+}
+
+// IsSolutionUser ...
+func (s *SecurityContext) IsSolutionUser() bool {
+	return false
+}
+
+// Can returns whether the token owner can do action on resource with the token
+func (s *SecurityContext) Can(ctx context.Context, action types.Action, resource types.Resource) bool {
+	if !s.IsAuthenticated() {
+		return false
+	}
+
+	s.once.Do(func() {
+		var evaluators evaluator.Evaluators
+		if s.user.SysAdminFlag || s.user.AdminRoleInAuth {
+			evaluators = evaluators.Add(admin.New(s.GetUsername()))
+		}
+		s.userEvaluator = evaluators.Add(rbac_project.NewEvaluator(s.ctl, rbac_project.NewBuilderForUser(s.user, s.ctl)))
+
+		var policies []*types.Policy
+		for _, p := range s.token.Permissions {
+			scope, err := s.scope(ctx, p.Namespace)
+			if err != nil {
+				log.Warningf("failed to get the scope of the namespace %s for the token %s: %v", p.Namespace, s.token.Name, err)
+				continue
+			}
+			for _, a := range p.Access {
+				res := types.Resource(fmt.Sprintf("%s/%s", scope, a.Resource))
+				policies = append(policies, &types.Policy{Resource: res, Action: a.Action, Effect: a.Effect})
+				// give the PUSH action a pull access
+				if a.Action == rbac.ActionPush {
+					policies = append(policies, &types.Policy{Resource: res, Action: rbac.ActionPull})
+				}
+			}
+		}
+		s.tokenEvaluator = rbac_project.NewEvaluator(s.ctl, rbac_project.NewBuilderForPolicies(s.GetUsername(), policies))
+	})
+
+	return s.tokenEvaluator.HasPermission(ctx, resource, action) && s.userEvaluator.HasPermission(ctx, resource, action)
+}
+
+// scope returns the scope of the project namespace, the namespace "*" means all the projects
+func (s *SecurityContext) scope(ctx context.Context, namespace string) (string, error) {
+	if namespace == "*" {
+		return "/project/*", nil
+	}
+	p, err := s.ctl.GetByName(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/project/%d", p.ProjectID), nil
+}
@@ -0,0 +1,113 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+var (
+	private = &proModels.Project{
+		ProjectID: 1,
+		Name:      "library",
+		OwnerID:   1,
+	}
+)
+
+func TestIsAuthenticated(t *testing.T) {
+	ctx := NewSecurityContext(nil, nil)
+	assert.False(t, ctx.IsAuthenticated())
+	assert.Equal(t, "", ctx.GetUsername())
+
+	ctx = NewSecurityContext(&models.User{Username: "test", SysAdminFlag: true}, &model.AccessToken{Name: "ci"})
+	assert.True(t, ctx.IsAuthenticated())
+	assert.Equal(t, "test", ctx.GetUsername())
+	assert.False(t, ctx.IsSysAdmin())
+	assert.False(t, ctx.IsSolutionUser())
+	assert.Equal(t, ContextName, ctx.Name())
+}
+
+func TestCan(t *testing.T) {
+	token := &model.AccessToken{
+		Name: "ci",
+		Permissions: []*model.Permission{
+			{
+				Namespace: "library",
+				Access: []*types.Policy{
+					{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+				},
+			},
+		},
+	}
+	resource := project.NewNamespace(private.ProjectID).Resource(rbac.ResourceRepository)
+
+	{
+		// the user is a developer of the project
+		ctl := &projecttesting.Controller{}
+		mock.OnAnything(ctl, "Get").Return(private, nil)
+		mock.OnAnything(ctl, "GetByName").Return(private, nil)
+		mock.OnAnything(ctl, "ListRoles").Return([]int{common.RoleDeveloper}, nil)
+
+		ctx := NewSecurityContext(&models.User{Username: "developer"}, token)
+		ctx.ctl = ctl
+		assert.True(t, ctx.Can(context.TODO(), rbac.ActionPush, resource))
+		// the push implies the pull
+		assert.True(t, ctx.Can(context.TODO(), rbac.ActionPull, resource))
+		// not permitted by the token
+		assert.False(t, ctx.Can(context.TODO(), rbac.ActionDelete, resource))
+		assert.False(t, ctx.Can(context.TODO(), rbac.ActionRead, project.NewNamespace(private.ProjectID).Resource(rbac.ResourceMember)))
+	}
+
+	{
+		// the user is only a guest of the project, the token can't elevate the permissions
+		ctl := &projecttesting.Controller{}
+		mock.OnAnything(ctl, "Get").Return(private, nil)
+		mock.OnAnything(ctl, "GetByName").Return(private, nil)
+		mock.OnAnything(ctl, "ListRoles").Return([]int{common.RoleGuest}, nil)
+
+		ctx := NewSecurityContext(&models.User{Username: "guest"}, token)
+		ctx.ctl = ctl
+		assert.False(t, ctx.Can(context.TODO(), rbac.ActionPush, resource))
+		assert.True(t, ctx.Can(context.TODO(), rbac.ActionPull, resource))
+	}
+
+	{
+		// the user is a developer of the project via the LDAP or OIDC group kept with the token
+		ctl := &projecttesting.Controller{}
+		mock.OnAnything(ctl, "Get").Return(private, nil)
+		mock.OnAnything(ctl, "GetByName").Return(private, nil)
+		ctl.On("ListRoles", mock.Anything, private.ProjectID, testifymock.MatchedBy(func(u *models.User) bool {
+			return len(u.GroupIDs) == 1 && u.GroupIDs[0] == 5
+		})).Return([]int{common.RoleDeveloper}, nil)
+
+		ctx := NewSecurityContext(&models.User{Username: "ldapuser", GroupIDs: []int{5}}, token)
+		ctx.ctl = ctl
+		assert.True(t, ctx.Can(context.TODO(), rbac.ActionPush, resource))
+	}
+}
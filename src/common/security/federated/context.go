@@ -0,0 +1,121 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federated
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/controller/federation"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/permission/evaluator"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+// ContextName the name of the security context.
+const ContextName = "federated"
+
+// SecurityContext implements security.Context interface for the workloads authenticated by the workload identity federation
+type SecurityContext struct {
+	identity  *federation.Identity
+	ctl       project.Controller
+	evaluator evaluator.Evaluator
+	once      sync.Once
+}
+
+// NewSecurityContext ...
+func NewSecurityContext(identity *federation.Identity) *SecurityContext {
+	return &SecurityContext{
+		ctl:      project.Ctl,
+		identity: identity,
+	}
+}
+
+// Name returns the name of the security context
+func (s *SecurityContext) Name() string {
+	return ContextName
+}
+
+// IsAuthenticated returns true if the workload has been authenticated
+func (s *SecurityContext) IsAuthenticated() bool {
+	return s.identity != nil
+}
+
+// GetUsername returns the name of the federated identity
+func (s *SecurityContext) GetUsername() string {
+	if !s.IsAuthenticated() {
+		return ""
+	}
+	return s.identity.Username()
+}
+
+// Identity returns the federated identity
+func (s *SecurityContext) Identity() *federation.Identity {
+	return s.identity
+}
+
+// IsSysAdmin the federated identity cannot be a system admin
+func (s *SecurityContext) IsSysAdmin() bool {
+	return false
+}
+
+// IsSolutionUser the federated identity cannot be a solution user
+func (s *SecurityContext) IsSolutionUser() bool {
+	return false
+}
+
+// Can returns whether the federated identity can do action on resource
+func (s *SecurityContext) Can(ctx context.Context, action types.Action, resource types.Resource) bool {
+	if s.identity == nil {
+		return false
+	}
+
+	s.once.Do(func() {
+		var policies []*types.Policy
+		for _, p := range s.identity.Permissions {
+			scope, err := s.scope(ctx, p.Namespace)
+			if err != nil {
+				log.Warningf("failed to get the scope of the namespace %s for %s: %v", p.Namespace, s.GetUsername(), err)
+				continue
+			}
+			for _, a := range p.Access {
+				res := types.Resource(fmt.Sprintf("%s/%s", scope, a.Resource))
+				policies = append(policies, &types.Policy{Resource: res, Action: a.Action, Effect: a.Effect})
+				// give the PUSH action a pull access
+				if a.Action == rbac.ActionPush {
+					policies = append(policies, &types.Policy{Resource: res, Action: rbac.ActionPull})
+				}
+			}
+		}
+		s.evaluator = rbac_project.NewEvaluator(s.ctl, rbac_project.NewBuilderForPolicies(s.GetUsername(), policies))
+	})
+	return s.evaluator != nil && s.evaluator.HasPermission(ctx, resource, action)
+}
+
+// scope returns the scope of the project namespace, the namespace "*" means all the projects
+func (s *SecurityContext) scope(ctx context.Context, namespace string) (string, error) {
+	if namespace == "*" {
+		return "/project/*", nil
+	}
+	p, err := s.ctl.GetByName(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/project/%d", p.ProjectID), nil
+}
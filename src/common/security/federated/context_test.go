@@ -0,0 +1,80 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federated
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/controller/federation"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+var (
+	private = &proModels.Project{
+		ProjectID: 1,
+		Name:      "library",
+		OwnerID:   1,
+	}
+)
+
+func TestIsAuthenticated(t *testing.T) {
+	ctx := NewSecurityContext(nil)
+	assert.False(t, ctx.IsAuthenticated())
+	assert.Equal(t, "", ctx.GetUsername())
+
+	ctx = NewSecurityContext(&federation.Identity{Subject: "repo:org/app:ref:refs/heads/main"})
+	assert.True(t, ctx.IsAuthenticated())
+	assert.Equal(t, "federated+repo:org/app:ref:refs/heads/main", ctx.GetUsername())
+	assert.False(t, ctx.IsSysAdmin())
+	assert.False(t, ctx.IsSolutionUser())
+	assert.Equal(t, ContextName, ctx.Name())
+}
+
+func TestCan(t *testing.T) {
+	identity := &federation.Identity{
+		Subject: "repo:org/app:ref:refs/heads/main",
+		Permissions: []*model.Permission{
+			{
+				Namespace: "library",
+				Access: []*types.Policy{
+					{Resource: "repository/org/*", Action: rbac.ActionPush},
+				},
+			},
+		},
+	}
+
+	ctl := &projecttesting.Controller{}
+	mock.OnAnything(ctl, "Get").Return(private, nil)
+	mock.OnAnything(ctl, "GetByName").Return(private, nil)
+
+	ctx := NewSecurityContext(identity)
+	ctx.ctl = ctl
+	resource := project.RepositoryResource(private.ProjectID, "org/app", rbac.ResourceRepository)
+	assert.True(t, ctx.Can(context.TODO(), rbac.ActionPush, resource))
+	// the push implies the pull
+	assert.True(t, ctx.Can(context.TODO(), rbac.ActionPull, resource))
+	assert.False(t, ctx.Can(context.TODO(), rbac.ActionDelete, resource))
+	assert.False(t, ctx.Can(context.TODO(), rbac.ActionPush, project.RepositoryResource(private.ProjectID, "other/app", rbac.ResourceRepository)))
+	assert.False(t, ctx.Can(context.TODO(), rbac.ActionPush, project.NewNamespace(private.ProjectID).Resource(rbac.ResourceRepository)))
+}
@@ -0,0 +1,210 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accesstoken"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/user"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
+)
+
+const (
+	// TokenPrefix is the prefix of the personal access tokens, it distinguishes the tokens from the passwords
+	TokenPrefix = "hpat_"
+	// the length of the random part of the token
+	tokenLength = 40
+	// the max length of the token name, it's the size of the name column of the access_token table
+	maxNameLength = 255
+	// the minimal interval to update the last used time of the token
+	usageRecordInterval = time.Minute
+)
+
+var (
+	// Ctl is a global variable for the default personal access token controller implementation
+	Ctl = NewController()
+)
+
+// Controller to handle the requests related with the personal access tokens
+type Controller interface {
+	// Get gets the token by ID
+	Get(ctx context.Context, id int64) (*model.AccessToken, error)
+	// Count returns the total count of tokens according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the tokens according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error)
+	// Create creates the token which expires after the duration in days, -1 means never expires.
+	// The ID and the token are returned, the token can't be retrieved anymore.
+	// The groups of the owner set to the token are kept for the auth modes resolving the groups at login
+	Create(ctx context.Context, t *model.AccessToken, duration int64) (int64, string, error)
+	// Delete deletes(revokes) the token by ID
+	Delete(ctx context.Context, id int64) error
+	// Authenticate returns the token and its owner if the token is valid and owned by the user
+	Authenticate(ctx context.Context, username, token string) (*model.AccessToken, *commonmodels.User, error)
+	// RecordUsage records the last used time and the source IP of the token
+	RecordUsage(ctx context.Context, t *model.AccessToken, ip string) error
+}
+
+// NewController creates an instance of the default personal access token controller
+func NewController() Controller {
+	return &controller{
+		tokenMgr: accesstoken.Mgr,
+		userMgr:  user.Mgr,
+		groupMgr: usergroup.Mgr,
+		authMode: config.AuthMode,
+	}
+}
+
+type controller struct {
+	tokenMgr accesstoken.Manager
+	userMgr  user.Manager
+	groupMgr usergroup.Manager
+	authMode func(ctx context.Context) (string, error)
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*model.AccessToken, error) {
+	return c.tokenMgr.Get(ctx, id)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.tokenMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error) {
+	return c.tokenMgr.List(ctx, query)
+}
+
+func (c *controller) Create(ctx context.Context, t *model.AccessToken, duration int64) (int64, string, error) {
+	if err := validate(t, duration); err != nil {
+		return 0, "", err
+	}
+	if duration == -1 {
+		t.ExpiresAt = -1
+	} else {
+		t.ExpiresAt = time.Now().AddDate(0, 0, int(duration)).Unix()
+	}
+
+	token := TokenPrefix + utils.GenerateRandomStringWithLen(tokenLength)
+	t.TokenHash = hash(token)
+	id, err := c.tokenMgr.Create(ctx, t)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, token, nil
+}
+
+func (c *controller) Delete(ctx context.Context, id int64) error {
+	return c.tokenMgr.Delete(ctx, id)
+}
+
+func (c *controller) Authenticate(ctx context.Context, username, token string) (*model.AccessToken, *commonmodels.User, error) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return nil, nil, errors.UnauthorizedError(nil).WithMessage("invalid personal access token")
+	}
+	t, err := c.tokenMgr.GetByTokenHash(ctx, hash(token))
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil, nil, errors.UnauthorizedError(nil).WithMessage("invalid personal access token")
+		}
+		return nil, nil, err
+	}
+	if t.IsExpired() {
+		return nil, nil, errors.UnauthorizedError(nil).WithMessagef("the personal access token %s is expired", t.Name)
+	}
+	u, err := c.userMgr.Get(ctx, t.UserID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil, nil, errors.UnauthorizedError(nil).WithMessagef("the owner of the personal access token %s not found", t.Name)
+		}
+		return nil, nil, err
+	}
+	if u.Username != username {
+		return nil, nil, errors.UnauthorizedError(nil).WithMessagef("the personal access token isn't owned by %s", username)
+	}
+	u.GroupIDs, err = c.groupIDs(ctx, t, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, u, nil
+}
+
+// groupIDs returns the groups of the token owner. The groups of the LDAP and OIDC users are resolved when they
+// log in, which can't be done with the token, so the ones kept when the token was created are used. Otherwise the
+// current membership in DB is loaded, so the removal of the user from a group takes effect on the existing tokens.
+// The membership provisioned via SCIM replaces them anyway when SCIM is enabled.
+func (c *controller) groupIDs(ctx context.Context, t *model.AccessToken, u *commonmodels.User) ([]int, error) {
+	mode, err := c.authMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if (mode == common.LDAPAuth || mode == common.OIDCAuth) && len(t.GroupIDs) > 0 {
+		return t.GroupIDs, nil
+	}
+	return c.groupMgr.ListGroupIDsByMember(ctx, u.UserID)
+}
+
+func (c *controller) RecordUsage(ctx context.Context, t *model.AccessToken, ip string) error {
+	if t == nil {
+		return nil
+	}
+	now := time.Now()
+	// avoid updating the database for every request with the token
+	if t.LastUsedIP == ip && now.Sub(t.LastUsedTime) < usageRecordInterval {
+		return nil
+	}
+	t.LastUsedTime, t.LastUsedIP = now, ip
+	return c.tokenMgr.Update(ctx, t, "last_used_time", "last_used_ip")
+}
+
+// validate validates the token, the duration must be either -1(never expires) or a positive integer
+func validate(t *model.AccessToken, duration int64) error {
+	if t == nil {
+		return errors.BadRequestError(nil).WithMessage("the access token is required")
+	}
+	t.Name = strings.TrimSpace(t.Name)
+	if len(t.Name) == 0 || len(t.Name) > maxNameLength {
+		return errors.BadRequestError(nil).WithMessagef("the length of the token name must be between 1 and %d", maxNameLength)
+	}
+	if duration != -1 && duration <= 0 {
+		return errors.BadRequestError(nil).WithMessagef("invalid duration %d, duration must be either -1(Never) or a positive integer", duration)
+	}
+	if len(t.Permissions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one permission is required for the token")
+	}
+	for _, p := range t.Permissions {
+		if p == nil || len(p.Namespace) == 0 || len(p.Access) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the namespace and access of the permission are required")
+		}
+	}
+	return nil
+}
+
+// hash returns the SHA256 digest of the token, the token is random enough to not need a salt
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
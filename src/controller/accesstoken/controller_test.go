@@ -0,0 +1,173 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/accesstoken"
+	"github.com/goharbor/harbor/src/testing/pkg/user"
+	"github.com/goharbor/harbor/src/testing/pkg/usergroup"
+)
+
+type ControllerTestSuite struct {
+	suite.Suite
+	tokenMgr *accesstoken.Manager
+	userMgr  *user.Manager
+	groupMgr *usergroup.Manager
+	ctl      *controller
+}
+
+func (suite *ControllerTestSuite) SetupTest() {
+	suite.tokenMgr = &accesstoken.Manager{}
+	suite.userMgr = &user.Manager{}
+	suite.groupMgr = &usergroup.Manager{}
+	suite.ctl = &controller{
+		tokenMgr: suite.tokenMgr,
+		userMgr:  suite.userMgr,
+		groupMgr: suite.groupMgr,
+		authMode: func(context.Context) (string, error) { return common.DBAuth, nil },
+	}
+}
+
+func (suite *ControllerTestSuite) permissions() []*model.Permission {
+	return []*model.Permission{
+		{
+			Namespace: "library",
+			Access:    []*types.Policy{{Resource: "repository", Action: "pull"}},
+		},
+	}
+}
+
+func (suite *ControllerTestSuite) TestCreate() {
+	ctx := context.TODO()
+
+	// invalid tokens
+	_, _, err := suite.ctl.Create(ctx, &model.AccessToken{Name: " ", Permissions: suite.permissions()}, 30)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+	_, _, err = suite.ctl.Create(ctx, &model.AccessToken{Name: "ci", Permissions: suite.permissions()}, 0)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+	_, _, err = suite.ctl.Create(ctx, &model.AccessToken{Name: "ci"}, 30)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+
+	var created *model.AccessToken
+	suite.tokenMgr.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*model.AccessToken)
+	}).Return(int64(1), nil)
+	id, token, err := suite.ctl.Create(ctx, &model.AccessToken{UserID: 2, Name: "ci", Permissions: suite.permissions()}, 30)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), id)
+	suite.True(strings.HasPrefix(token, TokenPrefix))
+	suite.Equal(hash(token), created.TokenHash)
+	suite.InDelta(time.Now().AddDate(0, 0, 30).Unix(), created.ExpiresAt, 5)
+
+	_, _, err = suite.ctl.Create(ctx, &model.AccessToken{UserID: 2, Name: "never", Permissions: suite.permissions()}, -1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(-1), created.ExpiresAt)
+}
+
+func (suite *ControllerTestSuite) TestAuthenticate() {
+	ctx := context.TODO()
+	token := TokenPrefix + "abc"
+
+	// not a personal access token
+	_, _, err := suite.ctl.Authenticate(ctx, "tester", "Harbor12345")
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	// unknown token
+	suite.tokenMgr.On("GetByTokenHash", mock.Anything, hash(TokenPrefix+"unknown")).Return(nil, errors.NotFoundError(nil))
+	_, _, err = suite.ctl.Authenticate(ctx, "tester", TokenPrefix+"unknown")
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	// expired token
+	suite.tokenMgr.On("GetByTokenHash", mock.Anything, hash(TokenPrefix+"expired")).Return(&model.AccessToken{
+		UserID:    2,
+		ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+	}, nil)
+	_, _, err = suite.ctl.Authenticate(ctx, "tester", TokenPrefix+"expired")
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	suite.tokenMgr.On("GetByTokenHash", mock.Anything, hash(token)).Return(&model.AccessToken{
+		UserID:    2,
+		ExpiresAt: -1,
+	}, nil)
+	suite.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "tester"}, nil)
+	suite.groupMgr.On("ListGroupIDsByMember", mock.Anything, 2).Return([]int{3}, nil)
+
+	// the token of another user
+	_, _, err = suite.ctl.Authenticate(ctx, "another", token)
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	t, u, err := suite.ctl.Authenticate(ctx, "tester", token)
+	suite.Require().NoError(err)
+	suite.Equal(2, t.UserID)
+	suite.Equal("tester", u.Username)
+	suite.Equal([]int{3}, u.GroupIDs)
+}
+
+func (suite *ControllerTestSuite) TestAuthenticateWithGroupsResolvedAtLogin() {
+	ctx := context.TODO()
+	token := TokenPrefix + "ldap"
+	// the LDAP group is kept when the token was created and isn't in the membership table filled by SCIM only
+	suite.tokenMgr.On("GetByTokenHash", mock.Anything, hash(token)).Return(&model.AccessToken{
+		UserID:    2,
+		ExpiresAt: -1,
+		GroupIDs:  []int{5},
+	}, nil)
+	suite.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "tester"}, nil)
+	suite.groupMgr.On("ListGroupIDsByMember", mock.Anything, 2).Return([]int{}, nil)
+
+	for _, mode := range []string{common.LDAPAuth, common.OIDCAuth} {
+		suite.ctl.authMode = func(context.Context) (string, error) { return mode, nil }
+		_, u, err := suite.ctl.Authenticate(ctx, "tester", token)
+		suite.Require().NoError(err)
+		suite.Equal([]int{5}, u.GroupIDs)
+	}
+	suite.groupMgr.AssertNotCalled(suite.T(), "ListGroupIDsByMember", mock.Anything, 2)
+
+	// the membership in DB is loaded for the other auth modes
+	suite.ctl.authMode = func(context.Context) (string, error) { return common.DBAuth, nil }
+	_, u, err := suite.ctl.Authenticate(ctx, "tester", token)
+	suite.Require().NoError(err)
+	suite.Empty(u.GroupIDs)
+}
+
+func (suite *ControllerTestSuite) TestRecordUsage() {
+	ctx := context.TODO()
+	suite.tokenMgr.On("Update", mock.Anything, mock.Anything, "last_used_time", "last_used_ip").Return(nil)
+
+	t := &model.AccessToken{ID: 1}
+	suite.NoError(suite.ctl.RecordUsage(ctx, t, "10.0.0.1"))
+	suite.Equal("10.0.0.1", t.LastUsedIP)
+	suite.NoError(suite.ctl.RecordUsage(ctx, t, "10.0.0.1"))
+	suite.tokenMgr.AssertNumberOfCalls(suite.T(), "Update", 1)
+	suite.NoError(suite.ctl.RecordUsage(ctx, t, "10.0.0.2"))
+	suite.tokenMgr.AssertNumberOfCalls(suite.T(), "Update", 2)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &ControllerTestSuite{})
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/config/metadata"
 	"github.com/goharbor/harbor/src/lib/config/models"
@@ -142,6 +143,14 @@ func (c *controller) validateCfg(ctx context.Context, cfgs map[string]any) error
 	if err = verifyValueLengthCfg(ctx, cfgs); err != nil {
 		return err
 	}
+	// verify the cfgs which can't be negative
+	if err = verifyNonNegativeCfg(ctx, cfgs); err != nil {
+		return err
+	}
+	// verify the vendor types of the execution retention counts
+	if err = verifyExecutionRetentionCountsCfg(ctx, cfgs); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -195,6 +204,54 @@ func verifyValueLengthCfg(_ context.Context, cfgs map[string]any) error {
 	return nil
 }
 
+// verifyNonNegativeCfg verifies the cfgs in which 0 disables the feature and the negative value is invalid.
+func verifyNonNegativeCfg(_ context.Context, cfgs map[string]any) error {
+	validateCfgs := []string{
+		common.RobotSecretGracePeriod,
+		common.RobotExpirationNotifyDays,
+		common.LoginMaxFailures,
+		common.LoginMaxFailuresPerIP,
+		common.LoginFailureWindow,
+		common.LoginLockoutDuration,
+		common.ExecutionRetentionDays,
+	}
+
+	for _, c := range validateCfgs {
+		if v, exist := cfgs[c]; exist {
+			// the cfgs is unmarshal from json string, the number type will be float64
+			if vf, ok := v.(float64); ok && vf < 0 {
+				return errors.BadRequestError(nil).WithMessagef("the %s value must not be negative", c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyExecutionRetentionCountsCfg verifies the execution retention counts are set for the vendor types swept by the sweeper,
+// the values are verified to be non-negative by the metadata.
+func verifyExecutionRetentionCountsCfg(_ context.Context, cfgs map[string]any) error {
+	v, exist := cfgs[common.ExecutionRetentionCounts]
+	if !exist {
+		return nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return errors.BadRequestError(nil).WithMessagef("the %s value must be a JSON string", common.ExecutionRetentionCounts)
+	}
+	counts := map[string]int64{}
+	if err := json.Unmarshal([]byte(str), &counts); err != nil {
+		return errors.BadRequestError(err).WithMessagef("invalid %s value", common.ExecutionRetentionCounts)
+	}
+	known := job.GetExecutionSweeperCount()
+	for vendor := range counts {
+		if _, exist := known[vendor]; !exist {
+			return errors.BadRequestError(nil).WithMessagef("the executions of the vendor type %s are not swept", vendor)
+		}
+	}
+	return nil
+}
+
 // maxValueLimitedByLength returns the max value can be equaled limited by the fixed length.
 func maxValueLimitedByLength(length int) int64 {
 	// return -1 if length is negative
@@ -120,3 +120,65 @@ func Test_verifyValueLengthCfg(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyNonNegativeCfg(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfgs    map[string]any
+		wantErr bool
+	}{
+		{name: "valid config", cfgs: map[string]any{
+			common.RobotSecretGracePeriod:    float64(24),
+			common.RobotExpirationNotifyDays: float64(7),
+		}, wantErr: false},
+		{name: "disabled by zero", cfgs: map[string]any{
+			common.RobotSecretGracePeriod:    float64(0),
+			common.RobotExpirationNotifyDays: float64(0),
+		}, wantErr: false},
+		{name: "invalid config with negative value", cfgs: map[string]any{
+			common.RobotSecretGracePeriod: float64(-1),
+		}, wantErr: true},
+		{name: "invalid login max failures", cfgs: map[string]any{
+			common.LoginMaxFailures: float64(-5),
+		}, wantErr: true},
+		{name: "invalid execution retention days", cfgs: map[string]any{
+			common.ExecutionRetentionDays: float64(-90),
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyNonNegativeCfg(context.TODO(), tt.cfgs); (err != nil) != tt.wantErr {
+				t.Errorf("verifyNonNegativeCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_verifyExecutionRetentionCountsCfg(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfgs    map[string]any
+		wantErr bool
+	}{
+		{name: "not set", cfgs: map[string]any{}, wantErr: false},
+		{name: "valid config", cfgs: map[string]any{
+			common.ExecutionRetentionCounts: `{"REPLICATION":100,"WEBHOOK":10}`,
+		}, wantErr: false},
+		{name: "unknown vendor type", cfgs: map[string]any{
+			common.ExecutionRetentionCounts: `{"UNKNOWN":100}`,
+		}, wantErr: true},
+		{name: "invalid json", cfgs: map[string]any{
+			common.ExecutionRetentionCounts: `{"REPLICATION":`,
+		}, wantErr: true},
+		{name: "not a string", cfgs: map[string]any{
+			common.ExecutionRetentionCounts: float64(100),
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyExecutionRetentionCountsCfg(context.TODO(), tt.cfgs); (err != nil) != tt.wantErr {
+				t.Errorf("verifyExecutionRetentionCountsCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
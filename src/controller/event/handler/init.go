@@ -24,6 +24,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/handler/replication"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/artifact"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/quota"
+	"github.com/goharbor/harbor/src/controller/event/handler/webhook/robot"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/scan"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -44,6 +45,7 @@ func init() {
 	_ = notifier.Subscribe(event.TopicScanningCompleted, &scan.Handler{})
 	_ = notifier.Subscribe(event.TopicReplication, &artifact.ReplicationHandler{})
 	_ = notifier.Subscribe(event.TopicTagRetention, &artifact.RetentionHandler{})
+	_ = notifier.Subscribe(event.TopicRobotExpiring, &robot.Handler{})
 
 	// replication
 	_ = notifier.Subscribe(event.TopicPushArtifact, &replication.Handler{})
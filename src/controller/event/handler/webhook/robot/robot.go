@@ -0,0 +1,103 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/handler/util"
+	evtModel "github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// Handler preprocess robot event data
+type Handler struct {
+}
+
+// Name ...
+func (r *Handler) Name() string {
+	return "RobotWebhook"
+}
+
+// Handle ...
+func (r *Handler) Handle(ctx context.Context, value any) error {
+	if !config.NotificationEnable(ctx) {
+		log.Debug("notification feature is not enabled")
+		return nil
+	}
+
+	robotEvent, ok := value.(*event.RobotExpiringEvent)
+	if !ok {
+		return errors.New("invalid robot event type")
+	}
+	if robotEvent == nil || robotEvent.Robot == nil {
+		return fmt.Errorf("nil robot event")
+	}
+
+	payload := constructRobotPayload(robotEvent)
+	for _, namespace := range robotEvent.Namespaces {
+		prj, err := project.Ctl.GetByName(ctx, namespace)
+		if err != nil {
+			log.Errorf("failed to get project:%s, error: %v", namespace, err)
+			continue
+		}
+
+		policies, err := notification.PolicyMgr.GetRelatedPolices(ctx, prj.ProjectID, robotEvent.EventType)
+		if err != nil {
+			log.Errorf("failed to find policy for %s event: %v", robotEvent.EventType, err)
+			return err
+		}
+		if len(policies) == 0 {
+			log.Debugf("cannot find policy for %s event: %v", robotEvent.EventType, robotEvent)
+			continue
+		}
+
+		if err := util.SendHookWithPolicies(ctx, policies, payload, robotEvent.EventType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsStateful ...
+func (r *Handler) IsStateful() bool {
+	return false
+}
+
+func constructRobotPayload(event *event.RobotExpiringEvent) *notifyModel.Payload {
+	robot := &evtModel.Robot{
+		Name:        event.Robot.Name,
+		Description: event.Robot.Description,
+		ExpiresAt:   event.Robot.ExpiresAt,
+		LastUsedIP:  event.Robot.LastUsedIP,
+	}
+	if !event.Robot.LastUsedTime.IsZero() {
+		robot.LastUsedTime = event.Robot.LastUsedTime.Unix()
+	}
+	return &notifyModel.Payload{
+		Type:    event.EventType,
+		OccurAt: event.OccurAt.Unix(),
+		EventData: &notifyModel.EventData{
+			Robot: robot,
+		},
+	}
+}
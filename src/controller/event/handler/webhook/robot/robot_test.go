@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	robotModel "github.com/goharbor/harbor/src/pkg/robot/model"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testing_notification "github.com/goharbor/harbor/src/testing/pkg/notification/policy"
+)
+
+type RobotHandlerTestSuite struct {
+	suite.Suite
+	originalPolicyMgr  policy.Manager
+	originalProjectCtl project.Controller
+	policyMgr          *testing_notification.Manager
+	projectCtl         *projecttesting.Controller
+}
+
+func (suite *RobotHandlerTestSuite) SetupSuite() {
+	config.InitWithSettings(map[string]any{
+		common.NotificationEnable: true,
+	})
+	suite.originalPolicyMgr = notification.PolicyMgr
+	suite.originalProjectCtl = project.Ctl
+}
+
+func (suite *RobotHandlerTestSuite) SetupTest() {
+	suite.policyMgr = &testing_notification.Manager{}
+	suite.projectCtl = &projecttesting.Controller{}
+	notification.PolicyMgr = suite.policyMgr
+	project.Ctl = suite.projectCtl
+}
+
+func (suite *RobotHandlerTestSuite) TearDownSuite() {
+	notification.PolicyMgr = suite.originalPolicyMgr
+	project.Ctl = suite.originalProjectCtl
+}
+
+func (suite *RobotHandlerTestSuite) TestHandle() {
+	handler := &Handler{}
+	ctx := context.TODO()
+
+	// invalid event
+	suite.Error(handler.Handle(ctx, &event.QuotaEvent{}))
+
+	evt := &event.RobotExpiringEvent{
+		EventType: event.TopicRobotExpiring,
+		Robot: &robotModel.Robot{
+			Name:      "robot$library+test",
+			ExpiresAt: time.Now().AddDate(0, 0, 7).Unix(),
+		},
+		Namespaces: []string{"library", "deleted"},
+		OccurAt:    time.Now(),
+	}
+	suite.projectCtl.On("GetByName", mock.Anything, "library").Return(&proModels.Project{ProjectID: 1, Name: "library"}, nil)
+	suite.projectCtl.On("GetByName", mock.Anything, "deleted").Return(nil, errors.NotFoundError(nil))
+	suite.policyMgr.On("GetRelatedPolices", mock.Anything, int64(1), event.TopicRobotExpiring).Return([]*policy_model.Policy{}, nil)
+	suite.NoError(handler.Handle(ctx, evt))
+	suite.policyMgr.AssertNumberOfCalls(suite.T(), "GetRelatedPolices", 1)
+}
+
+func (suite *RobotHandlerTestSuite) TestConstructRobotPayload() {
+	lastUsed := time.Now().Add(-time.Hour)
+	evt := &event.RobotExpiringEvent{
+		EventType: event.TopicRobotExpiring,
+		Robot: &robotModel.Robot{
+			Name:         "robot$library+test",
+			ExpiresAt:    100,
+			LastUsedTime: lastUsed,
+			LastUsedIP:   "10.0.0.1",
+		},
+		OccurAt: time.Now(),
+	}
+	payload := constructRobotPayload(evt)
+	suite.Equal(event.TopicRobotExpiring, payload.Type)
+	suite.Require().NotNil(payload.EventData.Robot)
+	suite.Equal("robot$library+test", payload.EventData.Robot.Name)
+	suite.Equal(int64(100), payload.EventData.Robot.ExpiresAt)
+	suite.Equal(lastUsed.Unix(), payload.EventData.Robot.LastUsedTime)
+	suite.Equal("10.0.0.1", payload.EventData.Robot.LastUsedIP)
+
+	// never used
+	evt.Robot.LastUsedTime = time.Time{}
+	suite.Zero(constructRobotPayload(evt).EventData.Robot.LastUsedTime)
+}
+
+func TestRobotHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &RobotHandlerTestSuite{})
+}
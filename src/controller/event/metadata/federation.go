@@ -0,0 +1,61 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+const opLogin = "login"
+
+// FederatedLoginEventMetadata is the metadata from which the login event of the workload
+// authenticated by the workload identity federation can be resolved
+type FederatedLoginEventMetadata struct {
+	Username string
+	Issuer   string
+	// the names of the trusts matched by the token
+	Trusts       []string
+	IsSuccessful bool
+	Reason       string
+}
+
+// Resolve to the event from the metadata
+func (f *FederatedLoginEventMetadata) Resolve(evt *event.Event) error {
+	description := fmt.Sprintf("federated login with the token issued by %s", f.Issuer)
+	if len(f.Trusts) > 0 {
+		description = fmt.Sprintf("%s, trusts: %s", description, strings.Join(f.Trusts, ","))
+	}
+	if len(f.Reason) > 0 {
+		description = fmt.Sprintf("%s, reason: %s", description, f.Reason)
+	}
+	evt.Topic = event2.TopicCommonEvent
+	evt.Data = &model.CommonEvent{
+		Operator:             f.Username,
+		ResourceType:         rbac.ResourceFederationTrust.String(),
+		ResourceName:         strings.Join(f.Trusts, ","),
+		OcurrAt:              time.Now(),
+		Operation:            opLogin,
+		OperationDescription: description,
+		IsSuccessful:         f.IsSuccessful,
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+type federationEventTestSuite struct {
+	suite.Suite
+}
+
+func (f *federationEventTestSuite) TestResolveOfFederatedLoginEventMetadata() {
+	e := &event.Event{}
+	metadata := &FederatedLoginEventMetadata{
+		Username:     "federated+repo:org/app:ref:refs/heads/main",
+		Issuer:       "https://token.actions.githubusercontent.com",
+		Trusts:       []string{"github-app"},
+		IsSuccessful: true,
+	}
+	f.Require().Nil(metadata.Resolve(e))
+	f.Equal(event2.TopicCommonEvent, e.Topic)
+	data, ok := e.Data.(*model.CommonEvent)
+	f.Require().True(ok)
+	f.Equal("federated+repo:org/app:ref:refs/heads/main", data.Operator)
+	f.Equal("federation-trust", data.ResourceType)
+	f.Equal("github-app", data.ResourceName)
+	f.Equal("login", data.Operation)
+	f.True(data.IsSuccessful)
+	f.Contains(data.OperationDescription, "https://token.actions.githubusercontent.com")
+}
+
+func TestFederationEventTestSuite(t *testing.T) {
+	suite.Run(t, &federationEventTestSuite{})
+}
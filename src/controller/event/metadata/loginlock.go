@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+const (
+	opLock   = "lock"
+	opUnlock = "unlock"
+)
+
+// LoginLockEventMetadata is the metadata from which the event of locking out the login of the user
+// or the client IP after the failures, or unlocking it, can be resolved
+type LoginLockEventMetadata struct {
+	// Operator is the user whose login failed for the lockout or the administrator for the unlock
+	Operator string
+	// Kind is the kind of the lock, user or ip
+	Kind string
+	// Name is the username or the client IP
+	Name string
+	// Duration is the period of the lockout, zero for the unlock
+	Duration time.Duration
+	// Unlock is true when the lock is removed by the administrator
+	Unlock bool
+}
+
+// Resolve to the event from the metadata
+func (l *LoginLockEventMetadata) Resolve(evt *event.Event) error {
+	operation := opLock
+	description := fmt.Sprintf("lock out the login of %s %s for %v after too many failures", l.Kind, l.Name, l.Duration)
+	if l.Unlock {
+		operation = opUnlock
+		description = fmt.Sprintf("unlock the login of %s %s", l.Kind, l.Name)
+	}
+	evt.Topic = event2.TopicCommonEvent
+	evt.Data = &model.CommonEvent{
+		Operator:             l.Operator,
+		ResourceType:         rbac.ResourceUser.String(),
+		ResourceName:         l.Name,
+		OcurrAt:              time.Now(),
+		Operation:            operation,
+		OperationDescription: description,
+		IsSuccessful:         true,
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+type loginLockEventTestSuite struct {
+	suite.Suite
+}
+
+func (l *loginLockEventTestSuite) TestResolveOfLock() {
+	e := &event.Event{}
+	metadata := &LoginLockEventMetadata{
+		Operator: "alice",
+		Kind:     "ip",
+		Name:     "10.0.0.1",
+		Duration: 15 * time.Minute,
+	}
+	l.Require().Nil(metadata.Resolve(e))
+	l.Equal(event2.TopicCommonEvent, e.Topic)
+	data, ok := e.Data.(*model.CommonEvent)
+	l.Require().True(ok)
+	l.Equal("alice", data.Operator)
+	l.Equal("user", data.ResourceType)
+	l.Equal("10.0.0.1", data.ResourceName)
+	l.Equal("lock", data.Operation)
+	l.Contains(data.OperationDescription, "15m0s")
+}
+
+func (l *loginLockEventTestSuite) TestResolveOfUnlock() {
+	e := &event.Event{}
+	metadata := &LoginLockEventMetadata{
+		Operator: "admin",
+		Kind:     "user",
+		Name:     "alice",
+		Unlock:   true,
+	}
+	l.Require().Nil(metadata.Resolve(e))
+	data, ok := e.Data.(*model.CommonEvent)
+	l.Require().True(ok)
+	l.Equal("admin", data.Operator)
+	l.Equal("alice", data.ResourceName)
+	l.Equal("unlock", data.Operation)
+	l.True(data.IsSuccessful)
+}
+
+func TestLoginLockEventTestSuite(t *testing.T) {
+	suite.Run(t, &loginLockEventTestSuite{})
+}
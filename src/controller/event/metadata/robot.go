@@ -76,3 +76,21 @@ func (d *DeleteRobotEventMetadata) Resolve(event *event.Event) error {
 	event.Data = data
 	return nil
 }
+
+// RobotExpiringEventMetadata is the metadata from which the robot expiring event can be resolved
+type RobotExpiringEventMetadata struct {
+	Robot      *model.Robot
+	Namespaces []string
+}
+
+// Resolve to the event from the metadata
+func (r *RobotExpiringEventMetadata) Resolve(event *event.Event) error {
+	event.Topic = event2.TopicRobotExpiring
+	event.Data = &event2.RobotExpiringEvent{
+		EventType:  event2.TopicRobotExpiring,
+		Robot:      r.Robot,
+		Namespaces: r.Namespaces,
+		OccurAt:    time.Now(),
+	}
+	return nil
+}
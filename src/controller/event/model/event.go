@@ -88,6 +88,15 @@ type Scan struct {
 	ScanType string `json:"scan_type,omitempty"`
 }
 
+// Robot describes the robot account infos
+type Robot struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	ExpiresAt    int64  `json:"expires_at"`
+	LastUsedTime int64  `json:"last_used_time,omitempty"`
+	LastUsedIP   string `json:"last_used_ip,omitempty"`
+}
+
 // CommonEvent ...
 type CommonEvent struct {
 	Operator             string
@@ -50,6 +50,7 @@ const (
 	TopicTagRetention      = "TAG_RETENTION"
 	TopicCreateRobot       = "CREATE_ROBOT"
 	TopicDeleteRobot       = "DELETE_ROBOT"
+	TopicRobotExpiring     = "ROBOT_EXPIRING"
 	TopicCommonEvent       = "COMMON_API"
 	ResourceTypeProject    = "project"
 	ResourceTypeArtifact   = "artifact"
@@ -451,3 +452,17 @@ func (c *DeleteRobotEvent) String() string {
 	return fmt.Sprintf("Name-%s Operator-%s OccurAt-%s",
 		c.Robot.Name, c.Operator, c.OccurAt.Format("2006-01-02 15:04:05"))
 }
+
+// RobotExpiringEvent is the event of the robot which is going to expire
+type RobotExpiringEvent struct {
+	EventType string
+	Robot     *robotModel.Robot
+	// the names of the projects which the robot can access, the webhook policies of them are notified
+	Namespaces []string
+	OccurAt    time.Time
+}
+
+func (r *RobotExpiringEvent) String() string {
+	return fmt.Sprintf("Name-%s ExpiresAt-%s OccurAt-%s",
+		r.Robot.Name, time.Unix(r.Robot.ExpiresAt, 0).Format("2006-01-02 15:04:05"), r.OccurAt.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,263 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/federation"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+const (
+	// LoginUsername is the username of the basic auth with which the workloads present their tokens as the password,
+	// e.g. docker login -u federated -p $OIDC_TOKEN
+	LoginUsername = "federated"
+	// UsernamePrefix is the prefix of the names of the federated identities
+	UsernamePrefix = "federated+"
+
+	// the max count of the tokens remembered to avoid auditing the same login repeatedly
+	maxAuditedTokens = 10000
+)
+
+var (
+	// Ctl is a global variable for the default federation controller implementation
+	Ctl = NewController()
+)
+
+// Identity is the workload authenticated with the token issued by a trusted external issuer
+type Identity struct {
+	Issuer  string
+	Subject string
+	// the names of the trusts matched by the token
+	Trusts []string
+	// the permissions of all the matched trusts
+	Permissions []*model.Permission
+	ExpiresAt   time.Time
+}
+
+// Username returns the name of the identity
+func (i *Identity) Username() string {
+	return UsernamePrefix + i.Subject
+}
+
+// Controller to handle the requests related with the workload identity federation
+type Controller interface {
+	// Get gets the trust by ID
+	Get(ctx context.Context, id int64) (*model.Trust, error)
+	// Count returns the total count of trusts according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the trusts according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Trust, error)
+	// Create creates the trust
+	Create(ctx context.Context, t *model.Trust) (int64, error)
+	// Update updates the trust
+	Update(ctx context.Context, t *model.Trust) error
+	// Delete deletes the trust by ID
+	Delete(ctx context.Context, id int64) error
+	// Authenticate verifies the token against the enabled trusts of its issuer and returns the identity granted with
+	// the permissions of all the matched trusts, a NotFoundError is returned if no trust is configured for the issuer.
+	// The login is audited once for each token
+	Authenticate(ctx context.Context, rawToken string) (*Identity, error)
+}
+
+// NewController creates an instance of the default federation controller
+func NewController() Controller {
+	return &controller{
+		trustMgr: federation.Mgr,
+		verifier: federation.NewVerifier(),
+		audited:  map[string]time.Time{},
+	}
+}
+
+type controller struct {
+	trustMgr federation.Manager
+	verifier federation.Verifier
+
+	auditedLock sync.Mutex
+	// the hashes of the audited tokens and their expiry time
+	audited map[string]time.Time
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*model.Trust, error) {
+	return c.trustMgr.Get(ctx, id)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.trustMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.Trust, error) {
+	return c.trustMgr.List(ctx, query)
+}
+
+func (c *controller) Create(ctx context.Context, t *model.Trust) (int64, error) {
+	if err := validate(t); err != nil {
+		return 0, err
+	}
+	return c.trustMgr.Create(ctx, t)
+}
+
+func (c *controller) Update(ctx context.Context, t *model.Trust) error {
+	if err := validate(t); err != nil {
+		return err
+	}
+	return c.trustMgr.Update(ctx, t, "name", "description", "issuer", "audience", "conditions", "permissions", "disabled")
+}
+
+func (c *controller) Delete(ctx context.Context, id int64) error {
+	return c.trustMgr.Delete(ctx, id)
+}
+
+// validate validates the trust, the audience and at least one condition are required,
+// otherwise the tokens issued by the issuer for any other workloads would be accepted
+func validate(t *model.Trust) error {
+	if t == nil {
+		return errors.BadRequestError(nil).WithMessage("the trust is required")
+	}
+	if len(strings.TrimSpace(t.Name)) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the name of the trust is required")
+	}
+	u, err := url.Parse(t.Issuer)
+	if err != nil || u.Scheme != "https" || len(u.Host) == 0 {
+		return errors.BadRequestError(nil).WithMessagef("invalid issuer %q, it must be an https URL", t.Issuer)
+	}
+	if len(t.Audience) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the audience of the trust is required")
+	}
+	if len(t.Conditions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one claim condition is required for the trust")
+	}
+	for name, pattern := range t.Conditions {
+		if len(name) == 0 || len(pattern) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the claim name and pattern of the condition cannot be empty")
+		}
+	}
+	if len(t.Permissions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one permission is required for the trust")
+	}
+	for _, p := range t.Permissions {
+		if p == nil || len(p.Namespace) == 0 || len(p.Access) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the namespace and access of the permission are required")
+		}
+	}
+	return nil
+}
+
+func (c *controller) Authenticate(ctx context.Context, rawToken string) (*Identity, error) {
+	issuer, err := federation.IssuerOf(rawToken)
+	if err != nil || len(issuer) == 0 {
+		return nil, errors.NotFoundError(err).WithMessage("the token isn't a JWT issued by any trusted issuer")
+	}
+	trusts, err := c.trustMgr.List(ctx, q.New(q.KeyWords{"issuer": issuer, "disabled": false}))
+	if err != nil {
+		return nil, err
+	}
+	if len(trusts) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessagef("no trust is configured for the issuer %s", issuer)
+	}
+
+	identity, err := c.authenticate(ctx, issuer, rawToken, trusts)
+	if err != nil {
+		c.audit(ctx, rawToken, time.Now().Add(time.Hour), &metadata.FederatedLoginEventMetadata{
+			Username: UsernamePrefix + "unknown",
+			Issuer:   issuer,
+			Reason:   err.Error(),
+		})
+		return nil, err
+	}
+	c.audit(ctx, rawToken, identity.ExpiresAt, &metadata.FederatedLoginEventMetadata{
+		Username:     identity.Username(),
+		Issuer:       issuer,
+		Trusts:       identity.Trusts,
+		IsSuccessful: true,
+	})
+	return identity, nil
+}
+
+func (c *controller) authenticate(ctx context.Context, issuer, rawToken string, trusts []*model.Trust) (*Identity, error) {
+	identity := &Identity{Issuer: issuer}
+	// the token is verified once for each audience
+	verified := map[string]map[string]any{}
+	for _, t := range trusts {
+		claims, ok := verified[t.Audience]
+		if !ok {
+			var err error
+			claims, err = c.verifier.Verify(ctx, issuer, t.Audience, rawToken)
+			if err != nil {
+				log.Debugf("the token isn't valid for the trust %s: %v", t.Name, err)
+				continue
+			}
+			verified[t.Audience] = claims
+		}
+		if !federation.MatchConditions(t.Conditions, claims) {
+			continue
+		}
+		identity.Trusts = append(identity.Trusts, t.Name)
+		identity.Permissions = append(identity.Permissions, t.Permissions...)
+		if sub, ok := claims["sub"].(string); ok {
+			identity.Subject = sub
+		}
+		if exp, ok := claims["exp"].(float64); ok {
+			identity.ExpiresAt = time.Unix(int64(exp), 0)
+		}
+	}
+	if len(verified) == 0 {
+		return nil, errors.UnauthorizedError(nil).WithMessagef("the token cannot be verified with the trusts of the issuer %s", issuer)
+	}
+	if len(identity.Trusts) == 0 {
+		return nil, errors.UnauthorizedError(nil).WithMessagef("the claims of the token don't match any trust of the issuer %s", issuer)
+	}
+	return identity, nil
+}
+
+// audit publishes the login event if the token hasn't been audited
+func (c *controller) audit(ctx context.Context, rawToken string, expiresAt time.Time, md *metadata.FederatedLoginEventMetadata) {
+	sum := sha256.Sum256([]byte(rawToken))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	c.auditedLock.Lock()
+	if exp, ok := c.audited[key]; ok && exp.After(now) {
+		c.auditedLock.Unlock()
+		return
+	}
+	if len(c.audited) >= maxAuditedTokens {
+		for k, exp := range c.audited {
+			if !exp.After(now) {
+				delete(c.audited, k)
+			}
+		}
+		// drop all of them if the tokens are all still valid, the worst case is auditing some logins again
+		if len(c.audited) >= maxAuditedTokens {
+			c.audited = map[string]time.Time{}
+		}
+	}
+	c.audited[key] = expiresAt
+	c.auditedLock.Unlock()
+
+	event.BuildAndPublish(ctx, md)
+}
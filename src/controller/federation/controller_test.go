@@ -0,0 +1,164 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/federation"
+)
+
+const githubIssuer = "https://token.actions.githubusercontent.com"
+
+type ControllerTestSuite struct {
+	suite.Suite
+	trustMgr *federation.Manager
+	verifier *federation.Verifier
+	ctl      *controller
+}
+
+func (suite *ControllerTestSuite) SetupTest() {
+	suite.trustMgr = &federation.Manager{}
+	suite.verifier = &federation.Verifier{}
+	suite.ctl = &controller{
+		trustMgr: suite.trustMgr,
+		verifier: suite.verifier,
+		audited:  map[string]time.Time{},
+	}
+}
+
+func (suite *ControllerTestSuite) trust(name string, conditions map[string]string, resource string) *model.Trust {
+	return &model.Trust{
+		Name:       name,
+		Issuer:     githubIssuer,
+		Audience:   "harbor",
+		Conditions: conditions,
+		Permissions: []*model.Permission{
+			{
+				Namespace: "library",
+				Access:    []*types.Policy{{Resource: types.Resource(resource), Action: "push"}},
+			},
+		},
+	}
+}
+
+func (suite *ControllerTestSuite) token(issuer string) string {
+	// the token is verified by the mocked verifier, so the signature doesn't matter
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": issuer}).SignedString([]byte("secret"))
+	suite.Require().NoError(err)
+	return s
+}
+
+func (suite *ControllerTestSuite) TestCreate() {
+	_, err := suite.ctl.Create(context.TODO(), suite.trust("app", nil, "repository"))
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+
+	t := suite.trust("app", map[string]string{"repository": "org/app"}, "repository")
+	t.Issuer = "http://insecure.issuer"
+	_, err = suite.ctl.Create(context.TODO(), t)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+
+	t = suite.trust("app", map[string]string{"repository": "org/app"}, "repository")
+	t.Audience = ""
+	_, err = suite.ctl.Create(context.TODO(), t)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+
+	t = suite.trust("app", map[string]string{"repository": "org/app"}, "repository")
+	suite.trustMgr.On("Create", mock.Anything, t).Return(int64(1), nil)
+	id, err := suite.ctl.Create(context.TODO(), t)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), id)
+}
+
+func (suite *ControllerTestSuite) TestAuthenticate() {
+	mock.OnAnything(suite.trustMgr, "List").Return([]*model.Trust{
+		suite.trust("main", map[string]string{"repository": "org/app", "ref": "refs/heads/main"}, "repository"),
+		suite.trust("tags", map[string]string{"repository": "org/app", "ref": "refs/tags/*"}, "artifact-label"),
+	}, nil)
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	suite.verifier.On("Verify", mock.Anything, githubIssuer, "harbor", mock.Anything).Return(map[string]any{
+		"sub":        "repo:org/app:ref:refs/heads/main",
+		"repository": "org/app",
+		"ref":        "refs/heads/main",
+		"exp":        float64(exp.Unix()),
+	}, nil)
+
+	identity, err := suite.ctl.Authenticate(context.TODO(), suite.token(githubIssuer))
+	suite.Require().NoError(err)
+	suite.Equal("federated+repo:org/app:ref:refs/heads/main", identity.Username())
+	suite.Equal([]string{"main"}, identity.Trusts)
+	suite.Require().Len(identity.Permissions, 1)
+	suite.Equal(types.Resource("repository"), identity.Permissions[0].Access[0].Resource)
+	suite.Equal(exp, identity.ExpiresAt)
+	// the token is verified once for the audience shared by the trusts
+	suite.verifier.AssertNumberOfCalls(suite.T(), "Verify", 1)
+	suite.Len(suite.ctl.audited, 1)
+
+	// the login with the same token is audited once
+	_, err = suite.ctl.Authenticate(context.TODO(), suite.token(githubIssuer))
+	suite.Require().NoError(err)
+	suite.Len(suite.ctl.audited, 1)
+}
+
+func (suite *ControllerTestSuite) TestAuthenticateNotMatched() {
+	mock.OnAnything(suite.trustMgr, "List").Return([]*model.Trust{
+		suite.trust("main", map[string]string{"repository": "org/app", "ref": "refs/heads/main"}, "repository"),
+	}, nil)
+	suite.verifier.On("Verify", mock.Anything, githubIssuer, "harbor", mock.Anything).Return(map[string]any{
+		"sub":        "repo:org/app:ref:refs/heads/dev",
+		"repository": "org/app",
+		"ref":        "refs/heads/dev",
+	}, nil)
+
+	_, err := suite.ctl.Authenticate(context.TODO(), suite.token(githubIssuer))
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+	// the failed login is audited too
+	suite.Len(suite.ctl.audited, 1)
+}
+
+func (suite *ControllerTestSuite) TestAuthenticateInvalidToken() {
+	mock.OnAnything(suite.trustMgr, "List").Return([]*model.Trust{
+		suite.trust("main", map[string]string{"repository": "org/app"}, "repository"),
+	}, nil)
+	suite.verifier.On("Verify", mock.Anything, githubIssuer, "harbor", mock.Anything).Return(nil, errors.New("invalid signature"))
+
+	_, err := suite.ctl.Authenticate(context.TODO(), suite.token(githubIssuer))
+	suite.True(errors.IsErr(err, errors.UnAuthorizedCode))
+}
+
+func (suite *ControllerTestSuite) TestAuthenticateUntrustedIssuer() {
+	mock.OnAnything(suite.trustMgr, "List").Return([]*model.Trust{}, nil)
+
+	_, err := suite.ctl.Authenticate(context.TODO(), suite.token("https://other.issuer"))
+	suite.True(errors.IsNotFoundErr(err))
+
+	_, err = suite.ctl.Authenticate(context.TODO(), "not a jwt")
+	suite.True(errors.IsNotFoundErr(err))
+	suite.verifier.AssertNotCalled(suite.T(), "Verify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.Empty(suite.ctl.audited)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &ControllerTestSuite{})
+}
@@ -41,6 +41,10 @@ func init() {
 	if err := task.RegisterCheckInProcessor(job.GarbageCollectionVendorType, gcCheckIn); err != nil {
 		log.Fatalf("failed to register the checkin processor for the garbage collection job, error %v", err)
 	}
+
+	if err := task.RegisterWorkflowLauncher(job.GarbageCollectionVendorType, gcWorkflowLauncher); err != nil {
+		log.Fatalf("failed to register the workflow launcher for the garbage collection job, error %v", err)
+	}
 }
 
 func gcCallback(ctx context.Context, p string) error {
@@ -52,6 +56,19 @@ func gcCallback(ctx context.Context, p string) error {
 	return err
 }
 
+// gcWorkflowLauncher starts the garbage collection in the workflow, the parameters are the same as the GC policy
+func gcWorkflowLauncher(ctx context.Context, params map[string]any) (int64, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return 0, err
+	}
+	policy := &Policy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal the param: %v", err)
+	}
+	return Ctl.Start(ctx, *policy, task.ExecutionTriggerWorkflow)
+}
+
 func gcTaskStatusChange(ctx context.Context, _ int64, status string) error {
 	if status == job.SuccessStatus.String() && config.QuotaPerProjectEnable(ctx) {
 		go func() {
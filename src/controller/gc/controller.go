@@ -17,6 +17,7 @@ package gc
 import (
 	"context"
 
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -48,7 +49,7 @@ type Controller interface {
 	// ListTasks lists the tasks according to the query
 	ListTasks(ctx context.Context, query *q.Query) (tasks []*Task, err error)
 	// GetTaskLog gets log of the specific task
-	GetTaskLog(ctx context.Context, id int64) ([]byte, error)
+	GetTaskLog(ctx context.Context, id int64, logQuery *jobquery.LogQuery) ([]byte, error)
 
 	// GetSchedule get the current gc schedule
 	GetSchedule(ctx context.Context) (*scheduler.Schedule, error)
@@ -179,12 +180,12 @@ func (c *controller) ListTasks(ctx context.Context, query *q.Query) ([]*Task, er
 }
 
 // GetTaskLog ...
-func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
+func (c *controller) GetTaskLog(ctx context.Context, id int64, logQuery *jobquery.LogQuery) ([]byte, error) {
 	_, err := c.GetTask(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return c.taskMgr.GetLog(ctx, id)
+	return c.taskMgr.QueryLog(ctx, id, logQuery)
 }
 
 // GetSchedule ...
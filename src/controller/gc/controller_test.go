@@ -62,9 +62,9 @@ func (g *gcCtrTestSuite) TestGetTaskLog() {
 			Status:      job.SuccessStatus.String(),
 		},
 	}, nil)
-	g.taskMgr.On("GetLog", mock.Anything, mock.Anything).Return([]byte("hello world"), nil)
+	g.taskMgr.On("QueryLog", mock.Anything, mock.Anything, mock.Anything).Return([]byte("hello world"), nil)
 
-	log, err := g.ctl.GetTaskLog(nil, 1)
+	log, err := g.ctl.GetTaskLog(nil, 1, nil)
 	g.Nil(err)
 	g.Equal([]byte("hello world"), log)
 }
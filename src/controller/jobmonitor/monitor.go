@@ -21,6 +21,8 @@ import (
 	"strings"
 	"time"
 
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
+	jsConfig "github.com/goharbor/harbor/src/jobservice/config"
 	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
@@ -30,9 +32,12 @@ import (
 	"github.com/gocraft/work"
 
 	"github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	libRedis "github.com/goharbor/harbor/src/lib/redis"
 	jm "github.com/goharbor/harbor/src/pkg/jobmonitor"
+	"github.com/goharbor/harbor/src/pkg/maintenance"
+	maintenanceModel "github.com/goharbor/harbor/src/pkg/maintenance/model"
 	"github.com/goharbor/harbor/src/pkg/task"
 	taskDao "github.com/goharbor/harbor/src/pkg/task/dao"
 )
@@ -71,7 +76,28 @@ type MonitorController interface {
 	PauseJobQueues(ctx context.Context, jobType string) error
 	// ResumeJobQueues resume the job queue by type
 	ResumeJobQueues(ctx context.Context, jobType string) error
-	GetJobLog(ctx context.Context, jobID string) ([]byte, error)
+	// UpdateQueuePolicy updates the priority and the concurrency limit of the job queue by type
+	UpdateQueuePolicy(ctx context.Context, jobType string, priority, maxConcurrency int64) error
+	// GetJobLog returns the log of the job, the entries are filtered and rendered with the log query if it's not nil
+	GetJobLog(ctx context.Context, jobID string, logQuery *jobquery.LogQuery) ([]byte, error)
+
+	// PauseExecution stops dequeuing the pending jobs of the execution, the running ones continue until finished
+	PauseExecution(ctx context.Context, executionID int64) error
+	// ResumeExecution resumes the paused execution
+	ResumeExecution(ctx context.Context, executionID int64) error
+
+	// ListMaintenanceWindows lists the maintenance windows
+	ListMaintenanceWindows(ctx context.Context, query *q.Query) ([]*maintenanceModel.Window, error)
+	// CountMaintenanceWindows counts the maintenance windows
+	CountMaintenanceWindows(ctx context.Context, query *q.Query) (int64, error)
+	// GetMaintenanceWindow gets the maintenance window by ID
+	GetMaintenanceWindow(ctx context.Context, id int64) (*maintenanceModel.Window, error)
+	// CreateMaintenanceWindow creates the maintenance window
+	CreateMaintenanceWindow(ctx context.Context, window *maintenanceModel.Window) (int64, error)
+	// UpdateMaintenanceWindow updates the maintenance window
+	UpdateMaintenanceWindow(ctx context.Context, window *maintenanceModel.Window) error
+	// DeleteMaintenanceWindow deletes the maintenance window by ID
+	DeleteMaintenanceWindow(ctx context.Context, id int64) error
 }
 
 type monitorController struct {
@@ -82,7 +108,10 @@ type monitorController struct {
 	queueStatusManager    queuestatus.Manager
 	monitorClient         func() (jm.JobServiceMonitorClient, error)
 	jobServiceRedisClient func() (jm.RedisClient, error)
+	backend               func() (string, error)
 	executionDAO          taskDao.ExecutionDAO
+	executionManager      task.ExecutionManager
+	windowManager         maintenance.Manager
 }
 
 // NewMonitorController ...
@@ -95,7 +124,10 @@ func NewMonitorController() MonitorController {
 		queueStatusManager:    queuestatus.Mgr,
 		monitorClient:         jobServiceMonitorClient,
 		jobServiceRedisClient: jm.JobServiceRedisClient,
+		backend:               jobServiceBackend,
 		executionDAO:          taskDao.NewExecutionDAO(),
+		executionManager:      task.ExecMgr,
+		windowManager:         maintenance.Mgr,
 	}
 }
 
@@ -116,7 +148,36 @@ func jobServiceMonitorClient() (jm.JobServiceMonitorClient, error) {
 	return work.NewClient(fmt.Sprintf("{%s}", config.Namespace), pool), nil
 }
 
+// jobServiceBackend returns the backend of the job queue, the job service of the previous versions doesn't report it
+func jobServiceBackend() (string, error) {
+	cfg, err := job.GlobalClient.GetJobServiceConfig()
+	if err != nil {
+		return "", err
+	}
+	if len(cfg.Backend) == 0 {
+		return jsConfig.JobServicePoolBackendRedis, nil
+	}
+	return cfg.Backend, nil
+}
+
+// requireRedisBackend returns an error when the job queue isn't backed by redis,
+// as the worker pools, the workers and the pending jobs are only tracked in the redis of the job service
+func (w *monitorController) requireRedisBackend() error {
+	backend, err := w.backend()
+	if err != nil {
+		return err
+	}
+	if backend != jsConfig.JobServicePoolBackendRedis {
+		return errors.New(nil).WithCode(errors.MethodNotAllowedCode).
+			WithMessagef("the operation is not supported by the %s backend of the job service", backend)
+	}
+	return nil
+}
+
 func (w *monitorController) ListWorkers(ctx context.Context, poolID string) ([]*jm.Worker, error) {
+	if err := w.requireRedisBackend(); err != nil {
+		return nil, err
+	}
 	mClient, err := w.monitorClient()
 	if err != nil {
 		return nil, err
@@ -125,6 +186,9 @@ func (w *monitorController) ListWorkers(ctx context.Context, poolID string) ([]*
 }
 
 func (w *monitorController) ListPools(ctx context.Context) ([]*jm.WorkerPool, error) {
+	if err := w.requireRedisBackend(); err != nil {
+		return nil, err
+	}
 	mClient, err := w.monitorClient()
 	if err != nil {
 		return nil, err
@@ -187,6 +251,9 @@ func (w *monitorController) allRunningJobs(ctx context.Context) ([]string, error
 }
 
 func (w *monitorController) StopPendingJobs(ctx context.Context, jobType string) error {
+	if err := w.requireRedisBackend(); err != nil {
+		return err
+	}
 	redisClient, err := w.jobServiceRedisClient()
 	if err != nil {
 		return err
@@ -250,6 +317,9 @@ func (w *monitorController) updateJobStatusInTask(ctx context.Context, vendorTyp
 }
 
 func (w *monitorController) ListQueues(ctx context.Context) ([]*jm.Queue, error) {
+	if err := w.requireRedisBackend(); err != nil {
+		return nil, err
+	}
 	mClient, err := w.monitorClient()
 	if err != nil {
 		return nil, err
@@ -263,17 +333,31 @@ func (w *monitorController) ListQueues(ctx context.Context) ([]*jm.Queue, error)
 	if err != nil {
 		return nil, err
 	}
+	// the scheduling policies are published by the worker pool, the queues are listed without them if not available
+	policies := map[string]*jobSvc.Policy{}
+	redisClient, err := w.jobServiceRedisClient()
+	if err == nil {
+		policies, err = redisClient.JobPolicies(ctx)
+	}
+	if err != nil {
+		log.Warningf("failed to get the policies of the job queues: %v", err)
+	}
 	result := make([]*jm.Queue, 0)
 	for _, queue := range qs {
 		if skippedUnusedJobType(queue.JobName) {
 			continue
 		}
-		result = append(result, &jm.Queue{
+		jq := &jm.Queue{
 			JobType: queue.JobName,
 			Count:   queue.Count,
 			Latency: queue.Latency,
 			Paused:  statusMap[queue.JobName],
-		})
+		}
+		if p, ok := policies[queue.JobName]; ok {
+			jq.Priority = int64(p.Priority)
+			jq.MaxConcurrency = int64(p.MaxConcurrency)
+		}
+		result = append(result, jq)
 	}
 	return result, nil
 }
@@ -283,13 +367,17 @@ func skippedUnusedJobType(jobType string) bool {
 }
 
 func (w *monitorController) PauseJobQueues(ctx context.Context, jobType string) error {
+	if !strings.EqualFold(jobType, all) {
+		return w.pauseQueue(ctx, jobType)
+	}
+	// all the job types are only known by the redis backend
+	if err := w.requireRedisBackend(); err != nil {
+		return err
+	}
 	redisClient, err := w.jobServiceRedisClient()
 	if err != nil {
 		return err
 	}
-	if !strings.EqualFold(jobType, all) {
-		return w.pauseQueue(ctx, jobType)
-	}
 
 	jobTypes, err := redisClient.AllJobTypes(ctx)
 	if err != nil {
@@ -308,13 +396,19 @@ func (w *monitorController) pauseQueue(ctx context.Context, jobType string) erro
 		log.Debug("context is nil, skip pause queue")
 		return nil
 	}
-	redisClient, err := w.jobServiceRedisClient()
+	backend, err := w.backend()
 	if err != nil {
 		return fmt.Errorf("failed to pause queue %v, error: %v", jobType, err)
 	}
-	err = redisClient.PauseJob(ctx, jobType)
-	if err != nil {
-		return fmt.Errorf("failed to pause queue %v, error: %v", jobType, err)
+	// the postgresql backend loads the paused job types from the database only
+	if backend == jsConfig.JobServicePoolBackendRedis {
+		redisClient, err := w.jobServiceRedisClient()
+		if err != nil {
+			return fmt.Errorf("failed to pause queue %v, error: %v", jobType, err)
+		}
+		if err := redisClient.PauseJob(ctx, jobType); err != nil {
+			return fmt.Errorf("failed to pause queue %v, error: %v", jobType, err)
+		}
 	}
 	if err := orm.WithTransaction(func(ctx context.Context) error {
 		return w.queueStatusManager.UpdateStatus(ctx, jobType, true)
@@ -325,13 +419,17 @@ func (w *monitorController) pauseQueue(ctx context.Context, jobType string) erro
 }
 
 func (w *monitorController) ResumeJobQueues(ctx context.Context, jobType string) error {
+	if !strings.EqualFold(jobType, all) {
+		return w.resumeQueue(ctx, jobType)
+	}
+	// all the job types are only known by the redis backend
+	if err := w.requireRedisBackend(); err != nil {
+		return err
+	}
 	redisClient, err := w.jobServiceRedisClient()
 	if err != nil {
 		return err
 	}
-	if !strings.EqualFold(jobType, all) {
-		return w.resumeQueue(ctx, jobType)
-	}
 	jobTypes, err := redisClient.AllJobTypes(ctx)
 	if err != nil {
 		return err
@@ -349,12 +447,19 @@ func (w *monitorController) resumeQueue(ctx context.Context, jobType string) err
 		log.Debug("context is nil, skip resume queue")
 		return nil
 	}
-	redisClient, err := w.jobServiceRedisClient()
+	backend, err := w.backend()
 	if err != nil {
 		return fmt.Errorf("failed to resume queue %v, error: %v", jobType, err)
 	}
-	if err := redisClient.UnpauseJob(ctx, jobType); err != nil {
-		return fmt.Errorf("failed to resume queue %v, error: %v", jobType, err)
+	// the postgresql backend loads the paused job types from the database only
+	if backend == jsConfig.JobServicePoolBackendRedis {
+		redisClient, err := w.jobServiceRedisClient()
+		if err != nil {
+			return fmt.Errorf("failed to resume queue %v, error: %v", jobType, err)
+		}
+		if err := redisClient.UnpauseJob(ctx, jobType); err != nil {
+			return fmt.Errorf("failed to resume queue %v, error: %v", jobType, err)
+		}
 	}
 	if err := orm.WithTransaction(func(ctx context.Context) error {
 		return w.queueStatusManager.UpdateStatus(ctx, jobType, false)
@@ -364,6 +469,66 @@ func (w *monitorController) resumeQueue(ctx context.Context, jobType string) err
 	return nil
 }
 
-func (w *monitorController) GetJobLog(ctx context.Context, jobID string) ([]byte, error) {
-	return w.taskManager.GetLogByJobID(ctx, jobID)
+func (w *monitorController) GetJobLog(ctx context.Context, jobID string, logQuery *jobquery.LogQuery) ([]byte, error) {
+	return w.taskManager.QueryLogByJobID(ctx, jobID, logQuery)
+}
+
+func (w *monitorController) UpdateQueuePolicy(ctx context.Context, jobType string, priority, maxConcurrency int64) error {
+	if priority < 1 || priority > int64(jobSvc.MaxPriority) {
+		return errors.BadRequestError(nil).WithMessagef("the priority should be between 1 and %d", jobSvc.MaxPriority)
+	}
+	if maxConcurrency < 0 {
+		return errors.BadRequestError(nil).WithMessage("the max concurrency should not be negative")
+	}
+	// the scheduling policies are only published by the redis backend
+	if err := w.requireRedisBackend(); err != nil {
+		return err
+	}
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	jobTypes, err := redisClient.AllJobTypes(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(jobTypes, jobType) {
+		return errors.NotFoundError(nil).WithMessagef("job type %s not found", jobType)
+	}
+	return redisClient.UpdateJobPolicy(ctx, jobType, &jobSvc.Policy{
+		Priority:       uint(priority),
+		MaxConcurrency: uint(maxConcurrency),
+	})
+}
+
+func (w *monitorController) PauseExecution(ctx context.Context, executionID int64) error {
+	return w.executionManager.Pause(ctx, executionID)
+}
+
+func (w *monitorController) ResumeExecution(ctx context.Context, executionID int64) error {
+	return w.executionManager.Resume(ctx, executionID)
+}
+
+func (w *monitorController) ListMaintenanceWindows(ctx context.Context, query *q.Query) ([]*maintenanceModel.Window, error) {
+	return w.windowManager.List(ctx, query)
+}
+
+func (w *monitorController) CountMaintenanceWindows(ctx context.Context, query *q.Query) (int64, error) {
+	return w.windowManager.Count(ctx, query)
+}
+
+func (w *monitorController) GetMaintenanceWindow(ctx context.Context, id int64) (*maintenanceModel.Window, error) {
+	return w.windowManager.Get(ctx, id)
+}
+
+func (w *monitorController) CreateMaintenanceWindow(ctx context.Context, window *maintenanceModel.Window) (int64, error) {
+	return w.windowManager.Create(ctx, window)
+}
+
+func (w *monitorController) UpdateMaintenanceWindow(ctx context.Context, window *maintenanceModel.Window) error {
+	return w.windowManager.Update(ctx, window)
+}
+
+func (w *monitorController) DeleteMaintenanceWindow(ctx context.Context, id int64) error {
+	return w.windowManager.Delete(ctx, id)
 }
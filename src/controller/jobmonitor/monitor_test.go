@@ -20,12 +20,17 @@ import (
 
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
+	maintenanceMock "github.com/goharbor/harbor/src/testing/pkg/maintenance"
 	queueStatusMock "github.com/goharbor/harbor/src/testing/pkg/queuestatus"
 
 	"github.com/gocraft/work"
 	"github.com/stretchr/testify/suite"
 
+	jsConfig "github.com/goharbor/harbor/src/jobservice/config"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/jobmonitor"
+	maintenanceModel "github.com/goharbor/harbor/src/pkg/maintenance/model"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/testing/mock"
 	monitorMock "github.com/goharbor/harbor/src/testing/pkg/jobmonitor"
@@ -42,6 +47,8 @@ type JobServiceMonitorTestSuite struct {
 	queueStatusManager queuestatus.Manager
 	sch                scheduler.Scheduler
 	redisClient        jobmonitor.RedisClient
+	executionManager   *taskMock.ExecutionManager
+	windowManager      *maintenanceMock.Manager
 }
 
 func (s *JobServiceMonitorTestSuite) SetupSuite() {
@@ -51,17 +58,24 @@ func (s *JobServiceMonitorTestSuite) SetupSuite() {
 	s.taskManager = &taskMock.Manager{}
 	s.redisClient = &monitorMock.RedisClient{}
 	s.queueStatusManager = &queueStatusMock.Manager{}
+	s.executionManager = &taskMock.ExecutionManager{}
+	s.windowManager = &maintenanceMock.Manager{}
 	s.monitController = &monitorController{
 		poolManager:        s.poolManager,
 		workerManager:      s.workerManager,
 		taskManager:        s.taskManager,
 		queueStatusManager: s.queueStatusManager,
+		executionManager:   s.executionManager,
+		windowManager:      s.windowManager,
 		monitorClient: func() (jobmonitor.JobServiceMonitorClient, error) {
 			return s.jmClient, nil
 		},
 		jobServiceRedisClient: func() (jobmonitor.RedisClient, error) {
 			return s.redisClient, nil
 		},
+		backend: func() (string, error) {
+			return jsConfig.JobServicePoolBackendRedis, nil
+		},
 	}
 }
 
@@ -108,11 +122,30 @@ func (s *JobServiceMonitorTestSuite) TestListQueue() {
 	mock.OnAnything(s.jmClient, "Queues").Return([]*work.Queue{
 		{JobName: "GARBAGE_COLLECTION", Count: 100, Latency: 10000}}, nil)
 	mock.OnAnything(s.queueStatusManager, "AllJobTypeStatus").Return(map[string]bool{"GARBAGE_COLLECTION": false}, nil).Once()
+	mock.OnAnything(s.redisClient, "JobPolicies").Return(map[string]*jobSvc.Policy{"GARBAGE_COLLECTION": {Priority: 1000, MaxConcurrency: 1}}, nil).Once()
 	queues, err := s.monitController.ListQueues(nil)
 	s.Assert().Nil(err)
 	s.Assert().Equal(1, len(queues))
 	s.Assert().Equal("GARBAGE_COLLECTION", queues[0].JobType)
 	s.Assert().False(queues[0].Paused)
+	s.Assert().Equal(int64(1000), queues[0].Priority)
+	s.Assert().Equal(int64(1), queues[0].MaxConcurrency)
+}
+
+func (s *JobServiceMonitorTestSuite) TestUpdateQueuePolicy() {
+	err := s.monitController.UpdateQueuePolicy(nil, "REPLICATION", 0, 1)
+	s.Assert().True(errors.IsErr(err, errors.BadRequestCode))
+	err = s.monitController.UpdateQueuePolicy(nil, "REPLICATION", 1000, -1)
+	s.Assert().True(errors.IsErr(err, errors.BadRequestCode))
+
+	mock.OnAnything(s.redisClient, "AllJobTypes").Return([]string{"REPLICATION"}, nil).Twice()
+	err = s.monitController.UpdateQueuePolicy(nil, "UNKNOWN", 1000, 1)
+	s.Assert().True(errors.IsNotFoundErr(err))
+
+	mock.OnAnything(s.redisClient, "UpdateJobPolicy").Return(nil).Once()
+	err = s.monitController.UpdateQueuePolicy(nil, "REPLICATION", 2000, 5)
+	s.Assert().Nil(err)
+	s.redisClient.(*monitorMock.RedisClient).AssertCalled(s.T(), "UpdateJobPolicy", mock.Anything, "REPLICATION", &jobSvc.Policy{Priority: 2000, MaxConcurrency: 5})
 }
 
 func (s *JobServiceMonitorTestSuite) TestPauseJob() {
@@ -124,6 +157,70 @@ func (s *JobServiceMonitorTestSuite) TestPauseJob() {
 	s.Assert().Nil(err)
 }
 
+func (s *JobServiceMonitorTestSuite) TestPostgreSQLBackend() {
+	ctl := &monitorController{
+		backend: func() (string, error) {
+			return jsConfig.JobServicePoolBackendPostgreSQL, nil
+		},
+	}
+	_, err := ctl.ListPools(nil)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	_, err = ctl.ListWorkers(nil, all)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	_, err = ctl.ListQueues(nil)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	err = ctl.StopRunningJobs(nil, all)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	err = ctl.StopPendingJobs(nil, all)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	err = ctl.PauseJobQueues(nil, all)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	err = ctl.ResumeJobQueues(nil, all)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+	err = ctl.UpdateQueuePolicy(nil, "REPLICATION", 1000, 1)
+	s.Assert().True(errors.IsErr(err, errors.MethodNotAllowedCode))
+}
+
+func (s *JobServiceMonitorTestSuite) TestPauseExecution() {
+	s.executionManager.On("Pause", mock.Anything, int64(1)).Return(nil).Once()
+	err := s.monitController.PauseExecution(nil, 1)
+	s.Assert().Nil(err)
+	s.executionManager.On("Resume", mock.Anything, int64(1)).Return(nil).Once()
+	err = s.monitController.ResumeExecution(nil, 1)
+	s.Assert().Nil(err)
+	s.executionManager.AssertExpectations(s.T())
+}
+
+func (s *JobServiceMonitorTestSuite) TestMaintenanceWindow() {
+	window := &maintenanceModel.Window{ID: 1, Name: "nightly", JobTypes: "all", Cron: "0 0 2 * * *", Duration: 60}
+	s.windowManager.On("Create", mock.Anything, window).Return(int64(1), nil).Once()
+	id, err := s.monitController.CreateMaintenanceWindow(nil, window)
+	s.Assert().Nil(err)
+	s.Assert().Equal(int64(1), id)
+
+	s.windowManager.On("Get", mock.Anything, int64(1)).Return(window, nil).Once()
+	w, err := s.monitController.GetMaintenanceWindow(nil, 1)
+	s.Assert().Nil(err)
+	s.Assert().Equal("nightly", w.Name)
+
+	s.windowManager.On("Update", mock.Anything, window).Return(nil).Once()
+	s.Assert().Nil(s.monitController.UpdateMaintenanceWindow(nil, window))
+
+	s.windowManager.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	total, err := s.monitController.CountMaintenanceWindows(nil, nil)
+	s.Assert().Nil(err)
+	s.Assert().Equal(int64(1), total)
+
+	s.windowManager.On("List", mock.Anything, mock.Anything).Return([]*maintenanceModel.Window{window}, nil).Once()
+	windows, err := s.monitController.ListMaintenanceWindows(nil, nil)
+	s.Assert().Nil(err)
+	s.Assert().Len(windows, 1)
+
+	s.windowManager.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+	s.Assert().Nil(s.monitController.DeleteMaintenanceWindow(nil, 1))
+	s.windowManager.AssertExpectations(s.T())
+}
+
 func TestJobServiceMonitorTestSuite(t *testing.T) {
 	suite.Run(t, &JobServiceMonitorTestSuite{})
 }
@@ -20,6 +20,8 @@ import (
 
 	"github.com/goharbor/harbor/src/common"
 	commonmodels "github.com/goharbor/harbor/src/common/models"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/controller/role"
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -75,18 +77,19 @@ type UserGroup struct {
 var ErrDuplicateProjectMember = errors.ConflictError(nil).WithMessage("The project member specified already exist")
 
 // ErrInvalidRole ...
-var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is not in 1,2,3")
+var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is neither a built-in role nor a custom role")
 
 type controller struct {
 	userManager  user.Manager
 	mgr          member.Manager
 	projectMgr   project.Manager
 	groupManager usergroup.Manager
+	roleCtl      role.Controller
 }
 
 // NewController ...
 func NewController() Controller {
-	return &controller{mgr: member.Mgr, projectMgr: pkg.ProjectMgr, userManager: user.New(), groupManager: usergroup.Mgr}
+	return &controller{mgr: member.Mgr, projectMgr: pkg.ProjectMgr, userManager: user.New(), groupManager: usergroup.Mgr, roleCtl: role.Ctl}
 }
 
 func (c *controller) Count(ctx context.Context, projectNameOrID any, query *q.Query) (int, error) {
@@ -105,6 +108,13 @@ func (c *controller) UpdateRole(ctx context.Context, projectNameOrID any, member
 	if p == nil {
 		return errors.BadRequestError(nil).WithMessage("project is not found")
 	}
+	valid, err := c.isValidRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidRole
+	}
 	return c.mgr.UpdateRole(ctx, p.ProjectID, memberID, role)
 }
 
@@ -223,24 +233,26 @@ func (c *controller) Create(ctx context.Context, projectNameOrID any, req Reques
 		return 0, ErrDuplicateProjectMember
 	}
 
-	if !isValidRole(member.Role) {
+	valid, err := c.isValidRole(ctx, member.Role)
+	if err != nil {
+		return 0, err
+	}
+	if !valid {
 		// Return invalid role error
 		return 0, ErrInvalidRole
 	}
 	return c.mgr.AddProjectMember(ctx, member)
 }
 
-func isValidRole(role int) bool {
-	switch role {
-	case common.RoleProjectAdmin,
-		common.RoleMaintainer,
-		common.RoleDeveloper,
-		common.RoleGuest,
-		common.RoleLimitedGuest:
-		return true
-	default:
-		return false
+// isValidRole checks whether the role is a built-in role or a custom role defined by the system admin
+func (c *controller) isValidRole(ctx context.Context, role int) (bool, error) {
+	if rbac_project.IsBuiltInRole(role) {
+		return true, nil
+	}
+	if role <= 0 {
+		return false, nil
 	}
+	return c.roleCtl.Exist(ctx, int64(role))
 }
 
 func (c *controller) List(ctx context.Context, projectNameOrID any, entityName string, query *q.Query) ([]*models.Member, error) {
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/usergroup"
 	modelGroup "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	mockRole "github.com/goharbor/harbor/src/testing/controller/role"
 	"github.com/goharbor/harbor/src/testing/mock"
 	mockMember "github.com/goharbor/harbor/src/testing/pkg/member"
 	mockProject "github.com/goharbor/harbor/src/testing/pkg/project"
@@ -42,6 +43,7 @@ type MemberControllerTestSuite struct {
 	memberManager member.Manager
 	projectMgr    project.Manager
 	groupManager  usergroup.Manager
+	roleCtl       *mockRole.Controller
 	controller    *controller
 }
 
@@ -50,11 +52,13 @@ func (suite *MemberControllerTestSuite) SetupSuite() {
 	suite.memberManager = &mockMember.Manager{}
 	suite.projectMgr = &mockProject.Manager{}
 	suite.groupManager = &mockUsergroup.Manager{}
+	suite.roleCtl = &mockRole.Controller{}
 	suite.controller = &controller{
 		userManager:  suite.userManager,
 		mgr:          suite.memberManager,
 		projectMgr:   suite.projectMgr,
 		groupManager: suite.groupManager,
+		roleCtl:      suite.roleCtl,
 	}
 }
 
@@ -62,6 +66,20 @@ func (suite *MemberControllerTestSuite) TearDownSuite() {
 
 }
 
+func (suite *MemberControllerTestSuite) TestUpdateRoleWithCustomRole() {
+	mock.OnAnything(suite.projectMgr, "Get").Return(&models.Project{
+		ProjectID: 1,
+	}, nil)
+	suite.roleCtl.On("Exist", mock.Anything, int64(100)).Return(true, nil).Once()
+	suite.memberManager.(*mockMember.Manager).On("UpdateRole", mock.Anything, int64(1), 1, 100).Return(nil).Once()
+	suite.NoError(suite.controller.UpdateRole(context.TODO(), 1, 1, 100))
+
+	suite.roleCtl.On("Exist", mock.Anything, int64(101)).Return(false, nil).Once()
+	err := suite.controller.UpdateRole(context.TODO(), 1, 1, 101)
+	suite.Error(err)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
 func (suite *MemberControllerTestSuite) TestAddProjectMemberWithUser() {
 	mock.OnAnything(suite.projectMgr, "Get").Return(&models.Project{
 		ProjectID: 1,
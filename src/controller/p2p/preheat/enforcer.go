@@ -116,6 +116,21 @@ var (
 	Enf = NewEnforcer()
 )
 
+func init() {
+	if err := task.RegisterWorkflowLauncher(job.P2PPreheatVendorType, workflowLauncher); err != nil {
+		log.Fatalf("failed to register the workflow launcher for the preheat, error %v", err)
+	}
+}
+
+// workflowLauncher enforces the preheat policy specified by "policy_id" in the workflow
+func workflowLauncher(ctx context.Context, params map[string]any) (int64, error) {
+	policyID, ok := task.Int64FromAny(params["policy_id"])
+	if !ok {
+		return 0, errors.BadRequestError(nil).WithMessage("the preheat policy ID is required")
+	}
+	return Enf.EnforcePolicy(ctx, policyID)
+}
+
 // defaultEnforcer is default implementation of Enforcer.
 type defaultEnforcer struct {
 	// for policy management
@@ -481,7 +496,7 @@ func (de *defaultEnforcer) startTask(ctx context.Context, executionID int64, can
 
 // getVulnerabilitySev gets the severity code value for the given artifact with allowlist option set
 func (de *defaultEnforcer) getVulnerabilitySev(ctx context.Context, p *proModels.Project, art *artifact.Artifact) (uint, error) {
-	vulnerable, err := de.scanCtl.GetVulnerable(ctx, art, p.CVEAllowlist.CVESet(), p.CVEAllowlist.IsExpired())
+	vulnerable, err := de.scanCtl.GetVulnerable(ctx, art, p.CVEAllowlist.CVESet(), p.CVEAllowlist.IsExpired(), scan.WithScannerGate(p.ScannerGate()))
 	if err != nil {
 		if errors.IsNotFoundErr(err) {
 			// no vulnerability report
@@ -112,6 +112,7 @@ func (suite *EnforcerTestSuite) SetupSuite() {
 		mock.AnythingOfType("*artifact.Artifact"),
 		mock.AnythingOfType("models.CVESet"),
 		mock.AnythingOfType("bool"),
+		mock.AnythingOfType("scan.Option"),
 	).Return(&scan.Vulnerable{Severity: &low, ScanStatus: "Success"}, nil)
 
 	fakeProCtl := &project.Controller{}
@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/project/metadata"
 	"github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/projectgroup"
 	"github.com/goharbor/harbor/src/pkg/user"
 )
 
@@ -73,6 +74,7 @@ func NewController() Controller {
 		metaMgr:      pkg.ProjectMetaMgr,
 		allowlistMgr: allowlist.NewDefaultManager(),
 		userMgr:      user.Mgr,
+		groupMgr:     projectgroup.Mgr,
 	}
 }
 
@@ -81,6 +83,7 @@ type controller struct {
 	metaMgr      metadata.Manager
 	allowlistMgr allowlist.Manager
 	userMgr      user.Manager
+	groupMgr     projectgroup.Manager
 }
 
 func (c *controller) Create(ctx context.Context, project *models.Project) (int64, error) {
@@ -134,6 +137,17 @@ func (c *controller) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
+	// the project is soft deleted, release it from the project group explicitly
+	group, err := c.groupMgr.GetByProject(ctx, id)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+	if group != nil {
+		if err := c.groupMgr.RemoveProject(ctx, group.ID, id); err != nil {
+			return err
+		}
+	}
+
 	e := &event.DeleteProjectEventMetadata{
 		ProjectID: proj.ProjectID,
 		Project:   proj.Name,
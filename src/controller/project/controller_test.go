@@ -27,11 +27,13 @@ import (
 	"github.com/goharbor/harbor/src/lib/q"
 	models2 "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/pkg/project/models"
+	pgModel "github.com/goharbor/harbor/src/pkg/projectgroup/model"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
 	"github.com/goharbor/harbor/src/testing/mock"
 	allowlisttesting "github.com/goharbor/harbor/src/testing/pkg/allowlist"
 	"github.com/goharbor/harbor/src/testing/pkg/project"
 	"github.com/goharbor/harbor/src/testing/pkg/project/metadata"
+	"github.com/goharbor/harbor/src/testing/pkg/projectgroup"
 	"github.com/goharbor/harbor/src/testing/pkg/user"
 )
 
@@ -67,6 +69,31 @@ func (suite *ControllerTestSuite) TestCreate() {
 	}
 }
 
+func (suite *ControllerTestSuite) TestDelete() {
+	ctx := context.TODO()
+	mgr := &project.Manager{}
+	metadataMgr := &metadata.Manager{}
+	groupMgr := &projectgroup.Manager{}
+	c := controller{projectMgr: mgr, metaMgr: metadataMgr, groupMgr: groupMgr}
+
+	mgr.On("Get", mock.Anything, int64(1)).Return(&models.Project{ProjectID: 1, Name: "library"}, nil)
+	mgr.On("Delete", mock.Anything, int64(1)).Return(nil)
+	metadataMgr.On("Get", mock.Anything, int64(1)).Return(map[string]string{}, nil)
+
+	{
+		groupMgr.On("GetByProject", mock.Anything, int64(1)).Return(nil, errors.NotFoundError(nil)).Once()
+		suite.Nil(c.Delete(ctx, 1))
+	}
+
+	{
+		// the project is released from its group
+		groupMgr.On("GetByProject", mock.Anything, int64(1)).Return(&pgModel.ProjectGroup{ID: 2}, nil).Once()
+		groupMgr.On("RemoveProject", mock.Anything, int64(2), int64(1)).Return(nil).Once()
+		suite.Nil(c.Delete(ctx, 1))
+		groupMgr.AssertExpectations(suite.T())
+	}
+}
+
 func (suite *ControllerTestSuite) TestGetByName() {
 	ctx := context.TODO()
 
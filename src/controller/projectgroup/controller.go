@@ -0,0 +1,274 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectgroup
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/projectgroup"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+var (
+	// Ctl is a global variable for the default project group controller implementation
+	Ctl = NewController()
+)
+
+// ProjectUsage is the storage usage of the project in the group
+type ProjectUsage struct {
+	ProjectID   int64
+	ProjectName string
+	Used        int64
+}
+
+// Usage is the storage quota of the group and the usage breakdown by the member projects
+type Usage struct {
+	Hard     int64
+	Used     int64
+	Projects []*ProjectUsage
+}
+
+// Controller to handle the requests related with the project groups
+type Controller interface {
+	// Create creates the group with the storage limit shared by the member projects, -1 means unlimited
+	Create(ctx context.Context, g *model.ProjectGroup, storageLimit int64) (int64, error)
+	// Get gets the group by ID
+	Get(ctx context.Context, id int64) (*model.ProjectGroup, error)
+	// Count returns the total count of groups according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the groups according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error)
+	// Update updates the group, the storage limit is kept when it's nil
+	Update(ctx context.Context, g *model.ProjectGroup, storageLimit *int64) error
+	// Delete deletes the group and its quota, the member projects are released from the group
+	Delete(ctx context.Context, id int64) error
+	// AddProject adds the project into the group
+	AddProject(ctx context.Context, groupID, projectID int64) error
+	// RemoveProject removes the project from the group
+	RemoveProject(ctx context.Context, groupID, projectID int64) error
+	// Usage returns the storage quota of the group and the usage of each member project
+	Usage(ctx context.Context, id int64) (*Usage, error)
+}
+
+// NewController creates an instance of the default project group controller
+func NewController() Controller {
+	return &controller{
+		groupMgr:   projectgroup.Mgr,
+		projectMgr: pkg.ProjectMgr,
+		quotaCtl:   quota.Ctl,
+	}
+}
+
+type controller struct {
+	groupMgr   projectgroup.Manager
+	projectMgr project.Manager
+	quotaCtl   quota.Controller
+}
+
+func (c *controller) Create(ctx context.Context, g *model.ProjectGroup, storageLimit int64) (int64, error) {
+	hardLimits := types.ResourceList{types.ResourceStorage: storageLimit}
+	if err := validate(ctx, g, hardLimits); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	h := func(ctx context.Context) (err error) {
+		id, err = c.groupMgr.Create(ctx, g)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.quotaCtl.Create(ctx, quota.ProjectGroupReference, quota.ReferenceID(id), hardLimits)
+		return err
+	}
+
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-create-project-group")); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*model.ProjectGroup, error) {
+	return c.groupMgr.Get(ctx, id)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.groupMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error) {
+	return c.groupMgr.List(ctx, query)
+}
+
+func (c *controller) Update(ctx context.Context, g *model.ProjectGroup, storageLimit *int64) error {
+	var hardLimits types.ResourceList
+	if storageLimit != nil {
+		hardLimits = types.ResourceList{types.ResourceStorage: *storageLimit}
+	}
+	if err := validate(ctx, g, hardLimits); err != nil {
+		return err
+	}
+
+	if err := c.groupMgr.Update(ctx, g, "name", "description"); err != nil {
+		return err
+	}
+
+	if hardLimits == nil {
+		return nil
+	}
+
+	qt, err := c.quotaCtl.GetByRef(ctx, quota.ProjectGroupReference, quota.ReferenceID(g.ID))
+	if err != nil {
+		return err
+	}
+	qt.SetHard(hardLimits)
+	return c.quotaCtl.Update(ctx, qt)
+}
+
+func (c *controller) Delete(ctx context.Context, id int64) error {
+	h := func(ctx context.Context) error {
+		qt, err := c.quotaCtl.GetByRef(ctx, quota.ProjectGroupReference, quota.ReferenceID(id))
+		if err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+		if qt != nil {
+			if err := c.quotaCtl.Delete(ctx, qt.ID); err != nil {
+				return err
+			}
+		}
+
+		return c.groupMgr.Delete(ctx, id)
+	}
+
+	return orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-delete-project-group"))
+}
+
+func (c *controller) AddProject(ctx context.Context, groupID, projectID int64) error {
+	if _, err := c.groupMgr.Get(ctx, groupID); err != nil {
+		return err
+	}
+	if _, err := c.projectMgr.Get(ctx, projectID); err != nil {
+		return err
+	}
+
+	if err := c.groupMgr.AddProject(ctx, groupID, projectID); err != nil {
+		return err
+	}
+
+	// the usage of the project is charged to the group, the group may exceed its limit, then the following requests of
+	// the member projects are denied until the usage is reduced
+	c.refresh(ctx, groupID)
+	return nil
+}
+
+func (c *controller) RemoveProject(ctx context.Context, groupID, projectID int64) error {
+	if err := c.groupMgr.RemoveProject(ctx, groupID, projectID); err != nil {
+		return err
+	}
+
+	c.refresh(ctx, groupID)
+	return nil
+}
+
+func (c *controller) Usage(ctx context.Context, id int64) (*Usage, error) {
+	qt, err := c.quotaCtl.GetByRef(ctx, quota.ProjectGroupReference, quota.ReferenceID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	hard, err := qt.GetHard()
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := qt.GetUsed()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &Usage{
+		Hard:     hard[types.ResourceStorage],
+		Used:     used[types.ResourceStorage],
+		Projects: []*ProjectUsage{},
+	}
+
+	projectIDs, err := c.groupMgr.ListProjects(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projectIDs) == 0 {
+		return usage, nil
+	}
+
+	projects, err := c.projectMgr.List(ctx, q.New(q.KeyWords{"project_id__in": projectIDs}))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range projects {
+		pu := &ProjectUsage{ProjectID: p.ProjectID, ProjectName: p.Name}
+
+		pq, err := c.quotaCtl.GetByRef(ctx, quota.ProjectReference, quota.ReferenceID(p.ProjectID))
+		if err != nil && !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		if pq != nil {
+			pused, err := pq.GetUsed()
+			if err != nil {
+				return nil, err
+			}
+			pu.Used = pused[types.ResourceStorage]
+		}
+
+		usage.Projects = append(usage.Projects, pu)
+	}
+
+	return usage, nil
+}
+
+// refresh refreshes the usage of the group quota after the members changed
+func (c *controller) refresh(ctx context.Context, groupID int64) {
+	if err := c.quotaCtl.Refresh(ctx, quota.ProjectGroupReference, quota.ReferenceID(groupID), quota.IgnoreLimitation(true)); err != nil {
+		log.G(ctx).Warningf("failed to refresh the quota usage of project group %d, error: %v", groupID, err)
+	}
+}
+
+func validate(ctx context.Context, g *model.ProjectGroup, hardLimits types.ResourceList) error {
+	g.Name = strings.TrimSpace(g.Name)
+	if len(g.Name) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the name of the project group is required")
+	}
+	if len(g.Name) > 255 {
+		return errors.BadRequestError(nil).WithMessage("the name of the project group is too long")
+	}
+
+	if hardLimits != nil {
+		if err := quota.Validate(ctx, quota.ProjectGroupReference, hardLimits); err != nil {
+			return errors.BadRequestError(err).WithMessagef("invalid storage limit: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	pkgquota "github.com/goharbor/harbor/src/pkg/quota"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	projecttesting "github.com/goharbor/harbor/src/testing/pkg/project"
+	projectgrouptesting "github.com/goharbor/harbor/src/testing/pkg/projectgroup"
+)
+
+type ControllerTestSuite struct {
+	suite.Suite
+	groupMgr   *projectgrouptesting.Manager
+	projectMgr *projecttesting.Manager
+	quotaCtl   *quotatesting.Controller
+	ctl        *controller
+}
+
+func (suite *ControllerTestSuite) SetupTest() {
+	suite.groupMgr = &projectgrouptesting.Manager{}
+	suite.projectMgr = &projecttesting.Manager{}
+	suite.quotaCtl = &quotatesting.Controller{}
+	suite.ctl = &controller{
+		groupMgr:   suite.groupMgr,
+		projectMgr: suite.projectMgr,
+		quotaCtl:   suite.quotaCtl,
+	}
+}
+
+func (suite *ControllerTestSuite) TestCreate() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	// invalid groups
+	_, err := suite.ctl.Create(ctx, &model.ProjectGroup{Name: " "}, -1)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+	_, err = suite.ctl.Create(ctx, &model.ProjectGroup{Name: "engineering"}, 0)
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+
+	suite.groupMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	suite.quotaCtl.On("Create", mock.Anything, "project_group", "1", types.ResourceList{types.ResourceStorage: 1024}).Return(int64(2), nil).Once()
+	id, err := suite.ctl.Create(ctx, &model.ProjectGroup{Name: "engineering"}, 1024)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), id)
+	suite.quotaCtl.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestUpdate() {
+	ctx := context.TODO()
+
+	suite.groupMgr.On("Update", mock.Anything, mock.Anything, "name", "description").Return(nil)
+
+	// the storage limit is kept
+	suite.Require().NoError(suite.ctl.Update(ctx, &model.ProjectGroup{ID: 1, Name: "engineering"}, nil))
+
+	q := &pkgquota.Quota{ID: 2, Hard: types.ResourceList{types.ResourceStorage: 1024}.String()}
+	mock.OnAnything(suite.quotaCtl, "GetByRef").Return(q, nil).Once()
+	suite.quotaCtl.On("Update", mock.Anything, q).Return(nil).Once()
+	limit := int64(2048)
+	suite.Require().NoError(suite.ctl.Update(ctx, &model.ProjectGroup{ID: 1, Name: "engineering"}, &limit))
+	hard, err := q.GetHard()
+	suite.Require().NoError(err)
+	suite.Equal(int64(2048), hard[types.ResourceStorage])
+}
+
+func (suite *ControllerTestSuite) TestDelete() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	mock.OnAnything(suite.quotaCtl, "GetByRef").Return(&pkgquota.Quota{ID: 2}, nil).Once()
+	suite.quotaCtl.On("Delete", mock.Anything, int64(2)).Return(nil).Once()
+	suite.groupMgr.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+	suite.Require().NoError(suite.ctl.Delete(ctx, 1))
+	suite.groupMgr.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestAddProject() {
+	ctx := context.TODO()
+
+	suite.groupMgr.On("Get", mock.Anything, int64(1)).Return(&model.ProjectGroup{ID: 1}, nil)
+	suite.projectMgr.On("Get", mock.Anything, int64(10)).Return(nil, errors.NotFoundError(nil)).Once()
+	err := suite.ctl.AddProject(ctx, 1, 10)
+	suite.True(errors.IsNotFoundErr(err))
+
+	suite.projectMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2}, nil).Once()
+	suite.groupMgr.On("AddProject", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+	// the failure of refreshing the usage is ignored
+	mock.OnAnything(suite.quotaCtl, "Refresh").Return(errors.New("failed")).Once()
+	suite.Require().NoError(suite.ctl.AddProject(ctx, 1, 2))
+	suite.quotaCtl.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestUsage() {
+	ctx := context.TODO()
+
+	suite.quotaCtl.On("GetByRef", mock.Anything, "project_group", "1").Return(&pkgquota.Quota{
+		Hard: types.ResourceList{types.ResourceStorage: 1024}.String(),
+		Used: types.ResourceList{types.ResourceStorage: 300}.String(),
+	}, nil)
+	suite.quotaCtl.On("GetByRef", mock.Anything, "project", "2").Return(&pkgquota.Quota{
+		Used: types.ResourceList{types.ResourceStorage: 100}.String(),
+	}, nil)
+	suite.quotaCtl.On("GetByRef", mock.Anything, "project", "3").Return(&pkgquota.Quota{
+		Used: types.ResourceList{types.ResourceStorage: 200}.String(),
+	}, nil)
+	suite.groupMgr.On("ListProjects", mock.Anything, int64(1)).Return([]int64{2, 3}, nil)
+	mock.OnAnything(suite.projectMgr, "List").Return([]*proModels.Project{
+		{ProjectID: 2, Name: "library"},
+		{ProjectID: 3, Name: "demo"},
+	}, nil)
+
+	usage, err := suite.ctl.Usage(ctx, 1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1024), usage.Hard)
+	suite.Equal(int64(300), usage.Used)
+	suite.Equal([]*ProjectUsage{
+		{ProjectID: 2, ProjectName: "library", Used: 100},
+		{ProjectID: 3, ProjectName: "demo", Used: 200},
+	}, usage.Projects)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &ControllerTestSuite{})
+}
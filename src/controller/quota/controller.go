@@ -102,6 +102,11 @@ type Controller interface {
 
 	// Update update quota
 	Update(ctx context.Context, q *quota.Quota) error
+
+	// Evaluate evaluates the usage of the quota against the warning thresholds and the overage policy of the
+	// reference object, the default warning thresholds are used when the reference object has none configured.
+	// It tracks the state of the quota and returns the notices which should be sent to the owners.
+	Evaluate(ctx context.Context, reference, referenceID string, defaultWarningThresholds ...int) ([]*quota.Notice, error)
 }
 
 // NewController creates an instance of the default quota controller
@@ -174,6 +179,14 @@ func (c *controller) GetByRef(ctx context.Context, reference, referenceID string
 }
 
 func (c *controller) assembleQuota(ctx context.Context, q *quota.Quota, opts *Options) (*quota.Quota, error) {
+	if opts.WithState {
+		state, err := c.quotaMgr.GetState(ctx, q.ID)
+		if err != nil {
+			return nil, err
+		}
+		q.State = state
+	}
+
 	if opts.WithReferenceObject {
 		driver, err := Driver(ctx, q.Reference)
 		if err != nil {
@@ -381,7 +394,24 @@ func (c *controller) Refresh(ctx context.Context, reference, referenceID string,
 	}
 
 	// update quota usage by db for refresh operation
-	return c.updateUsageWithRetry(ctx, reference, referenceID, refreshResources(calculateUsage, opts.IgnoreLimitation), updateQuotaProviderType(config.GetQuotaUpdateProvider()), opts.RetryOptions...)
+	if err := c.updateUsageWithRetry(ctx, reference, referenceID, refreshResources(calculateUsage, opts.IgnoreLimitation), updateQuotaProviderType(config.GetQuotaUpdateProvider()), opts.RetryOptions...); err != nil {
+		return err
+	}
+
+	parentReference, parentReferenceID, _, err := c.parent(ctx, reference, referenceID, nil)
+	if err != nil {
+		log.G(ctx).Warningf("failed to get the parent of %s %s, error: %v", reference, referenceID, err)
+		return nil
+	}
+
+	if parentReference != "" {
+		// the usage of the parent is refreshed on the best effort, the limitation of the parent is checked when requesting resources
+		if err := c.Refresh(ctx, parentReference, parentReferenceID, IgnoreLimitation(true)); err != nil {
+			log.G(ctx).Warningf("failed to refresh quota usage for %s %s, error: %v", parentReference, parentReferenceID, err)
+		}
+	}
+
+	return nil
 }
 
 func (c *controller) Request(ctx context.Context, reference, referenceID string, resources types.ResourceList, f func() error) error {
@@ -389,13 +419,48 @@ func (c *controller) Request(ctx context.Context, reference, referenceID string,
 		return f()
 	}
 
+	// the resources are also charged to the quota of the parent, eg the project group of the project,
+	// reserve them from the parent after reserving from the reference object so that both limits are enforced
+	parentReference, parentReferenceID, parentResources, err := c.parent(ctx, reference, referenceID, resources)
+	if err != nil {
+		return err
+	}
+
+	if parentReference != "" {
+		request := f
+		f = func() error {
+			return c.Request(ctx, parentReference, parentReferenceID, parentResources, request)
+		}
+	}
+
+	reserve := reserveResources(resources)
+
+	policy, err := c.policy(ctx, reference, referenceID)
+	if err != nil {
+		return err
+	}
+
+	if policy.AllowOverage() {
+		q, err := c.quotaMgr.GetByRef(ctx, reference, referenceID)
+		if err != nil {
+			return err
+		}
+
+		state, err := c.quotaMgr.GetState(ctx, q.ID)
+		if err != nil {
+			return err
+		}
+
+		reserve = reserveResourcesWithPolicy(resources, policy, state)
+	}
+
 	provider := updateQuotaProviderType(config.GetQuotaUpdateProvider())
-	if err := c.updateUsageWithRetry(ctx, reference, referenceID, reserveResources(resources), provider); err != nil {
+	if err := c.updateUsageWithRetry(ctx, reference, referenceID, reserve, provider); err != nil {
 		log.G(ctx).Errorf("reserve resources %s for %s %s failed, error: %v", resources.String(), reference, referenceID, err)
 		return err
 	}
 
-	err := f()
+	err = f()
 
 	if err != nil {
 		if er := c.updateUsageWithRetry(ctx, reference, referenceID, rollbackResources(resources), provider); er != nil {
@@ -464,6 +529,110 @@ func (c *controller) Update(ctx context.Context, u *quota.Quota) error {
 	return retry.Retry(f, options...)
 }
 
+func (c *controller) Evaluate(ctx context.Context, reference, referenceID string, defaultWarningThresholds ...int) ([]*quota.Notice, error) {
+	policy, err := c.policy(ctx, reference, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := driver.Policy{}
+	if policy != nil {
+		p = *policy
+	}
+	if len(p.WarningThresholds) == 0 {
+		p.WarningThresholds = defaultWarningThresholds
+	}
+
+	q, err := c.GetByRef(ctx, reference, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	hardLimits, err := q.GetHard()
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := q.GetUsed()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := c.quotaMgr.GetState(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := *state
+	notices, err := quota.EvaluateState(hardLimits, used, &p, state, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// only save the state when it's changed to avoid writing the db for every request
+	if *state != origin {
+		if err := c.quotaMgr.SaveState(ctx, state); err != nil {
+			return nil, err
+		}
+	}
+
+	return notices, nil
+}
+
+// policy returns the quota policy of the reference object, nil returned when the driver doesn't support it
+func (c *controller) policy(ctx context.Context, reference, referenceID string) (*driver.Policy, error) {
+	d, err := Driver(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := d.(driver.PolicyProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	return provider.Policy(ctx, referenceID)
+}
+
+// parent returns the reference, the reference id of the parent of the reference object and the resources charged to it,
+// empty reference returned when the reference object has no parent or the quota of the parent is disabled
+func (c *controller) parent(ctx context.Context, reference, referenceID string, resources types.ResourceList) (string, string, types.ResourceList, error) {
+	d, err := Driver(ctx, reference)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	provider, ok := d.(driver.ParentProvider)
+	if !ok {
+		return "", "", nil, nil
+	}
+
+	parentReference, parentReferenceID, err := provider.Parent(ctx, referenceID)
+	if err != nil || parentReference == "" {
+		return "", "", nil, err
+	}
+
+	parentDriver, err := Driver(ctx, parentReference)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	enabled, err := parentDriver.Enabled(ctx, parentReferenceID)
+	if err != nil || !enabled {
+		return "", "", nil, err
+	}
+
+	// only the resources supported by the parent are charged to it
+	parentResources := types.ResourceList{}
+	for resource := range parentDriver.HardLimits(ctx) {
+		if value, ok := resources[resource]; ok {
+			parentResources[resource] = value
+		}
+	}
+
+	return parentReference, parentReferenceID, parentResources, nil
+}
+
 // Driver returns quota driver for the reference
 func Driver(_ context.Context, reference string) (driver.Driver, error) {
 	d, ok := driver.Get(reference)
@@ -485,10 +654,19 @@ func Validate(ctx context.Context, reference string, hardLimits types.ResourceLi
 }
 
 func reserveResources(resources types.ResourceList) func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
+	return reserveResourcesWithPolicy(resources, nil, nil)
+}
+
+func reserveResourcesWithPolicy(resources types.ResourceList, policy *driver.Policy, state *quota.State) func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 	return func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 		newUsed := types.Add(used, resources)
 
-		if err := quota.IsSafe(hardLimits, used, newUsed, false); err != nil {
+		// the overage isn't allowed for the per artifact resources
+		if err := quota.IsArtifactSafe(hardLimits, resources); err != nil {
+			return nil, errors.DeniedError(err).WithMessagef("Quota exceeded when processing the request of %v", err)
+		}
+
+		if err := quota.IsSafeWithPolicy(hardLimits, used, newUsed, policy, state, time.Now()); err != nil {
 			return nil, errors.DeniedError(err).WithMessagef("Quota exceeded when processing the request of %v", err)
 		}
 
@@ -133,6 +133,22 @@ func (suite *ControllerTestSuite) TestRequestExceed() {
 	suite.Error(suite.ctl.Request(ctx, suite.reference, referenceID, resources, func() error { return nil }))
 }
 
+func (suite *ControllerTestSuite) TestRequestArtifactSizeExceed() {
+	// the largest existing artifact predates the lowered hard limit
+	hardLimits := types.ResourceList{types.ResourceStorage: 1000, types.ResourceArtifactSize: 100}
+	used := types.ResourceList{types.ResourceStorage: 150, types.ResourceArtifactSize: 150}
+	q := &quota.Quota{Hard: hardLimits.String(), Used: used.String()}
+	suite.PrepareForUpdate(q, nil)
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	referenceID := uuid.New().String()
+	f := func() error { return nil }
+
+	// the incoming artifact is over the hard limit even though it's smaller than the existing one
+	suite.Error(suite.ctl.Request(ctx, suite.reference, referenceID, types.ResourceList{types.ResourceStorage: 120, types.ResourceArtifactSize: 120}, f))
+	suite.Nil(suite.ctl.Request(ctx, suite.reference, referenceID, types.ResourceList{types.ResourceStorage: 100, types.ResourceArtifactSize: 100}, f))
+}
+
 func (suite *ControllerTestSuite) TestRequestFunctionFailed() {
 	suite.PrepareForUpdate(suite.quota, nil)
 
@@ -156,6 +172,119 @@ func (suite *ControllerTestSuite) TestRequestResourceIsZero() {
 	suite.Nil(err)
 }
 
+type policyDriver struct {
+	*drivertesting.Driver
+
+	policy *driver.Policy
+}
+
+func (d *policyDriver) Policy(_ context.Context, _ string) (*driver.Policy, error) {
+	return d.policy, nil
+}
+
+func (suite *ControllerTestSuite) TestRequestOverage() {
+	reference := "policy"
+	driver.Register(reference, &policyDriver{Driver: suite.driver, policy: &driver.Policy{OveragePercent: 10}})
+
+	suite.PrepareForUpdate(suite.quota, nil)
+	mock.OnAnything(suite.quotaMgr, "GetState").Return(&quota.State{}, nil)
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	referenceID := uuid.New().String()
+	f := func() error { return nil }
+
+	suite.Nil(suite.ctl.Request(ctx, reference, referenceID, types.ResourceList{types.ResourceStorage: 110}, f))
+	suite.Error(suite.ctl.Request(ctx, reference, referenceID, types.ResourceList{types.ResourceStorage: 111}, f))
+}
+
+func (suite *ControllerTestSuite) TestEvaluate() {
+	reference := "policy"
+	driver.Register(reference, &policyDriver{Driver: suite.driver, policy: &driver.Policy{WarningThresholds: []int{50, 90}}})
+
+	hardLimits := types.ResourceList{types.ResourceStorage: 100}
+	q := &quota.Quota{ID: 1, Hard: hardLimits.String(), Used: types.ResourceList{types.ResourceStorage: 60}.String()}
+	mock.OnAnything(suite.quotaMgr, "GetByRef").Return(q, nil)
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	referenceID := uuid.New().String()
+
+	{
+		// crossing the 50% threshold saves the state
+		mock.OnAnything(suite.quotaMgr, "GetState").Return(&quota.State{QuotaID: 1}, nil).Once()
+		mock.OnAnything(suite.quotaMgr, "SaveState").Return(nil).Once()
+
+		notices, err := suite.ctl.Evaluate(ctx, reference, referenceID, 85)
+		suite.Nil(err)
+		suite.Len(notices, 1)
+		suite.Contains(notices[0].Message, "50%")
+	}
+
+	{
+		// the threshold was already noticed, nothing changed
+		state := (&quota.State{QuotaID: 1}).SetWarningLevels(map[types.ResourceName]int{types.ResourceStorage: 50})
+		mock.OnAnything(suite.quotaMgr, "GetState").Return(state, nil).Once()
+
+		notices, err := suite.ctl.Evaluate(ctx, reference, referenceID, 85)
+		suite.Nil(err)
+		suite.Empty(notices)
+	}
+
+	suite.quotaMgr.AssertNumberOfCalls(suite.T(), "SaveState", 1)
+
+	{
+		// the default thresholds are used when no policy configured
+		mock.OnAnything(suite.driver, "Enabled").Return(true, nil)
+		mock.OnAnything(suite.quotaMgr, "GetState").Return(&quota.State{QuotaID: 1}, nil).Once()
+
+		notices, err := suite.ctl.Evaluate(ctx, suite.reference, referenceID, 85)
+		suite.Nil(err)
+		suite.Empty(notices)
+	}
+}
+
+type childDriver struct {
+	*drivertesting.Driver
+
+	parentReference string
+}
+
+func (d *childDriver) Parent(_ context.Context, _ string) (string, string, error) {
+	return d.parentReference, "1", nil
+}
+
+func (suite *ControllerTestSuite) TestRequestWithParent() {
+	parentDriver := &drivertesting.Driver{}
+	mock.OnAnything(parentDriver, "Enabled").Return(true, nil)
+	mock.OnAnything(parentDriver, "HardLimits").Return(types.ResourceList{types.ResourceStorage: -1})
+	driver.Register("parent", parentDriver)
+	driver.Register("child", &childDriver{Driver: suite.driver, parentReference: "parent"})
+
+	parentQuota := &quota.Quota{
+		Hard: types.ResourceList{types.ResourceStorage: 150}.String(),
+		Used: types.ResourceList{types.ResourceStorage: 100}.String(),
+	}
+	suite.quotaMgr.On("GetByRef", mock.Anything, "child", mock.Anything).Return(suite.quota, nil)
+	suite.quotaMgr.On("GetByRef", mock.Anything, "parent", "1").Return(parentQuota, nil)
+	mock.OnAnything(suite.quotaMgr, "Update").Return(nil)
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	referenceID := uuid.New().String()
+	f := func() error { return nil }
+
+	// the usage of the child is under its limit but the parent is exceeded
+	suite.Error(suite.ctl.Request(ctx, "child", referenceID, types.ResourceList{types.ResourceStorage: 60}, f))
+
+	used, err := suite.quota.GetUsed()
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), used[types.ResourceStorage], "the child quota should be rolled back")
+
+	suite.Nil(suite.ctl.Request(ctx, "child", referenceID, types.ResourceList{types.ResourceStorage: 50}, f))
+
+	used, err = parentQuota.GetUsed()
+	suite.Require().NoError(err)
+	suite.Equal(types.ResourceList{types.ResourceStorage: 150}, used)
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &ControllerTestSuite{})
 }
@@ -17,4 +17,6 @@ package driver // nolint:revive
 import (
 	// project quota driver
 	_ "github.com/goharbor/harbor/src/controller/quota/driver/project"
+	// project group quota driver
+	_ "github.com/goharbor/harbor/src/controller/quota/driver/projectgroup"
 )
@@ -18,16 +18,26 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/graph-gophers/dataloader"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/config/db"
+	"github.com/goharbor/harbor/src/pkg/project/metadata"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/projectgroup"
+	pgModel "github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	"github.com/goharbor/harbor/src/pkg/quota"
 	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
@@ -40,7 +50,11 @@ type driver struct {
 	cfg    config.Manager
 	loader *dataloader.Loader
 
-	blobCtl blob.Controller
+	artifactCtl   artifact.Controller
+	blobCtl       blob.Controller
+	repositoryCtl repository.Controller
+	metaMgr       metadata.Manager
+	groupMgr      projectgroup.Manager
 }
 
 func (d *driver) Enabled(ctx context.Context, _ string) (bool, error) {
@@ -58,7 +72,10 @@ func (d *driver) HardLimits(ctx context.Context) types.ResourceList {
 	}
 
 	return types.ResourceList{
-		types.ResourceStorage: d.cfg.Get(ctx, common.StoragePerProject).GetInt64(),
+		types.ResourceStorage:         d.cfg.Get(ctx, common.StoragePerProject).GetInt64(),
+		types.ResourceArtifactCount:   d.cfg.Get(ctx, common.ArtifactCountPerProject).GetInt64(),
+		types.ResourceRepositoryCount: d.cfg.Get(ctx, common.RepositoryCountPerProject).GetInt64(),
+		types.ResourceArtifactSize:    d.cfg.Get(ctx, common.ArtifactSizePerProject).GetInt64(),
 	}
 }
 
@@ -84,7 +101,10 @@ func (d *driver) Load(ctx context.Context, key string) (dr.QuotaRefObject, error
 
 func (d *driver) Validate(hardLimits types.ResourceList) error {
 	resources := map[types.ResourceName]bool{
-		types.ResourceStorage: true,
+		types.ResourceStorage:         true,
+		types.ResourceArtifactCount:   true,
+		types.ResourceRepositoryCount: true,
+		types.ResourceArtifactSize:    true,
 	}
 
 	for resource, value := range hardLimits {
@@ -98,7 +118,7 @@ func (d *driver) Validate(hardLimits types.ResourceList) error {
 	}
 
 	for resource := range resources {
-		if _, found := hardLimits[resource]; !found {
+		if _, found := hardLimits[resource]; !found && !resource.IsOptional() {
 			return fmt.Errorf("resource %s not found", resource)
 		}
 	}
@@ -117,7 +137,92 @@ func (d *driver) CalculateUsage(ctx context.Context, key string) (types.Resource
 		return nil, err
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	artifactCount, err := d.artifactCtl.Count(ctx, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryCount, err := d.repositoryCtl.Count(ctx, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+
+	// the usage of the artifact size is the size of the largest artifact in the project
+	var artifactSize int64
+	query := q.New(q.KeyWords{"ProjectID": projectID})
+	query.Sorts = []*q.Sort{q.NewSort("size", true)}
+	query.PageNumber, query.PageSize = 1, 1
+	arts, err := d.artifactCtl.List(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(arts) > 0 {
+		artifactSize = arts[0].Size
+	}
+
+	return types.ResourceList{
+		types.ResourceStorage:         size,
+		types.ResourceArtifactCount:   artifactCount,
+		types.ResourceRepositoryCount: repositoryCount,
+		types.ResourceArtifactSize:    artifactSize,
+	}, nil
+}
+
+// Policy returns the warning and overage policy configured in the metadata of the project
+func (d *driver) Policy(ctx context.Context, key string) (*dr.Policy, error) {
+	projectID, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	metas, err := d.metaMgr.Get(ctx, projectID,
+		proModels.ProMetaQuotaWarningThresholds, proModels.ProMetaQuotaOveragePercent, proModels.ProMetaQuotaGracePeriodDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metas) == 0 {
+		return nil, nil
+	}
+
+	policy := &dr.Policy{}
+	if value, ok := metas[proModels.ProMetaQuotaWarningThresholds]; ok {
+		if policy.WarningThresholds, err = quota.ParseWarningThresholds(value); err != nil {
+			log.G(ctx).Warningf("invalid %s of project %d, error: %v", proModels.ProMetaQuotaWarningThresholds, projectID, err)
+		}
+	}
+
+	if value, ok := metas[proModels.ProMetaQuotaOveragePercent]; ok {
+		if percent, err := strconv.Atoi(value); err == nil && percent > 0 {
+			policy.OveragePercent = percent
+		}
+	}
+
+	if value, ok := metas[proModels.ProMetaQuotaGracePeriodDays]; ok {
+		if days, err := strconv.Atoi(value); err == nil && days > 0 {
+			policy.GracePeriod = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	return policy, nil
+}
+
+// Parent returns the project group which the project belongs to
+func (d *driver) Parent(ctx context.Context, key string) (string, string, error) {
+	projectID, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return "", "", err
+	}
+
+	group, err := d.groupMgr.GetByProject(ctx, projectID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	return pgModel.QuotaReference, strconv.FormatInt(group.ID, 10), nil
 }
 
 func newDriver() dr.Driver {
@@ -126,8 +231,12 @@ func newDriver() dr.Driver {
 	loader := dataloader.NewBatchedLoader(getProjectsBatchFn, dataloader.WithClearCacheOnBatch())
 
 	return &driver{
-		cfg:     cfg,
-		loader:  loader,
-		blobCtl: blob.Ctl,
+		cfg:           cfg,
+		loader:        loader,
+		artifactCtl:   artifact.Ctl,
+		blobCtl:       blob.Ctl,
+		repositoryCtl: repository.Ctl,
+		metaMgr:       pkg.ProjectMetaMgr,
+		groupMgr:      projectgroup.Mgr,
 	}
 }
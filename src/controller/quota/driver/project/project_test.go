@@ -17,20 +17,31 @@ package project
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	pgModel "github.com/goharbor/harbor/src/pkg/projectgroup/model"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
+	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	"github.com/goharbor/harbor/src/testing/mock"
+	metadatatesting "github.com/goharbor/harbor/src/testing/pkg/project/metadata"
+	projectgrouptesting "github.com/goharbor/harbor/src/testing/pkg/projectgroup"
 )
 
 type DriverTestSuite struct {
 	suite.Suite
 
-	artifactCtl *artifacttesting.Controller
-	blobCtl     *blobtesting.Controller
+	artifactCtl   *artifacttesting.Controller
+	blobCtl       *blobtesting.Controller
+	repositoryCtl *repositorytesting.Controller
+	metaMgr       *metadatatesting.Manager
+	groupMgr      *projectgrouptesting.Manager
 
 	d *driver
 }
@@ -38,9 +49,16 @@ type DriverTestSuite struct {
 func (suite *DriverTestSuite) SetupTest() {
 	suite.artifactCtl = &artifacttesting.Controller{}
 	suite.blobCtl = &blobtesting.Controller{}
+	suite.repositoryCtl = &repositorytesting.Controller{}
+	suite.metaMgr = &metadatatesting.Manager{}
+	suite.groupMgr = &projectgrouptesting.Manager{}
 
 	suite.d = &driver{
-		blobCtl: suite.blobCtl,
+		artifactCtl:   suite.artifactCtl,
+		blobCtl:       suite.blobCtl,
+		repositoryCtl: suite.repositoryCtl,
+		metaMgr:       suite.metaMgr,
+		groupMgr:      suite.groupMgr,
 	}
 }
 
@@ -80,6 +98,31 @@ func (suite *DriverTestSuite) TestValidate() {
 			input:       map[types.ResourceName]int64{types.ResourceStorage: int64(12345)},
 			hasErr:      false,
 		},
+		{
+			description: "artifact count and repository count quota limits",
+			input: map[types.ResourceName]int64{
+				types.ResourceStorage:         -1,
+				types.ResourceArtifactCount:   1000,
+				types.ResourceRepositoryCount: 10,
+				types.ResourceArtifactSize:    -1,
+			},
+			hasErr: false,
+		},
+		{
+			description: "artifact count quota limit is 0",
+			input:       map[types.ResourceName]int64{types.ResourceStorage: -1, types.ResourceArtifactCount: 0},
+			hasErr:      true,
+		},
+		{
+			description: "storage quota limit not found",
+			input:       map[types.ResourceName]int64{types.ResourceArtifactCount: 1000},
+			hasErr:      true,
+		},
+		{
+			description: "resource not supported",
+			input:       map[types.ResourceName]int64{types.ResourceStorage: -1, "count": 10},
+			hasErr:      true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -96,15 +139,75 @@ func (suite *DriverTestSuite) TestCalculateUsage() {
 
 	{
 		mock.OnAnything(suite.blobCtl, "CalculateTotalSizeByProject").Return(int64(1000), nil).Once()
+		mock.OnAnything(suite.artifactCtl, "Count").Return(int64(10), nil).Once()
+		mock.OnAnything(suite.repositoryCtl, "Count").Return(int64(2), nil).Once()
+		largest := &artifact.Artifact{}
+		largest.Size = 500
+		mock.OnAnything(suite.artifactCtl, "List").Return([]*artifact.Artifact{largest}, nil).Once()
 
 		resources, err := suite.d.CalculateUsage(context.TODO(), "1")
 		if suite.Nil(err) {
-			suite.Len(resources, 1)
+			suite.Len(resources, 4)
 			suite.Equal(resources[types.ResourceStorage], int64(1000))
+			suite.Equal(resources[types.ResourceArtifactCount], int64(10))
+			suite.Equal(resources[types.ResourceRepositoryCount], int64(2))
+			suite.Equal(resources[types.ResourceArtifactSize], int64(500))
 		}
 	}
 }
 
+func (suite *DriverTestSuite) TestPolicy() {
+	{
+		suite.metaMgr.On("Get", mock.Anything, int64(1), mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{}, nil).Once()
+
+		policy, err := suite.d.Policy(context.TODO(), "1")
+		suite.Nil(err)
+		suite.Nil(policy)
+	}
+
+	{
+		suite.metaMgr.On("Get", mock.Anything, int64(1), mock.Anything, mock.Anything, mock.Anything).Return(map[string]string{
+			proModels.ProMetaQuotaWarningThresholds: "90,75,95",
+			proModels.ProMetaQuotaOveragePercent:    "10",
+			proModels.ProMetaQuotaGracePeriodDays:   "7",
+		}, nil).Once()
+
+		policy, err := suite.d.Policy(context.TODO(), "1")
+		if suite.Nil(err) {
+			suite.Equal([]int{75, 90, 95}, policy.WarningThresholds)
+			suite.Equal(10, policy.OveragePercent)
+			suite.Equal(7*24*time.Hour, policy.GracePeriod)
+		}
+	}
+}
+
+func (suite *DriverTestSuite) TestParent() {
+	{
+		suite.groupMgr.On("GetByProject", mock.Anything, int64(1)).Return(nil, errors.NotFoundError(nil)).Once()
+
+		reference, referenceID, err := suite.d.Parent(context.TODO(), "1")
+		suite.Nil(err)
+		suite.Empty(reference)
+		suite.Empty(referenceID)
+	}
+
+	{
+		suite.groupMgr.On("GetByProject", mock.Anything, int64(2)).Return(&pgModel.ProjectGroup{ID: 3}, nil).Once()
+
+		reference, referenceID, err := suite.d.Parent(context.TODO(), "2")
+		suite.Nil(err)
+		suite.Equal(pgModel.QuotaReference, reference)
+		suite.Equal("3", referenceID)
+	}
+
+	{
+		suite.groupMgr.On("GetByProject", mock.Anything, int64(4)).Return(nil, errors.New("failed")).Once()
+
+		_, _, err := suite.d.Parent(context.TODO(), "4")
+		suite.Error(err)
+	}
+}
+
 func TestDriverTestSuite(t *testing.T) {
 	suite.Run(t, &DriverTestSuite{})
 }
@@ -0,0 +1,122 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectgroup
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/pkg/config/db"
+	"github.com/goharbor/harbor/src/pkg/projectgroup"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+func init() {
+	dr.Register(model.QuotaReference, newDriver())
+}
+
+type driver struct {
+	cfg config.Manager
+
+	blobCtl  blob.Controller
+	groupMgr projectgroup.Manager
+}
+
+func (d *driver) Enabled(ctx context.Context, _ string) (bool, error) {
+	// the quota of the project group caps the usage of the member projects, so it follows the switch of the project quota
+	if err := d.cfg.Load(ctx); err != nil {
+		return false, err
+	}
+	return d.cfg.Get(ctx, common.QuotaPerProjectEnable).GetBool(), nil
+}
+
+func (d *driver) HardLimits(_ context.Context) types.ResourceList {
+	return types.ResourceList{
+		types.ResourceStorage: types.UNLIMITED,
+	}
+}
+
+func (d *driver) Load(ctx context.Context, key string) (dr.QuotaRefObject, error) {
+	groupID, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := d.groupMgr.Get(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dr.QuotaRefObject{
+		"id":   group.ID,
+		"name": group.Name,
+	}, nil
+}
+
+func (d *driver) Validate(hardLimits types.ResourceList) error {
+	for resource, value := range hardLimits {
+		if resource != types.ResourceStorage {
+			return fmt.Errorf("resource %s not support", resource)
+		}
+
+		if err := lib.ValidateQuotaLimit(value); err != nil {
+			return err
+		}
+	}
+
+	if _, found := hardLimits[types.ResourceStorage]; !found {
+		return fmt.Errorf("resource %s not found", types.ResourceStorage)
+	}
+
+	return nil
+}
+
+func (d *driver) CalculateUsage(ctx context.Context, key string) (types.ResourceList, error) {
+	groupID, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDs, err := d.groupMgr.ListProjects(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	for _, projectID := range projectIDs {
+		s, err := d.blobCtl.CalculateTotalSizeByProject(ctx, projectID, true)
+		if err != nil {
+			return nil, err
+		}
+		size += s
+	}
+
+	return types.ResourceList{types.ResourceStorage: size}, nil
+}
+
+func newDriver() dr.Driver {
+	return &driver{
+		cfg:      db.NewDBCfgManager(),
+		blobCtl:  blob.Ctl,
+		groupMgr: projectgroup.Mgr,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
+	"github.com/goharbor/harbor/src/testing/mock"
+	projectgrouptesting "github.com/goharbor/harbor/src/testing/pkg/projectgroup"
+)
+
+type DriverTestSuite struct {
+	suite.Suite
+
+	blobCtl  *blobtesting.Controller
+	groupMgr *projectgrouptesting.Manager
+
+	d *driver
+}
+
+func (suite *DriverTestSuite) SetupTest() {
+	suite.blobCtl = &blobtesting.Controller{}
+	suite.groupMgr = &projectgrouptesting.Manager{}
+
+	suite.d = &driver{
+		blobCtl:  suite.blobCtl,
+		groupMgr: suite.groupMgr,
+	}
+}
+
+func (suite *DriverTestSuite) TestValidate() {
+	suite.NoError(suite.d.Validate(types.ResourceList{types.ResourceStorage: -1}))
+	suite.NoError(suite.d.Validate(types.ResourceList{types.ResourceStorage: 1024}))
+	suite.Error(suite.d.Validate(types.ResourceList{types.ResourceStorage: 0}))
+	suite.Error(suite.d.Validate(types.ResourceList{types.ResourceStorage: 1024, types.ResourceArtifactCount: 10}))
+	suite.Error(suite.d.Validate(types.ResourceList{}))
+}
+
+func (suite *DriverTestSuite) TestLoad() {
+	suite.groupMgr.On("Get", mock.Anything, int64(1)).Return(&model.ProjectGroup{ID: 1, Name: "engineering"}, nil).Once()
+
+	ref, err := suite.d.Load(context.TODO(), "1")
+	if suite.Nil(err) {
+		suite.Equal(int64(1), ref["id"])
+		suite.Equal("engineering", ref["name"])
+	}
+}
+
+func (suite *DriverTestSuite) TestCalculateUsage() {
+	suite.groupMgr.On("ListProjects", mock.Anything, int64(1)).Return([]int64{1, 2}, nil).Once()
+	suite.blobCtl.On("CalculateTotalSizeByProject", mock.Anything, int64(1), true).Return(int64(1000), nil).Once()
+	suite.blobCtl.On("CalculateTotalSizeByProject", mock.Anything, int64(2), true).Return(int64(500), nil).Once()
+
+	resources, err := suite.d.CalculateUsage(context.TODO(), "1")
+	if suite.Nil(err) {
+		suite.Equal(types.ResourceList{types.ResourceStorage: 1500}, resources)
+	}
+}
+
+func TestDriverTestSuite(t *testing.T) {
+	suite.Run(t, &DriverTestSuite{})
+}
@@ -0,0 +1,136 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/goharbor/harbor/src/pkg/quota"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	// EvaluationVendorType is the vendor type of the schedule to evaluate the quotas of the projects
+	EvaluationVendorType = "QUOTA_EVALUATION"
+	// EvaluationCallback is the name of the callback for the schedule to evaluate the quotas of the projects
+	EvaluationCallback = "QUOTA_EVALUATION"
+
+	// evaluate hourly as the overage is noticed at most once a day
+	cronTypeHourly = "Hourly"
+	cronSpec       = "0 0 * * * *"
+
+	// the default warning threshold used when the project has none configured, same as the quota middleware
+	defaultWarningPercent = 85
+	// the levels of the quota event
+	levelExceeded = 1
+	levelWarning  = 2
+)
+
+var (
+	sched      = scheduler.Sched
+	projectMgr = pkg.ProjectMgr
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(EvaluationCallback, evaluationCallback); err != nil {
+		log.Fatalf("failed to register the callback for the quota evaluation schedule, error %v", err)
+	}
+}
+
+func evaluationCallback(ctx context.Context, _ string) error {
+	err := EvaluateProjects(ctx)
+	if err != nil {
+		log.Errorf("failed to evaluate the quotas of the projects: %v", err)
+	}
+	return err
+}
+
+// ScheduleEvaluation schedules the hourly quota evaluation if it's not scheduled yet
+func ScheduleEvaluation(ctx context.Context) error {
+	query := q.New(map[string]any{"vendor_type": EvaluationVendorType})
+	schedules, err := sched.ListSchedules(ctx, query)
+	if err != nil {
+		return err
+	}
+	if len(schedules) > 0 {
+		log.Debugf("quota evaluation already scheduled with ID: %d", schedules[0].ID)
+		return nil
+	}
+
+	id, err := sched.Schedule(ctx, EvaluationVendorType, 0, cronTypeHourly, cronSpec, EvaluationCallback, nil, nil)
+	if err != nil {
+		return err
+	}
+	log.Infof("scheduled the quota evaluation with ID: %d", id)
+	return nil
+}
+
+// EvaluateProjects evaluates the quotas of all the projects and notifies the owners,
+// so the warnings and the overage are noticed even when nobody pushes to the projects
+func EvaluateProjects(ctx context.Context) error {
+	query := q.New(q.KeyWords{"reference": "project"})
+	query.PageSize = 100
+	for page := int64(1); ; page++ {
+		query.PageNumber = page
+		quotas, err := Ctl.List(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		for _, qt := range quotas {
+			notices, err := Ctl.Evaluate(ctx, qt.Reference, qt.ReferenceID, defaultWarningPercent)
+			if err != nil {
+				log.Warningf("evaluate quota of %s %s failed, error: %v", qt.Reference, qt.ReferenceID, err)
+				continue
+			}
+			if len(notices) > 0 {
+				notify(ctx, qt.ReferenceID, notices)
+			}
+		}
+
+		if int64(len(quotas)) < query.PageSize {
+			return nil
+		}
+	}
+}
+
+func notify(ctx context.Context, referenceID string, notices []*quota.Notice) {
+	projectID, _ := strconv.ParseInt(referenceID, 10, 64)
+	project, err := projectMgr.Get(ctx, projectID)
+	if err != nil {
+		log.Errorf("get project %d failed, error: %v", projectID, err)
+		return
+	}
+
+	for _, notice := range notices {
+		level := levelWarning
+		if notice.Kind == quota.NoticeOverage {
+			level = levelExceeded
+		}
+		event.BuildAndPublish(ctx, &metadata.QuotaMetaData{
+			Project: project,
+			Level:   level,
+			Msg:     notice.Message,
+			OccurAt: time.Now(),
+		})
+	}
+}
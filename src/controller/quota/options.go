@@ -23,6 +23,7 @@ type Option func(*Options)
 type Options struct {
 	IgnoreLimitation    bool
 	WithReferenceObject bool
+	WithState           bool
 	// RetryOptions is the sets of options but for retry function.
 	RetryOptions []retry.Option
 }
@@ -41,6 +42,13 @@ func WithReferenceObject() func(*Options) {
 	}
 }
 
+// WithState set WithState to true for the Options
+func WithState() func(*Options) {
+	return func(opts *Options) {
+		opts.WithState = true
+	}
+}
+
 // WithRetryOptions set RetryOptions to Options
 func WithRetryOptions(retryOpts []retry.Option) func(*Options) {
 	return func(opts *Options) {
@@ -22,11 +22,14 @@ import (
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	pgModel "github.com/goharbor/harbor/src/pkg/projectgroup/model"
 )
 
 const (
 	// ProjectReference reference type for project
 	ProjectReference = "project"
+	// ProjectGroupReference reference type for project group
+	ProjectGroupReference = pgModel.QuotaReference
 )
 
 // ReferenceID returns reference id for the interface
@@ -22,6 +22,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/controller/replication/flow"
 	replicationmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
@@ -70,7 +71,7 @@ type Controller interface {
 	// GetTask gets the specific task
 	GetTask(ctx context.Context, taskID int64) (task *Task, err error)
 	// GetTaskLog gets the log of the specific task
-	GetTaskLog(ctx context.Context, taskID int64) (log []byte, err error)
+	GetTaskLog(ctx context.Context, taskID int64, logQuery *jobquery.LogQuery) (log []byte, err error)
 }
 
 // NewController creates a new instance of the replication controller
@@ -282,13 +283,13 @@ func (c *controller) GetTask(ctx context.Context, id int64) (*Task, error) {
 	return convertTask(tasks[0]), nil
 }
 
-func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
+func (c *controller) GetTaskLog(ctx context.Context, id int64, logQuery *jobquery.LogQuery) ([]byte, error) {
 	// make sure the task specified by ID is replication task
 	_, err := c.GetTask(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return c.taskMgr.GetLog(ctx, id)
+	return c.taskMgr.QueryLog(ctx, id, logQuery)
 }
 
 func convertExecution(exec *task.Execution) *Execution {
@@ -281,8 +281,8 @@ func (r *replicationTestSuite) TestGetTaskLog() {
 			ID: 1,
 		},
 	}, nil)
-	r.taskMgr.On("GetLog", mock.Anything, mock.Anything).Return([]byte{'a'}, nil)
-	data, err := r.ctl.GetTaskLog(nil, 1)
+	r.taskMgr.On("QueryLog", mock.Anything, mock.Anything, mock.Anything).Return([]byte{'a'}, nil)
+	data, err := r.ctl.GetTaskLog(nil, 1, nil)
 	r.Require().Nil(err)
 	r.Equal([]byte{'a'}, data)
 	r.taskMgr.AssertExpectations(r.T())
@@ -17,11 +17,14 @@ package flow
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
@@ -32,6 +35,7 @@ type copyFlow struct {
 	policy       *repctlmodel.Policy
 	executionMgr task.ExecutionManager
 	taskMgr      task.Manager
+	projectMgr   project.Manager
 }
 
 // NewCopyFlow returns an instance of the copy flow which replicates the resources from
@@ -41,6 +45,7 @@ func NewCopyFlow(executionID int64, policy *repctlmodel.Policy, resources ...*mo
 	return &copyFlow{
 		executionMgr: task.ExecMgr,
 		taskMgr:      task.Mgr,
+		projectMgr:   pkg.ProjectMgr,
 		executionID:  executionID,
 		policy:       policy,
 		resources:    resources,
@@ -114,6 +119,8 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 		}
 	}()
 
+	// cache the IDs of the local projects as the resources are usually under the same projects
+	projects := map[string]int64{}
 	for i, srcResource := range srcResources {
 		dstResource := dstResources[i]
 		// if dest resource should be skipped, ignore replicate.
@@ -131,17 +138,21 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 			return err
 		}
 
+		params := map[string]any{
+			"src_resource":  string(src),
+			"dst_resource":  string(dest),
+			"speed":         speed,
+			"copy_by_chunk": copyByChunk,
+		}
+		if projectID := c.localProjectID(ctx, srcResource, dstResource, projects); projectID > 0 {
+			params[job.ProjectIDParamKey] = projectID
+		}
 		job := &task.Job{
 			Name: job.ReplicationVendorType,
 			Metadata: &job.Metadata{
 				JobKind: job.KindGeneric,
 			},
-			Parameters: map[string]any{
-				"src_resource":  string(src),
-				"dst_resource":  string(dest),
-				"speed":         speed,
-				"copy_by_chunk": copyByChunk,
-			},
+			Parameters: params,
 		}
 
 		if _, err = c.taskMgr.Create(ctx, c.executionID, job, map[string]any{
@@ -157,3 +168,32 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 	}
 	return nil
 }
+
+// localProjectID returns the ID of the local project which the replicated resource belongs to,
+// the job service schedules the replication jobs fairly among the projects with it.
+// 0 is returned if the project can not be determined
+func (c *copyFlow) localProjectID(ctx context.Context, src, dst *model.Resource, projects map[string]int64) int64 {
+	// the source is the local registry for push-based replication, otherwise the destination is
+	res := dst
+	if c.policy.SrcRegistry == nil || c.policy.SrcRegistry.ID == 0 {
+		res = src
+	}
+	if c.projectMgr == nil || res == nil || res.Metadata == nil || res.Metadata.Repository == nil {
+		return 0
+	}
+
+	namespace, _, _ := strings.Cut(res.Metadata.Repository.Name, "/")
+	if id, ok := projects[namespace]; ok {
+		return id
+	}
+	var id int64
+	p, err := c.projectMgr.Get(ctx, namespace)
+	if err != nil {
+		log.Debugf("failed to get the local project %s of the replicated resource: %v", namespace, err)
+	} else {
+		id = p.ProjectID
+	}
+	projects[namespace] = id
+
+	return id
+}
@@ -19,9 +19,11 @@ import (
 
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/reg/adapter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/task"
+	testingProject "github.com/goharbor/harbor/src/testing/pkg/project"
 	testingTask "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
@@ -61,7 +63,11 @@ func (c *copyFlowTestSuite) TestRun() {
 	}, nil)
 
 	taskMgr := &testingTask.Manager{}
-	taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	taskMgr.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(j *task.Job) bool {
+		return j.Parameters[job.ProjectIDParamKey] == int64(1)
+	}), mock.Anything).Return(int64(1), nil).Once()
+	projectMgr := &testingProject.Manager{}
+	projectMgr.On("Get", mock.Anything, "proxy").Return(&proModels.Project{ProjectID: 1, Name: "proxy"}, nil).Once()
 	policy := &repctlmodel.Policy{
 		SrcRegistry: &model.Registry{
 			Type: "TEST_FOR_COPY_FLOW",
@@ -75,9 +81,12 @@ func (c *copyFlowTestSuite) TestRun() {
 		policy:       policy,
 		executionMgr: execMgr,
 		taskMgr:      taskMgr,
+		projectMgr:   projectMgr,
 	}
 	err := flow.Run(context.Background())
 	c.Require().Nil(err)
+	taskMgr.AssertExpectations(c.T())
+	projectMgr.AssertExpectations(c.T())
 }
 
 func TestCopyFlowTestSuite(t *testing.T) {
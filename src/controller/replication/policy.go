@@ -22,6 +22,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	pkgmodel "github.com/goharbor/harbor/src/pkg/replication/model"
@@ -58,6 +59,23 @@ func init() {
 	if err != nil {
 		log.Errorf("failed to register the callback function for replication: %v", err)
 	}
+
+	if err := task.RegisterWorkflowLauncher(job.ReplicationVendorType, workflowLauncher); err != nil {
+		log.Errorf("failed to register the workflow launcher for replication: %v", err)
+	}
+}
+
+// workflowLauncher starts the replication of the policy specified by "policy_id" in the workflow
+func workflowLauncher(ctx context.Context, params map[string]any) (int64, error) {
+	policyID, ok := task.Int64FromAny(params["policy_id"])
+	if !ok {
+		return 0, errors.BadRequestError(nil).WithMessage("the replication policy ID is required")
+	}
+	policy, err := Ctl.GetPolicy(ctx, policyID)
+	if err != nil {
+		return 0, err
+	}
+	return Ctl.Start(ctx, policy, nil, task.ExecutionTriggerWorkflow)
 }
 
 func (c *controller) PolicyCount(ctx context.Context, query *q.Query) (int64, error) {
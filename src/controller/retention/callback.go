@@ -20,8 +20,11 @@ import (
 	"fmt"
 
 	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
 )
 
 func init() {
@@ -29,6 +32,10 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to register retention callback, %v", err)
 	}
+
+	if err := task.RegisterWorkflowLauncher(job.RetentionVendorType, workflowLauncher); err != nil {
+		log.Fatalf("failed to register retention workflow launcher, %v", err)
+	}
 }
 
 func retentionCallback(ctx context.Context, p string) error {
@@ -43,3 +50,14 @@ func retentionCallback(ctx context.Context, p string) error {
 	_, err := Ctl.TriggerRetentionExec(ctx, param.PolicyID, param.Trigger, false)
 	return err
 }
+
+// workflowLauncher triggers the retention of the policy specified by "policy_id" in the workflow,
+// the retention runs in dry run mode if "dry_run" is true
+func workflowLauncher(ctx context.Context, params map[string]any) (int64, error) {
+	policyID, ok := task.Int64FromAny(params["policy_id"])
+	if !ok {
+		return 0, errors.BadRequestError(nil).WithMessage("the retention policy ID is required")
+	}
+	dryRun, _ := params["dry_run"].(bool)
+	return Ctl.TriggerRetentionExec(ctx, policyID, task.ExecutionTriggerWorkflow, dryRun)
+}
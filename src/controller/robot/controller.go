@@ -44,6 +44,9 @@ var (
 	Ctl = NewController()
 )
 
+// the minimal interval to update the last used time of the robot
+const usageRecordInterval = time.Minute
+
 // Controller to handle the requests related with robot account
 type Controller interface {
 	// Get ...
@@ -63,6 +66,16 @@ type Controller interface {
 
 	// List ...
 	List(ctx context.Context, query *q.Query, option *Option) ([]*Robot, error)
+
+	// RefreshSecret replaces the secret of the robot with the encrypted secret,
+	// the previous secret is still valid within the configured grace period
+	RefreshSecret(ctx context.Context, r *Robot, secret string) error
+
+	// RecordUsage records the last used time and the source IP of the robot
+	RecordUsage(ctx context.Context, r *Robot, ip string) error
+
+	// NotifyExpiration fires the events for the robots which expire after the configured days
+	NotifyExpiration(ctx context.Context) error
 }
 
 // controller ...
@@ -209,6 +222,34 @@ func (d *controller) List(ctx context.Context, query *q.Query, option *Option) (
 	return robotAccounts, nil
 }
 
+// RefreshSecret ...
+func (d *controller) RefreshSecret(ctx context.Context, r *Robot, secret string) error {
+	if r == nil {
+		return errors.New("cannot refresh the secret of a nil robot").WithCode(errors.BadRequestCode)
+	}
+	r.PreviousSecret, r.PreviousSecretExpiresAt = "", 0
+	if grace := config.RobotSecretGracePeriod(ctx); grace > 0 && len(r.Secret) > 0 && r.Secret != secret {
+		r.PreviousSecret = r.Secret
+		r.PreviousSecretExpiresAt = time.Now().Add(time.Duration(grace) * time.Hour).Unix()
+	}
+	r.Secret = secret
+	return d.robotMgr.Update(ctx, &r.Robot, "secret", "previous_secret", "previous_secret_expiresat")
+}
+
+// RecordUsage ...
+func (d *controller) RecordUsage(ctx context.Context, r *Robot, ip string) error {
+	if r == nil {
+		return nil
+	}
+	now := time.Now()
+	// avoid updating the database for every request of the robot
+	if r.LastUsedIP == ip && now.Sub(r.LastUsedTime) < usageRecordInterval {
+		return nil
+	}
+	r.LastUsedTime, r.LastUsedIP = now, ip
+	return d.robotMgr.Update(ctx, &r.Robot, "last_used_time", "last_used_ip")
+}
+
 func (d *controller) createPermission(ctx context.Context, r *Robot) error {
 	if r == nil {
 		return nil
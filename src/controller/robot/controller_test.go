@@ -4,12 +4,15 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/utils"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/security"
+	commonutils "github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/common/utils/test"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -261,6 +264,100 @@ func (suite *ControllerTestSuite) TestList() {
 
 }
 
+func (suite *ControllerTestSuite) TestRefreshSecret() {
+	robotMgr := &robot.Manager{}
+	c := controller{robotMgr: robotMgr}
+	ctx := context.TODO()
+	robotMgr.On("Update", mock.Anything, mock.Anything, "secret", "previous_secret", "previous_secret_expiresat").Return(nil)
+
+	oldSecret := commonutils.Encrypt("Harbor12345", "salt", commonutils.SHA256)
+	newSecret := commonutils.Encrypt("Harbor123456", "salt", commonutils.SHA256)
+
+	// no grace period
+	config.InitWithSettings(map[string]any{common.RobotSecretGracePeriod: 0})
+	r := &Robot{Robot: model.Robot{ID: 1, Secret: oldSecret, Salt: "salt"}}
+	suite.Nil(c.RefreshSecret(ctx, r, newSecret))
+	suite.Equal(newSecret, r.Secret)
+	suite.Empty(r.PreviousSecret)
+	suite.False(r.MatchSecret("Harbor12345"))
+
+	// the previous secret is valid in the grace period
+	config.InitWithSettings(map[string]any{common.RobotSecretGracePeriod: 24})
+	r = &Robot{Robot: model.Robot{ID: 1, Secret: oldSecret, Salt: "salt"}}
+	suite.Nil(c.RefreshSecret(ctx, r, newSecret))
+	suite.Equal(newSecret, r.Secret)
+	suite.Equal(oldSecret, r.PreviousSecret)
+	suite.InDelta(time.Now().Add(24*time.Hour).Unix(), r.PreviousSecretExpiresAt, 5)
+	suite.True(r.MatchSecret("Harbor12345"))
+	suite.True(r.MatchSecret("Harbor123456"))
+}
+
+func (suite *ControllerTestSuite) TestRecordUsage() {
+	robotMgr := &robot.Manager{}
+	c := controller{robotMgr: robotMgr}
+	ctx := context.TODO()
+	robotMgr.On("Update", mock.Anything, mock.Anything, "last_used_time", "last_used_ip").Return(nil)
+
+	r := &Robot{Robot: model.Robot{ID: 1}}
+	suite.Nil(c.RecordUsage(ctx, r, "10.0.0.1"))
+	suite.Equal("10.0.0.1", r.LastUsedIP)
+	suite.False(r.LastUsedTime.IsZero())
+	robotMgr.AssertNumberOfCalls(suite.T(), "Update", 1)
+
+	// used recently from the same IP, skip the update
+	suite.Nil(c.RecordUsage(ctx, r, "10.0.0.1"))
+	robotMgr.AssertNumberOfCalls(suite.T(), "Update", 1)
+
+	// used from another IP
+	suite.Nil(c.RecordUsage(ctx, r, "10.0.0.2"))
+	suite.Equal("10.0.0.2", r.LastUsedIP)
+	robotMgr.AssertNumberOfCalls(suite.T(), "Update", 2)
+}
+
+func (suite *ControllerTestSuite) TestNotifyExpiration() {
+	projectMgr := &project.Manager{}
+	rbacMgr := &rbac.Manager{}
+	robotMgr := &robot.Manager{}
+	c := controller{robotMgr: robotMgr, rbacMgr: rbacMgr, proMgr: projectMgr}
+	ctx := context.TODO()
+
+	// disabled
+	config.InitWithSettings(map[string]any{common.RobotExpirationNotifyDays: 0})
+	suite.Nil(c.NotifyExpiration(ctx))
+	robotMgr.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything)
+
+	config.InitWithSettings(map[string]any{
+		common.RobotPrefix:               "robot$",
+		common.RobotExpirationNotifyDays: 7,
+	})
+	deadline := time.Now().AddDate(0, 0, 7).Unix()
+	robotMgr.On("List", mock.Anything, testifymock.MatchedBy(func(query *q.Query) bool {
+		r, ok := query.Keywords["expiresat"].(*q.Range)
+		return ok && query.Keywords["disabled"] == false &&
+			r.Min.(int64) <= deadline-86400 && r.Max.(int64) < deadline && r.Max.(int64) > deadline-10
+	})).Return([]*model.Robot{
+		{
+			ID:        1,
+			Name:      "library+test",
+			ProjectID: 1,
+			Secret:    utils.GetNonce(),
+			ExpiresAt: deadline - 3600,
+		},
+	}, nil)
+	rbacMgr.On("GetPermissionsByRole", mock.Anything, mock.Anything, mock.Anything).Return([]*rbac_model.UniversalRolePermission{
+		{
+			RoleType: ROBOTTYPE,
+			RoleID:   1,
+			Scope:    "/project/1",
+			Resource: "repository",
+			Action:   "pull",
+		},
+	}, nil)
+	projectMgr.On("Get", mock.Anything, mock.Anything).Return(&proModels.Project{ProjectID: 1, Name: "library"}, nil)
+	suite.Nil(c.NotifyExpiration(ctx))
+	robotMgr.AssertExpectations(suite.T())
+}
+
 func (suite *ControllerTestSuite) TestToScope() {
 	projectMgr := &project.Manager{}
 	rbacMgr := &rbac.Manager{}
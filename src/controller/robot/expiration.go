@@ -0,0 +1,113 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robot
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	// ExpirationNotificationVendorType is the vendor type of the schedule to notify the expiring robots
+	ExpirationNotificationVendorType = "ROBOT_EXPIRATION_NOTIFICATION"
+	// ExpirationNotificationCallback is the name of the callback for the schedule to notify the expiring robots
+	ExpirationNotificationCallback = "ROBOT_EXPIRATION_NOTIFICATION"
+
+	cronTypeDaily = "Daily"
+	cronSpec      = "0 0 0 * * *"
+	// the interval of the schedule, the robots expire in the interval which is N days later are notified in every run
+	notificationInterval = 24 * time.Hour
+)
+
+var sched = scheduler.Sched
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(ExpirationNotificationCallback, expirationNotificationCallback); err != nil {
+		log.Fatalf("failed to register the callback for the robot expiration notification schedule, error %v", err)
+	}
+}
+
+func expirationNotificationCallback(ctx context.Context, _ string) error {
+	err := Ctl.NotifyExpiration(ctx)
+	if err != nil {
+		log.Errorf("failed to notify the expiring robots: %v", err)
+	}
+	return err
+}
+
+// ScheduleExpirationNotification schedules the daily robot expiration notification if it's not scheduled yet
+func ScheduleExpirationNotification(ctx context.Context) error {
+	query := q.New(map[string]any{"vendor_type": ExpirationNotificationVendorType})
+	schedules, err := sched.ListSchedules(ctx, query)
+	if err != nil {
+		return err
+	}
+	if len(schedules) > 0 {
+		log.Debugf("robot expiration notification already scheduled with ID: %d", schedules[0].ID)
+		return nil
+	}
+
+	id, err := sched.Schedule(ctx, ExpirationNotificationVendorType, 0, cronTypeDaily, cronSpec, ExpirationNotificationCallback, nil, nil)
+	if err != nil {
+		return err
+	}
+	log.Infof("scheduled the robot expiration notification with ID: %d", id)
+	return nil
+}
+
+// NotifyExpiration ...
+func (d *controller) NotifyExpiration(ctx context.Context) error {
+	days := config.RobotExpirationNotifyDays(ctx)
+	if days <= 0 {
+		log.Debug("the robot expiration notification is disabled")
+		return nil
+	}
+
+	deadline := time.Now().AddDate(0, 0, days)
+	robots, err := d.List(ctx, q.New(q.KeyWords{
+		"disabled": false,
+		"expiresat": &q.Range{
+			Min: deadline.Add(-notificationInterval).Unix(),
+			Max: deadline.Unix() - 1,
+		},
+	}), &Option{WithPermission: true})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range robots {
+		var namespaces []string
+		for _, p := range r.Permissions {
+			// the robots covering all the projects aren't notified to every project
+			if p.Kind == LEVELPROJECT && !p.IsCoverAll() {
+				namespaces = append(namespaces, p.Namespace)
+			}
+		}
+		log.Debugf("the robot %s expires at %s", r.Name, time.Unix(r.ExpiresAt, 0))
+		rb := r.Robot
+		event.BuildAndPublish(ctx, &metadata.RobotExpiringEventMetadata{
+			Robot:      &rb,
+			Namespaces: namespaces,
+		})
+	}
+	return nil
+}
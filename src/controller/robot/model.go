@@ -15,6 +15,9 @@
 package robot
 
 import (
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
 )
@@ -51,6 +54,16 @@ func (r *Robot) IsSysLevel() bool {
 	return r.Level == LEVELSYSTEM
 }
 
+// MatchSecret checks the secret against the current secret of the robot and the previous one
+// which is still valid within the grace period after refreshing the secret
+func (r *Robot) MatchSecret(secret string) bool {
+	encrypted := utils.Encrypt(secret, r.Salt, utils.SHA256)
+	if encrypted == r.Secret {
+		return true
+	}
+	return len(r.PreviousSecret) > 0 && encrypted == r.PreviousSecret && r.PreviousSecretExpiresAt > time.Now().Unix()
+}
+
 // setLevel, 0 is a system level robot, others are project level.
 func (r *Robot) setLevel() {
 	if r.ProjectID == 0 {
@@ -2,9 +2,11 @@ package robot
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
 	htesting "github.com/goharbor/harbor/src/testing"
@@ -111,6 +113,27 @@ func (suite *ModelTestSuite) TestIsCoverAll() {
 	suite.False(p.IsCoverAll())
 }
 
+func (suite *ModelTestSuite) TestMatchSecret() {
+	r := &Robot{
+		Robot: model.Robot{
+			Secret: utils.Encrypt("Harbor12345", "salt", utils.SHA256),
+			Salt:   "salt",
+		},
+	}
+	suite.True(r.MatchSecret("Harbor12345"))
+	suite.False(r.MatchSecret("Harbor123456"))
+
+	// the previous secret within the grace period
+	r.PreviousSecret = utils.Encrypt("Harbor123456", "salt", utils.SHA256)
+	r.PreviousSecretExpiresAt = time.Now().Add(time.Hour).Unix()
+	suite.True(r.MatchSecret("Harbor12345"))
+	suite.True(r.MatchSecret("Harbor123456"))
+
+	// the previous secret is expired
+	r.PreviousSecretExpiresAt = time.Now().Add(-time.Hour).Unix()
+	suite.False(r.MatchSecret("Harbor123456"))
+}
+
 func TestModelTestSuite(t *testing.T) {
 	suite.Run(t, &ModelTestSuite{})
 }
@@ -0,0 +1,232 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/rbac"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/pkg/role"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+// the custom roles are defined globally and are applicable to all the projects
+const scopeAllProjects = "/project/*"
+
+var (
+	// Ctl is a global variable for the default role controller implementation
+	Ctl = NewController()
+)
+
+// Role is the project role with its policies
+type Role struct {
+	model.Role
+	Policies []*types.Policy `json:"policies"`
+}
+
+// Controller to handle the requests related with the project roles
+type Controller interface {
+	// Get gets the role by ID, the policies of the custom role are populated
+	Get(ctx context.Context, id int64) (*Role, error)
+	// Count returns the total count of roles according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the roles according to the query, the policies of the custom roles are populated
+	List(ctx context.Context, query *q.Query) ([]*Role, error)
+	// Create creates the custom role with the policies
+	Create(ctx context.Context, r *Role) (int64, error)
+	// Update updates the description and the policies of the custom role
+	Update(ctx context.Context, r *Role) error
+	// Delete deletes the custom role which isn't assigned to any member
+	Delete(ctx context.Context, id int64) error
+	// Exist returns whether the role ID is a built-in role or an existing custom role
+	Exist(ctx context.Context, id int64) (bool, error)
+}
+
+// NewController creates an instance of the default role controller
+func NewController() Controller {
+	return &controller{
+		roleMgr: role.Mgr,
+		rbacMgr: rbac.Mgr,
+	}
+}
+
+type controller struct {
+	roleMgr role.Manager
+	rbacMgr rbac.Manager
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*Role, error) {
+	r, err := c.roleMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.populate(ctx, r)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.roleMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*Role, error) {
+	roles, err := c.roleMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Role
+	for _, r := range roles {
+		role, err := c.populate(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, role)
+	}
+	return result, nil
+}
+
+func (c *controller) Create(ctx context.Context, r *Role) (int64, error) {
+	if r == nil {
+		return 0, errors.BadRequestError(nil).WithMessage("cannot create a nil role")
+	}
+	var id int64
+	h := func(ctx context.Context) error {
+		r.Custom = true
+		roleID, err := c.roleMgr.Create(ctx, &r.Role)
+		if err != nil {
+			return err
+		}
+		r.ID = roleID
+		id = roleID
+		return c.createPolicies(ctx, r)
+	}
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-create-role")); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (c *controller) Update(ctx context.Context, r *Role) error {
+	if r == nil {
+		return errors.BadRequestError(nil).WithMessage("cannot update a nil role")
+	}
+	if err := c.requireCustom(ctx, r.ID); err != nil {
+		return err
+	}
+	h := func(ctx context.Context) error {
+		if err := c.roleMgr.Update(ctx, &r.Role, "name", "description"); err != nil {
+			return err
+		}
+		if err := c.rbacMgr.DeletePermissionsByRole(ctx, model.PermissionRoleType, r.ID); err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+		return c.createPolicies(ctx, r)
+	}
+	return orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-update-role"))
+}
+
+func (c *controller) Delete(ctx context.Context, id int64) error {
+	if err := c.requireCustom(ctx, id); err != nil {
+		return err
+	}
+	count, err := c.roleMgr.CountMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.PreconditionFailedError(nil).WithMessagef("the role %d is assigned to %d project members", id, count)
+	}
+	h := func(ctx context.Context) error {
+		if err := c.roleMgr.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := c.rbacMgr.DeletePermissionsByRole(ctx, model.PermissionRoleType, id); err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+		return nil
+	}
+	return orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-delete-role"))
+}
+
+func (c *controller) Exist(ctx context.Context, id int64) (bool, error) {
+	if rbac_project.IsBuiltInRole(int(id)) {
+		return true, nil
+	}
+	r, err := c.roleMgr.Get(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return r.Custom, nil
+}
+
+// requireCustom returns error if the role doesn't exist or is a built-in role
+func (c *controller) requireCustom(ctx context.Context, id int64) error {
+	r, err := c.roleMgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !r.Custom || rbac_project.IsBuiltInRole(int(r.ID)) {
+		return errors.ForbiddenError(nil).WithMessagef("the built-in role %s cannot be changed", r.Name)
+	}
+	return nil
+}
+
+func (c *controller) createPolicies(ctx context.Context, r *Role) error {
+	for _, policy := range r.Policies {
+		policyID, err := c.rbacMgr.CreateRbacPolicy(ctx, &rbac_model.PermissionPolicy{
+			Scope:    scopeAllProjects,
+			Resource: policy.Resource.String(),
+			Action:   policy.Action.String(),
+			Effect:   policy.GetEffect(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = c.rbacMgr.CreatePermission(ctx, &rbac_model.RolePermission{
+			RoleType:           model.PermissionRoleType,
+			RoleID:             r.ID,
+			PermissionPolicyID: policyID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) populate(ctx context.Context, r *model.Role) (*Role, error) {
+	role := &Role{Role: *r}
+	if !r.Custom {
+		return role, nil
+	}
+	permissions, err := c.rbacMgr.GetPermissionsByRole(ctx, model.PermissionRoleType, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range permissions {
+		role.Policies = append(role.Policies, &types.Policy{
+			Resource: types.Resource(p.Resource),
+			Action:   types.Action(p.Action),
+			Effect:   types.Effect(p.Effect),
+		})
+	}
+	return role, nil
+}
@@ -0,0 +1,127 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/rbac"
+	"github.com/goharbor/harbor/src/testing/pkg/role"
+)
+
+type ControllerTestSuite struct {
+	suite.Suite
+	roleMgr *role.Manager
+	rbacMgr *rbac.Manager
+	ctl     *controller
+	ctx     context.Context
+}
+
+func (suite *ControllerTestSuite) SetupTest() {
+	suite.roleMgr = &role.Manager{}
+	suite.rbacMgr = &rbac.Manager{}
+	suite.ctl = &controller{roleMgr: suite.roleMgr, rbacMgr: suite.rbacMgr}
+	suite.ctx = orm.NewContext(nil, &ormtesting.FakeOrmer{})
+}
+
+func (suite *ControllerTestSuite) TestGet() {
+	suite.roleMgr.On("Get", mock.Anything, int64(100)).Return(&model.Role{ID: 100, Name: "ci-publisher", Custom: true}, nil)
+	suite.rbacMgr.On("GetPermissionsByRole", mock.Anything, model.PermissionRoleType, int64(100)).Return([]*rbac_model.UniversalRolePermission{
+		{Scope: scopeAllProjects, Resource: "repository", Action: "push"},
+	}, nil)
+	r, err := suite.ctl.Get(suite.ctx, 100)
+	suite.Require().NoError(err)
+	suite.Equal("ci-publisher", r.Name)
+	suite.Require().Len(r.Policies, 1)
+	suite.Equal("push", r.Policies[0].Action.String())
+
+	// the policies of the built-in roles aren't persisted
+	suite.roleMgr.On("Get", mock.Anything, int64(1)).Return(&model.Role{ID: 1, Name: "projectAdmin"}, nil)
+	r, err = suite.ctl.Get(suite.ctx, 1)
+	suite.Require().NoError(err)
+	suite.Nil(r.Policies)
+}
+
+func (suite *ControllerTestSuite) TestCreate() {
+	suite.roleMgr.On("Create", mock.Anything, mock.Anything).Return(int64(100), nil)
+	mock.OnAnything(suite.rbacMgr, "CreateRbacPolicy").Return(int64(1), nil)
+	suite.rbacMgr.On("CreatePermission", mock.Anything, &rbac_model.RolePermission{
+		RoleType:           model.PermissionRoleType,
+		RoleID:             100,
+		PermissionPolicyID: 1,
+	}).Return(int64(1), nil)
+
+	r := &Role{
+		Role:     model.Role{Name: "ci-publisher"},
+		Policies: []*types.Policy{{Resource: "repository", Action: "push"}, {Resource: "scan", Action: "create"}},
+	}
+	id, err := suite.ctl.Create(suite.ctx, r)
+	suite.Require().NoError(err)
+	suite.Equal(int64(100), id)
+	suite.True(r.Custom)
+	suite.rbacMgr.AssertNumberOfCalls(suite.T(), "CreatePermission", 2)
+}
+
+func (suite *ControllerTestSuite) TestUpdateBuiltInRole() {
+	suite.roleMgr.On("Get", mock.Anything, int64(1)).Return(&model.Role{ID: 1, Name: "projectAdmin"}, nil)
+	err := suite.ctl.Update(suite.ctx, &Role{Role: model.Role{ID: 1, Name: "projectAdmin"}})
+	suite.Require().Error(err)
+	suite.True(errors.IsErr(err, errors.ForbiddenCode))
+}
+
+func (suite *ControllerTestSuite) TestDelete() {
+	suite.roleMgr.On("Get", mock.Anything, int64(100)).Return(&model.Role{ID: 100, Name: "ci-publisher", Custom: true}, nil)
+
+	// the role is in use
+	suite.roleMgr.On("CountMembers", mock.Anything, int64(100)).Return(int64(1), nil).Once()
+	err := suite.ctl.Delete(suite.ctx, 100)
+	suite.Require().Error(err)
+	suite.True(errors.IsErr(err, errors.PreconditionCode))
+
+	suite.roleMgr.On("CountMembers", mock.Anything, int64(100)).Return(int64(0), nil).Once()
+	suite.roleMgr.On("Delete", mock.Anything, int64(100)).Return(nil)
+	suite.rbacMgr.On("DeletePermissionsByRole", mock.Anything, model.PermissionRoleType, int64(100)).Return(nil)
+	suite.NoError(suite.ctl.Delete(suite.ctx, 100))
+}
+
+func (suite *ControllerTestSuite) TestExist() {
+	exist, err := suite.ctl.Exist(suite.ctx, 1)
+	suite.Require().NoError(err)
+	suite.True(exist)
+
+	suite.roleMgr.On("Get", mock.Anything, int64(100)).Return(&model.Role{ID: 100, Custom: true}, nil)
+	exist, err = suite.ctl.Exist(suite.ctx, 100)
+	suite.Require().NoError(err)
+	suite.True(exist)
+
+	suite.roleMgr.On("Get", mock.Anything, int64(101)).Return(nil, errors.NotFoundError(nil))
+	exist, err = suite.ctl.Exist(suite.ctx, 101)
+	suite.Require().NoError(err)
+	suite.False(exist)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &ControllerTestSuite{})
+}
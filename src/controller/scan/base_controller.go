@@ -41,6 +41,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/accessory"
 	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
 	sca "github.com/goharbor/harbor/src/pkg/scan"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
@@ -236,81 +237,112 @@ func (bc *basicController) Scan(ctx context.Context, artifact *ar.Artifact, opti
 		return errors.New("nil artifact to scan")
 	}
 
-	r, err := bc.sc.GetRegistrationByProject(ctx, artifact.ProjectID)
+	// Parse options
+	opts, err := parseOptions(options...)
+	if err != nil {
+		return errors.Wrap(err, "scan controller: scan")
+	}
+
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, artifact.ProjectID)
 	if err != nil {
 		return errors.Wrap(err, "scan controller: scan")
 	}
 
 	// In case it does not exist
-	if r == nil {
+	if len(registrations) == 0 {
 		return errors.PreconditionFailedError(nil).WithMessagef("no available scanner for project: %d", artifact.ProjectID)
 	}
 
+	// The primary scanner of the project
+	r := registrations[0]
+
 	// Check if it is disabled
 	if r.Disabled {
 		return errors.PreconditionFailedError(nil).WithMessagef("scanner %s is deactivated", r.Name)
 	}
 
-	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
-	if err != nil {
-		return err
-	}
-	// Parse options
-	opts, err := parseOptions(options...)
-	if err != nil {
-		return errors.Wrap(err, "scan controller: scan")
-	}
-
-	if !scannable {
-		if opts.FromEvent {
-			// skip to return err for event related scan
-			return nil
-		}
-		return errors.BadRequestError(nil).WithMessagef("the configured scanner %s does not support scanning artifact with mime type %s", r.Name, artifact.ManifestMediaType)
+	// All the scanners bound to the project scan the vulnerabilities in parallel,
+	// other kinds of scan are only done by the primary scanner
+	if opts.GetScanType() != v1.ScanTypeVulnerability {
+		registrations = registrations[:1]
 	}
 
 	var (
 		errs                []error
 		launchScanJobParams []*launchScanJobParam
+		placeholders        int
+		tags                = map[int64]string{}
 	)
 	handler := sca.GetScanHandler(opts.GetScanType())
-	for _, art := range artifacts {
-		reports, err := handler.MakePlaceHolder(ctx, art, r)
+	for _, reg := range registrations {
+		if reg.Disabled {
+			log.G(ctx).Debugf("skip the deactivated scanner %s for artifact %s@%s", reg.Name, artifact.RepositoryName, artifact.Digest)
+			continue
+		}
+
+		artifacts, scannable, err := bc.collectScanningArtifacts(ctx, reg, artifact)
 		if err != nil {
-			if errors.IsConflictErr(err) {
-				errs = append(errs, err)
-			} else {
-				return err
-			}
+			return err
 		}
 
-		var tag string
-		if art.Digest == artifact.Digest {
-			tag = opts.Tag
+		if !scannable {
+			if reg != r {
+				// the additional scanner does not support the artifact, it's fine to skip it
+				log.G(ctx).Debugf("skip the scanner %s which does not support artifact %s@%s", reg.Name, artifact.RepositoryName, artifact.Digest)
+				continue
+			}
+
+			if opts.FromEvent {
+				// skip to return err for event related scan
+				return nil
+			}
+			return errors.BadRequestError(nil).WithMessagef("the configured scanner %s does not support scanning artifact with mime type %s", reg.Name, artifact.ManifestMediaType)
 		}
 
-		if tag == "" {
-			latestTag, err := bc.getLatestTagOfArtifact(ctx, art.ID)
+		for _, art := range artifacts {
+			placeholders++
+
+			reports, err := handler.MakePlaceHolder(ctx, art, reg)
 			if err != nil {
-				return err
+				if errors.IsConflictErr(err) {
+					errs = append(errs, err)
+				} else {
+					return err
+				}
 			}
 
-			tag = latestTag
-		}
+			tag, ok := tags[art.ID]
+			if !ok {
+				if art.Digest == artifact.Digest {
+					tag = opts.Tag
+				}
 
-		if len(reports) > 0 {
-			launchScanJobParams = append(launchScanJobParams, &launchScanJobParam{
-				Registration: r,
-				Artifact:     art,
-				Tag:          tag,
-				Reports:      reports,
-				Type:         opts.GetScanType(),
-			})
+				if tag == "" {
+					latestTag, err := bc.getLatestTagOfArtifact(ctx, art.ID)
+					if err != nil {
+						return err
+					}
+
+					tag = latestTag
+				}
+
+				tags[art.ID] = tag
+			}
+
+			if len(reports) > 0 {
+				launchScanJobParams = append(launchScanJobParams, &launchScanJobParam{
+					Registration: reg,
+					Artifact:     art,
+					Tag:          tag,
+					Reports:      reports,
+					Type:         opts.GetScanType(),
+				})
+			}
 		}
 	}
 
 	// all report placeholder conflicted
-	if len(errs) == len(artifacts) {
+	if placeholders > 0 && len(errs) == placeholders {
 		return errs[0]
 	}
 
@@ -456,17 +488,61 @@ func (bc *basicController) isScanAllStopped(ctx context.Context, execID int64) b
 	return bc.cache().Contains(ctx, scanAllStoppedKey(execID))
 }
 
+// scanAllSummary summarizes the artifacts handled by the scan all or the stale scan.
+type scanAllSummary struct {
+	CheckedCount      int  `json:"checked_count,omitempty"`
+	TotalCount        int  `json:"total_count"`
+	SubmitCount       int  `json:"submit_count"`
+	ConflictCount     int  `json:"conflict_count"`
+	PreconditionCount int  `json:"precondition_count"`
+	UnsupportCount    int  `json:"unsupport_count"`
+	UnknowCount       int  `json:"unknow_count"`
+	BudgetExhausted   bool `json:"budget_exhausted,omitempty"`
+	// Cursor is the ID of the last artifact checked by the stale scan when the budget exhausted
+	Cursor int64 `json:"cursor,omitempty"`
+}
+
+// record counts the result of scanning one artifact
+func (s *scanAllSummary) record(err error) {
+	if err == nil {
+		s.SubmitCount++
+		return
+	}
+
+	switch errors.ErrCode(err) {
+	case errors.ConflictCode:
+		// a previous scan process is ongoing for the artifact
+		s.ConflictCount++
+	case errors.PreconditionCode:
+		// scanner not found or it's disabled
+		s.PreconditionCount++
+	case errors.BadRequestCode:
+		// artifact is unsupport
+		s.UnsupportCount++
+	default:
+		s.UnknowCount++
+	}
+}
+
+// scanArtifactInExecution scans the artifact in a separate transaction and attaches the scan job to the execution
+func (bc *basicController) scanArtifactInExecution(artifact *ar.Artifact, executionID int64) error {
+	scan := func(ctx context.Context) error {
+		return bc.Scan(ctx, artifact, WithExecutionID(executionID))
+	}
+
+	err := orm.WithTransaction(scan)(orm.SetTransactionOpNameToContext(bc.makeCtx(), "tx-start-scanall"))
+	if err != nil {
+		// Just logged
+		log.Errorf("failed to scan artifact %s, error %v", artifact, err)
+	}
+
+	return err
+}
+
 func (bc *basicController) startScanAll(ctx context.Context, executionID int64) error {
 	batchSize := 50
 
-	summary := struct {
-		TotalCount        int `json:"total_count"`
-		SubmitCount       int `json:"submit_count"`
-		ConflictCount     int `json:"conflict_count"`
-		PreconditionCount int `json:"precondition_count"`
-		UnsupportCount    int `json:"unsupport_count"`
-		UnknowCount       int `json:"unknow_count"`
-	}{}
+	summary := &scanAllSummary{}
 	// with cancel function to signal downstream worker
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -477,33 +553,14 @@ func (bc *basicController) startScanAll(ctx context.Context, executionID int64)
 		}
 
 		summary.TotalCount++
-
-		scan := func(ctx context.Context) error {
-			return bc.Scan(ctx, artifact, WithExecutionID(executionID))
-		}
-
-		if err := orm.WithTransaction(scan)(orm.SetTransactionOpNameToContext(bc.makeCtx(), "tx-start-scanall")); err != nil {
-			// Just logged
-			log.Errorf("failed to scan artifact %s, error %v", artifact, err)
-
-			switch errors.ErrCode(err) {
-			case errors.ConflictCode:
-				// a previous scan process is ongoing for the artifact
-				summary.ConflictCount++
-			case errors.PreconditionCode:
-				// scanner not found or it's disabled
-				summary.PreconditionCount++
-			case errors.BadRequestCode:
-				// artifact is unsupport
-				summary.UnsupportCount++
-			default:
-				summary.UnknowCount++
-			}
-		} else {
-			summary.SubmitCount++
-		}
+		summary.record(bc.scanArtifactInExecution(artifact, executionID))
 	}
 
+	return bc.completeScanAll(ctx, executionID, summary)
+}
+
+// completeScanAll saves the summary to the execution and marks the execution done or error when no scan job submitted
+func (bc *basicController) completeScanAll(ctx context.Context, executionID int64, summary *scanAllSummary) error {
 	exec, err := bc.execMgr.Get(ctx, executionID)
 	if err != nil {
 		return err
@@ -592,6 +649,11 @@ func (bc *basicController) GetReport(ctx context.Context, artifact *ar.Artifact,
 		return nil, errors.NotFoundError(nil).WithMessagef("no scanner registration configured for project: %d", artifact.ProjectID)
 	}
 
+	return bc.getReportByRegistration(ctx, artifact, r, mimes)
+}
+
+// getReportByRegistration returns the reports of the artifact generated by the scanner registration
+func (bc *basicController) getReportByRegistration(ctx context.Context, artifact *ar.Artifact, r *scanner.Registration, mimes []string) ([]*scan.Report, error) {
 	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
 	if err != nil {
 		return nil, err
@@ -765,37 +827,59 @@ func scanTaskForArtifacts(task *task.Task, artifactMap map[int64]any) bool {
 	return exist
 }
 
-func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artifact, allowlist allowlist.CVESet, allowlistIsExpired bool) (*Vulnerable, error) {
+func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artifact, allowlist allowlist.CVESet, allowlistIsExpired bool, options ...Option) (*Vulnerable, error) {
 	if artifact == nil {
 		return nil, errors.New("no way to get vulnerable for nil artifact")
 	}
 
+	opts, err := parseOptions(options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan controller: get vulnerable")
+	}
+
+	registrations, err := bc.sc.GetRegistrationsByProject(ctx, artifact.ProjectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan controller: get vulnerable")
+	}
+
+	if len(registrations) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessagef("no scanner registration configured for project: %d", artifact.ProjectID)
+	}
+
 	var (
-		mimeType string
-		reports  []*scan.Report
+		found      bool
+		scanStatus string
+		rps        []*vuln.Report
 	)
-	for _, m := range []string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport} {
-		rps, err := bc.GetReport(ctx, artifact, []string{m})
+	for _, r := range registrations {
+		status, rp, err := bc.getVulnerabilityReport(ctx, artifact, r)
 		if err != nil {
+			if errors.IsNotFoundErr(err) && len(registrations) > 1 {
+				// the artifact may be not supported by all the scanners
+				continue
+			}
 			return nil, err
 		}
 
-		if len(rps) == 0 {
+		if status == "" {
+			// not scanned by the scanner
 			continue
 		}
 
-		mimeType = m
-		reports = rps
-		break
-	}
+		if found {
+			scanStatus = vuln.MergeScanStatus(scanStatus, status)
+		} else {
+			scanStatus = status
+		}
+		found = true
 
-	if len(reports) == 0 {
-		return nil, errors.NotFoundError(nil).WithMessage("report not found")
+		if rp != nil {
+			rps = append(rps, rp)
+		}
 	}
 
-	scanStatus := reports[0].Status
-	for _, report := range reports {
-		scanStatus = vuln.MergeScanStatus(scanStatus, report.Status)
+	if !found {
+		return nil, errors.NotFoundError(nil).WithMessage("report not found")
 	}
 
 	vulnerable := &Vulnerable{
@@ -806,20 +890,11 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 		return vulnerable, nil
 	}
 
-	raw, err := report.Reports(reports).ResolveData(mimeType)
-	if err != nil {
-		return nil, err
-	}
-
-	if raw == nil {
+	rp := vuln.MergeScannerReports(opts.ScannerGate == proModels.ScannerGateAll, rps...)
+	if rp == nil {
 		return vulnerable, nil
 	}
 
-	rp, ok := raw.(*vuln.Report)
-	if !ok {
-		return nil, errors.Errorf("type mismatch: expect *vuln.Report but got %s", reflect.TypeOf(raw).String())
-	}
-
 	if vuls := rp.GetVulnerabilityItemList().Items(); len(vuls) > 0 {
 		vulnerable.VulnerabilitiesCount = len(vuls)
 
@@ -848,6 +923,59 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 	return vulnerable, nil
 }
 
+// getVulnerabilityReport returns the scan status and the vulnerability report of the artifact generated by the scanner registration,
+// the scan status is empty when the artifact is not scanned by the scanner,
+// the report is nil when the scan is not success or the report has no data.
+func (bc *basicController) getVulnerabilityReport(ctx context.Context, artifact *ar.Artifact, r *scanner.Registration) (string, *vuln.Report, error) {
+	var (
+		mimeType string
+		reports  []*scan.Report
+	)
+	for _, m := range []string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport} {
+		rps, err := bc.getReportByRegistration(ctx, artifact, r, []string{m})
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(rps) == 0 {
+			continue
+		}
+
+		mimeType = m
+		reports = rps
+		break
+	}
+
+	if len(reports) == 0 {
+		return "", nil, nil
+	}
+
+	scanStatus := reports[0].Status
+	for _, report := range reports {
+		scanStatus = vuln.MergeScanStatus(scanStatus, report.Status)
+	}
+
+	if scanStatus != job.SuccessStatus.String() {
+		return scanStatus, nil, nil
+	}
+
+	raw, err := report.Reports(reports).ResolveData(mimeType)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if raw == nil {
+		return scanStatus, nil, nil
+	}
+
+	rp, ok := raw.(*vuln.Report)
+	if !ok {
+		return "", nil, errors.Errorf("type mismatch: expect *vuln.Report but got %s", reflect.TypeOf(raw).String())
+	}
+
+	return scanStatus, rp, nil
+}
+
 // makeRobotAccount creates a robot account based on the arguments for scanning.
 func (bc *basicController) makeRobotAccount(ctx context.Context, projectID int64, repository string, registration *scanner.Registration, permission []*types.Policy) (*robot.Robot, error) {
 	// Use uuid as name to avoid duplicated entries.
@@ -969,6 +1097,7 @@ func (bc *basicController) launchScanJob(ctx context.Context, param *launchScanJ
 	params[sca.JobParameterRequest] = sJSON
 	params[sca.JobParameterMimes] = mimes
 	params[sca.JobParameterRobot] = robotJSON
+	params[job.ProjectIDParamKey] = param.Artifact.ProjectID
 	// because there is only one task type implementation
 	// both the vulnerability scan and generate sbom use the same job type for now
 	j := &task.Job{
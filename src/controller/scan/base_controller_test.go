@@ -151,6 +151,7 @@ func (suite *ControllerTestSuite) SetupSuite() {
 
 	sc := &scannertesting.Controller{}
 	sc.On("GetRegistrationByProject", mock.Anything, suite.artifact.ProjectID).Return(suite.registration, nil)
+	sc.On("GetRegistrationsByProject", mock.Anything, suite.artifact.ProjectID).Return([]*scanner.Registration{suite.registration}, nil)
 	sc.On("Ping", suite.registration).Return(m, nil)
 
 	mgr := &reporttesting.Manager{}
@@ -497,6 +498,71 @@ func (suite *ControllerTestSuite) TestScanControllerGetReport() {
 	assert.Equal(suite.T(), 1, len(rep))
 }
 
+// TestGetVulnerableWithMultipleScanners ...
+func (suite *ControllerTestSuite) TestGetVulnerableWithMultipleScanners() {
+	other := *suite.registration
+	other.ID, other.UUID, other.Name, other.IsDefault = 2, "uuid002", "Other", false
+
+	makeReport := func(reportUUID string, vuls ...*vuln.VulnerabilityItem) []*scan.Report {
+		data, err := json.Marshal(&vuln.Report{Vulnerabilities: vuls})
+		suite.Require().NoError(err)
+
+		return []*scan.Report{{UUID: reportUUID, Digest: suite.artifact.Digest, MimeType: v1.MimeTypeNativeReport, Report: string(data)}}
+	}
+
+	// use dedicated mocks to avoid the expectations left by the other cases
+	sc := &scannertesting.Controller{}
+	artifactCtl := &artifacttesting.Controller{}
+	taskMgr := &tasktesting.Manager{}
+	reportMgr := &reporttesting.Manager{}
+	accessoryMgr := &accessorytesting.Manager{}
+	converter := &postprocessorstesting.NativeScanReportConverter{}
+	c := *suite.c
+	c.sc, c.ar, c.taskMgr, c.manager, c.acc, c.reportConverter = sc, artifactCtl, taskMgr, reportMgr, accessoryMgr, converter
+
+	sc.On("GetRegistrationsByProject", mock.Anything, suite.artifact.ProjectID).Return([]*scanner.Registration{suite.registration, &other}, nil)
+	mock.OnAnything(accessoryMgr, "List").Return(nil, nil)
+	mock.OnAnything(artifactCtl, "HasUnscannableLayer").Return(false, nil)
+	mock.OnAnything(artifactCtl, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(suite.artifact)
+	})
+	reportMgr.On("GetBy", mock.Anything, suite.artifact.Digest, suite.registration.UUID, []string{v1.MimeTypeNativeReport}).Return(makeReport("rp-uuid-001",
+		&vuln.VulnerabilityItem{ID: "CVE-2017-8283", Package: "dpkg", Version: "1.17.27", Severity: vuln.Critical},
+		&vuln.VulnerabilityItem{ID: "CVE-2017-8284", Package: "dpkg", Version: "1.17.27", Severity: vuln.Low},
+	), nil)
+	reportMgr.On("GetBy", mock.Anything, suite.artifact.Digest, other.UUID, []string{v1.MimeTypeNativeReport}).Return(makeReport("rp-uuid-002",
+		&vuln.VulnerabilityItem{ID: "CVE-2017-8283", Package: "dpkg", Version: "1.17.27", Severity: vuln.Medium},
+		&vuln.VulnerabilityItem{ID: "CVE-2017-8285", Package: "libc", Version: "2.27", Severity: vuln.High},
+	), nil)
+	taskMgr.On("ListScanTasksByReportUUID", mock.Anything, "rp-uuid-001").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs(int64(1), "rp-uuid-001"), Status: "Success"},
+	}, nil)
+	taskMgr.On("ListScanTasksByReportUUID", mock.Anything, "rp-uuid-002").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs(int64(1), "rp-uuid-002"), Status: "Success"},
+	}, nil)
+	mock.OnAnything(converter, "FromRelationalSchema").Return(func(_ context.Context, _, _, summary string) (string, error) {
+		return summary, nil
+	})
+
+	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
+
+	// any scanner
+	vulnerable, err := c.GetVulnerable(ctx, suite.artifact, nil, false)
+	suite.Require().NoError(err)
+	suite.True(vulnerable.IsScanSuccess())
+	suite.Equal(3, vulnerable.VulnerabilitiesCount)
+	suite.Require().NotNil(vulnerable.Severity)
+	suite.Equal(vuln.Critical, *vulnerable.Severity)
+
+	// all scanners
+	vulnerable, err = c.GetVulnerable(ctx, suite.artifact, nil, false, WithScannerGate("all"))
+	suite.Require().NoError(err)
+	suite.Equal(1, vulnerable.VulnerabilitiesCount)
+	suite.Require().NotNil(vulnerable.Severity)
+	suite.Equal(vuln.Medium, *vulnerable.Severity)
+}
+
 // TestScanControllerGetScanLog ...
 func (suite *ControllerTestSuite) TestScanControllerGetScanLog() {
 	mock.OnAnything(suite.ar, "HasUnscannableLayer").Return(false, nil).Once()
@@ -34,6 +34,8 @@ import (
 const (
 	// ScanAllCallback the scheduler callback name of the scan all
 	ScanAllCallback = "scanAll"
+	// ScanStaleCallback the scheduler callback name of the stale scan
+	ScanStaleCallback = "scanStale"
 )
 
 var (
@@ -49,11 +51,23 @@ func init() {
 		log.Fatalf("failed to register the callback for the scan all schedule, error %v", err)
 	}
 
+	if err := scheduler.RegisterCallbackFunc(ScanStaleCallback, scanStaleCallback); err != nil {
+		log.Fatalf("failed to register the callback for the stale scan schedule, error %v", err)
+	}
+
+	if err := task.RegisterWorkflowLauncher(job.ScanAllVendorType, scanAllWorkflowLauncher); err != nil {
+		log.Fatalf("failed to register the workflow launcher for the scan all, error %v", err)
+	}
+
 	// NOTE: the vendor type of execution for the scan job trigger by the scan all is VendorTypeScanAll
 	if err := task.RegisterTaskStatusChangePostFunc(job.ScanAllVendorType, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan all job, error %v", err)
 	}
 
+	if err := task.RegisterTaskStatusChangePostFunc(job.ScanStaleVendorType, scanTaskStatusChange); err != nil {
+		log.Fatalf("failed to register the task status change post for the stale scan job, error %v", err)
+	}
+
 	if err := task.RegisterTaskStatusChangePostFunc(job.ImageScanJobVendorType, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan job, error %v", err)
 	}
@@ -62,6 +76,11 @@ func init() {
 	}
 }
 
+// scanAllWorkflowLauncher starts the scan all in the workflow
+func scanAllWorkflowLauncher(ctx context.Context, _ map[string]any) (int64, error) {
+	return scanCtl.ScanAll(ctx, task.ExecutionTriggerWorkflow, true)
+}
+
 func scanAllCallback(ctx context.Context, param string) error {
 	if param != "" {
 		params := make(map[string]any)
@@ -78,6 +97,23 @@ func scanAllCallback(ctx context.Context, param string) error {
 	return err
 }
 
+func scanStaleCallback(ctx context.Context, param string) error {
+	params := &struct {
+		*RescanPolicy
+		Operator string `json:"operator"`
+	}{RescanPolicy: &RescanPolicy{}}
+	if err := json.Unmarshal([]byte(param), params); err != nil {
+		return err
+	}
+
+	if params.Operator != "" {
+		ctx = context.WithValue(ctx, operator.ContextKey{}, params.Operator)
+	}
+
+	_, err := scanCtl.ScanStale(ctx, task.ExecutionTriggerSchedule, params.RescanPolicy, true)
+	return err
+}
+
 func scanTaskStatusChange(ctx context.Context, taskID int64, status string) (err error) {
 	logger := log.G(ctx).WithFields(log.Fields{"task_id": taskID, "status": status})
 
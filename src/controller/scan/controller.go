@@ -39,7 +39,7 @@ func (v *Vulnerable) IsScanSuccess() bool {
 
 // Controller provides the related operations for triggering scan.
 type Controller interface {
-	// Scan the given artifact
+	// Scan the given artifact with the scanners bound to the project of the artifact
 	//
 	//   Arguments:
 	//     ctx context.Context : the context for this method
@@ -107,6 +107,19 @@ type Controller interface {
 	//     error  : non nil error if any errors occurred
 	ScanAll(ctx context.Context, trigger string, async bool) (int64, error)
 
+	// ScanStale scans the artifacts whose reports are stale according to the rescan policy
+	//
+	//   Arguments:
+	//     ctx context.Context  : the context for this method
+	//     trigger string       : the trigger mode to start the stale scan job
+	//     policy *RescanPolicy : the policy deciding which artifacts are stale and the budget of the run
+	//     async bool           : scan the stale artifacts in background
+	//
+	//   Returns:
+	//     int64  : the id of the stale scan execution
+	//     error  : non nil error if any errors occurred
+	ScanStale(ctx context.Context, trigger string, policy *RescanPolicy, async bool) (int64, error)
+
 	// StopScanAll stops the scanAll
 	//
 	//   Arguments:
@@ -117,16 +130,18 @@ type Controller interface {
 	//     error  : non nil error if any errors occurred
 	StopScanAll(ctx context.Context, executionID int64, async bool) error
 
-	// GetVulnerable returns the vulnerable of the artifact for the allowlist
+	// GetVulnerable returns the vulnerable of the artifact for the allowlist,
+	// the reports of all the scanners bound to the project are merged according to the scanner gate option
 	//
 	//   Arguments:
 	//     ctx context.Context : the context for this method
 	//     artifact *artifact.Artifact : artifact to be scanned
 	//     allowlist map[string]struct{} : the set of CVE id of the items in the allowlist
 	//     allowlistIsExpired bool : whether the allowlist is expired
+	//     options ...Option : options for merging the reports of the scanners
 	//
 	//   Returns
 	//      *Vulnerable : the vulnerable
 	//     error        : non nil error if any errors occurred
-	GetVulnerable(ctx context.Context, artifact *artifact.Artifact, allowlist allowlist.CVESet, allowlistIsExpired bool) (*Vulnerable, error)
+	GetVulnerable(ctx context.Context, artifact *artifact.Artifact, allowlist allowlist.CVESet, allowlistIsExpired bool, options ...Option) (*Vulnerable, error)
 }
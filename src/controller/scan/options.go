@@ -22,6 +22,7 @@ type Options struct {
 	Tag         string // The tag of the artifact to scan
 	ScanType    string // The scan type could be sbom or vulnerability
 	FromEvent   bool   // indicate the current call from event or not
+	ScannerGate string // how the results of the scanners bound to the project are combined, "any" or "all"
 }
 
 // GetScanType returns the scan type. for backward compatibility, the default type is vulnerability.
@@ -72,3 +73,11 @@ func WithFromEvent(fromEvent bool) Option {
 		return nil
 	}
 }
+
+// WithScannerGate sets how the results of the scanners bound to the project are combined
+func WithScannerGate(gate string) Option {
+	return func(options *Options) error {
+		options.ScannerGate = gate
+		return nil
+	}
+}
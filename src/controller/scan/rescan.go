@@ -0,0 +1,385 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ar "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/event/operator"
+	sc "github.com/goharbor/harbor/src/controller/scanner"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+)
+
+const (
+	day = 24 * time.Hour
+
+	rescanPolicyKey = "policy"
+)
+
+// RescanPolicy decides which artifacts are rescanned by the stale scan and limits the work done in one run.
+// An artifact without any successful vulnerability report is always considered stale.
+type RescanPolicy struct {
+	// ReportAgeDays rescans the artifacts whose latest report is older than the days, 0 to disable
+	ReportAgeDays int `json:"report_age_days"`
+	// PulledWithinDays rescans the artifacts pulled within the days after their latest report, 0 to disable
+	PulledWithinDays int `json:"pulled_within_days"`
+	// OnVulnerabilityDBUpdate rescans the artifacts whose latest report predates the vulnerability database of the scanner
+	OnVulnerabilityDBUpdate bool `json:"on_vulnerability_db_update"`
+	// MaxArtifacts is the max count of artifacts submitted in one run, 0 for no limit
+	MaxArtifacts int `json:"max_artifacts"`
+	// MaxDuration is the max duration in seconds of one run, 0 for no limit
+	MaxDuration int64 `json:"max_duration"`
+}
+
+// NewRescanPolicy parses the rescan policy from the parameters
+func NewRescanPolicy(params map[string]any) (*RescanPolicy, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &RescanPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid rescan policy")
+	}
+
+	return policy, policy.Validate()
+}
+
+// Validate the rescan policy
+func (p *RescanPolicy) Validate() error {
+	if p.ReportAgeDays < 0 || p.PulledWithinDays < 0 || p.MaxArtifacts < 0 || p.MaxDuration < 0 {
+		return errors.BadRequestError(nil).WithMessage("the values of the rescan policy must not be negative")
+	}
+
+	if p.ReportAgeDays == 0 && p.PulledWithinDays == 0 && !p.OnVulnerabilityDBUpdate {
+		return errors.BadRequestError(nil).WithMessage("at least one of report_age_days, pulled_within_days and on_vulnerability_db_update is required for the rescan policy")
+	}
+
+	return nil
+}
+
+// ToParams converts the rescan policy to the parameters
+func (p *RescanPolicy) ToParams() map[string]any {
+	return map[string]any{
+		"report_age_days":            p.ReportAgeDays,
+		"pulled_within_days":         p.PulledWithinDays,
+		"on_vulnerability_db_update": p.OnVulnerabilityDBUpdate,
+		"max_artifacts":              p.MaxArtifacts,
+		"max_duration":               p.MaxDuration,
+	}
+}
+
+// budgetExhausted returns true when the run reaches the max artifacts or the max duration
+func (p *RescanPolicy) budgetExhausted(submitted int, startTime, now time.Time) bool {
+	if p.MaxArtifacts > 0 && submitted >= p.MaxArtifacts {
+		return true
+	}
+
+	return p.MaxDuration > 0 && now.Sub(startTime) >= time.Duration(p.MaxDuration)*time.Second
+}
+
+// isStale returns true when the artifact scanned at the time is stale according to the policy
+func (p *RescanPolicy) isStale(artifact *ar.Artifact, scannedAt, vulnDBUpdatedAt, now time.Time) bool {
+	if scannedAt.IsZero() {
+		return true
+	}
+
+	if p.ReportAgeDays > 0 && now.Sub(scannedAt) >= time.Duration(p.ReportAgeDays)*day {
+		return true
+	}
+
+	if p.PulledWithinDays > 0 && artifact.PullTime.After(scannedAt) &&
+		now.Sub(artifact.PullTime) <= time.Duration(p.PulledWithinDays)*day {
+		return true
+	}
+
+	return p.OnVulnerabilityDBUpdate && vulnDBUpdatedAt.After(scannedAt)
+}
+
+// ScanStale ...
+func (bc *basicController) ScanStale(ctx context.Context, trigger string, policy *RescanPolicy, async bool) (int64, error) {
+	if policy == nil {
+		return 0, errors.BadRequestError(nil).WithMessage("no rescan policy provided")
+	}
+	if err := policy.Validate(); err != nil {
+		return 0, err
+	}
+
+	// continue from the artifact where the previous run stopped, so the artifacts rescanned every run
+	// e.g. the ones keep failing to scan won't prevent the others from being reached
+	cursor, err := bc.staleScanCursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	extra := map[string]any{rescanPolicyKey: policy.ToParams()}
+	if op := operator.FromContext(ctx); op != "" {
+		extra["operator"] = op
+	}
+	executionID, err := bc.execMgr.Create(ctx, job.ScanStaleVendorType, 0, trigger, extra)
+	if err != nil {
+		return 0, err
+	}
+
+	if async {
+		go func(ctx context.Context) {
+			// if async, this is running in another goroutine ensure the execution exists in db
+			err := retry.Retry(func() error {
+				_, err := bc.execMgr.Get(ctx, executionID)
+				return err
+			})
+			if err != nil {
+				log.Errorf("failed to get the execution %d for the stale scan", executionID)
+				return
+			}
+
+			err = bc.startScanStale(ctx, executionID, policy, cursor)
+			if err != nil {
+				log.Errorf("failed to start stale scan, executionID=%d, error: %v", executionID, err)
+			}
+		}(bc.makeCtx())
+	} else {
+		if err := bc.startScanStale(ctx, executionID, policy, cursor); err != nil {
+			return 0, err
+		}
+	}
+
+	return executionID, nil
+}
+
+// staleScanCursor returns the ID of the last artifact checked by the previous stale scan which exhausted its budget,
+// 0 is returned if the previous stale scan went through all the artifacts.
+func (bc *basicController) staleScanCursor(ctx context.Context) (int64, error) {
+	executions, err := bc.execMgr.List(ctx, &q.Query{
+		Keywords:   map[string]any{"VendorType": job.ScanStaleVendorType},
+		Sorts:      []*q.Sort{q.NewSort("StartTime", true)},
+		PageNumber: 1,
+		PageSize:   1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(executions) == 0 {
+		return 0, nil
+	}
+
+	summary, ok := executions[0].ExtraAttrs["summary"].(map[string]any)
+	if !ok {
+		return 0, nil
+	}
+	// the number is json.Number as the extra attributes are decoded with UseNumber
+	switch cursor := summary["cursor"].(type) {
+	case json.Number:
+		return cursor.Int64()
+	case float64:
+		return int64(cursor), nil
+	}
+
+	return 0, nil
+}
+
+func (bc *basicController) startScanStale(ctx context.Context, executionID int64, policy *RescanPolicy, cursor int64) error {
+	batchSize := 50
+
+	summary := &scanAllSummary{}
+	// with cancel function to signal downstream worker
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	query := &q.Query{
+		Keywords: map[string]any{"id": &q.Range{Min: cursor + 1}},
+		Sorts:    []*q.Sort{q.NewSort("id", false)},
+	}
+	scanners := newStaleScanners(bc.sc)
+	startTime := time.Now()
+	lastCheckedID := cursor
+	for artifact := range ar.Iterator(ctx, batchSize, query, nil) {
+		// the stale scan shares the stop mark with the scan all as the mark is keyed by the execution
+		if bc.isScanAllStopped(ctx, executionID) {
+			return errScanAllStopped
+		}
+
+		if policy.budgetExhausted(summary.SubmitCount, startTime, time.Now()) {
+			// the left stale artifacts will be picked up by the next run which starts after the cursor
+			summary.BudgetExhausted = true
+			summary.Cursor = lastCheckedID
+			break
+		}
+
+		summary.CheckedCount++
+		lastCheckedID = artifact.ID
+
+		stale, err := bc.isArtifactStale(ctx, artifact, policy, scanners)
+		if err != nil {
+			log.Errorf("failed to check whether the report of artifact %s is stale, error %v", artifact, err)
+			summary.TotalCount++
+			summary.UnknowCount++
+			continue
+		}
+
+		if !stale {
+			continue
+		}
+
+		summary.TotalCount++
+		summary.record(bc.scanArtifactInExecution(artifact, executionID))
+	}
+
+	return bc.completeScanAll(ctx, executionID, summary)
+}
+
+// staleScanners looks up the scanners of the projects once in a stale scan run,
+// the scanners shared by the projects are pinged only once for their metadata.
+type staleScanners struct {
+	sc       sc.Controller
+	projects map[int64][]*scanner.Registration
+	// key is the UUID of the scanner
+	metadata map[string]*v1.ScannerAdapterMetadata
+}
+
+func newStaleScanners(ctl sc.Controller) *staleScanners {
+	return &staleScanners{
+		sc:       ctl,
+		projects: map[int64][]*scanner.Registration{},
+		metadata: map[string]*v1.ScannerAdapterMetadata{},
+	}
+}
+
+// get the scanners of the project, the primary one first
+func (s *staleScanners) get(ctx context.Context, projectID int64) ([]*scanner.Registration, error) {
+	if registrations, ok := s.projects[projectID]; ok {
+		return registrations, nil
+	}
+
+	registrations, err := s.sc.GetRegistrationsByProject(ctx, projectID, sc.WithPing(false))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range registrations {
+		if r.Disabled {
+			continue
+		}
+
+		meta, ok := s.metadata[r.UUID]
+		if !ok {
+			// the unreachable scanner is not pinged again in the run, it supports nothing without the metadata
+			if meta, err = s.sc.Ping(ctx, r); err != nil {
+				log.G(ctx).Warningf("failed to ping scanner %s, error: %v", r.Name, err)
+			}
+			s.metadata[r.UUID] = meta
+		}
+		r.Metadata = meta
+	}
+	s.projects[projectID] = registrations
+
+	return registrations, nil
+}
+
+// isArtifactStale checks the latest vulnerability reports of the artifact against the rescan policy,
+// the artifact is stale when the reports of any scanner of the project are stale as they scan it together.
+func (bc *basicController) isArtifactStale(ctx context.Context, artifact *ar.Artifact, policy *RescanPolicy, scanners *staleScanners) (bool, error) {
+	registrations, err := scanners.get(ctx, artifact.ProjectID)
+	if err != nil {
+		return false, err
+	}
+
+	if len(registrations) == 0 || registrations[0].Disabled {
+		// let the scan report the scanner is not found or deactivated
+		return true, nil
+	}
+
+	now := time.Now()
+	for _, r := range registrations {
+		if r.Disabled {
+			continue
+		}
+
+		artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
+		if err != nil {
+			return false, err
+		}
+
+		if !scannable {
+			// never scannable by the scanner, skip it instead of rescanning it every run
+			continue
+		}
+
+		scannedAt, err := bc.lastScannedAt(ctx, r, artifacts)
+		if err != nil {
+			return false, err
+		}
+
+		var vulnDBUpdatedAt time.Time
+		if r.Metadata != nil {
+			vulnDBUpdatedAt = r.Metadata.VulnerabilityDBUpdatedAt()
+		}
+
+		if policy.isStale(artifact, scannedAt, vulnDBUpdatedAt, now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// lastScannedAt returns the earliest end time of the latest successful vulnerability scans of the artifacts,
+// the zero time is returned when any of the artifacts has no successful vulnerability report.
+func (bc *basicController) lastScannedAt(ctx context.Context, r *scanner.Registration, artifacts []*ar.Artifact) (time.Time, error) {
+	var scannedAt time.Time
+	for _, a := range artifacts {
+		reports, err := bc.manager.GetBy(ctx, a.Digest, r.UUID, []string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport})
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		reportUUIDs := make([]string, 0, len(reports))
+		for _, report := range reports {
+			reportUUIDs = append(reportUUIDs, report.UUID)
+		}
+
+		tasks, err := bc.listScanTasks(ctx, reportUUIDs)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		var endTime time.Time
+		for _, t := range tasks {
+			if t.Status == job.SuccessStatus.String() && t.EndTime.After(endTime) {
+				endTime = t.EndTime
+			}
+		}
+
+		if endTime.IsZero() {
+			return time.Time{}, nil
+		}
+
+		if scannedAt.IsZero() || endTime.Before(scannedAt) {
+			scannedAt = endTime
+		}
+	}
+
+	return scannedAt, nil
+}
@@ -0,0 +1,201 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	libcache "github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	art "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/task"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	scannertesting "github.com/goharbor/harbor/src/testing/controller/scanner"
+	mockcache "github.com/goharbor/harbor/src/testing/lib/cache"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	reporttesting "github.com/goharbor/harbor/src/testing/pkg/scan/report"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+func TestNewRescanPolicy(t *testing.T) {
+	_, err := NewRescanPolicy(nil)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = NewRescanPolicy(map[string]any{"report_age_days": -1})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = NewRescanPolicy(map[string]any{"report_age_days": "seven"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	policy, err := NewRescanPolicy(map[string]any{"report_age_days": json.Number("7"), "max_artifacts": 100})
+	assert.NoError(t, err)
+	assert.Equal(t, &RescanPolicy{ReportAgeDays: 7, MaxArtifacts: 100}, policy)
+
+	parsed, err := NewRescanPolicy(policy.ToParams())
+	assert.NoError(t, err)
+	assert.Equal(t, policy, parsed)
+}
+
+func TestRescanPolicyBudgetExhausted(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, (&RescanPolicy{}).budgetExhausted(1000, now.Add(-time.Hour), now))
+	assert.False(t, (&RescanPolicy{MaxArtifacts: 10}).budgetExhausted(9, now, now))
+	assert.True(t, (&RescanPolicy{MaxArtifacts: 10}).budgetExhausted(10, now, now))
+	assert.False(t, (&RescanPolicy{MaxDuration: 60}).budgetExhausted(0, now.Add(-time.Second), now))
+	assert.True(t, (&RescanPolicy{MaxDuration: 60}).budgetExhausted(0, now.Add(-time.Minute), now))
+}
+
+func TestRescanPolicyIsStale(t *testing.T) {
+	now := time.Now()
+	a := &artifact.Artifact{}
+
+	// never scanned
+	assert.True(t, (&RescanPolicy{ReportAgeDays: 7}).isStale(a, time.Time{}, time.Time{}, now))
+
+	// report age
+	assert.False(t, (&RescanPolicy{ReportAgeDays: 7}).isStale(a, now.Add(-6*day), time.Time{}, now))
+	assert.True(t, (&RescanPolicy{ReportAgeDays: 7}).isStale(a, now.Add(-7*day), time.Time{}, now))
+
+	// pulled after the latest report
+	a.PullTime = now.Add(-2 * day)
+	assert.True(t, (&RescanPolicy{PulledWithinDays: 3}).isStale(a, now.Add(-4*day), time.Time{}, now))
+	assert.False(t, (&RescanPolicy{PulledWithinDays: 1}).isStale(a, now.Add(-4*day), time.Time{}, now))
+	assert.False(t, (&RescanPolicy{PulledWithinDays: 3}).isStale(a, now.Add(-day), time.Time{}, now))
+
+	// vulnerability database updated after the latest report
+	assert.True(t, (&RescanPolicy{OnVulnerabilityDBUpdate: true}).isStale(a, now.Add(-day), now.Add(-time.Hour), now))
+	assert.False(t, (&RescanPolicy{OnVulnerabilityDBUpdate: true}).isStale(a, now.Add(-day), now.Add(-2*day), now))
+	assert.False(t, (&RescanPolicy{ReportAgeDays: 7}).isStale(a, now.Add(-day), now.Add(-time.Hour), now))
+}
+
+func (suite *ControllerTestSuite) TestScanStale() {
+	{
+		// invalid policy
+		_, err := suite.c.ScanStale(context.TODO(), "SCHEDULE", &RescanPolicy{}, false)
+		suite.Error(err)
+	}
+
+	{
+		// fresh artifacts are skipped, the scanners of the project are looked up and pinged once in the run
+		executionID := int64(2)
+		fresh := &artifact.Artifact{Artifact: art.Artifact{ID: 1, ProjectID: 1, Digest: suite.artifact.Digest, Type: suite.artifact.Type}}
+		fresh.ManifestMediaType = suite.artifact.ManifestMediaType
+		another := &artifact.Artifact{Artifact: art.Artifact{ID: 2, ProjectID: 1, Digest: suite.artifact.Digest, Type: suite.artifact.Type}}
+		another.ManifestMediaType = suite.artifact.ManifestMediaType
+
+		// use dedicated mocks to avoid the expectations left by the other cases
+		artifactCtl := &artifacttesting.Controller{}
+		originalArtifactCtl := artifact.Ctl
+		artifact.Ctl = artifactCtl
+		defer func() { artifact.Ctl = originalArtifactCtl }()
+
+		execMgr := &tasktesting.ExecutionManager{}
+		taskMgr := &tasktesting.Manager{}
+		reportMgr := &reporttesting.Manager{}
+		accessoryMgr := &accessorytesting.Manager{}
+		cache := &mockcache.Cache{}
+		scannerCtl := &scannertesting.Controller{}
+		c := *suite.c
+		c.ar, c.execMgr, c.taskMgr, c.manager, c.acc, c.sc = artifactCtl, execMgr, taskMgr, reportMgr, accessoryMgr, scannerCtl
+		c.cache = func() libcache.Cache { return cache }
+
+		primary := *suite.registration
+		primary.Metadata = nil
+		unreachable := &scanner.Registration{ID: 2, UUID: "uuid002", Name: "unreachable"}
+		scannerCtl.On("GetRegistrationsByProject", mock.Anything, int64(1), mock.Anything).Return([]*scanner.Registration{&primary, unreachable}, nil).Once()
+		scannerCtl.On("Ping", mock.Anything, &primary).Return(suite.registration.Metadata, nil).Once()
+		scannerCtl.On("Ping", mock.Anything, unreachable).Return(nil, errors.New("unreachable")).Once()
+
+		mock.OnAnything(execMgr, "List").Return(nil, nil).Once()
+		execMgr.On("Create", mock.Anything, "SCAN_STALE", int64(0), "SCHEDULE", mock.Anything).Return(executionID, nil).Once()
+		execMgr.On("Get", mock.Anything, executionID).Return(&task.Execution{ID: executionID}, nil).Once()
+		mock.OnAnything(cache, "Contains").Return(false)
+
+		mock.OnAnything(artifactCtl, "List").Return([]*artifact.Artifact{fresh, another}, nil).Once()
+		mock.OnAnything(accessoryMgr, "List").Return(nil, nil)
+		mock.OnAnything(artifactCtl, "HasUnscannableLayer").Return(false, nil)
+		mock.OnAnything(artifactCtl, "Walk").Return(nil).Run(func(args mock.Arguments) {
+			walkFn := args.Get(2).(func(*artifact.Artifact) error)
+			walkFn(args.Get(1).(*artifact.Artifact))
+		})
+		reportMgr.On("GetBy", mock.Anything, fresh.Digest, suite.registration.UUID, mock.Anything).Return(
+			[]*scan.Report{{UUID: "rp-uuid-001", Digest: fresh.Digest}}, nil).Twice()
+		mock.OnAnything(taskMgr, "ListScanTasksByReportUUID").Return([]*task.Task{
+			{ExtraAttrs: suite.makeExtraAttrs(int64(1), "rp-uuid-001"), Status: "Success", EndTime: time.Now()},
+		}, nil).Twice()
+
+		mock.OnAnything(execMgr, "UpdateExtraAttrs").Return(nil).Run(func(args mock.Arguments) {
+			summary := args.Get(2).(map[string]any)["summary"].(*scanAllSummary)
+			suite.Equal(2, summary.CheckedCount)
+			suite.Equal(0, summary.TotalCount)
+		}).Once()
+		execMgr.On("MarkDone", mock.Anything, executionID, "no artifact found").Return(nil).Once()
+
+		_, err := c.ScanStale(context.TODO(), "SCHEDULE", &RescanPolicy{ReportAgeDays: 7}, false)
+		suite.NoError(err)
+		execMgr.AssertExpectations(suite.T())
+		scannerCtl.AssertExpectations(suite.T())
+		reportMgr.AssertExpectations(suite.T())
+	}
+
+	{
+		// continue from the cursor of the previous run and reset the cursor after going through all the artifacts
+		executionID := int64(3)
+		artifactCtl := &artifacttesting.Controller{}
+		originalArtifactCtl := artifact.Ctl
+		artifact.Ctl = artifactCtl
+		defer func() { artifact.Ctl = originalArtifactCtl }()
+
+		execMgr := &tasktesting.ExecutionManager{}
+		cache := &mockcache.Cache{}
+		c := *suite.c
+		c.ar, c.execMgr = artifactCtl, execMgr
+		c.cache = func() libcache.Cache { return cache }
+
+		previous := &task.Execution{ID: 1, ExtraAttrs: map[string]any{
+			"summary": map[string]any{"budget_exhausted": true, "cursor": json.Number("5")},
+		}}
+		mock.OnAnything(execMgr, "List").Return([]*task.Execution{previous}, nil).Once()
+		execMgr.On("Create", mock.Anything, "SCAN_STALE", int64(0), "SCHEDULE", mock.Anything).Return(executionID, nil).Once()
+		execMgr.On("Get", mock.Anything, executionID).Return(&task.Execution{ID: executionID}, nil).Once()
+		mock.OnAnything(cache, "Contains").Return(false)
+
+		mock.OnAnything(artifactCtl, "List").Return(nil, nil).Run(func(args mock.Arguments) {
+			query := args.Get(1).(*q.Query)
+			suite.Equal(int64(6), query.Keywords["id"].(*q.Range).Min)
+		}).Once()
+		mock.OnAnything(execMgr, "UpdateExtraAttrs").Return(nil).Run(func(args mock.Arguments) {
+			summary := args.Get(2).(map[string]any)["summary"].(*scanAllSummary)
+			suite.False(summary.BudgetExhausted)
+			suite.Equal(int64(0), summary.Cursor)
+		}).Once()
+		execMgr.On("MarkDone", mock.Anything, executionID, "no artifact found").Return(nil).Once()
+
+		_, err := c.ScanStale(context.TODO(), "SCHEDULE", &RescanPolicy{ReportAgeDays: 7, MaxArtifacts: 10}, false)
+		suite.NoError(err)
+		execMgr.AssertExpectations(suite.T())
+	}
+}
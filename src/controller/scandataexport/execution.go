@@ -125,10 +125,14 @@ func (c *controller) DeleteExecution(ctx context.Context, executionID int64) err
 func (c *controller) Start(ctx context.Context, request export.Request) (executionID int64, err error) {
 	logger := log.GetLogger(ctx)
 	vendorID := int64(ctx.Value(export.CsvJobVendorIDKey).(int))
+	if len(request.Format) == 0 {
+		request.Format = export.FormatCSV
+	}
 	extraAttrs := make(map[string]any)
 	extraAttrs[export.ProjectIDsAttribute] = request.Projects
 	extraAttrs[export.JobNameAttribute] = request.JobName
 	extraAttrs[export.UserNameAttribute] = request.UserName
+	extraAttrs[export.FormatAttribute] = request.Format
 	id, err := c.execMgr.Create(ctx, job.ScanDataExportVendorType, vendorID, task.ExecutionTriggerManual, extraAttrs)
 	logger.Infof("Created an execution record with id : %d for vendorID: %d", id, vendorID)
 	if err != nil {
@@ -204,21 +208,26 @@ func (c *controller) convertToExportExecStatus(ctx context.Context, exec *task.E
 	if statusMessage, ok := exec.ExtraAttrs[export.StatusMessageAttribute]; ok {
 		execStatus.StatusMessage = statusMessage.(string)
 	}
+	// the executions created before supporting formats are exported as CSV
+	execStatus.Format = export.FormatCSV
+	if format, ok := exec.ExtraAttrs[export.FormatAttribute].(string); ok && len(format) > 0 {
+		execStatus.Format = format
+	}
 
 	if len(execStatus.ExportDataDigest) > 0 {
-		artifactExists := c.isCsvArtifactPresent(ctx, exec.ID, execStatus.ExportDataDigest)
+		artifactExists := c.isArtifactPresent(ctx, exec.ID, execStatus.ExportDataDigest)
 		execStatus.FilePresent = artifactExists
 	}
 
 	return execStatus
 }
 
-func (c *controller) isCsvArtifactPresent(ctx context.Context, execID int64, digest string) bool {
+func (c *controller) isArtifactPresent(ctx context.Context, execID int64, digest string) bool {
 	logger := log.GetLogger(ctx)
 	repositoryName := fmt.Sprintf("scandata_export_%v", execID)
 	exists, err := c.sysArtifactMgr.Exists(ctx, strings.ToLower(export.Vendor), repositoryName, digest)
 	if err != nil {
-		logger.Errorf("failed to check existence of csv artifact for vendor: %s repository: %s digest: %s",
+		logger.Errorf("failed to check existence of export artifact for vendor: %s repository: %s digest: %s",
 			strings.ToLower(export.Vendor), repositoryName, digest)
 		exists = false
 	}
@@ -123,6 +123,7 @@ func (suite *ScanDataExportExecutionTestSuite) TestGetExecution() {
 		suite.Equal("test-job", exportExec.JobName)
 		suite.Equal("test-message", exportExec.StatusMessage)
 		suite.Equal(true, exportExec.FilePresent)
+		suite.Equal(export.FormatCSV, exportExec.Format)
 	}
 
 	// get execution fails
@@ -247,6 +248,7 @@ func (suite *ScanDataExportExecutionTestSuite) TestStart() {
 		attrs[export.ProjectIDsAttribute] = []int64{1}
 		attrs[export.JobNameAttribute] = "test-job"
 		attrs[export.UserNameAttribute] = "test-user"
+		attrs[export.FormatAttribute] = export.FormatCSV
 		suite.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, attrs).Return(int64(10), nil)
 		suite.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(20), nil)
 		ctx := context.Background()
@@ -311,6 +313,7 @@ func (suite *ScanDataExportExecutionTestSuite) TestStartWithTaskManagerError() {
 		attrs[export.ProjectIDsAttribute] = []int64{1}
 		attrs[export.JobNameAttribute] = "test-job"
 		attrs[export.UserNameAttribute] = "test-user"
+		attrs[export.FormatAttribute] = export.FormatCSV
 		suite.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, attrs).Return(int64(10), nil)
 		suite.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(-1), errors.New("Test Error"))
 		mock.OnAnything(suite.execMgr, "StopAndWait").Return(nil)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,8 +37,10 @@ import (
 
 const (
 	proScannerMetaKey = "projectScanner"
-	StatusUnhealthy   = "unhealthy"
-	StatusHealthy     = "healthy"
+	// registrationIDsSeparator separates the UUIDs of the scanners bound to a project, the first one is the primary scanner
+	registrationIDsSeparator = ","
+	StatusUnhealthy          = "unhealthy"
+	StatusHealthy            = "healthy"
 	// RetrieveCapFailMsg the message indicate failed to retrieve the scanner capabilities
 	RetrieveCapFailMsg = "failed to retrieve scanner capabilities, error %v"
 )
@@ -199,7 +202,8 @@ func (bc *basicController) SetDefaultRegistration(ctx context.Context, registrat
 	return bc.manager.SetAsDefault(ctx, registrationUUID)
 }
 
-// SetRegistrationByProject ...
+// SetRegistrationByProject replaces the primary scanner of the given project,
+// the additional scanners bound to the project are kept.
 func (bc *basicController) SetRegistrationByProject(ctx context.Context, projectID int64, registrationID string) error {
 	if projectID == 0 {
 		return errors.New("invalid project ID")
@@ -209,27 +213,71 @@ func (bc *basicController) SetRegistrationByProject(ctx context.Context, project
 		return errors.New("missing scanner UUID")
 	}
 
-	// Only keep the UUID in the metadata of the given project
-	// Scanner metadata existing?
 	m, err := bc.proMetaMgr.Get(ctx, projectID, proScannerMetaKey)
 	if err != nil {
 		return errors.Wrap(err, "api controller: set project scanner")
 	}
 
+	registrationIDs := []string{registrationID}
+	if existing := parseRegistrationIDs(m[proScannerMetaKey]); len(existing) > 1 {
+		for _, id := range existing[1:] {
+			if id != registrationID {
+				registrationIDs = append(registrationIDs, id)
+			}
+		}
+	}
+
+	return bc.SetRegistrationsByProject(ctx, projectID, registrationIDs)
+}
+
+// SetRegistrationsByProject ...
+func (bc *basicController) SetRegistrationsByProject(ctx context.Context, projectID int64, registrationIDs []string) error {
+	if projectID == 0 {
+		return errors.New("invalid project ID")
+	}
+
+	if len(registrationIDs) == 0 {
+		return errors.New("missing scanner UUID")
+	}
+
+	ids := make([]string, 0, len(registrationIDs))
+	for _, id := range registrationIDs {
+		if len(id) == 0 {
+			return errors.New("missing scanner UUID")
+		}
+
+		if strings.Contains(id, registrationIDsSeparator) {
+			return errors.Errorf("invalid scanner UUID %s", id)
+		}
+
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	value := strings.Join(ids, registrationIDsSeparator)
+
+	// Only keep the UUIDs in the metadata of the given project
+	// Scanner metadata existing?
+	m, err := bc.proMetaMgr.Get(ctx, projectID, proScannerMetaKey)
+	if err != nil {
+		return errors.Wrap(err, "api controller: set project scanners")
+	}
+
 	// Update if exists
 	if len(m) > 0 {
 		// Compare and set new
-		if registrationID != m[proScannerMetaKey] {
-			m[proScannerMetaKey] = registrationID
+		if value != m[proScannerMetaKey] {
+			m[proScannerMetaKey] = value
 			if err := bc.proMetaMgr.Update(ctx, projectID, m); err != nil {
-				return errors.Wrap(err, "api controller: set project scanner")
+				return errors.Wrap(err, "api controller: set project scanners")
 			}
 		}
 	} else {
 		meta := make(map[string]string, 1)
-		meta[proScannerMetaKey] = registrationID
+		meta[proScannerMetaKey] = value
 		if err := bc.proMetaMgr.Add(ctx, projectID, meta); err != nil {
-			return errors.Wrap(err, "api controller: set project scanner")
+			return errors.Wrap(err, "api controller: set project scanners")
 		}
 	}
 
@@ -238,68 +286,125 @@ func (bc *basicController) SetRegistrationByProject(ctx context.Context, project
 
 // GetRegistrationByProject ...
 func (bc *basicController) GetRegistrationByProject(ctx context.Context, projectID int64, options ...Option) (*scanner.Registration, error) {
+	registrations, err := bc.getRegistrationsByProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "api controller: get project scanner")
+	}
+
+	// No scanner configured
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+
+	registration := registrations[0]
+	bc.fillRegistration(ctx, registration, newOptions(options...))
+
+	return registration, nil
+}
+
+// GetRegistrationsByProject ...
+func (bc *basicController) GetRegistrationsByProject(ctx context.Context, projectID int64, options ...Option) ([]*scanner.Registration, error) {
+	registrations, err := bc.getRegistrationsByProject(ctx, projectID)
+	if err != nil {
+		return nil, errors.Wrap(err, "api controller: get project scanners")
+	}
+
+	opts := newOptions(options...)
+	for _, registration := range registrations {
+		bc.fillRegistration(ctx, registration, opts)
+	}
+
+	return registrations, nil
+}
+
+// getRegistrationsByProject returns the scanner registrations bound to the project, the primary one first,
+// or the system default registration when nothing is bound to the project.
+func (bc *basicController) getRegistrationsByProject(ctx context.Context, projectID int64) ([]*scanner.Registration, error) {
 	if projectID == 0 {
 		return nil, errors.New("invalid project ID")
 	}
 
-	// First, get it from the project metadata
+	// First, get them from the project metadata
 	m, err := bc.proMetaMgr.Get(ctx, projectID, proScannerMetaKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "api controller: get project scanner")
+		return nil, err
 	}
 
-	var registration *scanner.Registration
-	if len(m) > 0 {
-		if registrationID, ok := m[proScannerMetaKey]; ok && len(registrationID) > 0 {
-			registration, err = bc.manager.Get(ctx, registrationID)
-			if err != nil {
-				return nil, errors.Wrap(err, "api controller: get project scanner")
+	var (
+		registrations []*scanner.Registration
+		pruned        bool
+	)
+	ids := parseRegistrationIDs(m[proScannerMetaKey])
+	for _, id := range ids {
+		registration, err := bc.manager.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if registration == nil {
+			// Not found
+			// Might be deleted by the admin, the project scanner ID reference should be cleared
+			pruned = true
+			continue
+		}
+
+		registrations = append(registrations, registration)
+	}
+
+	if pruned {
+		if len(registrations) == 0 {
+			if err := bc.proMetaMgr.Delete(ctx, projectID, proScannerMetaKey); err != nil {
+				return nil, err
+			}
+		} else {
+			kept := make([]string, len(registrations))
+			for i, registration := range registrations {
+				kept[i] = registration.UUID
 			}
 
-			if registration == nil {
-				// Not found
-				// Might be deleted by the admin, the project scanner ID reference should be cleared
-				if err := bc.proMetaMgr.Delete(ctx, projectID, proScannerMetaKey); err != nil {
-					return nil, errors.Wrap(err, "api controller: get project scanner")
-				}
+			m[proScannerMetaKey] = strings.Join(kept, registrationIDsSeparator)
+			if err := bc.proMetaMgr.Update(ctx, projectID, m); err != nil {
+				return nil, err
 			}
 		}
 	}
 
-	if registration == nil {
+	if len(registrations) == 0 {
 		// Second, get the default one
-		registration, err = bc.manager.GetDefault(ctx)
+		registration, err := bc.manager.GetDefault(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "api controller: get project scanner")
+			return nil, err
 		}
-	}
 
-	// No scanner configured
-	if registration == nil {
-		return nil, nil
+		if registration != nil {
+			registrations = append(registrations, registration)
+		}
 	}
 
-	opts := newOptions(options...)
-
-	if opts.Ping {
-		// Get metadata of the configured registration
-		meta, err := bc.Ping(ctx, registration)
-		if err != nil {
-			// Not blocked, just logged it
-			log.Error(errors.Wrap(err, "api controller: get project scanner"))
-			registration.Health = StatusUnhealthy
-		} else {
-			registration.Health = StatusHealthy
-			// Fill in some metadata
-			registration.Adapter = meta.Scanner.Name
-			registration.Vendor = meta.Scanner.Vendor
-			registration.Version = meta.Scanner.Version
+	return registrations, nil
+}
 
-			registration.Metadata = meta
-		}
+// fillRegistration fills the health and metadata of the registration according to the options
+func (bc *basicController) fillRegistration(ctx context.Context, registration *scanner.Registration, opts *Options) {
+	if !opts.Ping {
+		return
 	}
 
-	return registration, nil
+	// Get metadata of the configured registration
+	meta, err := bc.Ping(ctx, registration)
+	if err != nil {
+		// Not blocked, just logged it
+		log.Error(errors.Wrap(err, "api controller: get project scanner"))
+		registration.Health = StatusUnhealthy
+	} else {
+		registration.Health = StatusHealthy
+		// Fill in some metadata
+		registration.Adapter = meta.Scanner.Name
+		registration.Vendor = meta.Scanner.Vendor
+		registration.Version = meta.Scanner.Version
+
+		registration.Metadata = meta
+	}
 }
 
 // Ping ...
@@ -383,6 +488,18 @@ var (
 	reservedNames = []string{"Trivy"}
 )
 
+// parseRegistrationIDs parses the UUIDs of the scanners bound to a project from the metadata value
+func parseRegistrationIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, registrationIDsSeparator) {
+		if id = strings.TrimSpace(id); len(id) > 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
 func isReservedName(name string) bool {
 	return slices.Contains(reservedNames, name)
 }
@@ -205,6 +205,63 @@ func (suite *ControllerTestSuite) TestSetRegistrationByProject() {
 	require.NoError(suite.T(), err)
 }
 
+// TestSetRegistrationsByProject tests SetRegistrationsByProject
+func (suite *ControllerTestSuite) TestSetRegistrationsByProject() {
+	var pid, pid2 int64 = 1, 2
+
+	err := suite.c.SetRegistrationsByProject(context.TODO(), pid, nil)
+	require.Error(suite.T(), err)
+
+	err = suite.c.SetRegistrationsByProject(context.TODO(), pid, []string{"uuid", ""})
+	require.Error(suite.T(), err)
+
+	// not set before, duplicated UUIDs are dropped
+	suite.mMeta.On("Get", mock.Anything, pid, proScannerMetaKey).Return(map[string]string{}, nil)
+	suite.mMeta.On("Add", mock.Anything, pid, map[string]string{proScannerMetaKey: "uuid,uuid2"}).Return(nil)
+
+	err = suite.c.SetRegistrationsByProject(context.TODO(), pid, []string{"uuid", "uuid2", "uuid"})
+	require.NoError(suite.T(), err)
+
+	// replace the primary scanner and keep the additional ones
+	suite.mMeta.On("Get", mock.Anything, pid2, proScannerMetaKey).Return(map[string]string{proScannerMetaKey: "uuid,uuid2,uuid3"}, nil)
+	suite.mMeta.On("Update", mock.Anything, pid2, map[string]string{proScannerMetaKey: "uuid3,uuid2"}).Return(nil)
+
+	err = suite.c.SetRegistrationByProject(context.TODO(), pid2, "uuid3")
+	require.NoError(suite.T(), err)
+}
+
+// TestGetRegistrationsByProject tests GetRegistrationsByProject
+func (suite *ControllerTestSuite) TestGetRegistrationsByProject() {
+	var pid int64 = 1
+
+	other := &scanner.Registration{
+		UUID: "uuid2",
+		Name: "other",
+		URL:  "https://other.scanner.com",
+	}
+	suite.sample.UUID = "uuid"
+
+	// the deleted registration is pruned from the project metadata
+	suite.mMeta.On("Get", mock.Anything, pid, proScannerMetaKey).Return(map[string]string{proScannerMetaKey: "uuid,deleted,uuid2"}, nil)
+	suite.mMeta.On("Update", mock.Anything, pid, map[string]string{proScannerMetaKey: "uuid,uuid2"}).Return(nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid").Return(suite.sample, nil)
+	suite.mMgr.On("Get", mock.Anything, "uuid2").Return(other, nil)
+	suite.mMgr.On("Get", mock.Anything, "deleted").Return(nil, nil)
+
+	l, err := suite.c.GetRegistrationsByProject(context.TODO(), pid)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), l, 2)
+	suite.Equal("forUT", l[0].Name)
+	suite.Equal("other", l[1].Name)
+	suite.Equal(StatusHealthy, l[1].Health)
+
+	r, err := suite.c.GetRegistrationByProject(context.TODO(), pid, WithPing(false))
+	require.NoError(suite.T(), err)
+	suite.Equal("forUT", r.Name)
+
+	suite.mMeta.AssertExpectations(suite.T())
+}
+
 // TestGetRegistrationByProject tests GetRegistrationByProject
 func (suite *ControllerTestSuite) TestGetRegistrationByProject() {
 	m := make(map[string]string, 1)
@@ -108,7 +108,7 @@ type Controller interface {
 	//    error : non nil error if any errors occurred
 	SetDefaultRegistration(ctx context.Context, registrationUUID string) error
 
-	// SetRegistrationByProject sets scanner for the given project.
+	// SetRegistrationByProject sets the primary scanner for the given project.
 	//
 	//  Arguments:
 	//    ctx context.Context : the context.Context for this method
@@ -131,6 +131,29 @@ type Controller interface {
 	//     error                 : non nil error if any errors occurred
 	GetRegistrationByProject(ctx context.Context, projectID int64, options ...Option) (*scanner.Registration, error)
 
+	// SetRegistrationsByProject binds the scanners to the given project, the first one is the primary scanner.
+	//
+	//  Arguments:
+	//    ctx context.Context : the context.Context for this method
+	//    projectID int64  : the ID of the given project
+	//    scannerIDs []string : the UUIDs of the scanners
+	//
+	//  Returns:
+	//    error : non nil error if any errors occurred
+	SetRegistrationsByProject(ctx context.Context, projectID int64, scannerIDs []string) error
+
+	// GetRegistrationsByProject returns the scanner registrations bound to the given project with the primary one first,
+	// or the system default registration if no scanner is bound to the project.
+	//
+	//   Arguments:
+	//     ctx context.Context : the context.Context for this method
+	//     projectID int64 : the ID of the given project
+	//
+	//   Returns:
+	//     []*scanner.Registration : the scanner registrations
+	//     error                   : non nil error if any errors occurred
+	GetRegistrationsByProject(ctx context.Context, projectID int64, options ...Option) ([]*scanner.Registration, error)
+
 	// Ping pings Scanner Adapter to test EndpointURL and Authorization settings.
 	// The implementation is supposed to call the GetMetadata method on scanner.Client.
 	// Returns `nil` if connection succeeded, a non `nil` error otherwise.
@@ -0,0 +1,378 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/member"
+	pkguser "github.com/goharbor/harbor/src/pkg/user"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
+	"github.com/goharbor/harbor/src/pkg/usergroup/model"
+)
+
+// the comment of the users provisioned via SCIM
+const userComment = "Provisioned via SCIM"
+
+var (
+	// Ctl is a global variable for the default SCIM controller implementation
+	Ctl = NewController()
+)
+
+// Group is the user group provisioned via SCIM with its members
+type Group struct {
+	*model.UserGroup
+	MemberIDs []int
+}
+
+// Controller provisions the users and groups pushed by the identity provider via SCIM
+type Controller interface {
+	// CountUsers returns the total count of users according to the query
+	CountUsers(ctx context.Context, query *q.Query) (int64, error)
+	// ListUsers lists the users according to the query
+	ListUsers(ctx context.Context, query *q.Query) ([]*commonmodels.User, error)
+	// GetUser gets the user by ID
+	GetUser(ctx context.Context, id int) (*commonmodels.User, error)
+	// CreateUser creates the user, the user can't login with password as a random one is set
+	CreateUser(ctx context.Context, u *commonmodels.User) (int, error)
+	// UpdateUser updates the email and realname of the user and activates or deactivates the user,
+	// the project memberships of the user are removed when deactivating
+	UpdateUser(ctx context.Context, u *commonmodels.User) error
+	// DeleteUser deletes the user
+	DeleteUser(ctx context.Context, id int) error
+	// ListUserGroupIDs lists the IDs of the provisioned groups which the user is member of
+	ListUserGroupIDs(ctx context.Context, userID int) ([]int, error)
+
+	// CountGroups returns the total count of the groups of the current auth mode according to the query
+	CountGroups(ctx context.Context, query *q.Query) (int64, error)
+	// ListGroups lists the groups of the current auth mode according to the query, the members aren't populated
+	ListGroups(ctx context.Context, query *q.Query) ([]*Group, error)
+	// GetGroup gets the group with its members by ID
+	GetGroup(ctx context.Context, id int) (*Group, error)
+	// CreateGroup creates the group with the members
+	CreateGroup(ctx context.Context, name string, memberIDs []int) (int, error)
+	// RenameGroup renames the group
+	RenameGroup(ctx context.Context, id int, name string) error
+	// AddGroupMembers adds the users into the group
+	AddGroupMembers(ctx context.Context, id int, userIDs []int) error
+	// RemoveGroupMembers removes the users from the group
+	RemoveGroupMembers(ctx context.Context, id int, userIDs []int) error
+	// ReplaceGroupMembers replaces all the members of the group with the users
+	ReplaceGroupMembers(ctx context.Context, id int, userIDs []int) error
+	// DeleteGroup deletes the group, the project memberships of the group are removed as well
+	DeleteGroup(ctx context.Context, id int) error
+
+	// GetProvisionState returns the state of the user maintained in Harbor DB, it's cached and
+	// invalidated once the user or the membership of the user is changed via SCIM
+	GetProvisionState(ctx context.Context, userID int) (*ProvisionState, error)
+}
+
+// NewController creates an instance of the default SCIM controller
+func NewController() Controller {
+	return &controller{
+		userCtl:   user.Ctl,
+		userMgr:   pkguser.Mgr,
+		groupMgr:  usergroup.Mgr,
+		memberMgr: member.Mgr,
+		cache:     cache.Default,
+	}
+}
+
+type controller struct {
+	userCtl   user.Controller
+	userMgr   pkguser.Manager
+	groupMgr  usergroup.Manager
+	memberMgr member.Manager
+	// cache stores the provision states of the users
+	cache func() cache.Cache
+}
+
+func (c *controller) CountUsers(ctx context.Context, query *q.Query) (int64, error) {
+	return c.userMgr.Count(ctx, query)
+}
+
+func (c *controller) ListUsers(ctx context.Context, query *q.Query) ([]*commonmodels.User, error) {
+	return c.userMgr.List(ctx, query)
+}
+
+func (c *controller) GetUser(ctx context.Context, id int) (*commonmodels.User, error) {
+	// the admin user can't be managed by the identity provider
+	if id == 1 {
+		return nil, errors.NotFoundError(nil).WithMessagef("user %d not found", id)
+	}
+	return c.userMgr.Get(ctx, id)
+}
+
+func (c *controller) CreateUser(ctx context.Context, u *commonmodels.User) (int, error) {
+	if len(u.Username) == 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("the userName is required")
+	}
+	// the users authenticate with the identity provider, set a random password to disable the password login
+	u.Password = utils.GenerateRandomString()
+	u.Comment = userComment
+	id, err := c.userMgr.Create(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	if u.Disabled {
+		if err := c.userMgr.SetDisabled(ctx, id, true); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+func (c *controller) UpdateUser(ctx context.Context, u *commonmodels.User) error {
+	current, err := c.GetUser(ctx, u.UserID)
+	if err != nil {
+		return err
+	}
+	if err := c.userMgr.UpdateProfile(ctx, u, "Email", "Realname"); err != nil {
+		return err
+	}
+	if current.Disabled == u.Disabled {
+		return nil
+	}
+	if err := c.userMgr.SetDisabled(ctx, u.UserID, u.Disabled); err != nil {
+		return err
+	}
+	c.invalidateProvisionState(ctx, u.UserID)
+	if u.Disabled {
+		// the deprovisioned user shouldn't keep the access to the projects even it's activated again
+		if err := c.memberMgr.DeleteMemberByUserID(ctx, u.UserID); err != nil {
+			return err
+		}
+		log.G(ctx).Infof("the user %s is deactivated via SCIM, the project memberships are removed", current.Username)
+	}
+	return nil
+}
+
+func (c *controller) DeleteUser(ctx context.Context, id int) error {
+	if _, err := c.GetUser(ctx, id); err != nil {
+		return err
+	}
+	groupIDs, err := c.groupMgr.ListGroupIDsByMember(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, groupID := range groupIDs {
+		if err := c.groupMgr.DeleteMember(ctx, groupID, id); err != nil {
+			return err
+		}
+	}
+	if err := c.userCtl.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.invalidateProvisionState(ctx, id)
+	return nil
+}
+
+func (c *controller) ListUserGroupIDs(ctx context.Context, userID int) ([]int, error) {
+	return c.groupMgr.ListGroupIDsByMember(ctx, userID)
+}
+
+func (c *controller) CountGroups(ctx context.Context, query *q.Query) (int64, error) {
+	query, err := c.groupQuery(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return c.groupMgr.Count(ctx, query)
+}
+
+func (c *controller) ListGroups(ctx context.Context, query *q.Query) ([]*Group, error) {
+	query, err := c.groupQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := c.groupMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Group
+	for _, g := range groups {
+		result = append(result, &Group{UserGroup: g})
+	}
+	return result, nil
+}
+
+func (c *controller) GetGroup(ctx context.Context, id int) (*Group, error) {
+	g, err := c.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err := c.groupMgr.ListMemberIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &Group{UserGroup: g, MemberIDs: memberIDs}, nil
+}
+
+func (c *controller) CreateGroup(ctx context.Context, name string, memberIDs []int) (int, error) {
+	if len(name) == 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("the displayName is required")
+	}
+	groupType, err := groupType(ctx)
+	if err != nil {
+		return 0, err
+	}
+	g := &model.UserGroup{GroupName: name, GroupType: groupType}
+	// the group may have been onboarded at the login of its members, reuse it
+	if err := c.groupMgr.Onboard(ctx, g); err != nil {
+		return 0, err
+	}
+	if err := c.AddGroupMembers(ctx, g.ID, memberIDs); err != nil {
+		return 0, err
+	}
+	return g.ID, nil
+}
+
+func (c *controller) RenameGroup(ctx context.Context, id int, name string) error {
+	if len(name) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the displayName is required")
+	}
+	g, err := c.getGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+	if g.GroupName == name {
+		return nil
+	}
+	return c.groupMgr.UpdateName(ctx, id, name)
+}
+
+func (c *controller) AddGroupMembers(ctx context.Context, id int, userIDs []int) error {
+	if _, err := c.getGroup(ctx, id); err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if _, err := c.GetUser(ctx, userID); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return errors.BadRequestError(nil).WithMessagef("the member %d doesn't exist", userID)
+			}
+			return err
+		}
+		if err := c.groupMgr.AddMember(ctx, id, userID); err != nil {
+			return err
+		}
+		c.invalidateProvisionState(ctx, userID)
+	}
+	return nil
+}
+
+func (c *controller) RemoveGroupMembers(ctx context.Context, id int, userIDs []int) error {
+	if _, err := c.getGroup(ctx, id); err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := c.groupMgr.DeleteMember(ctx, id, userID); err != nil {
+			return err
+		}
+		c.invalidateProvisionState(ctx, userID)
+	}
+	return nil
+}
+
+func (c *controller) ReplaceGroupMembers(ctx context.Context, id int, userIDs []int) error {
+	if _, err := c.getGroup(ctx, id); err != nil {
+		return err
+	}
+	current, err := c.groupMgr.ListMemberIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	var removed []int
+	for _, userID := range current {
+		if !contains(userIDs, userID) {
+			removed = append(removed, userID)
+		}
+	}
+	if err := c.RemoveGroupMembers(ctx, id, removed); err != nil {
+		return err
+	}
+	return c.AddGroupMembers(ctx, id, userIDs)
+}
+
+func (c *controller) DeleteGroup(ctx context.Context, id int) error {
+	if _, err := c.getGroup(ctx, id); err != nil {
+		return err
+	}
+	memberIDs, err := c.groupMgr.ListMemberIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := c.groupMgr.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.invalidateProvisionState(ctx, memberIDs...)
+	return nil
+}
+
+// getGroup returns the group of the current auth mode
+func (c *controller) getGroup(ctx context.Context, id int) (*model.UserGroup, error) {
+	groupType, err := groupType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	g, err := c.groupMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil || g.GroupType != groupType {
+		return nil, errors.NotFoundError(nil).WithMessagef("group %d not found", id)
+	}
+	return g, nil
+}
+
+// groupQuery limits the query to the groups of the current auth mode
+func (c *controller) groupQuery(ctx context.Context, query *q.Query) (*q.Query, error) {
+	groupType, err := groupType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query = q.MustClone(query)
+	query.Keywords["GroupType"] = groupType
+	return query, nil
+}
+
+// groupType returns the type of the groups provisioned in the current auth mode, the groups can only be provisioned
+// in the auth modes in which the group membership isn't resolved from the directory, e.g. OIDC and HTTP auth
+func groupType(ctx context.Context) (int, error) {
+	switch mode := lib.GetAuthMode(ctx); mode {
+	case common.OIDCAuth:
+		return common.OIDCGroupType, nil
+	case common.HTTPAuth:
+		return common.HTTPGroupType, nil
+	default:
+		return 0, errors.BadRequestError(nil).WithMessagef("the groups can't be provisioned in the auth mode %s", mode)
+	}
+}
+
+func contains(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib"
+	libcache "github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/usergroup/model"
+	usertesting "github.com/goharbor/harbor/src/testing/controller/user"
+	cachetesting "github.com/goharbor/harbor/src/testing/lib/cache"
+	"github.com/goharbor/harbor/src/testing/mock"
+	membertesting "github.com/goharbor/harbor/src/testing/pkg/member"
+	pkgusertesting "github.com/goharbor/harbor/src/testing/pkg/user"
+	usergrouptesting "github.com/goharbor/harbor/src/testing/pkg/usergroup"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl       *controller
+	userCtl   *usertesting.Controller
+	userMgr   *pkgusertesting.Manager
+	groupMgr  *usergrouptesting.Manager
+	memberMgr *membertesting.Manager
+	cache     *cachetesting.Cache
+	ctx       context.Context
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.userCtl = &usertesting.Controller{}
+	c.userMgr = &pkgusertesting.Manager{}
+	c.groupMgr = &usergrouptesting.Manager{}
+	c.memberMgr = &membertesting.Manager{}
+	c.cache = &cachetesting.Cache{}
+	c.cache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	c.ctl = &controller{
+		userCtl:   c.userCtl,
+		userMgr:   c.userMgr,
+		groupMgr:  c.groupMgr,
+		memberMgr: c.memberMgr,
+		cache:     func() libcache.Cache { return c.cache },
+	}
+	c.ctx = lib.WithAuthMode(context.TODO(), common.OIDCAuth)
+}
+
+func (c *controllerTestSuite) TestGetUser() {
+	// the admin user can't be managed
+	_, err := c.ctl.GetUser(c.ctx, 1)
+	c.True(errors.IsNotFoundErr(err))
+
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	u, err := c.ctl.GetUser(c.ctx, 2)
+	c.Require().Nil(err)
+	c.Equal("alice", u.Username)
+}
+
+func (c *controllerTestSuite) TestCreateUser() {
+	_, err := c.ctl.CreateUser(c.ctx, &commonmodels.User{})
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.userMgr.On("Create", mock.Anything, mock.Anything).Return(2, nil)
+	c.userMgr.On("SetDisabled", mock.Anything, 2, true).Return(nil)
+	u := &commonmodels.User{Username: "alice", Disabled: true}
+	id, err := c.ctl.CreateUser(c.ctx, u)
+	c.Require().Nil(err)
+	c.Equal(2, id)
+	c.NotEmpty(u.Password)
+	c.Equal(userComment, u.Comment)
+	c.userMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestUpdateUser() {
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	c.userMgr.On("UpdateProfile", mock.Anything, mock.Anything, "Email", "Realname").Return(nil)
+
+	// the state isn't changed
+	c.Nil(c.ctl.UpdateUser(c.ctx, &commonmodels.User{UserID: 2, Email: "alice@example.com"}))
+	c.userMgr.AssertNotCalled(c.T(), "SetDisabled", mock.Anything, mock.Anything, mock.Anything)
+
+	// the project memberships are removed when deactivating the user
+	c.userMgr.On("SetDisabled", mock.Anything, 2, true).Return(nil)
+	c.memberMgr.On("DeleteMemberByUserID", mock.Anything, 2).Return(nil)
+	c.Nil(c.ctl.UpdateUser(c.ctx, &commonmodels.User{UserID: 2, Disabled: true}))
+	c.userMgr.AssertExpectations(c.T())
+	c.memberMgr.AssertExpectations(c.T())
+	c.cache.AssertCalled(c.T(), "Delete", mock.Anything, "scim:user:2")
+}
+
+func (c *controllerTestSuite) TestDeleteUser() {
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	c.groupMgr.On("ListGroupIDsByMember", mock.Anything, 2).Return([]int{3}, nil)
+	c.groupMgr.On("DeleteMember", mock.Anything, 3, 2).Return(nil)
+	c.userCtl.On("Delete", mock.Anything, 2).Return(nil)
+	c.Nil(c.ctl.DeleteUser(c.ctx, 2))
+	c.groupMgr.AssertExpectations(c.T())
+	c.userCtl.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestGroupType() {
+	// the groups can't be provisioned in the database auth mode
+	_, err := c.ctl.CreateGroup(lib.WithAuthMode(context.TODO(), common.DBAuth), "dev", nil)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.groupMgr.On("Get", mock.Anything, 3).Return(&model.UserGroup{ID: 3, GroupName: "ldap", GroupType: common.LDAPGroupType}, nil)
+	_, err = c.ctl.GetGroup(c.ctx, 3)
+	c.True(errors.IsNotFoundErr(err))
+
+	c.groupMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
+	_, err = c.ctl.CountGroups(c.ctx, q.New(q.KeyWords{}))
+	c.Nil(err)
+	c.groupMgr.AssertCalled(c.T(), "Count", mock.Anything, q.New(q.KeyWords{"GroupType": common.OIDCGroupType}))
+}
+
+func (c *controllerTestSuite) TestCreateGroup() {
+	c.groupMgr.On("Onboard", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.UserGroup).ID = 3
+	}).Return(nil)
+	c.groupMgr.On("Get", mock.Anything, 3).Return(&model.UserGroup{ID: 3, GroupName: "dev", GroupType: common.OIDCGroupType}, nil)
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	c.groupMgr.On("AddMember", mock.Anything, 3, 2).Return(nil)
+
+	id, err := c.ctl.CreateGroup(c.ctx, "dev", []int{2})
+	c.Require().Nil(err)
+	c.Equal(3, id)
+	c.groupMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestReplaceGroupMembers() {
+	c.groupMgr.On("Get", mock.Anything, 3).Return(&model.UserGroup{ID: 3, GroupName: "dev", GroupType: common.OIDCGroupType}, nil)
+	c.groupMgr.On("ListMemberIDs", mock.Anything, 3).Return([]int{2, 4}, nil)
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	c.userMgr.On("Get", mock.Anything, 5).Return(&commonmodels.User{UserID: 5, Username: "bob"}, nil)
+	c.groupMgr.On("DeleteMember", mock.Anything, 3, 4).Return(nil)
+	c.groupMgr.On("AddMember", mock.Anything, 3, 2).Return(nil)
+	c.groupMgr.On("AddMember", mock.Anything, 3, 5).Return(nil)
+
+	c.Nil(c.ctl.ReplaceGroupMembers(c.ctx, 3, []int{2, 5}))
+	c.groupMgr.AssertExpectations(c.T())
+	// the removed member is affected at once
+	c.cache.AssertCalled(c.T(), "Delete", mock.Anything, "scim:user:4")
+
+	// the member must exist
+	c.userMgr.On("Get", mock.Anything, 6).Return(nil, errors.NotFoundError(nil))
+	err := c.ctl.AddGroupMembers(c.ctx, 3, []int{6})
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (c *controllerTestSuite) TestDeleteGroup() {
+	c.groupMgr.On("Get", mock.Anything, 3).Return(&model.UserGroup{ID: 3, GroupName: "dev", GroupType: common.OIDCGroupType}, nil)
+	c.groupMgr.On("ListMemberIDs", mock.Anything, 3).Return([]int{2, 4}, nil)
+	c.groupMgr.On("Delete", mock.Anything, 3).Return(nil)
+
+	c.Nil(c.ctl.DeleteGroup(c.ctx, 3))
+	c.groupMgr.AssertExpectations(c.T())
+	c.cache.AssertCalled(c.T(), "Delete", mock.Anything, "scim:user:2")
+	c.cache.AssertCalled(c.T(), "Delete", mock.Anything, "scim:user:4")
+}
+
+func (c *controllerTestSuite) TestGetProvisionState() {
+	c.cache.On("Fetch", mock.Anything, "scim:user:2", mock.Anything).Return(libcache.ErrNotFound)
+	c.cache.On("Save", mock.Anything, "scim:user:2", mock.Anything, mock.Anything).Return(nil)
+	c.userMgr.On("Get", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice", Disabled: true}, nil)
+	c.groupMgr.On("ListGroupIDsByMember", mock.Anything, 2).Return([]int{3}, nil)
+
+	state, err := c.ctl.GetProvisionState(c.ctx, 2)
+	c.Require().Nil(err)
+	c.Equal(&ProvisionState{Exist: true, Disabled: true, GroupIDs: []int{3}}, state)
+	c.cache.AssertCalled(c.T(), "Save", mock.Anything, "scim:user:2", mock.Anything, mock.Anything)
+
+	// the deleted user
+	c.cache.On("Fetch", mock.Anything, "scim:user:3", mock.Anything).Return(libcache.ErrNotFound)
+	c.cache.On("Save", mock.Anything, "scim:user:3", mock.Anything, mock.Anything).Return(nil)
+	c.userMgr.On("Get", mock.Anything, 3).Return(nil, errors.NotFoundError(nil))
+	state, err = c.ctl.GetProvisionState(c.ctx, 3)
+	c.Require().Nil(err)
+	c.False(state.Exist)
+
+	// the error is returned rather than an empty state
+	c.cache.On("Fetch", mock.Anything, "scim:user:4", mock.Anything).Return(libcache.ErrNotFound)
+	c.userMgr.On("Get", mock.Anything, 4).Return(nil, errors.New("failed to connect to the database"))
+	_, err = c.ctl.GetProvisionState(c.ctx, 4)
+	c.Error(err)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -0,0 +1,88 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const (
+	provisionStateKeyFormat = "scim:user:%d"
+	// the cached state is invalidated once it's changed via SCIM, the expiration bounds the staleness
+	// of the changes made out of SCIM, e.g. deleting the user via the API
+	provisionStateExpiration = 30 * time.Second
+)
+
+// ProvisionState is the state of the user maintained in Harbor DB which takes effect on every request of the user
+type ProvisionState struct {
+	Exist    bool  `json:"exist"`
+	Disabled bool  `json:"disabled"`
+	GroupIDs []int `json:"group_ids"`
+}
+
+func provisionStateKey(userID int) string {
+	return fmt.Sprintf(provisionStateKeyFormat, userID)
+}
+
+func (c *controller) GetProvisionState(ctx context.Context, userID int) (*ProvisionState, error) {
+	build := func() (any, error) {
+		state := &ProvisionState{}
+		u, err := c.userMgr.Get(ctx, userID)
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return state, nil
+			}
+			return nil, err
+		}
+		state.Exist, state.Disabled = true, u.Disabled
+		if state.GroupIDs, err = c.groupMgr.ListGroupIDsByMember(ctx, userID); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	ch := c.cache()
+	if ch == nil {
+		state, err := build()
+		if err != nil {
+			return nil, err
+		}
+		return state.(*ProvisionState), nil
+	}
+	state := &ProvisionState{}
+	if err := cache.FetchOrSave(ctx, ch, provisionStateKey(userID), state, build, provisionStateExpiration); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// invalidateProvisionState removes the cached state of the users, so the changes take effect on the next request
+func (c *controller) invalidateProvisionState(ctx context.Context, userIDs ...int) {
+	ch := c.cache()
+	if ch == nil {
+		return
+	}
+	for _, id := range userIDs {
+		if err := ch.Delete(ctx, provisionStateKey(id)); err != nil {
+			log.G(ctx).Warningf("failed to invalidate the provision state of the user %d: %v", id, err)
+		}
+	}
+}
@@ -16,12 +16,14 @@ package securityhub
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/pkg/securityhub"
 	secHubModel "github.com/goharbor/harbor/src/pkg/securityhub/model"
 	"github.com/goharbor/harbor/src/pkg/tag"
+	"github.com/goharbor/harbor/src/pkg/task"
 )
 
 // Ctl is the global controller for security hub
@@ -66,12 +68,22 @@ type Controller interface {
 	ListVuls(ctx context.Context, scannerUUID string, projectID int64, withTag bool, query *q.Query) ([]*secHubModel.VulnerabilityItem, error)
 	// CountVuls get all vulnerability count by query
 	CountVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error)
+	// TakeSnapshot starts the job to take the snapshot of the vulnerability aggregates for the trends
+	TakeSnapshot(ctx context.Context, trigger string) error
+	// ListTrends lists the daily snapshots of the project between the dates, projectID 0 is for the whole system
+	ListTrends(ctx context.Context, projectID int64, from, to time.Time) ([]*secHubModel.Snapshot, error)
+	// ListRemediationTimes lists the mean time to remediate the CVEs remediated between the dates, projectID 0 is for all the projects
+	ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*secHubModel.RemediationTime, error)
+	// CountRemediationTimes counts the CVEs remediated between the dates, projectID 0 is for all the projects
+	CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error)
 }
 
 type controller struct {
 	scannerMgr scanner.Manager
 	secHubMgr  securityhub.Manager
 	tagMgr     tag.Manager
+	execMgr    task.ExecutionManager
+	taskMgr    task.Manager
 }
 
 // NewController ...
@@ -80,6 +92,8 @@ func NewController() Controller {
 		scannerMgr: scanner.Mgr,
 		secHubMgr:  securityhub.Mgr,
 		tagMgr:     tag.Mgr,
+		execMgr:    task.ExecMgr,
+		taskMgr:    task.Mgr,
 	}
 }
 
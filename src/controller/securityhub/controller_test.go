@@ -17,17 +17,22 @@ package securityhub
 import (
 	"errors"
 	"testing"
+	"time"
 
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	"github.com/goharbor/harbor/src/pkg/securityhub/model"
 	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	"github.com/goharbor/harbor/src/pkg/task"
 	htesting "github.com/goharbor/harbor/src/testing"
 	"github.com/goharbor/harbor/src/testing/mock"
 	scannerMock "github.com/goharbor/harbor/src/testing/pkg/scan/scanner"
 	securityMock "github.com/goharbor/harbor/src/testing/pkg/securityhub"
 	tagMock "github.com/goharbor/harbor/src/testing/pkg/tag"
+	taskMock "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
 var sum = &model.Summary{
@@ -45,6 +50,8 @@ type ControllerTestSuite struct {
 	scannerMgr *scannerMock.Manager
 	secHubMgr  *securityMock.Manager
 	tagMgr     *tagMock.Manager
+	execMgr    *taskMock.ExecutionManager
+	taskMgr    *taskMock.Manager
 }
 
 // TestController is the entry of controller test suite
@@ -57,11 +64,15 @@ func (suite *ControllerTestSuite) SetupTest() {
 	suite.secHubMgr = &securityMock.Manager{}
 	suite.scannerMgr = &scannerMock.Manager{}
 	suite.tagMgr = &tagMock.Manager{}
+	suite.execMgr = &taskMock.ExecutionManager{}
+	suite.taskMgr = &taskMock.Manager{}
 
 	suite.c = &controller{
 		secHubMgr:  suite.secHubMgr,
 		scannerMgr: suite.scannerMgr,
 		tagMgr:     suite.tagMgr,
+		execMgr:    suite.execMgr,
+		taskMgr:    suite.taskMgr,
 	}
 }
 
@@ -195,3 +206,49 @@ func (suite *ControllerTestSuite) TestCountVuls() {
 	suite.NoError(err)
 	suite.Equal(int64(10), count)
 }
+
+func (suite *ControllerTestSuite) TestTakeSnapshot() {
+	ctx := suite.Context()
+	mock.OnAnything(suite.scannerMgr, "DefaultScannerUUID").Return("ruuid", nil)
+	suite.execMgr.On("Create", mock.Anything, job.SecurityHubSnapshotVendorType, int64(0), task.ExecutionTriggerSchedule).Return(int64(1), nil)
+	suite.taskMgr.On("Create", mock.Anything, int64(1), testifymock.MatchedBy(func(j *task.Job) bool {
+		return j.Name == job.SecurityHubSnapshotVendorType && j.Parameters["scanner_uuid"] == "ruuid"
+	})).Return(int64(1), nil)
+	suite.NoError(suite.c.TakeSnapshot(ctx, task.ExecutionTriggerSchedule))
+	suite.execMgr.AssertExpectations(suite.T())
+	suite.taskMgr.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestTakeSnapshotError() {
+	ctx := suite.Context()
+	mock.OnAnything(suite.scannerMgr, "DefaultScannerUUID").Return("", nil)
+	mock.OnAnything(suite.execMgr, "Create").Return(int64(1), nil)
+	mock.OnAnything(suite.taskMgr, "Create").Return(int64(0), errors.New("failed to submit job"))
+	mock.OnAnything(suite.execMgr, "StopAndWaitWithError").Return(nil)
+	suite.Error(suite.c.TakeSnapshot(ctx, task.ExecutionTriggerManual))
+	suite.execMgr.AssertCalled(suite.T(), "StopAndWaitWithError", mock.Anything, int64(1), mock.Anything, mock.Anything)
+}
+
+func (suite *ControllerTestSuite) TestListTrends() {
+	ctx := suite.Context()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	snapshots := []*model.Snapshot{
+		{ProjectID: 1, Date: from, CriticalCnt: 2},
+		{ProjectID: 1, Date: to, CriticalCnt: 1},
+	}
+	suite.secHubMgr.On("ListSnapshots", mock.Anything, int64(1), from, to).Return(snapshots, nil)
+	trends, err := suite.c.ListTrends(ctx, 1, from, to)
+	suite.NoError(err)
+	suite.Equal(snapshots, trends)
+
+	times := []*model.RemediationTime{{CVEID: "CVE-2020-1234", RemediatedCnt: 2, MeanDays: 3.5}}
+	mock.OnAnything(suite.secHubMgr, "ListRemediationTimes").Return(times, nil)
+	mock.OnAnything(suite.secHubMgr, "CountRemediationTimes").Return(int64(1), nil)
+	result, err := suite.c.ListRemediationTimes(ctx, 0, from, to, nil)
+	suite.NoError(err)
+	suite.Equal(times, result)
+	cnt, err := suite.c.CountRemediationTimes(ctx, 0, from, to)
+	suite.NoError(err)
+	suite.Equal(int64(1), cnt)
+}
@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	snapshotJob "github.com/goharbor/harbor/src/jobservice/job/impl/securityhub"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	secHubModel "github.com/goharbor/harbor/src/pkg/securityhub/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// SnapshotCallback is the name of the callback for the security hub snapshot schedule
+	SnapshotCallback = "SECURITY_HUB_SNAPSHOT"
+
+	cronTypeDaily = "Daily"
+	cronSpec      = "0 0 0 * * *"
+)
+
+var sched = scheduler.Sched
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(SnapshotCallback, snapshotCallback); err != nil {
+		log.Fatalf("failed to register the callback for the security hub snapshot schedule, error %v", err)
+	}
+}
+
+func snapshotCallback(ctx context.Context, _ string) error {
+	err := Ctl.TakeSnapshot(ctx, task.ExecutionTriggerSchedule)
+	if err != nil {
+		log.Errorf("failed to start the security hub snapshot job: %v", err)
+	}
+	return err
+}
+
+// ScheduleSnapshotJob schedules the daily security hub snapshot job if it's not scheduled yet
+func ScheduleSnapshotJob(ctx context.Context) error {
+	query := q.New(map[string]any{"vendor_type": job.SecurityHubSnapshotVendorType})
+	schedules, err := sched.ListSchedules(ctx, query)
+	if err != nil {
+		return err
+	}
+	if len(schedules) > 0 {
+		log.Debugf("security hub snapshot job already scheduled with ID: %d", schedules[0].ID)
+		return nil
+	}
+
+	id, err := sched.Schedule(ctx, job.SecurityHubSnapshotVendorType, 0, cronTypeDaily, cronSpec, SnapshotCallback, nil, nil)
+	if err != nil {
+		return err
+	}
+	log.Infof("scheduled the security hub snapshot job with ID: %d", id)
+	return nil
+}
+
+func (c *controller) TakeSnapshot(ctx context.Context, trigger string) error {
+	params := job.Parameters{}
+	// the snapshot aggregates the reports of the default scanner as the security summary does
+	scannerUUID, err := c.scannerMgr.DefaultScannerUUID(ctx)
+	if err != nil {
+		return err
+	}
+	if len(scannerUUID) > 0 {
+		params[snapshotJob.ScannerUUIDParam] = scannerUUID
+	}
+
+	execID, err := c.execMgr.Create(ctx, job.SecurityHubSnapshotVendorType, 0, trigger)
+	if err != nil {
+		return err
+	}
+
+	j := &task.Job{
+		Name: job.SecurityHubSnapshotVendorType,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: params,
+	}
+	if _, err = c.taskMgr.Create(ctx, execID, j); err != nil {
+		if e := c.execMgr.StopAndWaitWithError(ctx, execID, 10*time.Second, err); e != nil {
+			log.Errorf("failed to stop the execution %d: %v", execID, e)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c *controller) ListTrends(ctx context.Context, projectID int64, from, to time.Time) ([]*secHubModel.Snapshot, error) {
+	return c.secHubMgr.ListSnapshots(ctx, projectID, from, to)
+}
+
+func (c *controller) ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*secHubModel.RemediationTime, error) {
+	return c.secHubMgr.ListRemediationTimes(ctx, projectID, from, to, query)
+}
+
+func (c *controller) CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error) {
+	return c.secHubMgr.CountRemediationTimes(ctx, projectID, from, to)
+}
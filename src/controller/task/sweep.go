@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
@@ -32,6 +33,10 @@ var (
 type SweepParams struct {
 	// ExecRetainCounts records the retained execution counts for different vendor type
 	ExecRetainCounts map[string]int64
+	// ExecRetainDays is the days within which the executions are retained regardless of the retain counts, 0 disables it
+	ExecRetainDays int64
+	// ArchiveEnabled indicates whether to archive the swept executions before deleting them
+	ArchiveEnabled bool
 }
 
 const (
@@ -53,10 +58,31 @@ func init() {
 }
 
 func sweepCallback(ctx context.Context, _ string) error {
-	params := &SweepParams{ExecRetainCounts: job.GetExecutionSweeperCount()}
+	params := &SweepParams{
+		ExecRetainCounts: execRetainCounts(ctx),
+		ExecRetainDays:   int64(config.ExecutionRetentionDays(ctx)),
+		ArchiveEnabled:   config.ExecutionArchiveEnabled(ctx),
+	}
 	return SweepCtl.Start(ctx, params, task.ExecutionTriggerSchedule)
 }
 
+// execRetainCounts returns the retained execution counts of the vendor types, the counts set via
+// the configuration override the ones set by the env
+func execRetainCounts(ctx context.Context) map[string]int64 {
+	counts := make(map[string]int64)
+	for vendor, cnt := range job.GetExecutionSweeperCount() {
+		counts[vendor] = cnt
+	}
+	for vendor, cnt := range config.ExecutionRetentionCounts(ctx) {
+		if _, exist := counts[vendor]; !exist {
+			log.Warningf("skip the retention count of the unknown vendor type %s", vendor)
+			continue
+		}
+		counts[vendor] = cnt
+	}
+	return counts
+}
+
 type SweepController interface {
 	Start(ctx context.Context, params *SweepParams, trigger string) error
 }
@@ -69,6 +95,8 @@ type sweepController struct {
 func (sc *sweepController) Start(ctx context.Context, params *SweepParams, trigger string) error {
 	jobParams := make(map[string]any)
 	jobParams[task.ExecRetainCounts] = params.ExecRetainCounts
+	jobParams[task.ExecRetainDays] = params.ExecRetainDays
+	jobParams[task.ExecArchiveEnabled] = params.ArchiveEnabled
 
 	execID, err := sc.execMgr.Create(ctx, job.ExecSweepVendorType, systemVendorID, trigger, jobParams)
 	if err != nil {
@@ -114,9 +114,15 @@ func (c *controller) OnboardOIDCUser(ctx context.Context, u *commonmodels.User)
 	if u.OIDCUserMeta == nil {
 		return errors.BadRequestError(nil).WithMessage("OIDC meta of the user model is empty")
 	}
-	uid, err := c.mgr.Create(ctx, u)
+	uid, err := c.provisionedUserID(ctx, u.Username)
 	if err != nil {
-		return errors.Wrap(err, "failed to create user record")
+		return err
+	}
+	if uid == 0 {
+		uid, err = c.mgr.Create(ctx, u)
+		if err != nil {
+			return errors.Wrap(err, "failed to create user record")
+		}
 	}
 	u.UserID = uid
 	u.OIDCUserMeta.UserID = uid
@@ -129,6 +135,29 @@ func (c *controller) OnboardOIDCUser(ctx context.Context, u *commonmodels.User)
 	return nil
 }
 
+// provisionedUserID returns the ID of the user provisioned in advance, e.g. via SCIM, which hasn't been onboarded
+// via the OIDC provider yet, the OIDC metadata is linked to the user rather than creating a new one. 0 is returned if
+// there is no such user
+func (c *controller) provisionedUserID(ctx context.Context, username string) (int, error) {
+	u, err := c.mgr.GetByName(ctx, username)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	// never link the admin user
+	if u.UserID == 1 {
+		return 0, nil
+	}
+	if _, err := c.oidcMetaMgr.GetByUserID(ctx, u.UserID); err == nil {
+		return 0, errors.ConflictError(nil).WithMessagef("the user %s has been onboarded", username)
+	} else if !errors.IsNotFoundErr(err) {
+		return 0, err
+	}
+	return u.UserID, nil
+}
+
 func (c *controller) GetBySubIss(ctx context.Context, sub, iss string) (*commonmodels.User, error) {
 	oidcMeta, err := c.oidcMetaMgr.GetBySubIss(ctx, sub, iss)
 	if err != nil {
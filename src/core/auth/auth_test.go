@@ -16,7 +16,6 @@ package auth
 import (
 	"context"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -24,25 +23,6 @@ import (
 	"github.com/goharbor/harbor/src/pkg/usergroup/model"
 )
 
-var l = NewUserLock(100 * time.Millisecond)
-
-func TestLock(t *testing.T) {
-	t.Log("Locking john")
-	l.Lock("john")
-	if !l.IsLocked("john") {
-		t.Errorf("John should be locked")
-	}
-	t.Log("Locking jack")
-	l.Lock("jack")
-	t.Log("Wait for lock to expire...")
-	assert.Eventually(t, func() bool {
-		return !l.IsLocked("jack")
-	}, 200*time.Millisecond, 10*time.Millisecond, "After 100ms, jack shouldn't be locked")
-	if l.IsLocked("daniel") {
-		t.Errorf("daniel has never been locked, he should not be locked")
-	}
-}
-
 func TestDefaultAuthenticate(t *testing.T) {
 	authHelper := DefaultAuthenticateHelper{}
 	m := models.AuthModel{}
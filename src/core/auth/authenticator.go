@@ -18,21 +18,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib/config"
 	libErrors "github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	libredis "github.com/goharbor/harbor/src/lib/redis"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/usergroup/model"
 )
 
-// 1.5 seconds
+// 1.5 seconds, the first back-off period after the login failure
 const frozenTime time.Duration = 1500 * time.Millisecond
 
-var lock = NewUserLock(frozenTime)
+var (
+	loginLock     LoginLock
+	loginLockOnce sync.Once
+)
 
 // ErrorUserNotExist ...
 var ErrorUserNotExist = errors.New("user does not exist")
@@ -148,23 +156,141 @@ func Login(ctx context.Context, m models.AuthModel) (*models.User, error) {
 	if !ok {
 		return nil, fmt.Errorf("unrecognized auth_mode: %s", authMode)
 	}
-	if lock.IsLocked(m.Principal) {
-		log.Debugf("%s is locked due to login failure, login failed", m.Principal)
-		return nil, nil
+	lock := getLoginLock()
+	clientIP := normalizeIP(m.ClientIP)
+	if err := checkLocked(ctx, lock, m.Principal, clientIP); err != nil {
+		return nil, err
 	}
 	user, err := authenticator.Authenticate(ctx, m)
 	if err != nil {
 		if _, ok = err.(ErrAuth); ok {
-			log.Warningf("Login failed, locking %s, and sleep for %v", m.Principal, frozenTime)
-			lock.Lock(m.Principal)
-			time.Sleep(frozenTime)
+			recordFailure(ctx, lock, m.Principal, clientIP)
 		}
 		return nil, err
 	}
+	// the failures of the client IP are kept, otherwise one valid account is enough to reset them
+	if err := lock.Reset(ctx, LockKindUser, m.Principal); err != nil {
+		log.Warningf("failed to reset the login failures of %s, error: %v", m.Principal, err)
+	}
 	err = authenticator.PostAuthenticate(ctx, user)
+	if err == nil && user != nil && user.Disabled {
+		log.Debugf("%s is deactivated, login failed", m.Principal)
+		return nil, nil
+	}
 	return user, err
 }
 
+// getLoginLock returns the login lock backed by redis so that the failures are shared by the core replicas,
+// it falls back to the memory of the process when the redis client isn't available
+func getLoginLock() LoginLock {
+	loginLockOnce.Do(func() {
+		if loginLock != nil {
+			return
+		}
+		client, err := libredis.GetHarborClient()
+		if err != nil {
+			log.Warningf("failed to get the redis client, the login failures are tracked in the memory of the process, error: %v", err)
+			loginLock = NewMemoryLock()
+			return
+		}
+		loginLock = NewRedisLock(client)
+	})
+	return loginLock
+}
+
+// checkLocked returns ErrLocked when the login of the user or the client IP is blocked,
+// the login is allowed when the lock can't be checked to avoid locking out everybody
+func checkLocked(ctx context.Context, lock LoginLock, principal, clientIP string) error {
+	keys := [][2]string{{LockKindUser, principal}}
+	if clientIP != "" {
+		keys = append(keys, [2]string{LockKindIP, clientIP})
+	}
+	for _, key := range keys {
+		d, err := lock.Check(ctx, key[0], key[1])
+		if err != nil {
+			log.Warningf("failed to check the login lock of %s %s, error: %v", key[0], key[1], err)
+			continue
+		}
+		if d > 0 {
+			log.Debugf("the login of %s %s is blocked for %v due to login failures", key[0], key[1], d)
+			return ErrLocked{RetryAfter: d}
+		}
+	}
+	return nil
+}
+
+// recordFailure records the login failure of the user and the client IP, the login of the user is backed off
+// exponentially after each failure and both of them are locked out after the max failures within the window
+func recordFailure(ctx context.Context, lock LoginLock, principal, clientIP string) {
+	window := time.Duration(max(config.LoginFailureWindow(ctx), 1)) * time.Minute
+	duration := time.Duration(max(config.LoginLockoutDuration(ctx), 1)) * time.Minute
+	type target struct {
+		kind, name string
+		policy     *LockPolicy
+	}
+	targets := []target{{LockKindUser, principal, &LockPolicy{
+		MaxFailures: config.LoginMaxFailures(ctx),
+		Window:      window,
+		Duration:    duration,
+		Backoff:     true,
+	}}}
+	if clientIP != "" {
+		targets = append(targets, target{LockKindIP, clientIP, &LockPolicy{
+			MaxFailures: config.LoginMaxFailuresPerIP(ctx),
+			Window:      window,
+			Duration:    duration,
+		}})
+	}
+
+	for _, t := range targets {
+		blocked, lockout, err := lock.Fail(ctx, t.kind, t.name, t.policy)
+		if err != nil {
+			log.Warningf("failed to record the login failure of %s %s, error: %v", t.kind, t.name, err)
+			continue
+		}
+		if lockout {
+			log.Warningf("Login failed, locking out %s %s for %v after too many failures", t.kind, t.name, blocked)
+			event.BuildAndPublish(ctx, &metadata.LoginLockEventMetadata{
+				Operator: principal,
+				Kind:     t.kind,
+				Name:     t.name,
+				Duration: blocked,
+			})
+		} else if blocked > 0 {
+			log.Warningf("Login failed, blocking the login of %s %s for %v", t.kind, t.name, blocked)
+		}
+	}
+}
+
+// ListLocks lists the lockouts of the users and the client IPs
+func ListLocks(ctx context.Context) ([]*Lock, error) {
+	return getLoginLock().List(ctx)
+}
+
+// Unlock removes the lock and clears the login failures of the user or the client IP
+func Unlock(ctx context.Context, kind, name string) error {
+	switch kind {
+	case LockKindUser:
+	case LockKindIP:
+		name = normalizeIP(name)
+	default:
+		return libErrors.BadRequestError(nil).WithMessagef("unsupported lock kind: %s", kind)
+	}
+	if len(name) == 0 {
+		return libErrors.BadRequestError(nil).WithMessage("the name of the lock is required")
+	}
+	if err := getLoginLock().Reset(ctx, kind, name); err != nil {
+		return err
+	}
+	event.BuildAndPublish(ctx, &metadata.LoginLockEventMetadata{
+		Operator: operator.FromContext(ctx),
+		Kind:     kind,
+		Name:     name,
+		Unlock:   true,
+	})
+	return nil
+}
+
 func getHelper(ctx context.Context) (AuthenticateHelper, error) {
 	authMode, err := config.AuthMode(ctx)
 	if err != nil {
@@ -142,7 +142,7 @@ func (a *Auth) OnBoardUser(ctx context.Context, u *models.User) error {
 
 // PostAuthenticate generates the user model and on board the user.
 func (a *Auth) PostAuthenticate(ctx context.Context, u *models.User) error {
-	_, err := a.userMgr.GetByName(ctx, u.Username)
+	dbUser, err := a.userMgr.GetByName(ctx, u.Username)
 	if harborErrors.IsNotFoundErr(err) {
 		if err2 := a.fillInModel(u); err2 != nil {
 			return err2
@@ -151,7 +151,8 @@ func (a *Auth) PostAuthenticate(ctx context.Context, u *models.User) error {
 	} else if err != nil {
 		return err
 	}
-	// do nothing if user exists in DB
+	// only sync the deactivation state if user exists in DB
+	u.Disabled = dbUser.Disabled
 	return nil
 }
 
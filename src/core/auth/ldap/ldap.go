@@ -338,6 +338,7 @@ func (l *Auth) PostAuthenticate(ctx context.Context, u *models.User) error {
 			return err
 		}
 		u.UserID = dbUser.UserID
+		u.Disabled = dbUser.Disabled
 		if dbUser.Email != u.Email {
 			Re := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
 			if !Re.MatchString(u.Email) {
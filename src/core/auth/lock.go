@@ -15,37 +15,295 @@
 package auth
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
-// UserLock maintains a lock to block user from logging in within a short period of time.
-type UserLock struct {
-	failures map[string]time.Time
-	d        time.Duration
-	rw       *sync.RWMutex
+const (
+	// LockKindUser the kind of the lock on the username
+	LockKindUser = "user"
+	// LockKindIP the kind of the lock on the client IP
+	LockKindIP = "ip"
+
+	lockoutValue = "lockout"
+	backoffValue = "backoff"
+	// the max time to back off the login of the user after a failure
+	maxBackoff = time.Minute
+	// the prefix of the redis keys
+	lockKeyPrefix    = "login_lock:lock:"
+	failureKeyPrefix = "login_lock:failures:"
+)
+
+// LockPolicy is the policy to lock the login after the failures
+type LockPolicy struct {
+	// MaxFailures the failures within the window to lock out the login, 0 disables the lockout
+	MaxFailures int
+	// Window the period within which the failures are counted
+	Window time.Duration
+	// Duration the period for which the login is locked out
+	Duration time.Duration
+	// Backoff blocks the login for an exponentially increasing period after each failure
+	Backoff bool
+}
+
+// Lock is the lockout of the user or the client IP
+type Lock struct {
+	Kind      string
+	Name      string
+	ExpiresAt time.Time
+}
+
+// LoginLock tracks the login failures of the users and the client IPs and locks the login
+type LoginLock interface {
+	// Check returns the remaining period for which the login of the key is blocked, 0 means the login is allowed
+	Check(ctx context.Context, kind, name string) (time.Duration, error)
+	// Fail records a login failure of the key and blocks its login according to the policy,
+	// the blocked period is returned and lockout is true when the failures reach the max failures
+	Fail(ctx context.Context, kind, name string, policy *LockPolicy) (blocked time.Duration, lockout bool, err error)
+	// Reset clears the failures and the lock of the key
+	Reset(ctx context.Context, kind, name string) error
+	// List lists the lockouts, the back-offs aren't included
+	List(ctx context.Context) ([]*Lock, error)
+}
+
+// ErrLocked is the error returned when the login is blocked after the failures
+type ErrLocked struct {
+	// RetryAfter the period after which the login is allowed again
+	RetryAfter time.Duration
+}
+
+// Error ...
+func (e ErrLocked) Error() string {
+	return fmt.Sprintf("too many login failures, retry after %v", e.RetryAfter.Round(time.Second))
+}
+
+// backoff returns the period to block the login after the consecutive failures, starting from the frozen time
+func backoff(failures int64) time.Duration {
+	if failures < 1 {
+		return 0
+	}
+	// avoid the overflow, the result is capped anyway
+	if failures > 16 {
+		failures = 16
+	}
+	d := frozenTime << (failures - 1)
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// normalizeIP strips the port and the proxies from the client IP so that the failures are counted per client
+func normalizeIP(ip string) string {
+	// the leading entries of the X-Forwarded-For header are controlled by the client, only the last one
+	// appended by the proxy in front of harbor can be trusted, so the lockout can't be bypassed or spoofed
+	if i := strings.LastIndex(ip, ","); i >= 0 {
+		ip = ip[i+1:]
+	}
+	ip = strings.TrimSpace(ip)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		return host
+	}
+	return ip
+}
+
+// NewMemoryLock returns a login lock tracking the failures in the memory of the process,
+// it only protects the login handled by the process
+func NewMemoryLock() LoginLock {
+	return &memoryLock{
+		entries: map[string]*memoryLockEntry{},
+	}
+}
+
+type memoryLockEntry struct {
+	failures     int64
+	windowEnd    time.Time
+	blockedUntil time.Time
+	lockout      bool
+}
+
+type memoryLock struct {
+	entries map[string]*memoryLockEntry
+	rw      sync.RWMutex
+}
+
+func (m *memoryLock) Check(_ context.Context, kind, name string) (time.Duration, error) {
+	m.rw.RLock()
+	defer m.rw.RUnlock()
+	e, ok := m.entries[kind+":"+name]
+	if !ok {
+		return 0, nil
+	}
+	if d := time.Until(e.blockedUntil); d > 0 {
+		return d, nil
+	}
+	return 0, nil
+}
+
+func (m *memoryLock) Fail(_ context.Context, kind, name string, policy *LockPolicy) (time.Duration, bool, error) {
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	now := time.Now()
+	// drop the expired entries to bound the memory
+	for k, e := range m.entries {
+		if now.After(e.windowEnd) && now.After(e.blockedUntil) {
+			delete(m.entries, k)
+		}
+	}
+
+	key := kind + ":" + name
+	e, ok := m.entries[key]
+	if !ok || now.After(e.windowEnd) {
+		e = &memoryLockEntry{windowEnd: now.Add(policy.Window)}
+		m.entries[key] = e
+	}
+	e.failures++
+	blocked, lockout := block(e.failures, policy)
+	if blocked > 0 {
+		e.blockedUntil = now.Add(blocked)
+		e.lockout = lockout
+	}
+	if lockout {
+		// count the failures from scratch after the lockout
+		e.failures = 0
+		e.windowEnd = e.blockedUntil
+	}
+	return blocked, lockout, nil
+}
+
+func (m *memoryLock) Reset(_ context.Context, kind, name string) error {
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	delete(m.entries, kind+":"+name)
+	return nil
 }
 
-// NewUserLock ...
-func NewUserLock(freeze time.Duration) *UserLock {
-	return &UserLock{
-		make(map[string]time.Time),
-		freeze,
-		&sync.RWMutex{},
+func (m *memoryLock) List(_ context.Context) ([]*Lock, error) {
+	m.rw.RLock()
+	defer m.rw.RUnlock()
+	locks := []*Lock{}
+	for key, e := range m.entries {
+		if !e.lockout || time.Now().After(e.blockedUntil) {
+			continue
+		}
+		kind, name, _ := strings.Cut(key, ":")
+		locks = append(locks, &Lock{Kind: kind, Name: name, ExpiresAt: e.blockedUntil})
 	}
+	return locks, nil
 }
 
-// Lock marks a new login failure with the time it happens
-func (ul *UserLock) Lock(username string) {
-	ul.rw.Lock()
-	defer ul.rw.Unlock()
-	ul.failures[username] = time.Now()
+// NewRedisLock returns a login lock tracking the failures in redis, so that it's shared by all the core replicas
+func NewRedisLock(client *redis.Client) LoginLock {
+	return &redisLock{
+		client: client,
+	}
+}
+
+type redisLock struct {
+	client *redis.Client
+}
+
+// Count the failure and start the window with the first one in a single step,
+// so the counter never outlives the window when the client fails in between
+//
+// KEYS[1]: key of the failures
+// ARGV[1]: window in milliseconds
+var failScript = redis.NewScript(`
+local failures = redis.call('INCR', KEYS[1])
+if failures == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return failures
+`)
+
+func (r *redisLock) Check(ctx context.Context, kind, name string) (time.Duration, error) {
+	d, err := r.client.PTTL(ctx, lockKeyPrefix+kind+":"+name).Result()
+	if err != nil {
+		return 0, err
+	}
+	// negative values mean the key doesn't exist or has no expiration
+	if d < 0 {
+		return 0, nil
+	}
+	return d, nil
 }
 
-// IsLocked checks whether a login request is happened within a period of time or not
-// if it is, the authenticator should ignore the login request and return a failure immediately
-func (ul *UserLock) IsLocked(username string) bool {
-	ul.rw.RLock()
-	defer ul.rw.RUnlock()
-	return time.Since(ul.failures[username]) <= ul.d
+func (r *redisLock) Fail(ctx context.Context, kind, name string, policy *LockPolicy) (time.Duration, bool, error) {
+	failureKey := failureKeyPrefix + kind + ":" + name
+	failures, err := failScript.Run(ctx, r.client, []string{failureKey}, policy.Window.Milliseconds()).Int64()
+	if err != nil {
+		return 0, false, err
+	}
+
+	blocked, lockout := block(failures, policy)
+	if blocked == 0 {
+		return 0, false, nil
+	}
+	value := backoffValue
+	if lockout {
+		value = lockoutValue
+	}
+	if err := r.client.Set(ctx, lockKeyPrefix+kind+":"+name, value, blocked).Err(); err != nil {
+		return 0, false, err
+	}
+	if lockout {
+		// count the failures from scratch after the lockout
+		if err := r.client.Del(ctx, failureKey).Err(); err != nil {
+			return 0, false, err
+		}
+	}
+	return blocked, lockout, nil
+}
+
+func (r *redisLock) Reset(ctx context.Context, kind, name string) error {
+	return r.client.Del(ctx, lockKeyPrefix+kind+":"+name, failureKeyPrefix+kind+":"+name).Err()
+}
+
+func (r *redisLock) List(ctx context.Context) ([]*Lock, error) {
+	locks := []*Lock{}
+	iter := r.client.Scan(ctx, 0, lockKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		value, err := r.client.Get(ctx, key).Result()
+		if err == redis.Nil {
+			// expired after scanning
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if value != lockoutValue {
+			continue
+		}
+		d, err := r.client.PTTL(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			continue
+		}
+		kind, name, _ := strings.Cut(strings.TrimPrefix(key, lockKeyPrefix), ":")
+		locks = append(locks, &Lock{Kind: kind, Name: name, ExpiresAt: time.Now().Add(d)})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+// block returns the period to block the login after the failures according to the policy
+func block(failures int64, policy *LockPolicy) (time.Duration, bool) {
+	if policy.MaxFailures > 0 && failures >= int64(policy.MaxFailures) {
+		return policy.Duration, true
+	}
+	if policy.Backoff {
+		return backoff(failures), false
+	}
+	return 0, false
 }
@@ -0,0 +1,162 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	libredis "github.com/goharbor/harbor/src/lib/redis"
+)
+
+func TestBackoff(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(time.Duration(0), backoff(0))
+	assert.Equal(frozenTime, backoff(1))
+	assert.Equal(2*frozenTime, backoff(2))
+	assert.Equal(8*frozenTime, backoff(4))
+	assert.Equal(maxBackoff, backoff(10))
+	assert.Equal(maxBackoff, backoff(100))
+}
+
+func TestNormalizeIP(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("10.0.0.1", normalizeIP("10.0.0.1"))
+	assert.Equal("10.0.0.1", normalizeIP("10.0.0.1:52314"))
+	assert.Equal("172.16.0.1", normalizeIP("10.0.0.1, 172.16.0.1"))
+	assert.Equal("172.16.0.1", normalizeIP("10.0.0.1,172.16.0.1:52314"))
+	assert.Equal("::1", normalizeIP("[::1]:52314"))
+	assert.Equal("", normalizeIP(""))
+}
+
+func TestCheckLocked(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.TODO()
+	lock := NewMemoryLock()
+	_, _, err := lock.Fail(ctx, LockKindIP, "10.0.0.1", &LockPolicy{MaxFailures: 1, Window: time.Minute, Duration: time.Hour})
+	assert.Nil(err)
+
+	assert.Nil(checkLocked(ctx, lock, "john", ""))
+	assert.Nil(checkLocked(ctx, lock, "john", "10.0.0.2"))
+
+	err = checkLocked(ctx, lock, "john", "10.0.0.1")
+	locked, ok := err.(ErrLocked)
+	if assert.True(ok) {
+		assert.True(locked.RetryAfter > 59*time.Minute)
+	}
+}
+
+type LoginLockTestSuite struct {
+	suite.Suite
+	lock LoginLock
+}
+
+func (suite *LoginLockTestSuite) SetupTest() {
+	ctx := context.TODO()
+	for _, key := range [][2]string{{LockKindUser, "john"}, {LockKindUser, "jack"}, {LockKindIP, "10.0.0.1"}} {
+		suite.Require().NoError(suite.lock.Reset(ctx, key[0], key[1]))
+	}
+}
+
+func (suite *LoginLockTestSuite) TestBackoff() {
+	ctx := context.TODO()
+	policy := &LockPolicy{MaxFailures: 3, Window: time.Minute, Duration: time.Minute, Backoff: true}
+
+	d, err := suite.lock.Check(ctx, LockKindUser, "john")
+	suite.Require().NoError(err)
+	suite.Zero(d)
+
+	blocked, lockout, err := suite.lock.Fail(ctx, LockKindUser, "john", policy)
+	suite.Require().NoError(err)
+	suite.False(lockout)
+	suite.Equal(frozenTime, blocked)
+
+	blocked, lockout, err = suite.lock.Fail(ctx, LockKindUser, "john", policy)
+	suite.Require().NoError(err)
+	suite.False(lockout)
+	suite.Equal(2*frozenTime, blocked)
+
+	d, err = suite.lock.Check(ctx, LockKindUser, "john")
+	suite.Require().NoError(err)
+	suite.True(d > frozenTime && d <= 2*frozenTime)
+
+	// the back-offs aren't listed
+	locks, err := suite.lock.List(ctx)
+	suite.Require().NoError(err)
+	suite.Empty(locks)
+
+	// other users aren't affected
+	d, err = suite.lock.Check(ctx, LockKindUser, "jack")
+	suite.Require().NoError(err)
+	suite.Zero(d)
+}
+
+func (suite *LoginLockTestSuite) TestLockout() {
+	ctx := context.TODO()
+	policy := &LockPolicy{MaxFailures: 2, Window: time.Minute, Duration: time.Hour}
+
+	blocked, lockout, err := suite.lock.Fail(ctx, LockKindIP, "10.0.0.1", policy)
+	suite.Require().NoError(err)
+	suite.False(lockout)
+	suite.Zero(blocked)
+
+	blocked, lockout, err = suite.lock.Fail(ctx, LockKindIP, "10.0.0.1", policy)
+	suite.Require().NoError(err)
+	suite.True(lockout)
+	suite.Equal(time.Hour, blocked)
+
+	d, err := suite.lock.Check(ctx, LockKindIP, "10.0.0.1")
+	suite.Require().NoError(err)
+	suite.True(d > 59*time.Minute)
+
+	locks, err := suite.lock.List(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(locks, 1)
+	suite.Equal(LockKindIP, locks[0].Kind)
+	suite.Equal("10.0.0.1", locks[0].Name)
+
+	suite.Require().NoError(suite.lock.Reset(ctx, LockKindIP, "10.0.0.1"))
+	d, err = suite.lock.Check(ctx, LockKindIP, "10.0.0.1")
+	suite.Require().NoError(err)
+	suite.Zero(d)
+}
+
+func (suite *LoginLockTestSuite) TestDisabled() {
+	ctx := context.TODO()
+	policy := &LockPolicy{Window: time.Minute, Duration: time.Hour}
+
+	for range 10 {
+		blocked, lockout, err := suite.lock.Fail(ctx, LockKindUser, "jack", policy)
+		suite.Require().NoError(err)
+		suite.False(lockout)
+		suite.Zero(blocked)
+	}
+}
+
+func TestMemoryLock(t *testing.T) {
+	suite.Run(t, &LoginLockTestSuite{lock: NewMemoryLock()})
+}
+
+func TestRedisLock(t *testing.T) {
+	client, err := libredis.GetHarborClient()
+	if err != nil || client.Ping(context.TODO()).Err() != nil {
+		t.Skip("redis isn't available")
+	}
+	suite.Run(t, &LoginLockTestSuite{lock: NewRedisLock(client)})
+}
@@ -16,8 +16,10 @@ package controllers
 
 import (
 	"context"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/beego/beego/v2/server/web"
@@ -31,6 +33,8 @@ import (
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 )
@@ -96,9 +100,15 @@ func (cc *CommonController) Login() {
 	user, err := auth.Login(cc.Context(), models.AuthModel{
 		Principal: principal,
 		Password:  password,
+		ClientIP:  lib_http.GetClientIP(cc.Ctx.Request),
 	})
 	if err != nil {
 		log.Errorf("Error occurred in UserLogin: %v", err)
+		var locked auth.ErrLocked
+		if errors.As(err, &locked) {
+			cc.Ctx.Output.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(locked.RetryAfter.Seconds())), 10))
+			cc.CustomAbort(http.StatusTooManyRequests, "")
+		}
 		cc.CustomAbort(http.StatusUnauthorized, "")
 	}
 
@@ -208,6 +208,10 @@ func (oc *OIDCController) Callback() {
 		oc.SendError(err)
 		return
 	}
+	if u.Disabled {
+		oc.SendUnAuthorizedError(fmt.Errorf("the user %s is deactivated", u.Username))
+		return
+	}
 	oidc.InjectGroupsToUser(info, u)
 	um, err := ctluser.Ctl.Get(ctx, u.UserID, &ctluser.Option{WithOIDCInfo: true})
 	if err != nil {
@@ -35,7 +35,10 @@ import (
 	configCtl "github.com/goharbor/harbor/src/controller/config"
 	_ "github.com/goharbor/harbor/src/controller/event/handler"
 	"github.com/goharbor/harbor/src/controller/health"
+	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/controller/registry"
+	"github.com/goharbor/harbor/src/controller/robot"
+	"github.com/goharbor/harbor/src/controller/securityhub"
 	"github.com/goharbor/harbor/src/controller/systemartifact"
 	"github.com/goharbor/harbor/src/controller/task"
 	"github.com/goharbor/harbor/src/core/api"
@@ -319,6 +322,24 @@ func main() {
 		}, options...); err != nil {
 			log.Errorf("failed to schedule system artifact cleanup job, error: %v", err)
 		}
+		// schedule security hub snapshot job
+		if err := retry.Retry(func() error {
+			return securityhub.ScheduleSnapshotJob(ctx)
+		}, options...); err != nil {
+			log.Errorf("failed to schedule security hub snapshot job, error: %v", err)
+		}
+		// schedule robot expiration notification
+		if err := retry.Retry(func() error {
+			return robot.ScheduleExpirationNotification(ctx)
+		}, options...); err != nil {
+			log.Errorf("failed to schedule robot expiration notification, error: %v", err)
+		}
+		// schedule quota evaluation
+		if err := retry.Retry(func() error {
+			return quota.ScheduleEvaluation(ctx)
+		}, options...); err != nil {
+			log.Errorf("failed to schedule quota evaluation, error: %v", err)
+		}
 		// schedule system execution sweep job
 		if err := retry.Retry(func() error {
 			return task.ScheduleSweepJob(ctx)
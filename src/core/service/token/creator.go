@@ -163,20 +163,20 @@ func (rep repositoryFilter) filter(ctx context.Context, ctl project.Controller,
 		return err
 	}
 
-	resource := rbac_project.NewNamespace(project.ProjectID).Resource(rbac.ResourceRepository)
 	scopeList := make([]string, 0)
-	for s := range resourceScopes(ctx, resource) {
+	for s := range resourceScopes(ctx, project.ProjectID, img.repo) {
 		scopeList = append(scopeList, s)
 	}
 	a.Actions = scopeList
 	return nil
 }
 
-func resourceScopes(ctx context.Context, rc rbac.Resource) map[string]struct{} {
+// resourceScopes returns the scopes granted on the repository, the repository is the name without the project name
+func resourceScopes(ctx context.Context, projectID int64, repository string) map[string]struct{} {
 	sCtx, _ := security.FromContext(ctx)
 	res := map[string]struct{}{}
 	for a, s := range actionScopeMap {
-		if sCtx.Can(ctx, a, rc) {
+		if rbac_project.CanAccessRepository(ctx, sCtx, projectID, repository, a, rbac.ResourceRepository) {
 			res[s] = struct{}{}
 		}
 	}
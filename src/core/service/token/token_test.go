@@ -305,22 +305,26 @@ func TestResourceScopes(t *testing.T) {
 			project.NewNamespace(2).Resource(rbac.ResourceRepository): {rbac.ActionPull, rbac.ActionScannerPull, rbac.ActionPush},
 			project.NewNamespace(3).Resource(rbac.ResourceRepository): {rbac.ActionPull, rbac.ActionScannerPull, rbac.ActionPush, rbac.ActionDelete},
 			project.NewNamespace(4).Resource(rbac.ResourceRepository): {},
+			project.NewNamespace(1).Resource("repository/team-a/app"): {rbac.ActionPush},
 		},
 	}
 	ctx := security.NewContext(context.TODO(), sctx)
 	cases := []struct {
-		rc     rbac.Resource
-		expect map[string]struct{}
+		projectID  int64
+		repository string
+		expect     map[string]struct{}
 	}{
 		{
-			rc: project.NewNamespace(1).Resource(rbac.ResourceRepository),
+			projectID:  1,
+			repository: "app",
 			expect: map[string]struct{}{
 				"pull":         {},
 				"scanner-pull": {},
 			},
 		},
 		{
-			rc: project.NewNamespace(2).Resource(rbac.ResourceRepository),
+			projectID:  1,
+			repository: "team-a/app",
 			expect: map[string]struct{}{
 				"pull":         {},
 				"scanner-pull": {},
@@ -328,7 +332,17 @@ func TestResourceScopes(t *testing.T) {
 			},
 		},
 		{
-			rc: project.NewNamespace(3).Resource(rbac.ResourceRepository),
+			projectID:  2,
+			repository: "app",
+			expect: map[string]struct{}{
+				"pull":         {},
+				"scanner-pull": {},
+				"push":         {},
+			},
+		},
+		{
+			projectID:  3,
+			repository: "app",
 			expect: map[string]struct{}{
 				"pull":         {},
 				"scanner-pull": {},
@@ -337,15 +351,17 @@ func TestResourceScopes(t *testing.T) {
 			},
 		},
 		{
-			rc:     project.NewNamespace(4).Resource(rbac.ResourceRepository),
-			expect: map[string]struct{}{},
+			projectID:  4,
+			repository: "app",
+			expect:     map[string]struct{}{},
 		},
 		{
-			rc:     project.NewNamespace(5).Resource(rbac.ResourceRepository),
-			expect: map[string]struct{}{},
+			projectID:  5,
+			repository: "app",
+			expect:     map[string]struct{}{},
 		},
 	}
 	for _, c := range cases {
-		assert.Equal(t, c.expect, resourceScopes(ctx, c.rc))
+		assert.Equal(t, c.expect, resourceScopes(ctx, c.projectID, c.repository))
 	}
 }
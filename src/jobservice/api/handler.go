@@ -22,6 +22,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -65,6 +66,12 @@ type Handler interface {
 
 	// HandleGetConfigReq is used to handle the request of getting configure
 	HandleGetConfigReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleDrainReq is used to handle the request of draining the worker pool
+	HandleDrainReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleGetDrainStatusReq is used to handle the request of getting the draining status
+	HandleGetDrainStatusReq(w http.ResponseWriter, req *http.Request)
 }
 
 // DefaultHandler is the default request handler which implements the Handler interface.
@@ -203,7 +210,8 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
-	logData, err := dh.controller.GetJobLogData(jobID)
+	q := query.ParseLogQuery(req.URL.Query())
+	logData, err := dh.controller.GetJobLogData(jobID, q)
 	if err != nil {
 		code := http.StatusInternalServerError
 		if errs.IsObjectNotFoundError(err) {
@@ -219,6 +227,9 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 
 	dh.log(req, http.StatusOK, "")
 
+	if q.Format == query.LogFormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	}
 	w.WriteHeader(http.StatusOK)
 	writeDate(w, logData)
 }
@@ -300,16 +311,55 @@ func (dh *DefaultHandler) log(req *http.Request, code int, text string) {
 
 // HandleGetConfigReq return the config of the job service
 func (dh *DefaultHandler) HandleGetConfigReq(w http.ResponseWriter, req *http.Request) {
-	if config.DefaultConfig == nil || config.DefaultConfig.PoolConfig == nil || config.DefaultConfig.PoolConfig.RedisPoolCfg == nil {
+	if config.DefaultConfig == nil || config.DefaultConfig.PoolConfig == nil ||
+		(config.DefaultConfig.PoolConfig.Backend == config.JobServicePoolBackendRedis && config.DefaultConfig.PoolConfig.RedisPoolCfg == nil) {
 		logger.Errorf("Failed to get config, config is nil")
 		dh.handleError(w, req, http.StatusInternalServerError, errs.HandleJSONDataError(fmt.Errorf("no configuration")))
 		return
 	}
 	dh.handleJSONData(w, req, http.StatusOK, &job.Config{
+		Backend:         config.DefaultConfig.PoolConfig.Backend,
 		RedisPoolConfig: config.DefaultConfig.PoolConfig.RedisPoolCfg,
 	})
 }
 
+// HandleDrainReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleDrainReq(w http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.ReadRequestBodyError(err))
+		return
+	}
+
+	// The request body is optional
+	drainReq := &job.DrainRequest{}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, drainReq); err != nil {
+			dh.handleError(w, req, http.StatusBadRequest, errs.HandleJSONDataError(err))
+			return
+		}
+	}
+
+	status, err := dh.controller.Drain(time.Duration(drainReq.TimeoutSeconds) * time.Second)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsConflictError(err) {
+			code = http.StatusConflict
+		} else {
+			err = errs.DrainWorkerError(err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusAccepted, status)
+}
+
+// HandleGetDrainStatusReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleGetDrainStatusReq(w http.ResponseWriter, req *http.Request) {
+	dh.handleJSONData(w, req, http.StatusOK, dh.controller.GetDrainStatus())
+}
+
 func extractQuery(req *http.Request) *query.Parameter {
 	q := &query.Parameter{
 		PageNumber: 1,
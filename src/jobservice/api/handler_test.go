@@ -220,10 +220,34 @@ func (suite *APIHandlerTestSuite) TestCheckStatus() {
 	assert.Equal(suite.T(), "my-worker-pool-ID", poolStats.Pools[0].WorkerPoolID, "expected pool ID 'my-worker-pool-ID' but got %s", poolStats.Pools[0].WorkerPoolID)
 }
 
+// TestDrain ...
+func (suite *APIHandlerTestSuite) TestDrain() {
+	fc := &fakeController{}
+	fc.On("Drain", 30*time.Second).Return(&job.DrainStatus{Draining: true}, nil)
+	fc.On("Drain", time.Duration(0)).Return(nil, errs.ConflictError("drain of worker pool"))
+	fc.On("GetDrainStatus").Return(&job.DrainStatus{Draining: true, Running: []string{"fake_job_ID"}})
+	suite.controller = fc
+
+	data, _ := json.Marshal(&job.DrainRequest{TimeoutSeconds: 30})
+	res, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "drain"), data)
+	require.Equal(suite.T(), 202, code, "expected 202 accepted but got %d", code)
+	status := &job.DrainStatus{}
+	require.NoError(suite.T(), json.Unmarshal(res, status))
+	assert.True(suite.T(), status.Draining, "expect worker pool to be draining")
+
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "drain"), nil)
+	assert.Equal(suite.T(), 409, code, "expected 409 conflict but got %d", code)
+
+	res, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "drain"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	require.NoError(suite.T(), json.Unmarshal(res, status))
+	assert.Equal(suite.T(), []string{"fake_job_ID"}, status.Running)
+}
+
 // TestGetJobLogInvalidID ...
 func (suite *APIHandlerTestSuite) TestGetJobLogInvalidID() {
 	fc := &fakeController{}
-	fc.On("GetJobLogData", "fake_job_ID_not").Return(nil, errs.NoObjectFoundError("fake_job_ID_not"))
+	fc.On("GetJobLogData", "fake_job_ID_not", mock.Anything).Return(nil, errs.NoObjectFoundError("fake_job_ID_not"))
 	suite.controller = fc
 
 	_, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID_not/log"))
@@ -233,7 +257,7 @@ func (suite *APIHandlerTestSuite) TestGetJobLogInvalidID() {
 // TestGetJobLog ...
 func (suite *APIHandlerTestSuite) TestGetJobLog() {
 	fc := &fakeController{}
-	fc.On("GetJobLogData", "fake_job_ID").Return([]byte("hello log"), nil)
+	fc.On("GetJobLogData", "fake_job_ID", mock.Anything).Return([]byte("hello log"), nil)
 	suite.controller = fc
 
 	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log"))
@@ -241,6 +265,21 @@ func (suite *APIHandlerTestSuite) TestGetJobLog() {
 	assert.Equal(suite.T(), "hello log", string(resData))
 }
 
+// TestGetJobLogWithQuery ...
+func (suite *APIHandlerTestSuite) TestGetJobLogWithQuery() {
+	fc := &fakeController{}
+	fc.On("GetJobLogData", "fake_job_ID", &query.LogQuery{
+		Level:   "ERROR",
+		Keyword: "failed",
+		Format:  query.LogFormatJSON,
+	}).Return([]byte("[]"), nil)
+	suite.controller = fc
+
+	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?level=ERROR&q=failed&format=json"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), "[]", string(resData))
+}
+
 // TestGetPeriodicExecutionsWithoutQuery ...
 func (suite *APIHandlerTestSuite) TestGetPeriodicExecutionsWithoutQuery() {
 	q := &query.Parameter{
@@ -399,8 +438,8 @@ func (suite *APIHandlerTestSuite) CheckStatus() (*worker.Stats, error) {
 	return suite.controller.CheckStatus()
 }
 
-func (suite *APIHandlerTestSuite) GetJobLogData(jobID string) ([]byte, error) {
-	return suite.controller.GetJobLogData(jobID)
+func (suite *APIHandlerTestSuite) GetJobLogData(jobID string, q *query.LogQuery) ([]byte, error) {
+	return suite.controller.GetJobLogData(jobID, q)
 }
 
 func (suite *APIHandlerTestSuite) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
@@ -411,6 +450,14 @@ func (suite *APIHandlerTestSuite) GetJobs(query *query.Parameter) ([]*job.Stats,
 	return suite.controller.GetJobs(query)
 }
 
+func (suite *APIHandlerTestSuite) Drain(timeout time.Duration) (*job.DrainStatus, error) {
+	return suite.controller.Drain(timeout)
+}
+
+func (suite *APIHandlerTestSuite) GetDrainStatus() *job.DrainStatus {
+	return suite.controller.GetDrainStatus()
+}
+
 type fakeController struct {
 	mock.Mock
 }
@@ -452,8 +499,8 @@ func (fc *fakeController) CheckStatus() (*worker.Stats, error) {
 	return args.Get(0).(*worker.Stats), nil
 }
 
-func (fc *fakeController) GetJobLogData(jobID string) ([]byte, error) {
-	args := fc.Called(jobID)
+func (fc *fakeController) GetJobLogData(jobID string, q *query.LogQuery) ([]byte, error) {
+	args := fc.Called(jobID, q)
 	if args.Error(1) != nil {
 		return nil, args.Error(1)
 	}
@@ -479,6 +526,19 @@ func (fc *fakeController) GetJobs(query *query.Parameter) ([]*job.Stats, int64,
 	return args.Get(0).([]*job.Stats), args.Get(1).(int64), nil
 }
 
+func (fc *fakeController) Drain(timeout time.Duration) (*job.DrainStatus, error) {
+	args := fc.Called(timeout)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*job.DrainStatus), nil
+}
+
+func (fc *fakeController) GetDrainStatus() *job.DrainStatus {
+	return fc.Called().Get(0).(*job.DrainStatus)
+}
+
 func createJobStats(name, kind, cron string) *job.Stats {
 	now := time.Now()
 	params := make(job.Parameters)
@@ -102,4 +102,6 @@ func (br *BaseRouter) registerRoutes() {
 	subRouter.HandleFunc("/stats", br.handler.HandleCheckStatusReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/config", br.handler.HandleGetConfigReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/jobs/{job_id}/executions", br.handler.HandlePeriodicExecutions).Methods(http.MethodGet)
+	subRouter.HandleFunc("/drain", br.handler.HandleDrainReq).Methods(http.MethodPost)
+	subRouter.HandleFunc("/drain", br.handler.HandleGetDrainStatusReq).Methods(http.MethodGet)
 }
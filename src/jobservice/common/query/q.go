@@ -14,7 +14,11 @@
 
 package query
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
 
 const (
 	// DefaultPageSize defines the default page size
@@ -35,6 +39,16 @@ const (
 	ExtraParamKeyCursor = "Cursor"
 	// ExtraParamKeyKind defines extra parameter key for the job kind
 	ExtraParamKeyKind = "Kind"
+	// ParamKeyLogLevel defines query param key of the minimum level of the job log entries
+	ParamKeyLogLevel = "level"
+	// ParamKeyLogKeyword defines query param key of the keyword searched in the job log entries
+	ParamKeyLogKeyword = "q"
+	// ParamKeyLogFormat defines query param key of the format to render the job log
+	ParamKeyLogFormat = "format"
+	// LogFormatText renders the job log as plain text lines
+	LogFormatText = "text"
+	// LogFormatJSON renders the job log as an array of the structured entries
+	LogFormatJSON = "json"
 )
 
 // ExtraParameters to keep non pagination query parameters
@@ -70,3 +84,41 @@ type Parameter struct {
 	PageSize   uint
 	Extras     ExtraParameters
 }
+
+// LogQuery for filtering and rendering the job log
+type LogQuery struct {
+	// The minimum level of the entries, empty means all the levels
+	Level string
+	// The keyword contained by the entries case-insensitively, empty means no searching
+	Keyword string
+	// The format to render the entries, LogFormatText by default
+	Format string
+}
+
+// ParseLogQuery parses the job log query from the URL query values
+func ParseLogQuery(values url.Values) *LogQuery {
+	return &LogQuery{
+		Level:   strings.TrimSpace(values.Get(ParamKeyLogLevel)),
+		Keyword: values.Get(ParamKeyLogKeyword),
+		Format:  strings.ToLower(strings.TrimSpace(values.Get(ParamKeyLogFormat))),
+	}
+}
+
+// Values returns the URL query values of the job log query
+func (lq *LogQuery) Values() url.Values {
+	values := url.Values{}
+	if lq == nil {
+		return values
+	}
+	if len(lq.Level) > 0 {
+		values.Set(ParamKeyLogLevel, lq.Level)
+	}
+	if len(lq.Keyword) > 0 {
+		values.Set(ParamKeyLogKeyword, lq.Keyword)
+	}
+	if len(lq.Format) > 0 {
+		values.Set(ParamKeyLogFormat, lq.Format)
+	}
+
+	return values
+}
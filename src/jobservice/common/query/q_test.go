@@ -37,3 +37,14 @@ func (suite *QueryTestSuite) TestExtraParams() {
 	assert.Equal(suite.T(), true, ok)
 	assert.Equal(suite.T(), 100, int(v.(float64)))
 }
+
+// TestLogQuery tests the job log query
+func (suite *QueryTestSuite) TestLogQuery() {
+	lq := &LogQuery{Level: "WARNING", Keyword: "library/hello", Format: LogFormatJSON}
+	parsed := ParseLogQuery(lq.Values())
+	assert.Equal(suite.T(), lq, parsed)
+
+	var nilQuery *LogQuery
+	assert.Empty(suite.T(), nilQuery.Values())
+	assert.Equal(suite.T(), &LogQuery{}, ParseLogQuery(nilQuery.Values()))
+}
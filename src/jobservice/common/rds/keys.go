@@ -97,6 +97,41 @@ func KeyJobLockInfo(namespace string, jobType string) string {
 	return fmt.Sprintf("%s:lock_info", KeyJobs(namespace, jobType))
 }
 
+// KeyJobMaxConcurrency returns the key of the max concurrency for the specified job type.
+func KeyJobMaxConcurrency(namespace string, jobType string) string {
+	return fmt.Sprintf("%s:max_concurrency", KeyJobs(namespace, jobType))
+}
+
+// KeyJobPaused returns the key of the flag pausing the specified job type.
+func KeyJobPaused(namespace string, jobType string) string {
+	return fmt.Sprintf("%s:paused", KeyJobs(namespace, jobType))
+}
+
+// KeyScheduled returns the key of the queue holding the jobs scheduled to run later.
+func KeyScheduled(namespace string) string {
+	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "scheduled")
+}
+
+// KeyJobPolicies returns the key of the scheduling policies of the job types.
+func KeyJobPolicies(namespace string) string {
+	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "job_policies")
+}
+
+// KeyFairProjects returns the key of the projects having jobs waiting for the fair dispatching of the specified job type.
+func KeyFairProjects(namespace string, jobType string) string {
+	return fmt.Sprintf("%sfair:%s:projects", KeyNamespacePrefix(namespace), jobType)
+}
+
+// KeyFairQueue returns the key of the queue holding the jobs of the project for the fair dispatching of the specified job type.
+func KeyFairQueue(namespace string, jobType string, project string) string {
+	return fmt.Sprintf("%sfair:%s:queue:%s", KeyNamespacePrefix(namespace), jobType, project)
+}
+
+// KeyFairInFlight returns the key of the dispatched but unfinished jobs of the project of the specified job type.
+func KeyFairInFlight(namespace string, jobType string, project string) string {
+	return fmt.Sprintf("%sfair:%s:inflight:%s", KeyNamespacePrefix(namespace), jobType, project)
+}
+
 // KeyInProgressQueue returns the key of the in progress queue for the specified job type.
 func KeyInProgressQueue(namespace string, jobType string, workerPoolID string) string {
 	return fmt.Sprintf("%s:%s:inprogress", KeyJobs(namespace, jobType), workerPoolID)
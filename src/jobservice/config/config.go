@@ -40,6 +40,7 @@ const (
 	jobServiceRedisURL                   = "JOB_SERVICE_POOL_REDIS_URL"
 	jobServiceRedisNamespace             = "JOB_SERVICE_POOL_REDIS_NAMESPACE"
 	jobServiceRedisIdleConnTimeoutSecond = "JOB_SERVICE_POOL_REDIS_CONN_IDLE_TIMEOUT_SECOND"
+	jobServiceDrainTimeoutSecond         = "JOB_SERVICE_POOL_DRAIN_TIMEOUT_SECOND"
 	jobServiceAuthSecret                 = "JOBSERVICE_SECRET"
 	coreURL                              = "CORE_URL"
 	maxJobDurationSeconds                = "MAX_JOB_DURATION_SECONDS"
@@ -51,14 +52,22 @@ const (
 
 	// JobServicePoolBackendRedis represents redis backend
 	JobServicePoolBackendRedis = "redis"
+	// JobServicePoolBackendPostgreSQL represents the postgresql backend which shares the database of Harbor
+	JobServicePoolBackendPostgreSQL = "postgresql"
 
 	// secret of UI
 	uiAuthSecret = "CORE_SECRET"
 
 	// redis protocol schema
 	redisSchema = "redis://"
+
+	// the default max duration of waiting for the running jobs when draining the worker pool
+	defaultDrainTimeout = 60 * time.Second
 )
 
+// MaxJobPriority is the highest priority of the job type, it's exposed as job.MaxPriority
+const MaxJobPriority uint = 10000
+
 // DefaultConfig is the default configuration reference
 var DefaultConfig = &Configuration{}
 
@@ -114,6 +123,36 @@ type PoolConfig struct {
 	WorkerCount  uint             `yaml:"workers"`
 	Backend      string           `yaml:"backend"`
 	RedisPoolCfg *RedisPoolConfig `yaml:"redis_pool,omitempty"`
+	// The priority and concurrency limit of the job types, key is the vendor type of the job
+	JobPolicies map[string]*JobPolicyConfig `yaml:"job_policies,omitempty"`
+	// Schedule the jobs fairly among the projects, disabled if it's not set
+	FairScheduling *FairSchedulingConfig `yaml:"fair_scheduling,omitempty"`
+	// The max seconds of waiting for the running jobs to checkpoint when draining the worker pool
+	// on termination, the unfinished jobs are re-enqueued then. 60 seconds is used if it's not set
+	DrainTimeoutSecond uint `yaml:"drain_timeout_second"`
+}
+
+// FairSchedulingConfig keeps the settings of scheduling the jobs fairly among the projects.
+// The jobs of the specified types are dequeued round-robin across the projects carried in the job parameters.
+type FairSchedulingConfig struct {
+	// The vendor types of the jobs scheduled fairly
+	JobTypes []string `yaml:"job_types"`
+	// MaxInFlightPerProject is the max number of the dispatched but unfinished jobs of a project per job type,
+	// 0 means no limit
+	MaxInFlightPerProject uint `yaml:"max_in_flight_per_project"`
+	// QueueDepth is the max number of the jobs dispatched to the queue of the worker pool per job type,
+	// the worker count is used if it's not set
+	QueueDepth uint `yaml:"queue_depth"`
+}
+
+// JobPolicyConfig keeps the scheduling settings of the job type.
+type JobPolicyConfig struct {
+	// Priority between 1 and MaxJobPriority, the job type with the higher priority has more chance to be executed.
+	// The built-in priority of the job type is used if it's not set
+	Priority *uint `yaml:"priority"`
+	// MaxConcurrency is the max number of the running jobs of the job type, 0 means no limit.
+	// The limit declared by the job implementation is used if it's not set
+	MaxConcurrency *uint `yaml:"max_concurrency"`
 }
 
 // MetricConfig used for configure metrics
@@ -270,6 +309,18 @@ func (c *Configuration) loadEnvs() {
 		}
 	}
 
+	drainTimeout := utils.ReadEnv(jobServiceDrainTimeoutSecond)
+	if !utils.IsEmptyStr(drainTimeout) {
+		if seconds, err := strconv.Atoi(drainTimeout); err == nil && seconds >= 0 {
+			if c.PoolConfig == nil {
+				c.PoolConfig = &PoolConfig{}
+			}
+			c.PoolConfig.DrainTimeoutSecond = uint(seconds)
+		} else {
+			log.Warningf("Invalid drain timeout second: %s, will use the default one instead", drainTimeout)
+		}
+	}
+
 	if c.PoolConfig != nil && c.PoolConfig.Backend == JobServicePoolBackendRedis {
 		redisURL := utils.ReadEnv(jobServiceRedisURL)
 		if !utils.IsEmptyStr(redisURL) {
@@ -333,7 +384,8 @@ func (c *Configuration) validate() error {
 		return errors.New("no worker worker is configured")
 	}
 
-	if c.PoolConfig.Backend != JobServicePoolBackendRedis {
+	if c.PoolConfig.Backend != JobServicePoolBackendRedis &&
+		c.PoolConfig.Backend != JobServicePoolBackendPostgreSQL {
 		return fmt.Errorf("worker worker backend %s does not support", c.PoolConfig.Backend)
 	}
 
@@ -358,6 +410,19 @@ func (c *Configuration) validate() error {
 		}
 	}
 
+	if c.PoolConfig.FairScheduling != nil && len(c.PoolConfig.FairScheduling.JobTypes) == 0 {
+		return errors.New("job types of fair scheduling are required")
+	}
+
+	for jobType, policy := range c.PoolConfig.JobPolicies {
+		if policy == nil {
+			return fmt.Errorf("policy of job %s is empty", jobType)
+		}
+		if policy.Priority != nil && (*policy.Priority < 1 || *policy.Priority > MaxJobPriority) {
+			return fmt.Errorf("priority of job %s should be between 1 and %d, but current is %d", jobType, MaxJobPriority, *policy.Priority)
+		}
+	}
+
 	// Job service loggers
 	if len(c.LoggerConfigs) == 0 {
 		return errors.New("missing logger config of job service")
@@ -386,3 +451,11 @@ func MaxDanglingHour() int {
 	}
 	return 24 * 7
 }
+
+// DrainTimeout the max duration of waiting for the running jobs when draining the worker pool
+func DrainTimeout() time.Duration {
+	if DefaultConfig != nil && DefaultConfig.PoolConfig != nil && DefaultConfig.PoolConfig.DrainTimeoutSecond > 0 {
+		return time.Duration(DefaultConfig.PoolConfig.DrainTimeoutSecond) * time.Second
+	}
+	return defaultDrainTimeout
+}
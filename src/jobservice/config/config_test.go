@@ -82,6 +82,44 @@ func (suite *ConfigurationTestSuite) TestConfigLoadingWithEnv() {
 	assert.Equal(suite.T(), "core_url", GetCoreURL(), "expect core url 'core_url' but got '%s'", GetCoreURL())
 }
 
+// TestInvalidJobPolicy ...
+func (suite *ConfigurationTestSuite) TestInvalidJobPolicy() {
+	cfg := &Configuration{}
+	require.Nil(suite.T(), cfg.Load("../config_test.yml", false))
+
+	priority := MaxJobPriority + 1
+	cfg.PoolConfig.JobPolicies["REPLICATION"].Priority = &priority
+	assert.NotNil(suite.T(), cfg.validate(), "expect error for the priority out of range but got nil")
+
+	priority = 0
+	assert.NotNil(suite.T(), cfg.validate(), "expect error for the zero priority but got nil")
+
+	cfg.PoolConfig.JobPolicies["REPLICATION"].Priority = nil
+	assert.Nil(suite.T(), cfg.validate(), "expect no error for the priority not set")
+}
+
+// TestInvalidFairScheduling ...
+func (suite *ConfigurationTestSuite) TestInvalidFairScheduling() {
+	cfg := &Configuration{}
+	require.Nil(suite.T(), cfg.Load("../config_test.yml", false))
+
+	cfg.PoolConfig.FairScheduling.JobTypes = nil
+	assert.NotNil(suite.T(), cfg.validate(), "expect error for the fair scheduling without job types but got nil")
+}
+
+// TestPoolBackend ...
+func (suite *ConfigurationTestSuite) TestPoolBackend() {
+	cfg := &Configuration{}
+	require.Nil(suite.T(), cfg.Load("../config_test.yml", false))
+
+	cfg.PoolConfig.Backend = JobServicePoolBackendPostgreSQL
+	cfg.PoolConfig.RedisPoolCfg = nil
+	assert.Nil(suite.T(), cfg.validate(), "expect nil error for the postgresql backend without redis")
+
+	cfg.PoolConfig.Backend = "mysql"
+	assert.NotNil(suite.T(), cfg.validate(), "expect error for the unsupported backend but got nil")
+}
+
 // TestDefaultConfig ...
 func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	err := DefaultConfig.Load("../config_test.yml", true)
@@ -97,6 +135,20 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	redisURL := DefaultConfig.PoolConfig.RedisPoolCfg.RedisURL
 	assert.Equal(suite.T(), "redis://localhost:6379", redisURL, "expect redisURL '%s' but got '%s'", "redis://localhost:6379", redisURL)
 
+	policies := DefaultConfig.PoolConfig.JobPolicies
+	require.Equal(suite.T(), 2, len(policies), "expect 2 job policies configured but got %d", len(policies))
+	require.NotNil(suite.T(), policies["REPLICATION"].Priority, "expect priority of REPLICATION")
+	assert.Equal(suite.T(), uint(2000), *policies["REPLICATION"].Priority, "expect priority of REPLICATION to be 2000 but got %d", *policies["REPLICATION"].Priority)
+	assert.Nil(suite.T(), policies["REPLICATION"].MaxConcurrency, "expect no max concurrency of REPLICATION")
+	require.NotNil(suite.T(), policies["IMAGE_SCAN"].MaxConcurrency, "expect max concurrency of IMAGE_SCAN")
+	assert.Equal(suite.T(), uint(5), *policies["IMAGE_SCAN"].MaxConcurrency, "expect max concurrency of IMAGE_SCAN to be 5 but got %d", *policies["IMAGE_SCAN"].MaxConcurrency)
+
+	fair := DefaultConfig.PoolConfig.FairScheduling
+	require.NotNil(suite.T(), fair, "expect fair scheduling configured")
+	assert.Equal(suite.T(), []string{"IMAGE_SCAN", "RETENTION", "REPLICATION"}, fair.JobTypes)
+	assert.Equal(suite.T(), uint(2), fair.MaxInFlightPerProject, "expect max in-flight jobs per project to be 2 but got %d", fair.MaxInFlightPerProject)
+	assert.Equal(suite.T(), 120*time.Second, DrainTimeout(), "expect drain timeout to be 120s but got %v", DrainTimeout())
+
 	jLoggerCount := len(DefaultConfig.JobLoggerConfigs)
 	assert.Equal(suite.T(), 2, jLoggerCount, "expect 2 job loggers configured but got %d", jLoggerCount)
 
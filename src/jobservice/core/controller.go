@@ -16,10 +16,12 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	comUtils "github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -127,12 +129,33 @@ func (bc *basicController) RetryJob(jobID string) error {
 }
 
 // GetJobLogData is used to return the log text data for the specified job if exists
-func (bc *basicController) GetJobLogData(jobID string) ([]byte, error) {
+func (bc *basicController) GetJobLogData(jobID string, q *query.LogQuery) ([]byte, error) {
 	if utils.IsEmptyStr(jobID) {
 		return nil, errs.BadRequestError(errors.New("empty job ID"))
 	}
 
-	return logger.Retrieve(jobID)
+	data, err := logger.Retrieve(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil || *q == (query.LogQuery{}) {
+		// keep the raw log data as it is if no query specified, unless the entries are stored as JSON lines
+		// which are rendered as the plain text for the callers expecting the text log
+		if !logger.HasJSONRecords(data) {
+			return data, nil
+		}
+		q = &query.LogQuery{}
+	}
+
+	result, err := logger.Query(data, q)
+	if err != nil {
+		if errors.IsErr(err, errors.BadRequestCode) {
+			return nil, errs.BadRequestError(err)
+		}
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // CheckStatus is implementation of same method in core interface.
@@ -140,6 +163,23 @@ func (bc *basicController) CheckStatus() (*worker.Stats, error) {
 	return bc.backendWorker.Stats()
 }
 
+// Drain is implementation of same method in core interface.
+func (bc *basicController) Drain(timeout time.Duration) (*job.DrainStatus, error) {
+	if timeout <= 0 {
+		timeout = config.DrainTimeout()
+	}
+	if err := bc.backendWorker.Drain(timeout); err != nil {
+		return nil, err
+	}
+
+	return bc.backendWorker.DrainStatus(), nil
+}
+
+// GetDrainStatus is implementation of same method in core interface.
+func (bc *basicController) GetDrainStatus() *job.DrainStatus {
+	return bc.backendWorker.DrainStatus()
+}
+
 // GetPeriodicExecutions gets the periodic executions for the specified periodic job
 func (bc *basicController) GetPeriodicExecutions(periodicJobID string, query *query.Parameter) ([]*job.Stats, int64, error) {
 	if utils.IsEmptyStr(periodicJobID) {
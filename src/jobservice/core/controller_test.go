@@ -15,6 +15,7 @@ package core
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -128,6 +129,18 @@ func (suite *ControllerTestSuite) TestJobActions() {
 	assert.Nil(suite.T(), err, "job action: nil error expected but got %s", err)
 }
 
+// TestDrain ...
+func (suite *ControllerTestSuite) TestDrain() {
+	status := &job.DrainStatus{Draining: true}
+	suite.worker.On("Drain", 30*time.Second).Return(nil)
+	suite.worker.On("DrainStatus").Return(status)
+
+	res, err := suite.ctl.Drain(30 * time.Second)
+	require.Nil(suite.T(), err, "drain: nil error expected but got %s", err)
+	assert.True(suite.T(), res.Draining, "expect worker pool to be draining")
+	assert.Equal(suite.T(), status, suite.ctl.GetDrainStatus())
+}
+
 // TestCheckStatus ...
 func (suite *ControllerTestSuite) TestCheckStatus() {
 	suite.worker.On("Stats").Return(&worker.Stats{
@@ -271,6 +284,14 @@ func (suite *ControllerTestSuite) RetryJob(jobID string) error {
 	return suite.worker.RetryJob(jobID)
 }
 
+func (suite *ControllerTestSuite) Drain(timeout time.Duration) error {
+	return suite.worker.Drain(timeout)
+}
+
+func (suite *ControllerTestSuite) DrainStatus() *job.DrainStatus {
+	return suite.worker.DrainStatus()
+}
+
 // Implement manager interface
 func (suite *ControllerTestSuite) GetJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
 	return suite.manager.GetJobs(q)
@@ -366,6 +387,18 @@ func (f *fakeWorker) RetryJob(jobID string) error {
 	return f.Called(jobID).Error(0)
 }
 
+func (f *fakeWorker) Drain(timeout time.Duration) error {
+	return f.Called(timeout).Error(0)
+}
+
+func (f *fakeWorker) DrainStatus() *job.DrainStatus {
+	if s := f.Called().Get(0); s != nil {
+		return s.(*job.DrainStatus)
+	}
+
+	return nil
+}
+
 // fake manager
 type fakeManager struct {
 	mock.Mock
@@ -16,6 +16,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/worker"
@@ -60,8 +62,9 @@ type Interface interface {
 	// CheckStatus is used to handle the job service healthy status checking request.
 	CheckStatus() (*worker.Stats, error)
 
-	// GetJobLogData is used to return the log text data for the specified job if exists
-	GetJobLogData(jobID string) ([]byte, error)
+	// GetJobLogData is used to return the log text data for the specified job if exists.
+	// The entries are filtered and rendered with the log query if it's not nil.
+	GetJobLogData(jobID string, q *query.LogQuery) ([]byte, error)
 
 	// Get the periodic executions for the specified periodic job.
 	// Pagination by query is supported.
@@ -75,4 +78,16 @@ type Interface interface {
 	// For other cases, query the jobs with cursor, not standard pagination. The int64 is next cursor.
 	// The total number is also returned.
 	GetJobs(query *query.Parameter) ([]*job.Stats, int64, error)
+
+	// Drain the worker pool for handing over the jobs to the other pools, the job service exits once it's drained.
+	//
+	// timeout	time.Duration: the max duration of waiting for the running jobs, the configured one is used if it's zero.
+	//
+	// Returns:
+	//	*job.DrainStatus : the draining status.
+	//  error            : Error returned if the worker pool is already draining.
+	Drain(timeout time.Duration) (*job.DrainStatus, error)
+
+	// GetDrainStatus is used to return the draining status of the worker pool.
+	GetDrainStatus() *job.DrainStatus
 }
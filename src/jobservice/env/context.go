@@ -37,4 +37,10 @@ type Context struct {
 	// The base job context reference
 	// It will be the parent context of job execution context
 	JobContext job.Context
+
+	// The paused job types and executions persisted in the database
+	PauseState *job.PauseState
+
+	// The draining state of the worker pool for handing over the jobs to the other pools
+	DrainState *job.DrainState
 }
@@ -57,6 +57,8 @@ const (
 	GetPeriodicExecutionErrorCode
 	// StatusMismatchErrorCode is code for the error of mismatching status
 	StatusMismatchErrorCode
+	// DrainWorkerErrorCode is code for the error of draining worker pool
+	DrainWorkerErrorCode
 )
 
 // baseError ...
@@ -153,6 +155,11 @@ func GetPeriodicExecutionError(err error) error {
 	return New(GetPeriodicExecutionErrorCode, "failed to get periodic executions", err.Error())
 }
 
+// DrainWorkerError is error for the case of draining worker pool failed
+func DrainWorkerError(err error) error {
+	return New(DrainWorkerErrorCode, "drain worker pool failed with error", err.Error())
+}
+
 // objectNotFound is designed for the case of no object found
 type objectNotFoundError struct {
 	baseError
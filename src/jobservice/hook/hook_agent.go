@@ -64,23 +64,43 @@ func (e *Event) Validate() error {
 	return nil
 }
 
+// ackStore persists the ACKs of the hook events in the job stats.
+type ackStore interface {
+	// Set the ACK of the event if the event is not outdated.
+	ack(evt *Event) error
+
+	// Get the ACK of the job, nil returned if no ACK exists.
+	getAck(jobID string) (*job.ACK, error)
+}
+
 // Basic agent for usage
 type basicAgent struct {
-	context   context.Context
-	namespace string
-	client    Client
-	redisPool *redis.Pool
-	tokens    chan struct{}
+	context context.Context
+	client  Client
+	store   ackStore
+	tokens  chan struct{}
 }
 
 // NewAgent is constructor of basic agent
 func NewAgent(ctx *env.Context, ns string, redisPool *redis.Pool, retryConcurrency uint) Agent {
 	return &basicAgent{
-		context:   ctx.SystemContext,
-		namespace: ns,
-		client:    NewClient(ctx.SystemContext),
-		redisPool: redisPool,
-		tokens:    make(chan struct{}, retryConcurrency),
+		context: ctx.SystemContext,
+		client:  NewClient(ctx.SystemContext),
+		store: &redisAckStore{
+			namespace: ns,
+			redisPool: redisPool,
+		},
+		tokens: make(chan struct{}, retryConcurrency),
+	}
+}
+
+// NewPgAgent is constructor of the agent keeping the ACKs in the postgresql database
+func NewPgAgent(ctx *env.Context, retryConcurrency uint) Agent {
+	return &basicAgent{
+		context: ctx.SystemContext,
+		client:  NewClient(ctx.SystemContext),
+		store:   &pgAckStore{context: ctx.SystemContext},
+		tokens:  make(chan struct{}, retryConcurrency),
 	}
 }
 
@@ -109,7 +129,7 @@ func (ba *basicAgent) Trigger(evt *Event) error {
 	// The ACK can be used by the reaper to justify if the hook event should be resent again.
 	// The failure of persisting this ACK may cause duplicated hook event resending issue, which
 	// can be ignored.
-	if err := ba.ack(evt); err != nil {
+	if err := ba.store.ack(evt); err != nil {
 		// Just log error
 		logger.Error(errors.Wrap(err, "hook event ack error"))
 	}
@@ -160,61 +180,18 @@ func (ba *basicAgent) retry(evt *Event) {
 	}
 }
 
-// ack hook event
-func (ba *basicAgent) ack(evt *Event) error {
-	conn := ba.redisPool.Get()
-	defer func() {
-		if err := conn.Close(); err != nil {
-			logger.Error(errors.Wrap(err, "ack"))
-		}
-	}()
-
-	k := rds.KeyJobStats(ba.namespace, evt.Data.JobID)
-	k2 := rds.KeyJobTrackInProgress(ba.namespace)
-	reply, err := redis.String(rds.HookAckScript.Do(
-		conn,
-		k,
-		k2,
-		evt.Data.Status,
-		evt.Data.Metadata.Revision,
-		evt.Data.Metadata.CheckInAt,
-		evt.Data.JobID,
-	))
-	if err != nil {
-		return errors.Wrap(err, "ack")
-	}
-
-	if reply != "ok" {
-		return errors.Errorf("no ack done for event: %s", evt.Message)
-	}
-
-	return nil
-}
-
 // Check if the event has been outdated.
 func (ba *basicAgent) isOutdated(evt *Event) (bool, error) {
 	if evt == nil || evt.Data == nil {
 		return false, nil
 	}
 
-	conn := ba.redisPool.Get()
-	defer func() {
-		_ = conn.Close()
-	}()
-
-	key := rds.KeyJobStats(ba.namespace, evt.Data.JobID)
-	values, err := rds.HmGet(conn, key, "ack")
+	ack, err := ba.store.getAck(evt.Data.JobID)
 	if err != nil {
 		return false, errors.Wrap(err, "check outdated event error")
 	}
 
-	// Parse ack
-	if ab, ok := values[0].([]byte); ok && len(ab) > 0 {
-		ack := &job.ACK{}
-		if err := json.Unmarshal(ab, ack); err != nil {
-			return false, errors.Wrap(err, "parse ack error")
-		}
-
+	if ack != nil {
 		// Revision
 		diff := ack.Revision - evt.Data.Metadata.Revision
 		switch {
@@ -257,6 +234,69 @@ func (ba *basicAgent) isOutdated(evt *Event) (bool, error) {
 	return false, nil
 }
 
+// redisAckStore keeps the ACKs in the job stats hash in redis
+type redisAckStore struct {
+	namespace string
+	redisPool *redis.Pool
+}
+
+// ack hook event
+func (rs *redisAckStore) ack(evt *Event) error {
+	conn := rs.redisPool.Get()
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Error(errors.Wrap(err, "ack"))
+		}
+	}()
+
+	k := rds.KeyJobStats(rs.namespace, evt.Data.JobID)
+	k2 := rds.KeyJobTrackInProgress(rs.namespace)
+	reply, err := redis.String(rds.HookAckScript.Do(
+		conn,
+		k,
+		k2,
+		evt.Data.Status,
+		evt.Data.Metadata.Revision,
+		evt.Data.Metadata.CheckInAt,
+		evt.Data.JobID,
+	))
+	if err != nil {
+		return errors.Wrap(err, "ack")
+	}
+
+	if reply != "ok" {
+		return errors.Errorf("no ack done for event: %s", evt.Message)
+	}
+
+	return nil
+}
+
+// getAck of the job
+func (rs *redisAckStore) getAck(jobID string) (*job.ACK, error) {
+	conn := rs.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyJobStats(rs.namespace, jobID)
+	values, err := rds.HmGet(conn, key, "ack")
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse ack
+	if ab, ok := values[0].([]byte); ok && len(ab) > 0 {
+		ack := &job.ACK{}
+		if err := json.Unmarshal(ab, ack); err != nil {
+			return nil, errors.Wrap(err, "parse ack error")
+		}
+
+		return ack, nil
+	}
+
+	return nil, nil
+}
+
 func newBackoff(maxElapsedTime time.Duration) backoff.BackOff {
 	bf := backoff.NewExponentialBackOff()
 	bf.InitialInterval = 2 * time.Second
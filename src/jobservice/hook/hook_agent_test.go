@@ -54,9 +54,11 @@ func (suite *HookAgentTestSuite) SetupSuite() {
 	suite.namespace = tests.GiveMeTestNamespace()
 
 	suite.agent = &basicAgent{
-		context:   context.TODO(),
-		namespace: suite.namespace,
-		redisPool: suite.pool,
+		context: context.TODO(),
+		store: &redisAckStore{
+			namespace: suite.namespace,
+			redisPool: suite.pool,
+		},
 	}
 
 	suite.prepareData()
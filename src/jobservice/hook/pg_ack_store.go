@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	o "github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// pgAckStore keeps the ACKs in the job stats table of the postgresql database
+type pgAckStore struct {
+	context context.Context
+}
+
+// ack hook event, the same rule as the redis script is applied:
+// without ACK, the event of the current or a newer revision can be ACKed;
+// with ACK, the event can be ACKed when the ACKed revision is older, or it's the same revision
+// but the ACKed status is not later than the event status or the event is a check in.
+func (ps *pgAckStore) ack(evt *Event) error {
+	ormer, err := orm.FromContext(orm.Copy(ps.context))
+	if err != nil {
+		return err
+	}
+
+	ack := &job.ACK{
+		Status:    evt.Data.Status,
+		Revision:  evt.Data.Metadata.Revision,
+		CheckInAt: evt.Data.Metadata.CheckInAt,
+	}
+	st := job.Status(evt.Data.Status)
+	sql := fmt.Sprintf(`UPDATE job_service_stats SET ack = ?::jsonb WHERE job_id = ? AND (
+		(ack IS NULL AND revision <= ?) OR
+		(ack IS NOT NULL AND ((ack->>'revision')::bigint < ? OR
+		((ack->>'revision')::bigint = ? AND (%s <= ? OR ? <> 0)))))`, job.PgStatusCode("ack->>'status'"))
+	res, err := ormer.Raw(sql, ack.JSON(), evt.Data.JobID, ack.Revision, ack.Revision, ack.Revision,
+		st.Code(), ack.CheckInAt).Exec()
+	if err != nil {
+		return errors.Wrap(err, "ack")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "ack")
+	}
+	if n == 0 {
+		return errors.Errorf("no ack done for event: %s", evt.Message)
+	}
+
+	return nil
+}
+
+// getAck of the job
+func (ps *pgAckStore) getAck(jobID string) (*job.ACK, error) {
+	ormer, err := orm.FromContext(orm.Copy(ps.context))
+	if err != nil {
+		return nil, err
+	}
+
+	var data string
+	if err := ormer.Raw(`SELECT COALESCE(ack::text, '') FROM job_service_stats WHERE job_id = ?`, jobID).QueryRow(&data); err != nil {
+		if errors.Is(err, o.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	ack := &job.ACK{}
+	if err := json.Unmarshal([]byte(data), ack); err != nil {
+		return nil, errors.Wrap(err, "parse ack error")
+	}
+
+	return ack, nil
+}
@@ -0,0 +1,349 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CheckpointParamKey is the key of the job parameter carrying the checkpoint of the job drained from another
+// worker pool, the job can resume from it instead of starting over.
+const CheckpointParamKey = "_checkpoint_"
+
+// AbortTimeout is the time to wait for the aborted jobs to exit when the draining reaches the deadline
+const AbortTimeout = 30 * time.Second
+
+// checkpointErrorMessage is kept as the last error of the checkpointed job by the worker pool
+const checkpointErrorMessage = "job is checkpointed for draining"
+
+// CheckpointError is returned by the job exiting on the CheckpointCommand, the job is re-enqueued
+// immediately with the checkpoint data without consuming the retries.
+type CheckpointError struct {
+	Data string
+}
+
+// Error implements the error interface
+func (ce *CheckpointError) Error() string {
+	return checkpointErrorMessage
+}
+
+// Checkpoint returns the error for the job to exit with the progress when receiving the CheckpointCommand,
+// the data is passed to the re-enqueued job with the parameter CheckpointParamKey.
+func Checkpoint(data string) error {
+	return &CheckpointError{Data: data}
+}
+
+// CheckpointOf returns the checkpoint data if the error is returned by Checkpoint
+func CheckpointOf(err error) (string, bool) {
+	var ce *CheckpointError
+	if errors.As(err, &ce) {
+		return ce.Data, true
+	}
+
+	return "", false
+}
+
+// IsCheckpointed checks whether the last error kept by the worker pool is caused by the checkpoint
+func IsCheckpointed(lastErr string) bool {
+	return lastErr == checkpointErrorMessage
+}
+
+// Checkpoint returns the checkpoint data saved by the job when it was drained
+func (p Parameters) Checkpoint() (string, bool) {
+	v, ok := p[CheckpointParamKey]
+	if !ok {
+		return "", false
+	}
+
+	data, ok := v.(string)
+	return data, ok
+}
+
+// DrainStatus is the draining status of the worker pool
+type DrainStatus struct {
+	Draining  bool     `json:"draining"`
+	Completed bool     `json:"completed"`
+	StartedAt int64    `json:"started_at,omitempty"`
+	Deadline  int64    `json:"deadline,omitempty"`
+	Running   []string `json:"running_jobs"`
+	Requeued  []string `json:"requeued_jobs"`
+}
+
+// DrainState keeps the draining state of the worker pool. When draining, the worker pool stops taking
+// new jobs and the running ones are signaled to checkpoint, the jobs still running at the deadline are
+// aborted and handed over to the other worker pools once they exit. The methods are safe to be called
+// on the nil DrainState which never drains.
+type DrainState struct {
+	lock      sync.Mutex
+	startedAt time.Time
+	deadline  time.Time
+	completed bool
+	running   map[string]struct{}
+	requeued  []string
+	// the jobs running when aborting
+	aborted map[string]struct{}
+	// closed when the running jobs are aborted
+	abort chan struct{}
+	// signaled when a running job leaves
+	left chan struct{}
+	// closed when the draining is completed
+	done chan struct{}
+}
+
+// NewDrainState is constructor of DrainState
+func NewDrainState() *DrainState {
+	return &DrainState{
+		running: make(map[string]struct{}),
+		left:    make(chan struct{}, 1),
+		abort:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start to drain with the timeout of waiting for the running jobs, false is returned if it's already draining
+func (ds *DrainState) Start(timeout time.Duration) bool {
+	if ds == nil {
+		return false
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	if !ds.startedAt.IsZero() {
+		return false
+	}
+	ds.startedAt = time.Now()
+	ds.deadline = ds.startedAt.Add(timeout)
+
+	return true
+}
+
+// Draining checks whether the worker pool is draining
+func (ds *DrainState) Draining() bool {
+	if ds == nil {
+		return false
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	return !ds.startedAt.IsZero()
+}
+
+// Enter tracks the job starting to run, false is returned if the worker pool is draining
+// and the job should be handed over to the other worker pools.
+func (ds *DrainState) Enter(jobID string) bool {
+	if ds == nil {
+		return true
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	if !ds.startedAt.IsZero() {
+		return false
+	}
+	ds.running[jobID] = struct{}{}
+
+	return true
+}
+
+// Leave untracks the job which exits
+func (ds *DrainState) Leave(jobID string) {
+	if ds == nil {
+		return
+	}
+
+	ds.lock.Lock()
+	delete(ds.running, jobID)
+	ds.lock.Unlock()
+
+	select {
+	case ds.left <- struct{}{}:
+	default:
+	}
+}
+
+// Wait until all the running jobs exit or the deadline is reached, the IDs of the jobs still running are returned
+func (ds *DrainState) Wait() []string {
+	if ds == nil {
+		return nil
+	}
+
+	ds.lock.Lock()
+	deadline := ds.deadline
+	ds.lock.Unlock()
+
+	return ds.waitUntil(deadline)
+}
+
+// Abort the running jobs, they're signaled to stop and their contexts are canceled so that they aren't run
+// twice after being handed over. It waits for the jobs to exit within the timeout, the IDs of the aborted jobs
+// which exit and are handed over and the IDs of the jobs still running are returned.
+func (ds *DrainState) Abort(timeout time.Duration) ([]string, []string) {
+	if ds == nil {
+		return nil, nil
+	}
+
+	ds.lock.Lock()
+	if ds.aborted == nil {
+		ds.aborted = make(map[string]struct{}, len(ds.running))
+		for id := range ds.running {
+			ds.aborted[id] = struct{}{}
+		}
+		close(ds.abort)
+	}
+	ds.lock.Unlock()
+
+	running := ds.waitUntil(time.Now().Add(timeout))
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	handedOver := make([]string, 0, len(ds.aborted))
+	for id := range ds.aborted {
+		if _, ok := ds.running[id]; !ok {
+			handedOver = append(handedOver, id)
+		}
+	}
+	sort.Strings(handedOver)
+
+	return handedOver, running
+}
+
+// Aborted checks whether the job is aborted, the aborted job should be handed over whatever it returns
+func (ds *DrainState) Aborted(jobID string) bool {
+	if ds == nil {
+		return false
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	_, ok := ds.aborted[jobID]
+	return ok
+}
+
+// JobContext returns the context of the running job which is canceled when the jobs are aborted
+func (ds *DrainState) JobContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if ds == nil {
+		return ctx, cancel
+	}
+
+	go func() {
+		select {
+		case <-ds.abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// waitUntil waits until all the running jobs exit or the deadline is reached
+func (ds *DrainState) waitUntil(deadline time.Time) []string {
+	for {
+		ds.lock.Lock()
+		if len(ds.running) == 0 {
+			ds.lock.Unlock()
+			return nil
+		}
+		remaining := time.Until(deadline)
+		ds.lock.Unlock()
+
+		if remaining <= 0 {
+			return ds.runningJobs()
+		}
+
+		tm := time.NewTimer(remaining)
+		select {
+		case <-ds.left:
+		case <-tm.C:
+		}
+		tm.Stop()
+	}
+}
+
+// Complete the draining with the IDs of the handed over jobs
+func (ds *DrainState) Complete(requeued []string) {
+	if ds == nil {
+		return
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	if ds.completed {
+		return
+	}
+	ds.completed = true
+	ds.requeued = requeued
+	close(ds.done)
+}
+
+// Done returns the channel closed when the draining is completed
+func (ds *DrainState) Done() <-chan struct{} {
+	if ds == nil {
+		return nil
+	}
+
+	return ds.done
+}
+
+// Status returns the draining status
+func (ds *DrainState) Status() *DrainStatus {
+	status := &DrainStatus{
+		Running:  make([]string, 0),
+		Requeued: make([]string, 0),
+	}
+	if ds == nil {
+		return status
+	}
+
+	status.Running = ds.runningJobs()
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	if !ds.startedAt.IsZero() {
+		status.Draining = true
+		status.StartedAt = ds.startedAt.Unix()
+		status.Deadline = ds.deadline.Unix()
+	}
+	status.Completed = ds.completed
+	status.Requeued = append(status.Requeued, ds.requeued...)
+
+	return status
+}
+
+// runningJobs returns the sorted IDs of the running jobs
+func (ds *DrainState) runningJobs() []string {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	ids := make([]string, 0, len(ds.running))
+	for id := range ds.running {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckpoint tests the checkpoint error and parameter
+func TestCheckpoint(t *testing.T) {
+	data, ok := CheckpointOf(fmt.Errorf("wrapped: %w", Checkpoint("offset=10")))
+	assert.True(t, ok)
+	assert.Equal(t, "offset=10", data)
+
+	_, ok = CheckpointOf(fmt.Errorf("failed"))
+	assert.False(t, ok)
+	_, ok = CheckpointOf(nil)
+	assert.False(t, ok)
+
+	assert.True(t, IsCheckpointed(Checkpoint("").Error()))
+	assert.False(t, IsCheckpointed("failed"))
+
+	data, ok = Parameters{CheckpointParamKey: "offset=10"}.Checkpoint()
+	assert.True(t, ok)
+	assert.Equal(t, "offset=10", data)
+	_, ok = Parameters{}.Checkpoint()
+	assert.False(t, ok)
+}
+
+// TestDrainState tests the drain state
+func TestDrainState(t *testing.T) {
+	var nilState *DrainState
+	assert.False(t, nilState.Draining())
+	assert.True(t, nilState.Enter("job"))
+	assert.False(t, nilState.Status().Draining)
+
+	ds := NewDrainState()
+	require.True(t, ds.Enter("job-1"))
+	require.True(t, ds.Enter("job-2"))
+	assert.Equal(t, []string{"job-1", "job-2"}, ds.Status().Running)
+
+	require.True(t, ds.Start(time.Minute))
+	assert.False(t, ds.Start(time.Minute))
+	assert.True(t, ds.Draining())
+	// no new jobs are taken when draining
+	assert.False(t, ds.Enter("job-3"))
+
+	go func() {
+		ds.Leave("job-1")
+		ds.Leave("job-2")
+	}()
+	assert.Empty(t, ds.Wait())
+
+	ds.Complete(nil)
+	select {
+	case <-ds.Done():
+	default:
+		t.Fatal("expect the draining to be completed")
+	}
+	status := ds.Status()
+	assert.True(t, status.Draining)
+	assert.True(t, status.Completed)
+	assert.Empty(t, status.Running)
+}
+
+// TestDrainStateDeadline tests the jobs still running at the deadline are returned
+func TestDrainStateDeadline(t *testing.T) {
+	ds := NewDrainState()
+	require.True(t, ds.Enter("job-1"))
+	require.True(t, ds.Enter("job-2"))
+	require.True(t, ds.Start(100*time.Millisecond))
+
+	ds.Leave("job-1")
+	assert.Equal(t, []string{"job-2"}, ds.Wait())
+
+	ds.Complete([]string{"job-2"})
+	assert.Equal(t, []string{"job-2"}, ds.Status().Requeued)
+}
+
+// TestDrainStateAbort tests the jobs still running at the deadline are aborted
+func TestDrainStateAbort(t *testing.T) {
+	ds := NewDrainState()
+	require.True(t, ds.Enter("job-1"))
+	require.True(t, ds.Enter("job-2"))
+	ctx, cancel := ds.JobContext(context.Background())
+	defer cancel()
+	require.True(t, ds.Start(10*time.Millisecond))
+	assert.Equal(t, []string{"job-1", "job-2"}, ds.Wait())
+	assert.False(t, ds.Aborted("job-1"))
+
+	// job-1 exits once its context is canceled, job-2 keeps running
+	go func() {
+		<-ctx.Done()
+		ds.Leave("job-1")
+	}()
+	handedOver, running := ds.Abort(100 * time.Millisecond)
+	assert.Equal(t, []string{"job-1"}, handedOver)
+	assert.Equal(t, []string{"job-2"}, running)
+	assert.True(t, ds.Aborted("job-1"))
+	assert.True(t, ds.Aborted("job-2"))
+	assert.False(t, ds.Aborted("job-3"))
+
+	var nilState *DrainState
+	assert.False(t, nilState.Aborted("job-1"))
+	handedOver, running = nilState.Abort(time.Second)
+	assert.Empty(t, handedOver)
+	assert.Empty(t, running)
+}
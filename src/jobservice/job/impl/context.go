@@ -26,6 +26,7 @@ import (
 	o "github.com/beego/beego/v2/client/orm"
 
 	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -132,7 +133,7 @@ func (c *Context) Build(tracker job.Tracker) (job.Context, error) {
 	// Set loggers for job
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	lg, err := createLoggers(tracker.Job().Info.JobID)
+	lg, err := createLoggers(tracker.Job().Info)
 	if err != nil {
 		return nil, err
 	}
@@ -182,38 +183,63 @@ func (c *Context) Tracker() job.Tracker {
 }
 
 // create loggers based on the configurations.
-func createLoggers(jobID string) (logger.Interface, error) {
+func createLoggers(info *job.StatsInfo) (logger.Interface, error) {
+	jobID := info.JobID
 	// Init job loggers here
 	lOptions := make([]logger.Option, 0)
 	for _, lc := range config.DefaultConfig.JobLoggerConfigs {
 		// For running job, the depth should be 5
-		if lc.Name == logger.NameFile || lc.Name == logger.NameStdOutput || lc.Name == logger.NameDB {
-			if lc.Settings == nil {
-				lc.Settings = map[string]any{}
-			}
-			lc.Settings["depth"] = 5
+		if lc.Name != logger.NameStdOutput && !isPerJobLogger(lc.Name) {
+			lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, lc.Settings))
+			continue
+		}
+		if lc.Settings == nil {
+			lc.Settings = map[string]any{}
 		}
-		if lc.Name == logger.NameFile || lc.Name == logger.NameDB {
-			// Need extra param
-			fSettings := map[string]any{}
-			maps.Copy(fSettings, lc.Settings)
-			if lc.Name == logger.NameFile {
-				// Append file name param
-				fSettings["filename"] = fmt.Sprintf("%s.log", jobID)
-				lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
-			} else { // DB Logger
-				// Append DB key
+		lc.Settings["depth"] = 5
+		if lc.Name == logger.NameStdOutput {
+			lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, lc.Settings))
+			continue
+		}
+
+		// Need extra param
+		fSettings := map[string]any{}
+		maps.Copy(fSettings, lc.Settings)
+		switch lc.Name {
+		case logger.NameFile:
+			// Append file name param, the job logs are kept as the structured JSON lines
+			fSettings["filename"] = fmt.Sprintf("%s.log", jobID)
+			fSettings["format"] = query.LogFormatJSON
+		case logger.NameDB:
+			// Append DB key
+			fSettings["key"] = jobID
+		default:
+			// Append the object key and the tags of the job
+			if lc.Name == logger.NameObjectStorage {
 				fSettings["key"] = jobID
-				lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
 			}
-		} else {
-			lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, lc.Settings))
+			fSettings["job_id"] = jobID
+			fSettings["vendor_type"] = info.JobName
+			if executionID, ok := info.Parameters.ExecutionID(); ok {
+				fSettings["execution_id"] = executionID
+			}
 		}
+		lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
 	}
 	// Get logger for the job
 	return logger.GetLogger(lOptions...)
 }
 
+// isPerJobLogger checks if the logger is created for each job with the job info
+func isPerJobLogger(name string) bool {
+	switch name {
+	case logger.NameFile, logger.NameDB, logger.NameObjectStorage, logger.NameLoki, logger.NameOTLP:
+		return true
+	default:
+		return false
+	}
+}
+
 func initDBCompleted() error {
 	return sweeper.PrepareDBSweep()
 }
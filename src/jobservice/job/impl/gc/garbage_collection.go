@@ -47,6 +47,8 @@ import (
 var (
 	regCtlInit = registryctl.Init
 	errGcStop  = errors.New("stopped")
+	// errGcCheckpoint is returned when the worker pool is draining, the GC exits with a checkpoint to be resumed
+	errGcCheckpoint = errors.New("checkpoint")
 )
 
 const (
@@ -76,6 +78,8 @@ type GarbageCollector struct {
 	deleteSet       []*blobModels.Blob
 	timeWindowHours int64
 	workers         int
+	// the progress of the GC drained from another worker pool
+	checkpoint gcCheckpoint
 }
 
 // MaxFails implements the interface in job/Interface
@@ -116,6 +120,13 @@ func (gc *GarbageCollector) init(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 	gc.parseParams(params)
+	if data, ok := params.Checkpoint(); ok && data != "" {
+		if err := json.Unmarshal([]byte(data), &gc.checkpoint); err != nil {
+			gc.logger.Warningf("failed to parse the checkpoint of GC, start from scratch: %v", err)
+		} else {
+			gc.logger.Infof("resume the drained GC with %d pending candidates", len(gc.checkpoint.Pending))
+		}
+	}
 	return nil
 }
 
@@ -186,6 +197,21 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 			gc.logger.Info("received the stop signal, quit GC job.")
 			return nil
 		}
+		if err == errGcCheckpoint {
+			// the marked candidates are out of the time window, keep them to be swept by the resumed GC
+			gc.logger.Info("received the checkpoint signal, quit GC job to be resumed by another worker pool.")
+			pending := gc.checkpoint.Pending
+			kept := make(map[int64]struct{}, len(pending))
+			for _, id := range pending {
+				kept[id] = struct{}{}
+			}
+			for _, blob := range gc.deleteSet {
+				if _, ok := kept[blob.ID]; !ok {
+					pending = append(pending, blob.ID)
+				}
+			}
+			return job.Checkpoint(gc.checkpointData(pending))
+		}
 		gc.logger.Errorf("failed to execute GC job at mark phase, error: %v", err)
 		return err
 	}
@@ -198,6 +224,17 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 				gc.logger.Info("received the stop signal, quit GC job after cleaning up the cache.")
 				return gc.cleanCache(ctx.SystemContext())
 			}
+			if err == errGcCheckpoint {
+				gc.logger.Info("received the checkpoint signal, quit GC job to be resumed by another worker pool after cleaning up the cache.")
+				if err := gc.cleanCache(ctx.SystemContext()); err != nil {
+					gc.logger.Warningf("failed to clean up the cache before checkpoint, the resumed GC cleans it up: %v", err)
+				}
+				pending := make([]int64, 0, len(gc.deleteSet))
+				for _, blob := range gc.deleteSet {
+					pending = append(pending, blob.ID)
+				}
+				return job.Checkpoint(gc.checkpointData(pending))
+			}
 			gc.logger.Errorf("failed to execute GC job at sweep phase, error: %v", err)
 			return err
 		}
@@ -238,8 +275,24 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 	if len(orphanBlobs) != 0 {
 		blobs = append(blobs, orphanBlobs...)
 	}
+	pendingBlobs, err := gc.pendingBlobs(ctx)
+	if err != nil {
+		gc.logger.Errorf("failed to get the pending gc candidates: %v", err)
+		return err
+	}
+	if len(pendingBlobs) != 0 {
+		candidates := make(map[int64]struct{}, len(blobs))
+		for _, blob := range blobs {
+			candidates[blob.ID] = struct{}{}
+		}
+		for _, blob := range pendingBlobs {
+			if _, exist := candidates[blob.ID]; !exist {
+				blobs = append(blobs, blob)
+			}
+		}
+	}
 	if len(blobs) == 0 {
-		if err := saveGCRes(ctx, int64(0), int64(0), int64(0)); err != nil {
+		if err := saveGCRes(ctx, gc.checkpoint.SweepSize, gc.checkpoint.Blobs, gc.checkpoint.Manifests); err != nil {
 			gc.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
 		}
 		gc.logger.Info("no need to execute GC as there is no non referenced artifacts.")
@@ -253,8 +306,8 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 
 	for _, blob := range blobs {
 		if !gc.dryRun {
-			if gc.shouldStop(ctx) {
-				return errGcStop
+			if err := gc.interrupted(ctx); err != nil {
+				return err
 			}
 			blob.Status = blobModels.StatusDelete
 			count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
@@ -311,18 +364,22 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 		end := min(start+blobChunkSize, total)
 		blobChunks[i] = gc.deleteSet[start:end]
 	}
+	// the candidates handled by the workers, the rest are kept in the checkpoint when draining
+	handled := make([]bool, total)
 
 	g := new(errgroup.Group)
 	g.SetLimit(gc.workers)
 	index := int64(0)
-	for _, blobChunk := range blobChunks {
+	for i, blobChunk := range blobChunks {
 		blobChunk := blobChunk
+		offset := i * blobChunkSize
 		g.Go(func() error {
 			uid := uuid.New().String()
-			for _, blob := range blobChunk {
-				if gc.shouldStop(ctx) {
-					return errGcStop
+			for j, blob := range blobChunk {
+				if err := gc.interrupted(ctx); err != nil {
+					return err
 				}
+				handled[offset+j] = true
 
 				localIndex := atomic.AddInt64(&index, 1)
 				// set the status firstly, if the blob is updated by any HEAD/PUT request, it should be fail and skip.
@@ -482,6 +539,19 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 	}
 
 	if err := g.Wait(); err != nil {
+		if err == errGcCheckpoint {
+			gc.checkpoint.SweepSize += sweepSize
+			gc.checkpoint.Blobs += blobCnt
+			gc.checkpoint.Manifests += mfCnt
+			remaining := make([]*blobModels.Blob, 0, total)
+			for i, blob := range gc.deleteSet {
+				if !handled[i] {
+					remaining = append(remaining, blob)
+				}
+			}
+			gc.deleteSet = remaining
+			return err
+		}
 		gc.logger.Errorf("failed to execute mark(), error out, %v", err)
 		return err
 	}
@@ -489,7 +559,7 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 	gc.logger.Infof("%d blobs and %d manifests are actually deleted", blobCnt, mfCnt)
 	gc.logger.Infof("The GC job actual frees up %s space.", formatSize(sweepSize))
 
-	if err := saveGCRes(ctx, sweepSize, blobCnt, mfCnt); err != nil {
+	if err := saveGCRes(ctx, gc.checkpoint.SweepSize+sweepSize, gc.checkpoint.Blobs+blobCnt, gc.checkpoint.Manifests+mfCnt); err != nil {
 		gc.logger.Errorf("failed to save the garbage collection results, errMsg=%v", err)
 	}
 
@@ -577,8 +647,8 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 				}
 				allTrashedArts = append(allTrashedArts, simulateDeletions...)
 			} else {
-				if gc.shouldStop(ctx) {
-					return nil, errGcStop
+				if err := gc.interrupted(ctx); err != nil {
+					return nil, err
 				}
 				if err := gc.artCtl.Delete(ctx.SystemContext(), untagged.ID); err != nil {
 					// the failure ones can be GCed by the next execution
@@ -627,8 +697,8 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) ([]*blobModels.Blob, error) {
 	var orphanBlobs []*blobModels.Blob
 	for result := range project.ListAll(ctx.SystemContext(), 50, nil, project.Metadata(false)) {
-		if gc.shouldStop(ctx) {
-			return nil, errGcStop
+		if err := gc.interrupted(ctx); err != nil {
+			return nil, err
 		}
 		if result.Error != nil {
 			gc.logger.Errorf("remove untagged blobs for all projects got error: %v", result.Error)
@@ -643,9 +713,8 @@ func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) ([]*blobMo
 		}
 
 		for {
-			if gc.shouldStop(ctx) {
-				gc.logger.Info("received the stop signal, quit GC job.")
-				return nil, errGcStop
+			if err := gc.interrupted(ctx); err != nil {
+				return nil, err
 			}
 			blobRG := q.Range{
 				Min: lastBlobID,
@@ -730,20 +799,65 @@ func (gc *GarbageCollector) markDeleteFailed(ctx job.Context, blob *blobModels.B
 	return nil
 }
 
-func (gc *GarbageCollector) shouldStop(ctx job.Context) bool {
+// interrupted returns errGcStop when the job is stopped, or errGcCheckpoint when the worker pool is draining
+func (gc *GarbageCollector) interrupted(ctx job.Context) error {
 	opCmd, exit := ctx.OPCommand()
-	if exit && opCmd.IsStop() {
-		return true
+	if !exit {
+		return nil
 	}
-	return false
+	if opCmd.IsStop() {
+		return errGcStop
+	}
+	if opCmd.IsCheckpoint() {
+		return errGcCheckpoint
+	}
+	return nil
+}
+
+// pendingBlobs returns the candidates marked but not swept by the drained GC, as the marking refreshes the
+// update time of the blobs, they're out of the time window and cannot be got by uselessBlobs.
+// The blobs requested by any HEAD/PUT after the draining are back to StatusNone and skipped.
+func (gc *GarbageCollector) pendingBlobs(ctx job.Context) ([]*blobModels.Blob, error) {
+	if len(gc.checkpoint.Pending) == 0 {
+		return nil, nil
+	}
+	ids := make([]any, 0, len(gc.checkpoint.Pending))
+	for _, id := range gc.checkpoint.Pending {
+		ids = append(ids, id)
+	}
+	return gc.blobMgr.List(ctx.SystemContext(), q.New(q.KeyWords{
+		"id":     &q.OrList{Values: ids},
+		"status": blobModels.StatusDelete,
+	}))
+}
+
+// checkpointData returns the checkpoint of the GC with the results so far and the candidates not swept yet
+func (gc *GarbageCollector) checkpointData(pending []int64) string {
+	cp := gcCheckpoint{gcResult: gc.checkpoint.gcResult, Pending: pending}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		gc.logger.Errorf("failed to marshal the checkpoint of GC, error: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// gcResult is the result of the GC checked in to the execution
+type gcResult struct {
+	SweepSize int64 `json:"freed_space"`
+	Blobs     int64 `json:"purged_blobs"`
+	Manifests int64 `json:"purged_manifests"`
+}
+
+// gcCheckpoint is the progress kept by the GC drained from a worker pool, the resumed GC reports the results
+// accumulated by the previous runs and sweeps the pending candidates besides the ones got by marking.
+type gcCheckpoint struct {
+	gcResult
+	Pending []int64 `json:"pending,omitempty"`
 }
 
 func saveGCRes(ctx job.Context, sweepSize, blobs, manifests int64) error {
-	gcObj := struct {
-		SweepSize int64 `json:"freed_space"`
-		Blobs     int64 `json:"purged_blobs"`
-		Manifests int64 `json:"purged_manifests"`
-	}{
+	gcObj := gcResult{
 		SweepSize: sweepSize,
 		Blobs:     blobs,
 		Manifests: manifests,
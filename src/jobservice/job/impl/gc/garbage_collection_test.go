@@ -227,6 +227,39 @@ func (suite *gcTestSuite) TestStop() {
 	suite.Equal(errGcStop, gc.mark(ctx))
 }
 
+func (suite *gcTestSuite) TestCheckpoint() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.CheckpointCommand, true)
+
+	gc := &GarbageCollector{
+		blobMgr:           suite.blobMgr,
+		registryCtlClient: suite.registryCtlClient,
+		logger:            logger,
+		deleteSet: []*pkg_blob.Blob{
+			{ID: 1, Digest: suite.DigestString(), ContentType: schema2.MediaTypeLayer},
+			{ID: 2, Digest: suite.DigestString(), ContentType: schema2.MediaTypeLayer},
+		},
+		workers:    1,
+		checkpoint: gcCheckpoint{gcResult: gcResult{SweepSize: 10, Blobs: 1}},
+	}
+
+	// none of the candidates is swept, all of them are kept with the results of the previous runs
+	suite.Equal(errGcCheckpoint, gc.sweep(ctx))
+	suite.Len(gc.deleteSet, 2)
+	suite.JSONEq(`{"freed_space":10,"purged_blobs":1,"purged_manifests":0,"pending":[1,2]}`,
+		gc.checkpointData([]int64{1, 2}))
+
+	// the pending candidates still marked are swept by the resumed GC
+	mock.OnAnything(suite.blobMgr, "List").Return([]*pkg_blob.Blob{{ID: 2, Status: pkg_blob.StatusDelete}}, nil).Once()
+	gc.checkpoint.Pending = []int64{1, 2}
+	blobs, err := gc.pendingBlobs(ctx)
+	suite.Nil(err)
+	suite.Len(blobs, 1)
+	suite.Equal(int64(2), blobs[0].ID)
+}
+
 func (suite *gcTestSuite) TestRun() {
 	ctx := &mockjobservice.MockJobContext{}
 	logger := &mockjobservice.MockJobLogger{}
@@ -22,6 +22,7 @@ import (
 	// import chart transfer
 	_ "github.com/goharbor/harbor/src/controller/replication/transfer/image"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	jlogger "github.com/goharbor/harbor/src/jobservice/logger"
 
 	// import aliacr adapter
 	_ "github.com/goharbor/harbor/src/pkg/reg/adapter/aliacr"
@@ -89,6 +90,10 @@ func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
 		logger.Errorf("failed to parse parameters: %v", err)
 		return err
 	}
+	// attach the repository to the log entries to make them searchable
+	if src.Metadata != nil && src.Metadata.Repository != nil {
+		logger = jlogger.WithFields(logger, map[string]any{"repository": src.Metadata.Repository.Name})
+	}
 
 	factory, err := transfer.GetFactory(src.Type)
 	if err != nil {
@@ -96,20 +101,34 @@ func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
+	// the transfer quits in the same way when the worker pool is draining, the blobs copied already
+	// are skipped by the existence checking when the job is resumed by another worker pool
+	checkpointed := false
 	stopFunc := func() bool {
 		cmd, exist := ctx.OPCommand()
 		if !exist {
 			return false
 		}
+		if cmd.IsCheckpoint() {
+			checkpointed = true
+			return true
+		}
 		return cmd == job.StopCommand
 	}
-	trans, err := factory(ctx.GetLogger(), stopFunc)
+	trans, err := factory(logger, stopFunc)
 	if err != nil {
 		logger.Errorf("failed to create transfer: %v", err)
 		return err
 	}
 
-	return trans.Transfer(src, dst, opts)
+	if err = trans.Transfer(src, dst, opts); err != nil {
+		return err
+	}
+	if checkpointed {
+		logger.Info("the worker pool is draining, quit the replication to be resumed by another worker pool")
+		return job.Checkpoint("")
+	}
+	return nil
 }
 
 func parseParams(params map[string]any) (*model.Resource, *model.Resource, *transfer.Options, error) {
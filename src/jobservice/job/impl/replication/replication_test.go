@@ -21,8 +21,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/goharbor/harbor/src/controller/replication/transfer"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/job/impl"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
 )
 
 func TestParseParam(t *testing.T) {
@@ -88,3 +90,34 @@ func TestRun(t *testing.T) {
 	require.Nil(t, rep.Run(&impl.Context{}, params))
 	assert.True(t, transferred)
 }
+
+type stoppableTransfer struct {
+	stopFunc transfer.StopFunc
+}
+
+func (s *stoppableTransfer) Transfer(src *model.Resource, dst *model.Resource, opts *transfer.Options) error {
+	// quit silently as the transfers do when stopped
+	s.stopFunc()
+	return nil
+}
+
+func TestRunCheckpoint(t *testing.T) {
+	err := transfer.RegisterFactory("stoppable", func(_ transfer.Logger, stopFunc transfer.StopFunc) (transfer.Transfer, error) {
+		return &stoppableTransfer{stopFunc: stopFunc}, nil
+	})
+	require.Nil(t, err)
+	params := map[string]any{
+		"src_resource": `{"type":"stoppable"}`,
+		"dst_resource": `{}`,
+	}
+
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("GetLogger").Return(&mockjobservice.MockJobLogger{})
+	ctx.On("OPCommand").Return(job.CheckpointCommand, true).Once()
+	rep := &Replication{}
+	_, ok := job.CheckpointOf(rep.Run(ctx, params))
+	assert.True(t, ok)
+
+	ctx.On("OPCommand").Return(job.StopCommand, true).Once()
+	assert.Nil(t, rep.Run(ctx, params))
+}
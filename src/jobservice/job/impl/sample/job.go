@@ -93,6 +93,11 @@ func (j *Job) Run(ctx job.Context, params job.Parameters) error {
 			logger.Info("Exit for receiving stop signal")
 			return nil
 		}
+		if cmd.IsCheckpoint() {
+			// Resume from the last progress once re-enqueued to other pools
+			logger.Info("Exit for receiving checkpoint signal")
+			return job.Checkpoint("progress data: %60")
+		}
 	}
 
 	// Successfully exit
@@ -23,7 +23,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/gocarina/gocsv"
 	"github.com/opencontainers/go-digest"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -94,13 +93,17 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 	logger := ctx.GetLogger()
 	logger.Infof("Scan data export job started in mode : %v", mode)
 	sde.init()
-	fileName := fmt.Sprintf("%s/scandata_export_%s.csv", sde.scanDataExportDirPath, params[export.JobID])
+	format, err := sde.extractFormat(params)
+	if err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("%s/scandata_export_%s.%s", sde.scanDataExportDirPath, params[export.JobID], export.FileExtension(format))
 
-	// ensure that CSV files are cleared post the completion of the Run.
-	defer sde.cleanupCsvFile(ctx, fileName, params)
-	err := sde.writeCsvFile(ctx, params, fileName)
+	// ensure that the exported files are cleared post the completion of the Run.
+	defer sde.cleanupFile(ctx, fileName, params)
+	err = sde.writeDataFile(ctx, params, fileName, format)
 	if err != nil {
-		logger.Errorf("error when writing data to CSV: %v", err)
+		logger.Errorf("error when writing data to %s: %v", format, err)
 		return err
 	}
 
@@ -111,21 +114,21 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 	}
 	logger.Infof("Export Job Id = %s, FileName = %s, Hash = %v", params[export.JobID], fileName, hash)
 
-	csvFile, err := os.OpenFile(fileName, os.O_RDONLY, os.ModePerm)
+	dataFile, err := os.OpenFile(fileName, os.O_RDONLY, os.ModePerm)
 	if err != nil {
 		logger.Errorf(
 			"Export Job Id = %s. Error when moving report file %s to persistent storage: %v", params[export.JobID], fileName, err)
 		return err
 	}
-	baseFileName := filepath.Base(fileName)
-	repositoryName := strings.TrimSuffix(baseFileName, filepath.Ext(baseFileName))
-	logger.Infof("Creating repository for CSV file with blob : %s", repositoryName)
+	defer dataFile.Close()
+	repositoryName := strings.TrimSuffix(filepath.Base(fileName), "."+export.FileExtension(format))
+	logger.Infof("Creating repository for %s file with blob : %s", format, repositoryName)
 	stat, err := os.Stat(fileName)
 	if err != nil {
 		logger.Errorf("Error when fetching file size: %v", err)
 		return err
 	}
-	logger.Infof("Export Job Id = %s. CSV file size: %d", params[export.JobID], stat.Size())
+	logger.Infof("Export Job Id = %s. %s file size: %d", params[export.JobID], format, stat.Size())
 	// earlier return and update status message if the file size is 0, unnecessary to push a empty system artifact.
 	if stat.Size() == 0 {
 		extra := map[string]any{
@@ -136,12 +139,12 @@ func (sde *ScanDataExport) Run(ctx job.Context, params job.Parameters) error {
 			logger.Errorf("Export Job Id = %s. Error when updating the exec extra attributes 'status_message' to 'No vulnerabilities found or matched': %v", params[export.JobID], updateErr)
 		}
 
-		logger.Infof("Export Job Id = %s. Exported CSV file is empty, skip to push system artifact, exit job", params[export.JobID])
+		logger.Infof("Export Job Id = %s. Exported %s file is empty, skip to push system artifact, exit job", params[export.JobID], format)
 		return nil
 	}
 
-	csvExportArtifactRecord := model.SystemArtifact{Repository: repositoryName, Digest: hash.String(), Size: stat.Size(), Type: "ScanData_CSV", Vendor: strings.ToLower(export.Vendor)}
-	artID, err := sde.sysArtifactMgr.Create(ctx.SystemContext(), &csvExportArtifactRecord, csvFile)
+	exportArtifactRecord := model.SystemArtifact{Repository: repositoryName, Digest: hash.String(), Size: stat.Size(), Type: export.ArtifactType(format), Vendor: strings.ToLower(export.Vendor)}
+	artID, err := sde.sysArtifactMgr.Create(ctx.SystemContext(), &exportArtifactRecord, dataFile)
 	if err != nil {
 		logger.Errorf(
 			"Export Job Id = %s. Error when persisting report file %s to persistent storage: %v", params[export.JobID], fileName, err)
@@ -179,16 +182,21 @@ func (sde *ScanDataExport) updateExecAttributes(ctx job.Context, params job.Para
 	return sde.execMgr.UpdateExtraAttrs(ctx.SystemContext(), execID, attrsToUpdate)
 }
 
-func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters, fileName string) error {
+func (sde *ScanDataExport) writeDataFile(ctx job.Context, params job.Parameters, fileName string, format string) error {
 	logger := ctx.GetLogger()
-	csvFile, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.ModePerm)
+	dataFile, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, os.ModePerm)
 	if err != nil {
-		logger.Errorf("Failed to create CSV export file %s. Error : %v", fileName, err)
+		logger.Errorf("Failed to create %s export file %s. Error : %v", format, fileName, err)
 		return err
 	}
-	defer csvFile.Close()
+	defer dataFile.Close()
 
-	logger.Infof("Created CSV export file %s", csvFile.Name())
+	logger.Infof("Created %s export file %s", format, dataFile.Name())
+
+	writer, err := export.NewDataWriter(format, dataFile)
+	if err != nil {
+		return err
+	}
 
 	systemContext := ctx.SystemContext()
 	var exportParams export.Params
@@ -274,13 +282,7 @@ func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters,
 			}
 			logger.Infof("Export Group Id = %d, Job Id = %s, Page Number = %d, Page Size = %d Num Records = %d", groupID, params[export.JobID], exportParams.PageNumber, exportParams.PageSize, len(data))
 
-			// for the first page write the CSV with the headers
-			if exportParams.PageNumber == 1 && groupID == 0 {
-				err = gocsv.Marshal(data, csvFile)
-			} else {
-				err = gocsv.MarshalWithoutHeaders(data, csvFile)
-			}
-			if err != nil {
+			if err = writer.Write(data); err != nil {
 				return err
 			}
 
@@ -291,7 +293,28 @@ func (sde *ScanDataExport) writeCsvFile(ctx job.Context, params job.Parameters,
 			}
 		}
 	}
-	return nil
+	return writer.Close()
+}
+
+// extractFormat returns the output format specified in the export request, defaults to CSV
+func (sde *ScanDataExport) extractFormat(params job.Parameters) (string, error) {
+	if _, ok := params[export.JobRequest]; !ok {
+		return export.FormatCSV, nil
+	}
+
+	criteria, err := sde.extractCriteria(params)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case criteria.Format == "":
+		return export.FormatCSV, nil
+	case !export.IsSupportedFormat(criteria.Format):
+		return "", errors.Errorf("unsupported scan data export format: %s", criteria.Format)
+	default:
+		return criteria.Format, nil
+	}
 }
 
 func (sde *ScanDataExport) extractCriteria(params job.Parameters) (*export.Request, error) {
@@ -363,15 +386,15 @@ func (sde *ScanDataExport) init() {
 	}
 }
 
-func (sde *ScanDataExport) cleanupCsvFile(ctx job.Context, fileName string, params job.Parameters) {
+func (sde *ScanDataExport) cleanupFile(ctx job.Context, fileName string, params job.Parameters) {
 	logger := ctx.GetLogger()
 	if _, err := os.Stat(fileName); os.IsNotExist(err) {
-		logger.Infof("Export Job Id = %s, CSV Export File = %s does not exist. Nothing to do", params[export.JobID], fileName)
+		logger.Infof("Export Job Id = %s, Export File = %s does not exist. Nothing to do", params[export.JobID], fileName)
 		return
 	}
 	err := os.Remove(fileName)
 	if err != nil {
-		logger.Errorf("Export Job Id = %s, CSV Export File = %s could not deleted. Error = %v", params[export.JobID], fileName, err)
+		logger.Errorf("Export Job Id = %s, Export File = %s could not deleted. Error = %v", params[export.JobID], fileName, err)
 		return
 	}
 }
@@ -114,6 +114,41 @@ func (suite *ScanDataExportJobTestSuite) TestRun() {
 
 }
 
+func (suite *ScanDataExportJobTestSuite) TestRunWithFormat() {
+	data := suite.createDataRecords(3)
+	mock.OnAnything(suite.exportMgr, "Fetch").Return(data, nil).Once()
+	mock.OnAnything(suite.digestCalculator, "Calculate").Return(digest.Digest(MockDigest), nil)
+	mock.OnAnything(suite.filterProcessor, "ProcessRepositoryFilter").Return([]int64{1}, nil).Once()
+	mock.OnAnything(suite.filterProcessor, "ProcessTagFilter").Return([]*artifact.Artifact{{Artifact: artpkg.Artifact{ID: 1}}}, nil).Once()
+	mock.OnAnything(suite.filterProcessor, "ProcessLabelFilter").Return([]*artifact.Artifact{{Artifact: artpkg.Artifact{ID: 1}}}, nil).Once()
+	mock.OnAnything(suite.execMgr, "Get").Return(&task.Execution{ID: ExecID, ExtraAttrs: map[string]any{}}, nil)
+
+	params := job.Parameters{}
+	params[export.JobModeKey] = export.JobModeExport
+	params["JobId"] = JobId
+	params["Request"] = map[string]any{
+		"projects": []int64{1},
+		"format":   export.FormatSARIF,
+	}
+	ctx := &mockjobservice.MockJobContext{}
+
+	err := suite.job.Run(ctx, params)
+	suite.NoError(err)
+	sysArtifactRecordMatcher := testifymock.MatchedBy(func(sa *model.SystemArtifact) bool {
+		return sa.Repository == "scandata_export_1000000" && sa.Type == "ScanData_SARIF" && sa.Digest == MockDigest
+	})
+	suite.sysArtifactMgr.AssertCalled(suite.T(), "Create", mock.Anything, sysArtifactRecordMatcher, mock.Anything)
+	_, err = os.Stat("/tmp/scandata_export_1000000.sarif")
+	suite.Truef(os.IsNotExist(err), "Expected SARIF file to be deleted")
+
+	// unsupported format
+	params["Request"] = map[string]any{
+		"projects": []int64{1},
+		"format":   "pdf",
+	}
+	suite.Error(suite.job.Run(ctx, params))
+}
+
 func (suite *ScanDataExportJobTestSuite) TestRunWithEmptyData() {
 	var data []export.Data
 	mock.OnAnything(suite.exportMgr, "Fetch").Return(data, nil).Once()
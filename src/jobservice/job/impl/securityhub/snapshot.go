@@ -0,0 +1,83 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/securityhub"
+)
+
+// ScannerUUIDParam is the parameter of the scanner whose reports are aggregated in the snapshot
+const ScannerUUIDParam = "scanner_uuid"
+
+// Snapshot takes the daily snapshot of the vulnerability aggregates for the security hub trends
+type Snapshot struct {
+	secHubMgr securityhub.Manager
+	now       func() time.Time
+}
+
+// MaxFails ...
+func (s *Snapshot) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency ...
+func (s *Snapshot) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry ...
+func (s *Snapshot) ShouldRetry() bool {
+	return true
+}
+
+// Validate ...
+func (s *Snapshot) Validate(params job.Parameters) error {
+	if v, ok := params[ScannerUUIDParam]; ok {
+		if _, ok := v.(string); !ok {
+			return errors.Errorf("invalid %s parameter: %v", ScannerUUIDParam, v)
+		}
+	}
+	return nil
+}
+
+// Run ...
+func (s *Snapshot) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	s.init()
+
+	// the snapshot is taken without the scanner when no scanner configured, only the artifacts are counted
+	scannerUUID, _ := params[ScannerUUIDParam].(string)
+	date := s.now().UTC()
+	logger.Infof("Taking the security hub snapshot of %s for the scanner %q...", date.Format(time.DateOnly), scannerUUID)
+	if err := s.secHubMgr.TakeSnapshot(ctx.SystemContext(), scannerUUID, date); err != nil {
+		logger.Errorf("Failed to take the security hub snapshot: %v", err)
+		return err
+	}
+	logger.Info("Took the security hub snapshot successfully")
+	return nil
+}
+
+func (s *Snapshot) init() {
+	if s.secHubMgr == nil {
+		s.secHubMgr = securityhub.Mgr
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityhub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/securityhub"
+)
+
+type SnapshotJobSuite struct {
+	suite.Suite
+	secHubMgr *securityhub.Manager
+	job       *Snapshot
+	now       time.Time
+}
+
+func (suite *SnapshotJobSuite) SetupTest() {
+	suite.secHubMgr = &securityhub.Manager{}
+	suite.now = time.Date(2024, 5, 1, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+	suite.job = &Snapshot{secHubMgr: suite.secHubMgr, now: func() time.Time { return suite.now }}
+}
+
+func (suite *SnapshotJobSuite) TestRun() {
+	suite.secHubMgr.On("TakeSnapshot", mock.Anything, "ruuid", suite.now.UTC()).Return(nil)
+
+	err := suite.job.Run(&mockjobservice.MockJobContext{}, job.Parameters{ScannerUUIDParam: "ruuid"})
+	suite.NoError(err)
+	suite.secHubMgr.AssertExpectations(suite.T())
+}
+
+func (suite *SnapshotJobSuite) TestRunFailure() {
+	mock.OnAnything(suite.secHubMgr, "TakeSnapshot").Return(errors.New("test error"))
+
+	err := suite.job.Run(&mockjobservice.MockJobContext{}, job.Parameters{})
+	suite.Error(err)
+	suite.secHubMgr.AssertCalled(suite.T(), "TakeSnapshot", mock.Anything, "", suite.now.UTC())
+}
+
+func (suite *SnapshotJobSuite) TestValidate() {
+	suite.NoError(suite.job.Validate(job.Parameters{}))
+	suite.NoError(suite.job.Validate(job.Parameters{ScannerUUIDParam: "ruuid"}))
+	suite.Error(suite.job.Validate(job.Parameters{ScannerUUIDParam: 1}))
+}
+
+func TestSnapshotJobSuite(t *testing.T) {
+	suite.Run(t, &SnapshotJobSuite{})
+}
@@ -48,6 +48,10 @@ const (
 	ExecSweepVendorType = "EXECUTION_SWEEP"
 	// ScanAllVendorType: the name of the scan all job
 	ScanAllVendorType = "SCAN_ALL"
+	// ScanStaleVendorType: the name of the job which rescans the artifacts with stale reports
+	ScanStaleVendorType = "SCAN_STALE"
+	// SecurityHubSnapshotVendorType : the name of the job which takes the daily snapshot of the security hub
+	SecurityHubSnapshotVendorType = "SECURITY_HUB_SNAPSHOT"
 	// AuditLogsGDPRCompliantVendorType : the name of the job which makes audit logs table GDPR-compliant
 	AuditLogsGDPRCompliantVendorType = "AUDIT_LOGS_GDPR_COMPLIANT"
 )
@@ -58,6 +62,7 @@ var (
 		ImageScanJobVendorType:          lib.GetEnvInt64("IMAGE_SCAN_EXECUTION_RETENTION_COUNT", 1),
 		SBOMJobVendorType:               lib.GetEnvInt64("SBOM_EXECUTION_RETENTION_COUNT", 1),
 		ScanAllVendorType:               lib.GetEnvInt64("SCAN_ALL_EXECUTION_RETENTION_COUNT", 1),
+		ScanStaleVendorType:             lib.GetEnvInt64("SCAN_STALE_EXECUTION_RETENTION_COUNT", 10),
 		PurgeAuditVendorType:            lib.GetEnvInt64("PURGE_AUDIT_EXECUTION_RETENTION_COUNT", 10),
 		ExecSweepVendorType:             lib.GetEnvInt64("EXECUTION_SWEEP_EXECUTION_RETENTION_COUNT", 10),
 		GarbageCollectionVendorType:     lib.GetEnvInt64("GARBAGE_COLLECTION_EXECUTION_RETENTION_COUNT", 50),
@@ -68,6 +73,7 @@ var (
 		SystemArtifactCleanupVendorType: lib.GetEnvInt64("SYSTEM_ARTIFACT_CLEANUP_EXECUTION_RETENTION_COUNT", 50),
 		P2PPreheatVendorType:            lib.GetEnvInt64("P2P_PREHEAT_EXECUTION_RETENTION_COUNT", 50),
 		RetentionVendorType:             lib.GetEnvInt64("RETENTION_EXECUTION_RETENTION_COUNT", 50),
+		SecurityHubSnapshotVendorType:   lib.GetEnvInt64("SECURITY_HUB_SNAPSHOT_EXECUTION_RETENTION_COUNT", 10),
 	}
 )
 
@@ -26,6 +26,37 @@ import (
 // Parameters for job execution.
 type Parameters map[string]any
 
+// ProjectIDParamKey is the key of the job parameter carrying the ID of the project which the job belongs to,
+// it's used to schedule the jobs fairly among the projects.
+const ProjectIDParamKey = "project_id"
+
+// ExecutionIDParamKey is the key of the job parameter carrying the ID of the execution which the job belongs to,
+// it's used to tag the job logs and to hold the jobs of the paused execution.
+const ExecutionIDParamKey = "execution_id"
+
+// ExecutionID returns the ID of the execution which the job belongs to
+func (p Parameters) ExecutionID() (int64, bool) {
+	v, ok := p[ExecutionIDParamKey]
+	if !ok {
+		return 0, false
+	}
+
+	switch id := v.(type) {
+	case int64:
+		return id, true
+	case int:
+		return int64(id), true
+	case float64:
+		// The number is decoded as float64 from the JSON
+		return int64(id), true
+	case json.Number:
+		n, err := id.Int64()
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
 // Request is the request of launching a job.
 type Request struct {
 	Job *RequestBody `json:"job"`
@@ -97,6 +128,12 @@ type ActionRequest struct {
 	Action string `json:"action"`
 }
 
+// DrainRequest is designed for draining the worker pool.
+// Zero timeout means using the configured one.
+type DrainRequest struct {
+	TimeoutSeconds uint `json:"timeout_seconds"`
+}
+
 // StatusChange is designed for reporting the status change via hook.
 type StatusChange struct {
 	JobID    string     `json:"job_id"`
@@ -158,5 +195,7 @@ func (st *Stats) Validate() error {
 
 // Config job service config
 type Config struct {
+	// Backend is the backend of the job queue, the redis pool config is only available with the redis backend
+	Backend         string                  `json:"backend,omitempty"`
 	RedisPoolConfig *config.RedisPoolConfig `json:"redis_pool_config"`
 }
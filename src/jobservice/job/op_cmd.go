@@ -17,6 +17,9 @@ package job
 const (
 	// StopCommand is const for stop command
 	StopCommand OPCommand = "stop"
+	// CheckpointCommand is const for checkpoint command, it's sent when the worker pool is draining.
+	// The job should exit with the progress returned by Checkpoint to be resumed by another worker pool.
+	CheckpointCommand OPCommand = "checkpoint"
 	// NilCommand is const for a nil command
 	NilCommand OPCommand = "nil"
 )
@@ -28,3 +31,8 @@ type OPCommand string
 func (oc OPCommand) IsStop() bool {
 	return oc == "stop"
 }
+
+// IsCheckpoint return if the op command is checkpoint
+func (oc OPCommand) IsCheckpoint() bool {
+	return oc == CheckpointCommand
+}
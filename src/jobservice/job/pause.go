@@ -0,0 +1,144 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+// PausedLoader loads the paused job types and the IDs of the paused executions.
+// The job type "all" stands for all job types.
+type PausedLoader func(ctx context.Context) (jobTypes []string, executionIDs []int64, err error)
+
+// PauseState caches the paused job types and executions persisted in the database, which is refreshed
+// periodically to let the worker pool hold the paused jobs. The methods are safe to be called on the nil
+// PauseState which pauses nothing.
+type PauseState struct {
+	loader PausedLoader
+
+	lock       sync.RWMutex
+	loaded     bool
+	all        bool
+	jobTypes   map[string]struct{}
+	executions map[int64]struct{}
+}
+
+// NewPauseState creates the pause state with the loader
+func NewPauseState(loader PausedLoader) *PauseState {
+	return &PauseState{
+		loader:     loader,
+		jobTypes:   make(map[string]struct{}),
+		executions: make(map[int64]struct{}),
+	}
+}
+
+// Refresh reloads the paused job types and executions, the cached ones are kept if failed to load
+func (ps *PauseState) Refresh(ctx context.Context) error {
+	jobTypes, executionIDs, err := ps.loader(ctx)
+	if err != nil {
+		return err
+	}
+
+	all := false
+	types := make(map[string]struct{}, len(jobTypes))
+	for _, t := range jobTypes {
+		if strings.EqualFold(t, "all") {
+			all = true
+			continue
+		}
+		types[t] = struct{}{}
+	}
+	executions := make(map[int64]struct{}, len(executionIDs))
+	for _, id := range executionIDs {
+		executions[id] = struct{}{}
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	ps.loaded, ps.all, ps.jobTypes, ps.executions = true, all, types, executions
+
+	return nil
+}
+
+// Start refreshing the pause state periodically until the context is done
+func (ps *PauseState) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := ps.Refresh(ctx); err != nil {
+				logger.Errorf("Failed to refresh the pause state: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				logger.Info("Pause state refresher is stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Loaded returns whether the pause state has been loaded at least once
+func (ps *PauseState) Loaded() bool {
+	if ps == nil {
+		return false
+	}
+
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return ps.loaded
+}
+
+// IsJobTypePaused returns whether the job type is paused
+func (ps *PauseState) IsJobTypePaused(jobType string) bool {
+	if ps == nil {
+		return false
+	}
+
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+	if ps.all {
+		return true
+	}
+	_, ok := ps.jobTypes[jobType]
+
+	return ok
+}
+
+// IsExecutionPaused returns whether the execution which the job belongs to is paused
+func (ps *PauseState) IsExecutionPaused(params Parameters) bool {
+	if ps == nil {
+		return false
+	}
+	id, ok := params.ExecutionID()
+	if !ok {
+		return false
+	}
+
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+	_, ok = ps.executions[id]
+
+	return ok
+}
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecutionID tests getting the execution ID from the job parameters
+func TestExecutionID(t *testing.T) {
+	_, ok := Parameters{}.ExecutionID()
+	assert.False(t, ok)
+
+	id, ok := Parameters{ExecutionIDParamKey: float64(12)}.ExecutionID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), id)
+
+	id, ok = Parameters{ExecutionIDParamKey: int64(13)}.ExecutionID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(13), id)
+
+	id, ok = Parameters{ExecutionIDParamKey: json.Number("15")}.ExecutionID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(15), id)
+
+	_, ok = Parameters{ExecutionIDParamKey: "14"}.ExecutionID()
+	assert.False(t, ok)
+}
+
+// TestPauseState tests the pause state
+func TestPauseState(t *testing.T) {
+	var nilState *PauseState
+	assert.False(t, nilState.Loaded())
+	assert.False(t, nilState.IsJobTypePaused(SampleJob))
+	assert.False(t, nilState.IsExecutionPaused(Parameters{ExecutionIDParamKey: int64(1)}))
+
+	jobTypes, executionIDs, loadErr := []string{SampleJob}, []int64{1}, error(nil)
+	ps := NewPauseState(func(_ context.Context) ([]string, []int64, error) {
+		return jobTypes, executionIDs, loadErr
+	})
+	assert.False(t, ps.Loaded())
+	require.NoError(t, ps.Refresh(context.TODO()))
+	assert.True(t, ps.Loaded())
+	assert.True(t, ps.IsJobTypePaused(SampleJob))
+	assert.False(t, ps.IsJobTypePaused(SlackJobVendorType))
+	assert.True(t, ps.IsExecutionPaused(Parameters{ExecutionIDParamKey: float64(1)}))
+	assert.False(t, ps.IsExecutionPaused(Parameters{ExecutionIDParamKey: float64(2)}))
+	assert.False(t, ps.IsExecutionPaused(Parameters{}))
+
+	// all job types are paused
+	jobTypes = []string{"ALL"}
+	require.NoError(t, ps.Refresh(context.TODO()))
+	assert.True(t, ps.IsJobTypePaused(SlackJobVendorType))
+
+	// the cached state is kept when failed to load
+	loadErr = errors.New("failed")
+	assert.Error(t, ps.Refresh(context.TODO()))
+	assert.True(t, ps.IsJobTypePaused(SlackJobVendorType))
+	assert.True(t, ps.IsExecutionPaused(Parameters{ExecutionIDParamKey: int64(1)}))
+}
@@ -0,0 +1,510 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	o "github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/jobservice/common/list"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// PgStatusCode returns the SQL expression to get the comparable code of the status in the specified column,
+// it keeps consistent with Status.Code()
+func PgStatusCode(column string) string {
+	return fmt.Sprintf("CASE %s WHEN 'Pending' THEN 0 WHEN 'Scheduled' THEN 1 WHEN 'Running' THEN 2 ELSE 3 END", column)
+}
+
+// pgStats is the row of the job stats in the database
+type pgStats struct {
+	Status     string `orm:"column(status)"`
+	Revision   int64  `orm:"column(revision)"`
+	Info       string `orm:"column(info)"`
+	Ack        string `orm:"column(ack)"`
+	UpdateTime int64  `orm:"column(update_time)"`
+}
+
+// pgTracker implements Tracker interface based on the postgresql database
+type pgTracker struct {
+	context   context.Context
+	jobID     string
+	jobStats  *Stats
+	callback  HookCallback
+	retryList *list.SyncList
+}
+
+// NewPgTrackerWithID builds a postgresql based tracker with the provided job ID
+func NewPgTrackerWithID(
+	ctx context.Context,
+	jobID string,
+	callback HookCallback,
+	retryList *list.SyncList,
+) Tracker {
+	return &pgTracker{
+		context:   ctx,
+		jobID:     jobID,
+		callback:  callback,
+		retryList: retryList,
+	}
+}
+
+// NewPgTrackerWithStats builds a postgresql based tracker with the provided job stats
+func NewPgTrackerWithStats(
+	ctx context.Context,
+	stats *Stats,
+	callback HookCallback,
+	retryList *list.SyncList,
+) Tracker {
+	return &pgTracker{
+		context:   ctx,
+		jobStats:  stats,
+		jobID:     stats.Info.JobID,
+		callback:  callback,
+		retryList: retryList,
+	}
+}
+
+// Load the job stats which tracked by this tracker with the backend data
+func (pt *pgTracker) Load() error {
+	ormer, err := orm.FromContext(pt.ormContext())
+	if err != nil {
+		return err
+	}
+
+	row := &pgStats{}
+	sql := `SELECT status, revision, info::text AS info, COALESCE(ack::text, '') AS ack, update_time
+		FROM job_service_stats WHERE job_id = ?`
+	if err := ormer.Raw(sql, pt.jobID).QueryRow(row); err != nil {
+		if errors.Is(err, o.ErrNoRows) {
+			return errs.NoObjectFoundError(pt.jobID)
+		}
+
+		return errors.Wrap(err, "load job stats")
+	}
+
+	info := &StatsInfo{}
+	if err := json.Unmarshal([]byte(row.Info), info); err != nil {
+		return errors.Wrap(err, "load job stats")
+	}
+	// The columns take precedence over the data in the info
+	info.Status = row.Status
+	info.Revision = row.Revision
+	info.UpdateTime = row.UpdateTime
+	// Never read the check in data
+	info.CheckIn = ""
+	info.HookAck = nil
+	if len(row.Ack) > 0 {
+		ack := &ACK{}
+		if err := json.Unmarshal([]byte(row.Ack), ack); err != nil {
+			return errors.Wrap(err, "load job stats")
+		}
+		info.HookAck = ack
+	}
+
+	pt.jobStats = &Stats{Info: info}
+
+	return nil
+}
+
+// Job returns the job stats which tracked by this tracker
+func (pt *pgTracker) Job() *Stats {
+	return pt.jobStats
+}
+
+// Update the properties of the job stats.
+// The status and the revision are not covered, they're changed via the status switching methods
+func (pt *pgTracker) Update(fieldAndValues ...any) error {
+	if len(fieldAndValues) == 0 {
+		return errors.New("no properties specified to update")
+	}
+
+	if len(fieldAndValues)%2 != 0 {
+		return errors.New("properties should be specified in pairs")
+	}
+
+	now := time.Now().Unix()
+	fields := make(map[string]any)
+	for i := 0; i < len(fieldAndValues); i += 2 {
+		field, ok := fieldAndValues[i].(string)
+		if !ok {
+			return errors.Errorf("invalid property name: %v", fieldAndValues[i])
+		}
+		if field == "update_time" {
+			now = toInt64(fieldAndValues[i+1], now)
+			continue
+		}
+		fields[field] = fieldAndValues[i+1]
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return errors.Wrap(err, "update job stats")
+	}
+
+	ormer, err := orm.FromContext(pt.ormContext())
+	if err != nil {
+		return err
+	}
+
+	sql := `UPDATE job_service_stats SET info = info || ?::jsonb, update_time = ? WHERE job_id = ?`
+	if _, err := ormer.Raw(sql, string(data), now, pt.jobID).Exec(); err != nil {
+		return errors.Wrap(err, "update job stats")
+	}
+
+	return nil
+}
+
+// Status returns the current status of job tracked by this tracker
+func (pt *pgTracker) Status() (Status, error) {
+	// Retrieve the latest status again in case get the outdated one.
+	status, err := pgStatus(pt.ormContext(), pt.jobID)
+	if err != nil {
+		return "", err
+	}
+
+	st := Status(status)
+	if err := st.Validate(); err != nil {
+		return "", errors.New("malformed status data returned")
+	}
+
+	return st, nil
+}
+
+// NumericID returns the numeric ID of the periodic job
+func (pt *pgTracker) NumericID() (int64, error) {
+	if pt.jobStats.Info.NumericPID > 0 {
+		return pt.jobStats.Info.NumericPID, nil
+	}
+
+	return -1, errors.Errorf("numeric ID not found for job: %s", pt.jobID)
+}
+
+// PeriodicExecutionDone marks the execution done by setting the run_at column to -1,
+// the run_at in the stats data is kept
+func (pt *pgTracker) PeriodicExecutionDone() error {
+	if utils.IsEmptyStr(pt.jobStats.Info.UpstreamJobID) {
+		return errors.Errorf("%s is not periodic job execution", pt.jobID)
+	}
+
+	ormer, err := orm.FromContext(pt.ormContext())
+	if err != nil {
+		return err
+	}
+
+	_, err = ormer.Raw(`UPDATE job_service_stats SET run_at = -1 WHERE job_id = ?`, pt.jobID).Exec()
+
+	return err
+}
+
+// CheckIn message
+func (pt *pgTracker) CheckIn(message string) error {
+	if utils.IsEmptyStr(message) {
+		return errors.New("check in error: empty message")
+	}
+
+	now := time.Now().Unix()
+	current := Status(pt.jobStats.Info.Status)
+
+	pt.refresh(current, message)
+	errFireHE := pt.fireHookEvent(current, message)
+	err := pt.Update(
+		// skip checkin data here
+		"check_in_at", now,
+		"update_time", now,
+	)
+	if err != nil {
+		if errFireHE != nil {
+			return errors.Wrap(err, errFireHE.Error())
+		}
+
+		return err
+	}
+
+	return errFireHE
+}
+
+// Run job
+func (pt *pgTracker) Run() error {
+	if err := pt.setStatus(RunningStatus); err != nil {
+		return errors.Wrap(err, "run")
+	}
+
+	return nil
+}
+
+// Stop job
+func (pt *pgTracker) Stop() error {
+	if err := pt.setStatus(StoppedStatus); err != nil {
+		return errors.Wrap(err, "stop")
+	}
+
+	return nil
+}
+
+// Fail job
+func (pt *pgTracker) Fail() error {
+	if err := pt.setStatus(ErrorStatus); err != nil {
+		return errors.Wrap(err, "fail")
+	}
+
+	return nil
+}
+
+// Succeed job
+func (pt *pgTracker) Succeed() error {
+	if err := pt.setStatus(SuccessStatus); err != nil {
+		return errors.Wrap(err, "succeed")
+	}
+
+	return nil
+}
+
+// Save the stats of job tracked by this tracker
+func (pt *pgTracker) Save() error {
+	if pt.jobStats == nil {
+		return errors.New("nil job stats to save")
+	}
+
+	stats := pt.jobStats
+	now := time.Now().Unix()
+	// Set the first revision if it is not set.
+	if stats.Info.Revision <= 0 {
+		stats.Info.Revision = now
+	}
+	stats.Info.UpdateTime = now
+
+	// The check in data is not persisted for saving space and the ACK is kept in a separate column
+	info := *stats.Info
+	info.CheckIn = ""
+	info.HookAck = nil
+	data, err := json.Marshal(&info)
+	if err != nil {
+		return errors.Wrap(err, "save job stats")
+	}
+
+	var ack, upstream any
+	if stats.Info.HookAck != nil {
+		ack = stats.Info.HookAck.JSON()
+	}
+	if !utils.IsEmptyStr(stats.Info.UpstreamJobID) {
+		upstream = stats.Info.UpstreamJobID
+	}
+
+	ormer, err := orm.FromContext(pt.ormContext())
+	if err != nil {
+		return err
+	}
+
+	sql := `INSERT INTO job_service_stats (job_id, upstream_job_id, status, revision, run_at, info, ack, update_time)
+		VALUES (?, ?, ?, ?, ?, ?::jsonb, ?::jsonb, ?)
+		ON CONFLICT (job_id) DO UPDATE SET upstream_job_id = EXCLUDED.upstream_job_id, status = EXCLUDED.status,
+		revision = EXCLUDED.revision, run_at = EXCLUDED.run_at, info = EXCLUDED.info,
+		ack = COALESCE(EXCLUDED.ack, job_service_stats.ack), update_time = EXCLUDED.update_time`
+	if _, err := ormer.Raw(sql, stats.Info.JobID, upstream, stats.Info.Status, stats.Info.Revision,
+		stats.Info.RunAt, string(data), ack, now).Exec(); err != nil {
+		return errors.Wrap(err, "save job stats")
+	}
+
+	return nil
+}
+
+// UpdateStatusWithRetry updates the status with retry enabled.
+// If update status failed, then retry if permitted.
+func (pt *pgTracker) UpdateStatusWithRetry(targetStatus Status) error {
+	err := CompareAndSetPgStatus(pt.ormContext(), pt.jobID, targetStatus, pt.jobStats.Info.Revision)
+	if err != nil {
+		// Status mismatching error will be directly ignored as the status has already been outdated
+		if !errs.IsStatusMismatchError(err) && pt.retryList != nil {
+			// Push to the retrying daemon
+			pt.retryList.Push(SimpleStatusChange{
+				JobID:        pt.jobID,
+				TargetStatus: targetStatus.String(),
+				Revision:     pt.jobStats.Info.Revision,
+			})
+		}
+	}
+
+	return err
+}
+
+// Reset the job status to `pending` and update the revision.
+// Usually for the retry jobs
+func (pt *pgTracker) Reset() error {
+	ormer, err := orm.FromContext(pt.ormContext())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	sql := `UPDATE job_service_stats SET status = ?, revision = ?, update_time = ?, info = info - 'check_in_at'
+		WHERE job_id = ?`
+	if _, err := ormer.Raw(sql, PendingStatus.String(), now, now, pt.jobID).Exec(); err != nil {
+		return errors.Wrap(err, "reset")
+	}
+
+	// Sync current tracker
+	pt.jobStats.Info.Status = PendingStatus.String()
+	pt.jobStats.Info.Revision = now
+	pt.jobStats.Info.UpdateTime = now
+	pt.jobStats.Info.CheckIn = ""
+	pt.jobStats.Info.CheckInAt = 0
+
+	return nil
+}
+
+// FireHook fires status hook event to report current status
+func (pt *pgTracker) FireHook() error {
+	return pt.fireHookEvent(
+		Status(pt.jobStats.Info.Status),
+		pt.jobStats.Info.CheckIn,
+	)
+}
+
+// setStatus sets the job status to the target status and fire status change hook
+func (pt *pgTracker) setStatus(status Status) error {
+	err := pt.UpdateStatusWithRetry(status)
+	if !errs.IsStatusMismatchError(err) {
+		pt.refresh(status)
+		if er := pt.fireHookEvent(status); er != nil {
+			// Add more error context
+			if err != nil {
+				return errors.Wrap(er, err.Error())
+			}
+
+			return er
+		}
+	}
+
+	return err
+}
+
+// refresh the job stats in mem
+func (pt *pgTracker) refresh(targetStatus Status, checkIn ...string) {
+	now := time.Now().Unix()
+
+	pt.jobStats.Info.Status = targetStatus.String()
+	if len(checkIn) > 0 {
+		pt.jobStats.Info.CheckIn = checkIn[0]
+		pt.jobStats.Info.CheckInAt = now
+	}
+	pt.jobStats.Info.UpdateTime = now
+}
+
+// fireHookEvent fires the hook event
+func (pt *pgTracker) fireHookEvent(status Status, checkIn ...string) error {
+	// Check if hook URL is registered
+	if utils.IsEmptyStr(pt.jobStats.Info.WebHookURL) {
+		// Do nothing
+		return nil
+	}
+
+	change := &StatusChange{
+		JobID:    pt.jobID,
+		Status:   status.String(),
+		Metadata: pt.jobStats.Info,
+	}
+
+	if len(checkIn) > 0 {
+		change.CheckIn = checkIn[0]
+	}
+
+	// If callback is registered, then trigger now
+	if pt.callback != nil {
+		return pt.callback(pt.jobStats.Info.WebHookURL, change)
+	}
+
+	return nil
+}
+
+// ormContext returns the context carrying a new orm, the status updating should not be
+// canceled along with the system context when the jobservice is stopping
+func (pt *pgTracker) ormContext() context.Context {
+	ctx := pt.context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return orm.Copy(ctx)
+}
+
+// CompareAndSetPgStatus sets the status of the job stats in the database if the change is not outdated,
+// the same rule as the redis based tracker is applied:
+// a change with a newer revision or a later status of the same revision (including the same status) is accepted.
+func CompareAndSetPgStatus(ctx context.Context, jobID string, targetStatus Status, revision int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`UPDATE job_service_stats SET status = ?, update_time = ?
+		WHERE job_id = ? AND (revision < ? OR (revision = ? AND (%s < ? OR status = ?)))`, PgStatusCode("status"))
+	res, err := ormer.Raw(sql, targetStatus.String(), time.Now().Unix(), jobID,
+		revision, revision, targetStatus.Code(), targetStatus.String()).Exec()
+	if err != nil {
+		return errors.Wrap(err, "compare and set status error")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "compare and set status error")
+	}
+	if n > 0 {
+		return nil
+	}
+
+	current, err := pgStatus(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	return errs.StatusMismatchError(current, targetStatus.String())
+}
+
+func pgStatus(ctx context.Context, jobID string) (string, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var status string
+	if err := ormer.Raw(`SELECT status FROM job_service_stats WHERE job_id = ?`, jobID).QueryRow(&status); err != nil {
+		if errors.Is(err, o.ErrNoRows) {
+			return "", errs.NoObjectFoundError(jobID)
+		}
+
+		return "", errors.Wrap(err, "get status error")
+	}
+
+	return status, nil
+}
+
+func toInt64(v any, defaultValue int64) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case int:
+		return int64(n)
+	default:
+		return defaultValue
+	}
+}
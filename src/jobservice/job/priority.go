@@ -14,10 +14,26 @@
 
 package job
 
+import (
+	"github.com/goharbor/harbor/src/jobservice/config"
+)
+
 const (
 	defaultPriority uint = 1000
+	// MaxPriority is the highest priority of the job
+	MaxPriority = config.MaxJobPriority
 )
 
+// Policy is the scheduling policy of the job type which is enforced by the worker pool
+type Policy struct {
+	// Priority of the job type, between 1 and MaxPriority
+	Priority uint `json:"priority"`
+	// MaxConcurrency is the max number of the running jobs of the job type, 0 means no limit
+	MaxConcurrency uint `json:"max_concurrency"`
+	// Customized is true when the policy is adjusted at runtime, it overrides the configured one
+	Customized bool `json:"customized"`
+}
+
 // PrioritySampler define the job priority generation method
 type PrioritySampler interface {
 	// Priority for the given job.
@@ -34,10 +50,17 @@ type PrioritySampler interface {
 }
 
 // defaultSampler is default implementation of PrioritySampler
-type defaultSampler struct{}
+type defaultSampler struct {
+	// the configured job policies which take precedence over the built-in priorities
+	policies map[string]*config.JobPolicyConfig
+}
 
 // For the given job
 func (ps *defaultSampler) For(job string) uint {
+	if p, ok := ps.policies[job]; ok && p != nil && p.Priority != nil {
+		return *p.Priority
+	}
+
 	switch job {
 	// As an example, sample job has the lowest priority
 	case SampleJob:
@@ -54,5 +77,27 @@ func (ps *defaultSampler) For(job string) uint {
 
 // Priority returns the default job priority sampler implementation.
 func Priority() PrioritySampler {
-	return &defaultSampler{}
+	return &defaultSampler{policies: configuredPolicies()}
+}
+
+// PolicyOf returns the configured scheduling policy of the given job.
+// The maxConcurrency declared by the job implementation is used if the concurrency is not configured.
+func PolicyOf(job string, maxConcurrency uint) *Policy {
+	policy := &Policy{
+		Priority:       Priority().For(job),
+		MaxConcurrency: maxConcurrency,
+	}
+	if p, ok := configuredPolicies()[job]; ok && p != nil && p.MaxConcurrency != nil {
+		policy.MaxConcurrency = *p.MaxConcurrency
+	}
+
+	return policy
+}
+
+func configuredPolicies() map[string]*config.JobPolicyConfig {
+	if config.DefaultConfig == nil || config.DefaultConfig.PoolConfig == nil {
+		return nil
+	}
+
+	return config.DefaultConfig.PoolConfig.JobPolicies
 }
@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
 )
 
 // PrioritySamplerSuite is test suite for PrioritySampler.
@@ -51,3 +53,31 @@ func (suite *PrioritySamplerSuite) Test() {
 	p4 := suite.sampler.For(SlackJobVendorType)
 	suite.Equal((uint)(1), p4, "Job priority for %s", SlackJobVendorType)
 }
+
+// TestConfigured tests the configured priorities and concurrency limits
+func (suite *PrioritySamplerSuite) TestConfigured() {
+	concurrency, priority := uint(3), uint(5000)
+	sampler := &defaultSampler{
+		policies: map[string]*config.JobPolicyConfig{
+			ReplicationVendorType: {Priority: &priority},
+			SampleJob:             {MaxConcurrency: &concurrency},
+		},
+	}
+	suite.Equal((uint)(5000), sampler.For(ReplicationVendorType), "Job priority for %s", ReplicationVendorType)
+	suite.Equal((uint)(1), sampler.For(SampleJob), "Job priority for %s", SampleJob)
+	suite.Equal(defaultPriority, sampler.For(RetentionVendorType), "Job priority for %s", RetentionVendorType)
+
+	origin := config.DefaultConfig
+	defer func() { config.DefaultConfig = origin }()
+	config.DefaultConfig = &config.Configuration{
+		PoolConfig: &config.PoolConfig{JobPolicies: sampler.policies},
+	}
+
+	p := PolicyOf(ReplicationVendorType, 10)
+	suite.Equal((uint)(5000), p.Priority, "Job priority for %s", ReplicationVendorType)
+	suite.Equal((uint)(10), p.MaxConcurrency, "Job concurrency for %s", ReplicationVendorType)
+
+	p = PolicyOf(SampleJob, 10)
+	suite.Equal((uint)(1), p.Priority, "Job priority for %s", SampleJob)
+	suite.Equal((uint)(3), p.MaxConcurrency, "Job concurrency for %s", SampleJob)
+}
@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lcm
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/common/list"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// pgController is the implementation of Controller based on the postgresql database
+type pgController struct {
+	context   context.Context
+	callback  job.HookCallback
+	wg        *sync.WaitGroup
+	retryList *list.SyncList
+}
+
+// NewPgController is the constructor of the postgresql based controller
+func NewPgController(ctx *env.Context, callback job.HookCallback) Controller {
+	return &pgController{
+		context:   ctx.SystemContext,
+		callback:  callback,
+		wg:        ctx.WG,
+		retryList: list.New(),
+	}
+}
+
+// Serve ...
+func (pc *pgController) Serve() error {
+	pc.wg.Add(1)
+	go pc.loopForRestoreDeadStatus()
+
+	logger.Info("Status restoring loop is started")
+
+	return nil
+}
+
+// New tracker
+func (pc *pgController) New(stats *job.Stats) (job.Tracker, error) {
+	if stats == nil {
+		return nil, errors.New("nil stats when creating job tracker")
+	}
+
+	if err := stats.Validate(); err != nil {
+		return nil, errors.Errorf("error occurred when creating job tracker: %s", err)
+	}
+
+	pt := job.NewPgTrackerWithStats(pc.context, stats, pc.callback, pc.retryList)
+	if err := pt.Save(); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+// Track and attache with the job
+func (pc *pgController) Track(jobID string) (job.Tracker, error) {
+	pt := job.NewPgTrackerWithID(pc.context, jobID, pc.callback, pc.retryList)
+	if err := pt.Load(); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+// loopForRestoreDeadStatus is a loop to restore the dead states of jobs
+func (pc *pgController) loopForRestoreDeadStatus() {
+	// Generate random timer duration
+	rd := func() time.Duration {
+		return longLoopInterval + time.Duration(rand.Int31n(shortInterval))*time.Second
+	}
+
+	defer func() {
+		logger.Info("Status restoring loop is stopped")
+		pc.wg.Done()
+	}()
+
+	tm := time.NewTimer(shortInterval * time.Second)
+	defer tm.Stop()
+
+	for {
+		select {
+		case <-tm.C:
+			tm.Reset(rd())
+			pc.retryLoop()
+		case <-pc.context.Done():
+			return // terminated
+		}
+	}
+}
+
+// retryLoop iterates the retry queue and do retrying
+func (pc *pgController) retryLoop() {
+	ctx := orm.Copy(pc.context)
+	pc.retryList.Iterate(func(ele any) bool {
+		if change, ok := ele.(job.SimpleStatusChange); ok {
+			logger.Debugf("Retry the status update action: %v", change)
+
+			err := job.CompareAndSetPgStatus(ctx, change.JobID, job.Status(change.TargetStatus), change.Revision)
+			if err != nil {
+				logger.Errorf("Failed to retry the status update action: %v : %s", err, "retry loop: lcm")
+			}
+
+			if err == nil || errs.IsStatusMismatchError(err) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
@@ -33,14 +33,14 @@ type DBLogger struct {
 	key           string
 }
 
-// NewDBLogger crates a new DB logger
+// NewDBLogger crates a new DB logger, the logs are stored as JSON lines
 // nil might be returned
 func NewDBLogger(key string, level string, depth int) (*DBLogger, error) {
 	buffer := bytes.NewBuffer(make([]byte, 0))
 	bw := bufio.NewWriter(buffer)
 	logLevel := parseLevel(level)
 
-	backendLogger := log.New(bw, log.NewTextFormatter(), logLevel, depth)
+	backendLogger := log.New(bw, log.NewJSONFormatter(), logLevel, depth)
 
 	return &DBLogger{
 		backendLogger: backendLogger,
@@ -70,6 +70,16 @@ func (dbl *DBLogger) Close() error {
 	return nil
 }
 
+// WithFields returns the logger attaching the fields to the logs
+func (dbl *DBLogger) WithFields(fields map[string]any) *DBLogger {
+	return &DBLogger{
+		backendLogger: dbl.backendLogger.WithFields(fields),
+		bw:            dbl.bw,
+		buffer:        dbl.buffer,
+		key:           dbl.key,
+	}
+}
+
 // Debug ...
 func (dbl *DBLogger) Debug(v ...any) {
 	dbl.backendLogger.Debug(v...)
@@ -27,15 +27,15 @@ type FileLogger struct {
 	streamRef     *os.File
 }
 
-// NewFileLogger crates a new file logger
+// NewFileLogger crates a new file logger, the logs are formatted as plain text or JSON lines according to the format
 // nil might be returned
-func NewFileLogger(level string, logPath string, depth int) (*FileLogger, error) {
+func NewFileLogger(level string, logPath string, depth int, format string) (*FileLogger, error) {
 	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY, 0600)
 	if err != nil {
 		return nil, err
 	}
 	logLevel := parseLevel(level)
-	backendLogger := log.New(f, log.NewTextFormatter(), logLevel, depth)
+	backendLogger := log.New(f, newFormatter(format), logLevel, depth)
 
 	return &FileLogger{
 		backendLogger: backendLogger,
@@ -53,6 +53,14 @@ func (fl *FileLogger) Close() error {
 	return nil
 }
 
+// WithFields returns the logger attaching the fields to the logs
+func (fl *FileLogger) WithFields(fields map[string]any) *FileLogger {
+	return &FileLogger{
+		backendLogger: fl.backendLogger.WithFields(fields),
+		streamRef:     fl.streamRef,
+	}
+}
+
 // Debug ...
 func (fl *FileLogger) Debug(v ...any) {
 	fl.backendLogger.Debug(v...)
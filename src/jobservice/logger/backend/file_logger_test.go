@@ -8,14 +8,14 @@ import (
 
 // Test file logger creation with non existing file path
 func TestFileLoggerCreation(t *testing.T) {
-	if _, err := NewFileLogger("DEBUG", "/non-existing/a.log", 4); err == nil {
+	if _, err := NewFileLogger("DEBUG", "/non-existing/a.log", 4, ""); err == nil {
 		t.Fatalf("expect non nil error but got nil when creating file logger with non existing path")
 	}
 }
 
 // Test file logger
 func TestFileLogger(t *testing.T) {
-	l, err := NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	l, err := NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, "")
 	if err != nil {
 		t.Fatal(err)
 	}
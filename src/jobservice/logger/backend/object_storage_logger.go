@@ -0,0 +1,146 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/logger/objectstore"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const objectStorageUploadTimeout = 5 * time.Minute
+
+// ObjectStorageLogger spools the logs to a local temporary file and uploads them to the S3 compatible object storage
+// when closing, so the memory usage doesn't grow with the size of the logs
+type ObjectStorageLogger struct {
+	backendLogger *log.Logger
+	bw            *bufio.Writer
+	spool         *os.File
+	client        *objectstore.Client
+	key           string
+	tags          *Tags
+}
+
+// NewObjectStorageLogger is constructor of ObjectStorageLogger, the logs are stored as JSON lines
+func NewObjectStorageLogger(client *objectstore.Client, logID string, tags *Tags, level string, depth int) (*ObjectStorageLogger, error) {
+	spool, err := os.CreateTemp("", "job-log-*.log")
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(spool)
+	logLevel := parseLevel(level)
+
+	backendLogger := log.New(bw, log.NewJSONFormatter(), logLevel, depth)
+
+	return &ObjectStorageLogger{
+		backendLogger: backendLogger,
+		bw:            bw,
+		spool:         spool,
+		client:        client,
+		key:           client.Key(logID),
+		tags:          tags,
+	}, nil
+}
+
+// Close the logger and upload the logs, the object is tagged with the job info in the metadata
+func (osl *ObjectStorageLogger) Close() error {
+	defer func() {
+		_ = osl.spool.Close()
+		_ = os.Remove(osl.spool.Name())
+	}()
+
+	if err := osl.bw.Flush(); err != nil {
+		return err
+	}
+	size, err := osl.spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := osl.spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), objectStorageUploadTimeout)
+	defer cancel()
+
+	return osl.client.Upload(ctx, osl.key, osl.spool, size, osl.tags.toMap("job-id", "vendor-type", "execution-id"))
+}
+
+// WithFields returns the logger attaching the fields to the logs
+func (osl *ObjectStorageLogger) WithFields(fields map[string]any) *ObjectStorageLogger {
+	return &ObjectStorageLogger{
+		backendLogger: osl.backendLogger.WithFields(fields),
+		bw:            osl.bw,
+		spool:         osl.spool,
+		client:        osl.client,
+		key:           osl.key,
+		tags:          osl.tags,
+	}
+}
+
+// Debug ...
+func (osl *ObjectStorageLogger) Debug(v ...any) {
+	osl.backendLogger.Debug(v...)
+}
+
+// Debugf with format
+func (osl *ObjectStorageLogger) Debugf(format string, v ...any) {
+	osl.backendLogger.Debugf(format, v...)
+}
+
+// Info ...
+func (osl *ObjectStorageLogger) Info(v ...any) {
+	osl.backendLogger.Info(v...)
+}
+
+// Infof with format
+func (osl *ObjectStorageLogger) Infof(format string, v ...any) {
+	osl.backendLogger.Infof(format, v...)
+}
+
+// Warning ...
+func (osl *ObjectStorageLogger) Warning(v ...any) {
+	osl.backendLogger.Warning(v...)
+}
+
+// Warningf with format
+func (osl *ObjectStorageLogger) Warningf(format string, v ...any) {
+	osl.backendLogger.Warningf(format, v...)
+}
+
+// Error ...
+func (osl *ObjectStorageLogger) Error(v ...any) {
+	osl.backendLogger.Error(v...)
+}
+
+// Errorf with format
+func (osl *ObjectStorageLogger) Errorf(format string, v ...any) {
+	osl.backendLogger.Errorf(format, v...)
+}
+
+// Fatal error
+func (osl *ObjectStorageLogger) Fatal(v ...any) {
+	osl.backendLogger.Fatal(v...)
+}
+
+// Fatalf error
+func (osl *ObjectStorageLogger) Fatalf(format string, v ...any) {
+	osl.backendLogger.Fatalf(format, v...)
+}
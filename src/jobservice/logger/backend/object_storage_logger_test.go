@@ -0,0 +1,61 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/jobservice/logger/objectstore"
+)
+
+// Test object storage logger spooling the logs to the local file
+func TestObjectStorageLogger(t *testing.T) {
+	var (
+		path string
+		data []byte
+		meta http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		data, _ = io.ReadAll(r.Body)
+		meta = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client, err := objectstore.NewClient(&objectstore.Options{Endpoint: server.URL, Bucket: "job-logs"})
+	require.Nil(t, err)
+	tags := &Tags{JobID: "job_id", VendorType: "GARBAGE_COLLECTION", ExecutionID: 12}
+	l, err := NewObjectStorageLogger(client, "job_id", tags, "INFO", 4)
+	require.Nil(t, err)
+
+	l.Debug("JobLog Debug: TestObjectStorageLogger")
+	l.Infof("JobLog Infof: %s", "TestObjectStorageLogger")
+	spool := l.spool.Name()
+	require.Nil(t, l.Close())
+
+	assert.Equal(t, "/job-logs/job_id.log", path)
+	assert.Equal(t, "GARBAGE_COLLECTION", meta.Get("X-Amz-Meta-Vendor-Type"))
+	assert.Contains(t, string(data), "JobLog Infof: TestObjectStorageLogger")
+	assert.NotContains(t, string(data), "JobLog Debug")
+	_, err = os.Stat(spool)
+	assert.True(t, os.IsNotExist(err))
+}
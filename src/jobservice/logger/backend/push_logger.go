@@ -0,0 +1,443 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const (
+	// PushProtocolLoki pushes the logs via the push API of Loki
+	PushProtocolLoki = "loki"
+	// PushProtocolOTLP pushes the logs via the OTLP/HTTP logs API with the JSON encoding
+	PushProtocolOTLP = "otlp"
+
+	pushServiceName = "harbor-jobservice"
+	pushBatchSize   = 500
+	pushTimeout     = 30 * time.Second
+	// the max count of the full batches waiting to be pushed, the batches are dropped when the queue is full
+	pushQueueSize = 8
+)
+
+// pushEntry is a log entry to push
+type pushEntry struct {
+	time  time.Time
+	level log.Level
+	line  string
+}
+
+// pusher sends the log entries to the log aggregation system
+type pusher interface {
+	push(ctx context.Context, entries []*pushEntry) error
+}
+
+// PushLogger pushes the logs to the log aggregation systems like Loki or the OTLP collectors in batches,
+// the log entries are tagged with the job ID, vendor type and execution ID.
+type PushLogger struct {
+	backendLogger *log.Logger
+	batcher       *pushBatcher
+}
+
+// NewPushLogger is constructor of PushLogger
+func NewPushLogger(protocol, endpoint string, headers map[string]string, tags *Tags, level string, depth int) (*PushLogger, error) {
+	if len(endpoint) == 0 {
+		return nil, errors.New("missing endpoint of the push logger")
+	}
+
+	client := &http.Client{
+		Timeout:   pushTimeout,
+		Transport: commonhttp.GetHTTPTransport(),
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	var p pusher
+	switch strings.ToLower(protocol) {
+	case PushProtocolLoki:
+		p = &lokiPusher{
+			url:     endpoint + "/loki/api/v1/push",
+			headers: headers,
+			client:  client,
+			tags:    tags,
+		}
+	case PushProtocolOTLP:
+		p = &otlpPusher{
+			url:     endpoint + "/v1/logs",
+			headers: headers,
+			client:  client,
+			tags:    tags,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported protocol of the push logger: %s", protocol)
+	}
+
+	b := newPushBatcher(p)
+	return &PushLogger{
+		backendLogger: log.New(io.Discard, b, parseLevel(level), depth),
+		batcher:       b,
+	}, nil
+}
+
+// Close the logger and push the remaining entries
+func (pl *PushLogger) Close() error {
+	return pl.batcher.close()
+}
+
+// WithFields returns the logger attaching the fields to the logs, the logs are still collected and pushed by
+// the batcher of the original logger
+func (pl *PushLogger) WithFields(fields map[string]any) *PushLogger {
+	return &PushLogger{
+		backendLogger: pl.backendLogger.WithFields(fields),
+		batcher:       pl.batcher,
+	}
+}
+
+// Debug ...
+func (pl *PushLogger) Debug(v ...any) {
+	pl.backendLogger.Debug(v...)
+}
+
+// Debugf with format
+func (pl *PushLogger) Debugf(format string, v ...any) {
+	pl.backendLogger.Debugf(format, v...)
+}
+
+// Info ...
+func (pl *PushLogger) Info(v ...any) {
+	pl.backendLogger.Info(v...)
+}
+
+// Infof with format
+func (pl *PushLogger) Infof(format string, v ...any) {
+	pl.backendLogger.Infof(format, v...)
+}
+
+// Warning ...
+func (pl *PushLogger) Warning(v ...any) {
+	pl.backendLogger.Warning(v...)
+}
+
+// Warningf with format
+func (pl *PushLogger) Warningf(format string, v ...any) {
+	pl.backendLogger.Warningf(format, v...)
+}
+
+// Error ...
+func (pl *PushLogger) Error(v ...any) {
+	pl.backendLogger.Error(v...)
+}
+
+// Errorf with format
+func (pl *PushLogger) Errorf(format string, v ...any) {
+	pl.backendLogger.Errorf(format, v...)
+}
+
+// Fatal error
+func (pl *PushLogger) Fatal(v ...any) {
+	pl.backendLogger.Fatal(v...)
+}
+
+// Fatalf error
+func (pl *PushLogger) Fatalf(format string, v ...any) {
+	pl.backendLogger.Fatalf(format, v...)
+}
+
+// pushBatcher collects the log entries into batches and pushes the full batches in the background,
+// so the logging calls of the job aren't blocked by the log aggregation system
+type pushBatcher struct {
+	pusher  pusher
+	lock    sync.Mutex
+	entries []*pushEntry
+	queue   chan []*pushEntry
+	done    chan struct{}
+	closed  bool
+	dropped int
+	// keep the error of pushing the former batches
+	lastErr error
+}
+
+func newPushBatcher(p pusher) *pushBatcher {
+	b := &pushBatcher{
+		pusher:  p,
+		entries: make([]*pushEntry, 0),
+		queue:   make(chan []*pushEntry, pushQueueSize),
+		done:    make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+// Format implements log.Formatter to collect the log entries, the batch is queued to push once it's full
+func (b *pushBatcher) Format(r *log.Record) ([]byte, error) {
+	line := r.Msg
+	if len(r.Line) > 0 {
+		line = r.Line + " " + r.Msg
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return nil, nil
+	}
+	b.entries = append(b.entries, &pushEntry{
+		time:  r.Time,
+		level: r.Lvl,
+		line:  strings.TrimSuffix(line, "\n"),
+	})
+	if len(b.entries) >= pushBatchSize {
+		select {
+		case b.queue <- b.entries:
+		default:
+			// drop the batch rather than blocking the job or using the unlimited memory
+			b.dropped += len(b.entries)
+		}
+		b.entries = make([]*pushEntry, 0)
+	}
+
+	return nil, nil
+}
+
+// loop pushes the queued batches until the queue is closed, the entries are dropped even the pushing is failed
+func (b *pushBatcher) loop() {
+	defer close(b.done)
+	for entries := range b.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+		err := b.pusher.push(ctx, entries)
+		cancel()
+		if err != nil {
+			b.lock.Lock()
+			b.lastErr = err
+			b.lock.Unlock()
+		}
+	}
+}
+
+// close queues the remaining entries and waits for all the batches to be pushed
+func (b *pushBatcher) close() error {
+	b.lock.Lock()
+	if b.closed {
+		b.lock.Unlock()
+		<-b.done
+		return nil
+	}
+	b.closed = true
+	entries := b.entries
+	b.entries = nil
+	b.lock.Unlock()
+
+	// no more writer of the queue after it's marked as closed
+	if len(entries) > 0 {
+		b.queue <- entries
+	}
+	close(b.queue)
+	<-b.done
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.lastErr != nil {
+		return b.lastErr
+	}
+	if b.dropped > 0 {
+		return fmt.Errorf("%d log entries are dropped as the push queue is full", b.dropped)
+	}
+	return nil
+}
+
+// lokiPusher pushes the logs via the push API of Loki
+type lokiPusher struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	tags    *Tags
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	// [timestamp in nanoseconds, line, structured metadata]
+	Values [][]any `json:"values"`
+}
+
+func (lp *lokiPusher) push(ctx context.Context, entries []*pushEntry) error {
+	// Only the low cardinality tags are used as the stream labels,
+	// the job ID and execution ID are attached as the structured metadata.
+	labels := lp.tags.toMap("job_id", "vendor_type", "execution_id")
+	stream := map[string]string{"service_name": pushServiceName}
+	if vendorType, ok := labels["vendor_type"]; ok {
+		stream["vendor_type"] = vendorType
+		delete(labels, "vendor_type")
+	}
+
+	values := make([][]any, 0, len(entries))
+	for _, e := range entries {
+		metadata := maps.Clone(labels)
+		metadata["level"] = levelText(e.level)
+		values = append(values, []any{strconv.FormatInt(e.time.UnixNano(), 10), e.line, metadata})
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"streams": []*lokiStream{{Stream: stream, Values: values}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, lp.client, lp.url, lp.headers, body)
+}
+
+// otlpPusher pushes the logs via the OTLP/HTTP logs API with the JSON encoding
+type otlpPusher struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	tags    *Tags
+}
+
+type otlpAttribute struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string            `json:"timeUnixNano"`
+	SeverityNumber int               `json:"severityNumber"`
+	SeverityText   string            `json:"severityText"`
+	Body           map[string]string `json:"body"`
+	Attributes     []*otlpAttribute  `json:"attributes,omitempty"`
+}
+
+func (op *otlpPusher) push(ctx context.Context, entries []*pushEntry) error {
+	tags := op.tags.toMap("harbor.job.id", "harbor.job.vendor_type", "harbor.job.execution_id")
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]*otlpAttribute, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, stringAttribute(k, tags[k]))
+	}
+
+	records := make([]*otlpLogRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, &otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(e.time.UnixNano(), 10),
+			SeverityNumber: severityNumber(e.level),
+			SeverityText:   levelText(e.level),
+			Body:           map[string]string{"stringValue": e.line},
+			Attributes:     attrs,
+		})
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"resourceLogs": []any{
+			map[string]any{
+				"resource": map[string]any{
+					"attributes": []*otlpAttribute{stringAttribute("service.name", pushServiceName)},
+				},
+				"scopeLogs": []any{
+					map[string]any{
+						"scope":      map[string]string{"name": "job-logger"},
+						"logRecords": records,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, op.client, op.url, op.headers, body)
+}
+
+func stringAttribute(key, value string) *otlpAttribute {
+	return &otlpAttribute{Key: key, Value: map[string]string{"stringValue": value}}
+}
+
+// send posts the JSON body to the URL
+func send(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push logs to %s error: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("push logs to %s failed with status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return nil
+}
+
+// levelText returns the text of the log level
+func levelText(lvl log.Level) string {
+	switch lvl {
+	case log.DebugLevel:
+		return "DEBUG"
+	case log.InfoLevel:
+		return "INFO"
+	case log.WarningLevel:
+		return "WARNING"
+	case log.ErrorLevel:
+		return "ERROR"
+	case log.FatalLevel:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// severityNumber returns the severity number of the level defined in the OpenTelemetry log data model
+func severityNumber(lvl log.Level) int {
+	switch lvl {
+	case log.DebugLevel:
+		return 5
+	case log.InfoLevel:
+		return 9
+	case log.WarningLevel:
+		return 13
+	case log.ErrorLevel:
+		return 17
+	case log.FatalLevel:
+		return 21
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver records the payloads pushed to the fake log aggregation system
+type receiver struct {
+	path     string
+	headers  http.Header
+	payloads []map[string]any
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.path = req.URL.Path
+	r.headers = req.Header.Clone()
+	data, _ := io.ReadAll(req.Body)
+	payload := map[string]any{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.payloads = append(r.payloads, payload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Test push logger with the Loki protocol
+func TestLokiPushLogger(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	tags := &Tags{JobID: "job_id", VendorType: "GARBAGE_COLLECTION", ExecutionID: 12}
+	l, err := NewPushLogger(PushProtocolLoki, server.URL+"/", map[string]string{"X-Scope-OrgID": "harbor"}, tags, "INFO", 4)
+	require.Nil(t, err)
+
+	l.Debug("JobLog Debug: TestLokiPushLogger")
+	l.Info("JobLog Info: TestLokiPushLogger")
+	l.Errorf("JobLog Errorf: %s", "TestLokiPushLogger")
+	require.Nil(t, l.Close())
+
+	require.Len(t, rc.payloads, 1)
+	assert.Equal(t, "/loki/api/v1/push", rc.path)
+	assert.Equal(t, "harbor", rc.headers.Get("X-Scope-OrgID"))
+
+	streams := rc.payloads[0]["streams"].([]any)
+	require.Len(t, streams, 1)
+	stream := streams[0].(map[string]any)
+	assert.Equal(t, map[string]any{"service_name": "harbor-jobservice", "vendor_type": "GARBAGE_COLLECTION"}, stream["stream"])
+
+	values := stream["values"].([]any)
+	require.Len(t, values, 2)
+	last := values[1].([]any)
+	assert.Contains(t, last[1], "JobLog Errorf: TestLokiPushLogger")
+	assert.Equal(t, map[string]any{"job_id": "job_id", "execution_id": "12", "level": "ERROR"}, last[2])
+}
+
+// Test push logger with the OTLP protocol
+func TestOTLPPushLogger(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	tags := &Tags{JobID: "job_id", VendorType: "REPLICATION"}
+	l, err := NewPushLogger(PushProtocolOTLP, server.URL, nil, tags, "DEBUG", 4)
+	require.Nil(t, err)
+
+	for range pushBatchSize + 1 {
+		l.Warning("JobLog Warning: TestOTLPPushLogger")
+	}
+	require.Nil(t, l.Close())
+
+	// The full batch is pushed before closing
+	require.Len(t, rc.payloads, 2)
+	assert.Equal(t, "/v1/logs", rc.path)
+
+	resourceLogs := rc.payloads[1]["resourceLogs"].([]any)
+	scopeLogs := resourceLogs[0].(map[string]any)["scopeLogs"].([]any)
+	records := scopeLogs[0].(map[string]any)["logRecords"].([]any)
+	require.Len(t, records, 1)
+	record := records[0].(map[string]any)
+	assert.Equal(t, "WARNING", record["severityText"])
+	assert.EqualValues(t, 13, record["severityNumber"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "harbor.job.id", "value": map[string]any{"stringValue": "job_id"}},
+		map[string]any{"key": "harbor.job.vendor_type", "value": map[string]any{"stringValue": "REPLICATION"}},
+	}, record["attributes"])
+}
+
+// Test push logger with the bad settings
+func TestPushLoggerErr(t *testing.T) {
+	_, err := NewPushLogger(PushProtocolLoki, "", nil, nil, "INFO", 4)
+	require.NotNil(t, err)
+	_, err = NewPushLogger("unknown", "http://loki:3100", nil, nil, "INFO", 4)
+	require.NotNil(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	l, err := NewPushLogger(PushProtocolLoki, server.URL, nil, nil, "INFO", 4)
+	require.Nil(t, err)
+	l.Info("JobLog Info: TestPushLoggerErr")
+	require.NotNil(t, l.Close())
+}
+
+// Test the logging calls aren't blocked by the slow log aggregation system
+func TestPushLoggerNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	pushed := make(chan struct{}, 2*pushBatchSize)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		pushed <- struct{}{}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	l, err := NewPushLogger(PushProtocolLoki, server.URL, nil, nil, "INFO", 4)
+	require.Nil(t, err)
+
+	logged := make(chan struct{})
+	go func() {
+		for range 2 * pushBatchSize {
+			l.Info("JobLog Info: TestPushLoggerNonBlocking")
+		}
+		close(logged)
+	}()
+	select {
+	case <-logged:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the logging calls are blocked by the pushing")
+	}
+
+	close(release)
+	require.Nil(t, l.Close())
+	assert.Len(t, pushed, 2)
+}
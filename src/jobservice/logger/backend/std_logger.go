@@ -47,6 +47,13 @@ func NewStdOutputLogger(level string, output string, depth int) *StdOutputLogger
 	}
 }
 
+// WithFields returns the logger attaching the fields to the logs
+func (sl *StdOutputLogger) WithFields(fields map[string]any) *StdOutputLogger {
+	return &StdOutputLogger{
+		backendLogger: sl.backendLogger.WithFields(fields),
+	}
+}
+
 // Debug ...
 func (sl *StdOutputLogger) Debug(v ...any) {
 	sl.backendLogger.Debug(v...)
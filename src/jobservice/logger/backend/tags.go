@@ -0,0 +1,44 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"strconv"
+)
+
+// Tags of the job which the logs belong to
+type Tags struct {
+	JobID       string
+	VendorType  string
+	ExecutionID int64
+}
+
+// toMap returns the non-empty tags with the keys
+func (t *Tags) toMap(jobIDKey, vendorTypeKey, executionIDKey string) map[string]string {
+	m := make(map[string]string)
+	if t == nil {
+		return m
+	}
+	if len(t.JobID) > 0 {
+		m[jobIDKey] = t.JobID
+	}
+	if len(t.VendorType) > 0 {
+		m[vendorTypeKey] = t.VendorType
+	}
+	if t.ExecutionID > 0 {
+		m[executionIDKey] = strconv.FormatInt(t.ExecutionID, 10)
+	}
+	return m
+}
@@ -17,9 +17,19 @@ package backend
 import (
 	"strings"
 
+	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/lib/log"
 )
 
+// newFormatter returns the formatter of the format, the logs are formatted as plain text by default
+func newFormatter(format string) log.Formatter {
+	if strings.EqualFold(format, query.LogFormatJSON) {
+		return log.NewJSONFormatter()
+	}
+
+	return log.NewTextFormatter()
+}
+
 func parseLevel(lvl string) log.Level {
 	var level = log.WarningLevel
 
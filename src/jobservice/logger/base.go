@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
 	"sync"
 
@@ -226,7 +227,14 @@ func Init(ctx context.Context) error {
 	for _, lc := range config.DefaultConfig.JobLoggerConfigs {
 		jOptions = append(jOptions, BackendOption(lc.Name, lc.Level, lc.Settings))
 		if lc.Sweeper != nil {
-			sOptions = append(sOptions, SweeperOption(lc.Name, lc.Sweeper.Duration, lc.Sweeper.Settings))
+			sSettings := lc.Sweeper.Settings
+			if lc.Name == NameObjectStorage {
+				// The sweeper shares the connection settings with the object storage logger
+				sSettings = make(map[string]any)
+				maps.Copy(sSettings, lc.Settings)
+				maps.Copy(sSettings, lc.Sweeper.Settings)
+			}
+			sOptions = append(sOptions, SweeperOption(lc.Name, lc.Sweeper.Duration, sSettings))
 		}
 	}
 
@@ -32,6 +32,16 @@ func NewEntry(loggers []Interface) *Entry {
 	}
 }
 
+// WithFields returns the entry attaching the fields to the logs of all the loggers
+func (e *Entry) WithFields(fields map[string]any) *Entry {
+	loggers := make([]Interface, 0, len(e.loggers))
+	for _, l := range e.loggers {
+		loggers = append(loggers, WithFields(l, fields))
+	}
+
+	return NewEntry(loggers)
+}
+
 // Debug ...
 func (e *Entry) Debug(v ...any) {
 	for _, l := range e.loggers {
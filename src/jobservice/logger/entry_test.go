@@ -47,7 +47,7 @@ func TestEntry(t *testing.T) {
 	require.Nil(t, err)
 	loggers = append(loggers, dbl)
 
-	fl, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	fl, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, "")
 	require.Nil(t, err)
 	loggers = append(loggers, fl)
 
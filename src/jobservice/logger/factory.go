@@ -16,9 +16,11 @@ package logger
 
 import (
 	"errors"
+	"fmt"
 	"path"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/backend"
+	"github.com/goharbor/harbor/src/jobservice/logger/objectstore"
 )
 
 // Factory creates a new logger based on the settings.
@@ -27,8 +29,8 @@ type Factory func(options ...OptionItem) (Interface, error)
 // FileFactory is factory of file logger
 func FileFactory(options ...OptionItem) (Interface, error) {
 	var (
-		level, baseDir, fileName string
-		depth                    int
+		level, baseDir, fileName, format string
+		depth                            int
 	)
 	for _, op := range options {
 		switch op.Field() {
@@ -38,6 +40,8 @@ func FileFactory(options ...OptionItem) (Interface, error) {
 			baseDir = op.String()
 		case "filename":
 			fileName = op.String()
+		case "format":
+			format = op.String()
 		case "depth":
 			depth = op.Int()
 		default:
@@ -52,7 +56,7 @@ func FileFactory(options ...OptionItem) (Interface, error) {
 		return nil, errors.New("missing file name option of the file logger")
 	}
 
-	return backend.NewFileLogger(level, path.Join(baseDir, fileName), depth)
+	return backend.NewFileLogger(level, path.Join(baseDir, fileName), depth, format)
 }
 
 // StdFactory is factory of std output logger.
@@ -100,3 +104,126 @@ func DBFactory(options ...OptionItem) (Interface, error) {
 
 	return backend.NewDBLogger(key, level, depth)
 }
+
+// ObjectStorageFactory is factory of the S3 compatible object storage logger
+func ObjectStorageFactory(options ...OptionItem) (Interface, error) {
+	var (
+		level, key string
+		depth      int
+	)
+	for _, op := range options {
+		switch op.Field() {
+		case "level":
+			level = op.String()
+		case "key":
+			key = op.String()
+		case "depth":
+			depth = op.Int()
+		default:
+		}
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("missing key option of the object storage logger")
+	}
+
+	client, err := objectStoreClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.NewObjectStorageLogger(client, key, tagsOf(options...), level, depth)
+}
+
+// LokiFactory is factory of the logger pushing logs to Loki
+func LokiFactory(options ...OptionItem) (Interface, error) {
+	return pushFactory(backend.PushProtocolLoki, options...)
+}
+
+// OTLPFactory is factory of the logger pushing logs to the OTLP/HTTP logs endpoint
+func OTLPFactory(options ...OptionItem) (Interface, error) {
+	return pushFactory(backend.PushProtocolOTLP, options...)
+}
+
+func pushFactory(protocol string, options ...OptionItem) (Interface, error) {
+	var (
+		level, endpoint string
+		depth           int
+	)
+	headers := make(map[string]string)
+	for _, op := range options {
+		switch op.Field() {
+		case "level":
+			level = op.String()
+		case "endpoint":
+			endpoint = op.String()
+		case "tenant":
+			// The tenant of the multi-tenant Loki
+			if len(op.String()) > 0 {
+				headers["X-Scope-OrgID"] = op.String()
+			}
+		case "headers":
+			if hs, ok := op.Raw().(map[string]any); ok {
+				for k, v := range hs {
+					headers[k] = fmt.Sprintf("%v", v)
+				}
+			}
+		case "depth":
+			depth = op.Int()
+		default:
+		}
+	}
+
+	return backend.NewPushLogger(protocol, endpoint, headers, tagsOf(options...), level, depth)
+}
+
+// objectStoreClient creates the client of the object storage with the settings
+func objectStoreClient(options ...OptionItem) (*objectstore.Client, error) {
+	opts := &objectstore.Options{}
+	for _, op := range options {
+		switch op.Field() {
+		case "endpoint":
+			opts.Endpoint = op.String()
+		case "region":
+			opts.Region = op.String()
+		case "bucket":
+			opts.Bucket = op.String()
+		case "access_key":
+			opts.AccessKey = op.String()
+		case "secret_key":
+			opts.SecretKey = op.String()
+		case "prefix":
+			opts.Prefix = op.String()
+		case "insecure":
+			opts.Insecure, _ = op.Raw().(bool)
+		default:
+		}
+	}
+
+	return objectstore.NewClient(opts)
+}
+
+// tagsOf returns the tags of the job which the logs belong to
+func tagsOf(options ...OptionItem) *backend.Tags {
+	tags := &backend.Tags{}
+	for _, op := range options {
+		switch op.Field() {
+		case "job_id":
+			tags.JobID = op.String()
+		case "vendor_type":
+			tags.VendorType = op.String()
+		case "execution_id":
+			switch v := op.Raw().(type) {
+			case int64:
+				tags.ExecutionID = v
+			case int:
+				tags.ExecutionID = int64(v)
+			case float64:
+				tags.ExecutionID = int64(v)
+			}
+		default:
+		}
+	}
+
+	return tags
+}
@@ -73,3 +73,46 @@ func TestDBFactoryErr1(t *testing.T) {
 	_, err := DBFactory(ois...)
 	require.NotNil(t, err)
 }
+
+// TestObjectStorageFactory
+func TestObjectStorageFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"endpoint", "http://minio:9000"})
+	ois = append(ois, OptionItem{"bucket", "job-logs"})
+	ois = append(ois, OptionItem{"depth", 5})
+
+	// missing key
+	_, err := ObjectStorageFactory(ois...)
+	require.NotNil(t, err)
+
+	ois = append(ois, OptionItem{"key", "key_object_storage_logger_unit_test"})
+	ois = append(ois, OptionItem{"execution_id", float64(12)})
+	l, err := ObjectStorageFactory(ois...)
+	require.Nil(t, err)
+	require.Equal(t, NameObjectStorage, GetLoggerName(l))
+	require.Equal(t, int64(12), tagsOf(ois...).ExecutionID)
+
+	// missing bucket
+	_, err = ObjectStorageFactory(OptionItem{"key", "key"}, OptionItem{"endpoint", "http://minio:9000"})
+	require.NotNil(t, err)
+}
+
+// TestPushFactory
+func TestPushFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"depth", 5})
+
+	// missing endpoint
+	_, err := LokiFactory(ois...)
+	require.NotNil(t, err)
+
+	ois = append(ois, OptionItem{"endpoint", "http://loki:3100"})
+	ois = append(ois, OptionItem{"tenant", "harbor"})
+	_, err = LokiFactory(ois...)
+	require.Nil(t, err)
+
+	_, err = OTLPFactory(OptionItem{"endpoint", "http://otel-collector:4318"}, OptionItem{"headers", map[string]any{"Authorization": "Bearer token"}})
+	require.Nil(t, err)
+}
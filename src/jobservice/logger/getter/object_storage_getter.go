@@ -0,0 +1,59 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package getter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/logger/objectstore"
+)
+
+const objectStorageDownloadTimeout = 2 * time.Minute
+
+// ObjectStorageGetter is responsible for retrieving the log data from the S3 compatible object storage
+type ObjectStorageGetter struct {
+	client *objectstore.Client
+}
+
+// NewObjectStorageGetter is constructor of ObjectStorageGetter
+func NewObjectStorageGetter(client *objectstore.Client) *ObjectStorageGetter {
+	return &ObjectStorageGetter{
+		client: client,
+	}
+}
+
+// Retrieve implements @Interface.Retrieve
+func (osg *ObjectStorageGetter) Retrieve(logID string) ([]byte, error) {
+	if err := isValidLogID(logID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), objectStorageDownloadTimeout)
+	defer cancel()
+
+	data, err := osg.client.Get(ctx, osg.client.Key(logID), logSizeLimit())
+	if err != nil {
+		if errors.Is(err, objectstore.ErrObjectNotFound) {
+			return nil, errs.NoObjectFoundError(fmt.Sprintf("log entity: %s", logID))
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
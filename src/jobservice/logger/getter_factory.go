@@ -44,3 +44,13 @@ func FileGetterFactory(options ...OptionItem) (getter.Interface, error) {
 func DBGetterFactory(_ ...OptionItem) (getter.Interface, error) {
 	return getter.NewDBGetter(), nil
 }
+
+// ObjectStorageGetterFactory creates a getter for the object storage logger
+func ObjectStorageGetterFactory(options ...OptionItem) (getter.Interface, error) {
+	client, err := objectStoreClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return getter.NewObjectStorageGetter(client), nil
+}
@@ -14,6 +14,10 @@
 
 package logger
 
+import (
+	"github.com/goharbor/harbor/src/jobservice/logger/backend"
+)
+
 // Interface for logger.
 type Interface interface {
 	// For debuging
@@ -46,3 +50,28 @@ type Interface interface {
 	// For fatal error with error
 	Fatalf(format string, v ...any)
 }
+
+// WithFields returns the logger attaching the structured fields like the repository or the digest to the logs.
+// The logger itself is returned if it doesn't support the fields.
+func WithFields(l Interface, fields map[string]any) Interface {
+	if len(fields) == 0 {
+		return l
+	}
+
+	switch fl := l.(type) {
+	case *Entry:
+		return fl.WithFields(fields)
+	case *backend.FileLogger:
+		return fl.WithFields(fields)
+	case *backend.DBLogger:
+		return fl.WithFields(fields)
+	case *backend.StdOutputLogger:
+		return fl.WithFields(fields)
+	case *backend.ObjectStorageLogger:
+		return fl.WithFields(fields)
+	case *backend.PushLogger:
+		return fl.WithFields(fields)
+	default:
+		return l
+	}
+}
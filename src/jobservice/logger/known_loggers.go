@@ -29,6 +29,12 @@ const (
 	NameStdOutput = "STD_OUTPUT"
 	// NameDB is the unique name of the DB logger.
 	NameDB = "DB"
+	// NameObjectStorage is the unique name of the S3 compatible object storage logger.
+	NameObjectStorage = "OBJECT_STORAGE"
+	// NameLoki is the unique name of the logger pushing logs to Loki.
+	NameLoki = "LOKI"
+	// NameOTLP is the unique name of the logger pushing logs to the OTLP/HTTP logs endpoint.
+	NameOTLP = "OTLP"
 )
 
 // Declaration is used to declare a supported logger.
@@ -52,6 +58,11 @@ var knownLoggers = map[string]*Declaration{
 	NameStdOutput: {StdFactory, nil, nil, true},
 	// DB logger
 	NameDB: {DBFactory, DBSweeperFactory, DBGetterFactory, false},
+	// S3 compatible object storage logger
+	NameObjectStorage: {ObjectStorageFactory, ObjectStorageSweeperFactory, ObjectStorageGetterFactory, false},
+	// Log aggregation systems, the logs are retrieved from the systems directly
+	NameLoki: {LokiFactory, nil, nil, false},
+	NameOTLP: {OTLPFactory, nil, nil, false},
 }
 
 // IsKnownLogger checks if the logger is supported with name.
@@ -107,6 +118,8 @@ func GetLoggerName(l Interface) string {
 		name = NameStdOutput
 	case *backend.FileLogger:
 		name = NameFile
+	case *backend.ObjectStorageLogger:
+		name = NameObjectStorage
 	default:
 		name = reflect.TypeOf(l).String()
 	}
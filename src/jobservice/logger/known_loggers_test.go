@@ -26,6 +26,13 @@ func TestKnownLoggers(t *testing.T) {
 	// has getter
 	b = HasGetter(NameDB)
 	require.True(t, b)
+	b = HasGetter(NameObjectStorage)
+	require.True(t, b)
+	// the logs pushed to the log aggregation systems are not retrieved by job service
+	b = HasGetter(NameLoki)
+	require.False(t, b)
+	b = HasGetter(NameOTLP)
+	require.False(t, b)
 
 	// no sweeper
 	b = HasSweeper(NameStdOutput)
@@ -33,6 +40,8 @@ func TestKnownLoggers(t *testing.T) {
 	// has sweeper
 	b = HasSweeper(NameDB)
 	require.True(t, b)
+	b = HasSweeper(NameObjectStorage)
+	require.True(t, b)
 
 	// unknown level
 	b = IsKnownLevel("unknown")
@@ -54,7 +63,7 @@ func TestGetLoggerName(t *testing.T) {
 	stdLog := backend.NewStdOutputLogger("DEBUG", backend.StdErr, 4)
 	require.Equal(t, NameStdOutput, GetLoggerName(stdLog))
 
-	fileLog, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
+	fileLog, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4, "")
 	require.Nil(t, err)
 	require.Equal(t, NameFile, GetLoggerName(fileLog))
 
@@ -0,0 +1,489 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	service       = "s3"
+	// envAccessKey and envSecretKey are used when the credentials are not set in the options,
+	// to avoid keeping the secret in the configuration file
+	envAccessKey = "AWS_ACCESS_KEY_ID"
+	envSecretKey = "AWS_SECRET_ACCESS_KEY"
+	// metaPrefix is the header prefix of the user-defined object metadata
+	metaPrefix = "X-Amz-Meta-"
+	// partSize is the size of the parts to upload the large objects, the min part size of S3 is 5MiB
+	partSize = 8 << 20
+)
+
+// ErrObjectNotFound is returned when the object does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// Options of the S3 compatible object storage
+type Options struct {
+	// Endpoint of the object storage with scheme, e.g: https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// Prefix of the object keys
+	Prefix string
+	// Skip the verification of the server certificate
+	Insecure bool
+}
+
+// Object is the brief info of the object in the bucket
+type Object struct {
+	Key          string
+	LastModified time.Time
+}
+
+// Client is a lightweight client of the S3 compatible object storage which only supports the operations
+// required by the job logs. The path style URL is used to be compatible with the most implementations.
+type Client struct {
+	endpoint    *url.URL
+	bucket      string
+	prefix      string
+	region      string
+	credentials aws.Credentials
+	signer      *v4.Signer
+	client      *http.Client
+}
+
+// NewClient creates a client of the object storage with the options
+func NewClient(opts *Options) (*Client, error) {
+	if opts == nil {
+		return nil, errors.New("nil object storage options")
+	}
+	if len(opts.Endpoint) == 0 {
+		return nil, errors.New("missing endpoint of the object storage")
+	}
+	if len(opts.Bucket) == 0 {
+		return nil, errors.New("missing bucket of the object storage")
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(opts.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint of the object storage: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid scheme of the object storage endpoint: %s", opts.Endpoint)
+	}
+
+	region := opts.Region
+	if len(region) == 0 {
+		region = defaultRegion
+	}
+	accessKey, secretKey := opts.AccessKey, opts.SecretKey
+	if len(accessKey) == 0 && len(secretKey) == 0 {
+		accessKey, secretKey = os.Getenv(envAccessKey), os.Getenv(envSecretKey)
+	}
+
+	return &Client{
+		endpoint: endpoint,
+		bucket:   opts.Bucket,
+		prefix:   strings.Trim(opts.Prefix, "/"),
+		region:   region,
+		credentials: aws.Credentials{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+		},
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			// S3 does not double escape the path
+			o.DisableURIPathEscaping = true
+		}),
+		client: &http.Client{
+			Timeout:   5 * time.Minute,
+			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(opts.Insecure)),
+		},
+	}, nil
+}
+
+// Key returns the object key of the log with the prefix
+func (c *Client) Key(logID string) string {
+	return path.Join(c.prefix, fmt.Sprintf("%s.log", logID))
+}
+
+// Prefix returns the prefix of the object keys
+func (c *Client) Prefix() string {
+	if len(c.prefix) == 0 {
+		return ""
+	}
+	return c.prefix + "/"
+}
+
+// Put uploads the data as the object with the user-defined metadata
+func (c *Client) Put(ctx context.Context, key string, data []byte, metadata map[string]string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	for k, v := range metadata {
+		req.Header.Set(metaPrefix+k, v)
+	}
+
+	resp, err := c.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Upload uploads the data read from the reader as the object with the user-defined metadata,
+// the objects larger than the part size are uploaded in parts to keep only one part in the memory
+func (c *Client) Upload(ctx context.Context, key string, r io.Reader, size int64, metadata map[string]string) error {
+	if size <= partSize {
+		data, err := io.ReadAll(io.LimitReader(r, size))
+		if err != nil {
+			return err
+		}
+		return c.Put(ctx, key, data, metadata)
+	}
+
+	uploadID, err := c.createMultipartUpload(ctx, key, metadata)
+	if err != nil {
+		return err
+	}
+
+	parts, err := c.uploadParts(ctx, key, uploadID, r)
+	if err == nil {
+		err = c.completeMultipartUpload(ctx, key, uploadID, parts)
+	}
+	if err != nil {
+		// abort the upload to release the storage of the uploaded parts
+		if e := c.abortMultipartUpload(context.Background(), key, uploadID); e != nil {
+			return fmt.Errorf("%w, abort the multipart upload: %v", err, e)
+		}
+		return err
+	}
+	return nil
+}
+
+// completedPart is the part info in the request to complete the multipart upload
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (c *Client) createMultipartUpload(ctx context.Context, key string, metadata map[string]string) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	for k, v := range metadata {
+		req.Header.Set(metaPrefix+k, v)
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp)
+	}
+	result := &struct {
+		UploadID string `xml:"UploadId"`
+	}{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return "", fmt.Errorf("decode the multipart upload: %w", err)
+	}
+	return result.UploadID, nil
+}
+
+func (c *Client) uploadParts(ctx context.Context, key, uploadID string, r io.Reader) ([]*completedPart, error) {
+	parts := make([]*completedPart, 0)
+	buf := make([]byte, partSize)
+	for number := 1; ; number++ {
+		n, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+
+		query := url.Values{}
+		query.Set("partNumber", fmt.Sprintf("%d", number))
+		query.Set("uploadId", uploadID)
+		req, err := c.newRequest(ctx, http.MethodPut, key, query, buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(req, buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, responseError(resp)
+		}
+		parts = append(parts, &completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+
+		if n < partSize {
+			return parts, nil
+		}
+	}
+}
+
+func (c *Client) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []*completedPart) error {
+	body, err := xml.Marshal(&struct {
+		XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+		Parts   []*completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+
+	resp, err := c.do(req, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the error might be returned in the body with the 200 status code
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK || bytes.Contains(data, []byte("<Error>")) {
+		return fmt.Errorf("object storage request %s %s failed with status %d: %s",
+			req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Get downloads the object, only the last tail bytes are returned if tail > 0
+func (c *Client) Get(ctx context.Context, key string, tail int64) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tail > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=-%d", tail))
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return io.ReadAll(resp.Body)
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Empty object
+		return []byte{}, nil
+	case http.StatusNotFound:
+		return nil, ErrObjectNotFound
+	default:
+		return nil, responseError(resp)
+	}
+}
+
+// Delete removes the object
+func (c *Client) Delete(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// List lists all the objects whose keys start with the prefix
+func (c *Client) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects := make([]*Object, 0)
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if len(prefix) > 0 {
+			query.Set("prefix", prefix)
+		}
+		if len(token) > 0 {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		result, err := c.list(req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range result.Contents {
+			objects = append(objects, &Object{
+				Key:          o.Key,
+				LastModified: o.LastModified,
+			})
+		}
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// listBucketResult is the response of the ListObjectsV2 API
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (c *Client) list(req *http.Request) (*listBucketResult, error) {
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	result := &listBucketResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("decode the objects list: %w", err)
+	}
+	return result, nil
+}
+
+// newRequest builds the path style request of the object or the bucket if the key is empty
+func (c *Client) newRequest(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	u := *c.endpoint
+	u.RawPath = path.Join("/", c.endpoint.EscapedPath(), url.PathEscape(c.bucket))
+	u.Path = path.Join("/", c.endpoint.Path, c.bucket)
+	if len(key) > 0 {
+		u.Path = u.Path + "/" + key
+		u.RawPath = u.RawPath + "/" + escapeKey(key)
+	}
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = int64(len(body))
+	}
+	return req, nil
+}
+
+// do signs and sends the request
+func (c *Client) do(req *http.Request, body []byte) (*http.Response, error) {
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if len(c.credentials.AccessKeyID) > 0 {
+		if err := c.signer.SignHTTP(req.Context(), c.credentials, req, payloadHash, service, c.region, time.Now().UTC()); err != nil {
+			return nil, fmt.Errorf("sign the object storage request: %w", err)
+		}
+	}
+
+	return c.client.Do(req)
+}
+
+// escapeKey escapes the segments of the object key
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// responseError builds the error with the error response of the object storage
+func responseError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("object storage request %s %s failed with status %d: %s",
+		resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,268 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeS3 is an in-memory S3 compatible server supporting the path style requests of one bucket
+type fakeS3 struct {
+	lock     sync.Mutex
+	bucket   string
+	objects  map[string][]byte
+	modTimes map[string]time.Time
+	metadata map[string]http.Header
+	pageSize int
+	// the uploaded parts of the multipart uploads
+	uploads map[string]map[int][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/"+f.bucket)
+	key = strings.TrimPrefix(key, "/")
+	uploadID := r.URL.Query().Get("uploadId")
+	switch {
+	case r.Method == http.MethodGet && len(key) == 0:
+		f.list(w, r)
+	case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+		uploadID = fmt.Sprintf("upload-%d", len(f.uploads)+1)
+		f.uploads[uploadID] = map[int][]byte{}
+		f.metadata[key] = r.Header.Clone()
+		_, _ = fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", uploadID)
+	case r.Method == http.MethodPut && len(uploadID) > 0:
+		number, _ := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		data, _ := io.ReadAll(r.Body)
+		f.uploads[uploadID][number] = data
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && len(uploadID) > 0:
+		data := make([]byte, 0)
+		for i := 1; i <= len(f.uploads[uploadID]); i++ {
+			data = append(data, f.uploads[uploadID][i]...)
+		}
+		delete(f.uploads, uploadID)
+		f.objects[key] = data
+		f.modTimes[key] = time.Now()
+		_, _ = w.Write([]byte("<CompleteMultipartUploadResult></CompleteMultipartUploadResult>"))
+	case r.Method == http.MethodDelete && len(uploadID) > 0:
+		delete(f.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		f.modTimes[key] = time.Now()
+		f.metadata[key] = r.Header.Clone()
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if rg := r.Header.Get("Range"); len(rg) > 0 {
+			n, _ := strconv.Atoi(strings.TrimPrefix(rg, "bytes=-"))
+			if len(data) == 0 {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			if n < len(data) {
+				data = data[len(data)-n:]
+			}
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	keys := make([]string, 0)
+	for k := range f.objects {
+		if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+	end := min(start+f.pageSize, len(keys))
+	type content struct {
+		Key          string
+		LastModified string
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []content
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}{}
+	for _, k := range keys[start:end] {
+		result.Contents = append(result.Contents, content{Key: k, LastModified: f.modTimes[k].UTC().Format(time.RFC3339)})
+	}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = fmt.Sprintf("%d", end)
+	}
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// ClientTestSuite tests the client of the object storage
+type ClientTestSuite struct {
+	suite.Suite
+
+	fake   *fakeS3
+	server *httptest.Server
+	client *Client
+}
+
+// SetupTest prepares the fake object storage
+func (suite *ClientTestSuite) SetupTest() {
+	suite.fake = &fakeS3{
+		bucket:   "job-logs",
+		objects:  make(map[string][]byte),
+		modTimes: make(map[string]time.Time),
+		metadata: make(map[string]http.Header),
+		pageSize: 2,
+		uploads:  make(map[string]map[int][]byte),
+	}
+	suite.server = httptest.NewServer(suite.fake)
+
+	client, err := NewClient(&Options{
+		Endpoint:  suite.server.URL,
+		Bucket:    "job-logs",
+		Prefix:    "/harbor/",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	suite.Require().NoError(err)
+	suite.client = client
+}
+
+// TearDownTest closes the fake object storage
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// TestClientTestSuite is entry of go test
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+// TestNewClient tests the validation of the options
+func (suite *ClientTestSuite) TestNewClient() {
+	_, err := NewClient(nil)
+	suite.Error(err)
+	_, err = NewClient(&Options{Bucket: "b"})
+	suite.Error(err)
+	_, err = NewClient(&Options{Endpoint: "http://minio:9000"})
+	suite.Error(err)
+	_, err = NewClient(&Options{Endpoint: "minio:9000", Bucket: "b"})
+	suite.Error(err)
+
+	suite.Equal("harbor/job_id.log", suite.client.Key("job_id"))
+	suite.Equal("harbor/", suite.client.Prefix())
+}
+
+// TestObject tests the operations of the object
+func (suite *ClientTestSuite) TestObject() {
+	ctx := context.Background()
+	key := suite.client.Key("a@1")
+
+	err := suite.client.Put(ctx, key, []byte("line1\nline2\n"), map[string]string{"job-id": "a"})
+	suite.Require().NoError(err)
+	suite.Equal("a", suite.fake.metadata[key].Get("X-Amz-Meta-Job-Id"))
+
+	data, err := suite.client.Get(ctx, key, 0)
+	suite.Require().NoError(err)
+	suite.Equal("line1\nline2\n", string(data))
+
+	data, err = suite.client.Get(ctx, key, 6)
+	suite.Require().NoError(err)
+	suite.Equal("line2\n", string(data))
+
+	suite.Require().NoError(suite.client.Delete(ctx, key))
+	_, err = suite.client.Get(ctx, key, 0)
+	suite.ErrorIs(err, ErrObjectNotFound)
+}
+
+// TestUpload tests uploading the small object at once and the large object in parts
+func (suite *ClientTestSuite) TestUpload() {
+	ctx := context.Background()
+	key := suite.client.Key("b@1")
+
+	small := "line1\n"
+	err := suite.client.Upload(ctx, key, strings.NewReader(small), int64(len(small)), map[string]string{"job-id": "b"})
+	suite.Require().NoError(err)
+	suite.Equal(small, string(suite.fake.objects[key]))
+
+	large := strings.Repeat("0123456789abcdef", partSize/8+1)
+	err = suite.client.Upload(ctx, key, strings.NewReader(large), int64(len(large)), map[string]string{"job-id": "b"})
+	suite.Require().NoError(err)
+	suite.Equal(large, string(suite.fake.objects[key]))
+	suite.Equal("b", suite.fake.metadata[key].Get("X-Amz-Meta-Job-Id"))
+	suite.Empty(suite.fake.uploads)
+}
+
+// TestList tests listing the objects with the pagination
+func (suite *ClientTestSuite) TestList() {
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "c"} {
+		suite.Require().NoError(suite.client.Put(ctx, suite.client.Key(id), []byte(id), nil))
+	}
+	suite.Require().NoError(suite.client.Put(ctx, "other/d.log", []byte("d"), nil))
+
+	objects, err := suite.client.List(ctx, suite.client.Prefix())
+	suite.Require().NoError(err)
+	suite.Require().Len(objects, 3)
+	suite.Equal("harbor/a.log", objects[0].Key)
+	suite.Equal("harbor/c.log", objects[2].Key)
+	suite.False(objects[0].LastModified.IsZero())
+}
+
+// TestUnauthorized tests the error of the rejected request
+func (suite *ClientTestSuite) TestUnauthorized() {
+	client, err := NewClient(&Options{Endpoint: suite.server.URL, Bucket: "job-logs"})
+	suite.Require().NoError(err)
+	if len(client.credentials.AccessKeyID) > 0 {
+		suite.T().Skip("credentials are set in the environment")
+	}
+
+	err = client.Put(context.Background(), "a.log", []byte("a"), nil)
+	suite.ErrorContains(err, "403")
+}
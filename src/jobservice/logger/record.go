@@ -0,0 +1,225 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/common/query"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// the plain text entry formatted as "time [level] line message"
+var textEntryPattern = regexp.MustCompile(`^(\S+) \[(DEBUG|INFO|WARNING|ERROR|FATAL)\] (.*)$`)
+
+// the max size of the single line of the job log
+const maxLogLineSize = 16 * 1024 * 1024
+
+// Record is the structured entry of the job log
+type Record struct {
+	log.JSONRecord
+
+	level log.Level
+	// the original lines of the plain text entry
+	text string
+}
+
+// ParseRecords parses the job log data into the structured entries. Both the JSON lines and the plain text lines
+// written by the earlier versions are supported, the plain text lines which don't start a new entry are appended
+// to the message of the previous entry.
+func ParseRecords(data []byte) ([]*Record, error) {
+	var records []*Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(line, "{") {
+			r := &Record{}
+			if err := json.Unmarshal([]byte(line), &r.JSONRecord); err == nil {
+				r.level = levelOf(r.Level)
+				records = append(records, r)
+				continue
+			}
+		}
+
+		if m := textEntryPattern.FindStringSubmatch(line); m != nil {
+			r := &Record{text: line}
+			r.Level, r.level = m[2], levelOf(m[2])
+			if t, err := time.Parse(time.RFC3339, m[1]); err == nil {
+				r.Time = t
+			}
+			r.Msg = m[3]
+			records = append(records, r)
+			continue
+		}
+
+		// The continued line of the previous entry
+		if len(records) > 0 {
+			prev := records[len(records)-1]
+			prev.Msg = prev.Msg + "\n" + line
+			if len(prev.text) > 0 {
+				prev.text = prev.text + "\n" + line
+			}
+			continue
+		}
+		records = append(records, &Record{
+			JSONRecord: log.JSONRecord{Msg: line},
+			level:      log.DebugLevel,
+			text:       line,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "parse job log")
+	}
+
+	return records, nil
+}
+
+// HasJSONRecords returns whether the job log data contains the entries stored as JSON lines
+func HasJSONRecords(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("{")) || bytes.Contains(data, []byte("\n{"))
+}
+
+// FilterRecords returns the entries matching the query
+func FilterRecords(records []*Record, q *query.LogQuery) ([]*Record, error) {
+	if q == nil || (len(q.Level) == 0 && len(q.Keyword) == 0) {
+		return records, nil
+	}
+
+	minLevel := log.DebugLevel
+	if len(q.Level) > 0 {
+		minLevel = levelOf(q.Level)
+		if minLevel < 0 {
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid log level: %s", q.Level)
+		}
+	}
+	keyword := strings.ToLower(q.Keyword)
+
+	filtered := make([]*Record, 0)
+	for _, r := range records {
+		if r.level < minLevel {
+			continue
+		}
+		if len(keyword) > 0 && !r.contains(keyword) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	return filtered, nil
+}
+
+// RenderRecords renders the entries in the format of the query,
+// the plain text is rendered in the same layout as the text formatter
+func RenderRecords(records []*Record, q *query.LogQuery) ([]byte, error) {
+	format := query.LogFormatText
+	if q != nil && len(q.Format) > 0 {
+		format = q.Format
+	}
+
+	switch format {
+	case query.LogFormatJSON:
+		if records == nil {
+			records = make([]*Record, 0)
+		}
+		return json.Marshal(records)
+	case query.LogFormatText:
+		buf := &bytes.Buffer{}
+		for _, r := range records {
+			buf.WriteString(r.String())
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, errors.BadRequestError(nil).WithMessagef("unsupported log format: %s", format)
+	}
+}
+
+// Query parses the job log data, filters the entries and renders them with the query
+func Query(data []byte, q *query.LogQuery) ([]byte, error) {
+	records, err := ParseRecords(data)
+	if err != nil {
+		return nil, err
+	}
+	if records, err = FilterRecords(records, q); err != nil {
+		return nil, err
+	}
+
+	return RenderRecords(records, q)
+}
+
+// String renders the entry as the plain text
+func (r *Record) String() string {
+	if len(r.text) > 0 {
+		return r.text
+	}
+
+	s := fmt.Sprintf("%s [%s] ", r.Time.Format(time.RFC3339), r.Level)
+	line := r.Line
+	if len(r.Fields) > 0 {
+		keys := make([]string, 0, len(r.Fields))
+		for k := range r.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf(`%v="%v"`, k, r.Fields[k]))
+		}
+		line = line + "[" + strings.Join(parts, " ") + "]"
+	}
+	if len(line) > 0 {
+		s = s + line + ": "
+	}
+
+	return strings.TrimSuffix(s+r.Msg, "\n")
+}
+
+// contains returns whether the message, the line or the fields contain the lowercase keyword
+func (r *Record) contains(keyword string) bool {
+	if strings.Contains(strings.ToLower(r.Msg), keyword) || strings.Contains(strings.ToLower(r.Line), keyword) {
+		return true
+	}
+	for k, v := range r.Fields {
+		if strings.Contains(strings.ToLower(k), keyword) || strings.Contains(strings.ToLower(fmt.Sprint(v)), keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// levelOf returns the level by the name, -1 is returned if the name is unknown
+func levelOf(name string) log.Level {
+	for lvl := log.DebugLevel; lvl <= log.FatalLevel; lvl++ {
+		if strings.EqualFold(lvl.String(), name) {
+			return lvl
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/jobservice/common/query"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const legacyLog = `2024-01-01T00:00:00Z [INFO] [/jobservice/job.go:12]: job started
+2024-01-01T00:00:01Z [ERROR] [/jobservice/job.go:20]: failed to copy library/hello
+stack line 1
+stack line 2
+`
+
+func TestParseLegacyRecords(t *testing.T) {
+	records, err := ParseRecords([]byte(legacyLog))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "INFO", records[0].Level)
+	assert.Equal(t, "ERROR", records[1].Level)
+	assert.Contains(t, records[1].Msg, "stack line 2")
+
+	// the plain text is rendered as it was
+	data, err := RenderRecords(records, nil)
+	require.NoError(t, err)
+	assert.Equal(t, legacyLog, string(data))
+}
+
+func TestQueryStructuredLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := log.New(buf, log.NewJSONFormatter(), log.DebugLevel)
+	l.Debug("debug message")
+	l.WithFields(log.Fields{"repository": "library/hello", "digest": "sha256:abc"}).Info("copying the artifact")
+	l.WithFields(log.Fields{"repository": "library/busybox"}).Error("failed to copy the artifact")
+
+	assert.True(t, HasJSONRecords(buf.Bytes()))
+	assert.False(t, HasJSONRecords([]byte(legacyLog)))
+
+	// render as the plain text without query
+	data, err := Query(buf.Bytes(), &query.LogQuery{})
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 3)
+	assert.NotContains(t, string(data), "{")
+
+	// filter by level
+	data, err = Query(buf.Bytes(), &query.LogQuery{Level: "info"})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `[INFO]`)
+	assert.Contains(t, lines[0], `[digest="sha256:abc" repository="library/hello"]: copying the artifact`)
+
+	// search the fields and render as JSON
+	data, err = Query(buf.Bytes(), &query.LogQuery{Keyword: "BUSYBOX", Format: query.LogFormatJSON})
+	require.NoError(t, err)
+	var records []*log.JSONRecord
+	require.NoError(t, json.Unmarshal(data, &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "ERROR", records[0].Level)
+	assert.Equal(t, "library/busybox", records[0].Fields["repository"])
+
+	// nothing matched
+	data, err = Query(buf.Bytes(), &query.LogQuery{Keyword: "nothing", Format: query.LogFormatJSON})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	// invalid query
+	_, err = Query(buf.Bytes(), &query.LogQuery{Level: "verbose"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+	_, err = Query(buf.Bytes(), &query.LogQuery{Format: "xml"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sweeper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/logger/objectstore"
+)
+
+// ObjectStorageSweeper is used to sweep the logs kept in the S3 compatible object storage
+type ObjectStorageSweeper struct {
+	duration int
+	client   *objectstore.Client
+}
+
+// NewObjectStorageSweeper is constructor of ObjectStorageSweeper
+func NewObjectStorageSweeper(client *objectstore.Client, duration int) *ObjectStorageSweeper {
+	return &ObjectStorageSweeper{
+		duration: duration,
+		client:   client,
+	}
+}
+
+// Sweep logs
+func (oss *ObjectStorageSweeper) Sweep() (int, error) {
+	ctx := context.Background()
+
+	objects, err := oss.client.List(ctx, oss.client.Prefix())
+	if err != nil {
+		return 0, fmt.Errorf("getting outdated log objects failed with error: %s", err)
+	}
+
+	// Start to sweep log objects
+	// Record all errors
+	cleared := 0
+	errs := make([]string, 0)
+	before := time.Now().Add(time.Duration(oss.duration) * oneDay * -1)
+	for _, o := range objects {
+		if !strings.HasSuffix(o.Key, ".log") || !o.LastModified.Before(before) {
+			continue
+		}
+		if err := oss.client.Delete(ctx, o.Key); err != nil {
+			errs = append(errs, fmt.Sprintf("remove log object '%s' error: %s", o.Key, err))
+			continue // go on for next one
+		}
+		cleared++
+	}
+
+	if len(errs) > 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	// Return error with high priority
+	return cleared, err
+}
+
+// Duration for sweeping
+func (oss *ObjectStorageSweeper) Duration() int {
+	return oss.duration
+}
@@ -60,3 +60,20 @@ func DBSweeperFactory(options ...OptionItem) (sweeper.Interface, error) {
 
 	return sweeper.NewDBSweeper(duration), nil
 }
+
+// ObjectStorageSweeperFactory creates sweeper for the object storage logger
+func ObjectStorageSweeperFactory(options ...OptionItem) (sweeper.Interface, error) {
+	var duration = 1
+	for _, op := range options {
+		if op.Field() == "duration" && op.Int() > 0 {
+			duration = op.Int()
+		}
+	}
+
+	client, err := objectStoreClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return sweeper.NewObjectStorageSweeper(client, duration), nil
+}
@@ -0,0 +1,217 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgt
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/jobservice/common/query"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// pgManager is the implementation of @manager based on the postgresql database.
+type pgManager struct {
+	// system context
+	ctx context.Context
+}
+
+// NewPgManager news a postgresql based manager
+func NewPgManager(ctx context.Context) Manager {
+	return &pgManager{
+		ctx: ctx,
+	}
+}
+
+// GetJobs is implementation of Manager.GetJobs
+// To keep consistent with the redis based manager, the offset of the next batch is returned as the cursor,
+// 0 is returned if no more jobs.
+func (pm *pgManager) GetJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
+	cursor, count := int64(0), query.DefaultPageSize
+	if q != nil {
+		if q.PageSize > 0 {
+			count = q.PageSize
+		}
+
+		if cur, ok := q.Extras.Get(query.ExtraParamKeyCursor); ok {
+			cursor = cur.(int64)
+		}
+	}
+
+	ids, err := pm.queryJobIDs(`SELECT job_id FROM job_service_stats ORDER BY job_id LIMIT ? OFFSET ?`, count, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nextCur := int64(0)
+	if len(ids) == int(count) {
+		nextCur = cursor + int64(count)
+	}
+
+	return pm.load(ids), nextCur, nil
+}
+
+// GetPeriodicExecution is implementation of Manager.GetPeriodicExecution
+func (pm *pgManager) GetPeriodicExecution(pID string, q *query.Parameter) ([]*job.Stats, int64, error) {
+	if utils.IsEmptyStr(pID) {
+		return nil, 0, errors.New("nil periodic job ID")
+	}
+
+	t := job.NewPgTrackerWithID(pm.ctx, pID, nil, nil)
+	if err := t.Load(); err != nil {
+		return nil, 0, err
+	}
+
+	if t.Job().Info.JobKind != job.KindPeriodic {
+		return nil, 0, errors.Errorf("only periodic job has executions: %s kind is received", t.Job().Info.JobKind)
+	}
+
+	// The run_at column of the done executions is set to -1
+	cond := `upstream_job_id = ?`
+	if q != nil {
+		if nonStoppedOnly, ok := q.Extras.Get(query.ExtraParamKeyNonStoppedOnly); ok {
+			if v, yes := nonStoppedOnly.(bool); yes && v {
+				cond = `upstream_job_id = ? AND run_at >= 0`
+			}
+		}
+	}
+
+	var pageNumber, pageSize uint = 1, query.DefaultPageSize
+	if q != nil {
+		if q.PageNumber > 0 {
+			pageNumber = q.PageNumber
+		}
+		if q.PageSize > 0 {
+			pageSize = q.PageSize
+		}
+	}
+
+	total, err := pm.count(`SELECT COUNT(*) FROM job_service_stats WHERE `+cond, pID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*job.Stats, 0)
+	if total == 0 || (int64)((pageNumber-1)*pageSize) >= total {
+		return results, total, nil
+	}
+
+	ids, err := pm.queryJobIDs(`SELECT job_id FROM job_service_stats WHERE `+cond+
+		` ORDER BY (info->>'run_at')::bigint DESC LIMIT ? OFFSET ?`, pID, pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(results, pm.load(ids)...), total, nil
+}
+
+// GetScheduledJobs is implementation of Manager.GetScheduledJobs
+func (pm *pgManager) GetScheduledJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
+	var pageNumber, pageSize uint = 1, query.DefaultPageSize
+	if q != nil {
+		if q.PageNumber > 1 {
+			pageNumber = q.PageNumber
+		}
+		if q.PageSize > 0 {
+			pageSize = q.PageSize
+		}
+	}
+
+	cond := `status = 'Scheduled' AND info->>'kind' = 'Scheduled'`
+	total, err := pm.count(`SELECT COUNT(*) FROM job_service_stats WHERE ` + cond)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, err := pm.queryJobIDs(`SELECT job_id FROM job_service_stats WHERE `+cond+
+		` ORDER BY (info->>'run_at')::bigint LIMIT ? OFFSET ?`, pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pm.load(ids), total, nil
+}
+
+// GetJob is implementation of Manager.GetJob
+func (pm *pgManager) GetJob(jobID string) (*job.Stats, error) {
+	if utils.IsEmptyStr(jobID) {
+		return nil, errs.BadRequestError("empty job ID")
+	}
+
+	t := job.NewPgTrackerWithID(pm.ctx, jobID, nil, nil)
+	if err := t.Load(); err != nil {
+		return nil, err
+	}
+
+	return t.Job(), nil
+}
+
+// SaveJob is implementation of Manager.SaveJob
+func (pm *pgManager) SaveJob(j *job.Stats) error {
+	if j == nil {
+		return errs.BadRequestError("nil saving job stats")
+	}
+
+	t := job.NewPgTrackerWithStats(pm.ctx, j, nil, nil)
+	return t.Save()
+}
+
+// load the stats of the jobs, the failed ones are skipped
+func (pm *pgManager) load(ids []string) []*job.Stats {
+	results := make([]*job.Stats, 0, len(ids))
+	for _, id := range ids {
+		t := job.NewPgTrackerWithID(pm.ctx, id, nil, nil)
+		if err := t.Load(); err != nil {
+			logger.Errorf("retrieve stats data of job %s error: %s", id, err)
+			continue
+		}
+
+		results = append(results, t.Job())
+	}
+
+	return results
+}
+
+func (pm *pgManager) queryJobIDs(sql string, args ...any) ([]string, error) {
+	ormer, err := orm.FromContext(orm.Copy(pm.ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	if _, err := ormer.Raw(sql, args...).QueryRows(&ids); err != nil {
+		return nil, errors.Wrap(err, "query job stats")
+	}
+
+	return ids, nil
+}
+
+func (pm *pgManager) count(sql string, args ...any) (int64, error) {
+	ormer, err := orm.FromContext(orm.Copy(pm.ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	if err := ormer.Raw(sql, args...).QueryRow(&total); err != nil {
+		return 0, errors.Wrap(err, "count job stats")
+	}
+
+	return total, nil
+}
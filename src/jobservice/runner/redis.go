@@ -71,6 +71,10 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 
 	// used to instrument process time
 	now := time.Now()
+	// the retried job keeps the enqueue time of the first attempt, only instrument the first one
+	if j.Fails == 0 && j.EnqueuedAt > 0 {
+		metric.JobserviceTaskQueueWaitTimeSummary.WithLabelValues(j.Name).Observe(now.Sub(time.Unix(j.EnqueuedAt, 0)).Seconds())
+	}
 	// Check if the job is a periodic one as periodic job has its own ID format
 	if eID, yes := isPeriodicJobExecution(j); yes {
 		jID = eID
@@ -79,6 +83,14 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	}
 	span.SetAttributes(attribute.Key("jobID").String(jID), attribute.Key("jobName").String(j.Name))
 
+	// Hand over the job to the other worker pools without running it as the worker pool is draining
+	if !rj.context.DrainState.Enter(jID) {
+		logger.Infof("Job '%s:%s' is re-enqueued as the worker pool is draining", j.Name, j.ID)
+		data, _ := job.Parameters(j.Args).Checkpoint()
+		return job.Checkpoint(data)
+	}
+	defer rj.context.DrainState.Leave(jID)
+
 	// As the job stats may not be ready when job executing sometimes (corner case),
 	// the track call here may get NOT_FOUND error. For that case, let's do retry to recovery.
 	for retried := 0; retried <= maxTrackRetries; retried++ {
@@ -116,6 +128,13 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 
 	// Defer to switch status
 	defer func() {
+		// The checkpointed job keeps the running status and it's reset when being run by another worker pool
+		if _, ok := job.CheckpointOf(err); ok {
+			metric.JobserviceTotalTask.WithLabelValues(j.Name, "checkpoint").Inc()
+			logger.Infof("Job '%s:%s' exit with checkpoint for draining", j.Name, j.ID)
+			return
+		}
+
 		// Switch job status based on the returned error.
 		// The err happened here should not override the job run error, just log it.
 		if err != nil {
@@ -227,8 +246,29 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		tracelib.RecordError(span, err, "failed set status to run")
 		return
 	}
-	// Run the job
-	err = runningJob.Run(execContext, j.Args)
+	// Run the job, it's signaled to checkpoint via the op command when the worker pool is draining
+	// and its context is canceled when it's aborted at the deadline of the draining
+	jobCtx, cancel := rj.context.DrainState.JobContext(execContext.SystemContext())
+	defer cancel()
+	err = runningJob.Run(&drainingContext{Context: execContext, ctx: jobCtx, jobID: jID, state: rj.context.DrainState}, j.Args)
+	if rj.context.DrainState.Aborted(jID) {
+		if _, ok := job.CheckpointOf(err); !ok {
+			// The aborted job is handed over to resume from the former checkpoint whatever it returns
+			data, _ := job.Parameters(j.Args).Checkpoint()
+			err = job.Checkpoint(data)
+		}
+	}
+	if data, ok := job.CheckpointOf(err); ok {
+		// Pass the checkpoint to the re-enqueued job
+		if len(data) > 0 {
+			if j.Args == nil {
+				j.Args = make(map[string]any)
+			}
+			j.Args[job.CheckpointParamKey] = data
+		}
+
+		return job.Checkpoint(data)
+	}
 	// Add error context
 	if err != nil {
 		err = errors.Wrap(err, "run error")
@@ -247,6 +287,35 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	return
 }
 
+// drainingContext sends the checkpoint command to the running job when the worker pool is draining,
+// and the stop command when the job is aborted at the deadline of the draining
+type drainingContext struct {
+	job.Context
+	ctx   context.Context
+	jobID string
+	state *job.DrainState
+}
+
+// SystemContext returns the context canceled when the job is aborted
+func (dc *drainingContext) SystemContext() context.Context {
+	return dc.ctx
+}
+
+// OPCommand returns the checkpoint command if no other command and the worker pool is draining
+func (dc *drainingContext) OPCommand() (job.OPCommand, bool) {
+	if cmd, ok := dc.Context.OPCommand(); ok {
+		return cmd, ok
+	}
+	if dc.state.Aborted(dc.jobID) {
+		return job.StopCommand, true
+	}
+	if dc.state.Draining() {
+		return job.CheckpointCommand, true
+	}
+
+	return job.NilCommand, false
+}
+
 func (rj *RedisJob) retry(j job.Interface, wj *work.Job) {
 	if !j.ShouldRetry() {
 		// Cancel retry immediately
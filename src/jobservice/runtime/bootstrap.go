@@ -43,6 +43,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/job/impl/replication"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/sample"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/scandataexport"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/securityhub"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/systemartifact"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -52,9 +53,13 @@ import (
 	sync2 "github.com/goharbor/harbor/src/jobservice/sync"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 	"github.com/goharbor/harbor/src/jobservice/worker/cworker"
+	"github.com/goharbor/harbor/src/jobservice/worker/pgworker"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/metric"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 	redislib "github.com/goharbor/harbor/src/lib/redis"
+	"github.com/goharbor/harbor/src/pkg/maintenance"
 	"github.com/goharbor/harbor/src/pkg/p2p/preheat"
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
 	"github.com/goharbor/harbor/src/pkg/retention"
@@ -67,6 +72,8 @@ const (
 	dialConnectionTimeout = 30 * time.Second
 	dialReadTimeout       = 10 * time.Second
 	dialWriteTimeout      = 10 * time.Second
+	// the interval to reload the paused job types and executions from the database
+	pauseStateRefreshInterval = 10 * time.Second
 )
 
 // JobService ...
@@ -111,6 +118,12 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		rootContext.JobContext = impl.NewDefaultContext(ctx)
 	}
 
+	// Keep the pause state persisted in the database in sync, the database has been initialized along with the job context
+	rootContext.PauseState = job.NewPauseState(loadPaused)
+	rootContext.PauseState.Start(ctx, pauseStateRefreshInterval)
+	// Track the running jobs for draining the worker pool on termination
+	rootContext.DrainState = job.NewDrainState()
+
 	// Alliance to config
 	cfg := config.DefaultConfig
 
@@ -140,36 +153,7 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		// Create hook agent, it's a singleton object
 		// the retryConcurrency keep same with worker num
 		hookAgent := hook.NewAgent(rootContext, namespace, redisPool, workerNum)
-		hookCallback := func(URL string, change *job.StatusChange) error {
-			msg := fmt.Sprintf(
-				"status change: job=%s, status=%s, revision=%d",
-				change.JobID,
-				change.Status,
-				change.Metadata.Revision,
-			)
-			if !utils.IsEmptyStr(change.CheckIn) {
-				// Ignore the real check in message to avoid too big message stream
-				cData := change.CheckIn
-				if len(cData) > 256 {
-					cData = fmt.Sprintf("<DATA BLOCK: %d bytes>", len(cData))
-				}
-				msg = fmt.Sprintf("%s, check_in=%s", msg, cData)
-			}
-
-			evt := &hook.Event{
-				URL:       URL,
-				Timestamp: change.Metadata.UpdateTime, // use update timestamp to avoid duplicated resending.
-				Data:      change,
-				Message:   msg,
-			}
-
-			// Hook event sending should not influence the main job flow (because job may call checkin() in the job run).
-			if err := hookAgent.Trigger(evt); err != nil {
-				logger.Error(err)
-			}
-
-			return nil
-		}
+		hookCallback := newHookCallback(hookAgent)
 
 		// Create job life cycle management controller
 		lcmCtl := lcm.NewController(rootContext, namespace, redisPool, hookCallback)
@@ -216,6 +200,43 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 				logger.Error(err)
 			}
 		}
+	} else if cfg.PoolConfig.Backend == config.JobServicePoolBackendPostgreSQL {
+		// The database has been initialized along with the job context
+		manager = mgt.NewPgManager(ctx)
+		hookAgent := hook.NewPgAgent(rootContext, cfg.PoolConfig.WorkerCount)
+		lcmCtl := lcm.NewPgController(rootContext, newHookCallback(hookAgent))
+
+		// Start the backend worker
+		backendWorker, err = bs.loadAndRunPgWorkerPool(rootContext, cfg.PoolConfig.WorkerCount, lcmCtl)
+		if err != nil {
+			return errors.Errorf("load and run worker error: %s", err)
+		}
+
+		// Run daemon process of life cycle controller
+		if err = lcmCtl.Serve(); err != nil {
+			return errors.Errorf("start life cycle controller error: %s", err)
+		}
+
+		// Initialize sync worker, the paused queues are held by the pause state as no redis flag is used by this backend
+		if bs.syncEnabled {
+			syncWorker = sync2.New(3).
+				WithContext(rootContext).
+				UseManager(manager).
+				UseScheduler(pgworker.NewScheduler(rootContext.SystemContext, lcmCtl)).
+				WithCoreInternalAddr(strings.TrimSuffix(config.GetCoreURL(), "/")).
+				UseCoreScheduler(scheduler.Sched).
+				UseCoreExecutionManager(task.ExecMgr).
+				UseCoreTaskManager(task.Mgr).
+				UseQueueStatusManager(queuestatus.Mgr).
+				WithPolicyLoader(func() ([]*period.Policy, error) {
+					return pgworker.LoadPolicies(orm.Context())
+				})
+			// Start sync worker
+			// Not block the regular process.
+			if err := syncWorker.Start(); err != nil {
+				logger.Error(err)
+			}
+		}
 	} else {
 		return errors.Errorf("worker backend '%s' is not supported", cfg.PoolConfig.Backend)
 	}
@@ -245,6 +266,17 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		select {
 		case <-sig:
 			terminated = true
+			// Hand over the running jobs before shutting down, the API server keeps serving
+			// the draining status meanwhile
+			if er := backendWorker.Drain(config.DrainTimeout()); er != nil {
+				logger.Warningf("Drain worker pool error: %s", er)
+			}
+			<-rootContext.DrainState.Done()
+			return
+		case <-rootContext.DrainState.Done():
+			// Drained via the API
+			terminated = true
+			logger.Info("Worker pool is drained, shutting down")
 			return
 		case err = <-errChan:
 			logger.Errorf("Received error from error chan: %s", err)
@@ -272,6 +304,40 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	return
 }
 
+// newHookCallback returns the callback to send the job status change events via the hook agent
+func newHookCallback(hookAgent hook.Agent) job.HookCallback {
+	return func(URL string, change *job.StatusChange) error {
+		msg := fmt.Sprintf(
+			"status change: job=%s, status=%s, revision=%d",
+			change.JobID,
+			change.Status,
+			change.Metadata.Revision,
+		)
+		if !utils.IsEmptyStr(change.CheckIn) {
+			// Ignore the real check in message to avoid too big message stream
+			cData := change.CheckIn
+			if len(cData) > 256 {
+				cData = fmt.Sprintf("<DATA BLOCK: %d bytes>", len(cData))
+			}
+			msg = fmt.Sprintf("%s, check_in=%s", msg, cData)
+		}
+
+		evt := &hook.Event{
+			URL:       URL,
+			Timestamp: change.Metadata.UpdateTime, // use update timestamp to avoid duplicated resending.
+			Data:      change,
+			Message:   msg,
+		}
+
+		// Hook event sending should not influence the main job flow (because job may call checkin() in the job run).
+		if err := hookAgent.Trigger(evt); err != nil {
+			logger.Error(err)
+		}
+
+		return nil
+	}
+}
+
 func (bs *Bootstrap) createMetricServer(cfg *config.Configuration) {
 	if cfg.Metric != nil && cfg.Metric.Enabled {
 		metric.RegisterJobServiceCollectors()
@@ -311,31 +377,7 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	workerPoolID = redisWorker.GetPoolID()
 
 	// Register jobs here
-	if err := redisWorker.RegisterJobs(
-		map[string]any{
-			// Only for debugging and testing purpose
-			job.SampleJob: (*sample.Job)(nil),
-			// Functional jobs
-			job.ImageScanJobVendorType:      (*scan.Job)(nil),
-			job.PurgeAuditVendorType:        (*purge.Job)(nil),
-			job.GarbageCollectionVendorType: (*gc.GarbageCollector)(nil),
-			job.ReplicationVendorType:       (*replication.Replication)(nil),
-			job.RetentionVendorType:         (*retention.Job)(nil),
-			scheduler.JobNameScheduler:      (*scheduler.PeriodicJob)(nil),
-			job.WebhookJobVendorType:        (*notification.WebhookJob)(nil),
-			job.SlackJobVendorType:          (*notification.SlackJob)(nil),
-			job.P2PPreheatVendorType:        (*preheat.Job)(nil),
-			job.ScanDataExportVendorType:    (*scandataexport.ScanDataExport)(nil),
-			// In v2.2 we migrate the scheduled replication, garbage collection and scan all to
-			// the scheduler mechanism, the following three jobs are kept for the legacy jobs
-			// and they can be removed after several releases
-			"IMAGE_REPLICATE":                    (*legacy.ReplicationScheduler)(nil),
-			"IMAGE_GC":                           (*legacy.GarbageCollectionScheduler)(nil),
-			"IMAGE_SCAN_ALL":                     (*legacy.ScanAllScheduler)(nil),
-			job.SystemArtifactCleanupVendorType:  (*systemartifact.Cleanup)(nil),
-			job.ExecSweepVendorType:              (*task.SweepJob)(nil),
-			job.AuditLogsGDPRCompliantVendorType: (*gdpr.AuditLogsDataMasking)(nil),
-		}); err != nil {
+	if err := redisWorker.RegisterJobs(knownJobs()); err != nil {
 		// exit
 		return nil, err
 	}
@@ -345,6 +387,49 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	return redisWorker, nil
 }
 
+// Load and run the postgresql based worker
+func (bs *Bootstrap) loadAndRunPgWorkerPool(ctx *env.Context, workers uint, lcmCtl lcm.Controller) (worker.Interface, error) {
+	pgWorker := pgworker.NewWorker(ctx, workers, lcmCtl)
+	workerPoolID = pgWorker.GetPoolID()
+
+	if err := pgWorker.RegisterJobs(knownJobs()); err != nil {
+		return nil, err
+	}
+	if err := pgWorker.Start(); err != nil {
+		return nil, err
+	}
+	return pgWorker, nil
+}
+
+// knownJobs returns the jobs to register, key is the job name and value is the job handler
+func knownJobs() map[string]any {
+	return map[string]any{
+		// Only for debugging and testing purpose
+		job.SampleJob: (*sample.Job)(nil),
+		// Functional jobs
+		job.ImageScanJobVendorType:      (*scan.Job)(nil),
+		job.PurgeAuditVendorType:        (*purge.Job)(nil),
+		job.GarbageCollectionVendorType: (*gc.GarbageCollector)(nil),
+		job.ReplicationVendorType:       (*replication.Replication)(nil),
+		job.RetentionVendorType:         (*retention.Job)(nil),
+		scheduler.JobNameScheduler:      (*scheduler.PeriodicJob)(nil),
+		job.WebhookJobVendorType:        (*notification.WebhookJob)(nil),
+		job.SlackJobVendorType:          (*notification.SlackJob)(nil),
+		job.P2PPreheatVendorType:        (*preheat.Job)(nil),
+		job.ScanDataExportVendorType:    (*scandataexport.ScanDataExport)(nil),
+		// In v2.2 we migrate the scheduled replication, garbage collection and scan all to
+		// the scheduler mechanism, the following three jobs are kept for the legacy jobs
+		// and they can be removed after several releases
+		"IMAGE_REPLICATE":                    (*legacy.ReplicationScheduler)(nil),
+		"IMAGE_GC":                           (*legacy.GarbageCollectionScheduler)(nil),
+		"IMAGE_SCAN_ALL":                     (*legacy.ScanAllScheduler)(nil),
+		job.SystemArtifactCleanupVendorType:  (*systemartifact.Cleanup)(nil),
+		job.ExecSweepVendorType:              (*task.SweepJob)(nil),
+		job.AuditLogsGDPRCompliantVendorType: (*gdpr.AuditLogsDataMasking)(nil),
+		job.SecurityHubSnapshotVendorType:    (*securityhub.Snapshot)(nil),
+	}
+}
+
 // Get a redis connection pool
 func (bs *Bootstrap) getRedisPool(redisPoolConfig *config.RedisPoolConfig) *redis.Pool {
 	pool, err := redislib.GetRedisPool("JobService", redisPoolConfig.RedisURL, &redislib.PoolParam{
@@ -360,3 +445,32 @@ func (bs *Bootstrap) getRedisPool(redisPoolConfig *config.RedisPoolConfig) *redi
 	// else
 	return pool
 }
+
+// loadPaused loads the job types paused manually or by the open maintenance windows and the paused running executions
+func loadPaused(ctx context.Context) ([]string, []int64, error) {
+	ctx = orm.Clone(ctx)
+	statuses, err := queuestatus.Mgr.AllJobTypeStatus(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	jobTypes, err := maintenance.Mgr.ActiveJobTypes(ctx, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	for jobType, paused := range statuses {
+		if paused {
+			jobTypes = append(jobTypes, jobType)
+		}
+	}
+
+	executions, err := task.ExecMgr.List(ctx, q.New(q.KeyWords{"Paused": true, "Status": job.RunningStatus.String()}))
+	if err != nil {
+		return nil, nil, err
+	}
+	executionIDs := make([]int64, 0, len(executions))
+	for _, execution := range executions {
+		executionIDs = append(executionIDs, execution.ID)
+	}
+
+	return jobTypes, executionIDs, nil
+}
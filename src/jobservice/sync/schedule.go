@@ -396,6 +396,11 @@ func (w *Worker) getTask(ctx context.Context, schedule *scheduler.Schedule) (*ta
 }
 
 func (w *Worker) syncQueueStatus(ctx context.Context) {
+	if w.monitorRedisClient == nil {
+		// the queue status is kept in redis, no queue to pause for the other backends
+		log.Debug("no monitor redis client, skip syncing queue status")
+		return
+	}
 	queues, err := w.queueStatusManager.List(ctx)
 	if err != nil {
 		log.Errorf("failed to sync queue status because of %v", err)
@@ -15,14 +15,19 @@
 package cworker
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
 
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/errs"
@@ -38,6 +43,8 @@ import (
 
 var (
 	workerPoolDeadTime = 10 * time.Second
+	// the interval to check the job policies customized at runtime
+	policyReloadInterval = 30 * time.Second
 )
 
 const (
@@ -49,8 +56,11 @@ const (
 
 // basicWorker is the worker implementation based on gocraft/work powered by redis.
 type basicWorker struct {
-	namespace string
-	redisPool *redis.Pool
+	namespace   string
+	redisPool   *redis.Pool
+	workerCount uint
+	// guards the pool and the registered jobs as the pool is replaced when the job priorities are changed
+	poolLock  sync.RWMutex
 	pool      *work.WorkerPool
 	enqueuer  *work.Enqueuer
 	client    *work.Client
@@ -58,10 +68,24 @@ type basicWorker struct {
 	scheduler period.Scheduler
 	ctl       lcm.Controller
 	reaper    *reaper
+	fair      *fairQueue
+	pauser    *pauser
+	// set once the worker pool is stopped, by draining or exiting
+	stopped atomic.Bool
 
 	// key is name of known job
 	// value is the type of known job
 	knownJobs *sync.Map
+	// key is name of known job
+	// value is the handler and the applied policy of the job
+	registered map[string]*registeredJob
+}
+
+// registeredJob keeps what is needed to put the job into a new worker pool
+type registeredJob struct {
+	handler  func(*work.Job) error
+	maxFails uint
+	policy   *job.Policy
 }
 
 // workerContext ...
@@ -86,16 +110,26 @@ func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *
 		wc = workerCount
 	}
 
+	knownJobs := new(sync.Map)
 	return &basicWorker{
-		namespace: namespace,
-		redisPool: redisPool,
-		pool:      work.NewWorkerPool(workerContext{}, wc, namespace, redisPool),
-		enqueuer:  work.NewEnqueuer(namespace, redisPool),
-		client:    work.NewClient(namespace, redisPool),
-		scheduler: period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
-		ctl:       ctl,
-		context:   ctx,
-		knownJobs: new(sync.Map),
+		namespace:   namespace,
+		redisPool:   redisPool,
+		workerCount: wc,
+		pool:        work.NewWorkerPool(workerContext{}, wc, namespace, redisPool),
+		enqueuer:    work.NewEnqueuer(namespace, redisPool),
+		client:      work.NewClient(namespace, redisPool),
+		scheduler:   period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
+		ctl:         ctl,
+		context:     ctx,
+		knownJobs:   knownJobs,
+		registered:  make(map[string]*registeredJob),
+		fair:        newFairQueue(namespace, redisPool, wc),
+		pauser: &pauser{
+			namespace: namespace,
+			redisPool: redisPool,
+			state:     ctx.PauseState,
+			knownJobs: knownJobs,
+		},
 		reaper: &reaper{
 			context:   ctx.SystemContext,
 			namespace: namespace,
@@ -136,11 +170,18 @@ func (w *basicWorker) Start() error {
 
 	// Start the backend worker pool
 	// Add middleware
-	w.pool.Middleware((*workerContext).logJob)
+	w.useMiddleware(w.pool)
+	if w.fair != nil {
+		w.fair.start(w.context.SystemContext)
+	}
+	w.pauser.start(w.context.SystemContext)
 	// Non blocking call
 	w.pool.Start()
 	logger.Infof("Basic worker is started")
 
+	// Apply the job policies customized at runtime
+	w.watchPolicies(w.context.SystemContext)
+
 	// Listen to the system signal
 	// IMPORTANT: This goroutine must be started AFTER w.pool.Start() returns
 	// to avoid a race condition between Start() and Stop() in the gocraft/work library.
@@ -152,7 +193,7 @@ func (w *basicWorker) Start() error {
 		}()
 
 		<-w.context.SystemContext.Done()
-		w.pool.Stop()
+		w.stop()
 	}()
 
 	// Start the reaper
@@ -166,8 +207,55 @@ func (w *basicWorker) Start() error {
 	return nil
 }
 
+// Drain the worker pool for handing over the jobs to the other pools
+func (w *basicWorker) Drain(timeout time.Duration) error {
+	poolID := w.GetPoolID()
+	if !w.context.DrainState.Start(timeout) {
+		return errs.ConflictError(fmt.Sprintf("drain of worker pool %s", poolID))
+	}
+
+	logger.Infof("Start to drain worker pool %s, timeout: %v", poolID, timeout)
+	// Stop fetching new jobs, it returns after the running jobs exit
+	go w.stop()
+
+	go func() {
+		var handedOver, running []string
+		if unfinished := w.context.DrainState.Wait(); len(unfinished) > 0 {
+			// Abort the unfinished jobs, they're handed over to the other pools via the checkpoint once they exit.
+			// The ones still running are left to the reaper of the other pools after the process exits,
+			// re-enqueuing them here makes them run twice.
+			handedOver, running = w.context.DrainState.Abort(job.AbortTimeout)
+		}
+		w.context.DrainState.Complete(handedOver)
+		logger.Infof("Worker pool %s is drained, %d unfinished jobs are handed over, %d jobs are still running", poolID, len(handedOver), len(running))
+	}()
+
+	return nil
+}
+
+// DrainStatus returns the draining status of the worker pool
+func (w *basicWorker) DrainStatus() *job.DrainStatus {
+	return w.context.DrainState.Status()
+}
+
+// stop the worker pool only once as the draining and exiting may both stop it,
+// the later call returns immediately without waiting for the running jobs
+func (w *basicWorker) stop() {
+	w.poolLock.Lock()
+	pool := w.pool
+	stopping := w.stopped.CompareAndSwap(false, true)
+	w.poolLock.Unlock()
+
+	if stopping {
+		pool.Stop()
+	}
+}
+
 // GetPoolID returns the worker pool id
 func (w *basicWorker) GetPoolID() string {
+	w.poolLock.RLock()
+	defer w.poolLock.RUnlock()
+
 	v := reflect.ValueOf(*w.pool)
 	return v.FieldByName("workerPoolID").String()
 }
@@ -203,6 +291,12 @@ func (w *basicWorker) Enqueue(jobName string, params job.Parameters, isUnique bo
 		if j, err = w.enqueuer.EnqueueUnique(jobName, params); err != nil {
 			return nil, err
 		}
+	} else if w.fair.accept(jobName) {
+		// Hold the job to be dispatched fairly among the projects,
+		// the unique jobs are not covered as the uniqueness is checked by the worker pool when enqueuing
+		if j, err = w.fair.enqueue(jobName, params); err != nil {
+			return nil, err
+		}
 	} else {
 		// Enqueue job
 		if j, err = w.enqueuer.Enqueue(jobName, params); err != nil {
@@ -427,26 +521,190 @@ func (w *basicWorker) registerJob(name string, j any) (err error) {
 	redisJob := runner.NewRedisJob(j, w.context, w.ctl)
 	// Get more info from j
 	theJ := runner.Wrap(j)
+	rj := &registeredJob{
+		// Use generic handler to handle as we do not accept context with this way.
+		handler: func(wj *work.Job) error {
+			err := redisJob.Run(wj)
+			if _, ok := job.CheckpointOf(err); ok {
+				// The checkpointed job is re-enqueued without consuming the retries
+				wj.Fails--
+			}
+
+			return err
+		},
+		maxFails: theJ.MaxFails(),
+		policy:   w.policyOf(name, theJ),
+	}
+
+	w.poolLock.Lock()
+	w.registered[name] = rj
 	// Put into the pool
-	w.pool.JobWithOptions(
+	addJob(w.pool, name, rj)
+	w.poolLock.Unlock()
+	// Keep the name of registered jobs as known jobs for future validation
+	w.knownJobs.Store(name, j)
+
+	logger.Infof("Register job %s with name %s, priority: %d, max concurrency: %d", reflect.TypeOf(j).String(), name, rj.policy.Priority, rj.policy.MaxConcurrency)
+
+	return nil
+}
+
+// addJob puts the registered job into the worker pool with its policy
+func addJob(pool *work.WorkerPool, name string, rj *registeredJob) {
+	pool.JobWithOptions(
 		name,
 		work.JobOptions{
-			MaxFails:       theJ.MaxFails(),
-			MaxConcurrency: theJ.MaxCurrency(),
-			Priority:       job.Priority().For(name),
+			MaxFails:       rj.maxFails,
+			MaxConcurrency: rj.policy.MaxConcurrency,
+			Priority:       rj.policy.Priority,
 			SkipDead:       true,
+			Backoff:        backoff,
 		},
-		// Use generic handler to handle as we do not accept context with this way.
-		func(job *work.Job) error {
-			return redisJob.Run(job)
-		},
+		rj.handler,
 	)
-	// Keep the name of registered jobs as known jobs for future validation
-	w.knownJobs.Store(name, j)
+}
 
-	logger.Infof("Register job %s with name %s", reflect.TypeOf(j).String(), name)
+// useMiddleware adds the middleware of the worker to the worker pool
+func (w *basicWorker) useMiddleware(pool *work.WorkerPool) {
+	pool.Middleware((*workerContext).logJob)
+	if w.fair != nil {
+		pool.Middleware(w.fair.release)
+	}
+	pool.Middleware(w.pauser.hold)
+}
 
-	return nil
+// watchPolicies reloads the job policies customized at runtime periodically until the context is done
+func (w *basicWorker) watchPolicies(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(policyReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.reloadPolicies()
+			case <-ctx.Done():
+				logger.Info("Job policy watcher is stopped")
+				return
+			}
+		}
+	}()
+}
+
+// reloadPolicies applies the job policies customized at runtime.
+// The max concurrency is read from redis by the worker pool when fetching the jobs, while the priority is fixed
+// once the job is put into the worker pool and the running pool can not be updated safely. So the pool is replaced
+// by a new one with the changed priorities, the old one stops fetching jobs and exits after its running jobs complete.
+func (w *basicWorker) reloadPolicies() {
+	policies, err := w.customizedPolicies()
+	if err != nil {
+		logger.Errorf("Failed to get the customized job policies: %v", err)
+		return
+	}
+
+	w.poolLock.Lock()
+	defer w.poolLock.Unlock()
+
+	if w.stopped.Load() {
+		return
+	}
+
+	changed := false
+	for name, rj := range w.registered {
+		p, ok := policies[name]
+		if !ok || *p == *rj.policy {
+			continue
+		}
+		if p.Priority != rj.policy.Priority {
+			changed = true
+		}
+		// Keep the max concurrency too as it's written to redis when the new pool starts
+		rj.policy = p
+		logger.Infof("Job policy of %s is changed, priority: %d, max concurrency: %d", name, p.Priority, p.MaxConcurrency)
+	}
+	if !changed {
+		return
+	}
+
+	pool := work.NewWorkerPool(workerContext{}, w.workerCount, w.namespace, w.redisPool)
+	w.useMiddleware(pool)
+	for name, rj := range w.registered {
+		addJob(pool, name, rj)
+	}
+	pool.Start()
+
+	old := w.pool
+	w.pool = pool
+	w.context.WG.Add(1)
+	go func() {
+		defer w.context.WG.Done()
+		old.Stop()
+	}()
+
+	logger.Infof("Worker pool is restarted to apply the changed job priorities")
+}
+
+// customizedPolicies returns the job policies customized at runtime, key is the job name
+func (w *basicWorker) customizedPolicies() (map[string]*job.Policy, error) {
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	values, err := redis.StringMap(conn.Do("HGETALL", rds.KeyJobPolicies(w.namespace)))
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]*job.Policy, len(values))
+	for name, value := range values {
+		p := &job.Policy{}
+		if err := json.Unmarshal([]byte(value), p); err != nil {
+			logger.Warningf("Failed to parse the customized policy of job %s: %v", name, err)
+			continue
+		}
+		if p.Customized && p.Priority > 0 {
+			policies[name] = p
+		}
+	}
+
+	return policies, nil
+}
+
+// policyOf returns the scheduling policy of the job type.
+// The policy customized at runtime via the job service monitor takes precedence over the configured one,
+// the effective policy is saved back to redis to be exposed by the monitor.
+func (w *basicWorker) policyOf(name string, j job.Interface) *job.Policy {
+	policy := job.PolicyOf(name, j.MaxCurrency())
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyJobPolicies(w.namespace)
+	raw, err := redis.Bytes(conn.Do("HGET", key, name))
+	if err != nil && err != redis.ErrNil {
+		logger.Warningf("Failed to get the customized policy of job %s: %v", name, err)
+	}
+	if len(raw) > 0 {
+		customized := &job.Policy{}
+		if err := json.Unmarshal(raw, customized); err != nil {
+			logger.Warningf("Failed to parse the customized policy of job %s: %v", name, err)
+		} else if customized.Customized && customized.Priority > 0 {
+			policy = customized
+		}
+	}
+
+	data, err := json.Marshal(policy)
+	if err == nil {
+		_, err = conn.Do("HSET", key, name, data)
+	}
+	if err != nil {
+		logger.Warningf("Failed to save the policy of job %s: %v", name, err)
+	}
+
+	return policy
 }
 
 // Ping the redis server
@@ -491,3 +749,14 @@ func generateResult(
 		},
 	}
 }
+
+// backoff re-enqueues the checkpointed job immediately,
+// the failed ones are retried with the default back-off of the worker pool
+func backoff(j *work.Job) int64 {
+	if job.IsCheckpointed(j.LastErr) {
+		return 0
+	}
+
+	fails := j.Fails
+	return (fails * fails * fails * fails) + 15 + (rand.Int63n(30) * (fails + 1))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -116,6 +117,50 @@ func (suite *CWorkerTestSuite) TestRegisterJobs() {
 	assert.NoError(suite.T(), err, "validate parameters: nil error expected but got %s", err)
 }
 
+// TestPolicyOf tests the effective job policy
+func (suite *CWorkerTestSuite) TestPolicyOf() {
+	w := suite.cWorker.(*basicWorker)
+	policy := w.policyOf("fake_policy_job", &fakeJob{})
+	assert.Equal(suite.T(), job.Priority().For("fake_policy_job"), policy.Priority)
+	assert.Equal(suite.T(), uint(0), policy.MaxConcurrency)
+	assert.False(suite.T(), policy.Customized)
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, err := conn.Do("HSET", rds.KeyJobPolicies(suite.namespace), "fake_policy_job", `{"priority":5,"max_concurrency":2,"customized":true}`)
+	require.NoError(suite.T(), err)
+
+	policy = w.policyOf("fake_policy_job", &fakeJob{})
+	assert.Equal(suite.T(), uint(5), policy.Priority)
+	assert.Equal(suite.T(), uint(2), policy.MaxConcurrency)
+	assert.True(suite.T(), policy.Customized)
+}
+
+// TestReloadPolicies tests applying the job policies customized at runtime
+func (suite *CWorkerTestSuite) TestReloadPolicies() {
+	w := suite.cWorker.(*basicWorker)
+	poolID := w.GetPoolID()
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, err := conn.Do("HSET", rds.KeyJobPolicies(suite.namespace), "fake_long_run_job", `{"priority":3,"max_concurrency":1,"customized":true}`)
+	require.NoError(suite.T(), err)
+
+	w.reloadPolicies()
+	assert.NotEqual(suite.T(), poolID, w.GetPoolID(), "expect the worker pool replaced to apply the changed priority")
+	assert.Equal(suite.T(), uint(3), w.registered["fake_long_run_job"].policy.Priority)
+	assert.Equal(suite.T(), uint(1), w.registered["fake_long_run_job"].policy.MaxConcurrency)
+
+	// Nothing changed
+	poolID = w.GetPoolID()
+	w.reloadPolicies()
+	assert.Equal(suite.T(), poolID, w.GetPoolID(), "expect the worker pool kept as the priorities are not changed")
+}
+
 // TestEnqueueJob tests enqueue job
 func (suite *CWorkerTestSuite) TestEnqueueJob() {
 	params := make(job.Parameters)
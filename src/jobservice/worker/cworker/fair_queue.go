@@ -0,0 +1,265 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+const (
+	fairDispatchInterval = 2 * time.Second
+	// the project of the jobs without project ID
+	fairSharedProject = "0"
+)
+
+// Push the job into the queue of the project and append the project to the ring if the queue was empty.
+//
+// KEYS[1] = the queue of the project
+// KEYS[2] = the ring of the projects
+// ARGV[1] = the serialized job
+// ARGV[2] = the project
+var fairEnqueueScript = redis.NewScript(2, `
+if redis.call('lpush', KEYS[1], ARGV[1]) == 1 then
+  redis.call('lpush', KEYS[2], ARGV[2])
+end
+return 1
+`)
+
+// Move the jobs from the queues of the projects to the queue of the worker pool round-robin until the queue of
+// the worker pool is full or no more job can be dispatched. The project whose queue is drained is removed from the
+// ring to keep the ring only containing the projects having waiting jobs, the project reaching the in-flight limit
+// is skipped in this turn.
+//
+// KEYS[1] = the queue of the worker pool
+// KEYS[2] = the ring of the projects
+// ARGV[1] = the key prefix of the queues of the projects
+// ARGV[2] = the key prefix of the in-flight jobs of the projects
+// ARGV[3] = the max number of the jobs in the queue of the worker pool
+// ARGV[4] = the max number of the in-flight jobs of a project, 0 means no limit
+// ARGV[5] = the current timestamp
+// ARGV[6] = the timestamp before which the in-flight jobs are considered as lost
+var fairDispatchScript = redis.NewScript(2, `
+local queued = redis.call('llen', KEYS[1])
+local projects = redis.call('llen', KEYS[2])
+local depth = tonumber(ARGV[3])
+local maxInFlight = tonumber(ARGV[4])
+local moved = 0
+local skipped = 0
+while queued < depth and projects > 0 and skipped < projects do
+  local project = redis.call('rpoplpush', KEYS[2], KEYS[2])
+  local inFlight = ARGV[2] .. project
+  redis.call('zremrangebyscore', inFlight, '-inf', ARGV[6])
+  if maxInFlight > 0 and redis.call('zcard', inFlight) >= maxInFlight then
+    skipped = skipped + 1
+  else
+    local queue = ARGV[1] .. project
+    local j = redis.call('rpop', queue)
+    if j then
+      redis.call('lpush', KEYS[1], j)
+      redis.call('zadd', inFlight, ARGV[5], cjson.decode(j).id)
+      queued = queued + 1
+      moved = moved + 1
+      skipped = 0
+    end
+    if not j or redis.call('llen', queue) == 0 then
+      redis.call('lrem', KEYS[2], 1, project)
+      projects = projects - 1
+    end
+  end
+end
+return moved
+`)
+
+// fairQueue schedules the jobs of the configured types fairly among the projects.
+// The jobs are held in the queues of their projects first, and dispatched to the queue of the worker pool
+// round-robin across the projects. As at most `depth` jobs are kept in the queue of the worker pool, a project
+// flooding the job type can only delay the other projects by one turn.
+type fairQueue struct {
+	namespace   string
+	redisPool   *redis.Pool
+	jobTypes    map[string]struct{}
+	depth       uint
+	maxInFlight uint
+}
+
+// newFairQueue creates the fair queue with the configuration, nil is returned if the fair scheduling is not enabled
+func newFairQueue(namespace string, redisPool *redis.Pool, workerCount uint) *fairQueue {
+	if config.DefaultConfig == nil || config.DefaultConfig.PoolConfig == nil || config.DefaultConfig.PoolConfig.FairScheduling == nil {
+		return nil
+	}
+
+	cfg := config.DefaultConfig.PoolConfig.FairScheduling
+	fq := &fairQueue{
+		namespace:   namespace,
+		redisPool:   redisPool,
+		jobTypes:    make(map[string]struct{}, len(cfg.JobTypes)),
+		depth:       workerCount,
+		maxInFlight: cfg.MaxInFlightPerProject,
+	}
+	if cfg.QueueDepth > 0 {
+		fq.depth = cfg.QueueDepth
+	}
+	for _, t := range cfg.JobTypes {
+		fq.jobTypes[t] = struct{}{}
+	}
+
+	return fq
+}
+
+// accept returns whether the job is scheduled by the fair queue
+func (fq *fairQueue) accept(jobName string) bool {
+	if fq == nil {
+		return false
+	}
+	_, ok := fq.jobTypes[jobName]
+
+	return ok
+}
+
+// enqueue holds the job in the queue of its project and triggers the dispatching
+func (fq *fairQueue) enqueue(jobName string, params job.Parameters) (*work.Job, error) {
+	j := &work.Job{
+		Name:       jobName,
+		ID:         utils.MakeIdentifier(),
+		EnqueuedAt: time.Now().Unix(),
+		Args:       params,
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+
+	project := projectOf(params)
+	conn := fq.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err := fairEnqueueScript.Do(conn, rds.KeyFairQueue(fq.namespace, jobName, project), rds.KeyFairProjects(fq.namespace, jobName), data, project); err != nil {
+		return nil, err
+	}
+
+	if _, err := fq.dispatch(conn, jobName); err != nil {
+		// the job will be dispatched by the next turn of the loop
+		logger.Warningf("Failed to dispatch the jobs of %s: %v", jobName, err)
+	}
+
+	return j, nil
+}
+
+// dispatch moves the jobs of the type to the queue of the worker pool, the number of the moved jobs is returned
+func (fq *fairQueue) dispatch(conn redis.Conn, jobName string) (int, error) {
+	now := time.Now()
+	return redis.Int(fairDispatchScript.Do(conn,
+		rds.KeyJobs(fq.namespace, jobName),
+		rds.KeyFairProjects(fq.namespace, jobName),
+		rds.KeyFairQueue(fq.namespace, jobName, ""),
+		rds.KeyFairInFlight(fq.namespace, jobName, ""),
+		fq.depth,
+		fq.maxInFlight,
+		now.Unix(),
+		now.Add(-config.MaxUpdateDuration()).Unix(),
+	))
+}
+
+// start the loop to dispatch the jobs, the jobs are also dispatched when enqueuing and finishing the jobs,
+// the loop covers the cases that the worker pool is busy or the in-flight limit is reached at that time
+func (fq *fairQueue) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(fairDispatchInterval)
+		defer ticker.Stop()
+
+		logger.Info("Fair job dispatcher is started")
+		for {
+			select {
+			case <-ticker.C:
+				fq.dispatchAll()
+			case <-ctx.Done():
+				logger.Info("Fair job dispatcher is stopped")
+				return
+			}
+		}
+	}()
+}
+
+func (fq *fairQueue) dispatchAll() {
+	conn := fq.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	for jobName := range fq.jobTypes {
+		if _, err := fq.dispatch(conn, jobName); err != nil {
+			logger.Errorf("Failed to dispatch the jobs of %s: %v", jobName, err)
+		}
+	}
+}
+
+// release is the middleware of the worker pool which removes the job from the in-flight jobs of its project
+// once the job is processed. The retries of the failed job are not counted as in-flight.
+func (fq *fairQueue) release(j *work.Job, next work.NextMiddlewareFunc) error {
+	err := next()
+
+	if _, ok := fq.jobTypes[j.Name]; ok {
+		conn := fq.redisPool.Get()
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		if _, er := conn.Do("ZREM", rds.KeyFairInFlight(fq.namespace, j.Name, projectOf(j.Args)), j.ID); er != nil {
+			logger.Errorf("Failed to release the in-flight job %s:%s: %v", j.Name, j.ID, er)
+		}
+		if _, er := fq.dispatch(conn, j.Name); er != nil {
+			logger.Warningf("Failed to dispatch the jobs of %s: %v", j.Name, er)
+		}
+	}
+
+	return err
+}
+
+// projectOf returns the project of the job from its parameters
+func projectOf(params map[string]any) string {
+	switch v := params[job.ProjectIDParamKey].(type) {
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if len(v) > 0 {
+			return v
+		}
+	case nil:
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+
+	return fairSharedProject
+}
@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gocraft/work"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/tests"
+)
+
+// FairQueueTestSuite tests the fair dispatching of the jobs
+type FairQueueTestSuite struct {
+	suite.Suite
+
+	namespace string
+	pool      *redis.Pool
+}
+
+// TestFairQueueTestSuite is entry of go test
+func TestFairQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(FairQueueTestSuite))
+}
+
+// SetupSuite prepares the test suite
+func (suite *FairQueueTestSuite) SetupSuite() {
+	suite.namespace = tests.GiveMeTestNamespace()
+	suite.pool = tests.GiveMeRedisPool()
+}
+
+// TearDownTest clears the test data
+func (suite *FairQueueTestSuite) TearDownTest() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = tests.ClearAll(suite.namespace, conn)
+}
+
+// TestRoundRobin tests the jobs are dispatched round-robin across the projects
+func (suite *FairQueueTestSuite) TestRoundRobin() {
+	fq := suite.fairQueue(1, 0)
+	for _, p := range []int64{1, 1, 1, 2} {
+		_, err := fq.enqueue(job.RetentionVendorType, job.Parameters{job.ProjectIDParamKey: p})
+		suite.Require().NoError(err)
+	}
+
+	var projects []string
+	for range 4 {
+		j := suite.fetch(job.RetentionVendorType)
+		suite.Require().NotNil(j)
+		projects = append(projects, projectOf(j.Args))
+		suite.dispatch(fq, job.RetentionVendorType)
+	}
+	suite.Equal([]string{"1", "1", "2", "1"}, projects)
+	suite.Nil(suite.fetch(job.RetentionVendorType))
+}
+
+// TestMaxInFlight tests the jobs are held when the in-flight limit of the project is reached
+func (suite *FairQueueTestSuite) TestMaxInFlight() {
+	fq := suite.fairQueue(10, 1)
+	for range 2 {
+		_, err := fq.enqueue(job.ImageScanJobVendorType, job.Parameters{job.ProjectIDParamKey: "1"})
+		suite.Require().NoError(err)
+	}
+	_, err := fq.enqueue(job.ImageScanJobVendorType, job.Parameters{})
+	suite.Require().NoError(err)
+
+	first := suite.fetch(job.ImageScanJobVendorType)
+	suite.Require().NotNil(first)
+	suite.Equal("1", projectOf(first.Args))
+	shared := suite.fetch(job.ImageScanJobVendorType)
+	suite.Require().NotNil(shared)
+	suite.Equal(fairSharedProject, projectOf(shared.Args))
+	suite.Nil(suite.fetch(job.ImageScanJobVendorType))
+
+	// the job is dispatched once the in-flight one is finished
+	suite.Require().NoError(fq.release(first, func() error { return nil }))
+	second := suite.fetch(job.ImageScanJobVendorType)
+	suite.Require().NotNil(second)
+	suite.Equal("1", projectOf(second.Args))
+}
+
+// TestProjectOf tests getting the project from the job parameters
+func (suite *FairQueueTestSuite) TestProjectOf() {
+	suite.Equal("123456789", projectOf(map[string]any{job.ProjectIDParamKey: float64(123456789)}))
+	suite.Equal("2", projectOf(map[string]any{job.ProjectIDParamKey: int64(2)}))
+	suite.Equal("3", projectOf(map[string]any{job.ProjectIDParamKey: "3"}))
+	suite.Equal(fairSharedProject, projectOf(map[string]any{}))
+	suite.Equal(fairSharedProject, projectOf(nil))
+}
+
+func (suite *FairQueueTestSuite) fairQueue(depth, maxInFlight uint) *fairQueue {
+	return &fairQueue{
+		namespace: suite.namespace,
+		redisPool: suite.pool,
+		jobTypes: map[string]struct{}{
+			job.RetentionVendorType:    {},
+			job.ImageScanJobVendorType: {},
+		},
+		depth:       depth,
+		maxInFlight: maxInFlight,
+	}
+}
+
+func (suite *FairQueueTestSuite) dispatch(fq *fairQueue, jobName string) {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err := fq.dispatch(conn, jobName)
+	suite.Require().NoError(err)
+}
+
+// fetch pops the job from the queue of the worker pool as the worker does
+func (suite *FairQueueTestSuite) fetch(jobName string) *work.Job {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	data, err := redis.Bytes(conn.Do("RPOP", rds.KeyJobs(suite.namespace, jobName)))
+	if err == redis.ErrNil {
+		return nil
+	}
+	suite.Require().NoError(err)
+
+	j := &work.Job{}
+	suite.Require().NoError(json.Unmarshal(data, j))
+
+	return j
+}
@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+const (
+	pauseSyncInterval = 10 * time.Second
+	// the delay to check the held job of the paused execution again
+	pausedJobDelay = 15 * time.Second
+)
+
+// pauser enforces the pause state persisted in the database on the worker pool.
+// The paused job types are flagged in redis to stop dequeuing them, so the flags lost on the redis reset are
+// recovered in the next turn. The jobs of the paused executions are held in the scheduled queue and checked again
+// later, so the pending jobs are not run until the executions are resumed while the running ones continue.
+type pauser struct {
+	namespace string
+	redisPool *redis.Pool
+	state     *job.PauseState
+	knownJobs *sync.Map
+}
+
+// start syncing the paused job types to redis periodically until the context is done
+func (p *pauser) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(pauseSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.sync()
+			case <-ctx.Done():
+				logger.Info("Job pauser is stopped")
+				return
+			}
+		}
+	}()
+}
+
+// sync the pause flags of the known job types in redis with the pause state
+func (p *pauser) sync() {
+	if !p.state.Loaded() {
+		// Do not touch the flags before the state is loaded
+		return
+	}
+
+	conn := p.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	p.knownJobs.Range(func(k any, _ any) bool {
+		jobName := k.(string)
+		key := rds.KeyJobPaused(p.namespace, jobName)
+		var err error
+		if p.state.IsJobTypePaused(jobName) {
+			_, err = conn.Do("SET", key, "1")
+		} else {
+			_, err = conn.Do("DEL", key)
+		}
+		if err != nil {
+			logger.Errorf("Failed to sync the pause flag of %s: %v", jobName, err)
+		}
+
+		return true
+	})
+}
+
+// hold is the middleware of the worker pool which puts the job of the paused execution back to the scheduled
+// queue instead of running it, the job is kept pending as it is not handed over to the runner.
+func (p *pauser) hold(j *work.Job, next work.NextMiddlewareFunc) error {
+	if !p.state.IsExecutionPaused(j.Args) {
+		return next()
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	conn := p.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.Do("ZADD", rds.KeyScheduled(p.namespace), time.Now().Add(pausedJobDelay).Unix(), data); err != nil {
+		return err
+	}
+	logger.Debugf("Job %s:%s of the paused execution is held", j.Name, j.ID)
+
+	return nil
+}
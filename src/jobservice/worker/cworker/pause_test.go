@@ -0,0 +1,127 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cworker
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/gocraft/work"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/tests"
+)
+
+// PauserTestSuite tests the enforcement of the pause state
+type PauserTestSuite struct {
+	suite.Suite
+
+	namespace string
+	pool      *redis.Pool
+}
+
+// TestPauserTestSuite is entry of go test
+func TestPauserTestSuite(t *testing.T) {
+	suite.Run(t, new(PauserTestSuite))
+}
+
+// SetupSuite prepares the test suite
+func (suite *PauserTestSuite) SetupSuite() {
+	suite.namespace = tests.GiveMeTestNamespace()
+	suite.pool = tests.GiveMeRedisPool()
+}
+
+// TearDownTest clears the test data
+func (suite *PauserTestSuite) TearDownTest() {
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_ = tests.ClearAll(suite.namespace, conn)
+}
+
+// TestSync tests the pause flags are synced with the pause state
+func (suite *PauserTestSuite) TestSync() {
+	p := suite.pauser([]string{job.GarbageCollectionVendorType}, nil)
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+	// the flag lost is recovered and the stale flag is removed
+	_, err := conn.Do("SET", rds.KeyJobPaused(suite.namespace, job.ReplicationVendorType), "1")
+	suite.Require().NoError(err)
+
+	p.sync()
+
+	paused, err := redis.Bool(conn.Do("EXISTS", rds.KeyJobPaused(suite.namespace, job.GarbageCollectionVendorType)))
+	suite.Require().NoError(err)
+	suite.True(paused)
+	paused, err = redis.Bool(conn.Do("EXISTS", rds.KeyJobPaused(suite.namespace, job.ReplicationVendorType)))
+	suite.Require().NoError(err)
+	suite.False(paused)
+}
+
+// TestHold tests the jobs of the paused executions are held
+func (suite *PauserTestSuite) TestHold() {
+	p := suite.pauser(nil, []int64{1})
+
+	called := false
+	next := func() error {
+		called = true
+		return nil
+	}
+
+	// the job of the running execution
+	err := p.hold(&work.Job{Name: job.ReplicationVendorType, ID: "2", Args: map[string]any{job.ExecutionIDParamKey: float64(2)}}, next)
+	suite.Require().NoError(err)
+	suite.True(called)
+
+	// the job of the paused execution
+	called = false
+	err = p.hold(&work.Job{Name: job.ReplicationVendorType, ID: "1", Args: map[string]any{job.ExecutionIDParamKey: float64(1)}}, next)
+	suite.Require().NoError(err)
+	suite.False(called)
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+	count, err := redis.Int(conn.Do("ZCARD", rds.KeyScheduled(suite.namespace)))
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *PauserTestSuite) pauser(jobTypes []string, executionIDs []int64) *pauser {
+	state := job.NewPauseState(func(_ context.Context) ([]string, []int64, error) {
+		return jobTypes, executionIDs, nil
+	})
+	suite.Require().NoError(state.Refresh(context.TODO()))
+
+	knownJobs := new(sync.Map)
+	knownJobs.Store(job.GarbageCollectionVendorType, nil)
+	knownJobs.Store(job.ReplicationVendorType, nil)
+
+	return &pauser{
+		namespace: suite.namespace,
+		redisPool: suite.pool,
+		state:     state,
+		knownJobs: knownJobs,
+	}
+}
@@ -15,6 +15,8 @@
 package worker
 
 import (
+	"time"
+
 	"github.com/goharbor/harbor/src/jobservice/job"
 )
 
@@ -117,4 +119,20 @@ type Interface interface {
 	// Return:
 	//  error           : error returned if meet any problems
 	RetryJob(jobID string) error
+
+	// Drain the worker pool for handing over the jobs to the other pools.
+	// It stops taking new jobs and signals the running jobs to checkpoint, the jobs still running
+	// at the timeout are re-enqueued immediately. Non blocking call.
+	//
+	// timeout time.Duration : the max duration of waiting for the running jobs
+	//
+	// Return:
+	//  error           : error returned if the worker pool is already draining
+	Drain(timeout time.Duration) error
+
+	// Return the draining status of the worker pool.
+	//
+	// Returns:
+	//  *job.DrainStatus : the draining status
+	DrainStatus() *job.DrainStatus
 }
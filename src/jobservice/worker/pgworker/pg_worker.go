@@ -0,0 +1,672 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gocraft/work"
+
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/runner"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+var (
+	workerPoolDeadTime = 10 * time.Second
+)
+
+const (
+	workerPoolStatusHealthy      = "Healthy"
+	workerPoolStatusDead         = "Dead"
+	defaultWorkerCount      uint = 10
+	// the interval to poll the queue when no runnable jobs
+	pollInterval      = 1 * time.Second
+	heartbeatInterval = 5 * time.Second
+	// the delay to check the held job of the paused execution again
+	pausedJobDelay = 15 * time.Second
+)
+
+// registeredJob keeps the handler and the options of the registered job
+type registeredJob struct {
+	handler  *runner.RedisJob
+	maxFails uint
+	policy   *job.Policy
+}
+
+// pgWorker is the worker implementation based on the postgresql database,
+// the jobs are kept in the queue table and dequeued with "FOR UPDATE SKIP LOCKED" to be shared among the nodes.
+type pgWorker struct {
+	poolID      string
+	workerCount uint
+	startedAt   int64
+	context     *env.Context
+	scheduler   period.Scheduler
+	ctl         lcm.Controller
+	reaper      *reaper
+	// the tokens limit the number of the concurrently running jobs
+	tokens  chan struct{}
+	running sync.WaitGroup
+
+	// key is name of known job
+	// value is the type of known job
+	knownJobs *sync.Map
+	// key is name of known job
+	// value is the *registeredJob
+	registered *sync.Map
+}
+
+// NewWorker is constructor of the postgresql based worker
+func NewWorker(ctx *env.Context, workerCount uint, ctl lcm.Controller) worker.Interface {
+	wc := defaultWorkerCount
+	if workerCount > 0 {
+		wc = workerCount
+	}
+
+	return &pgWorker{
+		poolID:      utils.MakeIdentifier(),
+		workerCount: wc,
+		context:     ctx,
+		scheduler:   NewScheduler(ctx.SystemContext, ctl),
+		ctl:         ctl,
+		reaper: &reaper{
+			context: ctx.SystemContext,
+			lcmCtl:  ctl,
+		},
+		tokens:     make(chan struct{}, wc),
+		knownJobs:  new(sync.Map),
+		registered: new(sync.Map),
+	}
+}
+
+// Start to serve
+// Unblock action
+func (w *pgWorker) Start() error {
+	if w.context == nil || w.context.SystemContext == nil {
+		// report and exit
+		return errors.New("missing context")
+	}
+
+	if w.ctl == nil {
+		return errors.New("missing job life cycle controller")
+	}
+
+	w.startedAt = time.Now().Unix()
+	// Register the worker pool and test the database connection
+	if err := w.heartbeat(); err != nil {
+		return err
+	}
+
+	// Start the periodic scheduler
+	w.scheduler.Start()
+
+	w.context.WG.Add(1)
+	go w.loop()
+	logger.Infof("Postgresql worker is started")
+
+	// Start the reaper
+	w.reaper.start()
+
+	return nil
+}
+
+// Drain the worker pool for handing over the jobs to the other pools
+func (w *pgWorker) Drain(timeout time.Duration) error {
+	if !w.context.DrainState.Start(timeout) {
+		return errs.ConflictError(fmt.Sprintf("drain of worker pool %s", w.poolID))
+	}
+
+	logger.Infof("Start to drain worker pool %s, timeout: %v", w.poolID, timeout)
+	go func() {
+		var handedOver, running []string
+		if unfinished := w.context.DrainState.Wait(); len(unfinished) > 0 {
+			// Abort the unfinished jobs, they're handed over to the other pools via the checkpoint once they exit.
+			// The ones still running are left to the reaper of the other pools after the process exits,
+			// re-enqueuing them here makes them run twice.
+			handedOver, running = w.context.DrainState.Abort(job.AbortTimeout)
+		}
+		w.context.DrainState.Complete(handedOver)
+		logger.Infof("Worker pool %s is drained, %d unfinished jobs are handed over, %d jobs are still running", w.poolID, len(handedOver), len(running))
+	}()
+
+	return nil
+}
+
+// DrainStatus returns the draining status of the worker pool
+func (w *pgWorker) DrainStatus() *job.DrainStatus {
+	return w.context.DrainState.Status()
+}
+
+// GetPoolID returns the worker pool id
+func (w *pgWorker) GetPoolID() string {
+	return w.poolID
+}
+
+// RegisterJobs is used to register multiple jobs to worker.
+func (w *pgWorker) RegisterJobs(jobs map[string]any) error {
+	if len(jobs) == 0 {
+		// Do nothing
+		return nil
+	}
+
+	for name, j := range jobs {
+		if err := w.registerJob(name, j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enqueue job
+func (w *pgWorker) Enqueue(jobName string, params job.Parameters, isUnique bool, webHook string) (*job.Stats, error) {
+	now := time.Now().Unix()
+	res, err := w.enqueue(jobName, params, isUnique, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Info.JobKind = job.KindGeneric
+	res.Info.WebHookURL = webHook
+
+	return res, nil
+}
+
+// Schedule job
+func (w *pgWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
+	now := time.Now().Unix()
+	runAt := now + int64(runAfterSeconds)
+	res, err := w.enqueue(jobName, params, isUnique, now, runAt)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Info.JobKind = job.KindScheduled
+	res.Info.WebHookURL = webHook
+	res.Info.RunAt = runAt
+	res.Info.Status = job.ScheduledStatus.String()
+
+	return res, nil
+}
+
+// PeriodicallyEnqueue job
+func (w *pgWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, _ bool, webHook string) (*job.Stats, error) {
+	p := &period.Policy{
+		ID:            utils.MakeIdentifier(),
+		JobName:       jobName,
+		CronSpec:      cronSetting,
+		JobParameters: params,
+		WebHookURL:    webHook,
+	}
+
+	id, err := w.scheduler.Schedule(p)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       p.ID,
+			JobName:     jobName,
+			Status:      job.ScheduledStatus.String(),
+			JobKind:     job.KindPeriodic,
+			CronSpec:    cronSetting,
+			WebHookURL:  webHook,
+			NumericPID:  id,
+			EnqueueTime: time.Now().Unix(),
+			UpdateTime:  time.Now().Unix(),
+			RefLink:     fmt.Sprintf("/api/v1/jobs/%s", p.ID),
+			Parameters:  params,
+		},
+	}
+
+	return res, nil
+}
+
+// Stats of the worker pools
+func (w *pgWorker) Stats() (*worker.Stats, error) {
+	pools, err := listPools(orm.Copy(w.context.SystemContext))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*worker.StatsData, 0)
+	for _, p := range pools {
+		wPoolStatus := workerPoolStatusHealthy
+		if time.Unix(p.HeartbeatAt, 0).Add(workerPoolDeadTime).Before(time.Now()) {
+			wPoolStatus = workerPoolStatusDead
+		}
+
+		jobNames := make([]string, 0)
+		if len(p.JobNames) > 0 {
+			if err := json.Unmarshal([]byte(p.JobNames), &jobNames); err != nil {
+				logger.Errorf("Malformed job names of worker pool %s: %v", p.PoolID, err)
+			}
+		}
+
+		stats = append(stats, &worker.StatsData{
+			WorkerPoolID: p.PoolID,
+			StartedAt:    p.StartedAt,
+			HeartbeatAt:  p.HeartbeatAt,
+			JobNames:     jobNames,
+			Concurrency:  p.Concurrency,
+			Status:       wPoolStatus,
+		})
+	}
+
+	if len(stats) == 0 {
+		return nil, errors.New("failed to get stats of worker pools")
+	}
+
+	return &worker.Stats{
+		Pools: stats,
+	}, nil
+}
+
+// StopJob will stop the job
+func (w *pgWorker) StopJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to stop")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil && !errs.IsObjectNotFoundError(err) {
+		// For none not found error, directly return
+		return err
+	}
+
+	// For periodical job and stats not found cases
+	if errs.IsObjectNotFoundError(err) || (t != nil && t.Job().Info.JobKind == job.KindPeriodic) {
+		return w.scheduler.UnSchedule(jobID)
+	}
+
+	// General or scheduled job
+	if job.RunningStatus.Before(job.Status(t.Job().Info.Status)) {
+		// Job has been in the final states
+		logger.Warningf("Trying to stop a(n) %s job: ID=%s, Kind=%s", t.Job().Info.Status, jobID, t.Job().Info.JobKind)
+		return nil
+	}
+
+	// Mark status to stopped
+	if err := t.Stop(); err != nil {
+		return err
+	}
+
+	// Remove the job from the queue if it is not running yet
+	if n, err := removePendingJobs(orm.Copy(w.context.SystemContext), jobID); err != nil {
+		logger.Warningf("Failed to remove the stopped job %s from the queue: %v", lib.TrimLineBreaks(jobID), err)
+	} else if n == 0 {
+		logger.Debugf("Stopped job %s is not found in the queue, is it running?", lib.TrimLineBreaks(jobID))
+	}
+
+	return nil
+}
+
+// RetryJob retry the job
+func (w *pgWorker) RetryJob(_ string) error {
+	return errors.New("not implemented")
+}
+
+// IsKnownJob ...
+func (w *pgWorker) IsKnownJob(name string) (any, bool) {
+	return w.knownJobs.Load(name)
+}
+
+// ValidateJobParameters ...
+func (w *pgWorker) ValidateJobParameters(jobType any, params job.Parameters) error {
+	if jobType == nil {
+		return errors.New("nil job type")
+	}
+
+	theJ := runner.Wrap(jobType)
+	return theJ.Validate(params)
+}
+
+// registerJob is used to register the job to the worker.
+// j is the type of job
+func (w *pgWorker) registerJob(name string, j any) (err error) {
+	if utils.IsEmptyStr(name) || j == nil {
+		return errors.New("job can not be registered with empty name or nil interface")
+	}
+
+	// j must be job.Interface
+	if _, ok := j.(job.Interface); !ok {
+		return errors.Errorf("job must implement the job.Interface: %s", reflect.TypeOf(j).String())
+	}
+
+	// 1:1 constraint
+	if jInList, ok := w.knownJobs.Load(name); ok {
+		return fmt.Errorf("job name %s has been already registered with %s", name, reflect.TypeOf(jInList).String())
+	}
+
+	// Same job implementation can be only registered with one name
+	w.knownJobs.Range(func(jName any, jInList any) bool {
+		jobImpl := reflect.TypeOf(j).String()
+		if reflect.TypeOf(jInList).String() == jobImpl {
+			err = errors.Errorf("job %s has been already registered with name %s", jobImpl, jName)
+			return false
+		}
+
+		return true
+	})
+
+	// Something happened in the range
+	if err != nil {
+		return
+	}
+
+	theJ := runner.Wrap(j)
+	policy := job.PolicyOf(name, theJ.MaxCurrency())
+	w.registered.Store(name, &registeredJob{
+		handler:  runner.NewRedisJob(j, w.context, w.ctl),
+		maxFails: theJ.MaxFails(),
+		policy:   policy,
+	})
+	// Keep the name of registered jobs as known jobs for future validation
+	w.knownJobs.Store(name, j)
+
+	logger.Infof("Register job %s with name %s, priority: %d, max concurrency: %d", reflect.TypeOf(j).String(), name, policy.Priority, policy.MaxConcurrency)
+
+	return nil
+}
+
+// enqueue puts the job into the queue and returns the stats of the pending job
+func (w *pgWorker) enqueue(jobName string, params job.Parameters, isUnique bool, enqueuedAt, runAt int64) (*job.Stats, error) {
+	args, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ""
+	if isUnique {
+		if key, err = uniqueKey(jobName, params); err != nil {
+			return nil, err
+		}
+	}
+
+	var priority uint
+	if v, ok := w.registered.Load(jobName); ok {
+		priority = v.(*registeredJob).policy.Priority
+	}
+
+	qj := &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    jobName,
+		Args:       string(args),
+		EnqueuedAt: enqueuedAt,
+		RunAt:      runAt,
+	}
+	// As the job is declared to be unique,
+	// here we only need to make sure only 1 job with the same type and parameters in the queue
+	inserted, err := insertJob(orm.Copy(w.context.SystemContext), qj, key, priority)
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		return nil, fmt.Errorf("job '%s' can not be enqueued, please check the job metatdata", jobName)
+	}
+
+	return &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       qj.JobID,
+			JobName:     jobName,
+			IsUnique:    isUnique,
+			Status:      job.PendingStatus.String(),
+			EnqueueTime: enqueuedAt,
+			UpdateTime:  time.Now().Unix(),
+			RefLink:     fmt.Sprintf("/api/v1/jobs/%s", qj.JobID),
+			Parameters:  params,
+		},
+	}, nil
+}
+
+// loop dequeues the jobs and runs them until the system context is done,
+// then waits for the running jobs to complete
+func (w *pgWorker) loop() {
+	defer func() {
+		w.context.WG.Done()
+		logger.Infof("Postgresql worker is stopped")
+	}()
+
+	hb := time.NewTicker(heartbeatInterval)
+	defer hb.Stop()
+
+	ctx := w.context.SystemContext
+	func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hb.C:
+				if err := w.heartbeat(); err != nil {
+					logger.Error(err)
+				}
+			case w.tokens <- struct{}{}:
+				qj, err := w.fetch()
+				if err != nil {
+					logger.Errorf("Failed to dequeue job: %v", err)
+				}
+				if qj == nil {
+					<-w.tokens
+					// Wait for a while as no runnable jobs or error occurred
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(pollInterval):
+					}
+
+					continue
+				}
+
+				w.running.Add(1)
+				go w.process(qj)
+			}
+		}
+	}()
+
+	// Keep the heartbeats of the running jobs until they're completed,
+	// otherwise they will be taken as orphan ones and requeued by other nodes.
+	done := make(chan struct{})
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		case <-w.context.DrainState.Done():
+			// The unfinished jobs have been handed over to the other worker pools
+			return
+		case <-hb.C:
+			if err := w.heartbeat(); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// fetch the next runnable job, the job types reaching the concurrency limit are excluded
+func (w *pgWorker) fetch() (*queuedJob, error) {
+	// No new jobs are taken when draining
+	if w.context.DrainState.Draining() {
+		return nil, nil
+	}
+
+	ctx := orm.Copy(w.context.SystemContext)
+
+	var counts map[string]uint
+	names := make([]string, 0)
+	var err error
+	w.registered.Range(func(k, v any) bool {
+		name, rj := k.(string), v.(*registeredJob)
+		if w.context.PauseState.IsJobTypePaused(name) {
+			return true
+		}
+		if rj.policy.MaxConcurrency > 0 {
+			if counts == nil {
+				if counts, err = runningCounts(ctx); err != nil {
+					return false
+				}
+			}
+			if counts[name] >= rj.policy.MaxConcurrency {
+				return true
+			}
+		}
+		names = append(names, name)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	qj, err := dequeue(ctx, w.poolID, names, now)
+	if err != nil || qj == nil {
+		return qj, err
+	}
+	if params, er := qj.parameters(); er == nil && w.context.PauseState.IsExecutionPaused(params) {
+		// Put the job of the paused execution back to the queue to check it again later
+		logger.Debugf("Job %s:%s of the paused execution is held", qj.JobName, qj.JobID)
+		return nil, retryLater(ctx, qj.ID, qj.Fails, qj.LastErr, now+int64(pausedJobDelay.Seconds()))
+	}
+
+	return qj, nil
+}
+
+// process runs the job and handles the result:
+// the succeeded and dead jobs are removed from the queue and the failed ones are retried with back-off.
+func (w *pgWorker) process(qj *queuedJob) {
+	defer func() {
+		<-w.tokens
+		w.running.Done()
+	}()
+
+	ctx := orm.Copy(w.context.SystemContext)
+	v, ok := w.registered.Load(qj.JobName)
+	if !ok {
+		// Should not happen as only the registered jobs are dequeued
+		logger.Errorf("Job %s:%s is not registered", qj.JobName, qj.JobID)
+		return
+	}
+	rj := v.(*registeredJob)
+
+	params, err := qj.parameters()
+	if err != nil {
+		logger.Error(err)
+		if err := removeJob(ctx, qj.ID); err != nil {
+			logger.Error(err)
+		}
+
+		return
+	}
+
+	wj := &work.Job{
+		Name:       qj.JobName,
+		ID:         qj.JobID,
+		EnqueuedAt: qj.EnqueuedAt,
+		Args:       params,
+		Fails:      qj.Fails,
+		LastErr:    qj.LastErr,
+	}
+
+	jobCopy := *wj
+	// as the args may contain sensitive information, ignore them when logging the detail
+	jobCopy.Args = nil
+	jobInfo, _ := utils.SerializeJob(&jobCopy)
+	logger.Infof("Job incoming: %s", jobInfo)
+
+	runErr := rj.handler.Run(wj)
+	if _, ok := job.CheckpointOf(runErr); ok {
+		// Hand over the checkpointed job to the other worker pools immediately without consuming the retries
+		args, err := json.Marshal(wj.Args)
+		if err == nil {
+			err = requeueJob(ctx, qj.ID, string(args), time.Now().Unix())
+		}
+		if err != nil {
+			logger.Errorf("Failed to re-enqueue the checkpointed job %s:%s: %v", qj.JobName, qj.JobID, err)
+		}
+
+		return
+	}
+	if runErr == nil {
+		if err := removeJob(ctx, qj.ID); err != nil {
+			logger.Error(err)
+		}
+
+		return
+	}
+
+	// The runner sets a huge fails number to the job which should not be retried
+	fails := wj.Fails + 1
+	if fails < int64(rj.maxFails) {
+		runAt := time.Now().Unix() + backoff(fails)
+		if err := retryLater(ctx, qj.ID, fails, runErr.Error(), runAt); err != nil {
+			logger.Error(err)
+		}
+
+		return
+	}
+
+	// Dead job, it's not kept as the dead jobs are skipped by the redis worker too
+	if err := removeJob(ctx, qj.ID); err != nil {
+		logger.Error(err)
+	}
+}
+
+// heartbeat of the worker pool
+func (w *pgWorker) heartbeat() error {
+	names := make([]string, 0)
+	w.registered.Range(func(k, _ any) bool {
+		names = append(names, k.(string))
+		return true
+	})
+	sort.Strings(names)
+
+	data, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+
+	return heartbeat(orm.Copy(w.context.SystemContext), &poolHeartbeat{
+		PoolID:      w.poolID,
+		JobNames:    string(data),
+		Concurrency: w.workerCount,
+		StartedAt:   w.startedAt,
+		HeartbeatAt: time.Now().Unix(),
+	})
+}
+
+// backoff returns the seconds to wait before retrying the failed job,
+// it's the same as the default one of the redis worker
+func backoff(fails int64) int64 {
+	return (fails * fails * fails * fails) + 15 + (rand.Int63n(30) * (fails + 1))
+}
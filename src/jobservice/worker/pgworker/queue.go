@@ -0,0 +1,304 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	o "github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+const (
+	statePending = "pending"
+	stateRunning = "running"
+)
+
+// queuedJob is the job row in the queue table
+type queuedJob struct {
+	ID         int64  `orm:"column(id)"`
+	JobID      string `orm:"column(job_id)"`
+	JobName    string `orm:"column(job_name)"`
+	Args       string `orm:"column(args)"`
+	Fails      int64  `orm:"column(fails)"`
+	LastErr    string `orm:"column(last_err)"`
+	EnqueuedAt int64  `orm:"column(enqueued_at)"`
+	RunAt      int64  `orm:"column(run_at)"`
+}
+
+// parameters of the queued job
+func (q *queuedJob) parameters() (job.Parameters, error) {
+	params := make(job.Parameters)
+	if len(q.Args) == 0 {
+		return params, nil
+	}
+
+	if err := json.Unmarshal([]byte(q.Args), &params); err != nil {
+		return nil, errors.Wrapf(err, "parse the arguments of job %s", q.JobID)
+	}
+
+	return params, nil
+}
+
+// insertJob puts the job into the queue, false is returned if the job is duplicated with
+// the existing one which has the same ID and run time or the same unique key.
+func insertJob(ctx context.Context, j *queuedJob, uniqueKey string, priority uint) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var key any
+	if len(uniqueKey) > 0 {
+		key = uniqueKey
+	}
+
+	sql := `INSERT INTO job_service_queue (job_id, job_name, args, unique_key, priority, state, enqueued_at, run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`
+	res, err := ormer.Raw(sql, j.JobID, j.JobName, j.Args, key, priority, statePending, j.EnqueuedAt, j.RunAt).Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "insert job into the queue")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "insert job into the queue")
+	}
+
+	return n > 0, nil
+}
+
+// dequeue locks the next runnable job of the specified types with the highest priority and marks it running,
+// the rows locked by other nodes are skipped. nil is returned if no runnable jobs.
+func dequeue(ctx context.Context, poolID string, names []string, now int64) (*queuedJob, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The unique key is released once the job is running, the same as the redis backend
+	sql := fmt.Sprintf(`UPDATE job_service_queue SET state = ?, worker_pool_id = ?, heartbeat_at = ?, unique_key = NULL
+		WHERE id = (SELECT id FROM job_service_queue WHERE state = ? AND run_at <= ? AND job_name IN (%s)
+		ORDER BY priority DESC, run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING id, job_id, job_name, COALESCE(args, '') AS args, fails, COALESCE(last_err, '') AS last_err, enqueued_at, run_at`,
+		orm.ParamPlaceholderForIn(len(names)))
+	params := []any{stateRunning, poolID, now, statePending, now}
+	for _, name := range names {
+		params = append(params, name)
+	}
+
+	j := &queuedJob{}
+	if err := ormer.Raw(sql, params...).QueryRow(j); err != nil {
+		if errors.Is(err, o.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "dequeue job")
+	}
+
+	return j, nil
+}
+
+// retryLater puts the failed job back to the queue to run at the specified time
+func retryLater(ctx context.Context, id int64, fails int64, lastErr string, runAt int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql := `UPDATE job_service_queue SET state = ?, fails = ?, last_err = ?, run_at = ?, worker_pool_id = NULL, heartbeat_at = NULL
+		WHERE id = ?`
+	if _, err := ormer.Raw(sql, statePending, fails, lastErr, runAt, id).Exec(); err != nil {
+		return errors.Wrap(err, "put job back to the queue")
+	}
+
+	return nil
+}
+
+// requeueJob puts the checkpointed job back to the queue with the updated arguments to run at the specified time,
+// the fails are kept as they're not consumed by the checkpoint
+func requeueJob(ctx context.Context, id int64, args string, runAt int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql := `UPDATE job_service_queue SET state = ?, args = ?, run_at = ?, worker_pool_id = NULL, heartbeat_at = NULL
+		WHERE id = ?`
+	if _, err := ormer.Raw(sql, statePending, args, runAt, id).Exec(); err != nil {
+		return errors.Wrap(err, "put checkpointed job back to the queue")
+	}
+
+	return nil
+}
+
+// removeJob removes the done or dead job from the queue
+func removeJob(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ormer.Raw(`DELETE FROM job_service_queue WHERE id = ?`, id).Exec(); err != nil {
+		return errors.Wrap(err, "remove job from the queue")
+	}
+
+	return nil
+}
+
+// removePendingJobs removes the pending jobs with the specified ID, the running ones are kept
+func removePendingJobs(ctx context.Context, jobID string) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := ormer.Raw(`DELETE FROM job_service_queue WHERE job_id = ? AND state = ?`, jobID, statePending).Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "remove pending jobs from the queue")
+	}
+
+	return res.RowsAffected()
+}
+
+// runningCounts returns the numbers of the running jobs of the job types
+func runningCounts(ctx context.Context) (map[string]uint, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		names  []string
+		counts []uint
+	)
+	sql := `SELECT job_name, COUNT(*) FROM job_service_queue WHERE state = ? GROUP BY job_name`
+	if _, err := ormer.Raw(sql, stateRunning).QueryRows(&names, &counts); err != nil {
+		return nil, errors.Wrap(err, "count running jobs")
+	}
+
+	res := make(map[string]uint, len(names))
+	for i, name := range names {
+		if i < len(counts) {
+			res[name] = counts[i]
+		}
+	}
+
+	return res, nil
+}
+
+// uniqueKey of the job, the jobs with the same name and parameters share the same key
+func uniqueKey(name string, params job.Parameters) (string, error) {
+	// the keys of map are sorted when marshaling
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(append([]byte(name+":"), data...))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// poolHeartbeat is the heartbeat row of the worker pool
+type poolHeartbeat struct {
+	PoolID      string `orm:"column(pool_id)"`
+	JobNames    string `orm:"column(job_names)"`
+	Concurrency uint   `orm:"column(concurrency)"`
+	StartedAt   int64  `orm:"column(started_at)"`
+	HeartbeatAt int64  `orm:"column(heartbeat_at)"`
+}
+
+// heartbeat saves the heartbeat of the worker pool and refreshes the heartbeats of the running jobs of the pool
+func heartbeat(ctx context.Context, hb *poolHeartbeat) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql := `INSERT INTO job_service_worker_pool (pool_id, job_names, concurrency, started_at, heartbeat_at)
+		VALUES (?, ?, ?, ?, ?) ON CONFLICT (pool_id) DO UPDATE SET job_names = EXCLUDED.job_names,
+		concurrency = EXCLUDED.concurrency, heartbeat_at = EXCLUDED.heartbeat_at`
+	if _, err := ormer.Raw(sql, hb.PoolID, hb.JobNames, hb.Concurrency, hb.StartedAt, hb.HeartbeatAt).Exec(); err != nil {
+		return errors.Wrap(err, "save heartbeat of worker pool")
+	}
+
+	sql = `UPDATE job_service_queue SET heartbeat_at = ? WHERE state = ? AND worker_pool_id = ?`
+	if _, err := ormer.Raw(sql, hb.HeartbeatAt, stateRunning, hb.PoolID).Exec(); err != nil {
+		return errors.Wrap(err, "refresh heartbeats of running jobs")
+	}
+
+	return nil
+}
+
+// listPools returns the heartbeats of all the worker pools
+func listPools(ctx context.Context) ([]*poolHeartbeat, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]*poolHeartbeat, 0)
+	sql := `SELECT pool_id, COALESCE(job_names, '') AS job_names, concurrency, started_at, heartbeat_at
+		FROM job_service_worker_pool ORDER BY started_at`
+	if _, err := ormer.Raw(sql).QueryRows(&pools); err != nil {
+		return nil, errors.Wrap(err, "list worker pools")
+	}
+
+	return pools, nil
+}
+
+// requeueOrphanJobs puts the running jobs whose heartbeats are older than the specified time back to the queue,
+// they're left by the crashed worker pools
+func requeueOrphanJobs(ctx context.Context, before int64) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := `UPDATE job_service_queue SET state = ?, worker_pool_id = NULL, heartbeat_at = NULL
+		WHERE state = ? AND heartbeat_at < ?`
+	res, err := ormer.Raw(sql, statePending, stateRunning, before).Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "requeue orphan jobs")
+	}
+
+	return res.RowsAffected()
+}
+
+// removeDeadPools removes the heartbeats of the worker pools which are dead before the specified time
+func removeDeadPools(ctx context.Context, before int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ormer.Raw(`DELETE FROM job_service_worker_pool WHERE heartbeat_at < ?`, before).Exec(); err != nil {
+		return errors.Wrap(err, "remove dead worker pools")
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// QueueTestSuite tests the job queue based on the postgresql database
+type QueueTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	poolID string
+}
+
+// SetupSuite prepares the test suite
+func (suite *QueueTestSuite) SetupSuite() {
+	common_dao.PrepareTestForPostgresSQL()
+	suite.ctx = orm.Context()
+	suite.poolID = utils.MakeIdentifier()
+}
+
+// TearDownTest clears the queue
+func (suite *QueueTestSuite) TearDownTest() {
+	ormer, err := orm.FromContext(suite.ctx)
+	require.NoError(suite.T(), err)
+	_, err = ormer.Raw(`DELETE FROM job_service_queue WHERE job_name LIKE 'pg_queue_test%'`).Exec()
+	require.NoError(suite.T(), err)
+	_, err = ormer.Raw(`DELETE FROM job_service_worker_pool WHERE pool_id = ?`, suite.poolID).Exec()
+	require.NoError(suite.T(), err)
+}
+
+// TestQueueTestSuite is entry of go test
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
+
+// TestInsertAndDequeue tests the job with the higher priority is dequeued first
+func (suite *QueueTestSuite) TestInsertAndDequeue() {
+	now := time.Now().Unix()
+
+	inserted, err := insertJob(suite.ctx, &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    "pg_queue_test_low",
+		Args:       `{"name":"low"}`,
+		EnqueuedAt: now,
+		RunAt:      now,
+	}, "", 1)
+	suite.Require().NoError(err)
+	suite.True(inserted)
+
+	highID := utils.MakeIdentifier()
+	inserted, err = insertJob(suite.ctx, &queuedJob{
+		JobID:      highID,
+		JobName:    "pg_queue_test_high",
+		Args:       `{"name":"high"}`,
+		EnqueuedAt: now,
+		RunAt:      now,
+	}, "", 9)
+	suite.Require().NoError(err)
+	suite.True(inserted)
+
+	// Not runnable yet
+	_, err = insertJob(suite.ctx, &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    "pg_queue_test_high",
+		EnqueuedAt: now,
+		RunAt:      now + 3600,
+	}, "", 10)
+	suite.Require().NoError(err)
+
+	names := []string{"pg_queue_test_low", "pg_queue_test_high"}
+	qj, err := dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+	suite.Equal(highID, qj.JobID)
+
+	params, err := qj.parameters()
+	suite.Require().NoError(err)
+	suite.Equal("high", params["name"])
+
+	counts, err := runningCounts(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Equal(uint(1), counts["pg_queue_test_high"])
+
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+	suite.Equal("pg_queue_test_low", qj.JobName)
+
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Nil(qj)
+}
+
+// TestUniqueJob tests the duplicated unique job is not queued
+func (suite *QueueTestSuite) TestUniqueJob() {
+	now := time.Now().Unix()
+	key, err := uniqueKey("pg_queue_test_unique", job.Parameters{"name": "unique"})
+	suite.Require().NoError(err)
+
+	for i, expected := range []bool{true, false} {
+		inserted, err := insertJob(suite.ctx, &queuedJob{
+			JobID:      utils.MakeIdentifier(),
+			JobName:    "pg_queue_test_unique",
+			EnqueuedAt: now,
+			RunAt:      now,
+		}, key, 0)
+		suite.Require().NoError(err)
+		suite.Equal(expected, inserted, "insert unique job #%d", i)
+	}
+
+	// The unique key is released once the job is running
+	qj, err := dequeue(suite.ctx, suite.poolID, []string{"pg_queue_test_unique"}, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+
+	inserted, err := insertJob(suite.ctx, &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    "pg_queue_test_unique",
+		EnqueuedAt: now,
+		RunAt:      now,
+	}, key, 0)
+	suite.Require().NoError(err)
+	suite.True(inserted)
+}
+
+// TestRetryAndOrphan tests the failed and orphan jobs are put back to the queue
+func (suite *QueueTestSuite) TestRetryAndOrphan() {
+	now := time.Now().Unix()
+	names := []string{"pg_queue_test_retry"}
+
+	_, err := insertJob(suite.ctx, &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    "pg_queue_test_retry",
+		EnqueuedAt: now,
+		RunAt:      now,
+	}, "", 0)
+	suite.Require().NoError(err)
+
+	qj, err := dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+
+	suite.Require().NoError(retryLater(suite.ctx, qj.ID, 1, "failed", now+60))
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Nil(qj)
+
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now+60)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+	suite.Equal(int64(1), qj.Fails)
+	suite.Equal("failed", qj.LastErr)
+
+	// The heartbeat of the running job is outdated
+	n, err := requeueOrphanJobs(suite.ctx, now+61)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now+60)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+	suite.Require().NoError(removeJob(suite.ctx, qj.ID))
+
+	counts, err := runningCounts(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Zero(counts["pg_queue_test_retry"])
+}
+
+// TestRequeue tests the checkpointed jobs and the jobs of the drained worker pool are put back to the queue
+func (suite *QueueTestSuite) TestRequeue() {
+	now := time.Now().Unix()
+	names := []string{"pg_queue_test_requeue"}
+
+	_, err := insertJob(suite.ctx, &queuedJob{
+		JobID:      utils.MakeIdentifier(),
+		JobName:    "pg_queue_test_requeue",
+		Args:       `{"a":1}`,
+		EnqueuedAt: now,
+		RunAt:      now,
+	}, "", 0)
+	suite.Require().NoError(err)
+
+	qj, err := dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+
+	suite.Require().NoError(requeueJob(suite.ctx, qj.ID, `{"a":1,"_checkpoint_":"10"}`, now))
+	qj, err = dequeue(suite.ctx, suite.poolID, names, now)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(qj)
+	suite.Zero(qj.Fails)
+	params, err := qj.parameters()
+	suite.Require().NoError(err)
+	data, ok := params.Checkpoint()
+	suite.True(ok)
+	suite.Equal("10", data)
+	suite.Require().NoError(removeJob(suite.ctx, qj.ID))
+}
+
+// TestHeartbeat tests the heartbeat of the worker pool
+func (suite *QueueTestSuite) TestHeartbeat() {
+	now := time.Now().Unix()
+	err := heartbeat(suite.ctx, &poolHeartbeat{
+		PoolID:      suite.poolID,
+		JobNames:    "pg_queue_test",
+		Concurrency: 5,
+		StartedAt:   now,
+		HeartbeatAt: now,
+	})
+	suite.Require().NoError(err)
+
+	pools, err := listPools(suite.ctx)
+	suite.Require().NoError(err)
+	found := false
+	for _, p := range pools {
+		if p.PoolID == suite.poolID {
+			found = true
+			suite.Equal(uint(5), p.Concurrency)
+		}
+	}
+	suite.True(found)
+
+	suite.Require().NoError(removeDeadPools(suite.ctx, now+1))
+	pools, err = listPools(suite.ctx)
+	suite.Require().NoError(err)
+	for _, p := range pools {
+		suite.NotEqual(suite.poolID, p.PoolID)
+	}
+}
+
+// TestUniqueKey tests the unique key of the job
+func TestUniqueKey(t *testing.T) {
+	k1, err := uniqueKey("job", job.Parameters{"a": 1, "b": "2"})
+	require.NoError(t, err)
+	k2, err := uniqueKey("job", job.Parameters{"b": "2", "a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, k1, k2)
+
+	k3, err := uniqueKey("other_job", job.Parameters{"a": 1, "b": "2"})
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k3)
+}
+
+// TestBackoff tests the delay of the retry grows with the fails
+func TestBackoff(t *testing.T) {
+	assert.GreaterOrEqual(t, backoff(1), int64(16))
+	assert.Less(t, backoff(1), int64(16+60))
+	assert.Greater(t, backoff(5), int64(625))
+}
+
+// TestCreateExecution tests the execution of the periodic job
+func TestCreateExecution(t *testing.T) {
+	p := &period.Policy{
+		ID:            "fake_policy",
+		JobName:       "fake_job",
+		CronSpec:      "0 * * * * *",
+		JobParameters: job.Parameters{"name": "fake"},
+	}
+	e := createExecution(p, 1000)
+	assert.Equal(t, "fake_policy@1000", e.Info.JobID)
+	assert.Equal(t, "fake_policy", e.Info.UpstreamJobID)
+	assert.Equal(t, job.KindScheduled, e.Info.JobKind)
+	assert.Equal(t, job.ScheduledStatus.String(), e.Info.Status)
+	assert.Equal(t, int64(1000), e.Info.RunAt)
+}
+
+// TestCompare tests the comparing of the status and the ACKed status
+func TestCompare(t *testing.T) {
+	info := &job.StatsInfo{Status: job.RunningStatus.String(), Revision: 2}
+	assert.Greater(t, compare(info), 0)
+
+	info.HookAck = &job.ACK{Status: job.RunningStatus.String(), Revision: 2}
+	assert.Equal(t, 0, compare(info))
+
+	info.HookAck = &job.ACK{Status: job.SuccessStatus.String(), Revision: 2}
+	assert.Less(t, compare(info), 0)
+
+	info.HookAck = &job.ACK{Status: job.SuccessStatus.String(), Revision: 1}
+	assert.Greater(t, compare(info), 0)
+}
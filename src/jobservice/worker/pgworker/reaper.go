@@ -0,0 +1,232 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+const (
+	reapLoopInterval        = 1 * time.Hour
+	initialReapLoopInterval = 5 * time.Minute
+	// the interval to requeue the jobs left by the crashed worker pools
+	orphanLoopInterval = 1 * time.Minute
+	// the running job is taken as orphan if its heartbeat is not refreshed for the duration
+	orphanJobDeadTime = 1 * time.Minute
+	// the heartbeats of the dead worker pools are kept for the duration
+	deadPoolRetention = 24 * time.Hour
+	// the stats of the successful and stopped jobs are kept for 1 day, and the failed ones are kept for 7 days,
+	// consistent with the expiration of the stats in redis
+	doneStatsRetention  = 24 * time.Hour
+	errorStatsRetention = 7 * 24 * time.Hour
+	reapBatchSize       = 500
+)
+
+// reaper is designed to requeue the orphan jobs and reap the outdated job stats and web hook
+type reaper struct {
+	context context.Context
+	lcmCtl  lcm.Controller
+}
+
+// start the reap process
+// Non blocking call
+func (r *reaper) start() {
+	go func() {
+		defer logger.Info("Reaper is stopped")
+
+		tm := time.NewTimer(initialReapLoopInterval)
+		defer tm.Stop()
+		orphanTicker := time.NewTicker(orphanLoopInterval)
+		defer orphanTicker.Stop()
+
+		logger.Info("Reaper is started")
+		for {
+			select {
+			case <-orphanTicker.C:
+				if err := r.requeueOrphanJobs(); err != nil {
+					logger.Error(err)
+				}
+			case <-tm.C:
+				tm.Reset(reapLoopInterval)
+				if err := r.syncOutdatedStats(); err != nil {
+					logger.Error(err)
+				}
+				if err := r.removeExpiredStats(); err != nil {
+					logger.Error(err)
+				}
+			case <-r.context.Done():
+				return // Terminated
+			}
+		}
+	}()
+}
+
+// requeueOrphanJobs puts the running jobs of the crashed worker pools back to the queue,
+// the runner resets the status of them when running again.
+func (r *reaper) requeueOrphanJobs() error {
+	ctx := orm.Copy(r.context)
+	now := time.Now()
+
+	n, err := requeueOrphanJobs(ctx, now.Add(-orphanJobDeadTime).Unix())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		logger.Infof("Reaper: requeue %d orphan jobs", n)
+	}
+
+	return removeDeadPools(ctx, now.Add(-deadPoolRetention).Unix())
+}
+
+// syncOutdatedStats ensures the job status is correctly updated and
+// the related status change hook events are successfully fired.
+func (r *reaper) syncOutdatedStats() error {
+	logger.Info("Start: reap outdated job stats")
+	defer logger.Info("End: reap outdated job stats")
+
+	ormer, err := orm.FromContext(orm.Copy(r.context))
+	if err != nil {
+		return err
+	}
+
+	// The ongoing jobs and the jobs whose status changes are not ACKed
+	sql := fmt.Sprintf(`SELECT job_id FROM job_service_stats WHERE job_id > ? AND (
+		(status IN ('Pending', 'Scheduled', 'Running') AND info->>'kind' <> 'Periodic') OR
+		(COALESCE(info->>'web_hook_url', '') <> '' AND
+		(ack IS NULL OR (ack->>'revision')::bigint <> revision OR %s <> %s)))
+		ORDER BY job_id LIMIT ?`, job.PgStatusCode("ack->>'status'"), job.PgStatusCode("status"))
+
+	last := ""
+	for {
+		var ids []string
+		if _, err := ormer.Raw(sql, last, reapBatchSize).QueryRows(&ids); err != nil {
+			return errors.Wrap(err, "reaper error")
+		}
+
+		for _, id := range ids {
+			if err := r.syncStats(id); err != nil {
+				// Log and ignore the error
+				logger.Errorf("Failed to call reap handler: %v", err)
+			}
+		}
+
+		if len(ids) < reapBatchSize {
+			return nil
+		}
+		last = ids[len(ids)-1]
+	}
+}
+
+// syncStats syncs the status and the ACKed status of the job
+func (r *reaper) syncStats(jobID string) error {
+	t, err := r.lcmCtl.Track(jobID)
+	if err != nil {
+		return errors.Wrap(err, "sync outdated stats handler error")
+	}
+
+	info := t.Job().Info
+	// Compare and check if the status and the ACKed status are consistent
+	diff := compare(info)
+	switch {
+	case diff == 0 || (diff > 0 && len(info.WebHookURL) == 0):
+		if !job.Status(info.Status).Final() &&
+			time.Unix(info.UpdateTime, 0).Add(config.MaxUpdateDuration()).Before(time.Now()) {
+			// Status hung
+			// Mark job status to error state
+			if err := t.Fail(); err != nil {
+				return err
+			}
+
+			logger.Infof("Reaper: mark job %s failed as job is still not finished in 1 day", info.JobID)
+		}
+	case diff > 0:
+		// The hook event of current job status is not ACKed
+		if err := t.FireHook(); err != nil {
+			return err
+		}
+
+		logger.Infof("Reaper: fire hook again for job %s as job status change is not ACKed: %s(rev=%d)", info.JobID, info.Status, info.Revision)
+	default:
+		// Current status is outdated, update it with ACKed status.
+		if err := t.UpdateStatusWithRetry(job.Status(info.HookAck.Status)); err != nil {
+			return err
+		}
+
+		logger.Infof("Reaper: update the status of job %s to the ACKed status: %s(%d)", info.JobID, info.HookAck.Status, info.Revision)
+	}
+
+	return nil
+}
+
+// removeExpiredStats removes the stats of the jobs which have been done for a while
+func (r *reaper) removeExpiredStats() error {
+	ormer, err := orm.FromContext(orm.Copy(r.context))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sql := `DELETE FROM job_service_stats WHERE (status IN ('Success', 'Stopped') AND update_time < ?) OR
+		(status = 'Error' AND update_time < ?)`
+	res, err := ormer.Raw(sql, now.Add(-doneStatsRetention).Unix(), now.Add(-errorStatsRetention).Unix()).Exec()
+	if err != nil {
+		return errors.Wrap(err, "remove expired job stats")
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		logger.Infof("Reaper: remove %d expired job stats", n)
+	}
+
+	return nil
+}
+
+// compare the status and the status in the ack
+// 0: status == ack.status
+// >0: status > ack.status
+// <0: status < ack.status
+//
+// compare based on:
+// revision:status_code:check_in
+func compare(j *job.StatsInfo) int {
+	// No ack existing
+	if j.HookAck == nil {
+		return 1
+	}
+
+	// Compare revision
+	rev := j.Revision - j.HookAck.Revision
+	if rev != 0 {
+		return (int)(rev)
+	}
+
+	// Revision is same, then compare the status
+	switch {
+	case job.Status(j.Status).Before(job.Status(j.HookAck.Status)):
+		return -1
+	case job.Status(j.Status).After(job.Status(j.HookAck.Status)):
+		return 1
+	}
+
+	// Revision and status are same, then compare the checkin
+	return (int)(j.CheckInAt - j.HookAck.CheckInAt)
+}
@@ -0,0 +1,321 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand"
+	"time"
+
+	comUtils "github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+const (
+	enqueuerSleep   = 2 * time.Minute
+	enqueuerHorizon = 4 * time.Minute
+	// the key of the advisory lock held when enqueuing the periodic executions,
+	// only one node does the enqueuing at a time
+	periodicLockKey int64 = 0x6a6f627365727669
+)
+
+// scheduler manages the periodic job policies in the postgresql database.
+type scheduler struct {
+	context context.Context
+	ctl     lcm.Controller
+}
+
+// NewScheduler is constructor of the periodic scheduler based on the postgresql database
+func NewScheduler(ctx context.Context, ctl lcm.Controller) period.Scheduler {
+	return &scheduler{
+		context: ctx,
+		ctl:     ctl,
+	}
+}
+
+// LoadPolicies loads all the periodic job policies from the database
+func LoadPolicies(ctx context.Context) ([]*period.Policy, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ids  []int64
+		data []string
+	)
+	if _, err := ormer.Raw(`SELECT id, policy FROM job_service_periodic_policy ORDER BY id`).QueryRows(&ids, &data); err != nil {
+		return nil, errors.Wrap(err, "load periodic job policies")
+	}
+
+	policies := make([]*period.Policy, 0, len(data))
+	for i, raw := range data {
+		p := &period.Policy{}
+		if err := p.DeSerialize([]byte(raw)); err != nil {
+			// Ignore error which means the policy data is not valid
+			logger.Errorf("Malformed policy: %s; error: %s", raw, err)
+			continue
+		}
+		p.NumericID = ids[i]
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+// Start the periodic enqueuing process
+func (s *scheduler) Start() {
+	go s.loop()
+	logger.Info("Scheduler: periodic enqueuer is started")
+}
+
+// Schedule is implementation of the same method in period.Interface
+func (s *scheduler) Schedule(p *period.Policy) (int64, error) {
+	if p == nil {
+		return -1, errors.New("bad policy object: nil")
+	}
+
+	if err := p.Validate(); err != nil {
+		return -1, err
+	}
+
+	rawJSON, err := p.Serialize()
+	if err != nil {
+		return -1, err
+	}
+
+	ctx := orm.Copy(s.context)
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var pid int64
+	sql := `INSERT INTO job_service_periodic_policy (policy_id, policy) VALUES (?, ?)
+		ON CONFLICT (policy_id) DO UPDATE SET policy = EXCLUDED.policy RETURNING id`
+	if err := ormer.Raw(sql, p.ID, string(rawJSON)).QueryRow(&pid); err != nil {
+		return -1, errors.Wrap(err, "save periodic job policy")
+	}
+
+	// Do the 1st round of enqueuing
+	if err := s.scheduleNextJobs(ctx, p); err != nil {
+		logger.Errorf("Enqueue the executions of the periodic job %s error: %s", p.ID, err)
+	}
+
+	return pid, nil
+}
+
+// UnSchedule is implementation of the same method in period.Interface
+func (s *scheduler) UnSchedule(policyID string) error {
+	if utils.IsEmptyStr(policyID) {
+		return errors.New("bad periodic job ID: nil")
+	}
+
+	ctx := orm.Copy(s.context)
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Switch the job stats of the periodic job to stopped if the job stats existing
+	if t, err := s.ctl.Track(policyID); err != nil {
+		if !errs.IsObjectNotFoundError(err) {
+			return err
+		}
+	} else if err := t.Stop(); err != nil {
+		logger.Errorf("Stop periodic job %s failed with error: %s", lib.TrimLineBreaks(policyID), err)
+	}
+
+	// The queued executions share the ID of the periodic job (policy)
+	if _, err := removePendingJobs(ctx, policyID); err != nil {
+		logger.Errorf("Remove the queued executions of periodic job %s error: %s", lib.TrimLineBreaks(policyID), err)
+	}
+
+	// Stop the executions not done yet to block execution.
+	// This is a try best action, its failure will not cause the unschedule action failed.
+	var eIDs []string
+	sql := `SELECT job_id FROM job_service_stats WHERE upstream_job_id = ? AND run_at >= 0`
+	if _, err := ormer.Raw(sql, policyID).QueryRows(&eIDs); err != nil {
+		logger.Errorf("Get executions for periodic job %s error: %s", lib.TrimLineBreaks(policyID), err)
+	}
+	for _, eID := range eIDs {
+		eTracker, err := s.ctl.Track(eID)
+		if err != nil {
+			logger.Errorf("Track execution %s error: %s", eID, err)
+			continue
+		}
+
+		st := job.Status(eTracker.Job().Info.Status)
+		if job.RunningStatus.After(st) || job.RunningStatus.Equal(st) {
+			if err := eTracker.Stop(); err != nil {
+				logger.Errorf("Stop execution %s error: %s", eID, err)
+			} else {
+				logger.Debugf("Stop execution %q of periodic job %s", eID, policyID)
+			}
+		}
+	}
+
+	res, err := ormer.Raw(`DELETE FROM job_service_periodic_policy WHERE policy_id = ?`, policyID).Exec()
+	if err != nil {
+		return errors.Wrap(err, "unschedule periodic job error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		logger.Warningf("No periodic job with ID=%s removed from the periodic job policy table", lib.TrimLineBreaks(policyID))
+	}
+
+	return nil
+}
+
+func (s *scheduler) loop() {
+	defer func() {
+		logger.Info("Scheduler: periodic enqueuer is stopped")
+	}()
+
+	// Do enqueue immediately when starting
+	s.enqueue()
+
+	timer := time.NewTimer(nextTurn())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.context.Done():
+			return // exit
+		case <-timer.C:
+			s.enqueue()
+			timer.Reset(nextTurn())
+		}
+	}
+}
+
+// enqueue the executions of all the periodic jobs if the lock is acquired
+func (s *scheduler) enqueue() {
+	err := orm.WithTransaction(func(ctx context.Context) error {
+		ormer, err := orm.FromContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		var locked bool
+		if err := ormer.Raw(`SELECT pg_try_advisory_xact_lock(?)`, periodicLockKey).QueryRow(&locked); err != nil {
+			return errors.Wrap(err, "acquire lock for periodic enqueuing")
+		}
+		if !locked {
+			// Other node is enqueuing
+			return nil
+		}
+
+		policies, err := LoadPolicies(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range policies {
+			if err := s.scheduleNextJobs(ctx, p); err != nil {
+				logger.Errorf("Enqueue the executions of the periodic job %s error: %s", p.ID, err)
+			}
+		}
+
+		return nil
+	})(orm.Copy(s.context))
+	if err != nil {
+		logger.Errorf("%s:%s", err, "enqueue error: enqueuer")
+	}
+}
+
+// scheduleNextJobs queues the executions of the periodic job in the next time slots,
+// the execution of the slot which has been queued is skipped
+func (s *scheduler) scheduleNextJobs(ctx context.Context, p *period.Policy) error {
+	// Follow UTC time spec
+	nowTime := time.Unix(time.Now().UTC().Unix(), 0).UTC()
+	horizon := nowTime.Add(enqueuerHorizon)
+	schedule, err := comUtils.CronParser().Parse(p.CronSpec)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cron spec %s", p.CronSpec)
+	}
+
+	for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
+		epoch := t.Unix()
+
+		params := make(job.Parameters)
+		maps.Copy(params, p.JobParameters)
+		params[period.PeriodicExecutionMark] = fmt.Sprintf("%d", epoch)
+		args, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+
+		// Use the ID of policy as the job ID, the execution is identified by the ID and the run time
+		inserted, err := insertJob(ctx, &queuedJob{
+			JobID:      p.ID,
+			JobName:    p.JobName,
+			Args:       string(args),
+			EnqueuedAt: epoch,
+			RunAt:      epoch,
+		}, "", job.Priority().For(p.JobName))
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			continue
+		}
+
+		execution := createExecution(p, epoch)
+		if _, err := s.ctl.New(execution); err != nil {
+			return errors.Wrapf(err, "save stats data of job execution '%s'", execution.Info.JobID)
+		}
+
+		logger.Debugf("Scheduled execution for periodic job %s:%s at %d", lib.TrimLineBreaks(p.JobName), p.ID, epoch)
+	}
+
+	return nil
+}
+
+// createExecution creates execution object
+func createExecution(p *period.Policy, runAt int64) *job.Stats {
+	eID := fmt.Sprintf("%s@%d", p.ID, runAt)
+
+	return &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:         eID,
+			JobName:       p.JobName,
+			WebHookURL:    p.WebHookURL,
+			CronSpec:      p.CronSpec,
+			UpstreamJobID: p.ID,
+			RunAt:         runAt,
+			Status:        job.ScheduledStatus.String(),
+			JobKind:       job.KindScheduled, // For periodic job execution, it should be set to 'scheduled'
+			EnqueueTime:   time.Now().Unix(),
+			RefLink:       fmt.Sprintf("/api/v1/jobs/%s", eID),
+			Parameters:    p.JobParameters,
+		},
+	}
+}
+
+// nextTurn returns the next check time slot with a random buffer
+func nextTurn() time.Duration {
+	return enqueuerSleep + time.Duration(rand.Intn(5))*time.Second
+}
@@ -152,6 +152,23 @@ var (
 		{Name: common.WithTrivy, Scope: SystemScope, Group: BasicGroup, EnvKey: "WITH_TRIVY", DefaultValue: "false", ItemType: &BoolType{}, Editable: true},
 		// the unit of expiration is days
 		{Name: common.RobotTokenDuration, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_TOKEN_DURATION", DefaultValue: "30", ItemType: &IntType{}, Editable: true, Description: `The robot account token duration in days`},
+		// the unit of the grace period is hours, 0 means the previous secret is invalid immediately after refreshing
+		{Name: common.RobotSecretGracePeriod, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_SECRET_GRACE_PERIOD", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The hours in which the previous secret of the robot account is still valid after refreshing the secret`},
+		// 0 means no notification is sent before the expiration of the robot
+		{Name: common.RobotExpirationNotifyDays, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_EXPIRATION_NOTIFY_DAYS", DefaultValue: "7", ItemType: &IntType{}, Editable: true, Description: `The days before the expiration of the robot account to send the notification`},
+		// the SCIM endpoint is disabled if the token is empty
+		{Name: common.SCIMToken, Scope: UserScope, Group: BasicGroup, ItemType: &PasswordType{}, Editable: true, Description: `The bearer token of the identity provider to provision the users and groups via SCIM`},
+		// 0 disables the lockout, the login is still backed off exponentially after the failures of the user
+		{Name: common.LoginMaxFailures, Scope: UserScope, Group: BasicGroup, EnvKey: "LOGIN_MAX_FAILURES", DefaultValue: "5", ItemType: &IntType{}, Editable: true, Description: `The login failures of the user within the window to lock the user out`},
+		{Name: common.LoginMaxFailuresPerIP, Scope: UserScope, Group: BasicGroup, EnvKey: "LOGIN_MAX_FAILURES_PER_IP", DefaultValue: "20", ItemType: &IntType{}, Editable: true, Description: `The login failures from the client IP within the window to lock the IP out`},
+		// the unit of the window and the lockout duration is minutes
+		{Name: common.LoginFailureWindow, Scope: UserScope, Group: BasicGroup, EnvKey: "LOGIN_FAILURE_WINDOW", DefaultValue: "15", ItemType: &IntType{}, Editable: true, Description: `The minutes within which the login failures are counted`},
+		{Name: common.LoginLockoutDuration, Scope: UserScope, Group: BasicGroup, EnvKey: "LOGIN_LOCKOUT_DURATION", DefaultValue: "15", ItemType: &IntType{}, Editable: true, Description: `The minutes for which the user or the client IP is locked out`},
+		// 0 disables the time based retention, only the retention counts of the vendor types are applied
+		{Name: common.ExecutionRetentionDays, Scope: UserScope, Group: BasicGroup, EnvKey: "EXECUTION_RETENTION_DAYS", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The days within which the executions are retained by the execution sweeper`},
+		// overrides the retention counts of the vendor types set by the *_EXECUTION_RETENTION_COUNT env of core
+		{Name: common.ExecutionRetentionCounts, Scope: UserScope, Group: BasicGroup, EnvKey: "EXECUTION_RETENTION_COUNTS", DefaultValue: "{}", ItemType: &StringToInt64MapType{}, Editable: true, Description: `The counts of the executions retained by the execution sweeper for the vendor types`},
+		{Name: common.ExecutionArchiveEnabled, Scope: UserScope, Group: BasicGroup, EnvKey: "EXECUTION_ARCHIVE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `Archive the swept executions and their task logs before deleting them`},
 		{Name: common.RobotNamePrefix, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_NAME_PREFIX", DefaultValue: "robot$", ItemType: &NonEmptyStringType{}, Editable: true, Description: `The robot account name prefix`},
 		{Name: common.RobotScannerNamePrefix, Scope: SystemScope, Group: BasicGroup, EnvKey: "ROBOT_SCANNER_NAME_PREFIX", DefaultValue: "scanner", ItemType: &StringType{}, Editable: true, Description: `The scanner robot account name prefix`},
 		{Name: common.NotificationEnable, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable notification`},
@@ -162,6 +179,9 @@ var (
 
 		{Name: common.QuotaPerProjectEnable, Scope: UserScope, Group: QuotaGroup, EnvKey: "QUOTA_PER_PROJECT_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable quota per project`},
 		{Name: common.StoragePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "STORAGE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The storage quota per project`},
+		{Name: common.ArtifactCountPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "ARTIFACT_COUNT_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The artifact count quota per project`},
+		{Name: common.RepositoryCountPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "REPOSITORY_COUNT_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The repository count quota per project`},
+		{Name: common.ArtifactSizePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "ARTIFACT_SIZE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The max size of a single artifact per project`},
 
 		{Name: common.TraceEnabled, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Enable trace`},
 		{Name: common.TraceServiceName, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_SERVICE_NAME", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The service name of the trace`},
@@ -217,6 +217,29 @@ func (t *StringToStringMapType) get(str string) (any, error) {
 	return result, err
 }
 
+// StringToInt64MapType is the map of the non-negative int64 values
+type StringToInt64MapType struct {
+}
+
+func (t *StringToInt64MapType) validate(str string) error {
+	result := map[string]int64{}
+	if err := json.Unmarshal([]byte(str), &result); err != nil {
+		return err
+	}
+	for k, v := range result {
+		if v < 0 {
+			return fmt.Errorf("the value of %s should not be negative", k)
+		}
+	}
+	return nil
+}
+
+func (t *StringToInt64MapType) get(str string) (any, error) {
+	result := map[string]int64{}
+	err := json.Unmarshal([]byte(str), &result)
+	return result, err
+}
+
 // QuotaType ...
 type QuotaType struct {
 	Int64Type
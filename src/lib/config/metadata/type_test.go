@@ -110,6 +110,19 @@ func TestStringToStringMapType_get(t *testing.T) {
 	assert.Equal(t, map[string]string{"sample": "abc", "another": "welcome"}, result)
 }
 
+func TestStringToInt64MapType_validate(t *testing.T) {
+	test := &StringToInt64MapType{}
+	assert.Nil(t, test.validate(`{"REPLICATION":100, "WEBHOOK":0}`))
+	assert.NotNil(t, test.validate(`{"REPLICATION":"100"}`))
+	assert.NotNil(t, test.validate(`{"REPLICATION":-1}`))
+}
+
+func TestStringToInt64MapType_get(t *testing.T) {
+	test := &StringToInt64MapType{}
+	result, _ := test.get(`{"REPLICATION":100, "WEBHOOK":0}`)
+	assert.Equal(t, map[string]int64{"REPLICATION": 100, "WEBHOOK": 0}, result)
+}
+
 func TestDurationType(t *testing.T) {
 	test := &DurationType{}
 	// test get
@@ -145,6 +145,23 @@ func (c *ConfigureValue) GetStringToStringMap() map[string]string {
 	return result
 }
 
+// GetStringToInt64Map - return the string to int64 map of current value
+func (c *ConfigureValue) GetStringToInt64Map() map[string]int64 {
+	result := map[string]int64{}
+	if item, ok := Instance().GetByName(c.Name); ok {
+		val, err := item.ItemType.get(c.Value)
+		if err != nil {
+			log.Errorf("The GetStringToInt64Map failed, error: %+v", err)
+			return result
+		}
+		if mapValue, suc := val.(map[string]int64); suc {
+			return mapValue
+		}
+	}
+	log.Errorf("GetStringToInt64Map failed, current value's metadata is not defined, %+v", c)
+	return result
+}
+
 // GetDuration - return the time.Duration value of current value
 func (c *ConfigureValue) GetDuration() time.Duration {
 	if item, ok := Instance().GetByName(c.Name); ok {
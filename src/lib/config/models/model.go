@@ -49,7 +49,10 @@ type OIDCSetting struct {
 
 // QuotaSetting wraps the settings for Quota
 type QuotaSetting struct {
-	StoragePerProject int64 `json:"storage_per_project"`
+	StoragePerProject         int64 `json:"storage_per_project"`
+	ArtifactCountPerProject   int64 `json:"artifact_count_per_project"`
+	RepositoryCountPerProject int64 `json:"repository_count_per_project"`
+	ArtifactSizePerProject    int64 `json:"artifact_size_per_project"`
 }
 
 func init() {
@@ -100,6 +100,56 @@ func RobotTokenDuration(ctx context.Context) int {
 	return DefaultMgr().Get(ctx, common.RobotTokenDuration).GetInt()
 }
 
+// RobotSecretGracePeriod returns the hours in which the previous secret of robot account is still valid after refreshing
+func RobotSecretGracePeriod(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.RobotSecretGracePeriod).GetInt()
+}
+
+// RobotExpirationNotifyDays returns the days before the expiration of robot account to send the notification
+func RobotExpirationNotifyDays(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.RobotExpirationNotifyDays).GetInt()
+}
+
+// SCIMToken returns the bearer token to access the SCIM endpoint, empty means SCIM is disabled
+func SCIMToken(ctx context.Context) string {
+	return DefaultMgr().Get(ctx, common.SCIMToken).GetString()
+}
+
+// LoginMaxFailures returns the login failures of the user within the window to lock the user out
+func LoginMaxFailures(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.LoginMaxFailures).GetInt()
+}
+
+// LoginMaxFailuresPerIP returns the login failures from the client IP within the window to lock the IP out
+func LoginMaxFailuresPerIP(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.LoginMaxFailuresPerIP).GetInt()
+}
+
+// LoginFailureWindow returns the minutes within which the login failures are counted
+func LoginFailureWindow(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.LoginFailureWindow).GetInt()
+}
+
+// LoginLockoutDuration returns the minutes for which the user or the client IP is locked out
+func LoginLockoutDuration(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.LoginLockoutDuration).GetInt()
+}
+
+// ExecutionRetentionDays returns the days within which the executions are retained, 0 disables the time based retention
+func ExecutionRetentionDays(ctx context.Context) int {
+	return DefaultMgr().Get(ctx, common.ExecutionRetentionDays).GetInt()
+}
+
+// ExecutionRetentionCounts returns the counts of the executions retained for the vendor types set via the configuration
+func ExecutionRetentionCounts(ctx context.Context) map[string]int64 {
+	return DefaultMgr().Get(ctx, common.ExecutionRetentionCounts).GetStringToInt64Map()
+}
+
+// ExecutionArchiveEnabled returns whether to archive the swept executions before deleting them
+func ExecutionArchiveEnabled(ctx context.Context) bool {
+	return DefaultMgr().Get(ctx, common.ExecutionArchiveEnabled).GetBool()
+}
+
 // SelfRegistration returns the enablement of self registration
 func SelfRegistration(ctx context.Context) (bool, error) {
 	return DefaultMgr().Get(ctx, common.SelfRegistration).GetBool(), nil
@@ -208,7 +258,10 @@ func QuotaSetting(ctx context.Context) (*cfgModels.QuotaSetting, error) {
 		return nil, err
 	}
 	return &cfgModels.QuotaSetting{
-		StoragePerProject: DefaultMgr().Get(ctx, common.StoragePerProject).GetInt64(),
+		StoragePerProject:         DefaultMgr().Get(ctx, common.StoragePerProject).GetInt64(),
+		ArtifactCountPerProject:   DefaultMgr().Get(ctx, common.ArtifactCountPerProject).GetInt64(),
+		RepositoryCountPerProject: DefaultMgr().Get(ctx, common.RepositoryCountPerProject).GetInt64(),
+		ArtifactSizePerProject:    DefaultMgr().Get(ctx, common.ArtifactSizePerProject).GetInt64(),
 	}, nil
 }
 
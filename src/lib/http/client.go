@@ -0,0 +1,42 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"os"
+)
+
+func trueClientIPHeaderName() string {
+	// because the true client IP header varies based on the foreground proxy/lb settings,
+	// make it configurable by env
+	name := os.Getenv("TRUE_CLIENT_IP_HEADER")
+	if len(name) == 0 {
+		name = "x-forwarded-for"
+	}
+	return name
+}
+
+// GetClientIP get client ip from request
+func GetClientIP(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	ip := r.Header.Get(trueClientIPHeaderName())
+	if len(ip) > 0 {
+		return ip
+	}
+	return r.RemoteAddr
+}
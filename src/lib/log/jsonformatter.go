@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint:revive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JSONRecord is the record formatted by the JSONFormatter
+type JSONRecord struct {
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level"`
+	Line   string    `json:"line,omitempty"`
+	Msg    string    `json:"msg"`
+	Fields Fields    `json:"fields,omitempty"`
+}
+
+// JSONFormatter represents a kind of formatter that formats the logs as JSON lines
+type JSONFormatter struct{}
+
+// NewJSONFormatter returns a JSONFormatter
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+// Format formats the log as a JSON object followed by a line break
+func (j *JSONFormatter) Format(r *Record) ([]byte, error) {
+	jr := &JSONRecord{
+		Time:   r.Time,
+		Level:  r.Lvl.string(),
+		Line:   r.Line,
+		Msg:    r.Msg,
+		Fields: r.Fields,
+	}
+	b, err := json.Marshal(jr)
+	if err != nil {
+		// fall back to the string representations of the fields which can't be marshaled
+		fields := make(Fields, len(r.Fields))
+		for k, v := range r.Fields {
+			fields[k] = fmt.Sprint(v)
+		}
+		jr.Fields = fields
+		if b, err = json.Marshal(jr); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, '\n'), nil
+}
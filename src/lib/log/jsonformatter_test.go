@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := New(buf, NewJSONFormatter(), DebugLevel)
+	l.WithFields(Fields{"repository": "library/hello", "ch": make(chan int)}).Warning("message")
+
+	record := &JSONRecord{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), record))
+	assert.Equal(t, "WARNING", record.Level)
+	assert.Equal(t, "message", record.Msg)
+	assert.Contains(t, record.Line, "jsonformatter_test.go")
+	assert.NotContains(t, record.Line, "repository")
+	assert.Equal(t, "library/hello", record.Fields["repository"])
+	assert.NotEmpty(t, record.Fields["ch"])
+	assert.WithinDuration(t, time.Now(), record.Time, time.Minute)
+}
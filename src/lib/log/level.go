@@ -35,6 +35,11 @@ const (
 	FatalLevel
 )
 
+// String returns the name of the level
+func (l Level) String() string {
+	return l.string()
+}
+
 func (l Level) string() (lvl string) {
 	switch l {
 	case DebugLevel:
@@ -174,6 +174,7 @@ func (l *Logger) setLevel(lvl Level) {
 }
 
 func (l *Logger) output(record *Record) (err error) {
+	record.Fields = l.fields
 	b, err := l.fmtter.Format(record)
 	if err != nil {
 		return
@@ -285,6 +286,11 @@ func (l *Logger) getLine() string {
 		str = line(l.callDepth)
 	}
 
+	// the JSON formatter keeps the fields apart from the line
+	if _, ok := l.fmtter.(*JSONFormatter); ok {
+		return str
+	}
+
 	str = str + l.fieldsStr
 
 	if str != "" {
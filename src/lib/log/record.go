@@ -24,6 +24,8 @@ type Record struct {
 	Msg  string    // content of the log
 	Line string    // in which file and line that the log produced
 	Lvl  Level     // level of the log
+	// the structured fields of the log, they are rendered into the line except for the JSON formatter
+	Fields Fields
 }
 
 // NewRecord creates a record according to the arguments provided and returns it
@@ -26,6 +26,7 @@ func RegisterJobServiceCollectors() {
 		JobserviceInfo,
 		JobserviceTotalTask,
 		JobservieTaskProcessTimeSummary,
+		JobserviceTaskQueueWaitTimeSummary,
 	}...)
 }
 
@@ -60,4 +61,14 @@ var (
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		},
 		[]string{"type", "status"})
+	// JobserviceTaskQueueWaitTimeSummary used for instrument the time the task waits in the queue
+	JobserviceTaskQueueWaitTimeSummary = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  os.Getenv(NamespaceEnvKey),
+			Subsystem:  os.Getenv(SubsystemEnvKey),
+			Name:       "task_queue_wait_time_seconds",
+			Help:       "The time duration of the task waiting in the queue before processing",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"type"})
 )
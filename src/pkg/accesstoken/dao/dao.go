@@ -0,0 +1,164 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+)
+
+// DAO defines the interface to access the personal access token data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, t *model.AccessToken) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, t *model.AccessToken, props ...string) error
+
+	// Get ...
+	Get(ctx context.Context, id int64) (*model.AccessToken, error)
+
+	// GetByTokenHash gets the token by the digest of the token
+	GetByTokenHash(ctx context.Context, hash string) (*model.AccessToken, error)
+
+	// Count returns the total count of tokens according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error)
+
+	// Delete ...
+	Delete(ctx context.Context, id int64) error
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, t *model.AccessToken) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.Encode(); err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(t)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "access token %s already exists", t.Name)
+	}
+	return id, nil
+}
+
+func (d *dao) Update(ctx context.Context, t *model.AccessToken, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := t.Encode(); err != nil {
+		return err
+	}
+	n, err := ormer.Update(t, props...)
+	if err != nil {
+		return orm.WrapConflictError(err, "access token %s already exists", t.Name)
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("access token %d not found", t.ID)
+	}
+	return nil
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.AccessToken, error) {
+	t := &model.AccessToken{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.Read(t); err != nil {
+		return nil, orm.WrapNotFoundError(err, "access token %d not found", id)
+	}
+	if err := t.Decode(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (d *dao) GetByTokenHash(ctx context.Context, hash string) (*model.AccessToken, error) {
+	t := &model.AccessToken{
+		TokenHash: hash,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.Read(t, "token_hash"); err != nil {
+		return nil, orm.WrapNotFoundError(err, "access token not found")
+	}
+	if err := t.Decode(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.AccessToken{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error) {
+	tokens := []*model.AccessToken{}
+	qs, err := orm.QuerySetter(ctx, &model.AccessToken{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&tokens); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if err := t.Decode(); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.AccessToken{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("access token %d not found", id)
+	}
+	return nil
+}
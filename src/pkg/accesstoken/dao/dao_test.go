@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type DaoTestSuite struct {
+	htesting.Suite
+	dao     DAO
+	tokenID int64
+}
+
+func (suite *DaoTestSuite) SetupSuite() {
+	suite.Suite.SetupSuite()
+	suite.dao = New()
+}
+
+func (suite *DaoTestSuite) SetupTest() {
+	var err error
+	suite.tokenID, err = suite.dao.Create(orm.Context(), &model.AccessToken{
+		UserID:    1,
+		Name:      "ci",
+		TokenHash: "digest",
+		ExpiresAt: -1,
+		Permissions: []*model.Permission{
+			{
+				Namespace: "library",
+				Access: []*types.Policy{
+					{Resource: "repository", Action: "pull"},
+				},
+			},
+		},
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *DaoTestSuite) TearDownTest() {
+	err := suite.dao.Delete(orm.Context(), suite.tokenID)
+	suite.True(err == nil || errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestCreate() {
+	_, err := suite.dao.Create(orm.Context(), &model.AccessToken{UserID: 1, Name: "ci", TokenHash: "another"})
+	suite.Require().Error(err)
+	suite.True(errors.IsConflictErr(err))
+}
+
+func (suite *DaoTestSuite) TestGetAndUpdate() {
+	t, err := suite.dao.Get(orm.Context(), suite.tokenID)
+	suite.Require().NoError(err)
+	suite.Equal("ci", t.Name)
+	suite.Require().Len(t.Permissions, 1)
+	suite.Equal("library", t.Permissions[0].Namespace)
+
+	t.LastUsedIP = "10.0.0.1"
+	suite.Require().NoError(suite.dao.Update(orm.Context(), t, "last_used_ip"))
+	t, err = suite.dao.Get(orm.Context(), suite.tokenID)
+	suite.Require().NoError(err)
+	suite.Equal("10.0.0.1", t.LastUsedIP)
+
+	_, err = suite.dao.Get(orm.Context(), 10000)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestListAndCount() {
+	query := q.New(q.KeyWords{"user_id": 1})
+	n, err := suite.dao.Count(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+
+	tokens, err := suite.dao.List(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Require().Len(tokens, 1)
+	suite.Equal(suite.tokenID, tokens[0].ID)
+}
+
+func (suite *DaoTestSuite) TestGetByTokenHash() {
+	t, err := suite.dao.GetByTokenHash(orm.Context(), "digest")
+	suite.Require().NoError(err)
+	suite.Equal(suite.tokenID, t.ID)
+	suite.Require().Len(t.Permissions, 1)
+
+	_, err = suite.dao.GetByTokenHash(orm.Context(), "unknown")
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestDelete() {
+	suite.Require().NoError(suite.dao.Delete(orm.Context(), suite.tokenID))
+	err := suite.dao.Delete(orm.Context(), suite.tokenID)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &DaoTestSuite{})
+}
@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/dao"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+)
+
+var (
+	// Mgr is a global variable for the default personal access token manager implementation
+	Mgr = NewManager()
+)
+
+// Manager defines the interface to manage the personal access tokens of the users
+type Manager interface {
+	// Create creates the token and returns its ID
+	Create(ctx context.Context, t *model.AccessToken) (int64, error)
+	// Update updates the token
+	Update(ctx context.Context, t *model.AccessToken, props ...string) error
+	// Get gets the token by ID
+	Get(ctx context.Context, id int64) (*model.AccessToken, error)
+	// GetByTokenHash gets the token by the digest of the token
+	GetByTokenHash(ctx context.Context, hash string) (*model.AccessToken, error)
+	// Count returns the total count of tokens according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the tokens according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error)
+	// Delete deletes the token by ID
+	Delete(ctx context.Context, id int64) error
+}
+
+// NewManager returns a default implementation of Manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, t *model.AccessToken) (int64, error) {
+	return m.dao.Create(ctx, t)
+}
+
+func (m *manager) Update(ctx context.Context, t *model.AccessToken, props ...string) error {
+	return m.dao.Update(ctx, t, props...)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.AccessToken, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) GetByTokenHash(ctx context.Context, hash string) (*model.AccessToken, error) {
+	return m.dao.GetByTokenHash(ctx, hash)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.AccessToken, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
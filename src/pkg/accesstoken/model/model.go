@@ -0,0 +1,100 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+func init() {
+	orm.RegisterModel(&AccessToken{})
+}
+
+// AccessToken is the personal access token of the user, it grants a subset of the user's permissions
+type AccessToken struct {
+	ID          int64  `orm:"pk;auto;column(id)" json:"id"`
+	UserID      int    `orm:"column(user_id)" json:"user_id"`
+	Name        string `orm:"column(name)" json:"name" sort:"default"`
+	Description string `orm:"column(description)" json:"description"`
+	// only the SHA256 digest of the token is stored, the token is returned once when it's created
+	TokenHash   string        `orm:"column(token_hash)" filter:"false" json:"-"`
+	Permissions []*Permission `orm:"-" json:"permissions"`
+	// Use JSON data format
+	PermissionsStr string    `orm:"column(permissions)" json:"-"`
+	ExpiresAt      int64     `orm:"column(expiresat)" json:"expires_at"`
+	LastUsedTime   time.Time `orm:"column(last_used_time)" json:"last_used_time"`
+	LastUsedIP     string    `orm:"column(last_used_ip)" json:"last_used_ip"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime     time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+	// the groups of the owner when the token was created, they're used for the auth modes resolving the groups at login
+	GroupIDs []int `orm:"-" json:"-"`
+	// Use JSON data format
+	GroupIDsStr string `orm:"column(group_ids)" json:"-"`
+}
+
+// Permission is the access granted by the token in the project,
+// the namespace is the project name and "*" means all the projects
+type Permission struct {
+	Namespace string          `json:"namespace"`
+	Access    []*types.Policy `json:"access"`
+}
+
+// TableName ...
+func (a *AccessToken) TableName() string {
+	return "access_token"
+}
+
+// IsExpired returns whether the token is expired, the token never expires if the ExpiresAt is -1
+func (a *AccessToken) IsExpired() bool {
+	return a.ExpiresAt != -1 && a.ExpiresAt <= time.Now().Unix()
+}
+
+// Encode encodes the permissions to the JSON string stored in the database
+func (a *AccessToken) Encode() error {
+	permissions, err := json.Marshal(a.Permissions)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the permissions")
+	}
+	a.PermissionsStr = string(permissions)
+	if len(a.GroupIDs) > 0 {
+		groupIDs, err := json.Marshal(a.GroupIDs)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode the group IDs")
+		}
+		a.GroupIDsStr = string(groupIDs)
+	}
+	return nil
+}
+
+// Decode decodes the permissions from the JSON string stored in the database
+func (a *AccessToken) Decode() error {
+	if len(a.PermissionsStr) > 0 {
+		if err := json.Unmarshal([]byte(a.PermissionsStr), &a.Permissions); err != nil {
+			return errors.Wrap(err, "failed to decode the permissions")
+		}
+	}
+	if len(a.GroupIDsStr) > 0 {
+		if err := json.Unmarshal([]byte(a.GroupIDsStr), &a.GroupIDs); err != nil {
+			return errors.Wrap(err, "failed to decode the group IDs")
+		}
+	}
+	return nil
+}
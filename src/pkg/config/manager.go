@@ -208,14 +208,15 @@ func (c *CfgManager) ValidateCfg(ctx context.Context, cfgs map[string]any) error
 			cfgs[key] = strVal
 		}
 
-		// check storage per project before setting it
-		if key == common.StoragePerProject {
-			storagePerProject, err := strconv.ParseInt(strVal, 10, 64)
+		// check the quota per project before setting it
+		if key == common.StoragePerProject || key == common.ArtifactCountPerProject ||
+			key == common.RepositoryCountPerProject || key == common.ArtifactSizePerProject {
+			quotaPerProject, err := strconv.ParseInt(strVal, 10, 64)
 			if err != nil {
 				return fmt.Errorf("cannot parse string value(%v) to int64", strVal)
 			}
 
-			if err := lib.ValidateQuotaLimit(storagePerProject); err != nil {
+			if err := lib.ValidateQuotaLimit(quotaPerProject); err != nil {
 				return err
 			}
 		}
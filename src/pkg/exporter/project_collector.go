@@ -63,6 +63,14 @@ var (
 		desc:      newDescWithLables("", "project_quota_byte", "The quota of a project", "project_name"),
 		valueType: prometheus.GaugeValue,
 	}
+	projectResourceUsage = typedDesc{
+		desc:      newDescWithLables("", "project_quota_resource_usage", "The used quota resource of a project, e.g. artifact_count", "project_name", "resource"),
+		valueType: prometheus.GaugeValue,
+	}
+	projectResourceQuota = typedDesc{
+		desc:      newDescWithLables("", "project_quota_resource", "The quota resource of a project, -1 means unlimited", "project_name", "resource"),
+		valueType: prometheus.GaugeValue,
+	}
 	projectRepoTotal = typedDesc{
 		desc:      newDescWithLables("", "project_repo_total", "Total project repos number", "project_name", "public"),
 		valueType: prometheus.GaugeValue,
@@ -97,6 +105,8 @@ func (hc *ProjectCollector) Describe(c chan<- *prometheus.Desc) {
 	c <- projectTotal.Desc()
 	c <- projectUsage.Desc()
 	c <- projectQuote.Desc()
+	c <- projectResourceUsage.Desc()
+	c <- projectResourceQuota.Desc()
 	c <- projectRepoTotal.Desc()
 	c <- projectMemberTotal.Desc()
 	c <- artifactPullTotal.Desc()
@@ -112,6 +122,12 @@ func (hc *ProjectCollector) Collect(c chan<- prometheus.Metric) {
 	for _, p := range overview.ProjectMap {
 		c <- projectUsage.MustNewConstMetric(getQuotaValue(p.Usage), p.Name)
 		c <- projectQuote.MustNewConstMetric(getQuotaValue(p.Quota), p.Name)
+		for resource, value := range getQuotaResources(p.Usage) {
+			c <- projectResourceUsage.MustNewConstMetric(value, p.Name, resource)
+		}
+		for resource, value := range getQuotaResources(p.Quota) {
+			c <- projectResourceQuota.MustNewConstMetric(value, p.Name, resource)
+		}
 		c <- projectMemberTotal.MustNewConstMetric(p.MemberTotal, p.Name)
 		c <- projectRepoTotal.MustNewConstMetric(p.RepoTotal, p.Name, getPublicValue(p.Public))
 		c <- artifactPullTotal.MustNewConstMetric(p.PullTotal, p.Name)
@@ -166,6 +182,15 @@ type quotaType struct {
 	Storage float64
 }
 
+// getQuotaResources returns the values of all the resources in the quota hard limits or usage
+func getQuotaResources(q string) map[string]float64 {
+	resources := map[string]float64{}
+	if err := json.Unmarshal([]byte(q), &resources); err != nil {
+		log.Warningf("failed to unmarshal data into quota resources, error: %v", err)
+	}
+	return resources
+}
+
 func getPublicValue(public bool) string {
 	if public {
 		return "true"
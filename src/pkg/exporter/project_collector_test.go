@@ -187,3 +187,14 @@ func (c *ProjectCollectorTestSuite) TestProjectCollector() {
 	c.Equalf(pMap[testPro3.ProjectID].Usage, "{}", "project without quota record should have empty usage")
 
 }
+
+func TestGetQuotaResources(t *testing.T) {
+	resources := getQuotaResources(`{"storage": 100, "artifact_count": -1, "repository_count": 10}`)
+	if len(resources) != 3 || resources["storage"] != 100 || resources["artifact_count"] != -1 || resources["repository_count"] != 10 {
+		t.Errorf("unexpected quota resources: %v", resources)
+	}
+
+	if resources := getQuotaResources("{}"); len(resources) != 0 {
+		t.Errorf("unexpected quota resources: %v", resources)
+	}
+}
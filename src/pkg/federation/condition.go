@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MatchConditions returns whether the claims match all the conditions, the value of the condition is the pattern
+// of the claim value in which "*" matches any characters, a claim of array matches if any of its elements matches
+func MatchConditions(conditions map[string]string, claims map[string]any) bool {
+	for name, pattern := range conditions {
+		value, ok := claims[name]
+		if !ok {
+			return false
+		}
+		re := patternRegexp(pattern)
+		matched := false
+		switch v := value.(type) {
+		case []any:
+			for _, e := range v {
+				if re.MatchString(claimString(e)) {
+					matched = true
+					break
+				}
+			}
+		default:
+			matched = re.MatchString(claimString(v))
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func claimString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+func patternRegexp(pattern string) *regexp.Regexp {
+	pieces := strings.Split(pattern, "*")
+	for i := range pieces {
+		pieces[i] = regexp.QuoteMeta(pieces[i])
+	}
+	return regexp.MustCompile("^" + strings.Join(pieces, ".*") + "$")
+}
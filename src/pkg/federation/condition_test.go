@@ -0,0 +1,48 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchConditions(t *testing.T) {
+	claims := map[string]any{
+		"repository": "org/app",
+		"ref":        "refs/heads/main",
+		"run_id":     float64(1024),
+		"groups":     []any{"dev", "ops"},
+	}
+
+	cases := []struct {
+		conditions map[string]string
+		expected   bool
+	}{
+		{map[string]string{"repository": "org/app", "ref": "refs/heads/main"}, true},
+		{map[string]string{"repository": "org/*", "ref": "refs/heads/*"}, true},
+		{map[string]string{"repository": "org/app", "ref": "refs/tags/*"}, false},
+		{map[string]string{"repository": "org.app"}, false},
+		{map[string]string{"run_id": "1024"}, true},
+		{map[string]string{"groups": "ops"}, true},
+		{map[string]string{"groups": "admin"}, false},
+		{map[string]string{"environment": "prod"}, false},
+		{map[string]string{}, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, MatchConditions(c.conditions, claims), "%v", c.conditions)
+	}
+}
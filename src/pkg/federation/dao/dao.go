@@ -0,0 +1,144 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+)
+
+// DAO defines the interface to access the federation trust data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, t *model.Trust) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, t *model.Trust, props ...string) error
+
+	// Get ...
+	Get(ctx context.Context, id int64) (*model.Trust, error)
+
+	// Count returns the total count of trusts according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.Trust, error)
+
+	// Delete ...
+	Delete(ctx context.Context, id int64) error
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, t *model.Trust) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.Encode(); err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(t)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "federation trust %s already exists", t.Name)
+	}
+	return id, nil
+}
+
+func (d *dao) Update(ctx context.Context, t *model.Trust, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := t.Encode(); err != nil {
+		return err
+	}
+	n, err := ormer.Update(t, props...)
+	if err != nil {
+		return orm.WrapConflictError(err, "federation trust %s already exists", t.Name)
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("federation trust %d not found", t.ID)
+	}
+	return nil
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.Trust, error) {
+	t := &model.Trust{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.Read(t); err != nil {
+		return nil, orm.WrapNotFoundError(err, "federation trust %d not found", id)
+	}
+	if err := t.Decode(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Trust{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Trust, error) {
+	trusts := []*model.Trust{}
+	qs, err := orm.QuerySetter(ctx, &model.Trust{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&trusts); err != nil {
+		return nil, err
+	}
+	for _, t := range trusts {
+		if err := t.Decode(); err != nil {
+			return nil, err
+		}
+	}
+	return trusts, nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.Trust{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("federation trust %d not found", id)
+	}
+	return nil
+}
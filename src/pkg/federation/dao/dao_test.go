@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type DaoTestSuite struct {
+	htesting.Suite
+	dao     DAO
+	trustID int64
+}
+
+func (suite *DaoTestSuite) SetupSuite() {
+	suite.Suite.SetupSuite()
+	suite.dao = New()
+}
+
+func (suite *DaoTestSuite) SetupTest() {
+	var err error
+	suite.trustID, err = suite.dao.Create(orm.Context(), &model.Trust{
+		Name:       "github-app",
+		Issuer:     "https://token.actions.githubusercontent.com",
+		Audience:   "harbor",
+		Conditions: map[string]string{"repository": "org/app", "ref": "refs/heads/main"},
+		Permissions: []*model.Permission{
+			{
+				Namespace: "library",
+				Access: []*types.Policy{
+					{Resource: "repository", Action: "push"},
+				},
+			},
+		},
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *DaoTestSuite) TearDownTest() {
+	err := suite.dao.Delete(orm.Context(), suite.trustID)
+	suite.True(err == nil || errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestCreate() {
+	_, err := suite.dao.Create(orm.Context(), &model.Trust{Name: "github-app", Issuer: "https://issuer"})
+	suite.Require().Error(err)
+	suite.True(errors.IsConflictErr(err))
+}
+
+func (suite *DaoTestSuite) TestGetAndUpdate() {
+	t, err := suite.dao.Get(orm.Context(), suite.trustID)
+	suite.Require().NoError(err)
+	suite.Equal("github-app", t.Name)
+	suite.Equal("refs/heads/main", t.Conditions["ref"])
+	suite.Require().Len(t.Permissions, 1)
+	suite.Equal("library", t.Permissions[0].Namespace)
+
+	t.Conditions["ref"] = "refs/tags/*"
+	t.Disabled = true
+	suite.Require().NoError(suite.dao.Update(orm.Context(), t, "conditions", "disabled"))
+	t, err = suite.dao.Get(orm.Context(), suite.trustID)
+	suite.Require().NoError(err)
+	suite.Equal("refs/tags/*", t.Conditions["ref"])
+	suite.True(t.Disabled)
+
+	_, err = suite.dao.Get(orm.Context(), 10000)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestListAndCount() {
+	query := q.New(q.KeyWords{"issuer": "https://token.actions.githubusercontent.com"})
+	n, err := suite.dao.Count(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+
+	trusts, err := suite.dao.List(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Require().Len(trusts, 1)
+	suite.Equal("org/app", trusts[0].Conditions["repository"])
+}
+
+func (suite *DaoTestSuite) TestDelete() {
+	suite.Require().NoError(suite.dao.Delete(orm.Context(), suite.trustID))
+	err := suite.dao.Delete(orm.Context(), suite.trustID)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &DaoTestSuite{})
+}
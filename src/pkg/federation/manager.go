@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/federation/dao"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+)
+
+var (
+	// Mgr is a global variable for the default federation trust manager implementation
+	Mgr = NewManager()
+)
+
+// Manager defines the interface to manage the trusts of the workload identity federation
+type Manager interface {
+	// Create creates the trust and returns its ID
+	Create(ctx context.Context, t *model.Trust) (int64, error)
+	// Update updates the trust
+	Update(ctx context.Context, t *model.Trust, props ...string) error
+	// Get gets the trust by ID
+	Get(ctx context.Context, id int64) (*model.Trust, error)
+	// Count returns the total count of trusts according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the trusts according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Trust, error)
+	// Delete deletes the trust by ID
+	Delete(ctx context.Context, id int64) error
+}
+
+// NewManager returns a default implementation of Manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, t *model.Trust) (int64, error) {
+	return m.dao.Create(ctx, t)
+}
+
+func (m *manager) Update(ctx context.Context, t *model.Trust, props ...string) error {
+	return m.dao.Update(ctx, t, props...)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Trust, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Trust, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
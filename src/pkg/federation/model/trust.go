@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+func init() {
+	orm.RegisterModel(&Trust{})
+}
+
+// Trust maps the JWTs issued by an external OIDC issuer and matching the claim conditions to the Harbor permissions,
+// it lets the workloads such as the CI pipelines authenticate with their short-lived identity tokens
+type Trust struct {
+	ID          int64  `orm:"pk;auto;column(id)" json:"id"`
+	Name        string `orm:"column(name)" json:"name" sort:"default"`
+	Description string `orm:"column(description)" json:"description"`
+	Issuer      string `orm:"column(issuer)" json:"issuer"`
+	Audience    string `orm:"column(audience)" json:"audience"`
+	// the claims of the token must match all the conditions, the key is the claim name and the value is the pattern
+	// of the claim value in which "*" matches any characters, e.g. {"repository": "org/app", "ref": "refs/heads/*"}
+	Conditions    map[string]string `orm:"-" json:"conditions"`
+	ConditionsStr string            `orm:"column(conditions)" json:"-"`
+	Permissions   []*Permission     `orm:"-" json:"permissions"`
+	// Use JSON data format
+	PermissionsStr string    `orm:"column(permissions)" json:"-"`
+	Disabled       bool      `orm:"column(disabled)" json:"disabled"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime     time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// Permission is the access granted to the federated workloads in the project,
+// the namespace is the project name and "*" means all the projects
+type Permission struct {
+	Namespace string          `json:"namespace"`
+	Access    []*types.Policy `json:"access"`
+}
+
+// TableName ...
+func (t *Trust) TableName() string {
+	return "federation_trust"
+}
+
+// Encode encodes the conditions and permissions to the JSON strings stored in the database
+func (t *Trust) Encode() error {
+	conditions, err := json.Marshal(t.Conditions)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the conditions")
+	}
+	t.ConditionsStr = string(conditions)
+
+	permissions, err := json.Marshal(t.Permissions)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the permissions")
+	}
+	t.PermissionsStr = string(permissions)
+	return nil
+}
+
+// Decode decodes the conditions and permissions from the JSON strings stored in the database
+func (t *Trust) Decode() error {
+	if len(t.ConditionsStr) > 0 {
+		if err := json.Unmarshal([]byte(t.ConditionsStr), &t.Conditions); err != nil {
+			return errors.Wrap(err, "failed to decode the conditions")
+		}
+	}
+	if len(t.PermissionsStr) > 0 {
+		if err := json.Unmarshal([]byte(t.PermissionsStr), &t.Permissions); err != nil {
+			return errors.Wrap(err, "failed to decode the permissions")
+		}
+	}
+	return nil
+}
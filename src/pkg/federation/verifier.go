@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// Verifier verifies the JWTs issued by the external OIDC issuers
+type Verifier interface {
+	// Verify verifies the signature, expiry and audience of the token against the JWKS of the issuer,
+	// the claims of the token are returned if it's valid
+	Verify(ctx context.Context, issuer, audience, rawToken string) (map[string]any, error)
+}
+
+// NewVerifier returns a verifier which discovers the JWKS of the issuers by the OIDC discovery
+func NewVerifier() Verifier {
+	return &verifier{
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(),
+			Timeout:   30 * time.Second,
+		},
+	}
+}
+
+type verifier struct {
+	client *http.Client
+	// the providers keyed by the issuer, the keys of the providers are cached and refreshed when the key ID is unknown
+	providers sync.Map
+}
+
+func (v *verifier) Verify(ctx context.Context, issuer, audience, rawToken string) (map[string]any, error) {
+	provider, err := v.provider(issuer)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := provider.Verifier(&gooidc.Config{ClientID: audience}).Verify(gooidc.ClientContext(ctx, v.client), rawToken)
+	if err != nil {
+		return nil, errors.UnauthorizedError(err).WithMessagef("failed to verify the token issued by %s: %v", issuer, err)
+	}
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the claims of the token")
+	}
+	return claims, nil
+}
+
+func (v *verifier) provider(issuer string) (*gooidc.Provider, error) {
+	if p, ok := v.providers.Load(issuer); ok {
+		return p.(*gooidc.Provider), nil
+	}
+	// the provider fetches the keys with the context, so the context of the request can't be used
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(context.Background(), v.client), issuer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover the OIDC issuer %s", issuer)
+	}
+	v.providers.Store(issuer, provider)
+	return provider, nil
+}
+
+// IssuerOf returns the issuer claimed by the token without verifying it,
+// it's only used to find the trusts against which the token is verified
+func IssuerOf(rawToken string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, claims); err != nil {
+		return "", err
+	}
+	return claims.GetIssuer()
+}
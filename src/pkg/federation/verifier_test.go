@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type verifierTestSuite struct {
+	suite.Suite
+	key    *rsa.PrivateKey
+	server *httptest.Server
+}
+
+func (v *verifierTestSuite) SetupSuite() {
+	var err error
+	v.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	v.Require().NoError(err)
+
+	mux := http.NewServeMux()
+	v.server = httptest.NewServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                v.server.URL,
+			"jwks_uri":                              v.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "key1",
+					"alg": "RS256",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(v.key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(v.key.E)).Bytes()),
+				},
+			},
+		})
+	})
+}
+
+func (v *verifierTestSuite) TearDownSuite() {
+	v.server.Close()
+}
+
+func (v *verifierTestSuite) token(audience string, expiresAt time.Time) string {
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":        v.server.URL,
+		"sub":        "repo:org/app:ref:refs/heads/main",
+		"aud":        audience,
+		"exp":        expiresAt.Unix(),
+		"iat":        time.Now().Unix(),
+		"repository": "org/app",
+	})
+	t.Header["kid"] = "key1"
+	s, err := t.SignedString(v.key)
+	v.Require().NoError(err)
+	return s
+}
+
+func (v *verifierTestSuite) TestVerify() {
+	verifier := NewVerifier()
+
+	claims, err := verifier.Verify(context.TODO(), v.server.URL, "harbor", v.token("harbor", time.Now().Add(time.Hour)))
+	v.Require().NoError(err)
+	v.Equal("org/app", claims["repository"])
+	v.Equal("repo:org/app:ref:refs/heads/main", claims["sub"])
+
+	// the audience mismatches
+	_, err = verifier.Verify(context.TODO(), v.server.URL, "harbor", v.token("other", time.Now().Add(time.Hour)))
+	v.Error(err)
+
+	// the token is expired
+	_, err = verifier.Verify(context.TODO(), v.server.URL, "harbor", v.token("harbor", time.Now().Add(-time.Hour)))
+	v.Error(err)
+
+	// the token is signed by another key
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	v.Require().NoError(err)
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iss": v.server.URL, "aud": "harbor", "exp": time.Now().Add(time.Hour).Unix()})
+	t.Header["kid"] = "key1"
+	forged, err := t.SignedString(key)
+	v.Require().NoError(err)
+	_, err = verifier.Verify(context.TODO(), v.server.URL, "harbor", forged)
+	v.Error(err)
+}
+
+func (v *verifierTestSuite) TestIssuerOf() {
+	issuer, err := IssuerOf(v.token("harbor", time.Now().Add(time.Hour)))
+	v.Require().NoError(err)
+	v.Equal(v.server.URL, issuer)
+
+	_, err = IssuerOf("not a jwt")
+	v.Error(err)
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, &verifierTestSuite{})
+}
@@ -38,8 +38,10 @@ type Worker struct {
 
 // Queue the job queue
 type Queue struct {
-	JobType string
-	Count   int64
-	Latency int64
-	Paused  bool
+	JobType        string
+	Count          int64
+	Latency        int64
+	Paused         bool
+	Priority       int64
+	MaxConcurrency int64
 }
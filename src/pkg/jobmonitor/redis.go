@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/log"
 	libRedis "github.com/goharbor/harbor/src/lib/redis"
 )
@@ -45,6 +46,11 @@ type RedisClient interface {
 	UnpauseJob(ctx context.Context, jobName string) error
 	// StopPendingJobs stop the pending jobs of the specified type, and remove the jobs from the waiting queue
 	StopPendingJobs(ctx context.Context, jobType string) (jobIDs []string, err error)
+	// JobPolicies returns the scheduling policies of the job types, key is the job type
+	JobPolicies(ctx context.Context) (map[string]*jobSvc.Policy, error)
+	// UpdateJobPolicy customizes the scheduling policy of the specified type job,
+	// the concurrency limit takes effect immediately and the priority takes effect once the worker pool reloads the policy
+	UpdateJobPolicy(ctx context.Context, jobType string, policy *jobSvc.Policy) error
 }
 
 type redisClientImpl struct {
@@ -71,19 +77,64 @@ func redisPool(config *config.RedisPoolConfig) (*redis.Pool, error) {
 func (r *redisClientImpl) StopPendingJobs(ctx context.Context, jobType string) (jobIDs []string, err error) {
 	jobIDs = []string{}
 	log.Infof("job queue cleaned up %s", jobType)
-	redisKeyJobQueue := fmt.Sprintf("{%s}:jobs:%v", r.namespace, jobType)
+	namespace := fmt.Sprintf("{%s}", r.namespace)
 	conn := r.redisPool.Get()
 	defer conn.Close()
+
+	// the jobs held by the job service to be dispatched fairly among the projects are pending as well
+	queues := []string{rds.KeyJobs(namespace, jobType)}
+	projects, err := redis.Strings(conn.Do("LRANGE", rds.KeyFairProjects(namespace, jobType), 0, -1))
+	if err != nil {
+		return []string{}, err
+	}
+	for _, project := range projects {
+		queues = append(queues, rds.KeyFairQueue(namespace, jobType, project))
+	}
+
+	var deleted int64
+	for _, queue := range queues {
+		ids, ret, err := r.clearQueue(conn, queue)
+		if err != nil {
+			return []string{}, err
+		}
+		jobIDs = append(jobIDs, ids...)
+		deleted += ret
+	}
+	if len(projects) > 0 {
+		if _, err := conn.Do("DEL", rds.KeyFairProjects(namespace, jobType)); err != nil {
+			return []string{}, err
+		}
+	}
+	if len(jobIDs) == 0 && deleted == 0 {
+		return []string{}, nil
+	}
+	log.Infof("updated %d tasks in pending status to stop", len(jobIDs))
+
+	go func() {
+		// the amount of jobIDs maybe large, so use goroutine to remove the job status tracking info
+		r.removeJobStatusInRedis(ctx, jobIDs)
+	}()
+	if deleted < 1 {
+		// no job in queue removed
+		return []string{}, fmt.Errorf("no job in the queue removed")
+	}
+	log.Infof("deleted %d keys in waiting queue for %s", deleted, jobType)
+	log.Debugf("job id to be deleted %v", jobIDs)
+	return jobIDs, nil
+}
+
+// clearQueue removes the queue and returns the IDs of the jobs in it
+func (r *redisClientImpl) clearQueue(conn redis.Conn, redisKeyJobQueue string) (jobIDs []string, deleted int64, err error) {
 	var jobInfo struct {
 		ID string `json:"id"`
 	}
 	size, err := redis.Int64(conn.Do("LLEN", redisKeyJobQueue))
 	if err != nil {
 		log.Infof("fail to get the size of the queue")
-		return []string{}, err
+		return nil, 0, err
 	}
 	if size == 0 {
-		return []string{}, nil
+		return nil, 0, nil
 	}
 
 	// use batch to list the job in queue, because the too many object load from a list might cause the redis crash
@@ -91,7 +142,7 @@ func (r *redisClientImpl) StopPendingJobs(ctx context.Context, jobType string) (
 		endIndex := min(startIndex+batchSize, int64(size))
 		jobs, err := redis.Strings(conn.Do("LRANGE", redisKeyJobQueue, startIndex, endIndex))
 		if err != nil {
-			return []string{}, err
+			return nil, 0, err
 		}
 		for _, j := range jobs {
 			if err := json.Unmarshal([]byte(j), &jobInfo); err != nil {
@@ -104,22 +155,11 @@ func (r *redisClientImpl) StopPendingJobs(ctx context.Context, jobType string) (
 		}
 	}
 
-	log.Infof("updated %d tasks in pending status to stop", len(jobIDs))
-	ret, err := redis.Int64(conn.Do("DEL", redisKeyJobQueue))
+	deleted, err = redis.Int64(conn.Do("DEL", redisKeyJobQueue))
 	if err != nil {
-		return []string{}, err
+		return nil, 0, err
 	}
-	go func() {
-		// the amount of jobIDs maybe large, so use goroutine to remove the job status tracking info
-		r.removeJobStatusInRedis(ctx, jobIDs)
-	}()
-	if ret < 1 {
-		// no job in queue removed
-		return []string{}, fmt.Errorf("no job in the queue removed")
-	}
-	log.Infof("deleted %d keys in waiting queue for %s", ret, jobType)
-	log.Debugf("job id to be deleted %v", jobIDs)
-	return jobIDs, nil
+	return jobIDs, deleted, nil
 }
 
 // removeJobStatusInRedis remove job status track information from redis, to avoid performance impact when the jobIDs is too large, use batch to remove
@@ -167,6 +207,44 @@ func (r *redisClientImpl) UnpauseJob(_ context.Context, jobName string) error {
 	return err
 }
 
+func (r *redisClientImpl) JobPolicies(_ context.Context) (map[string]*jobSvc.Policy, error) {
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	values, err := redis.StringMap(conn.Do("HGETALL", rds.KeyJobPolicies(fmt.Sprintf("{%s}", r.namespace))))
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]*jobSvc.Policy, len(values))
+	for jobType, value := range values {
+		policy := &jobSvc.Policy{}
+		if err := json.Unmarshal([]byte(value), policy); err != nil {
+			log.Warningf("failed to parse the policy of job type %s: %v", jobType, err)
+			continue
+		}
+		policies[jobType] = policy
+	}
+	return policies, nil
+}
+
+func (r *redisClientImpl) UpdateJobPolicy(_ context.Context, jobType string, policy *jobSvc.Policy) error {
+	log.Infof("update policy of job type %s, priority: %d, max concurrency: %d", jobType, policy.Priority, policy.MaxConcurrency)
+	namespace := fmt.Sprintf("{%s}", r.namespace)
+	p := *policy
+	p.Customized = true
+	data, err := json.Marshal(&p)
+	if err != nil {
+		return err
+	}
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("HSET", rds.KeyJobPolicies(namespace), jobType, data); err != nil {
+		return err
+	}
+	// the worker pool reads the concurrency limit from redis when fetching the job
+	_, err = conn.Do("SET", rds.KeyJobMaxConcurrency(namespace, jobType), p.MaxConcurrency)
+	return err
+}
+
 // JobServiceRedisClient function to create redis client for job service
 func JobServiceRedisClient() (RedisClient, error) {
 	cfg, err := job.GlobalClient.GetJobServiceConfig()
@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
 )
 
 type RedisClientTestSuite struct {
@@ -128,6 +129,47 @@ func (s *RedisClientTestSuite) TestStopPendingJobs() {
 	s.Assert().Equal(100, len(jobIDs))
 }
 
+func (s *RedisClientTestSuite) TestStopFairPendingJobs() {
+	namespace := fmt.Sprintf("{%s}", s.redisClient.namespace)
+	conn := s.redisClient.redisPool.Get()
+	defer conn.Close()
+	for _, project := range []string{"1", "2"} {
+		for range 5 {
+			val, err := json.Marshal(map[string]string{"id": utils.GenerateRandomStringWithLen(10)})
+			s.Require().Nil(err)
+			_, err = conn.Do("LPUSH", rds.KeyFairQueue(namespace, "RETENTION", project), val)
+			s.Require().Nil(err)
+		}
+		_, err := conn.Do("LPUSH", rds.KeyFairProjects(namespace, "RETENTION"), project)
+		s.Require().Nil(err)
+	}
+
+	jobIDs, err := s.redisClient.StopPendingJobs(context.Background(), "RETENTION")
+	s.Require().Nil(err)
+	s.Equal(10, len(jobIDs))
+	exists, err := redis.Int(conn.Do("EXISTS", rds.KeyFairProjects(namespace, "RETENTION"), rds.KeyFairQueue(namespace, "RETENTION", "1")))
+	s.Require().Nil(err)
+	s.Equal(0, exists)
+}
+
+func (s *RedisClientTestSuite) TestUpdateJobPolicy() {
+	err := s.redisClient.UpdateJobPolicy(context.Background(), "REPLICATION", &jobSvc.Policy{Priority: 2000, MaxConcurrency: 3})
+	s.Require().Nil(err)
+
+	policies, err := s.redisClient.JobPolicies(context.Background())
+	s.Require().Nil(err)
+	s.Require().NotNil(policies["REPLICATION"])
+	s.Equal(uint(2000), policies["REPLICATION"].Priority)
+	s.Equal(uint(3), policies["REPLICATION"].MaxConcurrency)
+	s.True(policies["REPLICATION"].Customized)
+
+	conn := s.redisClient.redisPool.Get()
+	defer conn.Close()
+	concurrency, err := redis.Int(conn.Do("GET", rds.KeyJobMaxConcurrency(fmt.Sprintf("{%s}", s.redisClient.namespace), "REPLICATION")))
+	s.Require().Nil(err)
+	s.Equal(3, concurrency)
+}
+
 func TestRedisClientTestSuite(t *testing.T) {
 	suite.Run(t, &RedisClientTestSuite{})
 }
@@ -0,0 +1,139 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/maintenance/model"
+)
+
+func init() {
+	orm.RegisterModel(
+		new(model.Window),
+	)
+}
+
+// DAO is the data access object for maintenance windows
+type DAO interface {
+	// Create the maintenance window
+	Create(ctx context.Context, window *model.Window) (int64, error)
+	// Get the maintenance window specified by ID
+	Get(ctx context.Context, id int64) (*model.Window, error)
+	// Update the maintenance window, only the specified properties are updated if any
+	Update(ctx context.Context, window *model.Window, props ...string) error
+	// Delete the maintenance window specified by ID
+	Delete(ctx context.Context, id int64) error
+	// Count the maintenance windows according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the maintenance windows according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Window, error)
+}
+
+// New creates an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, window *model.Window) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(window)
+	if err != nil {
+		if e := orm.AsConflictError(err, "maintenance window %s already exists", window.Name); e != nil {
+			err = e
+		}
+	}
+	return id, err
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.Window, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	window := &model.Window{
+		ID: id,
+	}
+	if err = ormer.Read(window); err != nil {
+		if e := orm.AsNotFoundError(err, "maintenance window %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return window, nil
+}
+
+func (d *dao) Update(ctx context.Context, window *model.Window, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(window, props...)
+	if err != nil {
+		if e := orm.AsConflictError(err, "maintenance window %s already exists", window.Name); e != nil {
+			err = e
+		}
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("maintenance window %d not found", window.ID)
+	}
+	return nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.Window{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("maintenance window %d not found", id)
+	}
+	return nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Window{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Window, error) {
+	windows := []*model.Window{}
+	qs, err := orm.QuerySetter(ctx, &model.Window{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,84 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/maintenance/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type DaoTestSuite struct {
+	htesting.Suite
+	dao DAO
+}
+
+func (s *DaoTestSuite) SetupSuite() {
+	s.Suite.SetupSuite()
+	s.Suite.ClearTables = []string{"maintenance_window"}
+	s.dao = New()
+}
+
+func (s *DaoTestSuite) TestCRUD() {
+	ctx := s.Context()
+	window := &model.Window{
+		Name:     "nightly",
+		JobTypes: "GARBAGE_COLLECTION,REPLICATION",
+		Cron:     "0 0 2 * * *",
+		Duration: 60,
+		Enabled:  true,
+	}
+	id, err := s.dao.Create(ctx, window)
+	s.Require().Nil(err)
+	s.True(id > 0)
+
+	// conflict
+	_, err = s.dao.Create(ctx, &model.Window{Name: "nightly", JobTypes: "all"})
+	s.Require().NotNil(err)
+	s.True(errors.IsConflictErr(err))
+
+	w, err := s.dao.Get(ctx, id)
+	s.Require().Nil(err)
+	s.Equal("nightly", w.Name)
+	s.Equal(60, w.Duration)
+
+	w.Enabled = false
+	w.EndTime = time.Now()
+	s.Require().Nil(s.dao.Update(ctx, w, "Enabled", "EndTime"))
+
+	total, err := s.dao.Count(ctx, q.New(q.KeyWords{"Enabled": false}))
+	s.Require().Nil(err)
+	s.Equal(int64(1), total)
+
+	windows, err := s.dao.List(ctx, q.New(q.KeyWords{"Name": "nightly"}))
+	s.Require().Nil(err)
+	s.Require().Len(windows, 1)
+	s.False(windows[0].Enabled)
+
+	s.Require().Nil(s.dao.Delete(ctx, id))
+	_, err = s.dao.Get(ctx, id)
+	s.True(errors.IsNotFoundErr(err))
+	s.True(errors.IsNotFoundErr(s.dao.Delete(ctx, id)))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &DaoTestSuite{})
+}
@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/maintenance/dao"
+	"github.com/goharbor/harbor/src/pkg/maintenance/model"
+)
+
+var (
+	// Mgr is the global maintenance window manager
+	Mgr = NewManager()
+)
+
+// Manager manages the maintenance windows
+type Manager interface {
+	// Create the maintenance window
+	Create(ctx context.Context, window *model.Window) (int64, error)
+	// Get the maintenance window specified by ID
+	Get(ctx context.Context, id int64) (*model.Window, error)
+	// Update the maintenance window
+	Update(ctx context.Context, window *model.Window) error
+	// Delete the maintenance window specified by ID
+	Delete(ctx context.Context, id int64) error
+	// Count the maintenance windows according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the maintenance windows according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Window, error)
+	// ActiveJobTypes returns the job types selected by the windows open at the specified time
+	ActiveJobTypes(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// NewManager creates an instance of the default maintenance window manager
+func NewManager() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, window *model.Window) (int64, error) {
+	if err := validate(window); err != nil {
+		return 0, err
+	}
+	return m.dao.Create(ctx, window)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Window, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Update(ctx context.Context, window *model.Window) error {
+	if err := validate(window); err != nil {
+		return err
+	}
+	return m.dao.Update(ctx, window, "Name", "Description", "JobTypes", "Cron",
+		"Duration", "StartTime", "EndTime", "Enabled", "UpdateTime")
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Window, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) ActiveJobTypes(ctx context.Context, now time.Time) ([]string, error) {
+	windows, err := m.dao.List(ctx, q.New(q.KeyWords{"Enabled": true}))
+	if err != nil {
+		return nil, err
+	}
+	var jobTypes []string
+	added := map[string]struct{}{}
+	for _, window := range windows {
+		if !window.IsActive(now) {
+			continue
+		}
+		for _, jobType := range window.ListJobTypes() {
+			if _, exist := added[jobType]; exist {
+				continue
+			}
+			added[jobType] = struct{}{}
+			jobTypes = append(jobTypes, jobType)
+		}
+	}
+	return jobTypes, nil
+}
+
+func validate(window *model.Window) error {
+	if len(window.Name) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the name of the maintenance window is required")
+	}
+	if len(window.ListJobTypes()) == 0 {
+		return errors.BadRequestError(nil).WithMessage("at least one job type must be selected by the maintenance window")
+	}
+	if window.IsRecurring() {
+		if err := utils.ValidateCronString(window.Cron); err != nil {
+			return errors.BadRequestError(err).WithMessagef("invalid cron %s: %v", window.Cron, err)
+		}
+		if window.Duration <= 0 {
+			return errors.BadRequestError(nil).WithMessage("the duration of the recurring maintenance window must be greater than 0")
+		}
+		return nil
+	}
+	if window.StartTime.IsZero() || !window.EndTime.After(window.StartTime) {
+		return errors.BadRequestError(nil).WithMessage("either the cron and the duration or the start time and the end time later than it must be specified")
+	}
+	return nil
+}
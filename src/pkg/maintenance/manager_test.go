@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/maintenance/model"
+	"github.com/goharbor/harbor/src/testing/pkg/maintenance/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *dao.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	// invalid windows
+	for _, window := range []*model.Window{
+		{JobTypes: "all", Cron: "0 0 2 * * *", Duration: 60},
+		{Name: "window", Cron: "0 0 2 * * *", Duration: 60},
+		{Name: "window", JobTypes: "all", Cron: "invalid", Duration: 60},
+		{Name: "window", JobTypes: "all", Cron: "0 0 2 * * *"},
+		{Name: "window", JobTypes: "all", StartTime: time.Now(), EndTime: time.Now().Add(-time.Hour)},
+	} {
+		_, err := m.mgr.Create(context.Background(), window)
+		m.Require().NotNil(err)
+		m.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := m.mgr.Create(context.Background(), &model.Window{
+		Name:     "window",
+		JobTypes: "all",
+		Cron:     "0 0 2 * * *",
+		Duration: 60,
+	})
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestActiveJobTypes() {
+	now := time.Now()
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Window{
+		{
+			Name:      "active",
+			JobTypes:  "GARBAGE_COLLECTION,REPLICATION",
+			StartTime: now.Add(-time.Hour),
+			EndTime:   now.Add(time.Hour),
+			Enabled:   true,
+		},
+		{
+			Name:      "another active",
+			JobTypes:  "REPLICATION,RETENTION",
+			StartTime: now.Add(-time.Hour),
+			EndTime:   now.Add(time.Hour),
+			Enabled:   true,
+		},
+		{
+			Name:      "closed",
+			JobTypes:  "all",
+			StartTime: now.Add(-2 * time.Hour),
+			EndTime:   now.Add(-time.Hour),
+			Enabled:   true,
+		},
+	}, nil)
+	jobTypes, err := m.mgr.ActiveJobTypes(context.Background(), now)
+	m.Require().Nil(err)
+	m.Equal([]string{"GARBAGE_COLLECTION", "REPLICATION", "RETENTION"}, jobTypes)
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManagerTestSuite(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
+// MaintenanceWindowTable is the name of table in DB that holds the maintenance windows
+const MaintenanceWindowTable = "maintenance_window"
+
+// JobTypeAll stands for all job types
+const JobTypeAll = "all"
+
+// Window is the maintenance window during which the selected job types are paused automatically.
+// A window is either recurring, which opens at the times matching the cron and lasts for the duration,
+// or one-off, which opens at the start time and closes at the end time
+type Window struct {
+	ID          int64  `orm:"pk;auto;column(id)" json:"id"`
+	Name        string `orm:"column(name)" json:"name"`
+	Description string `orm:"column(description)" json:"description"`
+	// the comma separated job types, "all" stands for all job types
+	JobTypes string `orm:"column(job_types)" json:"job_types"`
+	Cron     string `orm:"column(cron)" json:"cron"`
+	// the duration of the recurring window in minutes
+	Duration     int       `orm:"column(duration)" json:"duration"`
+	StartTime    time.Time `orm:"column(start_time);type(datetime)" json:"start_time"`
+	EndTime      time.Time `orm:"column(end_time);type(datetime)" json:"end_time"`
+	Enabled      bool      `orm:"column(enabled)" json:"enabled"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (w *Window) TableName() string {
+	return MaintenanceWindowTable
+}
+
+// ListJobTypes returns the job types selected by the window
+func (w *Window) ListJobTypes() []string {
+	var jobTypes []string
+	for _, jobType := range strings.Split(w.JobTypes, ",") {
+		jobType = strings.TrimSpace(jobType)
+		if len(jobType) == 0 {
+			continue
+		}
+		if strings.EqualFold(jobType, JobTypeAll) {
+			jobType = JobTypeAll
+		} else {
+			jobType = strings.ToUpper(jobType)
+		}
+		jobTypes = append(jobTypes, jobType)
+	}
+	return jobTypes
+}
+
+// SetJobTypes sets the job types selected by the window
+func (w *Window) SetJobTypes(jobTypes []string) {
+	w.JobTypes = strings.Join(jobTypes, ",")
+}
+
+// IsRecurring returns whether the window is recurring
+func (w *Window) IsRecurring() bool {
+	return len(w.Cron) > 0
+}
+
+// IsActive returns whether the window is open at the specified time
+func (w *Window) IsActive(now time.Time) bool {
+	if !w.Enabled {
+		return false
+	}
+	if !w.IsRecurring() {
+		return !w.StartTime.IsZero() && !now.Before(w.StartTime) && now.Before(w.EndTime)
+	}
+	schedule, err := utils.CronParser().Parse(w.Cron)
+	if err != nil || w.Duration <= 0 {
+		return false
+	}
+	// the window is open if it was opened within the last duration
+	opened := schedule.Next(now.Add(-time.Duration(w.Duration) * time.Minute))
+	return !opened.After(now)
+}
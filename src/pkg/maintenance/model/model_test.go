@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListJobTypes(t *testing.T) {
+	window := &Window{JobTypes: " gc, ALL ,,REPLICATION"}
+	assert.Equal(t, []string{"GC", JobTypeAll, "REPLICATION"}, window.ListJobTypes())
+
+	window.SetJobTypes([]string{"GARBAGE_COLLECTION", "RETENTION"})
+	assert.Equal(t, "GARBAGE_COLLECTION,RETENTION", window.JobTypes)
+}
+
+func TestIsActive(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 30, 0, 0, time.Local)
+
+	// disabled
+	window := &Window{Cron: "0 0 2 * * *", Duration: 60}
+	assert.False(t, window.IsActive(now))
+
+	// recurring window opened within the duration
+	window.Enabled = true
+	assert.True(t, window.IsActive(now))
+
+	// recurring window closed
+	window.Duration = 20
+	assert.False(t, window.IsActive(now))
+
+	// invalid cron
+	window.Cron = "invalid"
+	assert.False(t, window.IsActive(now))
+
+	// one-off window
+	window = &Window{
+		Enabled:   true,
+		StartTime: now.Add(-time.Hour),
+		EndTime:   now.Add(time.Hour),
+	}
+	assert.True(t, window.IsActive(now))
+	assert.False(t, window.IsActive(now.Add(2*time.Hour)))
+	assert.False(t, window.IsActive(now.Add(-2*time.Hour)))
+}
@@ -43,6 +43,7 @@ type EventData struct {
 	Replication *model.Replication `json:"replication,omitempty"`
 	Retention   *model.Retention   `json:"retention,omitempty"`
 	Scan        *model.Scan        `json:"scan,omitempty"`
+	Robot       *model.Robot       `json:"robot,omitempty"`
 	Custom      map[string]string  `json:"custom_attributes,omitempty"`
 }
 
@@ -76,7 +76,13 @@ func startRegexpStorePurging(s *regexpStore, intervalDuration time.Duration) {
 func keyMatch2Build(key2 string) *regexp.Regexp {
 	re := regexp.MustCompile(`(.*):[^/]+(.*)`)
 
-	key2 = strings.Replace(key2, "/*", "/.*", -1)
+	// the "*" matches any characters, it's used as the wildcard of the repository name patterns too,
+	// e.g. /project/1/repository/team-a/*, so the other characters are quoted
+	pieces := strings.Split(key2, "*")
+	for i := range pieces {
+		pieces[i] = regexp.QuoteMeta(pieces[i])
+	}
+	key2 = strings.Join(pieces, ".*")
 	for {
 		if !strings.Contains(key2, "/:") {
 			break
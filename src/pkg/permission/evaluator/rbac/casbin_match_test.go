@@ -50,6 +50,31 @@ func Test_keyMatch2(t *testing.T) {
 			args: args{"/project/1/robot", "/project/:pid/robot"},
 			want: true,
 		},
+		{
+			name: "match /project/1/repository/team-a/service-x, /project/1/repository/team-a/*",
+			args: args{"/project/1/repository/team-a/service-x", "/project/1/repository/team-a/*"},
+			want: true,
+		},
+		{
+			name: "match /project/1/repository/team-b/service-x, /project/1/repository/team-a/*",
+			args: args{"/project/1/repository/team-b/service-x", "/project/1/repository/team-a/*"},
+			want: false,
+		},
+		{
+			name: "match /project/1/repository/team-a-x, /project/1/repository/team-a*",
+			args: args{"/project/1/repository/team-a-x", "/project/1/repository/team-a*"},
+			want: true,
+		},
+		{
+			name: "match /project/1/repository/team-ab, /project/1/repository/team.a*",
+			args: args{"/project/1/repository/team-ab", "/project/1/repository/team.a*"},
+			want: false,
+		},
+		{
+			name: "match /project/1/repository/app, /project/*/repository/app",
+			args: args{"/project/1/repository/app", "/project/*/repository/app"},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -21,6 +21,7 @@ const (
 	ProMetaEnableContentTrustCosign  = "enable_content_trust_cosign"
 	ProMetaPreventVul                = "prevent_vul" // prevent vulnerable images from being pulled
 	ProMetaSeverity                  = "severity"
+	ProMetaScannerGate               = "scanner_gate" // how the results of the scanners are combined for prevent_vul
 	ProMetaAutoScan                  = "auto_scan"
 	ProMetaReuseSysCVEAllowlist      = "reuse_sys_cve_allowlist"
 	ProMetaAutoSBOMGen               = "auto_sbom_generation"
@@ -30,4 +31,15 @@ const (
 	ProMetaProxyCacheFilterKind      = "proxy_cache_filter_kind"    // "doublestar" (default) or "regex"
 	ProMetaProxyReferrerAPI          = "proxy_referrer_api"
 	ProMetaProxyCacheLocalOnNotFound = "proxy_cache_local_on_not_found"
+	ProMetaQuotaWarningThresholds    = "quota_warning_thresholds" // comma separated usage percents, eg "75,90,95"
+	ProMetaQuotaOveragePercent       = "quota_overage_percent"    // percent of the hard limits allowed beyond them
+	ProMetaQuotaGracePeriodDays      = "quota_grace_period_days"  // days during which the usage can exceed the hard limits
+)
+
+// values of the scanner gate
+const (
+	// ScannerGateAny the vulnerabilities found by any scanner bound to the project are counted
+	ScannerGateAny = "any"
+	// ScannerGateAll only the vulnerabilities found by all the scanners bound to the project are counted
+	ScannerGateAll = "all"
 )
@@ -139,6 +139,15 @@ func (p *Project) Severity() string {
 	return severity
 }
 
+// ScannerGate returns how the results of the scanners are combined for prevent_vul, "any" by default
+func (p *Project) ScannerGate() string {
+	gate, exist := p.GetMetadata(ProMetaScannerGate)
+	if !exist || gate != ScannerGateAll {
+		return ScannerGateAny
+	}
+	return gate
+}
+
 // AutoScan ...
 func (p *Project) AutoScan() bool {
 	auto, exist := p.GetMetadata(ProMetaAutoScan)
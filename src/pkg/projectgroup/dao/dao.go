@@ -0,0 +1,199 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+)
+
+// DAO defines the interface to access the project group data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, g *model.ProjectGroup) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, g *model.ProjectGroup, props ...string) error
+
+	// Get ...
+	Get(ctx context.Context, id int64) (*model.ProjectGroup, error)
+
+	// Count returns the total count of groups according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error)
+
+	// Delete deletes the group and its memberships
+	Delete(ctx context.Context, id int64) error
+
+	// AddProject adds the project into the group
+	AddProject(ctx context.Context, groupID, projectID int64) error
+
+	// RemoveProject removes the project from the group
+	RemoveProject(ctx context.Context, groupID, projectID int64) error
+
+	// ListProjects returns the IDs of the projects in the group
+	ListProjects(ctx context.Context, groupID int64) ([]int64, error)
+
+	// GetByProject returns the group which the project belongs to
+	GetByProject(ctx context.Context, projectID int64) (*model.ProjectGroup, error)
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, g *model.ProjectGroup) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(g)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "project group %s already exists", g.Name)
+	}
+	return id, nil
+}
+
+func (d *dao) Update(ctx context.Context, g *model.ProjectGroup, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(g, props...)
+	if err != nil {
+		return orm.WrapConflictError(err, "project group %s already exists", g.Name)
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("project group %d not found", g.ID)
+	}
+	return nil
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.ProjectGroup, error) {
+	g := &model.ProjectGroup{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.Read(g); err != nil {
+		return nil, orm.WrapNotFoundError(err, "project group %d not found", id)
+	}
+	return g, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.ProjectGroup{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error) {
+	groups := []*model.ProjectGroup{}
+	qs, err := orm.QuerySetter(ctx, &model.ProjectGroup{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := ormer.QueryTable(&model.Member{}).Filter("group_id", id).Delete(); err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.ProjectGroup{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("project group %d not found", id)
+	}
+	return nil
+}
+
+func (d *dao) AddProject(ctx context.Context, groupID, projectID int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := ormer.Insert(&model.Member{GroupID: groupID, ProjectID: projectID}); err != nil {
+		return orm.WrapConflictError(err, "project %d already belongs to a project group", projectID)
+	}
+	return nil
+}
+
+func (d *dao) RemoveProject(ctx context.Context, groupID, projectID int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.QueryTable(&model.Member{}).Filter("group_id", groupID).Filter("project_id", projectID).Delete()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("project %d not found in project group %d", projectID, groupID)
+	}
+	return nil
+}
+
+func (d *dao) ListProjects(ctx context.Context, groupID int64) ([]int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members := []*model.Member{}
+	if _, err := ormer.QueryTable(&model.Member{}).Filter("group_id", groupID).OrderBy("project_id").All(&members); err != nil {
+		return nil, err
+	}
+	var projectIDs []int64
+	for _, m := range members {
+		projectIDs = append(projectIDs, m.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+func (d *dao) GetByProject(ctx context.Context, projectID int64) (*model.ProjectGroup, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &model.Member{ProjectID: projectID}
+	if err := ormer.Read(m, "project_id"); err != nil {
+		return nil, orm.WrapNotFoundError(err, "project %d doesn't belong to any project group", projectID)
+	}
+	return d.Get(ctx, m.GroupID)
+}
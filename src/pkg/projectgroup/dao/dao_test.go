@@ -0,0 +1,120 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type DaoTestSuite struct {
+	htesting.Suite
+	dao     DAO
+	groupID int64
+}
+
+func (suite *DaoTestSuite) SetupSuite() {
+	suite.Suite.SetupSuite()
+	suite.dao = New()
+}
+
+func (suite *DaoTestSuite) SetupTest() {
+	var err error
+	suite.groupID, err = suite.dao.Create(orm.Context(), &model.ProjectGroup{
+		Name:        "engineering",
+		Description: "projects of the engineering department",
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *DaoTestSuite) TearDownTest() {
+	err := suite.dao.Delete(orm.Context(), suite.groupID)
+	suite.True(err == nil || errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestCreate() {
+	_, err := suite.dao.Create(orm.Context(), &model.ProjectGroup{Name: "engineering"})
+	suite.Require().Error(err)
+	suite.True(errors.IsConflictErr(err))
+}
+
+func (suite *DaoTestSuite) TestGetAndUpdate() {
+	g, err := suite.dao.Get(orm.Context(), suite.groupID)
+	suite.Require().NoError(err)
+	suite.Equal("engineering", g.Name)
+
+	g.Description = "updated"
+	suite.Require().NoError(suite.dao.Update(orm.Context(), g, "description"))
+	g, err = suite.dao.Get(orm.Context(), suite.groupID)
+	suite.Require().NoError(err)
+	suite.Equal("updated", g.Description)
+
+	_, err = suite.dao.Get(orm.Context(), 10000)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestListAndCount() {
+	query := q.New(q.KeyWords{"name": "engineering"})
+	n, err := suite.dao.Count(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+
+	groups, err := suite.dao.List(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Require().Len(groups, 1)
+	suite.Equal(suite.groupID, groups[0].ID)
+}
+
+func (suite *DaoTestSuite) TestProjects() {
+	suite.Require().NoError(suite.dao.AddProject(orm.Context(), suite.groupID, 1))
+	err := suite.dao.AddProject(orm.Context(), suite.groupID, 1)
+	suite.True(errors.IsConflictErr(err))
+
+	projectIDs, err := suite.dao.ListProjects(orm.Context(), suite.groupID)
+	suite.Require().NoError(err)
+	suite.Equal([]int64{1}, projectIDs)
+
+	g, err := suite.dao.GetByProject(orm.Context(), 1)
+	suite.Require().NoError(err)
+	suite.Equal(suite.groupID, g.ID)
+
+	suite.Require().NoError(suite.dao.RemoveProject(orm.Context(), suite.groupID, 1))
+	err = suite.dao.RemoveProject(orm.Context(), suite.groupID, 1)
+	suite.True(errors.IsNotFoundErr(err))
+
+	_, err = suite.dao.GetByProject(orm.Context(), 1)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestDelete() {
+	suite.Require().NoError(suite.dao.AddProject(orm.Context(), suite.groupID, 1))
+	suite.Require().NoError(suite.dao.Delete(orm.Context(), suite.groupID))
+	err := suite.dao.Delete(orm.Context(), suite.groupID)
+	suite.True(errors.IsNotFoundErr(err))
+
+	_, err = suite.dao.GetByProject(orm.Context(), 1)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &DaoTestSuite{})
+}
@@ -0,0 +1,103 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectgroup
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/dao"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+)
+
+var (
+	// Mgr is a global variable for the default project group manager implementation
+	Mgr = NewManager()
+)
+
+// Manager defines the interface to manage the project groups
+type Manager interface {
+	// Create creates the group and returns its ID
+	Create(ctx context.Context, g *model.ProjectGroup) (int64, error)
+	// Update updates the group
+	Update(ctx context.Context, g *model.ProjectGroup, props ...string) error
+	// Get gets the group by ID
+	Get(ctx context.Context, id int64) (*model.ProjectGroup, error)
+	// Count returns the total count of groups according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the groups according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error)
+	// Delete deletes the group by ID, the member projects are released from the group
+	Delete(ctx context.Context, id int64) error
+	// AddProject adds the project into the group
+	AddProject(ctx context.Context, groupID, projectID int64) error
+	// RemoveProject removes the project from the group
+	RemoveProject(ctx context.Context, groupID, projectID int64) error
+	// ListProjects returns the IDs of the projects in the group
+	ListProjects(ctx context.Context, groupID int64) ([]int64, error)
+	// GetByProject returns the group which the project belongs to
+	GetByProject(ctx context.Context, projectID int64) (*model.ProjectGroup, error)
+}
+
+// NewManager returns a default implementation of Manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, g *model.ProjectGroup) (int64, error) {
+	return m.dao.Create(ctx, g)
+}
+
+func (m *manager) Update(ctx context.Context, g *model.ProjectGroup, props ...string) error {
+	return m.dao.Update(ctx, g, props...)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.ProjectGroup, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.ProjectGroup, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) AddProject(ctx context.Context, groupID, projectID int64) error {
+	return m.dao.AddProject(ctx, groupID, projectID)
+}
+
+func (m *manager) RemoveProject(ctx context.Context, groupID, projectID int64) error {
+	return m.dao.RemoveProject(ctx, groupID, projectID)
+}
+
+func (m *manager) ListProjects(ctx context.Context, groupID int64) ([]int64, error) {
+	return m.dao.ListProjects(ctx, groupID)
+}
+
+func (m *manager) GetByProject(ctx context.Context, projectID int64) (*model.ProjectGroup, error) {
+	return m.dao.GetByProject(ctx, projectID)
+}
@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&ProjectGroup{}, &Member{})
+}
+
+// QuotaReference the reference of the quota of the project group
+const QuotaReference = "project_group"
+
+// ProjectGroup is a group of projects sharing one quota, eg the projects owned by a business unit
+type ProjectGroup struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	Name         string    `orm:"column(name)" json:"name" sort:"default"`
+	Description  string    `orm:"column(description)" json:"description"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (g *ProjectGroup) TableName() string {
+	return "project_group"
+}
+
+// Member is the membership of the project in the group, a project belongs to one group at most
+type Member struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	GroupID      int64     `orm:"column(group_id)" json:"group_id"`
+	ProjectID    int64     `orm:"column(project_id)" json:"project_id"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (m *Member) TableName() string {
+	return "project_group_member"
+}
@@ -49,6 +49,12 @@ type DAO interface {
 
 	// List list quotas
 	List(ctx context.Context, query *q.Query) ([]*models.Quota, error)
+
+	// GetState returns the state of the quota, a new state is returned when no state tracked for the quota
+	GetState(ctx context.Context, quotaID int64) (*models.State, error)
+
+	// SaveState creates or updates the state of the quota
+	SaveState(ctx context.Context, state *models.State) error
 }
 
 // New returns an instance of the default DAO
@@ -129,6 +135,10 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
+	if _, err := o.Raw("DELETE FROM quota_state WHERE quota_id = ?", id).Exec(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -274,6 +284,57 @@ FROM
 	return quotas, nil
 }
 
+func (d *dao) GetState(ctx context.Context, quotaID int64) (*models.State, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &models.State{QuotaID: quotaID}
+	if err := o.Read(state, "quota_id"); err != nil {
+		if errors.Is(err, orm.ErrNoRows) {
+			return &models.State{QuotaID: quotaID}, nil
+		}
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (d *dao) SaveState(ctx context.Context, state *models.State) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state.WarningLevels == "" {
+		state.SetWarningLevels(nil)
+	}
+
+	sql := `
+INSERT INTO quota_state (quota_id, warning_levels, overage_start_time, grace_end_time, overage_notify_time, creation_time, update_time)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (quota_id) DO UPDATE SET warning_levels      = EXCLUDED.warning_levels,
+                                     overage_start_time  = EXCLUDED.overage_start_time,
+                                     grace_end_time      = EXCLUDED.grace_end_time,
+                                     overage_notify_time = EXCLUDED.overage_notify_time,
+                                     update_time         = EXCLUDED.update_time`
+
+	now := time.Now()
+	_, err = o.Raw(sql, state.QuotaID, state.WarningLevels,
+		nullTime(state.OverageStartTime), nullTime(state.GraceEndTime), nullTime(state.OverageNotifyTime), now, now).Exec()
+
+	return err
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
+
 func toQuota(quota *Quota, usage *QuotaUsage) *models.Quota {
 	return &models.Quota{
 		ID:           quota.ID,
@@ -16,6 +16,7 @@ package dao
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
@@ -35,6 +36,7 @@ func (suite *DaoTestSuite) SetupSuite() {
 	suite.Suite.ClearSQLs = []string{
 		"DELETE FROM quota WHERE id > 1",
 		"DELETE FROM quota_usage WHERE id > 1",
+		"DELETE FROM quota_state",
 	}
 	suite.dao = New()
 }
@@ -228,6 +230,54 @@ func (suite *DaoTestSuite) TestList() {
 
 }
 
+func (suite *DaoTestSuite) TestState() {
+	hardLimits := types.ResourceList{types.ResourceStorage: 100}
+	usage := types.ResourceList{types.ResourceStorage: 0}
+
+	ctx := suite.Context()
+	id, err := suite.dao.Create(ctx, "project", "5", hardLimits, usage)
+	suite.Nil(err)
+
+	{
+		// state not tracked yet
+		state, err := suite.dao.GetState(ctx, id)
+		if suite.Nil(err) {
+			suite.Equal(id, state.QuotaID)
+			suite.False(state.InOverage())
+		}
+	}
+
+	{
+		// create the state
+		state, _ := suite.dao.GetState(ctx, id)
+		state.SetWarningLevels(map[types.ResourceName]int{types.ResourceStorage: 90})
+		state.OverageStartTime = time.Now()
+		suite.Nil(suite.dao.SaveState(ctx, state))
+
+		state, err := suite.dao.GetState(ctx, id)
+		if suite.Nil(err) {
+			levels, _ := state.GetWarningLevels()
+			suite.Equal(90, levels[types.ResourceStorage])
+			suite.True(state.InOverage())
+			suite.True(state.GraceEndTime.IsZero())
+		}
+	}
+
+	{
+		// update the state
+		state, _ := suite.dao.GetState(ctx, id)
+		state.OverageStartTime = time.Time{}
+		suite.Nil(suite.dao.SaveState(ctx, state))
+
+		state, err := suite.dao.GetState(ctx, id)
+		if suite.Nil(err) {
+			suite.False(state.InOverage())
+		}
+	}
+
+	suite.Nil(suite.dao.Delete(ctx, id))
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &DaoTestSuite{})
 }
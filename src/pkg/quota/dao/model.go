@@ -18,11 +18,14 @@ import (
 	"time"
 
 	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/pkg/quota/models"
 )
 
 func init() {
 	orm.RegisterModel(&Quota{})
 	orm.RegisterModel(&QuotaUsage{})
+	orm.RegisterModel(&models.State{})
 }
 
 // Quota model for quota
@@ -17,6 +17,7 @@ package driver // nolint:revive
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
@@ -43,6 +44,34 @@ type Driver interface {
 	CalculateUsage(ctx context.Context, key string) (types.ResourceList, error)
 }
 
+// Policy the warning and overage policy of the quota for the ref object
+type Policy struct {
+	// WarningThresholds the usage percents in ascending order, a warning is notified once the usage crosses each of them
+	WarningThresholds []int
+	// OveragePercent the percent of the hard limits which the usage is allowed to exceed, 0 means no cap in the grace period
+	OveragePercent int
+	// GracePeriod the period since the usage exceeds the hard limits during which the overage is allowed, 0 means no time bound
+	GracePeriod time.Duration
+}
+
+// AllowOverage returns true when the usage is allowed to exceed the hard limits
+func (p *Policy) AllowOverage() bool {
+	return p != nil && (p.OveragePercent > 0 || p.GracePeriod > 0)
+}
+
+// PolicyProvider is implemented by the drivers which support the per ref object quota policy
+type PolicyProvider interface {
+	// Policy returns the quota policy of the ref object, nil returned when no policy configured
+	Policy(ctx context.Context, key string) (*Policy, error)
+}
+
+// ParentProvider is implemented by the drivers whose ref objects may share the quota of a parent ref object,
+// the usage of the ref object is also charged to the quota of the parent
+type ParentProvider interface {
+	// Parent returns the reference and the reference id of the parent, empty reference returned when no parent
+	Parent(ctx context.Context, key string) (reference, referenceID string, err error)
+}
+
 // Register register quota driver
 func Register(name string, driver Driver) {
 	driversMu.Lock()
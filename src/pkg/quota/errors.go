@@ -17,6 +17,7 @@ package quota
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
@@ -89,6 +90,11 @@ type ResourceOverflow struct {
 
 func (e *ResourceOverflow) Error() string {
 	resource := e.Resource
+	if resource.IsPerArtifact() {
+		return fmt.Sprintf("%s of %s resource for the artifact will exceed the configured upper limit of %s.",
+			resource.FormatValue(e.NewUsed), resource, resource.FormatValue(e.HardLimit))
+	}
+
 	var (
 		op    string
 		delta int64
@@ -125,3 +131,17 @@ func (e *ResourceNotFound) Error() string {
 func NewResourceNotFoundError(resource types.ResourceName) error {
 	return &ResourceNotFound{Resource: resource}
 }
+
+// GracePeriodExpired ...
+type GracePeriodExpired struct {
+	EndTime time.Time
+}
+
+func (e *GracePeriodExpired) Error() string {
+	return fmt.Sprintf("the grace period for exceeding the quota ended at %s", e.EndTime.UTC().Format(time.RFC3339))
+}
+
+// NewGracePeriodExpiredError ...
+func NewGracePeriodExpiredError(endTime time.Time) error {
+	return &GracePeriodExpired{EndTime: endTime}
+}
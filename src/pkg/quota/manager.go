@@ -27,6 +27,9 @@ import (
 // Quota alias `models.Quota` to make it natural to use the Manager
 type Quota = models.Quota
 
+// State alias `models.State` to make it natural to use the Manager
+type State = models.State
+
 // Manager interface provide the management functions for quotas
 type Manager interface {
 	// Create create quota for the reference object
@@ -49,6 +52,12 @@ type Manager interface {
 
 	// List list quotas
 	List(ctx context.Context, query *q.Query) ([]*Quota, error)
+
+	// GetState returns the notification and overage state tracked for the quota
+	GetState(ctx context.Context, quotaID int64) (*State, error)
+
+	// SaveState saves the notification and overage state of the quota
+	SaveState(ctx context.Context, state *State) error
 }
 
 var (
@@ -107,6 +116,14 @@ func (m *manager) List(ctx context.Context, query *q.Query) ([]*Quota, error) {
 	return m.dao.List(ctx, query)
 }
 
+func (m *manager) GetState(ctx context.Context, quotaID int64) (*State, error) {
+	return m.dao.GetState(ctx, quotaID)
+}
+
+func (m *manager) SaveState(ctx context.Context, state *State) error {
+	return m.dao.SaveState(ctx, state)
+}
+
 // NewManager returns quota manager
 func NewManager() Manager {
 	return &manager{dao: dao.New()}
@@ -39,6 +39,8 @@ type Quota struct {
 
 	HardChanged bool `orm:"-" json:"-"`
 	UsedChanged bool `orm:"-" json:"-"`
+
+	State *State `orm:"-" json:"-"`
 }
 
 // MarshalJSON ...
@@ -109,8 +111,16 @@ func (q *Quota) GetWarningResources(warningPercent int) ([]types.ResourceName, e
 
 	var resources []types.ResourceName
 	for resource, used := range usage {
+		// warning on the per artifact resource is meaningless as it limits every single artifact
+		if resource.IsPerArtifact() {
+			continue
+		}
+
 		limited, ok := hardLimits[resource]
 		if !ok {
+			if resource.IsOptional() {
+				continue
+			}
 			return nil, fmt.Errorf("resource %s not found in hard limits", resource)
 		}
 
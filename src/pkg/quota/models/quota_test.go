@@ -33,4 +33,11 @@ func TestGetWarningResources(t *testing.T) {
 	resources, err := q.GetWarningResources(85)
 	assert.Nil(err)
 	assert.Len(resources, 1)
+
+	q.SetHard(types.ResourceList{types.ResourceStorage: 300, types.ResourceArtifactCount: 100, types.ResourceArtifactSize: 100})
+	q.SetUsed(types.ResourceList{types.ResourceStorage: 10, types.ResourceArtifactCount: 90, types.ResourceArtifactSize: 100, types.ResourceRepositoryCount: 5})
+
+	resources, err = q.GetWarningResources(85)
+	assert.Nil(err)
+	assert.Equal([]types.ResourceName{types.ResourceArtifactCount}, resources)
 }
@@ -0,0 +1,74 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+// State the notification and overage state tracked for the quota
+type State struct {
+	ID      int64 `orm:"pk;auto;column(id)" json:"id"`
+	QuotaID int64 `orm:"column(quota_id)" json:"quota_id"`
+	// WarningLevels the highest warning threshold crossed by every resource
+	WarningLevels string `orm:"column(warning_levels);type(jsonb)" json:"-"`
+	// OverageStartTime the time when the usage exceeded the hard limits, zero when the usage is under the hard limits
+	OverageStartTime time.Time `orm:"column(overage_start_time);null" json:"overage_start_time"`
+	// GraceEndTime the end of the grace period started at the OverageStartTime, zero when no grace period
+	GraceEndTime time.Time `orm:"column(grace_end_time);null" json:"grace_end_time"`
+	// OverageNotifyTime the last time the overage was notified
+	OverageNotifyTime time.Time `orm:"column(overage_notify_time);null" json:"overage_notify_time"`
+	CreationTime      time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime        time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName returns table name for orm
+func (s *State) TableName() string {
+	return "quota_state"
+}
+
+// GetWarningLevels returns the warning levels of the resources
+func (s *State) GetWarningLevels() (map[types.ResourceName]int, error) {
+	levels := map[types.ResourceName]int{}
+	if s.WarningLevels == "" {
+		return levels, nil
+	}
+
+	if err := json.Unmarshal([]byte(s.WarningLevels), &levels); err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+// SetWarningLevels set the warning levels of the resources
+func (s *State) SetWarningLevels(levels map[types.ResourceName]int) *State {
+	if levels == nil {
+		levels = map[types.ResourceName]int{}
+	}
+
+	data, _ := json.Marshal(levels)
+	s.WarningLevels = string(data)
+
+	return s
+}
+
+// InOverage returns true when the usage is exceeding the hard limits
+func (s *State) InOverage() bool {
+	return !s.OverageStartTime.IsZero()
+}
@@ -0,0 +1,256 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+const (
+	// overageNotifyInterval the interval to notify the owners when the usage keeps exceeding the hard limits
+	overageNotifyInterval = 24 * time.Hour
+)
+
+// NoticeKind the kind of the quota notice
+type NoticeKind string
+
+const (
+	// NoticeWarning the usage crossed a warning threshold
+	NoticeWarning NoticeKind = "warning"
+	// NoticeOverage the usage exceeds the hard limits
+	NoticeOverage NoticeKind = "overage"
+)
+
+// Notice the notice about the quota usage which should be sent to the owners
+type Notice struct {
+	Kind      NoticeKind
+	Resources []types.ResourceName
+	Message   string
+}
+
+// ParseWarningThresholds parses the comma separated warning thresholds, eg "75,90,95",
+// the thresholds are returned in ascending order without duplicates
+func ParseWarningThresholds(s string) ([]int, error) {
+	seen := map[int]bool{}
+	var thresholds []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		threshold, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warning threshold %q", part)
+		}
+
+		if threshold <= 0 || threshold > 100 {
+			return nil, fmt.Errorf("warning threshold %d should be in the range of 1 to 100", threshold)
+		}
+
+		if !seen[threshold] {
+			seen[threshold] = true
+			thresholds = append(thresholds, threshold)
+		}
+	}
+
+	sort.Ints(thresholds)
+
+	return thresholds, nil
+}
+
+// ExceededResources returns the resources whose usage exceeds the hard limits, the per artifact resources are ignored
+func ExceededResources(hardLimits, used types.ResourceList) []types.ResourceName {
+	var resources []types.ResourceName
+	for resource, value := range used {
+		if resource.IsPerArtifact() {
+			continue
+		}
+
+		hardLimit, found := hardLimits[resource]
+		if !found || hardLimit == types.UNLIMITED {
+			continue
+		}
+
+		if value > hardLimit {
+			resources = append(resources, resource)
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i] < resources[j] })
+
+	return resources
+}
+
+// IsSafeWithPolicy check new used is safe under the hard limits or within the overage allowed by the policy,
+// the grace period is taken from the state when the current usage is exceeding the hard limits, otherwise it starts now
+func IsSafeWithPolicy(hardLimits, currentUsed, newUsed types.ResourceList, policy *driver.Policy, state *State, now time.Time) error {
+	err := IsSafe(hardLimits, currentUsed, newUsed, false)
+	if err == nil || !policy.AllowOverage() {
+		return err
+	}
+
+	if policy.GracePeriod > 0 {
+		graceEnd := now.Add(policy.GracePeriod)
+		// the state of a previous overage is stale when the current usage is under the hard limits
+		if state != nil && !state.GraceEndTime.IsZero() && len(ExceededResources(hardLimits, currentUsed)) > 0 {
+			graceEnd = state.GraceEndTime
+		}
+
+		if !now.Before(graceEnd) {
+			var errs Errors
+			return errs.Add(err, NewGracePeriodExpiredError(graceEnd))
+		}
+	}
+
+	return IsSafe(overageLimits(hardLimits, policy.OveragePercent), currentUsed, newUsed, false)
+}
+
+// overageLimits returns the limits which the usage can reach with the overage allowance,
+// all the resources except the per artifact ones are unlimited when the percent is 0
+func overageLimits(hardLimits types.ResourceList, percent int) types.ResourceList {
+	limits := types.ResourceList{}
+	for resource, value := range hardLimits {
+		switch {
+		case value == types.UNLIMITED || resource.IsPerArtifact():
+			limits[resource] = value
+		case percent <= 0:
+			limits[resource] = types.UNLIMITED
+		default:
+			// avoid the overflow of value * percent
+			limits[resource] = value + value/100*int64(percent) + value%100*int64(percent)/100
+		}
+	}
+
+	return limits
+}
+
+// EvaluateState evaluates the usage against the warning thresholds and the overage policy,
+// it updates the state and returns the notices which should be sent to the owners,
+// a warning is only noticed once when the usage crosses a threshold and the overage is noticed at most once a day
+func EvaluateState(hardLimits, used types.ResourceList, policy *driver.Policy, state *State, now time.Time) ([]*Notice, error) {
+	var thresholds []int
+	if policy != nil {
+		thresholds = policy.WarningThresholds
+	}
+
+	levels, err := state.GetWarningLevels()
+	if err != nil {
+		return nil, err
+	}
+
+	newLevels := map[types.ResourceName]int{}
+	crossed := map[int][]types.ResourceName{}
+	for resource, value := range used {
+		// warning on the per artifact resource is meaningless as it limits every single artifact
+		if resource.IsPerArtifact() {
+			continue
+		}
+
+		hardLimit, found := hardLimits[resource]
+		if !found || hardLimit == types.UNLIMITED {
+			continue
+		}
+
+		level := 0
+		for _, threshold := range thresholds {
+			// used / limited >= threshold / 100
+			if value*100 >= hardLimit*int64(threshold) {
+				level = threshold
+			}
+		}
+
+		if level == 0 {
+			continue
+		}
+
+		newLevels[resource] = level
+		if level > levels[resource] {
+			crossed[level] = append(crossed[level], resource)
+		}
+	}
+
+	if !maps.Equal(levels, newLevels) {
+		state.SetWarningLevels(newLevels)
+	}
+
+	var notices []*Notice
+	for _, threshold := range thresholds {
+		resources := crossed[threshold]
+		if len(resources) == 0 {
+			continue
+		}
+
+		sort.Slice(resources, func(i, j int) bool { return resources[i] < resources[j] })
+		notices = append(notices, &Notice{
+			Kind:      NoticeWarning,
+			Resources: resources,
+			Message:   fmt.Sprintf("quota usage reach %d%%: %s", threshold, usageMessage(resources, hardLimits, used)),
+		})
+	}
+
+	exceeded := ExceededResources(hardLimits, used)
+	if len(exceeded) == 0 {
+		state.OverageStartTime = time.Time{}
+		state.GraceEndTime = time.Time{}
+		state.OverageNotifyTime = time.Time{}
+
+		return notices, nil
+	}
+
+	if !state.InOverage() {
+		state.OverageStartTime = now
+		state.GraceEndTime = time.Time{}
+	}
+
+	// start the grace period when it's configured after the overage began
+	if state.GraceEndTime.IsZero() && policy != nil && policy.GracePeriod > 0 {
+		state.GraceEndTime = now.Add(policy.GracePeriod)
+	}
+
+	if now.Sub(state.OverageNotifyTime) >= overageNotifyInterval {
+		state.OverageNotifyTime = now
+
+		message := "quota usage exceeds the hard limits"
+		if !state.GraceEndTime.IsZero() {
+			message += fmt.Sprintf(", the grace period ends at %s", state.GraceEndTime.UTC().Format(time.RFC3339))
+		}
+		notices = append(notices, &Notice{
+			Kind:      NoticeOverage,
+			Resources: exceeded,
+			Message:   fmt.Sprintf("%s: %s", message, usageMessage(exceeded, hardLimits, used)),
+		})
+	}
+
+	return notices, nil
+}
+
+func usageMessage(resources []types.ResourceName, hardLimits, used types.ResourceList) string {
+	var parts []string
+	for _, resource := range resources {
+		parts = append(parts, fmt.Sprintf("resource %s used %s of %s",
+			resource, resource.FormatValue(used[resource]), resource.FormatValue(hardLimits[resource])))
+	}
+
+	return strings.Join(parts, "; ")
+}
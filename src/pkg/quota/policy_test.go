@@ -0,0 +1,179 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+func TestParseWarningThresholds(t *testing.T) {
+	thresholds, err := ParseWarningThresholds("95, 75,90,75")
+	require.NoError(t, err)
+	assert.Equal(t, []int{75, 90, 95}, thresholds)
+
+	thresholds, err = ParseWarningThresholds("")
+	require.NoError(t, err)
+	assert.Empty(t, thresholds)
+
+	_, err = ParseWarningThresholds("75,abc")
+	assert.Error(t, err)
+
+	_, err = ParseWarningThresholds("0")
+	assert.Error(t, err)
+
+	_, err = ParseWarningThresholds("101")
+	assert.Error(t, err)
+}
+
+func TestExceededResources(t *testing.T) {
+	hardLimits := types.ResourceList{
+		types.ResourceStorage:       100,
+		types.ResourceArtifactCount: types.UNLIMITED,
+		types.ResourceArtifactSize:  10,
+	}
+	used := types.ResourceList{
+		types.ResourceStorage:       101,
+		types.ResourceArtifactCount: 1000,
+		types.ResourceArtifactSize:  20,
+	}
+
+	assert.Equal(t, []types.ResourceName{types.ResourceStorage}, ExceededResources(hardLimits, used))
+	assert.Empty(t, ExceededResources(hardLimits, types.ResourceList{types.ResourceStorage: 100}))
+}
+
+func TestIsSafeWithPolicy(t *testing.T) {
+	now := time.Now()
+	hardLimits := types.ResourceList{types.ResourceStorage: 100}
+
+	// no policy
+	err := IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 105}, nil, nil, now)
+	assert.Error(t, err)
+
+	// overage allowance
+	policy := &driver.Policy{OveragePercent: 10}
+	assert.NoError(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 110}, policy, nil, now))
+	assert.Error(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 111}, policy, nil, now))
+
+	// grace period without cap starts now
+	policy = &driver.Policy{GracePeriod: 24 * time.Hour}
+	assert.NoError(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 1000}, policy, nil, now))
+
+	// grace period in progress
+	state := &State{OverageStartTime: now.Add(-time.Hour), GraceEndTime: now.Add(time.Hour)}
+	assert.NoError(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 120}, types.ResourceList{types.ResourceStorage: 130}, policy, state, now))
+
+	// grace period expired
+	state = &State{OverageStartTime: now.Add(-48 * time.Hour), GraceEndTime: now.Add(-24 * time.Hour)}
+	err = IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 120}, types.ResourceList{types.ResourceStorage: 130}, policy, state, now)
+	require.Error(t, err)
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	assert.NotNil(t, errs.Exceeded())
+
+	// stale grace period of a previous overage
+	assert.NoError(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 130}, policy, state, now))
+
+	// grace period with cap
+	policy = &driver.Policy{GracePeriod: 24 * time.Hour, OveragePercent: 20}
+	assert.NoError(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 120}, policy, nil, now))
+	assert.Error(t, IsSafeWithPolicy(hardLimits, types.ResourceList{types.ResourceStorage: 90}, types.ResourceList{types.ResourceStorage: 121}, policy, nil, now))
+
+	// per artifact resource is never allowed to exceed
+	hardLimits = types.ResourceList{types.ResourceStorage: 100, types.ResourceArtifactSize: 10}
+	policy = &driver.Policy{OveragePercent: 50}
+	assert.Error(t, IsSafeWithPolicy(hardLimits,
+		types.ResourceList{types.ResourceStorage: 10, types.ResourceArtifactSize: 5},
+		types.ResourceList{types.ResourceStorage: 20, types.ResourceArtifactSize: 11}, policy, nil, now))
+}
+
+func TestEvaluateState(t *testing.T) {
+	now := time.Now()
+	hardLimits := types.ResourceList{types.ResourceStorage: 100, types.ResourceArtifactCount: 10}
+	policy := &driver.Policy{WarningThresholds: []int{75, 90, 95}, GracePeriod: 24 * time.Hour}
+	state := &State{QuotaID: 1}
+
+	notices, err := EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 50, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	assert.Empty(t, notices)
+
+	// cross 75%
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 80, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	require.Len(t, notices, 1)
+	assert.Equal(t, NoticeWarning, notices[0].Kind)
+	assert.Contains(t, notices[0].Message, "75%")
+
+	// still above 75%, no duplicated warning
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 85, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	assert.Empty(t, notices)
+
+	// jump over 90% and 95%, only the highest one is noticed
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 96, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	require.Len(t, notices, 1)
+	assert.Contains(t, notices[0].Message, "95%")
+
+	// drop under 75% and cross it again
+	_, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 10, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 80, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	require.Len(t, notices, 1)
+	assert.Contains(t, notices[0].Message, "75%")
+
+	// exceed the hard limits, the grace period starts
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 110, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	require.Len(t, notices, 2)
+	assert.Equal(t, NoticeOverage, notices[1].Kind)
+	assert.Equal(t, []types.ResourceName{types.ResourceStorage}, notices[1].Resources)
+	assert.Equal(t, now, state.OverageStartTime)
+	assert.Equal(t, now.Add(24*time.Hour), state.GraceEndTime)
+
+	// the overage is noticed at most once a day
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 120, types.ResourceArtifactCount: 1}, policy, state, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, notices)
+	notices, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 120, types.ResourceArtifactCount: 1}, policy, state, now.Add(25*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, notices, 1)
+	assert.Equal(t, NoticeOverage, notices[0].Kind)
+	assert.Equal(t, now, state.OverageStartTime)
+
+	// back under the hard limits
+	_, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 50, types.ResourceArtifactCount: 1}, policy, state, now)
+	require.NoError(t, err)
+	assert.False(t, state.InOverage())
+	assert.True(t, state.GraceEndTime.IsZero())
+
+	// the overage began without grace period, the grace period starts once it's configured
+	state = &State{QuotaID: 1}
+	_, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 110, types.ResourceArtifactCount: 1}, &driver.Policy{}, state, now)
+	require.NoError(t, err)
+	assert.True(t, state.InOverage())
+	assert.True(t, state.GraceEndTime.IsZero())
+	_, err = EvaluateState(hardLimits, types.ResourceList{types.ResourceStorage: 110, types.ResourceArtifactCount: 1}, policy, state, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, now, state.OverageStartTime)
+	assert.Equal(t, now.Add(25*time.Hour), state.GraceEndTime)
+}
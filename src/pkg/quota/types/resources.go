@@ -31,6 +31,28 @@ const (
 
 	// ResourceStorage storage size, in bytes
 	ResourceStorage ResourceName = "storage"
+	// ResourceArtifactCount count of the artifacts
+	ResourceArtifactCount ResourceName = "artifact_count"
+	// ResourceRepositoryCount count of the repositories
+	ResourceRepositoryCount ResourceName = "repository_count"
+	// ResourceArtifactSize the max size of a single artifact, in bytes,
+	// the usage of it is the size of the largest artifact rather than the sum
+	ResourceArtifactSize ResourceName = "artifact_size"
+)
+
+var (
+	// optionalResources the resources which are treated as unlimited when missing from the hard limits,
+	// the quotas created before these resources were introduced don't have them
+	optionalResources = map[ResourceName]bool{
+		ResourceArtifactCount:   true,
+		ResourceRepositoryCount: true,
+		ResourceArtifactSize:    true,
+	}
+
+	// perArtifactResources the resources which limit every single artifact instead of the total usage
+	perArtifactResources = map[ResourceName]bool{
+		ResourceArtifactSize: true,
+	}
 )
 
 // ResourceName is the name identifying various resources in a ResourceList.
@@ -46,6 +68,16 @@ func (resource ResourceName) FormatValue(value int64) string {
 	return strconv.FormatInt(value, 10)
 }
 
+// IsOptional returns true when the resource is treated as unlimited if it's missing from the hard limits
+func (resource ResourceName) IsOptional() bool {
+	return optionalResources[resource]
+}
+
+// IsPerArtifact returns true when the resource limits every single artifact instead of the total usage
+func (resource ResourceName) IsPerArtifact() bool {
+	return perArtifactResources[resource]
+}
+
 // ResourceList is a set of (resource name, value) pairs.
 type ResourceList map[ResourceName]int64
 
@@ -88,12 +120,17 @@ func Equals(a ResourceList, b ResourceList) bool {
 	return true
 }
 
-// Add returns the result of a + b for each named resource
+// Add returns the result of a + b for each named resource,
+// the per artifact resource takes the larger one of a and b
 func Add(a ResourceList, b ResourceList) ResourceList {
 	result := ResourceList{}
 	for key, value := range a {
 		if other, found := b[key]; found {
-			value = value + other
+			if key.IsPerArtifact() {
+				value = max(value, other)
+			} else {
+				value = value + other
+			}
 		}
 		result[key] = value
 	}
@@ -106,18 +143,19 @@ func Add(a ResourceList, b ResourceList) ResourceList {
 	return result
 }
 
-// Subtract returns the result of a - b for each named resource
+// Subtract returns the result of a - b for each named resource,
+// the per artifact resource keeps the value of a as the largest artifact can't be known from b
 func Subtract(a ResourceList, b ResourceList) ResourceList {
 	result := ResourceList{}
 	for key, value := range a {
-		if other, found := b[key]; found {
+		if other, found := b[key]; found && !key.IsPerArtifact() {
 			value = value - other
 		}
 		result[key] = value
 	}
 
 	for key, value := range b {
-		if _, found := result[key]; !found {
+		if _, found := result[key]; !found && !key.IsPerArtifact() {
 			result[key] = -value
 		}
 	}
@@ -148,7 +186,7 @@ func IsNegative(a ResourceList) []ResourceName {
 // IsValidResource returns true when resource was supported
 func IsValidResource(resource ResourceName) bool {
 	switch resource {
-	case ResourceStorage:
+	case ResourceStorage, ResourceArtifactCount, ResourceRepositoryCount, ResourceArtifactSize:
 		return true
 	default:
 		return false
@@ -49,6 +49,12 @@ func (suite *ResourcesSuite) TestAdd() {
 	suite.Equal(res1, Add(ResourceList{}, res1))
 	suite.Equal(ResourceList{ResourceStorage: 200}, Add(res1, res2))
 	suite.Equal(ResourceList{ResourceStorage: 200}, Add(res1, res3))
+
+	// the per artifact resource takes the larger one
+	res4 := ResourceList{ResourceArtifactCount: 10, ResourceArtifactSize: 100}
+	res5 := ResourceList{ResourceArtifactCount: 1, ResourceArtifactSize: 50}
+	suite.Equal(ResourceList{ResourceArtifactCount: 11, ResourceArtifactSize: 100}, Add(res4, res5))
+	suite.Equal(ResourceList{ResourceArtifactCount: 11, ResourceArtifactSize: 200}, Add(res4, ResourceList{ResourceArtifactCount: 1, ResourceArtifactSize: 200}))
 }
 
 func (suite *ResourcesSuite) TestSubtract() {
@@ -59,6 +65,11 @@ func (suite *ResourcesSuite) TestSubtract() {
 	suite.Equal(res1, Subtract(res1, ResourceList{}))
 	suite.Equal(ResourceList{ResourceStorage: 0}, Subtract(res1, res2))
 	suite.Equal(ResourceList{ResourceStorage: 0}, Subtract(res1, res3))
+
+	// the per artifact resource keeps the value
+	res4 := ResourceList{ResourceArtifactCount: 10, ResourceArtifactSize: 100}
+	suite.Equal(ResourceList{ResourceArtifactCount: 9, ResourceArtifactSize: 100}, Subtract(res4, ResourceList{ResourceArtifactCount: 1, ResourceArtifactSize: 100}))
+	suite.Equal(ResourceList{ResourceArtifactCount: -1}, Subtract(ResourceList{}, ResourceList{ResourceArtifactCount: 1, ResourceArtifactSize: 100}))
 }
 
 func (suite *ResourcesSuite) TestZero() {
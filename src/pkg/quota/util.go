@@ -28,7 +28,9 @@ func IsSafe(hardLimits types.ResourceList, currentUsed types.ResourceList, newUs
 	for resource, value := range newUsed {
 		hardLimit, found := hardLimits[resource]
 		if !found {
-			errs = errs.Add(NewResourceNotFoundError(resource))
+			if !resource.IsOptional() {
+				errs = errs.Add(NewResourceNotFoundError(resource))
+			}
 			continue
 		}
 
@@ -36,6 +38,12 @@ func IsSafe(hardLimits types.ResourceList, currentUsed types.ResourceList, newUs
 			continue
 		}
 
+		// the usage of the per artifact resource is the largest artifact which may predate the hard limit,
+		// so it's not checked unless it grows, the incoming artifact itself is checked by IsArtifactSafe
+		if resource.IsPerArtifact() && value <= currentUsed[resource] {
+			continue
+		}
+
 		if value > hardLimit && !ignoreLimitation {
 			errs = errs.Add(NewResourceOverflowError(resource, hardLimit, currentUsed[resource], value))
 		}
@@ -48,6 +56,32 @@ func IsSafe(hardLimits types.ResourceList, currentUsed types.ResourceList, newUs
 	return nil
 }
 
+// IsArtifactSafe check the per artifact resources of the incoming artifact are safe under the hard limits
+func IsArtifactSafe(hardLimits types.ResourceList, resources types.ResourceList) error {
+	var errs Errors
+
+	for resource, value := range resources {
+		if !resource.IsPerArtifact() {
+			continue
+		}
+
+		hardLimit, found := hardLimits[resource]
+		if !found || hardLimit == types.UNLIMITED {
+			continue
+		}
+
+		if value > hardLimit {
+			errs = errs.Add(NewResourceOverflowError(resource, hardLimit, 0, value))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 // PrettyPrintResourceNames make resource names pretty
 func PrettyPrintResourceNames(a []types.ResourceName) string {
 	values := []string{}
@@ -62,6 +62,46 @@ func TestIsSafe(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"optional resource not in the hard limits",
+			args{
+				types.ResourceList{types.ResourceStorage: 100},
+				types.ResourceList{types.ResourceStorage: 10},
+				types.ResourceList{types.ResourceStorage: 20, types.ResourceArtifactCount: 1000},
+				false,
+			},
+			false,
+		},
+		{
+			"artifact count over the hard limit",
+			args{
+				types.ResourceList{types.ResourceStorage: 100, types.ResourceArtifactCount: 10},
+				types.ResourceList{types.ResourceStorage: 10, types.ResourceArtifactCount: 10},
+				types.ResourceList{types.ResourceStorage: 20, types.ResourceArtifactCount: 11},
+				false,
+			},
+			true,
+		},
+		{
+			"artifact size over the hard limit",
+			args{
+				types.ResourceList{types.ResourceArtifactSize: 100},
+				types.ResourceList{types.ResourceArtifactSize: 50},
+				types.ResourceList{types.ResourceArtifactSize: 150},
+				false,
+			},
+			true,
+		},
+		{
+			"existing artifact larger than the lowered hard limit",
+			args{
+				types.ResourceList{types.ResourceArtifactSize: 100},
+				types.ResourceList{types.ResourceArtifactSize: 150},
+				types.ResourceList{types.ResourceArtifactSize: 150},
+				false,
+			},
+			false,
+		},
 		{
 			"ignore limitation",
 			args{
@@ -82,6 +122,32 @@ func TestIsSafe(t *testing.T) {
 	}
 }
 
+func TestIsArtifactSafe(t *testing.T) {
+	hardLimits := types.ResourceList{types.ResourceStorage: 1000, types.ResourceArtifactSize: 100}
+
+	tests := []struct {
+		name      string
+		resources types.ResourceList
+		wantErr   bool
+	}{
+		{"under the hard limit", types.ResourceList{types.ResourceStorage: 500, types.ResourceArtifactSize: 100}, false},
+		{"over the hard limit", types.ResourceList{types.ResourceStorage: 500, types.ResourceArtifactSize: 101}, true},
+		{"not per artifact resource", types.ResourceList{types.ResourceStorage: 2000}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := IsArtifactSafe(hardLimits, tt.resources); (err != nil) != tt.wantErr {
+				t.Errorf("IsArtifactSafe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// unlimited
+	if err := IsArtifactSafe(types.ResourceList{types.ResourceArtifactSize: types.UNLIMITED}, types.ResourceList{types.ResourceArtifactSize: 1000}); err != nil {
+		t.Errorf("IsArtifactSafe() error = %v, wantErr false", err)
+	}
+}
+
 func TestPrettyPrintResourceNames(t *testing.T) {
 	type args struct {
 		a []types.ResourceName
@@ -211,7 +211,7 @@ func createJobs(repositoryRules map[selector.Repository]*lwp.Metadata, isDryRun
 		jobData := &jobData{
 			Repository: repository,
 			JobName:    job.RetentionVendorType,
-			JobParams:  make(map[string]any, 3),
+			JobParams:  make(map[string]any, 4),
 		}
 		// set dry run
 		jobData.JobParams[ParamDryRun] = isDryRun
@@ -227,6 +227,8 @@ func createJobs(repositoryRules map[selector.Repository]*lwp.Metadata, isDryRun
 			return nil, err
 		}
 		jobData.JobParams[ParamMeta] = policyJSON
+		// set project to schedule the jobs fairly among the projects
+		jobData.JobParams[job.ProjectIDParamKey] = repository.NamespaceID
 		jobDatas = append(jobDatas, jobData)
 	}
 	return jobDatas, nil
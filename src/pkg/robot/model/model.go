@@ -43,6 +43,11 @@ type Robot struct {
 	CreatorType  string    `orm:"column(creator_type)" json:"creator_type"`
 	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+	// the previous secret is still valid until it expires after the secret is refreshed
+	PreviousSecret          string    `orm:"column(previous_secret)" filter:"false" json:"-"`
+	PreviousSecretExpiresAt int64     `orm:"column(previous_secret_expiresat)" json:"previous_secret_expires_at"`
+	LastUsedTime            time.Time `orm:"column(last_used_time)" json:"last_used_time"`
+	LastUsedIP              string    `orm:"column(last_used_ip)" json:"last_used_ip"`
 }
 
 // TableName ...
@@ -0,0 +1,100 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type DaoTestSuite struct {
+	htesting.Suite
+	dao    DAO
+	roleID int64
+}
+
+func (suite *DaoTestSuite) SetupSuite() {
+	suite.Suite.SetupSuite()
+	suite.dao = New()
+}
+
+func (suite *DaoTestSuite) SetupTest() {
+	var err error
+	suite.roleID, err = suite.dao.Create(orm.Context(), &model.Role{
+		Name:        "ci-publisher",
+		Description: "push and scan",
+		Custom:      true,
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *DaoTestSuite) TearDownTest() {
+	err := suite.dao.Delete(orm.Context(), suite.roleID)
+	suite.True(err == nil || errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestCreate() {
+	// custom roles don't take the IDs reserved for the built-in roles
+	suite.GreaterOrEqual(suite.roleID, int64(100))
+
+	_, err := suite.dao.Create(orm.Context(), &model.Role{Name: "ci-publisher", Custom: true})
+	suite.Require().Error(err)
+	suite.True(errors.IsConflictErr(err))
+}
+
+func (suite *DaoTestSuite) TestGetAndUpdate() {
+	r, err := suite.dao.Get(orm.Context(), suite.roleID)
+	suite.Require().NoError(err)
+	suite.Equal("ci-publisher", r.Name)
+	suite.True(r.Custom)
+
+	r.Description = "push, scan and no delete"
+	suite.Require().NoError(suite.dao.Update(orm.Context(), r, "description"))
+	r, err = suite.dao.Get(orm.Context(), suite.roleID)
+	suite.Require().NoError(err)
+	suite.Equal("push, scan and no delete", r.Description)
+
+	_, err = suite.dao.Get(orm.Context(), 10000)
+	suite.True(errors.IsNotFoundErr(err))
+}
+
+func (suite *DaoTestSuite) TestListAndCount() {
+	roles, err := suite.dao.List(orm.Context(), q.New(q.KeyWords{"Custom": true}))
+	suite.Require().NoError(err)
+	suite.Require().Len(roles, 1)
+	suite.Equal(suite.roleID, roles[0].ID)
+
+	// the built-in roles and the custom role
+	count, err := suite.dao.Count(orm.Context(), nil)
+	suite.Require().NoError(err)
+	suite.Equal(int64(6), count)
+}
+
+func (suite *DaoTestSuite) TestCountMembers() {
+	count, err := suite.dao.CountMembers(orm.Context(), suite.roleID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), count)
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &DaoTestSuite{})
+}
@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/role/dao"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+var (
+	// Mgr is a global variable for the default role manager implementation
+	Mgr = NewManager()
+)
+
+// Manager defines the interface to manage the project roles
+type Manager interface {
+	// Create creates the role and returns its ID
+	Create(ctx context.Context, r *model.Role) (int64, error)
+	// Update updates the role
+	Update(ctx context.Context, r *model.Role, props ...string) error
+	// Get gets the role by ID
+	Get(ctx context.Context, id int64) (*model.Role, error)
+	// Count returns the total count of roles according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List lists the roles according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Role, error)
+	// Delete deletes the role by ID
+	Delete(ctx context.Context, id int64) error
+	// CountMembers returns the count of the project members assigned with the role
+	CountMembers(ctx context.Context, id int64) (int64, error)
+}
+
+// NewManager returns a default implementation of Manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, r *model.Role) (int64, error) {
+	return m.dao.Create(ctx, r)
+}
+
+func (m *manager) Update(ctx context.Context, r *model.Role, props ...string) error {
+	return m.dao.Update(ctx, r, props...)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Role, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) CountMembers(ctx context.Context, id int64) (int64, error) {
+	return m.dao.CountMembers(ctx, id)
+}
@@ -0,0 +1,43 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+// PermissionRoleType is the role type of the custom roles in the role permissions
+const PermissionRoleType = "projectrole"
+
+func init() {
+	orm.RegisterModel(&Role{})
+}
+
+// Role holds the details of a project role, the built-in roles can't be changed
+type Role struct {
+	ID           int64     `orm:"pk;auto;column(role_id)" json:"role_id"`
+	Name         string    `orm:"column(name)" json:"name" sort:"default"`
+	Description  string    `orm:"column(description)" json:"description"`
+	Custom       bool      `orm:"column(custom)" json:"custom"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (r *Role) TableName() string {
+	return "role"
+}
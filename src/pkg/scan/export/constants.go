@@ -22,6 +22,7 @@ const (
 	JobNameAttribute       = "job_name"
 	UserNameAttribute      = "user_name"
 	StatusMessageAttribute = "status_message"
+	FormatAttribute        = "format"
 	// the scan data is a temporary file, use /tmp directory to avoid the permission issue.
 	ScanDataExportDir  = "/tmp"
 	QueryPageSize      = 100000
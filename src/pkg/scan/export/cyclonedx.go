@@ -0,0 +1,214 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+const cycloneDXSpecVersion = "1.5"
+
+type cdxBOM struct {
+	BOMFormat       string              `json:"bomFormat"`
+	SpecVersion     string              `json:"specVersion"`
+	SerialNumber    string              `json:"serialNumber"`
+	Version         int                 `json:"version"`
+	Metadata        cdxMetadata         `json:"metadata"`
+	Components      []*cdxComponent     `json:"components"`
+	Vulnerabilities []*cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxMetadata struct {
+	Timestamp string   `json:"timestamp"`
+	Tools     cdxTools `json:"tools"`
+}
+
+type cdxTools struct {
+	Components []*cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef  string `json:"bom-ref,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type cdxVulnerability struct {
+	BOMRef         string       `json:"bom-ref"`
+	ID             string       `json:"id"`
+	Ratings        []*cdxRating `json:"ratings,omitempty"`
+	CWEs           []int        `json:"cwes,omitempty"`
+	Recommendation string       `json:"recommendation,omitempty"`
+	Affects        []*cdxAffect `json:"affects"`
+}
+
+type cdxRating struct {
+	Source   *cdxSource `json:"source,omitempty"`
+	Severity string     `json:"severity"`
+}
+
+type cdxSource struct {
+	Name string `json:"name"`
+}
+
+type cdxAffect struct {
+	Ref      string             `json:"ref"`
+	Versions []*cdxAffectedInfo `json:"versions,omitempty"`
+}
+
+type cdxAffectedInfo struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+// cycloneDXWriter writes the scan data as a CycloneDX vulnerability disclosure report (VDR).
+// The artifacts and their vulnerable packages are described as the components, and each vulnerability
+// refers to the affected packages, so the whole document is built in memory and written when closing the writer.
+type cycloneDXWriter struct {
+	out             io.Writer
+	components      []*cdxComponent
+	vulnerabilities []*cdxVulnerability
+	indexedComps    map[string]*cdxComponent
+	indexedVuls     map[string]*cdxVulnerability
+	affected        map[string]bool
+	rated           map[string]bool
+}
+
+func newCycloneDXWriter(out io.Writer) *cycloneDXWriter {
+	return &cycloneDXWriter{
+		out:          out,
+		indexedComps: map[string]*cdxComponent{},
+		indexedVuls:  map[string]*cdxVulnerability{},
+		affected:     map[string]bool{},
+		rated:        map[string]bool{},
+	}
+}
+
+func (w *cycloneDXWriter) Write(data []Data) error {
+	for i := range data {
+		d := &data[i]
+
+		artifactRef := fmt.Sprintf("%s@%s", d.Repository, d.ArtifactDigest)
+		w.addComponent(&cdxComponent{BOMRef: artifactRef, Type: "container", Name: d.Repository, Version: d.ArtifactDigest})
+
+		packageRef := fmt.Sprintf("%s|%s@%s", artifactRef, d.Package, d.Version)
+		w.addComponent(&cdxComponent{BOMRef: packageRef, Type: "library", Name: d.Package, Version: d.Version})
+
+		v, ok := w.indexedVuls[d.CVEId]
+		if !ok {
+			v = &cdxVulnerability{BOMRef: d.CVEId, ID: d.CVEId, CWEs: cweNumbers(parseCWEIds(d.CWEIds))}
+			w.indexedVuls[d.CVEId] = v
+			w.vulnerabilities = append(w.vulnerabilities, v)
+		}
+
+		if key := d.CVEId + "|" + d.ScannerName; !w.rated[key] {
+			w.rated[key] = true
+			v.Ratings = append(v.Ratings, &cdxRating{Source: &cdxSource{Name: d.ScannerName}, Severity: cycloneDXSeverity(d.Severity)})
+		}
+
+		if len(d.FixVersion) > 0 && len(v.Recommendation) == 0 {
+			v.Recommendation = fmt.Sprintf("Upgrade %s to version %s", d.Package, d.FixVersion)
+		}
+
+		if key := d.CVEId + "|" + packageRef; !w.affected[key] {
+			w.affected[key] = true
+			v.Affects = append(v.Affects, &cdxAffect{
+				Ref:      packageRef,
+				Versions: []*cdxAffectedInfo{{Version: d.Version, Status: "affected"}},
+			})
+		}
+	}
+
+	return nil
+}
+
+func (w *cycloneDXWriter) Close() error {
+	if len(w.vulnerabilities) == 0 {
+		return nil
+	}
+
+	bom := &cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: fmt.Sprintf("urn:uuid:%s", uuid.New().String()),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools: cdxTools{
+				Components: []*cdxComponent{{Type: "application", Name: "Harbor"}},
+			},
+		},
+		Components:      w.components,
+		Vulnerabilities: w.vulnerabilities,
+	}
+
+	return json.NewEncoder(w.out).Encode(bom)
+}
+
+func (w *cycloneDXWriter) addComponent(c *cdxComponent) {
+	if _, ok := w.indexedComps[c.BOMRef]; ok {
+		return
+	}
+
+	w.indexedComps[c.BOMRef] = c
+	w.components = append(w.components, c)
+}
+
+// cycloneDXSeverity maps the severity of the vulnerability to the severity of the CycloneDX rating
+func cycloneDXSeverity(severity string) string {
+	switch s := vuln.Severity(severity); s {
+	case vuln.Critical, vuln.High, vuln.Medium, vuln.Low, vuln.None:
+		return strings.ToLower(s.String())
+	case vuln.Negligible:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// parseCWEIds parses the comma separated CWE ids, e.g. CWE-79,CWE-89
+func parseCWEIds(cweIDs string) []string {
+	var ids []string
+	for _, id := range strings.Split(cweIDs, ",") {
+		if id = strings.TrimSpace(id); len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// cweNumbers returns the numbers of the CWE ids, the malformed ids are ignored
+func cweNumbers(cweIDs []string) []int {
+	var numbers []int
+	for _, id := range cweIDs {
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(id), "CWE-"))
+		if err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+
+	return numbers
+}
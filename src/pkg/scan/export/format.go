@@ -0,0 +1,156 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/gocarina/gocsv"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// the output formats of the scan data export
+const (
+	FormatCSV       = "csv"
+	FormatJSONLines = "jsonl"
+	FormatSARIF     = "sarif"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Formats returns all the supported output formats
+func Formats() []string {
+	return []string{FormatCSV, FormatJSONLines, FormatSARIF, FormatCycloneDX}
+}
+
+// IsSupportedFormat checks whether the format is supported, the empty format means the default one
+func IsSupportedFormat(format string) bool {
+	return format == "" || slices.Contains(Formats(), format)
+}
+
+// FileExtension returns the extension of the exported file for the format
+func FileExtension(format string) string {
+	switch format {
+	case FormatJSONLines:
+		return "jsonl"
+	case FormatSARIF:
+		return "sarif"
+	case FormatCycloneDX:
+		return "cdx.json"
+	default:
+		return "csv"
+	}
+}
+
+// ContentType returns the content type of the exported file for the format
+func ContentType(format string) string {
+	switch format {
+	case FormatJSONLines:
+		return "application/jsonl"
+	case FormatSARIF:
+		return "application/sarif+json"
+	case FormatCycloneDX:
+		return "application/vnd.cyclonedx+json"
+	default:
+		return "text/csv"
+	}
+}
+
+// ArtifactType returns the type of the system artifact storing the exported file for the format
+func ArtifactType(format string) string {
+	switch format {
+	case FormatJSONLines:
+		return "ScanData_JSONL"
+	case FormatSARIF:
+		return "ScanData_SARIF"
+	case FormatCycloneDX:
+		return "ScanData_CycloneDX"
+	default:
+		return "ScanData_CSV"
+	}
+}
+
+// DataWriter writes the exported scan data to the output in the specific format
+type DataWriter interface {
+	// Write writes a page of the scan data
+	Write(data []Data) error
+	// Close completes the output, nothing is written if no data was written before
+	Close() error
+}
+
+// NewDataWriter returns the writer for the format
+func NewDataWriter(format string, out io.Writer) (DataWriter, error) {
+	switch format {
+	case "", FormatCSV:
+		return &csvWriter{out: out}, nil
+	case FormatJSONLines:
+		return &jsonLinesWriter{encoder: json.NewEncoder(out)}, nil
+	case FormatSARIF:
+		return newSARIFWriter(out), nil
+	case FormatCycloneDX:
+		return newCycloneDXWriter(out), nil
+	default:
+		return nil, errors.Errorf("unsupported scan data export format: %s", format)
+	}
+}
+
+type csvWriter struct {
+	out     io.Writer
+	written bool
+}
+
+func (w *csvWriter) Write(data []Data) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	rows := make([]Data, len(data))
+	for i := range data {
+		rows[i] = data[i]
+		rows[i].sanitizeForCSV()
+	}
+
+	// write the CSV with the headers for the first page
+	if !w.written {
+		w.written = true
+		return gocsv.Marshal(rows, w.out)
+	}
+
+	return gocsv.MarshalWithoutHeaders(rows, w.out)
+}
+
+func (w *csvWriter) Close() error {
+	return nil
+}
+
+type jsonLinesWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonLinesWriter) Write(data []Data) error {
+	for i := range data {
+		if err := w.encoder.Encode(&data[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return nil
+}
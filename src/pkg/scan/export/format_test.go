@@ -0,0 +1,143 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleData() []Data {
+	return []Data{
+		{Repository: "library/nginx", ArtifactDigest: "sha256:aaa", CVEId: "CVE-2023-0001", Package: "openssl", Version: "1.1.1", FixVersion: "1.1.2", Severity: "Critical", CWEIds: "CWE-79,CWE-89", ScannerName: "Trivy"},
+		{Repository: "library/nginx", ArtifactDigest: "sha256:aaa", CVEId: "CVE-2023-0002", Package: "=libc", Version: "2.27", Severity: "Medium", ScannerName: "Trivy"},
+		{Repository: "library/redis", ArtifactDigest: "sha256:bbb", CVEId: "CVE-2023-0001", Package: "openssl", Version: "1.1.1", Severity: "Low", ScannerName: "Other"},
+	}
+}
+
+func TestIsSupportedFormat(t *testing.T) {
+	assert.True(t, IsSupportedFormat(""))
+	for _, f := range Formats() {
+		assert.True(t, IsSupportedFormat(f))
+	}
+	assert.False(t, IsSupportedFormat("pdf"))
+
+	_, err := NewDataWriter("pdf", &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestCSVWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewDataWriter(FormatCSV, buf)
+	require.NoError(t, err)
+
+	data := sampleData()
+	require.NoError(t, w.Write(data[:2]))
+	require.NoError(t, w.Write(data[2:]))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "Repository,"))
+	// the cells are sanitized for CSV only
+	assert.Contains(t, lines[2], "'=libc")
+	assert.Equal(t, "=libc", data[1].Package)
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewDataWriter(FormatJSONLines, buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(sampleData()))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	d := Data{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &d))
+	assert.Equal(t, "=libc", d.Package)
+	assert.Equal(t, "CVE-2023-0002", d.CVEId)
+}
+
+func TestSARIFWriter(t *testing.T) {
+	{
+		// nothing written without data
+		buf := &bytes.Buffer{}
+		w := newSARIFWriter(buf)
+		require.NoError(t, w.Close())
+		assert.Equal(t, 0, buf.Len())
+	}
+
+	buf := &bytes.Buffer{}
+	w := newSARIFWriter(buf)
+	data := sampleData()
+	require.NoError(t, w.Write(data[:1]))
+	require.NoError(t, w.Write(data[1:]))
+	require.NoError(t, w.Close())
+
+	log := struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string       `json:"name"`
+					Rules []*sarifRule `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []*sarifResult `json:"results"`
+		} `json:"runs"`
+	}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "Harbor", log.Runs[0].Tool.Driver.Name)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+	require.Len(t, log.Runs[0].Results, 3)
+	assert.Equal(t, "error", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "warning", log.Runs[0].Results[1].Level)
+	assert.Equal(t, "note", log.Runs[0].Results[2].Level)
+	assert.Equal(t, "library/redis@sha256:bbb", log.Runs[0].Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestCycloneDXWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := newCycloneDXWriter(buf)
+	require.NoError(t, w.Write(sampleData()))
+	require.NoError(t, w.Close())
+
+	bom := &cdxBOM{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	// 2 artifacts and 3 packages
+	assert.Len(t, bom.Components, 5)
+	require.Len(t, bom.Vulnerabilities, 2)
+
+	v := bom.Vulnerabilities[0]
+	assert.Equal(t, "CVE-2023-0001", v.ID)
+	assert.Equal(t, []int{79, 89}, v.CWEs)
+	assert.Len(t, v.Affects, 2)
+	require.Len(t, v.Ratings, 2)
+	assert.Equal(t, "critical", v.Ratings[0].Severity)
+	assert.Equal(t, "low", v.Ratings[1].Severity)
+	assert.Equal(t, "Upgrade openssl to version 1.1.2", v.Recommendation)
+}
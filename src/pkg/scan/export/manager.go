@@ -138,10 +138,6 @@ func (em *exportManager) Fetch(ctx context.Context, params Params) ([]Data, erro
 		return nil, err
 	}
 
-	for i := range exportData {
-		exportData[i].sanitizeForCSV()
-	}
-
 	return exportData, nil
 }
 
@@ -23,16 +23,16 @@ import (
 // Data models a single row of the exported scan vulnerability data
 
 type Data struct {
-	Repository     string `orm:"column(repository_name)" csv:"Repository"`
-	ArtifactDigest string `orm:"column(artifact_digest)" csv:"Artifact Digest"`
-	CVEId          string `orm:"column(cve_id)" csv:"CVE"`
-	Package        string `orm:"column(package)" csv:"Package"`
-	Version        string `orm:"column(package_version)" csv:"Current Version"`
-	FixVersion     string `orm:"column(fixed_version)" csv:"Fixed in version"`
-	Severity       string `orm:"column(severity)" csv:"Severity"`
-	CWEIds         string `orm:"column(cwe_ids)" csv:"CWE Ids"`
-	AdditionalData string `orm:"column(vendor_attributes)" csv:"Additional Data"`
-	ScannerName    string `orm:"column(scanner_name)" csv:"Scanner"`
+	Repository     string `orm:"column(repository_name)" csv:"Repository" json:"repository"`
+	ArtifactDigest string `orm:"column(artifact_digest)" csv:"Artifact Digest" json:"artifact_digest"`
+	CVEId          string `orm:"column(cve_id)" csv:"CVE" json:"cve_id"`
+	Package        string `orm:"column(package)" csv:"Package" json:"package"`
+	Version        string `orm:"column(package_version)" csv:"Current Version" json:"version"`
+	FixVersion     string `orm:"column(fixed_version)" csv:"Fixed in version" json:"fixed_version"`
+	Severity       string `orm:"column(severity)" csv:"Severity" json:"severity"`
+	CWEIds         string `orm:"column(cwe_ids)" csv:"CWE Ids" json:"cwe_ids"`
+	AdditionalData string `orm:"column(vendor_attributes)" csv:"Additional Data" json:"additional_data"`
+	ScannerName    string `orm:"column(scanner_name)" csv:"Scanner" json:"scanner"`
 }
 
 // Request encapsulates the filters to be provided when exporting the data for a scan.
@@ -61,6 +61,9 @@ type Request struct {
 
 	// A list of tags for which to export the scan data, defaults to all if empty
 	Tags string
+
+	// The output format of the exported scan data, defaults to CSV if empty
+	Format string
 }
 
 // FromJSON parses robot from json data
@@ -108,6 +111,8 @@ type Execution struct {
 	UserName string
 	// FilePresent is true if file artifact is actually present, false otherwise
 	FilePresent bool
+	// Format is the output format of the exported scan data
+	Format string
 }
 
 type Task struct {
@@ -0,0 +1,181 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifToolName the name of the tool reported in the SARIF log
+	sarifToolName = "Harbor"
+)
+
+type sarifRule struct {
+	ID               string         `json:"id"`
+	ShortDescription sarifMessage   `json:"shortDescription"`
+	HelpURI          string         `json:"helpUri,omitempty"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifWriter writes the scan data as a SARIF 2.1 log with one run.
+// The results are streamed to the output while the rules, which are much fewer than the results,
+// are kept in memory and written when closing the writer.
+type sarifWriter struct {
+	out     io.Writer
+	written bool
+	rules   []*sarifRule
+	indexed map[string]*sarifRule
+}
+
+func newSARIFWriter(out io.Writer) *sarifWriter {
+	return &sarifWriter{out: out, indexed: map[string]*sarifRule{}}
+}
+
+func (w *sarifWriter) Write(data []Data) error {
+	for i := range data {
+		d := &data[i]
+
+		result, err := json.Marshal(w.toResult(d))
+		if err != nil {
+			return err
+		}
+
+		prefix := ","
+		if !w.written {
+			w.written = true
+			prefix = fmt.Sprintf(`{"version":%q,"$schema":%q,"runs":[{"results":[`, sarifVersion, sarifSchema)
+		}
+
+		if _, err := io.WriteString(w.out, prefix); err != nil {
+			return err
+		}
+
+		if _, err := w.out.Write(result); err != nil {
+			return err
+		}
+
+		w.addRule(d)
+	}
+
+	return nil
+}
+
+func (w *sarifWriter) Close() error {
+	if !w.written {
+		return nil
+	}
+
+	tool, err := json.Marshal(map[string]any{
+		"driver": map[string]any{
+			"name":           sarifToolName,
+			"informationUri": "https://goharbor.io",
+			"rules":          w.rules,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w.out, `],"tool":%s}]}`, tool)
+	return err
+}
+
+func (w *sarifWriter) toResult(d *Data) *sarifResult {
+	text := fmt.Sprintf("%s %s in %s@%s is affected by %s", d.Package, d.Version, d.Repository, d.ArtifactDigest, d.CVEId)
+	if len(d.FixVersion) > 0 {
+		text = fmt.Sprintf("%s, fixed in version %s", text, d.FixVersion)
+	}
+
+	return &sarifResult{
+		RuleID:  d.CVEId,
+		Level:   sarifLevel(d.Severity),
+		Message: sarifMessage{Text: text},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: fmt.Sprintf("%s@%s", d.Repository, d.ArtifactDigest)},
+			},
+		}},
+		Properties: map[string]any{
+			"repository":      d.Repository,
+			"artifact_digest": d.ArtifactDigest,
+			"package":         d.Package,
+			"version":         d.Version,
+			"fixed_version":   d.FixVersion,
+			"severity":        d.Severity,
+			"cwe_ids":         d.CWEIds,
+			"scanner":         d.ScannerName,
+		},
+	}
+}
+
+func (w *sarifWriter) addRule(d *Data) {
+	if _, ok := w.indexed[d.CVEId]; ok {
+		return
+	}
+
+	rule := &sarifRule{
+		ID:               d.CVEId,
+		ShortDescription: sarifMessage{Text: d.CVEId},
+	}
+	if cwes := parseCWEIds(d.CWEIds); len(cwes) > 0 {
+		rule.Properties = map[string]any{"tags": cwes}
+	}
+
+	w.indexed[d.CVEId] = rule
+	w.rules = append(w.rules, rule)
+}
+
+// sarifLevel maps the severity of the vulnerability to the level of the SARIF result
+func sarifLevel(severity string) string {
+	switch vuln.ParseSeverityVersion3(severity) {
+	case vuln.Critical, vuln.High:
+		return "error"
+	case vuln.Medium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 )
@@ -25,6 +26,9 @@ import (
 const (
 	supportVulnerability = "support_vulnerability"
 	supportSBOM          = "support_sbom"
+
+	// PropertyVulnerabilityDBUpdatedAt is the scanner property reporting when the vulnerability database was last updated
+	PropertyVulnerabilityDBUpdatedAt = "harbor.scanner-adapter/vulnerability-database-updated-at"
 )
 
 var supportedMimeTypes = []string{
@@ -135,6 +139,22 @@ func (md *ScannerAdapterMetadata) GetCapability(mimeType string) *ScannerCapabil
 	return nil
 }
 
+// VulnerabilityDBUpdatedAt returns the last update time of the vulnerability database reported by the scanner,
+// the zero time is returned when the scanner does not report it or the value is malformed.
+func (md *ScannerAdapterMetadata) VulnerabilityDBUpdatedAt() time.Time {
+	v, ok := md.Properties[PropertyVulnerabilityDBUpdatedAt]
+	if !ok || len(v) == 0 {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
 // ConvertCapability converts the capability to map, used in get scanner API
 func (md *ScannerAdapterMetadata) ConvertCapability() map[string]any {
 	capabilities := make(map[string]any)
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +40,14 @@ func TestConvertCapabilityOldScaner(t *testing.T) {
 	assert.Equal(t, result[supportSBOM], false)
 	assert.Equal(t, result[supportVulnerability], true)
 }
+
+func TestVulnerabilityDBUpdatedAt(t *testing.T) {
+	md := &ScannerAdapterMetadata{}
+	assert.True(t, md.VulnerabilityDBUpdatedAt().IsZero())
+
+	md.Properties = ScannerProperties{PropertyVulnerabilityDBUpdatedAt: "not-a-time"}
+	assert.True(t, md.VulnerabilityDBUpdatedAt().IsZero())
+
+	md.Properties = ScannerProperties{PropertyVulnerabilityDBUpdatedAt: "2024-01-02T03:04:05Z"}
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), md.VulnerabilityDBUpdatedAt().UTC())
+}
@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/model"
+)
+
+func init() {
+	orm.RegisterModel(new(model.Package))
+}
+
+// PackageDAO is the data access object interface for the packages indexed from the sbom
+type PackageDAO interface {
+	// Create creates the packages in batch
+	Create(ctx context.Context, packages []*model.Package) error
+	// DeleteByArtifactID deletes the packages of the artifact
+	DeleteByArtifactID(ctx context.Context, artifactID int64) error
+	// List lists the packages with the given query
+	List(ctx context.Context, query *q.Query) ([]*model.Package, error)
+	// Count counts the packages with the given query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+}
+
+// NewPackageDAO returns an instance of the default PackageDAO
+func NewPackageDAO() PackageDAO {
+	return &packageDAO{}
+}
+
+type packageDAO struct{}
+
+func (d *packageDAO) Create(ctx context.Context, packages []*model.Package) error {
+	if len(packages) == 0 {
+		return nil
+	}
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = o.InsertMulti(100, packages)
+	return err
+}
+
+func (d *packageDAO) DeleteByArtifactID(ctx context.Context, artifactID int64) error {
+	qs, err := orm.QuerySetter(ctx, &model.Package{}, q.New(q.KeyWords{"ArtifactID": artifactID}))
+	if err != nil {
+		return err
+	}
+	_, err = qs.Delete()
+	return err
+}
+
+func (d *packageDAO) List(ctx context.Context, query *q.Query) ([]*model.Package, error) {
+	qs, err := orm.QuerySetter(ctx, &model.Package{}, query)
+	if err != nil {
+		return nil, err
+	}
+	packages := []*model.Package{}
+	if _, err = qs.All(&packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+func (d *packageDAO) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Package{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+// PackageTestSuite is test suite of testing package DAO.
+type PackageTestSuite struct {
+	htesting.Suite
+	dao PackageDAO
+}
+
+// TestPackage is the entry of PackageTestSuite.
+func TestPackage(t *testing.T) {
+	suite.Run(t, &PackageTestSuite{})
+}
+
+// SetupSuite prepares env for test suite.
+func (suite *PackageTestSuite) SetupSuite() {
+	suite.Suite.SetupSuite()
+	suite.dao = NewPackageDAO()
+}
+
+// SetupTest prepares env for test case.
+func (suite *PackageTestSuite) SetupTest() {
+	err := suite.dao.Create(orm.Context(), []*model.Package{
+		{ArtifactID: 1111, ProjectID: 1, RepositoryName: "library/app", Digest: "sha256:1111", Name: "log4j-core", Version: "2.14.1",
+			PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", License: "Apache-2.0"},
+		{ArtifactID: 1111, ProjectID: 1, RepositoryName: "library/app", Digest: "sha256:1111", Name: "openssl", Version: "3.0.2",
+			License: "OpenSSL"},
+		{ArtifactID: 2222, ProjectID: 2, RepositoryName: "other/app", Digest: "sha256:2222", Name: "Log4j-Core", Version: "2.17.1",
+			PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.17.1", License: "Apache-2.0"},
+	})
+	suite.Require().NoError(err)
+}
+
+// TearDownTest clears env for test case.
+func (suite *PackageTestSuite) TearDownTest() {
+	suite.Require().NoError(suite.dao.DeleteByArtifactID(orm.Context(), 1111))
+	suite.Require().NoError(suite.dao.DeleteByArtifactID(orm.Context(), 2222))
+}
+
+func (suite *PackageTestSuite) TestListByName() {
+	l, err := suite.dao.List(orm.Context(), q.New(q.KeyWords{"name": "LOG4J-core"}))
+	suite.Require().NoError(err)
+	suite.Require().Len(l, 2)
+	suite.Equal("2.14.1", l[0].Version)
+	suite.Equal("2.17.1", l[1].Version)
+}
+
+func (suite *PackageTestSuite) TestListByPURLPrefix() {
+	query := q.New(q.KeyWords{"purl_prefix": "pkg:maven/org.apache.logging.log4j/log4j-core@2.17"})
+	l, err := suite.dao.List(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Require().Len(l, 1)
+	suite.Equal(int64(2222), l[0].ArtifactID)
+
+	cnt, err := suite.dao.Count(orm.Context(), q.New(q.KeyWords{"purl_prefix": "pkg:maven/"}))
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), cnt)
+}
+
+func (suite *PackageTestSuite) TestListByProjects() {
+	query := q.New(q.KeyWords{"ProjectID": q.NewOrList([]any{int64(1)}), "License": &q.FuzzyMatchValue{Value: "apache"}})
+	l, err := suite.dao.List(orm.Context(), query)
+	suite.Require().NoError(err)
+	suite.Require().Len(l, 1)
+	suite.Equal("log4j-core", l[0].Name)
+}
+
+func (suite *PackageTestSuite) TestDeleteByArtifactID() {
+	suite.Require().NoError(suite.dao.DeleteByArtifactID(orm.Context(), 1111))
+	cnt, err := suite.dao.Count(orm.Context(), nil)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), cnt)
+}
@@ -17,9 +17,11 @@ package sbom
 import (
 	"context"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/uuid"
 
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/sbom/dao"
 	"github.com/goharbor/harbor/src/pkg/scan/sbom/model"
@@ -30,6 +32,9 @@ var (
 	Mgr = NewManager()
 )
 
+// maxVersionMatchCandidates is the max count of the packages to be matched with the version constraint
+const maxVersionMatchCandidates = 10000
+
 // Manager is used to manage the sbom reports.
 type Manager interface {
 	// Create a new report record.
@@ -96,19 +101,26 @@ type Manager interface {
 	Update(ctx context.Context, r *model.Report, cols ...string) error
 	// DeleteByExtraAttr delete scan_report by sbom_digest
 	DeleteByExtraAttr(ctx context.Context, mimeType, attrName, attrValue string) error
-	// DeleteByArtifactID delete sbom report by artifact id
+	// DeleteByArtifactID delete sbom report and the indexed packages by artifact id
 	DeleteByArtifactID(ctx context.Context, artifactID int64) error
+	// IndexPackages replaces the indexed packages of the artifact with the given packages
+	IndexPackages(ctx context.Context, artifactID int64, packages []*model.Package) error
+	// SearchPackages searches the indexed packages with the query, when the version constraint (e.g. "<2.17.0")
+	// is specified, only the packages with the semantic versions matching the constraint are returned
+	SearchPackages(ctx context.Context, query *q.Query, versionConstraint string) (int64, []*model.Package, error)
 }
 
 // basicManager is a default implementation of report manager.
 type basicManager struct {
-	dao dao.DAO
+	dao    dao.DAO
+	pkgDao dao.PackageDAO
 }
 
 // NewManager news basic manager.
 func NewManager() Manager {
 	return &basicManager{
-		dao: dao.New(),
+		dao:    dao.New(),
+		pkgDao: dao.NewPackageDAO(),
 	}
 }
 
@@ -198,6 +210,92 @@ func (bm *basicManager) DeleteByExtraAttr(ctx context.Context, mimeType, attrNam
 }
 
 func (bm *basicManager) DeleteByArtifactID(ctx context.Context, artifactID int64) error {
-	_, err := bm.dao.DeleteMany(ctx, *q.New(q.KeyWords{"ArtifactID": artifactID}))
-	return err
+	if _, err := bm.dao.DeleteMany(ctx, *q.New(q.KeyWords{"ArtifactID": artifactID})); err != nil {
+		return err
+	}
+	return bm.pkgDao.DeleteByArtifactID(ctx, artifactID)
+}
+
+func (bm *basicManager) IndexPackages(ctx context.Context, artifactID int64, packages []*model.Package) error {
+	if artifactID == 0 {
+		return errors.New("no artifact id to index the sbom packages")
+	}
+
+	h := func(ctx context.Context) error {
+		if err := bm.pkgDao.DeleteByArtifactID(ctx, artifactID); err != nil {
+			return err
+		}
+		for _, p := range packages {
+			p.ID = 0
+			p.ArtifactID = artifactID
+		}
+		return bm.pkgDao.Create(ctx, packages)
+	}
+	return orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-index-sbom-packages"))
+}
+
+func (bm *basicManager) SearchPackages(ctx context.Context, query *q.Query, versionConstraint string) (int64, []*model.Package, error) {
+	if len(versionConstraint) == 0 {
+		total, err := bm.pkgDao.Count(ctx, query)
+		if err != nil {
+			return 0, nil, err
+		}
+		packages, err := bm.pkgDao.List(ctx, query)
+		if err != nil {
+			return 0, nil, err
+		}
+		return total, packages, nil
+	}
+
+	constraint, err := semver.NewConstraint(versionConstraint)
+	if err != nil {
+		return 0, nil, errors.BadRequestError(err).WithMessagef("invalid version constraint %s", versionConstraint)
+	}
+
+	// the version constraint can't be evaluated by the database, list all the candidates and match them here
+	candidateQuery := &q.Query{}
+	if query != nil {
+		candidateQuery.Keywords = query.Keywords
+		candidateQuery.Sorts = query.Sorts
+	}
+	cnt, err := bm.pkgDao.Count(ctx, candidateQuery)
+	if err != nil {
+		return 0, nil, err
+	}
+	if cnt > maxVersionMatchCandidates {
+		return 0, nil, errors.BadRequestError(nil).
+			WithMessagef("too many packages (%d) to match the version constraint, narrow down the search by name or purl", cnt)
+	}
+	candidates, err := bm.pkgDao.List(ctx, candidateQuery)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var matched []*model.Package
+	for _, p := range candidates {
+		v, err := semver.NewVersion(p.Version)
+		if err != nil {
+			continue
+		}
+		if constraint.Check(v) {
+			matched = append(matched, p)
+		}
+	}
+
+	total := int64(len(matched))
+	if query != nil && query.PageSize > 0 {
+		start := int64(0)
+		if query.PageNumber > 1 {
+			start = (query.PageNumber - 1) * query.PageSize
+		}
+		if start >= total {
+			return total, []*model.Package{}, nil
+		}
+		end := start + query.PageSize
+		if end > total {
+			end = total
+		}
+		matched = matched[start:end]
+	}
+	return total, matched, nil
 }
@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// Package is a package listed in the SBOM of an artifact, it's indexed to search the artifacts containing the package
+type Package struct {
+	ID             int64     `orm:"pk;auto;column(id)"`
+	ArtifactID     int64     `orm:"column(artifact_id)"`
+	ProjectID      int64     `orm:"column(project_id)"`
+	RepositoryName string    `orm:"column(repository_name)"`
+	Digest         string    `orm:"column(digest)"`
+	Name           string    `orm:"column(name)"`
+	Version        string    `orm:"column(version)"`
+	PURL           string    `orm:"column(purl)"`
+	License        string    `orm:"column(license)"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add"`
+}
+
+// TableName for sbom package
+func (p *Package) TableName() string {
+	return "sbom_package"
+}
+
+// FilterByPURLPrefix filters the packages whose purl starts with the value,
+// e.g. "pkg:maven/org.apache.logging.log4j/log4j-core" matches all the versions of the package
+func (p *Package) FilterByPURLPrefix(_ context.Context, qs orm.QuerySeter, _ string, value any) orm.QuerySeter {
+	v, ok := value.(string)
+	if !ok || len(v) == 0 {
+		return qs
+	}
+	return qs.FilterRaw("purl", fmt.Sprintf("LIKE %s", orm.QuoteLiteral(orm.Escape(v)+"%")))
+}
+
+// FilterByName filters the packages by the name case-insensitively
+func (p *Package) FilterByName(_ context.Context, qs orm.QuerySeter, _ string, value any) orm.QuerySeter {
+	v, ok := value.(string)
+	if !ok || len(v) == 0 {
+		return qs
+	}
+	return qs.Filter("name__iexact", strings.TrimSpace(v))
+}
+
+// GetDefaultSorts sorts the packages by the name and version, then the artifact
+func (p *Package) GetDefaultSorts() []*q.Sort {
+	return []*q.Sort{
+		{Key: "Name"},
+		{Key: "Version"},
+		{Key: "RepositoryName"},
+		{Key: "Digest"},
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/model"
+)
+
+const (
+	// the max lengths of the columns of the sbom_package table
+	maxNameLength    = 255
+	maxVersionLength = 255
+	maxPURLLength    = 1024
+	maxLicenseLength = 1024
+)
+
+type spdxDocument struct {
+	SPDXVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name                  string             `json:"name"`
+	VersionInfo           string             `json:"versionInfo"`
+	LicenseConcluded      string             `json:"licenseConcluded"`
+	LicenseDeclared       string             `json:"licenseDeclared"`
+	PrimaryPackagePurpose string             `json:"primaryPackagePurpose"`
+	ExternalRefs          []*spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat  string                `json:"bomFormat"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string                `json:"type"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	PURL       string                `json:"purl"`
+	Licenses   []*cycloneDXLicense   `json:"licenses"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXLicense struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+// ParsePackages parses the packages listed in the SPDX or CycloneDX JSON SBOM,
+// the packages describing the container image itself are skipped and the duplicated packages are merged
+func ParsePackages(content []byte) ([]*model.Package, error) {
+	format := struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}{}
+	if err := json.Unmarshal(content, &format); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the sbom")
+	}
+
+	var packages []*model.Package
+	switch {
+	case len(format.SPDXVersion) > 0:
+		doc := &spdxDocument{}
+		if err := json.Unmarshal(content, doc); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the SPDX sbom")
+		}
+		packages = parseSPDXPackages(doc)
+	case format.BOMFormat == "CycloneDX":
+		doc := &cycloneDXDocument{}
+		if err := json.Unmarshal(content, doc); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the CycloneDX sbom")
+		}
+		packages = parseCycloneDXComponents(doc.Components)
+	default:
+		return nil, errors.New("unsupported sbom format, only the SPDX and CycloneDX JSON are supported")
+	}
+
+	return dedupPackages(packages), nil
+}
+
+func parseSPDXPackages(doc *spdxDocument) []*model.Package {
+	var packages []*model.Package
+	for _, p := range doc.Packages {
+		if p == nil || len(p.Name) == 0 || p.PrimaryPackagePurpose == "CONTAINER" {
+			continue
+		}
+
+		pkg := &model.Package{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			License: spdxLicense(p.LicenseConcluded),
+		}
+		if len(pkg.License) == 0 {
+			pkg.License = spdxLicense(p.LicenseDeclared)
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref != nil && ref.ReferenceType == "purl" {
+				pkg.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
+
+// spdxLicense returns the license expression, the special values NOASSERTION and NONE mean no license
+func spdxLicense(license string) string {
+	if license == "NOASSERTION" || license == "NONE" {
+		return ""
+	}
+	return license
+}
+
+func parseCycloneDXComponents(components []*cycloneDXComponent) []*model.Package {
+	var packages []*model.Package
+	for _, c := range components {
+		if c == nil {
+			continue
+		}
+
+		if len(c.Name) > 0 && c.Type != "container" {
+			var licenses []string
+			for _, l := range c.Licenses {
+				switch {
+				case l == nil:
+				case len(l.Expression) > 0:
+					licenses = append(licenses, l.Expression)
+				case l.License != nil && len(l.License.ID) > 0:
+					licenses = append(licenses, l.License.ID)
+				case l.License != nil && len(l.License.Name) > 0:
+					licenses = append(licenses, l.License.Name)
+				}
+			}
+			packages = append(packages, &model.Package{
+				Name:    c.Name,
+				Version: c.Version,
+				PURL:    c.PURL,
+				License: strings.Join(licenses, ", "),
+			})
+		}
+
+		// the components may be nested
+		packages = append(packages, parseCycloneDXComponents(c.Components)...)
+	}
+
+	return packages
+}
+
+// dedupPackages removes the duplicated packages and truncates the fields to fit the columns
+func dedupPackages(packages []*model.Package) []*model.Package {
+	result := make([]*model.Package, 0, len(packages))
+	indexed := map[string]bool{}
+	for _, p := range packages {
+		p.Name = truncate(p.Name, maxNameLength)
+		p.Version = truncate(p.Version, maxVersionLength)
+		p.PURL = truncate(p.PURL, maxPURLLength)
+		p.License = truncate(p.License, maxLicenseLength)
+
+		key := strings.Join([]string{p.Name, p.Version, p.PURL}, "|")
+		if indexed[key] {
+			continue
+		}
+		indexed[key] = true
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	// avoid cutting in the middle of a multi-byte character
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
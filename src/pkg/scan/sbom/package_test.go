@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/scan/sbom/model"
+)
+
+func TestParsePackagesSPDX(t *testing.T) {
+	content := `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "library/nginx", "primaryPackagePurpose": "CONTAINER"},
+    {"name": "log4j-core", "versionInfo": "2.14.1", "licenseConcluded": "NOASSERTION", "licenseDeclared": "Apache-2.0",
+     "externalRefs": [{"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:apache:log4j"},
+                      {"referenceType": "purl", "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]},
+    {"name": "log4j-core", "versionInfo": "2.14.1", "licenseDeclared": "Apache-2.0",
+     "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}]},
+    {"name": "openssl", "versionInfo": "3.0.2", "licenseConcluded": "OpenSSL"}
+  ]
+}`
+	packages, err := ParsePackages([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Package{
+		{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", License: "Apache-2.0"},
+		{Name: "openssl", Version: "3.0.2", License: "OpenSSL"},
+	}, packages)
+}
+
+func TestParsePackagesCycloneDX(t *testing.T) {
+	content := `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"type": "container", "name": "library/nginx"},
+    {"type": "library", "name": "spring-core", "version": "5.3.9", "purl": "pkg:maven/org.springframework/spring-core@5.3.9",
+     "licenses": [{"license": {"id": "Apache-2.0"}}, {"license": {"name": "Custom"}}],
+     "components": [{"type": "library", "name": "jcl", "version": "1.0", "licenses": [{"expression": "MIT OR Apache-2.0"}]}]}
+  ]
+}`
+	packages, err := ParsePackages([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Package{
+		{Name: "spring-core", Version: "5.3.9", PURL: "pkg:maven/org.springframework/spring-core@5.3.9", License: "Apache-2.0, Custom"},
+		{Name: "jcl", Version: "1.0", License: "MIT OR Apache-2.0"},
+	}, packages)
+}
+
+func TestParsePackagesUnsupported(t *testing.T) {
+	_, err := ParsePackages([]byte(`{"key": "value"}`))
+	assert.Error(t, err)
+
+	_, err = ParsePackages([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// the multi-byte character is not cut
+	assert.Equal(t, "a", truncate("a"+strings.Repeat("é", 2), 2))
+}
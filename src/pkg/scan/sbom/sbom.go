@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
@@ -83,7 +84,7 @@ func (h *scanHandler) RequestParameters() map[string]any {
 }
 
 // PostScan defines task specific operations after the scan is complete
-func (h *scanHandler) PostScan(ctx job.Context, sr *v1.ScanRequest, _ *scanModel.Report, rawReport string, startTime time.Time, robot *model.Robot) (string, error) {
+func (h *scanHandler) PostScan(ctx job.Context, sr *v1.ScanRequest, rp *scanModel.Report, rawReport string, startTime time.Time, robot *model.Robot) (string, error) {
 	sbomContent, s, err := retrieveSBOMContent(rawReport)
 	if err != nil {
 		return "", err
@@ -106,9 +107,37 @@ func (h *scanHandler) PostScan(ctx job.Context, sr *v1.ScanRequest, _ *scanModel
 		myLogger.Errorf("error when create accessory from image %v", err)
 		return "", err
 	}
+	if rp != nil {
+		// the package index is used by search only, failing to index doesn't fail the scan
+		if err := h.indexPackages(ctx.SystemContext(), sr.Artifact, rp.UUID, sbomContent); err != nil {
+			myLogger.Warningf("failed to index the packages of the sbom for %s@%s: %v", sr.Artifact.Repository, sr.Artifact.Digest, err)
+		}
+	}
 	return h.generateReport(startTime, sr.Artifact.Repository, dgst, "Success", s)
 }
 
+// indexPackages indexes the packages listed in the sbom content for the artifact of the report
+func (h *scanHandler) indexPackages(ctx context.Context, art *v1.Artifact, reportUUID string, sbomContent []byte) error {
+	packages, err := ParsePackages(sbomContent)
+	if err != nil {
+		return err
+	}
+	mgr := h.SBOMMgrFunc()
+	reports, err := mgr.List(ctx, q.New(q.KeyWords{"uuid": reportUUID}))
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		return errors.NotFoundError(nil).WithMessagef("sbom report %s not found", reportUUID)
+	}
+	for _, p := range packages {
+		p.ProjectID = art.NamespaceID
+		p.RepositoryName = art.Repository
+		p.Digest = art.Digest
+	}
+	return mgr.IndexPackages(ctx, reports[0].ArtifactID, packages)
+}
+
 // URLParameter defines the parameters for scan report url
 func (h *scanHandler) URLParameter(_ *v1.ScanRequest) (string, error) {
 	return fmt.Sprintf("sbom_media_type=%s", url.QueryEscape(sbomMediaTypeSpdx)), nil
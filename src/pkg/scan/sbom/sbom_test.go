@@ -15,6 +15,7 @@ import (
 	accessoryModel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	art "github.com/goharbor/harbor/src/pkg/artifact"
+	scanModel "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	sbomModel "github.com/goharbor/harbor/src/pkg/scan/sbom/model"
 	htesting "github.com/goharbor/harbor/src/testing"
 	artifactTest "github.com/goharbor/harbor/src/testing/controller/artifact"
@@ -193,6 +194,31 @@ func (suite *SBOMTestSuite) TestPostScan() {
 	suite.Require().NotEmpty(accessory)
 }
 
+func (suite *SBOMTestSuite) TestPostScanIndexPackages() {
+	req := &v1.ScanRequest{
+		Registry: &v1.Registry{
+			URL: "myregistry.example.com",
+		},
+		Artifact: &v1.Artifact{
+			NamespaceID: 1,
+			Repository:  "library/nosql",
+			Digest:      "sha256:digest",
+		},
+	}
+	rawReport := `{"sbom": {"spdxVersion": "SPDX-2.3", "packages": [{"name": "log4j-core", "versionInfo": "2.14.1"}]}}`
+	ctx := &jobservice.MockJobContext{}
+	ctx.On("GetLogger").Return(&jobservice.MockJobLogger{})
+	mock.OnAnything(suite.sbomManager, "List").Return([]*sbomModel.Report{{UUID: "uuid", ArtifactID: 2}}, nil).Once()
+	packages := []*sbomModel.Package{
+		{ProjectID: 1, RepositoryName: "library/nosql", Digest: "sha256:digest", Name: "log4j-core", Version: "2.14.1"},
+	}
+	suite.sbomManager.On("IndexPackages", mock.Anything, int64(2), packages).Return(nil).Once()
+	accessory, err := suite.handler.PostScan(ctx, req, &scanModel.Report{UUID: "uuid"}, rawReport, time.Now(), &model.Robot{Name: "robot"})
+	suite.Require().NoError(err)
+	suite.NotEmpty(accessory)
+	suite.sbomManager.AssertCalled(suite.T(), "IndexPackages", mock.Anything, int64(2), packages)
+}
+
 func (suite *SBOMTestSuite) TestMakeReportPlaceHolder() {
 	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
 	acc := &basemodel.Default{
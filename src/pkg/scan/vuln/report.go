@@ -95,6 +95,75 @@ func (report *Report) Merge(another *Report) *Report {
 	return r
 }
 
+// MergeScannerReports merges the reports generated by different scanners for the same artifact.
+// The vulnerabilities found by several scanners are de-duplicated, the highest severity is kept for them.
+// When requireAll is true, only the vulnerabilities found by all the scanners are kept with the lowest severity,
+// that's the severity all the scanners agree on.
+func MergeScannerReports(requireAll bool, reports ...*Report) *Report {
+	var available []*Report
+	for _, rp := range reports {
+		if rp != nil {
+			available = append(available, rp)
+		}
+	}
+
+	if len(available) == 0 {
+		return nil
+	}
+
+	if len(available) == 1 {
+		return available[0]
+	}
+
+	var (
+		items   []*VulnerabilityItem
+		indexed = map[string]*VulnerabilityItem{}
+		found   = map[string]int{}
+	)
+
+	r := &Report{GeneratedAt: available[0].GeneratedAt, Scanner: available[0].Scanner}
+	for _, rp := range available {
+		if rp.GeneratedAt > r.GeneratedAt {
+			r.GeneratedAt = rp.GeneratedAt
+			r.Scanner = rp.Scanner
+		}
+
+		for _, v := range rp.GetVulnerabilityItemList().Items() {
+			key := v.Key()
+			item, ok := indexed[key]
+			if !ok {
+				// copy the item to avoid changing the original report
+				cp := *v
+				indexed[key] = &cp
+				found[key] = 1
+				items = append(items, &cp)
+				continue
+			}
+
+			found[key]++
+			if requireAll && v.Severity.Code() < item.Severity.Code() ||
+				!requireAll && v.Severity.Code() > item.Severity.Code() {
+				item.Severity = v.Severity
+			}
+		}
+	}
+
+	l := &VulnerabilityItemList{}
+	for _, item := range items {
+		if requireAll && found[item.Key()] < len(available) {
+			continue
+		}
+
+		l.Add(item)
+	}
+
+	r.Severity, _ = l.GetSeveritySummary()
+	r.Vulnerabilities = l.Items()
+	r.vulnerabilityItemList = l
+
+	return r
+}
+
 // WithArtifactDigest set artifact digest for the report
 func (report *Report) WithArtifactDigest(artifactDigest string) {
 	for _, vul := range report.Vulnerabilities {
@@ -69,6 +69,51 @@ func TestReport_Merge(t *testing.T) {
 	}
 }
 
+func TestMergeScannerReports(t *testing.T) {
+	assert := assert.New(t)
+
+	trivy := &Report{
+		GeneratedAt: "2020-04-06T18:38:34.791086859Z",
+		Scanner:     &v1.Scanner{Name: "Trivy"},
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2017-8283", Package: "dpkg", Version: "1.17.27", Severity: Critical},
+			{ID: "CVE-2017-8284", Package: "dpkg", Version: "1.17.27", Severity: Low},
+		},
+	}
+	other := &Report{
+		GeneratedAt: "2020-04-06T18:38:34.791086860Z",
+		Scanner:     &v1.Scanner{Name: "Other"},
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2017-8283", Package: "dpkg", Version: "1.17.27", Severity: Medium},
+			{ID: "CVE-2017-8285", Package: "libc", Version: "2.27", Severity: High},
+		},
+	}
+
+	assert.Nil(MergeScannerReports(false))
+	assert.Equal(trivy, MergeScannerReports(false, nil, trivy))
+
+	// any scanner
+	r := MergeScannerReports(false, trivy, other)
+	assert.Equal("Other", r.Scanner.Name)
+	assert.Equal(Critical, r.Severity)
+	if assert.Len(r.Vulnerabilities, 3) {
+		assert.Equal("CVE-2017-8283", r.Vulnerabilities[0].ID)
+		assert.Equal(Critical, r.Vulnerabilities[0].Severity)
+	}
+
+	// all scanners
+	r = MergeScannerReports(true, trivy, other)
+	assert.Equal(Medium, r.Severity)
+	if assert.Len(r.Vulnerabilities, 1) {
+		assert.Equal("CVE-2017-8283", r.Vulnerabilities[0].ID)
+		assert.Equal(Medium, r.Vulnerabilities[0].Severity)
+	}
+
+	// the original reports are not changed
+	assert.Equal(Critical, trivy.Vulnerabilities[0].Severity)
+	assert.Equal(Medium, other.Vulnerabilities[0].Severity)
+}
+
 func TestReportMarshalJSON(t *testing.T) {
 	assert := assert.New(t)
 
@@ -17,6 +17,7 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -184,6 +185,18 @@ type SecurityHubDao interface {
 	ListVulnerabilities(ctx context.Context, registrationUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error)
 	// CountVulnerabilities count the total vulnerabilities
 	CountVulnerabilities(ctx context.Context, registrationUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error)
+	// ProjectSnapshots returns the current vulnerability aggregates of all the projects for the scanner
+	ProjectSnapshots(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error)
+	// SaveSnapshot saves the snapshot, the existing snapshot of the same project and date is overwritten
+	SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error
+	// ListSnapshots lists the snapshots of the project between the dates
+	ListSnapshots(ctx context.Context, projectID int64, from, to time.Time) ([]*model.Snapshot, error)
+	// TrackRemediation records the CVEs first seen and remediated at the date
+	TrackRemediation(ctx context.Context, scannerUUID string, date time.Time) error
+	// ListRemediationTimes lists the mean time to remediate the CVEs remediated between the dates
+	ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*model.RemediationTime, error)
+	// CountRemediationTimes counts the CVEs remediated between the dates
+	CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error)
 }
 
 // New creates a new SecurityHubDao instance.
@@ -0,0 +1,248 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+const (
+	// sql to aggregate the vulnerabilities of the scanned artifacts per project,
+	// an artifact is exceeding when its vulnerabilities reach the severity threshold configured in the project
+	projectSnapshotSQL = `SELECT a.project_id,
+       COALESCE(SUM(s.critical_cnt), 0) critical_cnt,
+       COALESCE(SUM(s.high_cnt), 0)     high_cnt,
+       COALESCE(SUM(s.medium_cnt), 0)   medium_cnt,
+       COALESCE(SUM(s.low_cnt), 0)      low_cnt,
+       COALESCE(SUM(s.none_cnt), 0)     none_cnt,
+       COALESCE(SUM(s.unknown_cnt), 0)  unknown_cnt,
+       COALESCE(SUM(s.fixable_cnt), 0)  fixable_cnt,
+       COUNT(DISTINCT a.id)             scanned_cnt,
+       COUNT(DISTINCT CASE
+           WHEN lower(pm.value) = 'critical' AND COALESCE(s.critical_cnt, 0) > 0 THEN a.id
+           WHEN lower(pm.value) = 'high' AND COALESCE(s.critical_cnt, 0) + COALESCE(s.high_cnt, 0) > 0 THEN a.id
+           WHEN lower(pm.value) = 'medium' AND COALESCE(s.critical_cnt, 0) + COALESCE(s.high_cnt, 0) + COALESCE(s.medium_cnt, 0) > 0 THEN a.id
+           WHEN lower(pm.value) = 'low' AND COALESCE(s.critical_cnt, 0) + COALESCE(s.high_cnt, 0) + COALESCE(s.medium_cnt, 0) + COALESCE(s.low_cnt, 0) > 0 THEN a.id
+           WHEN lower(pm.value) = 'none' AND COALESCE(s.critical_cnt, 0) + COALESCE(s.high_cnt, 0) + COALESCE(s.medium_cnt, 0) + COALESCE(s.low_cnt, 0) + COALESCE(s.none_cnt, 0) > 0 THEN a.id
+           END)                         exceeding_cnt
+FROM artifact a
+         JOIN scan_report s ON a.digest = s.digest AND s.registration_uuid = ?
+         LEFT JOIN project_metadata pm ON pm.project_id = a.project_id AND pm.name = 'severity'
+GROUP BY a.project_id`
+
+	// sql to count the artifacts per project
+	projectArtifactCountSQL = `SELECT project_id, COUNT(1) total_artifact_cnt FROM artifact GROUP BY project_id`
+
+	// sql to save the snapshot, the snapshot of the same day is overwritten
+	saveSnapshotSQL = `INSERT INTO security_snapshot (project_id, snapshot_date, critical_cnt, high_cnt, medium_cnt, low_cnt, none_cnt,
+                               unknown_cnt, fixable_cnt, total_artifact_cnt, scanned_cnt, exceeding_cnt)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (project_id, snapshot_date) DO UPDATE SET critical_cnt       = EXCLUDED.critical_cnt,
+                                                      high_cnt           = EXCLUDED.high_cnt,
+                                                      medium_cnt         = EXCLUDED.medium_cnt,
+                                                      low_cnt            = EXCLUDED.low_cnt,
+                                                      none_cnt           = EXCLUDED.none_cnt,
+                                                      unknown_cnt        = EXCLUDED.unknown_cnt,
+                                                      fixable_cnt        = EXCLUDED.fixable_cnt,
+                                                      total_artifact_cnt = EXCLUDED.total_artifact_cnt,
+                                                      scanned_cnt        = EXCLUDED.scanned_cnt,
+                                                      exceeding_cnt      = EXCLUDED.exceeding_cnt`
+
+	// sql to list the snapshots of the project in the date range
+	listSnapshotSQL = `SELECT project_id, snapshot_date, critical_cnt, high_cnt, medium_cnt, low_cnt, none_cnt,
+       unknown_cnt, fixable_cnt, total_artifact_cnt, scanned_cnt, exceeding_cnt
+FROM security_snapshot
+WHERE project_id = ?
+  AND snapshot_date BETWEEN ? AND ?
+ORDER BY snapshot_date`
+
+	// sql to query the CVEs found in the projects by the scanner
+	projectCVESQL = `SELECT DISTINCT a.project_id, vr.cve_id
+FROM artifact a
+         JOIN scan_report s ON a.digest = s.digest
+         JOIN report_vulnerability_record rvr ON s.uuid = rvr.report_uuid
+         JOIN vulnerability_record vr ON rvr.vuln_record_id = vr.id
+WHERE vr.registration_uuid = ?`
+
+	// sql to open the remediation records for the CVEs which are newly found in the projects
+	openRemediationSQL = `INSERT INTO cve_remediation (project_id, cve_id, first_seen)
+SELECT c.project_id, c.cve_id, ?
+FROM (` + projectCVESQL + `) c
+WHERE NOT EXISTS (SELECT 1
+                  FROM cve_remediation cr
+                  WHERE cr.project_id = c.project_id
+                    AND cr.cve_id = c.cve_id
+                    AND cr.remediated_date IS NULL)`
+
+	// sql to close the remediation records for the CVEs which are no longer found in the projects
+	closeRemediationSQL = `UPDATE cve_remediation cr
+SET remediated_date = ?
+WHERE cr.remediated_date IS NULL
+  AND NOT EXISTS (SELECT 1
+                  FROM artifact a
+                           JOIN scan_report s ON a.digest = s.digest
+                           JOIN report_vulnerability_record rvr ON s.uuid = rvr.report_uuid
+                           JOIN vulnerability_record vr ON rvr.vuln_record_id = vr.id
+                  WHERE vr.registration_uuid = ?
+                    AND a.project_id = cr.project_id
+                    AND vr.cve_id = cr.cve_id)`
+
+	// sql to calculate the mean days to remediate the CVEs remediated in the date range
+	remediationTimeSQL = `SELECT cve_id, COUNT(1) remediated_cnt, AVG(remediated_date - first_seen)::float8 mean_days
+FROM cve_remediation
+WHERE remediated_date BETWEEN ? AND ?`
+
+	dateLayout = "2006-01-02"
+)
+
+// ProjectSnapshots returns the current vulnerability aggregates of all the projects for the scanner,
+// the snapshots of the projects without any scanned artifact only contain the count of the artifacts
+func (d *dao) ProjectSnapshots(ctx context.Context, scannerUUID string) ([]*model.Snapshot, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []*model.Snapshot
+	if _, err = o.Raw(projectArtifactCountSQL).QueryRows(&counts); err != nil {
+		return nil, err
+	}
+
+	var scanned []*model.Snapshot
+	if len(scannerUUID) > 0 {
+		if _, err = o.Raw(projectSnapshotSQL, scannerUUID).QueryRows(&scanned); err != nil {
+			return nil, err
+		}
+	}
+
+	indexed := make(map[int64]*model.Snapshot, len(scanned))
+	for _, s := range scanned {
+		indexed[s.ProjectID] = s
+	}
+
+	snapshots := make([]*model.Snapshot, 0, len(counts))
+	for _, c := range counts {
+		s, ok := indexed[c.ProjectID]
+		if !ok {
+			s = &model.Snapshot{ProjectID: c.ProjectID}
+		}
+		s.TotalArtifactCnt = c.TotalArtifactCnt
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, nil
+}
+
+// SaveSnapshot saves the snapshot, the existing snapshot of the same project and date is overwritten
+func (d *dao) SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.Raw(saveSnapshotSQL, snapshot.ProjectID, snapshot.Date.Format(dateLayout),
+		snapshot.CriticalCnt, snapshot.HighCnt, snapshot.MediumCnt, snapshot.LowCnt, snapshot.NoneCnt,
+		snapshot.UnknownCnt, snapshot.FixableCnt, snapshot.TotalArtifactCnt, snapshot.ScannedCnt, snapshot.ExceedingCnt).Exec()
+	return err
+}
+
+// ListSnapshots lists the snapshots of the project between the dates, both inclusive
+func (d *dao) ListSnapshots(ctx context.Context, projectID int64, from, to time.Time) ([]*model.Snapshot, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*model.Snapshot, 0)
+	_, err = o.Raw(listSnapshotSQL, projectID, from.Format(dateLayout), to.Format(dateLayout)).QueryRows(&snapshots)
+	return snapshots, err
+}
+
+// TrackRemediation records the CVEs newly found in the projects by the scanner as first seen at the date,
+// and the CVEs no longer found as remediated at the date
+func (d *dao) TrackRemediation(ctx context.Context, scannerUUID string, date time.Time) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	day := date.Format(dateLayout)
+	if _, err = o.Raw(closeRemediationSQL, day, scannerUUID).Exec(); err != nil {
+		return err
+	}
+
+	_, err = o.Raw(openRemediationSQL, day, scannerUUID).Exec()
+	return err
+}
+
+// ListRemediationTimes lists the mean time to remediate the CVEs remediated between the dates,
+// the CVEs taking the longest time are listed first, projectID 0 is for all the projects
+func (d *dao) ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*model.RemediationTime, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStr, params := remediationTimeFilter(projectID, from, to)
+	sqlStr = fmt.Sprintf("%s GROUP BY cve_id ORDER BY mean_days DESC, cve_id", sqlStr)
+	sqlStr, params = applyRemediationPagination(sqlStr, query, params)
+
+	times := make([]*model.RemediationTime, 0)
+	_, err = o.Raw(sqlStr, params).QueryRows(&times)
+	return times, err
+}
+
+// CountRemediationTimes counts the CVEs remediated between the dates, projectID 0 is for all the projects
+func (d *dao) CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlStr, params := remediationTimeFilter(projectID, from, to)
+	var cnt int64
+	err = o.Raw(countSQL(fmt.Sprintf("%s GROUP BY cve_id", sqlStr)), params).QueryRow(&cnt)
+	return cnt, err
+}
+
+func remediationTimeFilter(projectID int64, from, to time.Time) (string, []any) {
+	sqlStr := remediationTimeSQL
+	params := []any{from.Format(dateLayout), to.Format(dateLayout)}
+	if projectID != 0 {
+		sqlStr = fmt.Sprintf("%s AND project_id = ?", sqlStr)
+		params = append(params, projectID)
+	}
+
+	return sqlStr, params
+}
+
+func applyRemediationPagination(sqlStr string, query *q.Query, params []any) (string, []any) {
+	offSet := int64(0)
+	pageSize := int64(15)
+	if query != nil && query.PageNumber > 1 {
+		offSet = (query.PageNumber - 1) * query.PageSize
+	}
+	if query != nil && query.PageSize > 0 {
+		pageSize = query.PageSize
+	}
+	params = append(params, pageSize, offSet)
+	return fmt.Sprintf("%v LIMIT ? OFFSET ?", sqlStr), params
+}
@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	testDao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/pkg/securityhub/model"
+)
+
+func (suite *SecurityDaoTestSuite) TestSnapshots() {
+	ctx := suite.Context()
+	testDao.ExecuteBatchSQL([]string{`insert into project_metadata (project_id, name, value) values (1, 'severity', 'high') on conflict do nothing`})
+	defer testDao.ExecuteBatchSQL([]string{
+		`delete from project_metadata where project_id = 1 and name = 'severity'`,
+		`delete from security_snapshot where project_id = 1`,
+	})
+
+	snapshots, err := suite.dao.ProjectSnapshots(ctx, "ruuid")
+	suite.Require().NoError(err)
+	var snapshot *model.Snapshot
+	for _, s := range snapshots {
+		if s.ProjectID == 1 {
+			snapshot = s
+		}
+	}
+	suite.Require().NotNil(snapshot)
+	suite.Equal(int64(50), snapshot.CriticalCnt)
+	suite.Equal(int64(20), snapshot.FixableCnt)
+	suite.Equal(int64(1), snapshot.ScannedCnt)
+	suite.Equal(int64(1), snapshot.ExceedingCnt)
+	suite.Equal(int64(3), snapshot.TotalArtifactCnt)
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	snapshot.Date = date
+	suite.Require().NoError(suite.dao.SaveSnapshot(ctx, snapshot))
+	// the snapshot of the same day is overwritten
+	snapshot.CriticalCnt = 40
+	suite.Require().NoError(suite.dao.SaveSnapshot(ctx, snapshot))
+
+	list, err := suite.dao.ListSnapshots(ctx, 1, date.AddDate(0, 0, -7), date)
+	suite.Require().NoError(err)
+	suite.Require().Len(list, 1)
+	suite.Equal(int64(40), list[0].CriticalCnt)
+	suite.Equal(date.Format(dateLayout), list[0].Date.Format(dateLayout))
+
+	list, err = suite.dao.ListSnapshots(ctx, 1, date.AddDate(0, 0, 1), date.AddDate(0, 0, 7))
+	suite.Require().NoError(err)
+	suite.Len(list, 0)
+}
+
+func (suite *SecurityDaoTestSuite) TestRemediation() {
+	ctx := suite.Context()
+	defer testDao.ExecuteBatchSQL([]string{`delete from cve_remediation where project_id = 1`})
+
+	firstSeen := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.dao.TrackRemediation(ctx, "ruuid", firstSeen))
+	// tracking again doesn't open another record for the same CVE
+	suite.Require().NoError(suite.dao.TrackRemediation(ctx, "ruuid", firstSeen.AddDate(0, 0, 1)))
+
+	// the CVE is fixed
+	testDao.ExecuteBatchSQL([]string{`delete from report_vulnerability_record where report_uuid = 'uuid' and vuln_record_id = 1`})
+	remediated := firstSeen.AddDate(0, 0, 3)
+	suite.Require().NoError(suite.dao.TrackRemediation(ctx, "ruuid", remediated))
+
+	times, err := suite.dao.ListRemediationTimes(ctx, 1, firstSeen, remediated, nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(times, 1)
+	suite.Equal("2023-4567-12345", times[0].CVEID)
+	suite.Equal(int64(1), times[0].RemediatedCnt)
+	suite.Equal(float64(3), times[0].MeanDays)
+
+	cnt, err := suite.dao.CountRemediationTimes(ctx, 0, firstSeen, remediated)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), cnt)
+
+	cnt, err = suite.dao.CountRemediationTimes(ctx, 1, remediated.AddDate(0, 0, 1), remediated.AddDate(0, 0, 7))
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), cnt)
+}
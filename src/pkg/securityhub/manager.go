@@ -16,7 +16,9 @@ package securityhub
 
 import (
 	"context"
+	"time"
 
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	"github.com/goharbor/harbor/src/pkg/securityhub/dao"
@@ -44,6 +46,15 @@ type Manager interface {
 	TotalVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error)
 	// ListVuls returns vulnerabilities list
 	ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error)
+	// TakeSnapshot takes the snapshot of the vulnerability aggregates of all the projects and the whole system at the date,
+	// and tracks the remediation of the CVEs found by the scanner
+	TakeSnapshot(ctx context.Context, scannerUUID string, date time.Time) error
+	// ListSnapshots lists the daily snapshots of the project between the dates, projectID 0 is for the whole system
+	ListSnapshots(ctx context.Context, projectID int64, from, to time.Time) ([]*model.Snapshot, error)
+	// ListRemediationTimes lists the mean time to remediate the CVEs remediated between the dates
+	ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*model.RemediationTime, error)
+	// CountRemediationTimes counts the CVEs remediated between the dates
+	CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error)
 }
 
 // NewManager news security manager.
@@ -85,3 +96,45 @@ func (s *securityManager) TotalVuls(ctx context.Context, scannerUUID string, pro
 func (s *securityManager) ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error) {
 	return s.dao.ListVulnerabilities(ctx, scannerUUID, projectID, query)
 }
+
+func (s *securityManager) TakeSnapshot(ctx context.Context, scannerUUID string, date time.Time) error {
+	h := func(ctx context.Context) error {
+		snapshots, err := s.dao.ProjectSnapshots(ctx, scannerUUID)
+		if err != nil {
+			return err
+		}
+
+		system := &model.Snapshot{ProjectID: 0, Date: date}
+		for _, snapshot := range snapshots {
+			snapshot.Date = date
+			if err := s.dao.SaveSnapshot(ctx, snapshot); err != nil {
+				return err
+			}
+			system.Add(snapshot)
+		}
+
+		if err := s.dao.SaveSnapshot(ctx, system); err != nil {
+			return err
+		}
+
+		// nothing to track when no scanner is configured
+		if len(scannerUUID) == 0 {
+			return nil
+		}
+		return s.dao.TrackRemediation(ctx, scannerUUID, date)
+	}
+
+	return orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-take-security-snapshot"))
+}
+
+func (s *securityManager) ListSnapshots(ctx context.Context, projectID int64, from, to time.Time) ([]*model.Snapshot, error) {
+	return s.dao.ListSnapshots(ctx, projectID, from, to)
+}
+
+func (s *securityManager) ListRemediationTimes(ctx context.Context, projectID int64, from, to time.Time, query *q.Query) ([]*model.RemediationTime, error) {
+	return s.dao.ListRemediationTimes(ctx, projectID, from, to, query)
+}
+
+func (s *securityManager) CountRemediationTimes(ctx context.Context, projectID int64, from, to time.Time) (int64, error) {
+	return s.dao.CountRemediationTimes(ctx, projectID, from, to)
+}
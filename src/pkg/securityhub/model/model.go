@@ -14,7 +14,11 @@
 
 package model
 
-import "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+)
 
 // Summary is the summary of scan result
 type Summary struct {
@@ -52,3 +56,43 @@ type VulnerabilityItem struct {
 	Tags           []string `orm:"-"`
 	ProjectID      int64    `orm:"column(project_id)"`
 }
+
+// Snapshot is the daily snapshot of the vulnerability aggregates of a project, the project ID 0 is for the whole system
+type Snapshot struct {
+	ProjectID        int64     `json:"project_id" orm:"column(project_id)"`
+	Date             time.Time `json:"date" orm:"column(snapshot_date)"`
+	CriticalCnt      int64     `json:"critical_cnt" orm:"column(critical_cnt)"`
+	HighCnt          int64     `json:"high_cnt" orm:"column(high_cnt)"`
+	MediumCnt        int64     `json:"medium_cnt" orm:"column(medium_cnt)"`
+	LowCnt           int64     `json:"low_cnt" orm:"column(low_cnt)"`
+	NoneCnt          int64     `json:"none_cnt" orm:"column(none_cnt)"`
+	UnknownCnt       int64     `json:"unknown_cnt" orm:"column(unknown_cnt)"`
+	FixableCnt       int64     `json:"fixable_cnt" orm:"column(fixable_cnt)"`
+	TotalArtifactCnt int64     `json:"total_artifact_cnt" orm:"column(total_artifact_cnt)"`
+	ScannedCnt       int64     `json:"scanned_cnt" orm:"column(scanned_cnt)"`
+	// ExceedingCnt is the count of artifacts whose vulnerabilities reach the severity threshold of the project
+	ExceedingCnt int64 `json:"exceeding_cnt" orm:"column(exceeding_cnt)"`
+}
+
+// Add adds the counts of another snapshot to the s
+func (s *Snapshot) Add(another *Snapshot) {
+	s.CriticalCnt += another.CriticalCnt
+	s.HighCnt += another.HighCnt
+	s.MediumCnt += another.MediumCnt
+	s.LowCnt += another.LowCnt
+	s.NoneCnt += another.NoneCnt
+	s.UnknownCnt += another.UnknownCnt
+	s.FixableCnt += another.FixableCnt
+	s.TotalArtifactCnt += another.TotalArtifactCnt
+	s.ScannedCnt += another.ScannedCnt
+	s.ExceedingCnt += another.ExceedingCnt
+}
+
+// RemediationTime is the mean time to remediate a CVE
+type RemediationTime struct {
+	CVEID string `json:"cve_id" orm:"column(cve_id)"`
+	// RemediatedCnt is the count of the remediations of the CVE, a CVE is remediated once per project
+	RemediatedCnt int64 `json:"remediated_cnt" orm:"column(remediated_cnt)"`
+	// MeanDays is the mean days from the CVE is first seen to it is remediated
+	MeanDays float64 `json:"mean_days" orm:"column(mean_days)"`
+}
@@ -17,6 +17,7 @@ package dao
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -62,8 +63,36 @@ const (
 	// execStatusOutdateBatchSize is the max number of members consumed from the
 	// set in a single SPOP call.
 	execStatusOutdateBatchSize = 100
+
+	// ProjectIDKeyword is the query keyword to filter the executions of the project. The execution
+	// doesn't record the project, it's resolved from the vendor resources scoped in the project
+	ProjectIDKeyword = "project_id"
+	// DurationKeyword is the query keyword to filter the finished executions by the duration in seconds,
+	// e.g. 'query.Keywords["duration"]=&q.Range{Min: 60, Max: 600}'
+	DurationKeyword = "duration"
 )
 
+// projectScopedVendors are the vendor types whose vendor ID references a resource of a project
+var projectScopedVendors = []struct {
+	vendorTypes []string
+	idSQL       string
+}{
+	{[]string{job.ImageScanJobVendorType, job.SBOMJobVendorType}, "SELECT id FROM artifact WHERE project_id = ?"},
+	{[]string{job.P2PPreheatVendorType}, "SELECT id FROM p2p_preheat_policy WHERE project_id = ?"},
+	{[]string{job.WebhookJobVendorType, job.SlackJobVendorType}, "SELECT id FROM notification_policy WHERE project_id = ?"},
+	{[]string{job.RetentionVendorType}, "SELECT id FROM retention_policy WHERE scope_level = 'project' AND scope_reference = ?"},
+}
+
+// columns of the execution can be filtered in the raw SQL
+var executionFilterColumns = map[string]string{
+	"VendorType": "vendor_type",
+	"VendorID":   "vendor_id",
+	"Status":     "status",
+	"Trigger":    "trigger",
+	"StartTime":  "start_time",
+	"EndTime":    "end_time",
+}
+
 // ExecutionStatusChangePostFunc is the function called after the execution status changed
 type ExecutionStatusChangePostFunc func(ctx context.Context, executionID int64, status string) (err error)
 
@@ -85,6 +114,10 @@ type ExecutionDAO interface {
 	Delete(ctx context.Context, id int64) (err error)
 	// GetMetrics returns the task metrics for the specified execution
 	GetMetrics(ctx context.Context, id int64) (metrics *Metrics, err error)
+	// ListMetrics returns the task metrics for the specified executions with one query, keyed by the execution ID
+	ListMetrics(ctx context.Context, ids ...int64) (metrics map[int64]*Metrics, err error)
+	// Aggregate the executions matching the query by vendor type, the pagination and sorting of the query are ignored
+	Aggregate(ctx context.Context, query *q.Query) (stats []*ExecutionStats, err error)
 	// RefreshStatus refreshes the status of the specified execution according to it's tasks. If it's status
 	// is final, update the end time as well
 	// If the status is changed, the returning "statusChanged" is set as "true" and the current status indicates
@@ -199,34 +232,68 @@ func (e *executionDAO) GetMetrics(ctx context.Context, id int64) (*Metrics, erro
 		return nil, err
 	}
 	metrics := &Metrics{}
-	if len(scs) == 0 {
-		return metrics, nil
+	for _, sc := range scs {
+		metrics.add(sc.Status, sc.Count)
 	}
+	return metrics, nil
+}
 
+func (e *executionDAO) ListMetrics(ctx context.Context, ids ...int64) (map[int64]*Metrics, error) {
+	metrics := make(map[int64]*Metrics, len(ids))
+	if len(ids) == 0 {
+		return metrics, nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := make([]any, 0, len(ids))
+	for _, id := range ids {
+		metrics[id] = &Metrics{}
+		params = append(params, id)
+	}
+	var scs []*struct {
+		ExecutionID int64  `orm:"column(execution_id)"`
+		Status      string `orm:"column(status)"`
+		Count       int64  `orm:"column(count)"`
+	}
+	sql := fmt.Sprintf("select execution_id, status, count(*) as count from task where execution_id in (%s) group by execution_id, status",
+		orm.ParamPlaceholderForIn(len(ids)))
+	if _, err = ormer.Raw(sql, params...).QueryRows(&scs); err != nil {
+		return nil, err
+	}
 	for _, sc := range scs {
-		switch sc.Status {
-		case job.SuccessStatus.String():
-			metrics.SuccessTaskCount = sc.Count
-		case job.ErrorStatus.String():
-			metrics.ErrorTaskCount = sc.Count
-		case job.PendingStatus.String():
-			metrics.PendingTaskCount = sc.Count
-		case job.RunningStatus.String():
-			metrics.RunningTaskCount = sc.Count
-		case job.ScheduledStatus.String():
-			metrics.ScheduledTaskCount = sc.Count
-		case job.StoppedStatus.String():
-			metrics.StoppedTaskCount = sc.Count
-		default:
-			log.Errorf("unknown task status: %s", sc.Status)
+		if m, exist := metrics[sc.ExecutionID]; exist {
+			m.add(sc.Status, sc.Count)
 		}
 	}
-	metrics.TaskCount = metrics.SuccessTaskCount + metrics.ErrorTaskCount +
-		metrics.PendingTaskCount + metrics.RunningTaskCount +
-		metrics.ScheduledTaskCount + metrics.StoppedTaskCount
 	return metrics, nil
 }
 
+// add the count of the tasks in the specified status to the metrics
+func (m *Metrics) add(status string, count int64) {
+	switch status {
+	case job.SuccessStatus.String():
+		m.SuccessTaskCount = count
+	case job.ErrorStatus.String():
+		m.ErrorTaskCount = count
+	case job.PendingStatus.String():
+		m.PendingTaskCount = count
+	case job.RunningStatus.String():
+		m.RunningTaskCount = count
+	case job.ScheduledStatus.String():
+		m.ScheduledTaskCount = count
+	case job.StoppedStatus.String():
+		m.StoppedTaskCount = count
+	default:
+		log.Errorf("unknown task status: %s", status)
+		return
+	}
+	m.TaskCount = m.SuccessTaskCount + m.ErrorTaskCount +
+		m.PendingTaskCount + m.RunningTaskCount +
+		m.ScheduledTaskCount + m.StoppedTaskCount
+}
+
 func (e *executionDAO) RefreshStatus(ctx context.Context, id int64) (bool, string, error) {
 	// as the status of the execution can be refreshed by multiple operators concurrently
 	// we use the optimistic locking to avoid the conflict and retry 5 times at most
@@ -363,11 +430,7 @@ func (e *executionDAO) querySetter(ctx context.Context, query *q.Query, options
 
 	// append the filter for "extra attrs"
 	if query != nil && len(query.Keywords) > 0 {
-		var (
-			jsonbStrus []jsonbStru
-			args       []any
-		)
-
+		var jsonbStrus []jsonbStru
 		for key, value := range query.Keywords {
 			if strings.HasPrefix(key, "ExtraAttrs.") && key != "ExtraAttrs." {
 				jsonbStrus = append(jsonbStrus, jsonbStru{
@@ -384,21 +447,206 @@ func (e *executionDAO) querySetter(ctx context.Context, query *q.Query, options
 				})
 			}
 		}
-		if len(jsonbStrus) == 0 {
-			return qs, nil
+		if len(jsonbStrus) > 0 {
+			idSQL, args := buildInClauseSQLForExtraAttrs(jsonbStrus)
+			inClause, err := orm.CreateInClause(ctx, idSQL, args...)
+			if err != nil {
+				return nil, err
+			}
+			qs = qs.FilterRaw("id", inClause)
 		}
 
-		idSQL, args := buildInClauseSQLForExtraAttrs(jsonbStrus)
-		inClause, err := orm.CreateInClause(ctx, idSQL, args...)
-		if err != nil {
-			return nil, err
+		// append the filters for the keywords which aren't columns
+		for _, key := range []string{ProjectIDKeyword, DurationKeyword} {
+			value, exist := query.Keywords[key]
+			if !exist {
+				continue
+			}
+			idSQL, args, err := buildIDSQLForKeyword(key, value)
+			if err != nil {
+				return nil, err
+			}
+			inClause, err := orm.CreateInClause(ctx, idSQL, args...)
+			if err != nil {
+				return nil, err
+			}
+			qs = qs.FilterRaw("id", inClause)
 		}
-		qs = qs.FilterRaw("id", inClause)
 	}
 
 	return qs, nil
 }
 
+func (e *executionDAO) Aggregate(ctx context.Context, query *q.Query) ([]*ExecutionStats, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := buildWhereSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	finished := fmt.Sprintf("status IN ('%s', '%s', '%s') AND end_time >= start_time",
+		job.SuccessStatus.String(), job.ErrorStatus.String(), job.StoppedStatus.String())
+	sql := fmt.Sprintf(`SELECT vendor_type,
+	COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE status = '%s') AS success_count,
+	COUNT(*) FILTER (WHERE status = '%s') AS error_count,
+	COUNT(*) FILTER (WHERE status = '%s') AS stopped_count,
+	COALESCE(AVG(EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE %s), 0) AS avg_duration,
+	COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) FILTER (WHERE %s), 0) AS p95_duration
+	FROM execution %s GROUP BY vendor_type ORDER BY vendor_type`,
+		job.SuccessStatus.String(), job.ErrorStatus.String(), job.StoppedStatus.String(), finished, finished, where)
+	stats := []*ExecutionStats{}
+	if _, err = ormer.Raw(sql, args...).QueryRows(&stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// buildWhereSQL builds the where clause of the raw SQL with the same keywords supported by "List"
+func buildWhereSQL(query *q.Query) (string, []any, error) {
+	if query == nil || len(query.Keywords) == 0 {
+		return "", nil, nil
+	}
+	// sort the keys to get the stable SQL
+	keys := make([]string, 0, len(query.Keywords))
+	for key := range query.Keywords {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		conds      []string
+		args       []any
+		jsonbStrus []jsonbStru
+	)
+	for _, key := range keys {
+		value := query.Keywords[key]
+		switch {
+		case key == ProjectIDKeyword || key == DurationKeyword:
+			idSQL, idArgs, err := buildIDSQLForKeyword(key, value)
+			if err != nil {
+				return "", nil, err
+			}
+			conds = append(conds, fmt.Sprintf("id IN (%s)", idSQL))
+			args = append(args, idArgs...)
+		case strings.HasPrefix(key, "ExtraAttrs.") && key != "ExtraAttrs.":
+			jsonbStrus = append(jsonbStrus, jsonbStru{keyPrefix: "ExtraAttrs.", key: key, value: value})
+		case strings.HasPrefix(key, "extra_attrs.") && key != "extra_attrs.":
+			jsonbStrus = append(jsonbStrus, jsonbStru{keyPrefix: "extra_attrs.", key: key, value: value})
+		default:
+			column, ok := executionFilterColumns[key]
+			if !ok {
+				// accept the snake case format as well
+				for _, col := range executionFilterColumns {
+					if col == key {
+						column, ok = col, true
+						break
+					}
+				}
+			}
+			if !ok {
+				// ignore the unknown keywords as the ORM does
+				continue
+			}
+			cond, colArgs := buildColumnCondSQL(column, value)
+			if len(cond) > 0 {
+				conds = append(conds, cond)
+				args = append(args, colArgs...)
+			}
+		}
+	}
+	if len(jsonbStrus) > 0 {
+		idSQL, idArgs := buildInClauseSQLForExtraAttrs(jsonbStrus)
+		conds = append(conds, fmt.Sprintf("id IN (%s)", idSQL))
+		args = append(args, idArgs...)
+	}
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args, nil
+}
+
+func buildColumnCondSQL(column string, value any) (string, []any) {
+	switch v := value.(type) {
+	case *q.Range:
+		var (
+			conds []string
+			args  []any
+		)
+		if v.Min != nil {
+			conds = append(conds, column+" >= ?")
+			args = append(args, v.Min)
+		}
+		if v.Max != nil {
+			conds = append(conds, column+" <= ?")
+			args = append(args, v.Max)
+		}
+		return strings.Join(conds, " AND "), args
+	case *q.OrList:
+		if len(v.Values) == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("%s IN (%s)", column, orm.ParamPlaceholderForIn(len(v.Values))), v.Values
+	case *q.FuzzyMatchValue:
+		return column + " LIKE ?", []any{"%" + orm.Escape(v.Value) + "%"}
+	default:
+		return column + " = ?", []any{value}
+	}
+}
+
+// buildIDSQLForKeyword builds the SQL selecting the IDs of the executions matching the keyword which isn't a column
+func buildIDSQLForKeyword(key string, value any) (string, []any, error) {
+	switch key {
+	case ProjectIDKeyword:
+		var projectID int64
+		switch v := value.(type) {
+		case int64:
+			projectID = v
+		case int:
+			projectID = int64(v)
+		default:
+			return "", nil, errors.BadRequestError(nil).WithMessagef("invalid project ID: %v", value)
+		}
+		var (
+			conds []string
+			args  []any
+		)
+		for _, vendor := range projectScopedVendors {
+			conds = append(conds, fmt.Sprintf("(vendor_type IN (%s) AND vendor_id IN (%s))",
+				orm.ParamPlaceholderForIn(len(vendor.vendorTypes)), vendor.idSQL))
+			for _, vendorType := range vendor.vendorTypes {
+				args = append(args, vendorType)
+			}
+			args = append(args, projectID)
+		}
+		// the scan data export records the projects in the extra attributes
+		conds = append(conds, "(vendor_type = ? AND extra_attrs->'project_ids' @> to_jsonb(?::bigint))")
+		args = append(args, job.ScanDataExportVendorType, projectID)
+		return "SELECT id FROM execution WHERE " + strings.Join(conds, " OR "), args, nil
+	case DurationKeyword:
+		r, ok := value.(*q.Range)
+		if !ok || (r.Min == nil && r.Max == nil) {
+			return "", nil, errors.BadRequestError(nil).WithMessage("the duration should be a range of seconds, e.g. [60~600]")
+		}
+		// the end time is set only when the execution is finished
+		sql := "SELECT id FROM execution WHERE end_time >= start_time"
+		var args []any
+		if r.Min != nil {
+			sql += " AND EXTRACT(EPOCH FROM end_time - start_time) >= ?"
+			args = append(args, r.Min)
+		}
+		if r.Max != nil {
+			sql += " AND EXTRACT(EPOCH FROM end_time - start_time) <= ?"
+			args = append(args, r.Max)
+		}
+		return sql, args, nil
+	default:
+		return "", nil, errors.Errorf("unsupported keyword %s", key)
+	}
+}
+
 // Param keys is strings.Split() after trim "extra_attrs."/"ExtraAttrs." prefix
 // key with keyPrefix supports multi-level query operator on PostgreSQL JSON data
 // examples:
@@ -16,6 +16,7 @@ package dao
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	libredis "github.com/goharbor/harbor/src/lib/redis"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -106,6 +108,85 @@ func (e *executionDAOTestSuite) TestList() {
 	e.Require().Len(executions, 0)
 }
 
+func (e *executionDAOTestSuite) TestListByDuration() {
+	now := time.Now()
+	err := e.executionDAO.Update(e.ctx, &Execution{
+		ID:        e.executionID,
+		Status:    job.SuccessStatus.String(),
+		StartTime: now.Add(-2 * time.Minute),
+		EndTime:   now,
+	}, "Status", "StartTime", "EndTime")
+	e.Require().Nil(err)
+
+	count, err := e.executionDAO.Count(e.ctx, &q.Query{
+		Keywords: map[string]any{
+			"VendorType":    "test",
+			DurationKeyword: &q.Range{Min: int64(60), Max: int64(180)},
+		},
+	})
+	e.Require().Nil(err)
+	e.Equal(int64(1), count)
+
+	count, err = e.executionDAO.Count(e.ctx, &q.Query{
+		Keywords: map[string]any{
+			"VendorType":    "test",
+			DurationKeyword: &q.Range{Min: int64(180)},
+		},
+	})
+	e.Require().Nil(err)
+	e.Equal(int64(0), count)
+
+	_, err = e.executionDAO.Count(e.ctx, &q.Query{
+		Keywords: map[string]any{
+			DurationKeyword: int64(60),
+		},
+	})
+	e.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (e *executionDAOTestSuite) TestListByProject() {
+	count, err := e.executionDAO.Count(e.ctx, &q.Query{
+		Keywords: map[string]any{
+			"VendorType":     "test",
+			ProjectIDKeyword: int64(1),
+		},
+	})
+	e.Require().Nil(err)
+	e.Equal(int64(0), count)
+}
+
+func (e *executionDAOTestSuite) TestAggregate() {
+	now := time.Now()
+	err := e.executionDAO.Update(e.ctx, &Execution{
+		ID:        e.executionID,
+		Status:    job.SuccessStatus.String(),
+		StartTime: now.Add(-time.Minute),
+		EndTime:   now,
+	}, "Status", "StartTime", "EndTime")
+	e.Require().Nil(err)
+	id, err := e.executionDAO.Create(e.ctx, &Execution{
+		VendorType: "test",
+		Trigger:    "test",
+		Status:     job.RunningStatus.String(),
+		StartTime:  now,
+	})
+	e.Require().Nil(err)
+	defer e.executionDAO.Delete(e.ctx, id)
+
+	stats, err := e.executionDAO.Aggregate(e.ctx, &q.Query{
+		Keywords: map[string]any{
+			"VendorType": "test",
+		},
+	})
+	e.Require().Nil(err)
+	e.Require().Len(stats, 1)
+	e.Equal("test", stats[0].VendorType)
+	e.Equal(int64(2), stats[0].Total)
+	e.Equal(int64(1), stats[0].SuccessCount)
+	e.Equal(1.0, stats[0].SuccessRate())
+	e.InDelta(60, stats[0].P95Duration, 1)
+}
+
 func (e *executionDAOTestSuite) TestGet() {
 	// not exist
 	_, err := e.executionDAO.Get(e.ctx, 10000)
@@ -212,6 +293,13 @@ func (e *executionDAOTestSuite) TestGetMetrics() {
 	e.Equal(int64(1), metrics.PendingTaskCount)
 	e.Equal(int64(1), metrics.RunningTaskCount)
 	e.Equal(int64(1), metrics.ScheduledTaskCount)
+
+	// the executions without tasks get the empty metrics
+	all, err := e.executionDAO.ListMetrics(e.ctx, e.executionID, e.executionID+1000)
+	e.Require().Nil(err)
+	e.Require().Len(all, 2)
+	e.Equal(metrics, all[e.executionID])
+	e.Equal(int64(0), all[e.executionID+1000].TaskCount)
 }
 
 func (e *executionDAOTestSuite) TestRefreshStatus() {
@@ -450,6 +538,56 @@ func Test_buildInClauseSQLForExtraAttrs(t *testing.T) {
 	}
 }
 
+func Test_buildWhereSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *q.Query
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{"nil query", nil, "", nil, false},
+		{"unknown keyword", &q.Query{Keywords: map[string]any{"unknown": "value"}}, "", nil, false},
+		{"columns", &q.Query{Keywords: map[string]any{
+			"VendorType": "GARBAGE_COLLECTION",
+			"status":     &q.OrList{Values: []any{"Success", "Error"}},
+			"trigger":    &q.FuzzyMatchValue{Value: "SCHED"},
+			"start_time": &q.Range{Min: int64(1)},
+		}}, "WHERE vendor_type = ? AND start_time >= ? AND status IN (?,?) AND trigger LIKE ?",
+			[]any{"GARBAGE_COLLECTION", int64(1), "Success", "Error", "%SCHED%"}, false},
+		{"duration", &q.Query{Keywords: map[string]any{
+			DurationKeyword: &q.Range{Max: int64(600)},
+		}}, "WHERE id IN (SELECT id FROM execution WHERE end_time >= start_time AND EXTRACT(EPOCH FROM end_time - start_time) <= ?)",
+			[]any{int64(600)}, false},
+		{"invalid duration", &q.Query{Keywords: map[string]any{DurationKeyword: "60"}}, "", nil, true},
+		{"extra attrs", &q.Query{Keywords: map[string]any{"extra_attrs.id": "1"}},
+			"WHERE id IN (select id from execution where extra_attrs->>?=?)", []any{"id", "1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := buildWhereSQL(tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func Test_buildIDSQLForKeyword(t *testing.T) {
+	sql, args, err := buildIDSQLForKeyword(ProjectIDKeyword, int64(1))
+	assert.NoError(t, err)
+	assert.Contains(t, sql, "SELECT id FROM artifact WHERE project_id = ?")
+	assert.Contains(t, sql, "extra_attrs->'project_ids'")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+
+	_, _, err = buildIDSQLForKeyword(ProjectIDKeyword, "library")
+	assert.Error(t, err)
+}
+
 func Test_parseExecStatusOutdateMember(t *testing.T) {
 	type args struct {
 		member string
@@ -25,6 +25,8 @@ import (
 func init() {
 	orm.RegisterModel(&Execution{})
 	orm.RegisterModel(&Task{})
+	orm.RegisterModel(&Workflow{})
+	orm.RegisterModel(&WorkflowNode{})
 }
 
 // Execution database model
@@ -43,6 +45,8 @@ type Execution struct {
 	UpdateTime    time.Time `orm:"column(update_time)"`
 	EndTime       time.Time `orm:"column(end_time)"`
 	Revision      int64     `orm:"column(revision)"`
+	// Paused indicates the pending tasks of the execution aren't dequeued until it is resumed
+	Paused bool `orm:"column(paused)"`
 }
 
 // Metrics is the task metrics for one execution
@@ -56,6 +60,27 @@ type Metrics struct {
 	StoppedTaskCount   int64 `json:"stopped_task_count"`
 }
 
+// ExecutionStats is the aggregated statistics of the executions of one vendor type
+type ExecutionStats struct {
+	VendorType   string `orm:"column(vendor_type)"`
+	Total        int64  `orm:"column(total)"`
+	SuccessCount int64  `orm:"column(success_count)"`
+	ErrorCount   int64  `orm:"column(error_count)"`
+	StoppedCount int64  `orm:"column(stopped_count)"`
+	// the durations are in seconds and only the finished executions are counted
+	AvgDuration float64 `orm:"column(avg_duration)"`
+	P95Duration float64 `orm:"column(p95_duration)"`
+}
+
+// SuccessRate returns the ratio of the successful executions among the finished ones
+func (e *ExecutionStats) SuccessRate() float64 {
+	finished := e.SuccessCount + e.ErrorCount + e.StoppedCount
+	if finished == 0 {
+		return 0
+	}
+	return float64(e.SuccessCount) / float64(finished)
+}
+
 // Task database model
 type Task struct {
 	ID             int64     `orm:"pk;auto;column(id)"`
@@ -94,3 +119,34 @@ type StatusCount struct {
 	Status string `orm:"column(status)"`
 	Count  int64  `orm:"column(count)"`
 }
+
+// Workflow database model
+type Workflow struct {
+	ID            int64     `orm:"pk;auto;column(id)"`
+	Name          string    `orm:"column(name)"`
+	Status        string    `orm:"column(status)"`
+	StatusMessage string    `orm:"column(status_message)"`
+	Creator       string    `orm:"column(creator)"`
+	StartTime     time.Time `orm:"column(start_time)" sort:"default:desc"`
+	UpdateTime    time.Time `orm:"column(update_time)"`
+	EndTime       time.Time `orm:"column(end_time)"`
+}
+
+// WorkflowNode database model, every node runs one execution
+type WorkflowNode struct {
+	ID         int64 `orm:"pk;auto;column(id)" sort:"default:asc"`
+	WorkflowID int64 `orm:"column(workflow_id)"`
+	// the ID of the upstream node, 0 for the root nodes
+	UpstreamID int64 `orm:"column(upstream_id)"`
+	// the status of the upstream execution which triggers the node
+	TriggerOn     string    `orm:"column(trigger_on)"`
+	Name          string    `orm:"column(name)"`
+	VendorType    string    `orm:"column(vendor_type)"`
+	Parameters    string    `orm:"column(parameters)"` // json string
+	ExecutionID   int64     `orm:"column(execution_id)"`
+	Status        string    `orm:"column(status)"`
+	StatusMessage string    `orm:"column(status_message)"`
+	StartTime     time.Time `orm:"column(start_time)"`
+	UpdateTime    time.Time `orm:"column(update_time)"`
+	EndTime       time.Time `orm:"column(end_time)"`
+}
@@ -0,0 +1,224 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// WfDAO is the global workflow dao
+var WfDAO = NewWorkflowDAO()
+
+// WorkflowDAO is the data access object interface for workflow
+type WorkflowDAO interface {
+	// Create a workflow
+	Create(ctx context.Context, workflow *Workflow) (id int64, err error)
+	// Get the specified workflow
+	Get(ctx context.Context, id int64) (workflow *Workflow, err error)
+	// Count returns the total count of workflows according to the query
+	Count(ctx context.Context, query *q.Query) (count int64, err error)
+	// List the workflows according to the query
+	List(ctx context.Context, query *q.Query) (workflows []*Workflow, err error)
+	// UpdateStatus updates the status of the workflow only when its current status is "fromStatus",
+	// the end time is set as well if the new status isn't running. The returning "updated" is false
+	// if the status of the workflow has been changed by others
+	UpdateStatus(ctx context.Context, id int64, status, message, fromStatus string) (updated bool, err error)
+	// Delete the specified workflow and its nodes
+	Delete(ctx context.Context, id int64) (err error)
+	// CreateNode creates a node of the workflow
+	CreateNode(ctx context.Context, node *WorkflowNode) (id int64, err error)
+	// ListNodes lists the workflow nodes according to the query
+	ListNodes(ctx context.Context, query *q.Query) (nodes []*WorkflowNode, err error)
+	// UpdateNodeStatus updates the status of the node only when its current status is "fromStatus",
+	// the start time is set when the node turns to running and the end time is set when the node
+	// turns to a final status. The returning "updated" is false if the status of the node has been
+	// changed by others
+	UpdateNodeStatus(ctx context.Context, id int64, status, message, fromStatus string) (updated bool, err error)
+	// SetNodeExecution records the execution launched by the node
+	SetNodeExecution(ctx context.Context, id int64, executionID int64) (err error)
+}
+
+// NewWorkflowDAO returns an instance of WorkflowDAO
+func NewWorkflowDAO() WorkflowDAO {
+	return &workflowDAO{}
+}
+
+type workflowDAO struct{}
+
+func (w *workflowDAO) Create(ctx context.Context, workflow *Workflow) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.Insert(workflow)
+}
+
+func (w *workflowDAO) Get(ctx context.Context, id int64) (*Workflow, error) {
+	workflow := &Workflow{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.Read(workflow); err != nil {
+		return nil, orm.WrapNotFoundError(err, "workflow %d not found", id)
+	}
+	return workflow, nil
+}
+
+func (w *workflowDAO) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &Workflow{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (w *workflowDAO) List(ctx context.Context, query *q.Query) ([]*Workflow, error) {
+	workflows := []*Workflow{}
+	qs, err := orm.QuerySetter(ctx, &Workflow{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&workflows); err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+func (w *workflowDAO) UpdateStatus(ctx context.Context, id int64, status, message, fromStatus string) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	var endTime any
+	if status != job.RunningStatus.String() {
+		endTime = now
+	}
+	sql := `update workflow set status = ?, status_message = ?, update_time = ?, end_time = ?
+		where id = ? and status = ?`
+	result, err := ormer.Raw(sql, status, message, now, endTime, id, fromStatus).Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (w *workflowDAO) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	// the nodes are deleted by the cascade foreign key
+	n, err := ormer.Delete(&Workflow{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("workflow %d not found", id)
+	}
+	return nil
+}
+
+func (w *workflowDAO) CreateNode(ctx context.Context, node *WorkflowNode) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(node)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "node %s already exists in the workflow %d", node.Name, node.WorkflowID)
+	}
+	return id, nil
+}
+
+func (w *workflowDAO) ListNodes(ctx context.Context, query *q.Query) ([]*WorkflowNode, error) {
+	nodes := []*WorkflowNode{}
+	qs, err := orm.QuerySetter(ctx, &WorkflowNode{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (w *workflowDAO) UpdateNodeStatus(ctx context.Context, id int64, status, message, fromStatus string) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	var (
+		sql  string
+		args []any
+	)
+	switch status {
+	case job.PendingStatus.String():
+		sql = `update workflow_node set status = ?, status_message = ?, update_time = ? where id = ? and status = ?`
+		args = []any{status, message, now, id, fromStatus}
+	case job.RunningStatus.String():
+		sql = `update workflow_node set status = ?, status_message = ?, update_time = ?, start_time = ?
+			where id = ? and status = ?`
+		args = []any{status, message, now, now, id, fromStatus}
+	default:
+		sql = `update workflow_node set status = ?, status_message = ?, update_time = ?, end_time = ?
+			where id = ? and status = ?`
+		args = []any{status, message, now, now, id, fromStatus}
+	}
+	result, err := ormer.Raw(sql, args...).Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (w *workflowDAO) SetNodeExecution(ctx context.Context, id int64, executionID int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(&WorkflowNode{
+		ID:          id,
+		ExecutionID: executionID,
+		UpdateTime:  time.Now(),
+	}, "ExecutionID", "UpdateTime")
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessagef("workflow node %d not found", id)
+	}
+	return nil
+}
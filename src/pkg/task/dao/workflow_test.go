@@ -0,0 +1,142 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+type workflowDAOTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	wfDAO      *workflowDAO
+	workflowID int64
+	nodeID     int64
+}
+
+func (w *workflowDAOTestSuite) SetupSuite() {
+	dao.PrepareTestForPostgresSQL()
+	w.ctx = orm.Context()
+	w.wfDAO = &workflowDAO{}
+}
+
+func (w *workflowDAOTestSuite) SetupTest() {
+	now := time.Now()
+	id, err := w.wfDAO.Create(w.ctx, &Workflow{
+		Name:       "workflow",
+		Status:     job.RunningStatus.String(),
+		StartTime:  now,
+		UpdateTime: now,
+	})
+	w.Require().Nil(err)
+	w.workflowID = id
+
+	id, err = w.wfDAO.CreateNode(w.ctx, &WorkflowNode{
+		WorkflowID: w.workflowID,
+		Name:       "root",
+		TriggerOn:  "Success",
+		VendorType: "test",
+		Status:     job.PendingStatus.String(),
+		UpdateTime: now,
+	})
+	w.Require().Nil(err)
+	w.nodeID = id
+}
+
+func (w *workflowDAOTestSuite) TearDownTest() {
+	err := w.wfDAO.Delete(w.ctx, w.workflowID)
+	w.Nil(err)
+}
+
+func (w *workflowDAOTestSuite) TestGet() {
+	_, err := w.wfDAO.Get(w.ctx, 10000)
+	w.True(errors.IsNotFoundErr(err))
+
+	workflow, err := w.wfDAO.Get(w.ctx, w.workflowID)
+	w.Require().Nil(err)
+	w.Equal("workflow", workflow.Name)
+}
+
+func (w *workflowDAOTestSuite) TestListAndCount() {
+	query := q.New(q.KeyWords{"Name": "workflow"})
+	count, err := w.wfDAO.Count(w.ctx, query)
+	w.Require().Nil(err)
+	w.Equal(int64(1), count)
+
+	workflows, err := w.wfDAO.List(w.ctx, query)
+	w.Require().Nil(err)
+	w.Require().Len(workflows, 1)
+	w.Equal(w.workflowID, workflows[0].ID)
+}
+
+func (w *workflowDAOTestSuite) TestUpdateStatus() {
+	updated, err := w.wfDAO.UpdateStatus(w.ctx, w.workflowID, job.StoppedStatus.String(), "", job.RunningStatus.String())
+	w.Require().Nil(err)
+	w.True(updated)
+
+	// the status is changed already
+	updated, err = w.wfDAO.UpdateStatus(w.ctx, w.workflowID, job.SuccessStatus.String(), "", job.RunningStatus.String())
+	w.Require().Nil(err)
+	w.False(updated)
+
+	workflow, err := w.wfDAO.Get(w.ctx, w.workflowID)
+	w.Require().Nil(err)
+	w.Equal(job.StoppedStatus.String(), workflow.Status)
+	w.False(workflow.EndTime.IsZero())
+}
+
+func (w *workflowDAOTestSuite) TestNodes() {
+	// duplicate name
+	_, err := w.wfDAO.CreateNode(w.ctx, &WorkflowNode{
+		WorkflowID: w.workflowID,
+		Name:       "root",
+		TriggerOn:  "Success",
+		VendorType: "test",
+		Status:     job.PendingStatus.String(),
+	})
+	w.True(errors.IsConflictErr(err))
+
+	claimed, err := w.wfDAO.UpdateNodeStatus(w.ctx, w.nodeID, job.RunningStatus.String(), "", job.PendingStatus.String())
+	w.Require().Nil(err)
+	w.True(claimed)
+	// claimed already
+	claimed, err = w.wfDAO.UpdateNodeStatus(w.ctx, w.nodeID, job.RunningStatus.String(), "", job.PendingStatus.String())
+	w.Require().Nil(err)
+	w.False(claimed)
+
+	err = w.wfDAO.SetNodeExecution(w.ctx, w.nodeID, 100)
+	w.Require().Nil(err)
+
+	nodes, err := w.wfDAO.ListNodes(w.ctx, q.New(q.KeyWords{"WorkflowID": w.workflowID}))
+	w.Require().Nil(err)
+	w.Require().Len(nodes, 1)
+	w.Equal(job.RunningStatus.String(), nodes[0].Status)
+	w.Equal(int64(100), nodes[0].ExecutionID)
+	w.False(nodes[0].StartTime.IsZero())
+}
+
+func TestWorkflowDAOSuite(t *testing.T) {
+	suite.Run(t, &workflowDAOTestSuite{})
+}
@@ -15,7 +15,6 @@
 package task
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"sync"
@@ -62,6 +61,11 @@ type ExecutionManager interface {
 	StopAndWait(ctx context.Context, id int64, timeout time.Duration) (err error)
 	// StopAndWaitWithError calls the StopAndWait first, if it doesn't return error, then it call MarkError if the origError is not empty
 	StopAndWaitWithError(ctx context.Context, id int64, timeout time.Duration, origError error) (err error)
+	// Pause the specified running execution, the pending tasks of it aren't dequeued by the jobservice
+	// until it is resumed while the running ones continue until finished
+	Pause(ctx context.Context, id int64) (err error)
+	// Resume the specified paused execution
+	Resume(ctx context.Context, id int64) (err error)
 	// Delete the specified execution and its tasks
 	Delete(ctx context.Context, id int64) (err error)
 	// Delete all executions and tasks of the specific vendor. They can be deleted only when all the executions/tasks
@@ -71,10 +75,14 @@ type ExecutionManager interface {
 	Get(ctx context.Context, id int64) (execution *Execution, err error)
 	// List executions according to the query
 	// Query the "ExtraAttrs" by setting 'query.Keywords["ExtraAttrs.key"]="value"'
+	// Query the executions of a project or by duration with the keywords "dao.ProjectIDKeyword" and "dao.DurationKeyword"
 	List(ctx context.Context, query *q.Query) (executions []*Execution, err error)
 	// Count counts total of executions according to the query.
 	// Query the "ExtraAttrs" by setting 'query.Keywords["ExtraAttrs.key"]="value"'
 	Count(ctx context.Context, query *q.Query) (int64, error)
+	// Aggregate the executions matching the query by vendor type, the success rate and the
+	// duration percentiles are calculated over the finished executions
+	Aggregate(ctx context.Context, query *q.Query) (stats []*dao.ExecutionStats, err error)
 }
 
 // NewExecutionManager return an instance of the default execution manager
@@ -96,6 +104,10 @@ func (e *executionManager) Count(ctx context.Context, query *q.Query) (int64, er
 	return e.executionDAO.Count(ctx, query)
 }
 
+func (e *executionManager) Aggregate(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	return e.executionDAO.Aggregate(ctx, query)
+}
+
 func (e *executionManager) Create(ctx context.Context, vendorType string, vendorID int64, trigger string,
 	extraAttrs ...map[string]any) (int64, error) {
 	extras := map[string]any{}
@@ -206,6 +218,39 @@ func (e *executionManager) Stop(ctx context.Context, id int64) error {
 	return err
 }
 
+func (e *executionManager) Pause(ctx context.Context, id int64) error {
+	execution, err := e.executionDAO.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if execution.Status != job.RunningStatus.String() {
+		return errors.PreconditionFailedError(nil).WithMessagef("the execution %d isn't running", id)
+	}
+	if execution.Paused {
+		return nil
+	}
+	return e.executionDAO.Update(ctx, &dao.Execution{
+		ID:         id,
+		Paused:     true,
+		UpdateTime: time.Now(),
+	}, "Paused", "UpdateTime")
+}
+
+func (e *executionManager) Resume(ctx context.Context, id int64) error {
+	execution, err := e.executionDAO.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !execution.Paused {
+		return nil
+	}
+	return e.executionDAO.Update(ctx, &dao.Execution{
+		ID:         id,
+		Paused:     false,
+		UpdateTime: time.Now(),
+	}, "Paused", "UpdateTime")
+}
+
 func (e *executionManager) StopAndWait(ctx context.Context, id int64, timeout time.Duration) error {
 	var (
 		overtime bool
@@ -334,37 +379,28 @@ func (e *executionManager) List(ctx context.Context, query *q.Query) ([]*Executi
 	if err != nil {
 		return nil, err
 	}
+	// populate the task metrics of the whole page with one query
+	var ids []int64
+	for _, execution := range executions {
+		ids = append(ids, execution.ID)
+	}
+	metrics, err := e.executionDAO.ListMetrics(ctx, ids...)
+	if err != nil {
+		log.Errorf("failed to get metrics of the executions: %v", err)
+	}
 	var execs []*Execution
 	for _, execution := range executions {
-		execs = append(execs, e.populateExecution(ctx, execution))
+		exec := &Execution{}
+		exec.From(execution)
+		exec.Metrics = metrics[execution.ID]
+		execs = append(execs, exec)
 	}
 	return execs, nil
 }
 
 func (e *executionManager) populateExecution(ctx context.Context, execution *dao.Execution) *Execution {
-	exec := &Execution{
-		ID:            execution.ID,
-		VendorType:    execution.VendorType,
-		VendorID:      execution.VendorID,
-		Status:        execution.Status,
-		StatusMessage: execution.StatusMessage,
-		Metrics:       nil,
-		Trigger:       execution.Trigger,
-		StartTime:     execution.StartTime,
-		UpdateTime:    execution.UpdateTime,
-		EndTime:       execution.EndTime,
-	}
-
-	if len(execution.ExtraAttrs) > 0 {
-		extras := map[string]any{}
-		d := json.NewDecoder(bytes.NewReader([]byte(execution.ExtraAttrs)))
-		d.UseNumber()
-		if err := d.Decode(&extras); err != nil {
-			log.Errorf("failed to unmarshal the extra attributes of execution %d: %v", execution.ID, err)
-		} else {
-			exec.ExtraAttrs = extras
-		}
-	}
+	exec := &Execution{}
+	exec.From(execution)
 
 	// populate task metrics
 	metrics, err := e.executionDAO.GetMetrics(ctx, execution.ID)
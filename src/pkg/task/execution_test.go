@@ -59,6 +59,17 @@ func (e *executionManagerTestSuite) TestCount() {
 	e.execDAO.AssertExpectations(e.T())
 }
 
+func (e *executionManagerTestSuite) TestAggregate() {
+	e.execDAO.On("Aggregate", mock.Anything, mock.Anything).Return([]*dao.ExecutionStats{
+		{VendorType: "GARBAGE_COLLECTION", Total: 4, SuccessCount: 3, ErrorCount: 1, P95Duration: 120},
+	}, nil)
+	stats, err := e.execMgr.Aggregate(nil, &q.Query{})
+	e.Require().Nil(err)
+	e.Require().Len(stats, 1)
+	e.Equal(0.75, stats[0].SuccessRate())
+	e.execDAO.AssertExpectations(e.T())
+}
+
 func (e *executionManagerTestSuite) TestCreate() {
 	e.execDAO.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
 	id, err := e.execMgr.Create(nil, "vendor", 0, ExecutionTriggerManual,
@@ -140,6 +151,60 @@ func (e *executionManagerTestSuite) TestStop() {
 	e.taskMgr.AssertExpectations(e.T())
 }
 
+func (e *executionManagerTestSuite) TestPause() {
+	// the execution isn't running
+	e.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{
+		ID:     1,
+		Status: job.SuccessStatus.String(),
+	}, nil)
+	err := e.execMgr.Pause(nil, 1)
+	e.Require().NotNil(err)
+	e.True(errors.IsErr(err, errors.PreconditionCode))
+	e.execDAO.AssertExpectations(e.T())
+
+	// reset the mocks
+	e.SetupTest()
+
+	// the execution is running
+	e.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{
+		ID:     1,
+		Status: job.RunningStatus.String(),
+	}, nil)
+	e.execDAO.On("Update", mock.Anything, mock.MatchedBy(func(execution *dao.Execution) bool {
+		return execution.ID == 1 && execution.Paused
+	}), "Paused", "UpdateTime").Return(nil)
+	err = e.execMgr.Pause(nil, 1)
+	e.Require().Nil(err)
+	e.execDAO.AssertExpectations(e.T())
+}
+
+func (e *executionManagerTestSuite) TestResume() {
+	// the execution isn't paused
+	e.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{
+		ID:     1,
+		Status: job.RunningStatus.String(),
+	}, nil)
+	err := e.execMgr.Resume(nil, 1)
+	e.Require().Nil(err)
+	e.execDAO.AssertNotCalled(e.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// reset the mocks
+	e.SetupTest()
+
+	// the execution is paused
+	e.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{
+		ID:     1,
+		Status: job.RunningStatus.String(),
+		Paused: true,
+	}, nil)
+	e.execDAO.On("Update", mock.Anything, mock.MatchedBy(func(execution *dao.Execution) bool {
+		return execution.ID == 1 && !execution.Paused
+	}), "Paused", "UpdateTime").Return(nil)
+	err = e.execMgr.Resume(nil, 1)
+	e.Require().Nil(err)
+	e.execDAO.AssertExpectations(e.T())
+}
+
 func (e *executionManagerTestSuite) TestStopAndWait() {
 	// timeout
 	e.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{
@@ -270,9 +335,11 @@ func (e *executionManagerTestSuite) TestList() {
 			Status: job.SuccessStatus.String(),
 		},
 	}, nil)
-	e.execDAO.On("GetMetrics", mock.Anything, mock.Anything).Return(&dao.Metrics{
-		TaskCount:        1,
-		SuccessTaskCount: 1,
+	e.execDAO.On("ListMetrics", mock.Anything, int64(1)).Return(map[int64]*dao.Metrics{
+		1: {
+			TaskCount:        1,
+			SuccessTaskCount: 1,
+		},
 	}, nil)
 	execs, err := e.execMgr.List(nil, nil)
 	e.Require().Nil(err)
@@ -15,6 +15,36 @@ type mockExecutionDAO struct {
 	mock.Mock
 }
 
+// Aggregate provides a mock function with given fields: ctx, query
+func (_m *mockExecutionDAO) Aggregate(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 []*dao.ExecutionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*dao.ExecutionStats, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*dao.ExecutionStats); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.ExecutionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AsyncRefreshStatus provides a mock function with given fields: ctx, id, vendor
 func (_m *mockExecutionDAO) AsyncRefreshStatus(ctx context.Context, id int64, vendor string) error {
 	ret := _m.Called(ctx, id, vendor)
@@ -197,6 +227,43 @@ func (_m *mockExecutionDAO) List(ctx context.Context, query *q.Query) ([]*dao.Ex
 	return r0, r1
 }
 
+// ListMetrics provides a mock function with given fields: ctx, ids
+func (_m *mockExecutionDAO) ListMetrics(ctx context.Context, ids ...int64) (map[int64]*dao.Metrics, error) {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMetrics")
+	}
+
+	var r0 map[int64]*dao.Metrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...int64) (map[int64]*dao.Metrics, error)); ok {
+		return rf(ctx, ids...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...int64) map[int64]*dao.Metrics); ok {
+		r0 = rf(ctx, ids...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]*dao.Metrics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...int64) error); ok {
+		r1 = rf(ctx, ids...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RefreshStatus provides a mock function with given fields: ctx, id
 func (_m *mockExecutionDAO) RefreshStatus(ctx context.Context, id int64) (bool, string, error) {
 	ret := _m.Called(ctx, id)
@@ -257,9 +324,9 @@ func (_m *mockExecutionDAO) Update(ctx context.Context, execution *dao.Execution
 	return r0
 }
 
-// newMockExecutionDAO creates a new instance of mockExecutionDAO. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewmockExecutionDAO creates a new instance of mockExecutionDAO. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockExecutionDAO(t interface {
+func NewmockExecutionDAO(t interface {
 	mock.TestingT
 	Cleanup(func())
 }) *mockExecutionDAO {
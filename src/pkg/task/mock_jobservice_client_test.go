@@ -4,6 +4,7 @@ package task
 
 import (
 	models "github.com/goharbor/harbor/src/common/job/models"
+	query "github.com/goharbor/harbor/src/jobservice/common/query"
 	job "github.com/goharbor/harbor/src/jobservice/job"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -121,6 +122,36 @@ func (_m *mockJobserviceClient) PostAction(uuid string, action string) error {
 	return r0
 }
 
+// QueryJobLog provides a mock function with given fields: uuid, q
+func (_m *mockJobserviceClient) QueryJobLog(uuid string, q *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(uuid, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryJobLog")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *query.LogQuery) ([]byte, error)); ok {
+		return rf(uuid, q)
+	}
+	if rf, ok := ret.Get(0).(func(string, *query.LogQuery) []byte); ok {
+		r0 = rf(uuid, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *query.LogQuery) error); ok {
+		r1 = rf(uuid, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitJob provides a mock function with given fields: _a0
 func (_m *mockJobserviceClient) SubmitJob(_a0 *models.JobData) (string, error) {
 	ret := _m.Called(_a0)
@@ -149,9 +180,9 @@ func (_m *mockJobserviceClient) SubmitJob(_a0 *models.JobData) (string, error) {
 	return r0, r1
 }
 
-// newMockJobserviceClient creates a new instance of mockJobserviceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewmockJobserviceClient creates a new instance of mockJobserviceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockJobserviceClient(t interface {
+func NewmockJobserviceClient(t interface {
 	mock.TestingT
 	Cleanup(func())
 }) *mockJobserviceClient {
@@ -13,6 +13,24 @@ type mockSweepManager struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields: ctx, vendorType, execIDs
+func (_m *mockSweepManager) Archive(ctx context.Context, vendorType string, execIDs []int64) error {
+	ret := _m.Called(ctx, vendorType, execIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int64) error); ok {
+		r0 = rf(ctx, vendorType, execIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Clean provides a mock function with given fields: ctx, execID
 func (_m *mockSweepManager) Clean(ctx context.Context, execID []int64) error {
 	ret := _m.Called(ctx, execID)
@@ -49,9 +67,9 @@ func (_m *mockSweepManager) FixDanglingStateExecution(ctx context.Context) error
 	return r0
 }
 
-// ListCandidates provides a mock function with given fields: ctx, vendorType, retainCnt
-func (_m *mockSweepManager) ListCandidates(ctx context.Context, vendorType string, retainCnt int64) ([]int64, error) {
-	ret := _m.Called(ctx, vendorType, retainCnt)
+// ListCandidates provides a mock function with given fields: ctx, vendorType, retainCnt, retainDays
+func (_m *mockSweepManager) ListCandidates(ctx context.Context, vendorType string, retainCnt int64, retainDays int64) ([]int64, error) {
+	ret := _m.Called(ctx, vendorType, retainCnt, retainDays)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListCandidates")
@@ -59,19 +77,19 @@ func (_m *mockSweepManager) ListCandidates(ctx context.Context, vendorType strin
 
 	var r0 []int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]int64, error)); ok {
-		return rf(ctx, vendorType, retainCnt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]int64, error)); ok {
+		return rf(ctx, vendorType, retainCnt, retainDays)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []int64); ok {
-		r0 = rf(ctx, vendorType, retainCnt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []int64); ok {
+		r0 = rf(ctx, vendorType, retainCnt, retainDays)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]int64)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = rf(ctx, vendorType, retainCnt)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, vendorType, retainCnt, retainDays)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,6 +5,7 @@ package task
 import (
 	context "context"
 
+	query "github.com/goharbor/harbor/src/jobservice/common/query"
 	q "github.com/goharbor/harbor/src/lib/q"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,9 +15,9 @@ type mockTaskManager struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx, query
-func (_m *mockTaskManager) Count(ctx context.Context, query *q.Query) (int64, error) {
-	ret := _m.Called(ctx, query)
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *mockTaskManager) Count(ctx context.Context, _a1 *q.Query) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Count")
@@ -25,16 +26,16 @@ func (_m *mockTaskManager) Count(ctx context.Context, query *q.Query) (int64, er
 	var r0 int64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -215,9 +216,9 @@ func (_m *mockTaskManager) IsTaskFinished(ctx context.Context, reportID string)
 	return r0
 }
 
-// List provides a mock function with given fields: ctx, query
-func (_m *mockTaskManager) List(ctx context.Context, query *q.Query) ([]*Task, error) {
-	ret := _m.Called(ctx, query)
+// List provides a mock function with given fields: ctx, _a1
+func (_m *mockTaskManager) List(ctx context.Context, _a1 *q.Query) ([]*Task, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -226,10 +227,10 @@ func (_m *mockTaskManager) List(ctx context.Context, query *q.Query) ([]*Task, e
 	var r0 []*Task
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*Task, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*Task); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*Task)
@@ -237,7 +238,7 @@ func (_m *mockTaskManager) List(ctx context.Context, query *q.Query) ([]*Task, e
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -275,6 +276,66 @@ func (_m *mockTaskManager) ListScanTasksByReportUUID(ctx context.Context, uuid s
 	return r0, r1
 }
 
+// QueryLog provides a mock function with given fields: ctx, id, logQuery
+func (_m *mockTaskManager) QueryLog(ctx context.Context, id int64, logQuery *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(ctx, id, logQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryLog")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) ([]byte, error)); ok {
+		return rf(ctx, id, logQuery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) []byte); ok {
+		r0 = rf(ctx, id, logQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *query.LogQuery) error); ok {
+		r1 = rf(ctx, id, logQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryLogByJobID provides a mock function with given fields: ctx, jobID, logQuery
+func (_m *mockTaskManager) QueryLogByJobID(ctx context.Context, jobID string, logQuery *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(ctx, jobID, logQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryLogByJobID")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *query.LogQuery) ([]byte, error)); ok {
+		return rf(ctx, jobID, logQuery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *query.LogQuery) []byte); ok {
+		r0 = rf(ctx, jobID, logQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *query.LogQuery) error); ok {
+		r1 = rf(ctx, jobID, logQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveStatusFromTask provides a mock function with given fields: ctx, reportID
 func (_m *mockTaskManager) RetrieveStatusFromTask(ctx context.Context, reportID string) string {
 	ret := _m.Called(ctx, reportID)
@@ -372,9 +433,9 @@ func (_m *mockTaskManager) UpdateStatusInBatch(ctx context.Context, jobIDs []str
 	return r0
 }
 
-// newMockTaskManager creates a new instance of mockTaskManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewmockTaskManager creates a new instance of mockTaskManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockTaskManager(t interface {
+func NewmockTaskManager(t interface {
 	mock.TestingT
 	Cleanup(func())
 }) *mockTaskManager {
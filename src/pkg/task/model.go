@@ -31,6 +31,16 @@ const (
 	ExecutionTriggerManual   = "MANUAL"
 	ExecutionTriggerSchedule = "SCHEDULE"
 	ExecutionTriggerEvent    = "EVENT"
+	ExecutionTriggerWorkflow = "WORKFLOW"
+
+	// WorkflowTriggerOnSuccess triggers the downstream node when the upstream execution succeeds
+	WorkflowTriggerOnSuccess = "Success"
+	// WorkflowTriggerOnError triggers the downstream node when the upstream execution fails
+	WorkflowTriggerOnError = "Error"
+	// WorkflowTriggerOnAny triggers the downstream node when the upstream execution succeeds or fails
+	WorkflowTriggerOnAny = "Any"
+	// WorkflowNodeSkippedStatus is the final status of the node which isn't triggered
+	WorkflowNodeSkippedStatus = "Skipped"
 )
 
 // Execution is one run for one action. It contains one or more tasks and provides the summary view of the tasks
@@ -54,6 +64,8 @@ type Execution struct {
 	StartTime  time.Time      `json:"start_time"`
 	UpdateTime time.Time      `json:"update_time"`
 	EndTime    time.Time      `json:"end_time"`
+	// the pending tasks of the paused execution aren't dequeued until it is resumed
+	Paused bool `json:"paused"`
 }
 
 // IsOnGoing returns true when the execution is running
@@ -91,6 +103,30 @@ type Task struct {
 	StatusRevision int64     `json:"status_revision"`
 }
 
+// From constructs an execution from DAO model, the metrics are not populated
+func (e *Execution) From(execution *dao.Execution) {
+	e.ID = execution.ID
+	e.VendorType = execution.VendorType
+	e.VendorID = execution.VendorID
+	e.Status = execution.Status
+	e.StatusMessage = execution.StatusMessage
+	e.Trigger = execution.Trigger
+	e.StartTime = execution.StartTime
+	e.UpdateTime = execution.UpdateTime
+	e.EndTime = execution.EndTime
+	e.Paused = execution.Paused
+	if len(execution.ExtraAttrs) > 0 {
+		extras := map[string]any{}
+		d := json.NewDecoder(bytes.NewReader([]byte(execution.ExtraAttrs)))
+		d.UseNumber()
+		if err := d.Decode(&extras); err != nil {
+			log.Errorf("failed to unmarshal the extra attributes of execution %d: %v", execution.ID, err)
+			return
+		}
+		e.ExtraAttrs = extras
+	}
+}
+
 // From constructs a task from DAO model
 func (t *Task) From(task *dao.Task) {
 	t.ID = task.ID
@@ -225,3 +261,98 @@ type Job struct {
 	Parameters job.Parameters
 	Metadata   *job.Metadata
 }
+
+// Workflow chains the executions of different vendors as a tree: the root nodes are launched when
+// the workflow is created and every downstream node is launched when its upstream node finishes
+// with the status it is triggered on
+type Workflow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// running/success/error/stopped
+	Status        string          `json:"status"`
+	StatusMessage string          `json:"status_message"`
+	Creator       string          `json:"creator"`
+	Nodes         []*WorkflowNode `json:"nodes"`
+	StartTime     time.Time       `json:"start_time"`
+	UpdateTime    time.Time       `json:"update_time"`
+	EndTime       time.Time       `json:"end_time"`
+}
+
+// From constructs a workflow from DAO model
+func (w *Workflow) From(workflow *dao.Workflow) {
+	w.ID = workflow.ID
+	w.Name = workflow.Name
+	w.Status = workflow.Status
+	w.StatusMessage = workflow.StatusMessage
+	w.Creator = workflow.Creator
+	w.StartTime = workflow.StartTime
+	w.UpdateTime = workflow.UpdateTime
+	w.EndTime = workflow.EndTime
+}
+
+// WorkflowNode is one step of the workflow which launches an execution of the vendor type
+type WorkflowNode struct {
+	ID         int64 `json:"id"`
+	WorkflowID int64 `json:"workflow_id"`
+	// the name of the node which is unique in the workflow
+	Name string `json:"name"`
+	// the name of the upstream node, empty for the root nodes
+	Upstream   string `json:"upstream"`
+	UpstreamID int64  `json:"upstream_id"`
+	// the status of the upstream execution to trigger the node: Success/Error/Any
+	TriggerOn string `json:"trigger_on"`
+	// the vendor type of the execution, a launcher must be registered for it
+	VendorType string `json:"vendor_type"`
+	// the parameters passed to the launcher
+	Parameters map[string]any `json:"parameters"`
+	// the ID of the launched execution, the root node can adopt an existing execution by
+	// specifying it when creating the workflow
+	ExecutionID int64 `json:"execution_id"`
+	// pending/running/success/error/stopped/skipped
+	Status        string    `json:"status"`
+	StatusMessage string    `json:"status_message"`
+	StartTime     time.Time `json:"start_time"`
+	UpdateTime    time.Time `json:"update_time"`
+	EndTime       time.Time `json:"end_time"`
+}
+
+// From constructs a workflow node from DAO model
+func (n *WorkflowNode) From(node *dao.WorkflowNode) {
+	n.ID = node.ID
+	n.WorkflowID = node.WorkflowID
+	n.Name = node.Name
+	n.UpstreamID = node.UpstreamID
+	n.TriggerOn = node.TriggerOn
+	n.VendorType = node.VendorType
+	n.ExecutionID = node.ExecutionID
+	n.Status = node.Status
+	n.StatusMessage = node.StatusMessage
+	n.StartTime = node.StartTime
+	n.UpdateTime = node.UpdateTime
+	n.EndTime = node.EndTime
+	if len(node.Parameters) > 0 {
+		params := map[string]any{}
+		d := json.NewDecoder(bytes.NewReader([]byte(node.Parameters)))
+		d.UseNumber()
+		if err := d.Decode(&params); err != nil {
+			log.Errorf("failed to unmarshal the parameters of workflow node %d: %v", node.ID, err)
+			return
+		}
+		n.Parameters = params
+	}
+}
+
+// IsFinal returns true when the node will not change any more
+func (n *WorkflowNode) IsFinal() bool {
+	return isWorkflowNodeFinal(n.Status)
+}
+
+func isWorkflowNodeFinal(status string) bool {
+	switch status {
+	case job.SuccessStatus.String(), job.ErrorStatus.String(),
+		job.StoppedStatus.String(), WorkflowNodeSkippedStatus:
+		return true
+	default:
+		return false
+	}
+}
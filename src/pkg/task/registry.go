@@ -25,6 +25,7 @@ var (
 	checkInProcessorRegistry              = map[string]CheckInProcessor{}
 	statusChangePostFuncRegistry          = map[string]StatusChangePostFunc{}
 	executionStatusChangePostFuncRegistry = map[string]ExecutionStatusChangePostFunc{}
+	workflowLauncherRegistry              = map[string]WorkflowLauncher{}
 )
 
 // CheckInProcessor is the processor to process the check in data which is sent by jobservice via webhook
@@ -36,6 +37,11 @@ type StatusChangePostFunc func(ctx context.Context, taskID int64, status string)
 // ExecutionStatusChangePostFunc is the function called after the execution status changed
 type ExecutionStatusChangePostFunc func(ctx context.Context, executionID int64, status string) (err error)
 
+// WorkflowLauncher launches an execution of the vendor for the workflow node and returns the execution ID.
+// The "params" contains the parameters of the node and the info of the upstream execution under the key
+// "upstream" if the node isn't a root node
+type WorkflowLauncher func(ctx context.Context, params map[string]any) (executionID int64, err error)
+
 // RegisterCheckInProcessor registers check in processor for the specific vendor type
 func RegisterCheckInProcessor(vendorType string, processor CheckInProcessor) error {
 	if _, exist := checkInProcessorRegistry[vendorType]; exist {
@@ -62,3 +68,12 @@ func RegisterExecutionStatusChangePostFunc(vendorType string, fc ExecutionStatus
 	executionStatusChangePostFuncRegistry[vendorType] = fc
 	return nil
 }
+
+// RegisterWorkflowLauncher registers a workflow launcher for the specific vendor type
+func RegisterWorkflowLauncher(vendorType string, launcher WorkflowLauncher) error {
+	if _, exist := workflowLauncherRegistry[vendorType]; exist {
+		return fmt.Errorf("the workflow launcher for %s already exists", vendorType)
+	}
+	workflowLauncherRegistry[vendorType] = launcher
+	return nil
+}
@@ -46,3 +46,12 @@ func TestRegisterExecutionStatusChangePostFunc(t *testing.T) {
 	err = RegisterExecutionStatusChangePostFunc("test", nil)
 	assert.NotNil(t, err)
 }
+
+func TestRegisterWorkflowLauncher(t *testing.T) {
+	err := RegisterWorkflowLauncher("test", nil)
+	assert.Nil(t, err)
+
+	// already exist
+	err = RegisterWorkflowLauncher("test", nil)
+	assert.NotNil(t, err)
+}
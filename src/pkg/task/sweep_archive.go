@@ -0,0 +1,197 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+const (
+	// ExecArchiveType is the system artifact type of the execution archives
+	ExecArchiveType = "ExecutionArchive"
+)
+
+var (
+	// ExecArchiveVendor is the system artifact vendor of the execution archives
+	ExecArchiveVendor = strings.ToLower(job.ExecSweepVendorType)
+)
+
+func init() {
+	// the execution archives are kept for compliance, so they are not cleaned up by the system artifact cleanup job
+	systemartifact.Mgr.RegisterCleanupCriteria(ExecArchiveVendor, ExecArchiveType, &archiveSelector{})
+}
+
+// executionArchive is the content of the archive for the swept executions, it's written by writeArchive in stream
+type executionArchive struct {
+	VendorType string               `json:"vendor_type"`
+	ArchivedAt time.Time            `json:"archived_at"`
+	Executions []*archivedExecution `json:"executions"`
+}
+
+type archivedExecution struct {
+	*Execution
+	Tasks []*archivedTask `json:"tasks"`
+}
+
+type archivedTask struct {
+	*Task
+	Log string `json:"log"`
+}
+
+// archiveSelector selects none of the execution archives for the cleanup
+type archiveSelector struct{}
+
+func (a *archiveSelector) List(_ context.Context) ([]*model.SystemArtifact, error) {
+	return nil, nil
+}
+
+func (a *archiveSelector) ListWithFilters(ctx context.Context, query *q.Query) ([]*model.SystemArtifact, error) {
+	return systemartifact.DefaultSelector.ListWithFilters(ctx, query)
+}
+
+func (sm *sweepManager) Archive(ctx context.Context, vendorType string, execIDs []int64) error {
+	if len(execIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]any, 0, len(execIDs))
+	for _, id := range execIDs {
+		ids = append(ids, id)
+	}
+	executions, err := sm.execDAO.List(ctx, &q.Query{Keywords: map[string]any{"ID": &q.OrList{Values: ids}}})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list executions to archive, vendor type: %s", vendorType)
+	}
+	tasks, err := sm.taskDAO.List(ctx, &q.Query{Keywords: map[string]any{"ExecutionID": &q.OrList{Values: ids}}})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tasks to archive, vendor type: %s", vendorType)
+	}
+
+	execTasks := make(map[int64][]*dao.Task, len(executions))
+	for _, tk := range tasks {
+		execTasks[tk.ExecutionID] = append(execTasks[tk.ExecutionID], tk)
+	}
+
+	// spool the compressed archive to a temporary file rather than the memory as it carries the task logs
+	file, err := os.CreateTemp("", "execution-archive-*.json.gz")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the temporary file for the archive, vendor type: %s", vendorType)
+	}
+	defer func() {
+		file.Close()
+		if err := os.Remove(file.Name()); err != nil {
+			log.Warningf("failed to remove the temporary file %s of the archive: %v", file.Name(), err)
+		}
+	}()
+	digester := digest.Canonical.Digester()
+	gw := gzip.NewWriter(io.MultiWriter(file, digester.Hash()))
+	if err = sm.writeArchive(gw, vendorType, executions, execTasks); err != nil {
+		return errors.Wrapf(err, "failed to encode the archive, vendor type: %s", vendorType)
+	}
+	if err = gw.Close(); err != nil {
+		return errors.Wrapf(err, "failed to compress the archive, vendor type: %s", vendorType)
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the size of the archive, vendor type: %s", vendorType)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "failed to rewind the archive, vendor type: %s", vendorType)
+	}
+
+	extras, err := json.Marshal(map[string]any{
+		"vendor_type":     vendorType,
+		"execution_count": len(executions),
+	})
+	if err != nil {
+		return err
+	}
+	artifact := &model.SystemArtifact{
+		Repository: fmt.Sprintf("%s_%d_%d", strings.ToLower(vendorType), execIDs[0], execIDs[len(execIDs)-1]),
+		Digest:     digester.Digest().String(),
+		Size:       size,
+		Type:       ExecArchiveType,
+		Vendor:     ExecArchiveVendor,
+		ExtraAttrs: string(extras),
+	}
+	if _, err = sm.sysArtifactMgr.Create(ctx, artifact, file); err != nil {
+		return errors.Wrapf(err, "failed to store the archive, vendor type: %s", vendorType)
+	}
+
+	return nil
+}
+
+// writeArchive writes the archive in the format of executionArchive execution by execution,
+// so that only the task logs of one execution are held in the memory at a time
+func (sm *sweepManager) writeArchive(w io.Writer, vendorType string, executions []*dao.Execution, tasks map[int64][]*dao.Task) error {
+	vt, err := json.Marshal(vendorType)
+	if err != nil {
+		return err
+	}
+	at, err := json.Marshal(time.Now())
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(w, `{"vendor_type":%s,"archived_at":%s,"executions":[`, vt, at); err != nil {
+		return err
+	}
+	for i, execution := range executions {
+		exec := &archivedExecution{Execution: &Execution{}, Tasks: []*archivedTask{}}
+		exec.From(execution)
+		for _, tk := range tasks[execution.ID] {
+			t := &archivedTask{Task: &Task{}}
+			t.From(tk)
+			if len(tk.JobID) > 0 {
+				// the log may be purged already, archive the task without log in this case
+				data, err := sm.retrieveLog(tk.JobID)
+				if err != nil {
+					log.Warningf("failed to retrieve the log of task %d for archiving: %v", tk.ID, err)
+				} else {
+					t.Log = string(data)
+				}
+			}
+			exec.Tasks = append(exec.Tasks, t)
+		}
+		data, err := json.Marshal(exec)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			data = append([]byte(","), data...)
+		}
+		if _, err = w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
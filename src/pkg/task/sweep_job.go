@@ -47,17 +47,25 @@ func init() {
 var (
 	// notice that the batch size should not over than 65535 as length limitation of postgres parameters
 	sweepBatchSize int
-	errStop        = errors.New("stopped")
+	// archiveBatchSize is the max count of executions stored in one archive
+	archiveBatchSize = 1000
+	errStop          = errors.New("stopped")
 )
 
 const (
 	// ExecRetainCounts is the params key of execution retain count
 	ExecRetainCounts = "execution_retain_counts"
+	// ExecRetainDays is the params key of execution retain days
+	ExecRetainDays = "execution_retain_days"
+	// ExecArchiveEnabled is the params key of the flag to archive the executions before deleting them
+	ExecArchiveEnabled = "execution_archive_enabled"
 )
 
 // SweepJob used to cleanup the executions and tasks for different vendors.
 type SweepJob struct {
 	execRetainCountsMap map[string]int64
+	execRetainDays      int64
+	archiveEnabled      bool
 	logger              logger.Interface
 	mgr                 SweepManager
 }
@@ -143,14 +151,25 @@ func (sj *SweepJob) parseParams(params job.Parameters) {
 		sj.logger.Errorf("failed to unmarshal params %s, error: %v", string(execRetainCounts), err)
 		return
 	}
+
+	// the number is float64 if the params are unmarshalled from json
+	switch days := params[ExecRetainDays].(type) {
+	case float64:
+		sj.execRetainDays = int64(days)
+	case int64:
+		sj.execRetainDays = days
+	case int:
+		sj.execRetainDays = int64(days)
+	}
+	sj.archiveEnabled, _ = params[ExecArchiveEnabled].(bool)
 }
 
 // sweep cleanup the executions/tasks by vendor type and retain count.
 func (sj *SweepJob) sweep(ctx job.Context, vendorType string, retainCount int64) error {
-	sj.logger.Infof("[%s] start to sweep, retain latest %d executions", vendorType, retainCount)
+	sj.logger.Infof("[%s] start to sweep, retain latest %d executions and the executions within %d days", vendorType, retainCount, sj.execRetainDays)
 
 	start := time.Now()
-	candidates, err := sj.mgr.ListCandidates(ctx.SystemContext(), vendorType, retainCount)
+	candidates, err := sj.mgr.ListCandidates(ctx.SystemContext(), vendorType, retainCount, sj.execRetainDays)
 	if err != nil {
 		sj.logger.Errorf("[%s] failed to list candidates, error: %v", vendorType, err)
 		return err
@@ -167,6 +186,13 @@ func (sj *SweepJob) sweep(ctx job.Context, vendorType string, retainCount int64)
 		// calculate the batch position
 		j := min(i+sweepBatchSize, total)
 
+		// the executions are deleted only after they are archived successfully
+		if sj.archiveEnabled {
+			if err = sj.archive(ctx, vendorType, candidates[i:j]); err != nil {
+				return err
+			}
+		}
+
 		if err = sj.mgr.Clean(ctx.SystemContext(), candidates[i:j]); err != nil {
 			sj.logger.Errorf("[%s] failed to batch clean candidates, error: %v", vendorType, err)
 			return err
@@ -178,6 +204,27 @@ func (sj *SweepJob) sweep(ctx job.Context, vendorType string, retainCount int64)
 	return nil
 }
 
+// archive archives the executions in batches before cleaning them.
+func (sj *SweepJob) archive(ctx job.Context, vendorType string, execIDs []int64) error {
+	total := len(execIDs)
+	for i := 0; i < total; i += archiveBatchSize {
+		// checkpoint
+		if sj.shouldStop(ctx) {
+			return errStop
+		}
+		j := min(i+archiveBatchSize, total)
+
+		if err := sj.mgr.Archive(ctx.SystemContext(), vendorType, execIDs[i:j]); err != nil {
+			sj.logger.Errorf("[%s] failed to archive candidates, error: %v", vendorType, err)
+			return err
+		}
+	}
+
+	sj.logger.Infof("[%s] %d executions were archived", vendorType, total)
+
+	return nil
+}
+
 func (sj *SweepJob) shouldStop(ctx job.Context) bool {
 	opCmd, exit := ctx.OPCommand()
 	if exit && opCmd.IsStop() {
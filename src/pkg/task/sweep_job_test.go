@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
 )
 
@@ -64,11 +65,46 @@ func (suite *sweepJobTestSuite) TestRun() {
 	ctx := context.TODO()
 	suite.jobCtx.On("OPCommand").Return(job.NilCommand, true)
 	suite.jobCtx.On("SystemContext").Return(ctx, nil)
-	suite.sweepMgr.On("ListCandidates", ctx, "WEBHOOK", int64(10)).Return([]int64{1}, nil)
-	suite.sweepMgr.On("ListCandidates", ctx, "REPLICATION", int64(20)).Return([]int64{2}, nil)
+	suite.sweepMgr.On("ListCandidates", ctx, "WEBHOOK", int64(10), int64(0)).Return([]int64{1}, nil)
+	suite.sweepMgr.On("ListCandidates", ctx, "REPLICATION", int64(20), int64(0)).Return([]int64{2}, nil)
 	suite.sweepMgr.On("Clean", ctx, []int64{1}).Return(nil)
 	suite.sweepMgr.On("Clean", ctx, []int64{2}).Return(nil)
 	suite.sweepMgr.On("FixDanglingStateExecution", ctx).Return(nil)
 	err = j.Run(suite.jobCtx, params)
 	suite.NoError(err)
 }
+
+func (suite *sweepJobTestSuite) TestRunWithArchive() {
+	params := map[string]any{
+		"execution_retain_counts": map[string]int{
+			"WEBHOOK": 10,
+		},
+		"execution_retain_days":     float64(90),
+		"execution_archive_enabled": true,
+	}
+	ctx := context.TODO()
+	jobCtx := &mockjobservice.MockJobContext{}
+	jobCtx.On("OPCommand").Return(job.NilCommand, true)
+	jobCtx.On("SystemContext").Return(ctx, nil)
+
+	// the executions should not be cleaned if failed to archive them
+	sweepMgr := &mockSweepManager{}
+	sweepMgr.On("FixDanglingStateExecution", ctx).Return(nil)
+	sweepMgr.On("ListCandidates", ctx, "WEBHOOK", int64(10), int64(90)).Return([]int64{1, 2}, nil)
+	sweepMgr.On("Archive", ctx, "WEBHOOK", []int64{1, 2}).Return(errors.New("failed to archive"))
+	j := &SweepJob{mgr: sweepMgr}
+	err := j.Run(jobCtx, params)
+	suite.Error(err)
+	sweepMgr.AssertNotCalled(suite.T(), "Clean", ctx, []int64{1, 2})
+
+	// normal case
+	sweepMgr = &mockSweepManager{}
+	sweepMgr.On("FixDanglingStateExecution", ctx).Return(nil)
+	sweepMgr.On("ListCandidates", ctx, "WEBHOOK", int64(10), int64(90)).Return([]int64{1, 2}, nil)
+	sweepMgr.On("Archive", ctx, "WEBHOOK", []int64{1, 2}).Return(nil)
+	sweepMgr.On("Clean", ctx, []int64{1, 2}).Return(nil)
+	j = &SweepJob{mgr: sweepMgr}
+	err = j.Run(jobCtx, params)
+	suite.NoError(err)
+	sweepMgr.AssertExpectations(suite.T())
+}
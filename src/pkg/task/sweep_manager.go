@@ -21,9 +21,11 @@ import (
 
 	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 )
 
@@ -37,8 +39,11 @@ var (
 )
 
 type SweepManager interface {
-	// ListCandidates lists the candidate execution ids which met the sweep criteria.
-	ListCandidates(ctx context.Context, vendorType string, retainCnt int64) (execIDs []int64, err error)
+	// ListCandidates lists the candidate execution ids which met the sweep criteria, the latest retainCnt executions
+	// and the executions started within retainDays days are retained, 0 retainDays disables the time based retention.
+	ListCandidates(ctx context.Context, vendorType string, retainCnt int64, retainDays int64) (execIDs []int64, err error)
+	// Archive stores the executions and the logs of their tasks as a compressed system artifact.
+	Archive(ctx context.Context, vendorType string, execIDs []int64) (err error)
 	// Clean deletes the tasks belonging to the execution which in final status and deletes executions.
 	Clean(ctx context.Context, execID []int64) (err error)
 	// FixDanglingStateExecution fixes the dangling state execution.
@@ -47,7 +52,10 @@ type SweepManager interface {
 
 // sweepManager implements the interface SweepManager.
 type sweepManager struct {
-	execDAO dao.ExecutionDAO
+	execDAO        dao.ExecutionDAO
+	taskDAO        dao.TaskDAO
+	sysArtifactMgr systemartifact.Manager
+	retrieveLog    func(logID string) ([]byte, error)
 }
 
 // listVendorIDs lists distinct vendor ids by vendor type.
@@ -92,7 +100,7 @@ func (sm *sweepManager) getCandidateMaxStartTime(ctx context.Context, vendorType
 	return &executions[0].StartTime, nil
 }
 
-func (sm *sweepManager) ListCandidates(ctx context.Context, vendorType string, retainCnt int64) ([]int64, error) {
+func (sm *sweepManager) ListCandidates(ctx context.Context, vendorType string, retainCnt int64, retainDays int64) ([]int64, error) {
 	ormer, err := orm.FromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -103,6 +111,13 @@ func (sm *sweepManager) ListCandidates(ctx context.Context, vendorType string, r
 		return nil, errors.Wrapf(err, "failed to list vendor ids for vendor type %s", vendorType)
 	}
 
+	// the executions started after the retention time are retained even if they are out of the retain count
+	var retentionTime *time.Time
+	if retainDays > 0 {
+		t := time.Now().AddDate(0, 0, -int(retainDays))
+		retentionTime = &t
+	}
+
 	// execIDs stores the result
 	var execIDs []int64
 	for _, vendorID := range vendorIDs {
@@ -114,6 +129,9 @@ func (sm *sweepManager) ListCandidates(ctx context.Context, vendorType string, r
 		if maxStartTime == nil {
 			continue
 		}
+		if retentionTime != nil && retentionTime.Before(*maxStartTime) {
+			maxStartTime = retentionTime
+		}
 		// candidate criteria
 		// 1. exact vendor type & vendor id
 		// 2. start_time is before the max start time
@@ -212,6 +230,9 @@ WHERE status = 'Running'
 
 func NewSweepManager() SweepManager {
 	return &sweepManager{
-		execDAO: dao.NewExecutionDAO(),
+		execDAO:        dao.NewExecutionDAO(),
+		taskDAO:        dao.NewTaskDAO(),
+		sysArtifactMgr: systemartifact.Mgr,
+		retrieveLog:    logger.Retrieve,
 	}
 }
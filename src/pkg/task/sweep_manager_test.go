@@ -15,16 +15,23 @@
 package task
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 	htesting "github.com/goharbor/harbor/src/testing"
 	"github.com/goharbor/harbor/src/testing/mock"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
 )
 
 type sweepManagerTestSuite struct {
@@ -62,3 +69,64 @@ func (suite *sweepManagerTestSuite) Test_sweepManager_FixDanglingStateExecution(
 	err := suite.mgr.FixDanglingStateExecution(suite.Context())
 	suite.NoError(err, "should not got error")
 }
+
+func (suite *sweepManagerTestSuite) TestArchive() {
+	execDAO := &mockExecutionDAO{}
+	taskDAO := &mockTaskDAO{}
+	sysArtifactMgr := &systemartifacttesting.Manager{}
+	mgr := &sweepManager{
+		execDAO:        execDAO,
+		taskDAO:        taskDAO,
+		sysArtifactMgr: sysArtifactMgr,
+		retrieveLog: func(logID string) ([]byte, error) {
+			if logID == "job-1" {
+				return []byte("log of job-1"), nil
+			}
+			return nil, errors.NotFoundError(nil)
+		},
+	}
+
+	execDAO.On("List", mock.Anything, mock.Anything).Return([]*dao.Execution{
+		{ID: 1, VendorType: "WEBHOOK", VendorID: 1, Status: "Success", ExtraAttrs: `{"event_type":"PUSH_ARTIFACT"}`},
+		{ID: 2, VendorType: "WEBHOOK", VendorID: 1, Status: "Error"},
+	}, nil)
+	taskDAO.On("List", mock.Anything, mock.Anything).Return([]*dao.Task{
+		{ID: 1, ExecutionID: 1, JobID: "job-1", Status: "Success"},
+		{ID: 2, ExecutionID: 2, JobID: "job-2", Status: "Error"},
+	}, nil)
+
+	archive := &executionArchive{}
+	var artifact *model.SystemArtifact
+	sysArtifactMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		artifact = args.Get(1).(*model.SystemArtifact)
+		data, err := io.ReadAll(args.Get(2).(io.Reader))
+		suite.Require().NoError(err)
+		suite.Equal(digest.FromBytes(data).String(), artifact.Digest)
+		suite.Equal(int64(len(data)), artifact.Size)
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		suite.Require().NoError(err)
+		suite.Require().NoError(json.NewDecoder(gr).Decode(archive))
+	}).Return(int64(1), nil)
+
+	err := mgr.Archive(context.TODO(), "WEBHOOK", []int64{1, 2})
+	suite.Require().NoError(err)
+	suite.Equal(ExecArchiveVendor, artifact.Vendor)
+	suite.Equal(ExecArchiveType, artifact.Type)
+	suite.Equal("webhook_1_2", artifact.Repository)
+	suite.Equal("WEBHOOK", archive.VendorType)
+	suite.Require().Len(archive.Executions, 2)
+	suite.Equal(int64(1), archive.Executions[0].ID)
+	suite.Equal("PUSH_ARTIFACT", archive.Executions[0].ExtraAttrs["event_type"])
+	suite.Require().Len(archive.Executions[0].Tasks, 1)
+	suite.Equal("log of job-1", archive.Executions[0].Tasks[0].Log)
+	suite.Require().Len(archive.Executions[1].Tasks, 1)
+	suite.Empty(archive.Executions[1].Tasks[0].Log)
+
+	// the executions should not be archived if failed to list the tasks
+	taskDAO = &mockTaskDAO{}
+	taskDAO.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("failed to list tasks"))
+	mgr.taskDAO = taskDAO
+	err = mgr.Archive(context.TODO(), "WEBHOOK", []int64{1, 2})
+	suite.Error(err)
+	sysArtifactMgr.AssertNumberOfCalls(suite.T(), "Create", 1)
+}
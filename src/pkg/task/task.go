@@ -18,10 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"time"
 
 	cjob "github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/common/job/models"
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -55,6 +57,10 @@ type Manager interface {
 	GetLog(ctx context.Context, id int64) (log []byte, err error)
 	// GetLogByJobID get the log of specified job id
 	GetLogByJobID(ctx context.Context, jobID string) (log []byte, err error)
+	// QueryLog gets the log entries of the specified task matching the query
+	QueryLog(ctx context.Context, id int64, logQuery *jobquery.LogQuery) (log []byte, err error)
+	// QueryLogByJobID gets the log entries of the specified job id matching the query
+	QueryLogByJobID(ctx context.Context, jobID string, logQuery *jobquery.LogQuery) (log []byte, err error)
 	// Count counts total of tasks according to the query.
 	// Query the "ExtraAttrs" by setting 'query.Keywords["ExtraAttrs.key"]="value"'
 	Count(ctx context.Context, query *q.Query) (int64, error)
@@ -114,7 +120,7 @@ func (m *manager) Create(ctx context.Context, executionID int64, jb *Job, extraA
 	// when the job is submitted to the jobservice and running, the task record may not
 	// insert yet, this will cause the status hook handler returning 404, and the jobservice
 	// will re-send the status hook again
-	jobID, err := m.submitJob(ctx, id, jb)
+	jobID, err := m.submitJob(ctx, executionID, id, jb)
 	if err != nil {
 		// failed to submit job to jobservice, delete the task record
 		log.Errorf("delete task %d from db due to failed to submit job %v, error: %v", id, jb.Name, err)
@@ -163,13 +169,21 @@ func (m *manager) createTaskRecord(ctx context.Context, executionID int64, extra
 	})
 }
 
-func (m *manager) submitJob(_ context.Context, id int64, jb *Job) (string, error) {
+func (m *manager) submitJob(_ context.Context, executionID, id int64, jb *Job) (string, error) {
 	jobData := &models.JobData{
 		Name:       jb.Name,
 		StatusHook: fmt.Sprintf("%s/service/notifications/tasks/%d", m.coreURL, id),
 	}
 	if jb.Parameters != nil {
-		jobData.Parameters = models.Parameters(jb.Parameters)
+		jobData.Parameters = models.Parameters(maps.Clone(jb.Parameters))
+	}
+	// Pass the execution ID to tag the job logs, skip the unique and periodic jobs
+	// as the parameters are used to identify the unique jobs and shared by the periodic executions
+	if jb.Metadata == nil || (!jb.Metadata.IsUnique && jb.Metadata.JobKind != job.KindPeriodic) {
+		if jobData.Parameters == nil {
+			jobData.Parameters = models.Parameters{}
+		}
+		jobData.Parameters[job.ExecutionIDParamKey] = executionID
 	}
 	if jb.Metadata != nil {
 		jobData.Metadata = &models.JobMetadata{
@@ -287,6 +301,18 @@ func (m *manager) GetLogByJobID(_ context.Context, jobID string) (log []byte, er
 	return m.jsClient.GetJobLog(jobID)
 }
 
+func (m *manager) QueryLog(ctx context.Context, id int64, logQuery *jobquery.LogQuery) ([]byte, error) {
+	task, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.QueryLogByJobID(ctx, task.JobID, logQuery)
+}
+
+func (m *manager) QueryLogByJobID(_ context.Context, jobID string, logQuery *jobquery.LogQuery) ([]byte, error) {
+	return m.jsClient.QueryJobLog(jobID, logQuery)
+}
+
 func (m *manager) RetrieveStatusFromTask(ctx context.Context, reportID string) string {
 	if len(reportID) == 0 {
 		return ""
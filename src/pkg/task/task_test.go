@@ -22,6 +22,8 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	cjob "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/common/job/models"
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
@@ -58,7 +60,10 @@ func (t *taskManagerTestSuite) TestCreate() {
 	// success to submit job to jobservice
 	t.execDAO.On("Get", mock.Anything, mock.Anything).Return(&dao.Execution{}, nil)
 	t.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
-	t.jsClient.On("SubmitJob", mock.Anything).Return("1", nil)
+	t.jsClient.On("SubmitJob", mock.MatchedBy(func(jobData *models.JobData) bool {
+		// the execution ID is passed to tag the job logs
+		return jobData.Parameters[job.ExecutionIDParamKey] == int64(1)
+	})).Return("1", nil)
 	t.dao.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	id, err := t.mgr.Create(nil, 1, &Job{}, map[string]any{"a": "b"})
@@ -127,6 +132,20 @@ func (t *taskManagerTestSuite) TestGet() {
 	t.dao.AssertExpectations(t.T())
 }
 
+func (t *taskManagerTestSuite) TestQueryLog() {
+	lq := &jobquery.LogQuery{Level: "ERROR"}
+	t.dao.On("Get", mock.Anything, mock.Anything).Return(&dao.Task{
+		ID:    1,
+		JobID: "1",
+	}, nil)
+	t.jsClient.On("QueryJobLog", "1", lq).Return([]byte("error log"), nil)
+	log, err := t.mgr.QueryLog(nil, 1, lq)
+	t.Require().Nil(err)
+	t.Equal("error log", string(log))
+	t.dao.AssertExpectations(t.T())
+	t.jsClient.AssertExpectations(t.T())
+}
+
 func (t *taskManagerTestSuite) TestUpdateExtraAttrs() {
 	t.dao.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	err := t.mgr.UpdateExtraAttrs(nil, 1, map[string]any{})
@@ -0,0 +1,544 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/gtask"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+const (
+	// WorkflowUpstreamParamKey is the key of the upstream execution info in the parameters passed to the launcher
+	WorkflowUpstreamParamKey = "upstream"
+
+	workflowReconcileInterval = 10 * time.Second
+	// the running node which doesn't record its execution within the timeout is treated as failed,
+	// e.g. the core is restarted during launching the execution
+	workflowLaunchTimeout = 10 * time.Minute
+)
+
+// WorkflowMgr is a global workflow manager instance
+var WorkflowMgr = NewWorkflowManager()
+
+func init() {
+	gtask.DefaultPool().AddTask(func(ctx context.Context) {
+		if err := WorkflowMgr.Reconcile(ctx); err != nil {
+			log.Errorf("failed to reconcile the workflows: %v", err)
+		}
+	}, workflowReconcileInterval)
+}
+
+// WorkflowManager manages the workflows which chain the executions of different vendors,
+// e.g. "replicate, then scan, then preheat" or "retention, then GC"
+type WorkflowManager interface {
+	// Create the workflow and launch its root nodes. The nodes reference their upstream by name and
+	// a root node can adopt an existing execution by specifying its ID
+	Create(ctx context.Context, workflow *Workflow) (id int64, err error)
+	// Get the specified workflow with its nodes
+	Get(ctx context.Context, id int64) (workflow *Workflow, err error)
+	// List the workflows according to the query, the nodes aren't populated
+	List(ctx context.Context, query *q.Query) (workflows []*Workflow, err error)
+	// Count the workflows according to the query
+	Count(ctx context.Context, query *q.Query) (count int64, err error)
+	// Stop the workflow as a whole: the pending nodes are skipped and the running executions are stopped
+	Stop(ctx context.Context, id int64) (err error)
+	// Delete the workflow in final status, the executions launched by it are kept
+	Delete(ctx context.Context, id int64) (err error)
+	// Reconcile syncs the status of the running nodes with their executions, launches the downstream
+	// nodes whose upstream are done and finishes the workflows whose nodes are all done.
+	// It's called periodically and is safe to be called by multiple instances concurrently
+	Reconcile(ctx context.Context) (err error)
+}
+
+// NewWorkflowManager returns an instance of the default workflow manager
+func NewWorkflowManager() WorkflowManager {
+	return &workflowManager{
+		wfDAO:   dao.NewWorkflowDAO(),
+		execMgr: ExecMgr,
+	}
+}
+
+type workflowManager struct {
+	wfDAO   dao.WorkflowDAO
+	execMgr ExecutionManager
+}
+
+func (w *workflowManager) Create(ctx context.Context, workflow *Workflow) (int64, error) {
+	nodes, err := w.validate(ctx, workflow)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		id    int64
+		roots []*dao.WorkflowNode
+	)
+	if err = orm.WithTransaction(func(ctx context.Context) error {
+		now := time.Now()
+		id, err = w.wfDAO.Create(ctx, &dao.Workflow{
+			Name:       workflow.Name,
+			Status:     job.RunningStatus.String(),
+			Creator:    workflow.Creator,
+			StartTime:  now,
+			UpdateTime: now,
+		})
+		if err != nil {
+			return err
+		}
+
+		ids := map[string]int64{}
+		for _, node := range nodes {
+			params, err := json.Marshal(node.Parameters)
+			if err != nil {
+				return err
+			}
+			n := &dao.WorkflowNode{
+				WorkflowID:  id,
+				UpstreamID:  ids[node.Upstream],
+				TriggerOn:   node.TriggerOn,
+				Name:        node.Name,
+				VendorType:  node.VendorType,
+				Parameters:  string(params),
+				ExecutionID: node.ExecutionID,
+				Status:      job.PendingStatus.String(),
+				UpdateTime:  now,
+			}
+			// the adopted execution is running already
+			if n.ExecutionID > 0 {
+				n.Status = job.RunningStatus.String()
+				n.StartTime = now
+			}
+			n.ID, err = w.wfDAO.CreateNode(ctx, n)
+			if err != nil {
+				return err
+			}
+			ids[n.Name] = n.ID
+			if n.UpstreamID == 0 && n.ExecutionID == 0 {
+				roots = append(roots, n)
+			}
+		}
+		return nil
+	})(ctx); err != nil {
+		return 0, err
+	}
+
+	for _, root := range roots {
+		w.launch(ctx, root, nil)
+	}
+	return id, nil
+}
+
+// validate the workflow and returns the nodes sorted to make sure every node is after its upstream
+func (w *workflowManager) validate(ctx context.Context, workflow *Workflow) ([]*WorkflowNode, error) {
+	if workflow == nil || len(workflow.Name) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("the name of the workflow is required")
+	}
+	if len(workflow.Nodes) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("the workflow contains no nodes")
+	}
+
+	names := map[string]*WorkflowNode{}
+	for _, node := range workflow.Nodes {
+		if len(node.Name) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("the name of the workflow node is required")
+		}
+		if _, exist := names[node.Name]; exist {
+			return nil, errors.BadRequestError(nil).WithMessagef("duplicate workflow node %s", node.Name)
+		}
+		names[node.Name] = node
+	}
+
+	for _, node := range workflow.Nodes {
+		if _, exist := workflowLauncherRegistry[node.VendorType]; !exist {
+			return nil, errors.BadRequestError(nil).WithMessagef("the vendor type %s of the node %s can't be launched by workflow", node.VendorType, node.Name)
+		}
+		if len(node.TriggerOn) == 0 {
+			node.TriggerOn = WorkflowTriggerOnSuccess
+		}
+		switch node.TriggerOn {
+		case WorkflowTriggerOnSuccess, WorkflowTriggerOnError, WorkflowTriggerOnAny:
+		default:
+			return nil, errors.BadRequestError(nil).WithMessagef("invalid trigger %s of the node %s", node.TriggerOn, node.Name)
+		}
+		if len(node.Upstream) > 0 {
+			if _, exist := names[node.Upstream]; !exist || node.Upstream == node.Name {
+				return nil, errors.BadRequestError(nil).WithMessagef("invalid upstream %s of the node %s", node.Upstream, node.Name)
+			}
+		}
+		if node.ExecutionID > 0 {
+			if len(node.Upstream) > 0 {
+				return nil, errors.BadRequestError(nil).WithMessagef("only the root node can adopt an existing execution, node %s", node.Name)
+			}
+			execution, err := w.execMgr.Get(ctx, node.ExecutionID)
+			if err != nil {
+				return nil, err
+			}
+			if execution.VendorType != node.VendorType {
+				return nil, errors.BadRequestError(nil).WithMessagef("the execution %d isn't a %s execution", node.ExecutionID, node.VendorType)
+			}
+		}
+	}
+
+	// sort the nodes, the nodes left are in a cycle
+	var sorted []*WorkflowNode
+	added := map[string]bool{"": true}
+	for len(sorted) < len(workflow.Nodes) {
+		progress := false
+		for _, node := range workflow.Nodes {
+			if !added[node.Name] && added[node.Upstream] {
+				sorted = append(sorted, node)
+				added[node.Name] = true
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, errors.BadRequestError(nil).WithMessage("the nodes of the workflow contain a cycle")
+		}
+	}
+	return sorted, nil
+}
+
+// launch claims the pending node and launches its execution, the status of the node is updated in place
+func (w *workflowManager) launch(ctx context.Context, node *dao.WorkflowNode, upstream *dao.WorkflowNode) {
+	claimed, err := w.wfDAO.UpdateNodeStatus(ctx, node.ID, job.RunningStatus.String(), "", job.PendingStatus.String())
+	if err != nil {
+		log.Errorf("failed to claim the workflow node %d: %v", node.ID, err)
+		return
+	}
+	// launched by others or skipped because the workflow is stopped
+	if !claimed {
+		return
+	}
+	node.Status = job.RunningStatus.String()
+
+	executionID, err := w.launchExecution(ctx, node, upstream)
+	if err != nil {
+		log.Errorf("failed to launch the execution for the workflow node %d: %v", node.ID, err)
+		w.updateNodeStatus(ctx, node, job.ErrorStatus.String(), err.Error())
+		return
+	}
+	if err = w.wfDAO.SetNodeExecution(ctx, node.ID, executionID); err != nil {
+		// the node will be failed by the launch timeout
+		log.Errorf("failed to record the execution %d of the workflow node %d: %v", executionID, node.ID, err)
+		return
+	}
+	node.ExecutionID = executionID
+
+	// the workflow may be stopped during launching
+	workflow, err := w.wfDAO.Get(ctx, node.WorkflowID)
+	if err != nil {
+		log.Errorf("failed to get the workflow %d: %v", node.WorkflowID, err)
+		return
+	}
+	if workflow.Status == job.StoppedStatus.String() {
+		if err = w.execMgr.Stop(ctx, executionID); err != nil {
+			log.Errorf("failed to stop the execution %d of the stopped workflow %d: %v", executionID, workflow.ID, err)
+		}
+	}
+}
+
+func (w *workflowManager) launchExecution(ctx context.Context, node *dao.WorkflowNode, upstream *dao.WorkflowNode) (int64, error) {
+	launcher, exist := workflowLauncherRegistry[node.VendorType]
+	if !exist {
+		return 0, fmt.Errorf("no workflow launcher registered for %s", node.VendorType)
+	}
+
+	params := map[string]any{}
+	if len(node.Parameters) > 0 {
+		d := json.NewDecoder(bytes.NewReader([]byte(node.Parameters)))
+		d.UseNumber()
+		if err := d.Decode(&params); err != nil {
+			return 0, fmt.Errorf("invalid parameters: %v", err)
+		}
+		// the parameters are "null" if not set
+		if params == nil {
+			params = map[string]any{}
+		}
+	}
+	if upstream != nil {
+		info := map[string]any{
+			"node":         upstream.Name,
+			"vendor_type":  upstream.VendorType,
+			"execution_id": upstream.ExecutionID,
+			"status":       upstream.Status,
+		}
+		if upstream.ExecutionID > 0 {
+			execution, err := w.execMgr.Get(ctx, upstream.ExecutionID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get the upstream execution %d: %v", upstream.ExecutionID, err)
+			}
+			info["extra_attrs"] = execution.ExtraAttrs
+		}
+		params[WorkflowUpstreamParamKey] = info
+	}
+	return launcher(ctx, params)
+}
+
+// updateNodeStatus updates the status of the running node, the status of the node is updated in place
+func (w *workflowManager) updateNodeStatus(ctx context.Context, node *dao.WorkflowNode, status, message string) {
+	updated, err := w.wfDAO.UpdateNodeStatus(ctx, node.ID, status, message, node.Status)
+	if err != nil {
+		log.Errorf("failed to update the status of the workflow node %d to %s: %v", node.ID, status, err)
+		return
+	}
+	if updated {
+		node.Status = status
+		node.StatusMessage = message
+	}
+}
+
+func (w *workflowManager) Get(ctx context.Context, id int64) (*Workflow, error) {
+	wf, err := w.wfDAO.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := w.wfDAO.ListNodes(ctx, q.New(q.KeyWords{"WorkflowID": id}))
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := &Workflow{}
+	workflow.From(wf)
+	names := map[int64]string{}
+	for _, node := range nodes {
+		names[node.ID] = node.Name
+	}
+	for _, node := range nodes {
+		n := &WorkflowNode{}
+		n.From(node)
+		n.Upstream = names[node.UpstreamID]
+		workflow.Nodes = append(workflow.Nodes, n)
+	}
+	return workflow, nil
+}
+
+func (w *workflowManager) List(ctx context.Context, query *q.Query) ([]*Workflow, error) {
+	wfs, err := w.wfDAO.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []*Workflow
+	for _, wf := range wfs {
+		workflow := &Workflow{}
+		workflow.From(wf)
+		workflows = append(workflows, workflow)
+	}
+	return workflows, nil
+}
+
+func (w *workflowManager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return w.wfDAO.Count(ctx, query)
+}
+
+func (w *workflowManager) Stop(ctx context.Context, id int64) error {
+	wf, err := w.wfDAO.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if wf.Status != job.RunningStatus.String() {
+		return errors.PreconditionFailedError(nil).WithMessagef("the workflow %d isn't running", id)
+	}
+	stopped, err := w.wfDAO.UpdateStatus(ctx, id, job.StoppedStatus.String(), "", job.RunningStatus.String())
+	if err != nil {
+		return err
+	}
+	// finished during stopping
+	if !stopped {
+		return nil
+	}
+
+	nodes, err := w.wfDAO.ListNodes(ctx, q.New(q.KeyWords{"WorkflowID": id}))
+	if err != nil {
+		return err
+	}
+	var errs errors.Errors
+	for _, node := range nodes {
+		switch node.Status {
+		case job.PendingStatus.String():
+			if _, err := w.wfDAO.UpdateNodeStatus(ctx, node.ID, WorkflowNodeSkippedStatus,
+				"the workflow is stopped", job.PendingStatus.String()); err != nil {
+				errs = append(errs, err)
+			}
+		case job.RunningStatus.String():
+			// the status of the node is synced with the stopped execution by the reconciliation,
+			// the node which is launching its execution stops the execution after launched
+			if node.ExecutionID == 0 {
+				continue
+			}
+			if err := w.execMgr.Stop(ctx, node.ExecutionID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (w *workflowManager) Delete(ctx context.Context, id int64) error {
+	wf, err := w.wfDAO.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if wf.Status == job.RunningStatus.String() {
+		return errors.PreconditionFailedError(nil).WithMessagef("the workflow %d is running, stop it first", id)
+	}
+	return w.wfDAO.Delete(ctx, id)
+}
+
+func (w *workflowManager) Reconcile(ctx context.Context) error {
+	// the running nodes of the stopped workflows are synced as well to get the status of the stopped executions
+	nodes, err := w.wfDAO.ListNodes(ctx, q.New(q.KeyWords{"Status": job.RunningStatus.String()}))
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		w.syncNode(ctx, node)
+	}
+
+	workflows, err := w.wfDAO.List(ctx, q.New(q.KeyWords{"Status": job.RunningStatus.String()}))
+	if err != nil {
+		return err
+	}
+	for _, workflow := range workflows {
+		if err = w.advance(ctx, workflow); err != nil {
+			log.Errorf("failed to advance the workflow %d: %v", workflow.ID, err)
+		}
+	}
+	return nil
+}
+
+// syncNode syncs the status of the running node with its execution
+func (w *workflowManager) syncNode(ctx context.Context, node *dao.WorkflowNode) {
+	if node.ExecutionID == 0 {
+		if time.Since(node.UpdateTime) > workflowLaunchTimeout {
+			w.updateNodeStatus(ctx, node, job.ErrorStatus.String(), "timeout to launch the execution")
+		}
+		return
+	}
+	execution, err := w.execMgr.Get(ctx, node.ExecutionID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			w.updateNodeStatus(ctx, node, job.ErrorStatus.String(), fmt.Sprintf("the execution %d is deleted", node.ExecutionID))
+			return
+		}
+		log.Errorf("failed to get the execution %d of the workflow node %d: %v", node.ExecutionID, node.ID, err)
+		return
+	}
+	if job.Status(execution.Status).Final() {
+		w.updateNodeStatus(ctx, node, execution.Status, execution.StatusMessage)
+	}
+}
+
+// advance launches or skips the pending nodes whose upstream are done and finishes the workflow
+// when all nodes are done
+func (w *workflowManager) advance(ctx context.Context, workflow *dao.Workflow) error {
+	nodes, err := w.wfDAO.ListNodes(ctx, q.New(q.KeyWords{"WorkflowID": workflow.ID}))
+	if err != nil {
+		return err
+	}
+	byID := map[int64]*dao.WorkflowNode{}
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+
+	// loop until no node changes, as a failed launch or a skipped node can trigger its downstream at once
+	for changed := true; changed; {
+		changed = false
+		for _, node := range nodes {
+			if node.Status != job.PendingStatus.String() {
+				continue
+			}
+			upstream := byID[node.UpstreamID]
+			// the root node isn't launched in the creation because of failure
+			if upstream == nil {
+				w.launch(ctx, node, nil)
+			} else if !isWorkflowNodeFinal(upstream.Status) {
+				continue
+			} else if isWorkflowTriggered(node.TriggerOn, upstream.Status) {
+				w.launch(ctx, node, upstream)
+			} else {
+				message := fmt.Sprintf("the upstream node %s is %s", upstream.Name, upstream.Status)
+				skipped, err := w.wfDAO.UpdateNodeStatus(ctx, node.ID, WorkflowNodeSkippedStatus, message, job.PendingStatus.String())
+				if err != nil {
+					return err
+				}
+				if skipped {
+					node.Status = WorkflowNodeSkippedStatus
+				}
+			}
+			if node.Status != job.PendingStatus.String() {
+				changed = true
+			}
+		}
+	}
+
+	status, message := job.SuccessStatus.String(), ""
+	for _, node := range nodes {
+		if !isWorkflowNodeFinal(node.Status) {
+			return nil
+		}
+	}
+	for _, node := range nodes {
+		switch node.Status {
+		case job.ErrorStatus.String():
+			if !isWorkflowErrorHandled(node, nodes) {
+				status, message = job.ErrorStatus.String(), fmt.Sprintf("the node %s failed", node.Name)
+			}
+		case job.StoppedStatus.String():
+			if status == job.SuccessStatus.String() {
+				status, message = job.StoppedStatus.String(), fmt.Sprintf("the node %s is stopped", node.Name)
+			}
+		}
+	}
+	_, err = w.wfDAO.UpdateStatus(ctx, workflow.ID, status, message, job.RunningStatus.String())
+	return err
+}
+
+// isWorkflowTriggered returns whether the node is triggered by the final status of its upstream
+func isWorkflowTriggered(triggerOn, upstreamStatus string) bool {
+	switch upstreamStatus {
+	case job.SuccessStatus.String():
+		return triggerOn == WorkflowTriggerOnSuccess || triggerOn == WorkflowTriggerOnAny
+	case job.ErrorStatus.String():
+		return triggerOn == WorkflowTriggerOnError || triggerOn == WorkflowTriggerOnAny
+	default:
+		// the downstream of the stopped and skipped nodes are skipped
+		return false
+	}
+}
+
+// isWorkflowErrorHandled returns whether the failure of the node is handled by a downstream node triggered on error
+func isWorkflowErrorHandled(node *dao.WorkflowNode, nodes []*dao.WorkflowNode) bool {
+	for _, n := range nodes {
+		if n.UpstreamID == node.ID && n.Status != WorkflowNodeSkippedStatus &&
+			(n.TriggerOn == WorkflowTriggerOnError || n.TriggerOn == WorkflowTriggerOnAny) {
+			return true
+		}
+	}
+	return false
+}
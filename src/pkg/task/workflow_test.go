@@ -0,0 +1,271 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+)
+
+const (
+	workflowTestVendor       = "WORKFLOW_TEST"
+	workflowTestFailedVendor = "WORKFLOW_TEST_FAILED"
+)
+
+func init() {
+	_ = RegisterWorkflowLauncher(workflowTestVendor, func(_ context.Context, params map[string]any) (int64, error) {
+		id, _ := Int64FromAny(params["execution_id"])
+		return id, nil
+	})
+	_ = RegisterWorkflowLauncher(workflowTestFailedVendor, func(_ context.Context, _ map[string]any) (int64, error) {
+		return 0, errors.New("failed to launch")
+	})
+}
+
+type workflowManagerTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	wfMgr   *workflowManager
+	wfDAO   *mockWorkflowDAO
+	execMgr *mockExecutionManager
+}
+
+func (w *workflowManagerTestSuite) SetupTest() {
+	w.ctx = orm.NewContext(nil, &ormtesting.FakeOrmer{})
+	w.wfDAO = &mockWorkflowDAO{}
+	w.execMgr = &mockExecutionManager{}
+	w.wfMgr = &workflowManager{
+		wfDAO:   w.wfDAO,
+		execMgr: w.execMgr,
+	}
+}
+
+func (w *workflowManagerTestSuite) TestValidate() {
+	cases := []*Workflow{
+		// no name
+		{Nodes: []*WorkflowNode{{Name: "a", VendorType: workflowTestVendor}}},
+		// no nodes
+		{Name: "wf"},
+		// duplicate nodes
+		{Name: "wf", Nodes: []*WorkflowNode{{Name: "a", VendorType: workflowTestVendor}, {Name: "a", VendorType: workflowTestVendor}}},
+		// unknown vendor type
+		{Name: "wf", Nodes: []*WorkflowNode{{Name: "a", VendorType: "unknown"}}},
+		// invalid trigger
+		{Name: "wf", Nodes: []*WorkflowNode{{Name: "a", VendorType: workflowTestVendor, TriggerOn: "Stopped"}}},
+		// unknown upstream
+		{Name: "wf", Nodes: []*WorkflowNode{{Name: "a", VendorType: workflowTestVendor, Upstream: "b"}}},
+		// cycle
+		{Name: "wf", Nodes: []*WorkflowNode{
+			{Name: "root", VendorType: workflowTestVendor},
+			{Name: "a", VendorType: workflowTestVendor, Upstream: "b"},
+			{Name: "b", VendorType: workflowTestVendor, Upstream: "a"},
+		}},
+		// non-root node adopts execution
+		{Name: "wf", Nodes: []*WorkflowNode{
+			{Name: "a", VendorType: workflowTestVendor},
+			{Name: "b", VendorType: workflowTestVendor, Upstream: "a", ExecutionID: 1},
+		}},
+	}
+	for _, c := range cases {
+		_, err := w.wfMgr.validate(w.ctx, c)
+		w.Error(err)
+		w.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	// sorted by the upstream and the default trigger is set
+	nodes, err := w.wfMgr.validate(w.ctx, &Workflow{Name: "wf", Nodes: []*WorkflowNode{
+		{Name: "b", VendorType: workflowTestVendor, Upstream: "a", TriggerOn: WorkflowTriggerOnError},
+		{Name: "a", VendorType: workflowTestVendor},
+	}})
+	w.Require().Nil(err)
+	w.Require().Len(nodes, 2)
+	w.Equal("a", nodes[0].Name)
+	w.Equal(WorkflowTriggerOnSuccess, nodes[0].TriggerOn)
+	w.Equal("b", nodes[1].Name)
+	w.Equal(WorkflowTriggerOnError, nodes[1].TriggerOn)
+}
+
+func (w *workflowManagerTestSuite) TestCreate() {
+	w.wfDAO.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	w.wfDAO.On("CreateNode", mock.Anything, mock.MatchedBy(func(n *dao.WorkflowNode) bool {
+		return n.Name == "root" && n.UpstreamID == 0 && n.Status == job.PendingStatus.String()
+	})).Return(int64(10), nil)
+	w.wfDAO.On("CreateNode", mock.Anything, mock.MatchedBy(func(n *dao.WorkflowNode) bool {
+		return n.Name == "child" && n.UpstreamID == 10 && n.Status == job.PendingStatus.String()
+	})).Return(int64(11), nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(10), job.RunningStatus.String(), "", job.PendingStatus.String()).Return(true, nil)
+	w.wfDAO.On("SetNodeExecution", mock.Anything, int64(10), int64(100)).Return(nil)
+	w.wfDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Workflow{ID: 1, Status: job.RunningStatus.String()}, nil)
+
+	id, err := w.wfMgr.Create(w.ctx, &Workflow{
+		Name: "wf",
+		Nodes: []*WorkflowNode{
+			{Name: "child", VendorType: workflowTestVendor, Upstream: "root"},
+			{Name: "root", VendorType: workflowTestVendor, Parameters: map[string]any{"execution_id": 100}},
+		},
+	})
+	w.Require().Nil(err)
+	w.Equal(int64(1), id)
+	w.wfDAO.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestCreateAdoptExecution() {
+	w.execMgr.On("Get", mock.Anything, int64(100)).Return(&Execution{ID: 100, VendorType: workflowTestVendor}, nil)
+	w.wfDAO.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	w.wfDAO.On("CreateNode", mock.Anything, mock.MatchedBy(func(n *dao.WorkflowNode) bool {
+		return n.ExecutionID == 100 && n.Status == job.RunningStatus.String()
+	})).Return(int64(10), nil)
+
+	id, err := w.wfMgr.Create(w.ctx, &Workflow{
+		Name:  "wf",
+		Nodes: []*WorkflowNode{{Name: "root", VendorType: workflowTestVendor, ExecutionID: 100}},
+	})
+	w.Require().Nil(err)
+	w.Equal(int64(1), id)
+	// the adopted execution isn't launched again
+	w.wfDAO.AssertNotCalled(w.T(), "UpdateNodeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	w.wfDAO.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestGet() {
+	w.wfDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Workflow{ID: 1, Name: "wf"}, nil)
+	w.wfDAO.On("ListNodes", mock.Anything, mock.Anything).Return([]*dao.WorkflowNode{
+		{ID: 10, WorkflowID: 1, Name: "root", Parameters: `{"policy_id":1}`},
+		{ID: 11, WorkflowID: 1, Name: "child", UpstreamID: 10},
+	}, nil)
+
+	workflow, err := w.wfMgr.Get(w.ctx, 1)
+	w.Require().Nil(err)
+	w.Equal("wf", workflow.Name)
+	w.Require().Len(workflow.Nodes, 2)
+	w.Equal("", workflow.Nodes[0].Upstream)
+	id, _ := Int64FromAny(workflow.Nodes[0].Parameters["policy_id"])
+	w.Equal(int64(1), id)
+	w.Equal("root", workflow.Nodes[1].Upstream)
+}
+
+func (w *workflowManagerTestSuite) TestStop() {
+	// not running
+	w.wfDAO.On("Get", mock.Anything, int64(2)).Return(&dao.Workflow{ID: 2, Status: job.SuccessStatus.String()}, nil)
+	err := w.wfMgr.Stop(w.ctx, 2)
+	w.True(errors.IsErr(err, errors.PreconditionCode))
+
+	w.wfDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Workflow{ID: 1, Status: job.RunningStatus.String()}, nil)
+	w.wfDAO.On("UpdateStatus", mock.Anything, int64(1), job.StoppedStatus.String(), "", job.RunningStatus.String()).Return(true, nil)
+	w.wfDAO.On("ListNodes", mock.Anything, mock.Anything).Return([]*dao.WorkflowNode{
+		{ID: 10, Status: job.SuccessStatus.String(), ExecutionID: 100},
+		{ID: 11, Status: job.RunningStatus.String(), ExecutionID: 101},
+		{ID: 12, Status: job.PendingStatus.String()},
+	}, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(12), WorkflowNodeSkippedStatus, mock.Anything, job.PendingStatus.String()).Return(true, nil)
+	w.execMgr.On("Stop", mock.Anything, int64(101)).Return(nil)
+
+	err = w.wfMgr.Stop(w.ctx, 1)
+	w.Require().Nil(err)
+	w.wfDAO.AssertExpectations(w.T())
+	w.execMgr.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestDelete() {
+	w.wfDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Workflow{ID: 1, Status: job.RunningStatus.String()}, nil)
+	err := w.wfMgr.Delete(w.ctx, 1)
+	w.True(errors.IsErr(err, errors.PreconditionCode))
+
+	w.wfDAO.On("Get", mock.Anything, int64(2)).Return(&dao.Workflow{ID: 2, Status: job.StoppedStatus.String()}, nil)
+	w.wfDAO.On("Delete", mock.Anything, int64(2)).Return(nil)
+	err = w.wfMgr.Delete(w.ctx, 2)
+	w.Require().Nil(err)
+	w.wfDAO.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestReconcile() {
+	// the running root node whose execution succeeded
+	root := &dao.WorkflowNode{ID: 10, WorkflowID: 1, Name: "root", VendorType: workflowTestVendor,
+		ExecutionID: 100, Status: job.RunningStatus.String()}
+	// triggered on success and launched
+	onSuccess := &dao.WorkflowNode{ID: 11, WorkflowID: 1, UpstreamID: 10, Name: "on-success", VendorType: workflowTestVendor,
+		TriggerOn: WorkflowTriggerOnSuccess, Parameters: `{"execution_id":101}`, Status: job.PendingStatus.String()}
+	// triggered on error and skipped
+	onError := &dao.WorkflowNode{ID: 12, WorkflowID: 1, UpstreamID: 10, Name: "on-error", VendorType: workflowTestVendor,
+		TriggerOn: WorkflowTriggerOnError, Status: job.PendingStatus.String()}
+	// the downstream of the skipped node is skipped as well
+	afterError := &dao.WorkflowNode{ID: 13, WorkflowID: 1, UpstreamID: 12, Name: "after-error", VendorType: workflowTestVendor,
+		TriggerOn: WorkflowTriggerOnAny, Status: job.PendingStatus.String()}
+
+	w.wfDAO.On("ListNodes", mock.Anything, mock.Anything).Return([]*dao.WorkflowNode{root}, nil).Once()
+	w.execMgr.On("Get", mock.Anything, int64(100)).Return(&Execution{ID: 100, Status: job.SuccessStatus.String(),
+		ExtraAttrs: map[string]any{"k": "v"}}, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(10), job.SuccessStatus.String(), "", job.RunningStatus.String()).Return(true, nil)
+	w.wfDAO.On("List", mock.Anything, mock.Anything).Return([]*dao.Workflow{{ID: 1, Status: job.RunningStatus.String()}}, nil)
+	w.wfDAO.On("ListNodes", mock.Anything, mock.Anything).Return([]*dao.WorkflowNode{root, onSuccess, onError, afterError}, nil).Once()
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(11), job.RunningStatus.String(), "", job.PendingStatus.String()).Return(true, nil)
+	w.wfDAO.On("SetNodeExecution", mock.Anything, int64(11), int64(101)).Return(nil)
+	w.wfDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Workflow{ID: 1, Status: job.RunningStatus.String()}, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(12), WorkflowNodeSkippedStatus, mock.Anything, job.PendingStatus.String()).Return(true, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(13), WorkflowNodeSkippedStatus, mock.Anything, job.PendingStatus.String()).Return(true, nil)
+
+	err := w.wfMgr.Reconcile(w.ctx)
+	w.Require().Nil(err)
+	w.Equal(job.SuccessStatus.String(), root.Status)
+	w.Equal(job.RunningStatus.String(), onSuccess.Status)
+	w.Equal(int64(101), onSuccess.ExecutionID)
+	w.Equal(WorkflowNodeSkippedStatus, onError.Status)
+	w.Equal(WorkflowNodeSkippedStatus, afterError.Status)
+	// the workflow isn't finished as the node is running
+	w.wfDAO.AssertNotCalled(w.T(), "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	w.wfDAO.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestAdvance() {
+	// the failure is handled by the node triggered on error
+	nodes := []*dao.WorkflowNode{
+		{ID: 10, WorkflowID: 1, Name: "root", Status: job.ErrorStatus.String()},
+		{ID: 11, WorkflowID: 1, UpstreamID: 10, Name: "on-error", VendorType: workflowTestFailedVendor,
+			TriggerOn: WorkflowTriggerOnError, Status: job.PendingStatus.String()},
+	}
+	w.wfDAO.On("ListNodes", mock.Anything, mock.Anything).Return(nodes, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(11), job.RunningStatus.String(), "", job.PendingStatus.String()).Return(true, nil)
+	w.wfDAO.On("UpdateNodeStatus", mock.Anything, int64(11), job.ErrorStatus.String(), "failed to launch", job.RunningStatus.String()).Return(true, nil)
+	// the failure of the node triggered on error isn't handled
+	w.wfDAO.On("UpdateStatus", mock.Anything, int64(1), job.ErrorStatus.String(), "the node on-error failed", job.RunningStatus.String()).Return(true, nil)
+
+	err := w.wfMgr.advance(w.ctx, &dao.Workflow{ID: 1, Status: job.RunningStatus.String()})
+	w.Require().Nil(err)
+	w.wfDAO.AssertExpectations(w.T())
+}
+
+func (w *workflowManagerTestSuite) TestIsWorkflowTriggered() {
+	w.True(isWorkflowTriggered(WorkflowTriggerOnSuccess, job.SuccessStatus.String()))
+	w.False(isWorkflowTriggered(WorkflowTriggerOnSuccess, job.ErrorStatus.String()))
+	w.True(isWorkflowTriggered(WorkflowTriggerOnError, job.ErrorStatus.String()))
+	w.False(isWorkflowTriggered(WorkflowTriggerOnError, job.SuccessStatus.String()))
+	w.True(isWorkflowTriggered(WorkflowTriggerOnAny, job.SuccessStatus.String()))
+	w.True(isWorkflowTriggered(WorkflowTriggerOnAny, job.ErrorStatus.String()))
+	w.False(isWorkflowTriggered(WorkflowTriggerOnAny, job.StoppedStatus.String()))
+	w.False(isWorkflowTriggered(WorkflowTriggerOnAny, WorkflowNodeSkippedStatus))
+}
+
+func TestWorkflowManager(t *testing.T) {
+	suite.Run(t, &workflowManagerTestSuite{})
+}
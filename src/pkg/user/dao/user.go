@@ -37,6 +37,7 @@ type User struct {
 	Realname        string         `orm:"column(realname)" json:"realname"`
 	Comment         string         `orm:"column(comment)" json:"comment"`
 	Deleted         bool           `orm:"column(deleted)" json:"deleted"`
+	Disabled        bool           `orm:"column(disabled)" json:"disabled"`
 	SysAdminFlag    bool           `orm:"column(sysadmin_flag)" json:"sysadmin_flag"`
 	ResetUUID       string         `orm:"column(reset_uuid)" json:"reset_uuid"`
 	Salt            string         `orm:"column(salt)" filter:"false" json:"-"`
@@ -64,6 +65,7 @@ func toDBUser(u *commonmodels.User) *User {
 	user.Realname = u.Realname
 	user.Comment = u.Comment
 	user.Deleted = u.Deleted
+	user.Disabled = u.Disabled
 	user.SysAdminFlag = u.SysAdminFlag
 	user.ResetUUID = u.ResetUUID
 	user.Salt = u.Salt
@@ -84,6 +86,7 @@ func toCommonUser(u *User) *commonmodels.User {
 	user.Realname = u.Realname
 	user.Comment = u.Comment
 	user.Deleted = u.Deleted
+	user.Disabled = u.Disabled
 	user.SysAdminFlag = u.SysAdminFlag
 	user.ResetUUID = u.ResetUUID
 	user.Salt = u.Salt
@@ -52,6 +52,8 @@ type Manager interface {
 	DeleteGDPR(ctx context.Context, id int) error
 	// SetSysAdminFlag sets the system admin flag of the user in local DB
 	SetSysAdminFlag(ctx context.Context, id int, admin bool) error
+	// SetDisabled sets the disabled flag of the user in local DB, the disabled user can't login
+	SetDisabled(ctx context.Context, id int, disabled bool) error
 	// UpdateProfile updates the user's profile
 	UpdateProfile(ctx context.Context, user *commonmodels.User, col ...string) error
 	// UpdatePassword updates user's password
@@ -85,6 +87,7 @@ func (m *manager) Onboard(ctx context.Context, user *commonmodels.User) error {
 		user.SysAdminFlag = u.SysAdminFlag
 		user.Realname = u.Realname
 		user.UserID = u.UserID
+		user.Disabled = u.Disabled
 		return nil
 	} else if !errors.IsNotFoundErr(err) {
 		return err
@@ -167,6 +170,14 @@ func (m *manager) SetSysAdminFlag(ctx context.Context, id int, admin bool) error
 	return m.dao.Update(ctx, u, "sysadmin_flag")
 }
 
+func (m *manager) SetDisabled(ctx context.Context, id int, disabled bool) error {
+	u := &commonmodels.User{
+		UserID:   id,
+		Disabled: disabled,
+	}
+	return m.dao.Update(ctx, u, "disabled")
+}
+
 func (m *manager) Create(ctx context.Context, user *commonmodels.User) (int, error) {
 	injectPasswd(user, user.Password)
 	// replace comma in username with underscore to avoid #19356
@@ -49,6 +49,16 @@ func (m *mgrTestSuite) TestSetAdminFlag() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *mgrTestSuite) TestSetDisabled() {
+	m.dao.On("Update", mock.Anything, testifymock.MatchedBy(
+		func(u *models.User) bool {
+			return u.UserID == 9 && u.Disabled
+		}), "disabled").Return(nil)
+	err := m.mgr.SetDisabled(context.Background(), 9, true)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
 func (m *mgrTestSuite) TestUserDeleteGDPR() {
 	existingUser := &models.User{
 		UserID:   123,
@@ -51,6 +51,14 @@ type DAO interface {
 	ReadOrCreate(ctx context.Context, g *model.UserGroup, keyAttribute string, combinedKeyAttributes ...string) (bool, int64, error)
 	// Search search user groups by names with fuzzy search
 	SearchByName(ctx context.Context, name string, limitSize int) ([]*model.UserGroup, error)
+	// AddMember adds the user into the user group, the membership is maintained in Harbor DB, e.g. provisioned via SCIM
+	AddMember(ctx context.Context, groupID, userID int) error
+	// DeleteMember removes the user from the user group
+	DeleteMember(ctx context.Context, groupID, userID int) error
+	// ListMemberIDs lists the IDs of the users in the user group
+	ListMemberIDs(ctx context.Context, groupID int) ([]int, error)
+	// ListGroupIDsByMember lists the IDs of the user groups which the user is member of
+	ListGroupIDsByMember(ctx context.Context, userID int) ([]int, error)
 }
 
 type dao struct {
@@ -180,3 +188,49 @@ func (d *dao) SearchByName(ctx context.Context, name string, limitSize int) ([]*
 	}
 	return usergroups, nil
 }
+
+func (d *dao) AddMember(ctx context.Context, groupID, userID int) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := "insert into user_group_member (group_id, user_id) values (?, ?) on conflict do nothing"
+	_, err = o.Raw(sql, groupID, userID).Exec()
+	return err
+}
+
+func (d *dao) DeleteMember(ctx context.Context, groupID, userID int) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := "delete from user_group_member where group_id = ? and user_id = ?"
+	_, err = o.Raw(sql, groupID, userID).Exec()
+	return err
+}
+
+func (d *dao) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	sql := "select user_id from user_group_member where group_id = ? order by user_id"
+	if _, err = o.Raw(sql, groupID).QueryRows(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (d *dao) ListGroupIDsByMember(ctx context.Context, userID int) ([]int, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	sql := "select group_id from user_group_member where user_id = ? order by group_id"
+	if _, err = o.Raw(sql, userID).QueryRows(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
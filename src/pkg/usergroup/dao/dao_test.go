@@ -30,7 +30,7 @@ type DaoTestSuite struct {
 
 func (s *DaoTestSuite) SetupSuite() {
 	s.Suite.SetupSuite()
-	s.Suite.ClearTables = []string{"user_group"}
+	s.Suite.ClearTables = []string{"user_group_member", "user_group"}
 	s.dao = New()
 }
 
@@ -96,6 +96,30 @@ func (s *DaoTestSuite) TestSearchByName() {
 	s.Equal("group", results2[0].GroupName)
 }
 
+func (s *DaoTestSuite) TestMember() {
+	ctx := s.Context()
+	id, err := s.dao.Add(ctx, model.UserGroup{GroupName: "scim_dev", GroupType: 3})
+	s.Require().Nil(err)
+
+	// the admin user whose ID is 1 always exists
+	s.Nil(s.dao.AddMember(ctx, id, 1))
+	// adding the member again doesn't return error
+	s.Nil(s.dao.AddMember(ctx, id, 1))
+
+	userIDs, err := s.dao.ListMemberIDs(ctx, id)
+	s.Nil(err)
+	s.Equal([]int{1}, userIDs)
+
+	groupIDs, err := s.dao.ListGroupIDsByMember(ctx, 1)
+	s.Nil(err)
+	s.Equal([]int{id}, groupIDs)
+
+	s.Nil(s.dao.DeleteMember(ctx, id, 1))
+	userIDs, err = s.dao.ListMemberIDs(ctx, id)
+	s.Nil(err)
+	s.Len(userIDs, 0)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &DaoTestSuite{})
 }
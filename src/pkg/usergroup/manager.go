@@ -53,6 +53,15 @@ type Manager interface {
 	Onboard(ctx context.Context, g *model.UserGroup) error
 	// SearchByName user groups by names with fuzzy search
 	SearchByName(ctx context.Context, name string, limitSize int) ([]*model.UserGroup, error)
+	// AddMember adds the user into the user group
+	AddMember(ctx context.Context, groupID, userID int) error
+	// DeleteMember removes the user from the user group
+	DeleteMember(ctx context.Context, groupID, userID int) error
+	// ListMemberIDs lists the IDs of the users in the user group
+	ListMemberIDs(ctx context.Context, groupID int) ([]int, error)
+	// ListGroupIDsByMember lists the IDs of the user groups which the user is member of,
+	// only the membership maintained in Harbor DB is included, the groups resolved at login aren't
+	ListGroupIDsByMember(ctx context.Context, userID int) ([]int, error)
 }
 
 type manager struct {
@@ -165,3 +174,19 @@ func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 func (m *manager) SearchByName(ctx context.Context, name string, limitSize int) ([]*model.UserGroup, error) {
 	return m.dao.SearchByName(ctx, name, limitSize)
 }
+
+func (m *manager) AddMember(ctx context.Context, groupID, userID int) error {
+	return m.dao.AddMember(ctx, groupID, userID)
+}
+
+func (m *manager) DeleteMember(ctx context.Context, groupID, userID int) error {
+	return m.dao.DeleteMember(ctx, groupID, userID)
+}
+
+func (m *manager) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	return m.dao.ListMemberIDs(ctx, groupID)
+}
+
+func (m *manager) ListGroupIDsByMember(ctx context.Context, userID int) ([]int, error) {
+	return m.dao.ListGroupIDsByMember(ctx, userID)
+}
@@ -19,11 +19,13 @@ import (
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/repository"
 )
 
 var (
-	artifactController = artifact.Ctl
-	blobController     = blob.Ctl
-	projectController  = project.Ctl
-	quotaController    = quota.Ctl
+	artifactController   = artifact.Ctl
+	blobController       = blob.Ctl
+	projectController    = project.Ctl
+	quotaController      = quota.Ctl
+	repositoryController = repository.Ctl
 )
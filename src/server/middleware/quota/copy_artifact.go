@@ -29,7 +29,9 @@
 package quota
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 	"strconv"
 	"strings"
@@ -43,6 +45,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
+	"github.com/goharbor/harbor/src/server/middleware/util"
 )
 
 // CopyArtifactMiddleware middleware to request count and storage resources for copy artifact API
@@ -132,7 +135,29 @@ func copyArtifactResources(r *http.Request, _, referenceID string) (types.Resour
 		}
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	// the repository name in the path is URL encoded twice, e.g. amd64%252Fphoton
+	dstRepositoryName := repositoryName
+	for range 2 {
+		if name, err := url.PathUnescape(dstRepositoryName); err == nil {
+			dstRepositoryName = name
+		}
+	}
+	dstRepositoryName = fmt.Sprintf("%s/%s", util.ParseProjectName(r), dstRepositoryName)
+
+	resources, err := artifactResources(r, dstRepositoryName, artifactDigests)
+	if err != nil {
+		logger.Errorf("get the artifact resources for the repository %s failed, error: %v", dstRepositoryName, err)
+		return nil, err
+	}
+
+	if resources == nil {
+		resources = types.ResourceList{}
+	} else {
+		resources[types.ResourceArtifactSize] = art.Size
+	}
+	resources[types.ResourceStorage] = size
+
+	return resources, nil
 }
 
 func copyArtifactResourcesEvent(level int) func(*http.Request, string, string, string) event.Metadata {
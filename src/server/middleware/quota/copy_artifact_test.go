@@ -39,7 +39,6 @@ import (
 	"github.com/goharbor/harbor/src/pkg/notification"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/quota"
-	"github.com/goharbor/harbor/src/pkg/quota/types"
 	"github.com/goharbor/harbor/src/testing/mock"
 )
 
@@ -103,10 +102,7 @@ func (suite *CopyArtifactMiddlewareTestSuite) TestResourcesWarning() {
 	})
 
 	{
-		q := &quota.Quota{}
-		q.SetHard(types.ResourceList{types.ResourceStorage: 100})
-		q.SetUsed(types.ResourceList{types.ResourceStorage: 50})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(q, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0?from=library/photon:2.0.1", nil)
 		eveCtx := notification.NewEventCtx()
@@ -119,10 +115,8 @@ func (suite *CopyArtifactMiddlewareTestSuite) TestResourcesWarning() {
 	}
 
 	{
-		q := &quota.Quota{}
-		q.SetHard(types.ResourceList{types.ResourceStorage: 100})
-		q.SetUsed(types.ResourceList{types.ResourceStorage: 85})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(q, nil).Once()
+		notices := []*quota.Notice{{Kind: quota.NoticeWarning, Message: "quota usage reach 85%"}}
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(notices, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0?from=library/photon:2.0.1", nil)
 		eveCtx := notification.NewEventCtx()
@@ -22,7 +22,6 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/pkg/blob"
-	"github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	"github.com/goharbor/harbor/src/testing/mock"
 )
@@ -62,7 +61,7 @@ func (suite *PostInitiateBlobUploadMiddlewareTestSuite) TestMiddleware() {
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, url, nil)
 		rr := httptest.NewRecorder()
@@ -81,7 +80,7 @@ func (suite *PostInitiateBlobUploadMiddlewareTestSuite) TestMiddleware() {
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, url, nil)
 		rr := httptest.NewRecorder()
@@ -97,7 +97,7 @@ func (suite *PutBlobUploadMiddlewareTestSuite) TestBlobNotExist() {
 		f := args.Get(4).(func() error)
 		f()
 	})
-	mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+	mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 	req := suite.makeRequest(100)
 	rr := httptest.NewRecorder()
@@ -22,10 +22,11 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
+	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
 
-// PutManifestMiddleware middleware to request count and storage resources for the project
+// PutManifestMiddleware middleware to request count, size and storage resources for the project
 func PutManifestMiddleware() func(http.Handler) http.Handler {
 	return RequestMiddleware(RequestConfig{
 		ReferenceObject:   projectReferenceObject,
@@ -50,17 +51,17 @@ func putManifestResources(r *http.Request, _, referenceID string) (types.Resourc
 		return nil, errors.Wrap(err, "unmarshal manifest failed").WithCode(errors.MANIFESTINVALID)
 	}
 
-	exist, err := blobController.Exist(r.Context(), descriptor.Digest.String(), blob.IsAssociatedWithProject(projectID))
+	resources, err := artifactResources(r, distribution.ParseName(r.URL.Path), []string{descriptor.Digest.String()})
 	if err != nil {
-		logger.Errorf("check manifest %s is associated with project failed, error: %v", descriptor.Digest.String(), err)
 		return nil, err
 	}
 
-	if exist {
+	if len(resources) == 0 {
+		// the artifact exists in the repository, e.g. tag the artifact
 		return nil, nil
 	}
 
-	size := descriptor.Size
+	artifactSize := descriptor.Size
 
 	var blobs []*models.Blob
 	for _, reference := range manifest.References() {
@@ -69,8 +70,26 @@ func putManifestResources(r *http.Request, _, referenceID string) (types.Resourc
 			Size:        reference.Size,
 			ContentType: reference.MediaType,
 		})
+
+		if !blobs[len(blobs)-1].IsForeignLayer() {
+			artifactSize += reference.Size
+		}
 	}
 
+	resources[types.ResourceArtifactSize] = artifactSize
+
+	exist, err := blobController.Exist(r.Context(), descriptor.Digest.String(), blob.IsAssociatedWithProject(projectID))
+	if err != nil {
+		logger.Errorf("check manifest %s is associated with project failed, error: %v", descriptor.Digest.String(), err)
+		return nil, err
+	}
+
+	if exist {
+		return resources, nil
+	}
+
+	size := descriptor.Size
+
 	missing, err := blobController.FindMissingAssociationsForProject(r.Context(), projectID, blobs)
 	if err != nil {
 		return nil, err
@@ -82,5 +101,7 @@ func putManifestResources(r *http.Request, _, referenceID string) (types.Resourc
 		}
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	resources[types.ResourceStorage] = size
+
+	return resources, nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
 	"github.com/goharbor/harbor/src/pkg/distribution"
@@ -32,6 +33,7 @@ import (
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
+	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
 	"github.com/goharbor/harbor/src/testing/mock"
 	distributiontesting "github.com/goharbor/harbor/src/testing/pkg/distribution"
 )
@@ -59,6 +61,8 @@ func (suite *PutManifestMiddlewareTestSuite) SetupTest() {
 	unmarshalManifest = func(r *http.Request) (distribution.Manifest, distribution.Descriptor, error) {
 		return suite.manifest, distribution.Descriptor{Digest: "digest", Size: 100}, nil
 	}
+
+	mock.OnAnything(suite.repositoryController, "GetByName").Return(&repomodel.RepoRecord{RepositoryID: 1}, nil)
 }
 
 func (suite *PutManifestMiddlewareTestSuite) TearDownTest() {
@@ -75,7 +79,33 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 	})
 
 	{
+		// artifact exists in the repository
+		mock.OnAnything(suite.artifactController, "GetByReference").Return(&artifact.Artifact{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.quotaController.AssertNotCalled(suite.T(), "Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(nil, errors.NotFoundError(nil))
+
+	{
+		// manifest associated with project, request the count and size resources only
 		mock.OnAnything(suite.blobController, "Exist").Return(true, nil).Once()
+		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+			resources := args.Get(3).(types.ResourceList)
+			suite.Equal(types.ResourceList{
+				types.ResourceArtifactCount: 1,
+				types.ResourceArtifactSize:  100 + 10 + 20,
+			}, resources)
+
+			f := args.Get(4).(func() error)
+			f()
+		})
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		rr := httptest.NewRecorder()
@@ -90,13 +120,14 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 3)
+			suite.Equal(resources[types.ResourceArtifactCount], int64(1))
 			suite.Equal(resources[types.ResourceStorage], int64(100))
 
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		rr := httptest.NewRecorder()
@@ -116,13 +147,14 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 3)
+			suite.Equal(resources[types.ResourceArtifactCount], int64(1))
 			suite.Equal(resources[types.ResourceStorage], int64(100+10))
 
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		rr := httptest.NewRecorder()
@@ -142,13 +174,14 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 3)
+			suite.Equal(resources[types.ResourceArtifactCount], int64(1))
 			suite.Equal(resources[types.ResourceStorage], int64(100+20))
 
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		rr := httptest.NewRecorder()
@@ -168,13 +201,14 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 3)
+			suite.Equal(resources[types.ResourceArtifactCount], int64(1))
 			suite.Equal(resources[types.ResourceStorage], int64(100))
 
 			f := args.Get(4).(func() error)
 			f()
 		})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		rr := httptest.NewRecorder()
@@ -184,8 +218,36 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 	}
 }
 
+func (suite *PutManifestMiddlewareTestSuite) TestNewRepository() {
+	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(nil, errors.NotFoundError(nil))
+	mock.OnAnything(suite.blobController, "Exist").Return(true, nil)
+	suite.repositoryController.ExpectedCalls = nil
+	mock.OnAnything(suite.repositoryController, "GetByName").Return(nil, errors.NotFoundError(nil))
+	mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+		resources := args.Get(3).(types.ResourceList)
+		suite.Equal(int64(1), resources[types.ResourceArtifactCount])
+		suite.Equal(int64(1), resources[types.ResourceRepositoryCount])
+
+		f := args.Get(4).(func() error)
+		f()
+	})
+	mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+	rr := httptest.NewRecorder()
+
+	PutManifestMiddleware()(next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusOK, rr.Code)
+}
+
 func (suite *PutManifestMiddlewareTestSuite) TestResourcesExceeded() {
 	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(nil, errors.NotFoundError(nil))
 	mock.OnAnything(suite.blobController, "Exist").Return(false, nil)
 	mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil)
 	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{}, nil)
@@ -229,6 +291,7 @@ func (suite *PutManifestMiddlewareTestSuite) TestResourcesExceeded() {
 
 func (suite *PutManifestMiddlewareTestSuite) TestResourcesWarning() {
 	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(nil, errors.NotFoundError(nil))
 	mock.OnAnything(suite.blobController, "Exist").Return(false, nil)
 	mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil)
 
@@ -243,10 +306,7 @@ func (suite *PutManifestMiddlewareTestSuite) TestResourcesWarning() {
 	})
 
 	{
-		q := &quota.Quota{}
-		q.SetHard(types.ResourceList{types.ResourceStorage: 100})
-		q.SetUsed(types.ResourceList{types.ResourceStorage: 50})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(q, nil).Once()
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(nil, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		eveCtx := notification.NewEventCtx()
@@ -259,10 +319,8 @@ func (suite *PutManifestMiddlewareTestSuite) TestResourcesWarning() {
 	}
 
 	{
-		q := &quota.Quota{}
-		q.SetHard(types.ResourceList{types.ResourceStorage: 100})
-		q.SetUsed(types.ResourceList{types.ResourceStorage: 85})
-		mock.OnAnything(suite.quotaController, "GetByRef").Return(q, nil).Once()
+		notices := []*quota.Notice{{Kind: quota.NoticeWarning, Message: "quota usage reach 85%"}}
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(notices, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
 		eveCtx := notification.NewEventCtx()
@@ -273,6 +331,23 @@ func (suite *PutManifestMiddlewareTestSuite) TestResourcesWarning() {
 		suite.Equal(http.StatusOK, rr.Code)
 		suite.Equal(1, eveCtx.Events.Len())
 	}
+
+	{
+		notices := []*quota.Notice{
+			{Kind: quota.NoticeWarning, Message: "quota usage reach 95%"},
+			{Kind: quota.NoticeOverage, Message: "quota usage exceeds the hard limits"},
+		}
+		mock.OnAnything(suite.quotaController, "Evaluate").Return(notices, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+		eveCtx := notification.NewEventCtx()
+		req = req.WithContext(notification.NewContext(req.Context(), eveCtx))
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal(2, eveCtx.Events.Len())
+	}
 }
 
 func (suite *PutManifestMiddlewareTestSuite) TestPutInvalid() {
@@ -17,7 +17,6 @@ package quota
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
 	cq "github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/lib"
@@ -44,13 +43,14 @@ type RequestConfig struct {
 	// Resources returns request resources for the reference object
 	Resources func(r *http.Request, reference, referenceID string) (types.ResourceList, error)
 
-	// ResourcesWarningPercent value from 0 to 100
+	// ResourcesWarningPercent value from 0 to 100, it's used when no warning thresholds configured for the reference object
 	ResourcesWarningPercent int
 
-	// ResourcesWarning returns event which will be notified when resources usage exceeded the wanring percent
+	// ResourcesWarning returns event which will be notified once when resources usage crosses a warning threshold
 	ResourcesWarning func(r *http.Request, reference, referenceID string, message string) event.Metadata
 
-	// ResourcesExceeded returns event which will be notified when resources exceeded the limitation
+	// ResourcesExceeded returns event which will be notified when resources exceeded the limitation,
+	// or at most once a day when resources usage keeps exceeding the limitation in the overage allowance
 	ResourcesExceeded func(r *http.Request, reference, referenceID string, message string) event.Metadata
 }
 
@@ -120,41 +120,29 @@ func RequestMiddleware(config RequestConfig, skippers ...middleware.Skipper) fun
 			return nil
 		})
 
-		if err == nil && config.ResourcesWarning != nil {
-			tryWarningNotification := func() {
-				q, err := quotaController.GetByRef(r.Context(), reference, referenceID)
-				if err != nil {
-					logger.Warningf("get quota of %s %s failed, error: %v", reference, referenceID, err)
-					return
-				}
-
-				resources, err := q.GetWarningResources(config.ResourcesWarningPercent)
-				if err != nil {
-					logger.Warningf("get warning resources failed, error: %v", err)
-					return
-				}
+		if err == nil && (config.ResourcesWarning != nil || config.ResourcesExceeded != nil) {
+			notices, err := quotaController.Evaluate(r.Context(), reference, referenceID, config.ResourcesWarningPercent)
+			if err != nil {
+				logger.Warningf("evaluate quota of %s %s failed, error: %v", reference, referenceID, err)
+			}
 
-				if len(resources) == 0 {
-					logger.Debug("not warning resources found")
-					return
+			for _, notice := range notices {
+				var evt event.Metadata
+				switch notice.Kind {
+				case quota.NoticeWarning:
+					if config.ResourcesWarning != nil {
+						evt = config.ResourcesWarning(r, reference, referenceID, notice.Message)
+					}
+				case quota.NoticeOverage:
+					if config.ResourcesExceeded != nil {
+						evt = config.ResourcesExceeded(r, reference, referenceID, notice.Message)
+					}
 				}
 
-				hardLimits, _ := q.GetHard()
-				used, _ := q.GetUsed()
-
-				var parts []string
-				for _, resource := range resources {
-					s := fmt.Sprintf("resource %s used %s of %s",
-						resource, resource.FormatValue(used[resource]), resource.FormatValue(hardLimits[resource]))
-					parts = append(parts, s)
+				if evt != nil {
+					notification.AddEvent(r.Context(), evt, true)
 				}
-
-				message := fmt.Sprintf("quota usage reach %d%%: %s", config.ResourcesWarningPercent, strings.Join(parts, "; "))
-				evt := config.ResourcesWarning(r, reference, referenceID, message)
-				notification.AddEvent(r.Context(), evt, true)
 			}
-
-			tryWarningNotification()
 		}
 
 		if err != nil && err != errNonSuccess {
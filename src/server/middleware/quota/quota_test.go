@@ -26,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/repository"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	pquota "github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
@@ -33,6 +34,7 @@ import (
 	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	"github.com/goharbor/harbor/src/testing/mock"
 )
 
@@ -50,6 +52,9 @@ type RequestMiddlewareTestSuite struct {
 
 	originallQuotaController quota.Controller
 	quotaController          *quotatesting.Controller
+
+	originalRepositoryController repository.Controller
+	repositoryController         *repositorytesting.Controller
 }
 
 func (suite *RequestMiddlewareTestSuite) SetupTest() {
@@ -70,6 +75,10 @@ func (suite *RequestMiddlewareTestSuite) SetupTest() {
 	suite.originallQuotaController = quotaController
 	suite.quotaController = &quotatesting.Controller{}
 	quotaController = suite.quotaController
+
+	suite.originalRepositoryController = repositoryController
+	suite.repositoryController = &repositorytesting.Controller{}
+	repositoryController = suite.repositoryController
 }
 
 func (suite *RequestMiddlewareTestSuite) TearDownTest() {
@@ -77,6 +86,7 @@ func (suite *RequestMiddlewareTestSuite) TearDownTest() {
 	blobController = suite.originalBlobController
 	projectController = suite.originalProjectController
 	quotaController = suite.originallQuotaController
+	repositoryController = suite.originalRepositoryController
 }
 
 func (suite *RequestMiddlewareTestSuite) makeRequestConfig(reference, referenceID string, resources types.ResourceList) RequestConfig {
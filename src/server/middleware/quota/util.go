@@ -25,9 +25,11 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
 	"github.com/goharbor/harbor/src/server/middleware/util"
 )
 
@@ -46,6 +48,38 @@ func projectReferenceObject(r *http.Request) (string, string, error) {
 	return quota.ProjectReference, quota.ReferenceID(project.ProjectID), nil
 }
 
+// artifactResources returns the artifact count and repository count resources for the artifacts which will be created in the repository,
+// it returns nil when all the artifacts exist in the repository
+func artifactResources(r *http.Request, repository string, digests []string) (types.ResourceList, error) {
+	ctx := r.Context()
+
+	var count int64
+	for _, digest := range digests {
+		_, err := artifactController.GetByReference(ctx, repository, digest, nil)
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	resources := types.ResourceList{types.ResourceArtifactCount: count}
+	if _, err := repositoryController.GetByName(ctx, repository); err != nil {
+		if !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		resources[types.ResourceRepositoryCount] = 1
+	}
+
+	return resources, nil
+}
+
 var (
 	unmarshalManifest = func(r *http.Request) (distribution.Manifest, distribution.Descriptor, error) {
 		body, err := lib.ReadRequestBody(r, common.MaxManifestBodySize)
@@ -0,0 +1,46 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/security"
+	accesstokenCtx "github.com/goharbor/harbor/src/common/security/accesstoken"
+	"github.com/goharbor/harbor/src/controller/accesstoken"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+type accessToken struct{}
+
+func (a *accessToken) Generate(req *http.Request) security.Context {
+	log := log.G(req.Context())
+	username, token, ok := req.BasicAuth()
+	if !ok || !strings.HasPrefix(token, accesstoken.TokenPrefix) {
+		return nil
+	}
+	t, user, err := accesstoken.Ctl.Authenticate(req.Context(), username, token)
+	if err != nil {
+		log.Errorf("failed to authenticate the personal access token of user %s: %v", username, err)
+		return nil
+	}
+	if err := accesstoken.Ctl.RecordUsage(req.Context(), t, lib_http.GetClientIP(req)); err != nil {
+		log.Warningf("failed to record the usage of the personal access token %d: %v", t.ID, err)
+	}
+	log.Debugf("an access token security context generated for request %s %s", req.Method, req.URL.Path)
+	return accesstokenCtx.NewSecurityContext(user, t)
+}
@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/accesstoken"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	accesstokentesting "github.com/goharbor/harbor/src/testing/controller/accesstoken"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+func TestAccessToken(t *testing.T) {
+	ctl := &accesstokentesting.Controller{}
+	defer func(c accesstoken.Controller) { accesstoken.Ctl = c }(accesstoken.Ctl)
+	accesstoken.Ctl = ctl
+
+	token := &model.AccessToken{ID: 1, UserID: 1, Name: "ci"}
+	ctl.On("Authenticate", mock.Anything, "admin", "hpat_valid").Return(token, &models.User{UserID: 1, Username: "admin"}, nil)
+	ctl.On("Authenticate", mock.Anything, "admin", "hpat_invalid").Return(nil, nil, errors.UnauthorizedError(nil))
+	ctl.On("RecordUsage", mock.Anything, token, mock.Anything).Return(nil)
+
+	a := &accessToken{}
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/api/v2.0/projects", nil)
+	require.Nil(t, err)
+	// not a personal access token
+	req.SetBasicAuth("admin", "Harbor12345")
+	assert.Nil(t, a.Generate(req))
+
+	req.SetBasicAuth("admin", "hpat_invalid")
+	assert.Nil(t, a.Generate(req))
+
+	req.SetBasicAuth("admin", "hpat_valid")
+	ctx := a.Generate(req)
+	require.NotNil(t, ctx)
+	assert.Equal(t, "admin", ctx.GetUsername())
+	ctl.AssertCalled(t, "RecordUsage", mock.Anything, token, mock.Anything)
+}
@@ -16,39 +16,18 @@ package security
 
 import (
 	"net/http"
-	"os"
 
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/core/auth"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
 )
 
 type basicAuth struct{}
 
-func trueClientIPHeaderName() string {
-	// because the true client IP header varies based on the foreground proxy/lb settings,
-	// make it configurable by env
-	name := os.Getenv("TRUE_CLIENT_IP_HEADER")
-	if len(name) == 0 {
-		name = "x-forwarded-for"
-	}
-	return name
-}
-
-// GetClientIP get client ip from request
-func GetClientIP(r *http.Request) string {
-	if r == nil {
-		return ""
-	}
-	ip := r.Header.Get(trueClientIPHeaderName())
-	if len(ip) > 0 {
-		return ip
-	}
-	return r.RemoteAddr
-}
-
 // GetUserAgent get the user agent of current request
 func GetUserAgent(r *http.Request) string {
 	if r == nil {
@@ -58,24 +37,35 @@ func GetUserAgent(r *http.Request) string {
 }
 
 func (b *basicAuth) Generate(req *http.Request) security.Context {
+	ctx, _ := b.GenerateOrReject(req)
+	return ctx
+}
+
+// GenerateOrReject rejects the request when the login of the user or the client IP is blocked after the failures,
+// so that the clients, e.g. docker CLI calling /v2 with the basic auth, get the back-off response rather than 401
+func (b *basicAuth) GenerateOrReject(req *http.Request) (security.Context, error) {
 	log := log.G(req.Context())
 	username, password, ok := req.BasicAuth()
 	if !ok {
-		return nil
+		return nil, nil
 	}
 	user, err := auth.Login(req.Context(), models.AuthModel{
 		Principal: username,
 		Password:  password,
+		ClientIP:  lib_http.GetClientIP(req),
 	})
 
 	if err != nil {
-		log.WithField("client IP", GetClientIP(req)).WithField("user agent", GetUserAgent(req)).Errorf("failed to authenticate user:%s, error:%v", username, err)
-		return nil
+		log.WithField("client IP", lib_http.GetClientIP(req)).WithField("user agent", GetUserAgent(req)).Errorf("failed to authenticate user:%s, error:%v", username, err)
+		if errors.As(err, &auth.ErrLocked{}) {
+			return nil, err
+		}
+		return nil, nil
 	}
 	if user == nil {
 		log.Debug("basic auth user is nil")
-		return nil
+		return nil, nil
 	}
 	log.Debugf("a basic auth security context generated for request %s %s", req.Method, req.URL.Path)
-	return local.NewSecurityContext(user)
+	return local.NewSecurityContext(user), nil
 }
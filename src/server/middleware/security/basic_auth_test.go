@@ -35,30 +35,6 @@ func TestBasicAuth(t *testing.T) {
 	assert.NotNil(t, ctx)
 }
 
-func TestGetClientIP(t *testing.T) {
-	h := http.Header{}
-	h.Set("X-Forwarded-For", "1.1.1.1")
-	type args struct {
-		r *http.Request
-	}
-	tests := []struct {
-		name string
-		args args
-		want string
-	}{
-		{"nil request", args{nil}, ""},
-		{"no header", args{&http.Request{RemoteAddr: "10.10.10.10"}}, "10.10.10.10"},
-		{"set x forworded for", args{&http.Request{Header: h, RemoteAddr: "10.10.10.10"}}, "1.1.1.1"},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := GetClientIP(tt.args.r); got != tt.want {
-				t.Errorf("GetClientIP() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGetUserAgent(t *testing.T) {
 	h := http.Header{}
 	h.Set("user-agent", "docker")
@@ -0,0 +1,61 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/security"
+	federatedCtx "github.com/goharbor/harbor/src/common/security/federated"
+	"github.com/goharbor/harbor/src/controller/federation"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+type federated struct{}
+
+func (f *federated) Generate(req *http.Request) security.Context {
+	log := log.G(req.Context())
+	token := federatedToken(req)
+	if len(token) == 0 {
+		return nil
+	}
+	identity, err := federation.Ctl.Authenticate(req.Context(), token)
+	if err != nil {
+		// the token isn't issued by any trusted issuer, leave it to the other generators
+		if !errors.IsNotFoundErr(err) {
+			log.Warningf("failed to authenticate the federated workload: %v", err)
+		}
+		return nil
+	}
+	log.Debugf("a federated security context generated for %s, request %s %s", identity.Username(), req.Method, req.URL.Path)
+	return federatedCtx.NewSecurityContext(identity)
+}
+
+// federatedToken returns the token presented by the workload, it's the password of the basic auth
+// with the dedicated username or the bearer token of the API and token service requests
+func federatedToken(req *http.Request) string {
+	if name, secret, ok := req.BasicAuth(); ok {
+		if name == federation.LoginUsername {
+			return secret
+		}
+		return ""
+	}
+	if !strings.HasPrefix(req.URL.Path, "/api") && req.URL.Path != "/service/token" {
+		return ""
+	}
+	return bearerToken(req)
+}
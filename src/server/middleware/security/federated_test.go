@@ -0,0 +1,68 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/controller/federation"
+	"github.com/goharbor/harbor/src/lib/errors"
+	federationtesting "github.com/goharbor/harbor/src/testing/controller/federation"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+func TestFederatedToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/service/token", nil)
+	require.Nil(t, err)
+	req.SetBasicAuth("federated", "jwt")
+	assert.Equal(t, "jwt", federatedToken(req))
+
+	req.SetBasicAuth("admin", "Harbor12345")
+	assert.Equal(t, "", federatedToken(req))
+
+	req, err = http.NewRequest(http.MethodGet, "http://127.0.0.1/api/v2.0/projects", nil)
+	require.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer jwt")
+	assert.Equal(t, "jwt", federatedToken(req))
+
+	req, err = http.NewRequest(http.MethodGet, "http://127.0.0.1/v2/library/app/manifests/latest", nil)
+	require.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer jwt")
+	assert.Equal(t, "", federatedToken(req))
+}
+
+func TestFederated(t *testing.T) {
+	ctl := &federationtesting.Controller{}
+	defer func(c federation.Controller) { federation.Ctl = c }(federation.Ctl)
+	federation.Ctl = ctl
+
+	ctl.On("Authenticate", mock.Anything, "trusted").Return(&federation.Identity{Subject: "repo:org/app"}, nil)
+	ctl.On("Authenticate", mock.Anything, "untrusted").Return(nil, errors.NotFoundError(nil))
+
+	f := &federated{}
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/service/token", nil)
+	require.Nil(t, err)
+	req.SetBasicAuth("federated", "trusted")
+	ctx := f.Generate(req)
+	require.NotNil(t, ctx)
+	assert.Equal(t, "federated+repo:org/app", ctx.GetUsername())
+
+	req.SetBasicAuth("federated", "untrusted")
+	assert.Nil(t, f.Generate(req))
+}
@@ -0,0 +1,69 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/scim"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+var (
+	scimCtl = scim.Ctl
+)
+
+// userContext is implemented by the security contexts of the Harbor users, e.g. the local and access token contexts
+type userContext interface {
+	User() *models.User
+}
+
+// applyProvisioning applies the state of the user pushed by the identity provider via SCIM to the security context when
+// SCIM is enabled: false is returned if the user is deactivated or the state can't be determined, and the groups of the
+// user are replaced with the provisioned membership. It takes effect on every request as the user in the session or the
+// token may be out of date, the state is cached and invalidated by the SCIM changes. The requests are let through untouched
+// when SCIM isn't enabled, so no lookup is added to the deployments not using it
+func applyProvisioning(ctx context.Context, sc security.Context) bool {
+	if len(config.SCIMToken(ctx)) == 0 {
+		return true
+	}
+	uc, ok := sc.(userContext)
+	if !ok || uc.User() == nil || uc.User().UserID == 0 {
+		return true
+	}
+	user := uc.User()
+	state, err := scimCtl.GetProvisionState(ctx, user.UserID)
+	if err != nil {
+		// fail closed, the deactivated user mustn't be let through when the state is unknown
+		log.G(ctx).Errorf("failed to get the provision state of the user %s: %v", user.Username, err)
+		return false
+	}
+	if !state.Exist {
+		log.G(ctx).Errorf("the user %s doesn't exist anymore", user.Username)
+		return false
+	}
+	if state.Disabled {
+		log.G(ctx).Errorf("the user %s is deactivated", user.Username)
+		return false
+	}
+
+	// the membership pushed by the identity provider is authoritative, so the removal of the user
+	// from a group takes effect on the existing sessions and tokens as well
+	user.GroupIDs = state.GroupIDs
+	return true
+}
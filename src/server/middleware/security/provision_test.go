@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security/local"
+	securitysecret "github.com/goharbor/harbor/src/common/security/secret"
+	ctlscim "github.com/goharbor/harbor/src/controller/scim"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/testing/controller/scim"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+func TestApplyProvisioning(t *testing.T) {
+	ctl := &scim.Controller{}
+	defer func(c ctlscim.Controller) {
+		scimCtl = c
+	}(scimCtl)
+	scimCtl = ctl
+
+	ctl.On("GetProvisionState", mock.Anything, 1).Return(&ctlscim.ProvisionState{Exist: true, GroupIDs: []int{2, 3}}, nil)
+	ctl.On("GetProvisionState", mock.Anything, 2).Return(&ctlscim.ProvisionState{Exist: true, Disabled: true}, nil)
+	ctl.On("GetProvisionState", mock.Anything, 3).Return(&ctlscim.ProvisionState{}, nil)
+	ctl.On("GetProvisionState", mock.Anything, 4).Return(nil, errors.New("failed to connect to the database"))
+
+	ctx := context.TODO()
+	config.InitWithSettings(map[string]any{common.SCIMToken: "scim-token"})
+	// not the context of a user
+	assert.True(t, applyProvisioning(ctx, securitysecret.NewSecurityContext("secret", nil)))
+	assert.True(t, applyProvisioning(ctx, local.NewSecurityContext(nil)))
+
+	// the groups are replaced with the provisioned ones, the group 1 removed via SCIM isn't kept
+	user := &models.User{UserID: 1, Username: "active", GroupIDs: []int{1, 2}}
+	assert.True(t, applyProvisioning(ctx, local.NewSecurityContext(user)))
+	assert.Equal(t, []int{2, 3}, user.GroupIDs)
+
+	assert.False(t, applyProvisioning(ctx, local.NewSecurityContext(&models.User{UserID: 2, Username: "deactivated"})))
+	assert.False(t, applyProvisioning(ctx, local.NewSecurityContext(&models.User{UserID: 3, Username: "deleted"})))
+	// fail closed
+	assert.False(t, applyProvisioning(ctx, local.NewSecurityContext(&models.User{UserID: 4, Username: "unknown"})))
+
+	// the requests pass untouched without looking up the state if SCIM isn't enabled
+	ctl.AssertNumberOfCalls(t, "GetProvisionState", 4)
+	config.InitWithSettings(map[string]any{common.SCIMToken: ""})
+	user = &models.User{UserID: 1, Username: "active", GroupIDs: []int{1}}
+	assert.True(t, applyProvisioning(ctx, local.NewSecurityContext(user)))
+	assert.Equal(t, []int{1}, user.GroupIDs)
+	assert.True(t, applyProvisioning(ctx, local.NewSecurityContext(&models.User{UserID: 2, Username: "deactivated"})))
+	assert.True(t, applyProvisioning(ctx, local.NewSecurityContext(&models.User{UserID: 4, Username: "unknown"})))
+	ctl.AssertNumberOfCalls(t, "GetProvisionState", 4)
+}
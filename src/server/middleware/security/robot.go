@@ -21,9 +21,9 @@ import (
 
 	"github.com/goharbor/harbor/src/common/security"
 	robotCtx "github.com/goharbor/harbor/src/common/security/robot"
-	"github.com/goharbor/harbor/src/common/utils"
 	robot_ctl "github.com/goharbor/harbor/src/controller/robot"
 	"github.com/goharbor/harbor/src/lib/config"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 )
@@ -54,7 +54,7 @@ func (r *robot) Generate(req *http.Request) security.Context {
 	}
 
 	robot := robots[0]
-	if utils.Encrypt(secret, robot.Salt, utils.SHA256) != robot.Secret {
+	if !robot.MatchSecret(secret) {
 		log.Errorf("failed to authenticate robot account: %s", name)
 		return nil
 	}
@@ -68,6 +68,10 @@ func (r *robot) Generate(req *http.Request) security.Context {
 		return nil
 	}
 
+	if err := robot_ctl.Ctl.RecordUsage(req.Context(), robot, lib_http.GetClientIP(req)); err != nil {
+		log.Warningf("failed to record the usage of robot account %s: %v", name, err)
+	}
+
 	log.Debugf("a robot security context generated for request %s %s", req.Method, req.URL.Path)
 	return robotCtx.NewSecurityContext(robot)
 }
@@ -15,11 +15,16 @@
 package security
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/server/middleware"
 )
@@ -27,9 +32,11 @@ import (
 var (
 	generators = []generator{
 		&secret{},
+		&accessToken{},
 		&oidcCli{},
 		&v2Token{},
 		&idToken{},
+		&federated{},
 		&authProxy{},
 		&robot{},
 		&basicAuth{},
@@ -43,6 +50,30 @@ type generator interface {
 	Generate(req *http.Request) security.Context
 }
 
+// rejector is implemented by the generators which reject the request rather than falling through to
+// the next generator, e.g. the login is blocked after too many failures
+type rejector interface {
+	GenerateOrReject(req *http.Request) (security.Context, error)
+}
+
+// generate generates the security context by the generator, an error is returned when the request is rejected
+func generate(g generator, req *http.Request) (security.Context, error) {
+	if r, ok := g.(rejector); ok {
+		return r.GenerateOrReject(req)
+	}
+	return g.Generate(req), nil
+}
+
+// reject responds the error of the rejected request, the client is told when to retry for the blocked login
+func reject(w http.ResponseWriter, err error) {
+	var locked auth.ErrLocked
+	if errors.As(err, &locked) {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(locked.RetryAfter.Seconds())), 10))
+		err = errors.New(nil).WithCode(errors.RateLimitCode).WithMessage(locked.Error())
+	}
+	lib_http.SendError(w, err)
+}
+
 // Middleware returns a security context middleware that populates the security context into the request context
 func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
 	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
@@ -54,8 +85,15 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 			log.Warningf("failed to get auth mode: %v", err)
 		}
 		for _, generator := range generators {
-			if ctx := generator.Generate(r); ctx != nil {
-				r = r.WithContext(security.NewContext(r.Context(), ctx))
+			ctx, err := generate(generator, r)
+			if err != nil {
+				reject(w, err)
+				return
+			}
+			if ctx != nil {
+				if applyProvisioning(r.Context(), ctx) {
+					r = r.WithContext(security.NewContext(r.Context(), ctx))
+				}
 				break
 			}
 		}
@@ -16,14 +16,17 @@ package security
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/utils/test"
+	"github.com/goharbor/harbor/src/core/auth"
 )
 
 func TestMain(m *testing.M) {
@@ -44,3 +47,10 @@ func TestSecurity(t *testing.T) {
 	require.True(t, exist)
 	assert.NotNil(t, ctx)
 }
+
+func TestReject(t *testing.T) {
+	rec := httptest.NewRecorder()
+	reject(rec, auth.ErrLocked{RetryAfter: 1500 * time.Millisecond})
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+}
@@ -66,13 +66,12 @@ func (rc *reqChecker) check(req *http.Request) (string, error) {
 			(req.Method == http.MethodHead || req.Method == http.MethodGet) { // make sure 401 is returned for CLI HEAD, see #11271
 			return getChallenge(req, al), fmt.Errorf("authorize header needed to send HEAD to repository")
 		} else if a.target == repository {
-			pn := strings.Split(a.name, "/")[0]
+			pn, repo, _ := strings.Cut(a.name, "/")
 			pid, err := rc.projectID(req.Context(), pn)
 			if err != nil {
 				return "", err
 			}
-			resource := rbac_project.NewNamespace(pid).Resource(rbac.ResourceRepository)
-			if !securityCtx.Can(req.Context(), a.action, resource) {
+			if !rbac_project.CanAccessRepository(req.Context(), securityCtx, pid, repo, a.action, rbac.ResourceRepository) {
 				return getChallenge(req, al), fmt.Errorf("unauthorized to access repository: %s, action: %s", a.name, a.action)
 			}
 		}
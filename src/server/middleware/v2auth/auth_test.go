@@ -100,6 +100,9 @@ func TestMiddleware(t *testing.T) {
 			"/project/2/repository": {
 				rbac.ActionPull: {},
 			},
+			"/project/4/repository/team-a/app": {
+				rbac.ActionPull: {},
+			},
 		}
 		m, ok := perms[resource.String()]
 		if !ok {
@@ -144,12 +147,24 @@ func TestMiddleware(t *testing.T) {
 		BlobMountProjectName: "project_0",
 		BlobMountDigest:      "sha256:08e4a417ff4e3913d8723a05cc34055db01c2fd165b588e049c5bad16ce6094f",
 	}
+	ar6 := lib.ArtifactInfo{
+		Repository:  "project_4/team-a/app",
+		Reference:   "v1",
+		ProjectName: "project_4",
+	}
+	ar7 := lib.ArtifactInfo{
+		Repository:  "project_4/team-b/app",
+		Reference:   "v1",
+		ProjectName: "project_4",
+	}
 
 	ctx1 := lib.WithArtifactInfo(baseCtx, ar1)
 	ctx2 := lib.WithArtifactInfo(baseCtx, ar2)
 	ctx3 := lib.WithArtifactInfo(baseCtx, ar3)
 	ctx4 := lib.WithArtifactInfo(baseCtx, ar4)
 	ctx5 := lib.WithArtifactInfo(baseCtx, ar5)
+	ctx6 := lib.WithArtifactInfo(baseCtx, ar6)
+	ctx7 := lib.WithArtifactInfo(baseCtx, ar7)
 	req1a, _ := http.NewRequest(http.MethodGet, "/v2/project_1/hello-world/manifest/v1", nil)
 	req1b, _ := http.NewRequest(http.MethodDelete, "/v2/project_1/hello-world/manifest/v1", nil)
 	req1c, _ := http.NewRequest(http.MethodHead, "/v2/project_1/hello-world/manifest/v1", nil)
@@ -163,6 +178,10 @@ func TestMiddleware(t *testing.T) {
 	req5, _ := http.NewRequest(http.MethodPost, "/v2/project_1/ubuntu/blobs/uploads/mount=?mount=sha256:08e4a417ff4e3913d8723a05cc34055db01c2fd165b588e049c5bad16ce6094f&from=project_3/ubuntu", nil)
 	req6, _ := http.NewRequest(http.MethodPost, "/v2/project_1/ubuntu/blobs/uploads/mount=?mount=sha256:08e4a417ff4e3913d8723a05cc34055db01c2fd165b588e049c5bad16ce6094f&from=project_0/ubuntu", nil)
 	req7, _ := http.NewRequest(http.MethodPost, "/v2/uploads/mount=?mount=sha256:08e4a417ff4e3913d8723a05cc34055db01c2fd165b588e049c5bad16ce6094f&from=project_0/ubuntu", nil)
+	req8, _ := http.NewRequest(http.MethodGet, "/v2/project_4/team-a/app/manifest/v1", nil)
+	req8.Header.Set("Authorization", "Bearer xxx")
+	req9, _ := http.NewRequest(http.MethodGet, "/v2/project_4/team-b/app/manifest/v1", nil)
+	req9.Header.Set("Authorization", "Bearer xxx")
 
 	cases := []struct {
 		input  *http.Request
@@ -212,6 +231,14 @@ func TestMiddleware(t *testing.T) {
 			input:  req7.WithContext(ctx5),
 			status: http.StatusUnauthorized,
 		},
+		{
+			input:  req8.WithContext(ctx6),
+			status: http.StatusOK,
+		},
+		{
+			input:  req9.WithContext(ctx7),
+			status: http.StatusUnauthorized,
+		},
 	}
 	for _, c := range cases {
 		rec := httptest.NewRecorder()
@@ -81,7 +81,7 @@ func Middleware() func(http.Handler) http.Handler {
 
 		projectSeverity := vuln.ParseSeverityVersion3(proj.Severity())
 
-		vulnerable, err := scanController.GetVulnerable(ctx, art, allowlist, proj.CVEAllowlist.IsExpired())
+		vulnerable, err := scanController.GetVulnerable(ctx, art, allowlist, proj.CVEAllowlist.IsExpired(), scan.WithScannerGate(proj.ScannerGate()))
 		if errors.IsNotFoundErr(err) {
 			// When the scanner is disconnected the artifact will be considered not scannable.
 			// We'll try to check the existing scan report even when it's not scannable, and only if there is no report, we will skip checking the vulnerability.
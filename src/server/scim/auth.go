@@ -0,0 +1,45 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+// authenticate authenticates the SCIM client with the bearer token configured in Harbor,
+// the SCIM endpoint is disabled when no token configured
+func authenticate() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, req *http.Request, next http.Handler) {
+		token := config.SCIMToken(req.Context())
+		if len(token) == 0 {
+			writeError(w, errors.ForbiddenError(nil).WithMessage("SCIM provisioning is disabled"))
+			return
+		}
+		auth := req.Header.Get("Authorization")
+		if len(auth) < len("Bearer ") || !strings.EqualFold(auth[:len("Bearer ")], "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(auth[len("Bearer "):]), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+			writeError(w, errors.UnauthorizedError(nil).WithMessage("invalid SCIM token"))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"net/http"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ServiceProviderConfig describes the SCIM features supported by Harbor
+type ServiceProviderConfig struct {
+	Schemas               []string                `json:"schemas"`
+	Patch                 supported               `json:"patch"`
+	Bulk                  bulkSupported           `json:"bulk"`
+	Filter                filterSupported         `json:"filter"`
+	ChangePassword        supported               `json:"changePassword"`
+	Sort                  supported               `json:"sort"`
+	ETag                  supported               `json:"etag"`
+	AuthenticationSchemes []*authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                   `json:"meta"`
+}
+
+// ResourceType describes the resource type supported by Harbor
+type ResourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta"`
+}
+
+func getServiceProviderConfig(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &ServiceProviderConfig{
+		Schemas: []string{serviceProviderConfigSchema},
+		Patch:   supported{Supported: true},
+		Filter:  filterSupported{Supported: true, MaxResults: maxCount},
+		AuthenticationSchemes: []*authenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication with the SCIM token configured in Harbor",
+			},
+		},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig"},
+	})
+}
+
+func listResourceTypes(w http.ResponseWriter, _ *http.Request) {
+	resourceTypes := []any{
+		&ResourceType{
+			Schemas:     []string{resourceTypeSchema},
+			ID:          userResourceType,
+			Name:        userResourceType,
+			Endpoint:    "/Users",
+			Description: "Harbor user",
+			Schema:      userSchema,
+			Meta:        &Meta{ResourceType: "ResourceType"},
+		},
+		&ResourceType{
+			Schemas:     []string{resourceTypeSchema},
+			ID:          groupResourceType,
+			Name:        groupResourceType,
+			Endpoint:    "/Groups",
+			Description: "Harbor user group",
+			Schema:      groupSchema,
+			Meta:        &Meta{ResourceType: "ResourceType"},
+		},
+	}
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: int64(len(resourceTypes)),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// comparison is the attribute comparison of the filter, e.g. userName eq "alice"
+type comparison struct {
+	attr  string
+	op    string
+	value any
+}
+
+// parseFilter parses the filter of the query, only the "eq" and "co" operators joined by "and" are supported,
+// that covers the queries sent by the common identity providers to look up the resources before provisioning
+func parseFilter(filter string) ([]*comparison, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	var comparisons []*comparison
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, invalidFilter("only the \"and\" logical operator is supported")
+			}
+			i++
+		}
+		if i+3 > len(tokens) {
+			return nil, invalidFilter("incomplete attribute comparison")
+		}
+		op := strings.ToLower(tokens[i+1])
+		if op != "eq" && op != "co" {
+			return nil, invalidFilter("only the \"eq\" and \"co\" operators are supported")
+		}
+		value, err := parseValue(tokens[i+2])
+		if err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, &comparison{attr: strings.ToLower(tokens[i]), op: op, value: value})
+		i += 3
+	}
+	return comparisons, nil
+}
+
+// toKeywords converts the comparisons to the keywords of the query, the attrs maps the lower case attribute name
+// of the resource to the keyword
+func toKeywords(comparisons []*comparison, attrs map[string]string) (q.KeyWords, error) {
+	kw := q.KeyWords{}
+	for _, c := range comparisons {
+		key, ok := attrs[c.attr]
+		if !ok {
+			return nil, invalidFilter("the attribute " + c.attr + " isn't supported in the filter")
+		}
+		if c.op == "co" {
+			s, ok := c.value.(string)
+			if !ok {
+				return nil, invalidFilter("the \"co\" operator only applies to the string attributes")
+			}
+			kw[key] = &q.FuzzyMatchValue{Value: s}
+			continue
+		}
+		kw[key] = c.value
+	}
+	return kw, nil
+}
+
+// tokenize splits the filter by the spaces, the spaces in the quoted string are kept
+func tokenize(filter string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range filter {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			current.WriteRune(r)
+			escaped = true
+		case r == '"':
+			current.WriteRune(r)
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, invalidFilter("unterminated string")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseValue parses the value of the comparison, it's the JSON string, boolean or number
+func parseValue(token string) (any, error) {
+	if strings.HasPrefix(token, "\"") {
+		s, err := strconv.Unquote(token)
+		if err != nil {
+			return nil, invalidFilter("invalid string " + token)
+		}
+		return s, nil
+	}
+	switch strings.ToLower(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return nil, invalidFilter("invalid value " + token)
+	}
+	return n, nil
+}
+
+func invalidFilter(message string) error {
+	return errors.BadRequestError(nil).WithCode(invalidFilterCode).WithMessage(message)
+}
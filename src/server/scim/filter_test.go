@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+func TestParseFilter(t *testing.T) {
+	comparisons, err := parseFilter(`userName eq "alice smith" and active eq true`)
+	require.Nil(t, err)
+	require.Len(t, comparisons, 2)
+	assert.Equal(t, &comparison{attr: "username", op: "eq", value: "alice smith"}, comparisons[0])
+	assert.Equal(t, &comparison{attr: "active", op: "eq", value: true}, comparisons[1])
+
+	comparisons, err = parseFilter(`displayName co "dev \"ops\""`)
+	require.Nil(t, err)
+	require.Len(t, comparisons, 1)
+	assert.Equal(t, &comparison{attr: "displayname", op: "co", value: `dev "ops"`}, comparisons[0])
+
+	comparisons, err = parseFilter(`value eq 2`)
+	require.Nil(t, err)
+	assert.Equal(t, 2, comparisons[0].value)
+
+	invalid := []string{
+		`userName sw "alice"`,
+		`userName eq "alice" or userName eq "bob"`,
+		`userName eq`,
+		`userName eq "alice`,
+		`userName eq alice`,
+	}
+	for _, filter := range invalid {
+		_, err = parseFilter(filter)
+		assert.Equal(t, invalidFilterCode, errors.ErrCode(err), filter)
+	}
+}
+
+func TestToKeywords(t *testing.T) {
+	attrs := map[string]string{"username": "username", "displayname": "realname"}
+	kw, err := toKeywords([]*comparison{
+		{attr: "username", op: "eq", value: "alice"},
+		{attr: "displayname", op: "co", value: "Ali"},
+	}, attrs)
+	require.Nil(t, err)
+	assert.Equal(t, q.KeyWords{"username": "alice", "realname": &q.FuzzyMatchValue{Value: "Ali"}}, kw)
+
+	_, err = toKeywords([]*comparison{{attr: "title", op: "eq", value: "dev"}}, attrs)
+	assert.Equal(t, invalidFilterCode, errors.ErrCode(err))
+
+	_, err = toKeywords([]*comparison{{attr: "username", op: "co", value: 1}}, attrs)
+	assert.Equal(t, invalidFilterCode, errors.ErrCode(err))
+}
@@ -0,0 +1,333 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	scimctl "github.com/goharbor/harbor/src/controller/scim"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// groupAttrs maps the attributes of the group supported in the filter to the keywords of the query
+var groupAttrs = map[string]string{
+	"displayname": "GroupName",
+}
+
+func newGroupHandler() *groupHandler {
+	return &groupHandler{
+		ctl: scimctl.Ctl,
+	}
+}
+
+type groupHandler struct {
+	ctl scimctl.Controller
+}
+
+func (g *groupHandler) list(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	query := q.New(q.KeyWords{})
+	if filter := req.URL.Query().Get("filter"); len(filter) > 0 {
+		comparisons, err := parseFilter(filter)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		kw, err := toKeywords(comparisons, groupAttrs)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		query.Keywords = kw
+	}
+
+	startIndex, count := pagination(req)
+	total, err := g.ctl.CountGroups(ctx, query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		Resources:    []any{},
+	}
+	if count > 0 && total > 0 {
+		var offset int
+		query.PageNumber, query.PageSize, offset = pageOf(startIndex, count)
+		groups, err := g.ctl.ListGroups(ctx, query)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		// the members aren't listed to avoid loading the members of all groups
+		for i := offset; i < len(groups); i++ {
+			resp.Resources = append(resp.Resources, toGroup(groups[i]))
+		}
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (g *groupHandler) get(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	g.writeGroup(w, req, id, http.StatusOK)
+}
+
+func (g *groupHandler) create(w http.ResponseWriter, req *http.Request) {
+	group := &Group{}
+	if err := decode(req, group); err != nil {
+		writeError(w, err)
+		return
+	}
+	name := strings.TrimSpace(group.DisplayName)
+	if len(name) == 0 {
+		writeError(w, errors.BadRequestError(nil).WithMessage("the displayName is required"))
+		return
+	}
+	memberIDs, err := parseMembers(group.Members)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := g.ctl.CreateGroup(req.Context(), name, memberIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Location", location(groupResourceType, id))
+	g.writeGroup(w, req, id, http.StatusCreated)
+}
+
+func (g *groupHandler) replace(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	group := &Group{}
+	if err := decode(req, group); err != nil {
+		writeError(w, err)
+		return
+	}
+	memberIDs, err := parseMembers(group.Members)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := g.ctl.RenameGroup(req.Context(), id, strings.TrimSpace(group.DisplayName)); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := g.ctl.ReplaceGroupMembers(req.Context(), id, memberIDs); err != nil {
+		writeError(w, err)
+		return
+	}
+	g.writeGroup(w, req, id, http.StatusOK)
+}
+
+func (g *groupHandler) patch(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	patch := &PatchRequest{}
+	if err := decode(req, patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	// make sure the group exists before applying the operations
+	if _, err := g.ctl.GetGroup(req.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	for _, op := range patch.Operations {
+		if op == nil {
+			continue
+		}
+		if err := g.applyPatch(req, id, op); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	g.writeGroup(w, req, id, http.StatusOK)
+}
+
+func (g *groupHandler) delete(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := g.ctl.DeleteGroup(req.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyPatch applies the patch operation to the group, only the displayName and members are supported
+func (g *groupHandler) applyPatch(req *http.Request, id int, op *PatchOperation) error {
+	ctx := req.Context()
+	opName := strings.ToLower(op.Op)
+	if opName != "add" && opName != "replace" && opName != "remove" {
+		return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessagef("unsupported operation %s", op.Op)
+	}
+	path := strings.ToLower(op.Path)
+	switch {
+	case len(path) == 0:
+		// the attributes are specified in the value if no path
+		values, ok := op.Value.(map[string]any)
+		if !ok || opName == "remove" {
+			return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("the value must be an object if no path specified")
+		}
+		for attr, value := range values {
+			if err := g.applyPatch(req, id, &PatchOperation{Op: opName, Path: attr, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case path == "displayname":
+		name, ok := op.Value.(string)
+		if !ok || opName == "remove" {
+			return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("the displayName must be a string")
+		}
+		return g.ctl.RenameGroup(ctx, id, strings.TrimSpace(name))
+	case path == "members":
+		memberIDs, err := parseMemberValues(op.Value)
+		if err != nil {
+			return err
+		}
+		switch opName {
+		case "add":
+			return g.ctl.AddGroupMembers(ctx, id, memberIDs)
+		case "replace":
+			return g.ctl.ReplaceGroupMembers(ctx, id, memberIDs)
+		}
+		// remove all members if no value specified
+		if op.Value == nil {
+			return g.ctl.ReplaceGroupMembers(ctx, id, nil)
+		}
+		return g.ctl.RemoveGroupMembers(ctx, id, memberIDs)
+	case strings.HasPrefix(path, "members["):
+		// e.g. members[value eq "2"]
+		if opName != "remove" || !strings.HasSuffix(path, "]") {
+			return errors.BadRequestError(nil).WithCode(invalidPathCode).WithMessagef("unsupported path %s", op.Path)
+		}
+		comparisons, err := parseFilter(op.Path[len("members[") : len(op.Path)-1])
+		if err != nil {
+			return err
+		}
+		var memberIDs []int
+		for _, c := range comparisons {
+			if c.attr != "value" || c.op != "eq" {
+				return errors.BadRequestError(nil).WithCode(invalidPathCode).WithMessagef("unsupported path %s", op.Path)
+			}
+			memberID, err := parseID(formatValue(c.value))
+			if err != nil {
+				return err
+			}
+			memberIDs = append(memberIDs, memberID)
+		}
+		return g.ctl.RemoveGroupMembers(ctx, id, memberIDs)
+	}
+	// the other attributes, e.g. externalId, aren't stored in Harbor
+	return nil
+}
+
+// writeGroup writes the group with its members to the response
+func (g *groupHandler) writeGroup(w http.ResponseWriter, req *http.Request, id int, statusCode int) {
+	group, err := g.ctl.GetGroup(req.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, statusCode, toGroup(group))
+}
+
+func toGroup(g *scimctl.Group) *Group {
+	group := &Group{
+		Schemas:     []string{groupSchema},
+		ID:          formatID(g.ID),
+		DisplayName: g.GroupName,
+		Meta: &Meta{
+			ResourceType: groupResourceType,
+			Location:     location(groupResourceType, g.ID),
+		},
+	}
+	for _, id := range g.MemberIDs {
+		group.Members = append(group.Members, &MultiValue{Value: formatID(id), Ref: location(userResourceType, id)})
+	}
+	return group
+}
+
+func parseMembers(members []*MultiValue) ([]int, error) {
+	var ids []int
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		id, err := parseID(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseMemberValues parses the member IDs from the value of the patch operation which is the list of the member objects
+func parseMemberValues(value any) ([]int, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		// some identity providers send the single member object
+		items = []any{value}
+	}
+	var ids []int
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("invalid members")
+		}
+		id, err := parseID(formatValue(m["value"]))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func formatValue(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,104 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/scim"
+	"github.com/goharbor/harbor/src/pkg/usergroup/model"
+	scimtesting "github.com/goharbor/harbor/src/testing/controller/scim"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+type groupHandlerTestSuite struct {
+	suite.Suite
+	ctl     *scimtesting.Controller
+	handler *groupHandler
+}
+
+func (g *groupHandlerTestSuite) SetupTest() {
+	g.ctl = &scimtesting.Controller{}
+	g.handler = &groupHandler{ctl: g.ctl}
+	g.ctl.On("GetGroup", mock.Anything, 5).Return(&scim.Group{
+		UserGroup: &model.UserGroup{ID: 5, GroupName: "developers"},
+		MemberIDs: []int{2, 3},
+	}, nil)
+}
+
+func (g *groupHandlerTestSuite) TestCreate() {
+	g.ctl.On("CreateGroup", mock.Anything, "developers", []int{2, 3}).Return(5, nil)
+	body := `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"developers",
+"members":[{"value":"2"},{"value":"3"}]}`
+	w := httptest.NewRecorder()
+	g.handler.create(w, newRequest(http.MethodPost, "/api/scim/v2/Groups", body, ""))
+	g.Equal(http.StatusCreated, w.Code)
+	g.Equal("https://harbor.test/api/scim/v2/Groups/5", w.Header().Get("Location"))
+	g.Contains(w.Body.String(), `"displayName":"developers"`)
+	g.Contains(w.Body.String(), `"$ref":"https://harbor.test/api/scim/v2/Users/3"`)
+
+	w = httptest.NewRecorder()
+	g.handler.create(w, newRequest(http.MethodPost, "/api/scim/v2/Groups", `{"members":[{"value":"2"}]}`, ""))
+	g.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (g *groupHandlerTestSuite) TestPatch() {
+	g.ctl.On("RenameGroup", mock.Anything, 5, "dev").Return(nil)
+	g.ctl.On("AddGroupMembers", mock.Anything, 5, []int{4}).Return(nil)
+	g.ctl.On("RemoveGroupMembers", mock.Anything, 5, []int{2}).Return(nil)
+	g.ctl.On("ReplaceGroupMembers", mock.Anything, 5, []int(nil)).Return(nil)
+
+	body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[
+{"op":"Replace","value":{"displayName":"dev","externalId":"abc"}},
+{"op":"add","path":"members","value":[{"value":"4"}]},
+{"op":"remove","path":"members[value eq \"2\"]"},
+{"op":"remove","path":"members"}]}`
+	w := httptest.NewRecorder()
+	g.handler.patch(w, newRequest(http.MethodPatch, "/api/scim/v2/Groups/5", body, "5"))
+	g.Equal(http.StatusOK, w.Code)
+	g.ctl.AssertExpectations(g.T())
+}
+
+func (g *groupHandlerTestSuite) TestPatchInvalid() {
+	w := httptest.NewRecorder()
+	body := `{"Operations":[{"op":"add","path":"members[value eq \"2\"]"}]}`
+	g.handler.patch(w, newRequest(http.MethodPatch, "/api/scim/v2/Groups/5", body, "5"))
+	g.Equal(http.StatusBadRequest, w.Code)
+	g.Contains(w.Body.String(), invalidPathCode)
+
+	w = httptest.NewRecorder()
+	body = `{"Operations":[{"op":"add","path":"members","value":[{"value":"abc"}]}]}`
+	g.handler.patch(w, newRequest(http.MethodPatch, "/api/scim/v2/Groups/5", body, "5"))
+	g.Equal(http.StatusBadRequest, w.Code)
+	g.ctl.AssertNotCalled(g.T(), "AddGroupMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (g *groupHandlerTestSuite) TestReplace() {
+	g.ctl.On("RenameGroup", mock.Anything, 5, "developers").Return(nil)
+	g.ctl.On("ReplaceGroupMembers", mock.Anything, 5, []int{2, 3}).Return(nil)
+	body := `{"displayName":"developers","members":[{"value":"2"},{"value":"3"}]}`
+	w := httptest.NewRecorder()
+	g.handler.replace(w, newRequest(http.MethodPut, "/api/scim/v2/Groups/5", body, "5"))
+	g.Equal(http.StatusOK, w.Code)
+	g.ctl.AssertExpectations(g.T())
+}
+
+func TestGroupHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &groupHandlerTestSuite{})
+}
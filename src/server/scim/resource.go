@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// the schemas defined in RFC 7643 and RFC 7644
+	userSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	patchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	errorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	resourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	userResourceType  = "User"
+	groupResourceType = "Group"
+)
+
+// Meta is the metadata of the SCIM resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Name is the name of the SCIM user, only the formatted name is stored as the realname of the user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValue is the multi-valued attribute, e.g. the emails of the user and the members of the group
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM user resource
+type User struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName"`
+	Name        *Name         `json:"name,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Emails      []*MultiValue `json:"emails,omitempty"`
+	Active      *bool         `json:"active,omitempty"`
+	Groups      []*MultiValue `json:"groups,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// realname returns the realname of the user in Harbor
+func (u *User) realname() string {
+	if len(u.DisplayName) > 0 {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if len(u.Name.Formatted) > 0 {
+		return u.Name.Formatted
+	}
+	if len(u.Name.GivenName) > 0 && len(u.Name.FamilyName) > 0 {
+		return u.Name.GivenName + " " + u.Name.FamilyName
+	}
+	return u.Name.GivenName + u.Name.FamilyName
+}
+
+// email returns the primary email of the user, the first one is returned if no primary email
+func (u *User) email() string {
+	for _, e := range u.Emails {
+		if e != nil && e.Primary {
+			return e.Value
+		}
+	}
+	for _, e := range u.Emails {
+		if e != nil {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// active returns whether the user is active, the user is active if not specified
+func (u *User) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Group is the SCIM group resource
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []*MultiValue `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// ListResponse is the response of the query of the resources
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// PatchRequest is the request to modify the resource with a set of operations
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is the operation of the patch request, the operation is one of "add", "remove" and "replace"
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Error is the SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func formatID(id int) string {
+	return strconv.Itoa(id)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,377 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	scimctl "github.com/goharbor/harbor/src/controller/scim"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// userAttrs maps the attributes of the user supported in the filter to the keywords of the query
+var userAttrs = map[string]string{
+	"username":       "username",
+	"emails":         "email",
+	"emails.value":   "email",
+	"displayname":    "realname",
+	"name.formatted": "realname",
+}
+
+func newUserHandler() *userHandler {
+	return &userHandler{
+		ctl: scimctl.Ctl,
+	}
+}
+
+type userHandler struct {
+	ctl scimctl.Controller
+}
+
+func (u *userHandler) list(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	query := q.New(q.KeyWords{})
+	if filter := req.URL.Query().Get("filter"); len(filter) > 0 {
+		comparisons, err := parseFilter(filter)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		var rest []*comparison
+		for _, c := range comparisons {
+			// the "active" attribute is stored as the opposite "disabled" flag
+			if c.attr == "active" {
+				active, ok := c.value.(bool)
+				if !ok || c.op != "eq" {
+					writeError(w, invalidFilter("the active attribute only supports the \"eq\" operator with the boolean value"))
+					return
+				}
+				query.Keywords["disabled"] = !active
+				continue
+			}
+			rest = append(rest, c)
+		}
+		kw, err := toKeywords(rest, userAttrs)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		maps.Copy(query.Keywords, kw)
+	}
+
+	startIndex, count := pagination(req)
+	total, err := u.ctl.CountUsers(ctx, query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		Resources:    []any{},
+	}
+	if count > 0 && total > 0 {
+		var offset int
+		query.PageNumber, query.PageSize, offset = pageOf(startIndex, count)
+		users, err := u.ctl.ListUsers(ctx, query)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		for i := offset; i < len(users); i++ {
+			resp.Resources = append(resp.Resources, toUser(users[i], nil))
+		}
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (u *userHandler) get(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	user, err := u.getUser(req, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (u *userHandler) create(w http.ResponseWriter, req *http.Request) {
+	user := &User{}
+	if err := decode(req, user); err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := u.ctl.CreateUser(req.Context(), &commonmodels.User{
+		Username: strings.TrimSpace(user.UserName),
+		Email:    user.email(),
+		Realname: user.realname(),
+		Disabled: !user.active(),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	created, err := u.getUser(req, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Location", created.Meta.Location)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (u *userHandler) replace(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	user := &User{}
+	if err := decode(req, user); err != nil {
+		writeError(w, err)
+		return
+	}
+	current, err := u.getUser(req, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if user.UserName != current.UserName {
+		writeError(w, errors.BadRequestError(nil).WithCode(mutabilityCode).WithMessage("the userName can't be changed"))
+		return
+	}
+	u.update(w, req, id, user)
+}
+
+func (u *userHandler) patch(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	patch := &PatchRequest{}
+	if err := decode(req, patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	user, err := u.getUser(req, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := applyUserPatch(user, patch.Operations); err != nil {
+		writeError(w, err)
+		return
+	}
+	u.update(w, req, id, user)
+}
+
+func (u *userHandler) delete(w http.ResponseWriter, req *http.Request) {
+	id, err := pathID(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := u.ctl.DeleteUser(req.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// update updates the user with the attributes of the resource and writes the updated resource to the response
+func (u *userHandler) update(w http.ResponseWriter, req *http.Request, id int, user *User) {
+	err := u.ctl.UpdateUser(req.Context(), &commonmodels.User{
+		UserID:   id,
+		Email:    user.email(),
+		Realname: user.realname(),
+		Disabled: !user.active(),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	updated, err := u.getUser(req, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// getUser returns the user resource with the groups which the user is member of
+func (u *userHandler) getUser(req *http.Request, id int) (*User, error) {
+	user, err := u.ctl.GetUser(req.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := u.ctl.ListUserGroupIDs(req.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user, groupIDs), nil
+}
+
+func toUser(u *commonmodels.User, groupIDs []int) *User {
+	active := !u.Disabled
+	user := &User{
+		Schemas:     []string{userSchema},
+		ID:          formatID(u.UserID),
+		UserName:    u.Username,
+		DisplayName: u.Realname,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: userResourceType,
+			Created:      formatTime(u.CreationTime),
+			LastModified: formatTime(u.UpdateTime),
+			Location:     location(userResourceType, u.UserID),
+		},
+	}
+	if len(u.Realname) > 0 {
+		user.Name = &Name{Formatted: u.Realname}
+	}
+	if len(u.Email) > 0 {
+		user.Emails = []*MultiValue{{Value: u.Email, Primary: true}}
+	}
+	for _, id := range groupIDs {
+		user.Groups = append(user.Groups, &MultiValue{Value: formatID(id), Ref: location(groupResourceType, id)})
+	}
+	return user
+}
+
+// applyUserPatch applies the patch operations to the user resource, the operations on the attributes which
+// aren't stored in Harbor, e.g. externalId and the enterprise extension, are ignored
+func applyUserPatch(user *User, operations []*PatchOperation) error {
+	for _, op := range operations {
+		if op == nil {
+			continue
+		}
+		opName := strings.ToLower(op.Op)
+		if opName != "add" && opName != "replace" && opName != "remove" {
+			return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessagef("unsupported operation %s", op.Op)
+		}
+		if len(op.Path) > 0 {
+			if err := setUserAttr(user, opName, op.Path, op.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		// the attributes are specified in the value if no path
+		values, ok := op.Value.(map[string]any)
+		if !ok {
+			return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("the value must be an object if no path specified")
+		}
+		for attr, value := range values {
+			if err := setUserAttr(user, opName, attr, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setUserAttr(user *User, op, path string, value any) error {
+	attr := strings.ToLower(path)
+	// only one email is kept, so the value filter is ignored, e.g. emails[type eq "work"].value
+	if i := strings.Index(attr, "["); i >= 0 {
+		if j := strings.Index(attr, "]"); j > i {
+			attr = attr[:i] + attr[j+1:]
+		}
+	}
+	remove := op == "remove"
+	switch attr {
+	case "active":
+		if remove {
+			return errors.BadRequestError(nil).WithCode(mutabilityCode).WithMessage("the active attribute can't be removed")
+		}
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		user.Active = &active
+	case "displayname", "name.formatted":
+		if remove {
+			user.DisplayName, user.Name = "", nil
+			return nil
+		}
+		s, ok := value.(string)
+		if !ok {
+			return errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessagef("the %s must be a string", path)
+		}
+		user.DisplayName = s
+	case "emails", "emails.value":
+		if remove {
+			user.Emails = nil
+			return nil
+		}
+		emails, err := parseEmails(value)
+		if err != nil {
+			return err
+		}
+		user.Emails = emails
+	case "username":
+		if s, ok := value.(string); remove || !ok || s != user.UserName {
+			return errors.BadRequestError(nil).WithCode(mutabilityCode).WithMessage("the userName can't be changed")
+		}
+	}
+	return nil
+}
+
+// parseBool parses the boolean value, some identity providers send the boolean as the string, e.g. "False"
+func parseBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err == nil {
+			return b, nil
+		}
+	}
+	return false, errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessagef("invalid boolean value %v", value)
+}
+
+// parseEmails parses the emails which are the string of the email address or the list of the email objects
+func parseEmails(value any) ([]*MultiValue, error) {
+	switch v := value.(type) {
+	case string:
+		return []*MultiValue{{Value: v, Primary: true}}, nil
+	case []any:
+		var emails []*MultiValue
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("invalid emails")
+			}
+			email := &MultiValue{}
+			email.Value, _ = m["value"].(string)
+			email.Primary, _ = m["primary"].(bool)
+			emails = append(emails, email)
+		}
+		return emails, nil
+	}
+	return nil, errors.BadRequestError(nil).WithCode(invalidValueCode).WithMessage("invalid emails")
+}
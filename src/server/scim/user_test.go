@@ -0,0 +1,212 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	beegocontext "github.com/beego/beego/v2/server/web/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/server/router"
+	"github.com/goharbor/harbor/src/testing/controller/scim"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+func TestMain(m *testing.M) {
+	config.InitWithSettings(map[string]any{
+		common.ExtEndpoint: "https://harbor.test",
+		common.SCIMToken:   "scim-token",
+	})
+	os.Exit(m.Run())
+}
+
+// newRequest creates the request with the ID param which is set by the beego router normally
+func newRequest(method, target, body, id string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	input := &beegocontext.BeegoInput{}
+	input.SetParam(":id", id)
+	return req.WithContext(context.WithValue(req.Context(), router.ContextKeyInput{}, input))
+}
+
+type userHandlerTestSuite struct {
+	suite.Suite
+	ctl     *scim.Controller
+	handler *userHandler
+}
+
+func (u *userHandlerTestSuite) SetupTest() {
+	u.ctl = &scim.Controller{}
+	u.handler = &userHandler{ctl: u.ctl}
+}
+
+func (u *userHandlerTestSuite) TestList() {
+	u.ctl.On("CountUsers", mock.Anything, mock.Anything).Return(int64(3), nil)
+	u.ctl.On("ListUsers", mock.Anything, mock.Anything).Return([]*commonmodels.User{
+		{UserID: 2, Username: "alice"},
+		{UserID: 3, Username: "bob", Disabled: true},
+		{UserID: 4, Username: "carol"},
+	}, nil)
+	w := httptest.NewRecorder()
+	u.handler.list(w, newRequest(http.MethodGet, `/api/scim/v2/Users?startIndex=2&count=2&filter=active+eq+false`, "", ""))
+	u.Equal(http.StatusOK, w.Code)
+
+	query := u.ctl.Calls[1].Arguments.Get(1).(*q.Query)
+	u.Equal(true, query.Keywords["disabled"])
+	u.Equal(int64(1), query.PageNumber)
+	u.Equal(int64(3), query.PageSize)
+
+	resp := &struct {
+		TotalResults int     `json:"totalResults"`
+		StartIndex   int     `json:"startIndex"`
+		ItemsPerPage int     `json:"itemsPerPage"`
+		Resources    []*User `json:"Resources"`
+	}{}
+	u.Require().Nil(json.Unmarshal(w.Body.Bytes(), resp))
+	u.Equal(3, resp.TotalResults)
+	u.Equal(2, resp.StartIndex)
+	u.Equal(2, resp.ItemsPerPage)
+	u.Equal("bob", resp.Resources[0].UserName)
+	u.False(resp.Resources[0].active())
+	u.Equal("https://harbor.test/api/scim/v2/Users/3", resp.Resources[0].Meta.Location)
+	u.Equal("carol", resp.Resources[1].UserName)
+}
+
+func (u *userHandlerTestSuite) TestListInvalidFilter() {
+	w := httptest.NewRecorder()
+	u.handler.list(w, newRequest(http.MethodGet, `/api/scim/v2/Users?filter=title+eq+"dev"`, "", ""))
+	u.Equal(http.StatusBadRequest, w.Code)
+	u.Contains(w.Body.String(), invalidFilterCode)
+}
+
+func (u *userHandlerTestSuite) TestCreate() {
+	u.ctl.On("CreateUser", mock.Anything, &commonmodels.User{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Realname: "Alice Smith",
+		Disabled: false,
+	}).Return(2, nil)
+	u.ctl.On("GetUser", mock.Anything, 2).Return(&commonmodels.User{
+		UserID:   2,
+		Username: "alice",
+		Email:    "alice@example.com",
+		Realname: "Alice Smith",
+	}, nil)
+	u.ctl.On("ListUserGroupIDs", mock.Anything, 2).Return([]int{5}, nil)
+
+	body := `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"alice",
+"name":{"givenName":"Alice","familyName":"Smith"},"emails":[{"value":"alice@example.com","primary":true}]}`
+	w := httptest.NewRecorder()
+	u.handler.create(w, newRequest(http.MethodPost, "/api/scim/v2/Users", body, ""))
+	u.Equal(http.StatusCreated, w.Code)
+	u.Equal("https://harbor.test/api/scim/v2/Users/2", w.Header().Get("Location"))
+	user := &User{}
+	u.Require().Nil(json.Unmarshal(w.Body.Bytes(), user))
+	u.Equal("2", user.ID)
+	u.Equal("alice@example.com", user.email())
+	u.Require().Len(user.Groups, 1)
+	u.Equal("5", user.Groups[0].Value)
+}
+
+func (u *userHandlerTestSuite) TestCreateConflict() {
+	u.ctl.On("CreateUser", mock.Anything, mock.Anything).Return(0, errors.ConflictError(nil).WithMessage("user alice already exists"))
+	w := httptest.NewRecorder()
+	u.handler.create(w, newRequest(http.MethodPost, "/api/scim/v2/Users", `{"userName":"alice"}`, ""))
+	u.Equal(http.StatusConflict, w.Code)
+	u.Contains(w.Body.String(), uniquenessCode)
+}
+
+func (u *userHandlerTestSuite) TestPatch() {
+	u.ctl.On("GetUser", mock.Anything, 2).Return(&commonmodels.User{
+		UserID:   2,
+		Username: "alice",
+		Email:    "alice@example.com",
+		Realname: "Alice",
+	}, nil)
+	u.ctl.On("ListUserGroupIDs", mock.Anything, 2).Return(nil, nil)
+	u.ctl.On("UpdateUser", mock.Anything, &commonmodels.User{
+		UserID:   2,
+		Email:    "alice@example.com",
+		Realname: "Alice Smith",
+		Disabled: true,
+	}).Return(nil)
+
+	body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[
+{"op":"Replace","path":"active","value":"False"},{"op":"replace","value":{"displayName":"Alice Smith"}}]}`
+	w := httptest.NewRecorder()
+	u.handler.patch(w, newRequest(http.MethodPatch, "/api/scim/v2/Users/2", body, "2"))
+	u.Equal(http.StatusOK, w.Code)
+	u.ctl.AssertExpectations(u.T())
+}
+
+func (u *userHandlerTestSuite) TestReplaceUserName() {
+	u.ctl.On("GetUser", mock.Anything, 2).Return(&commonmodels.User{UserID: 2, Username: "alice"}, nil)
+	u.ctl.On("ListUserGroupIDs", mock.Anything, 2).Return(nil, nil)
+	w := httptest.NewRecorder()
+	u.handler.replace(w, newRequest(http.MethodPut, "/api/scim/v2/Users/2", `{"userName":"bob"}`, "2"))
+	u.Equal(http.StatusBadRequest, w.Code)
+	u.Contains(w.Body.String(), mutabilityCode)
+	u.ctl.AssertNotCalled(u.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (u *userHandlerTestSuite) TestDelete() {
+	w := httptest.NewRecorder()
+	u.handler.delete(w, newRequest(http.MethodDelete, "/api/scim/v2/Users/abc", "", "abc"))
+	u.Equal(http.StatusNotFound, w.Code)
+
+	u.ctl.On("DeleteUser", mock.Anything, 2).Return(nil)
+	w = httptest.NewRecorder()
+	u.handler.delete(w, newRequest(http.MethodDelete, "/api/scim/v2/Users/2", "", "2"))
+	u.Equal(http.StatusNoContent, w.Code)
+}
+
+func TestUserHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &userHandlerTestSuite{})
+}
+
+func TestApplyUserPatch(t *testing.T) {
+	active := true
+	user := &User{UserName: "alice", DisplayName: "Alice", Active: &active}
+	err := applyUserPatch(user, []*PatchOperation{
+		{Op: "replace", Path: `emails[type eq "work"].value`, Value: "alice@example.com"},
+		{Op: "Add", Path: "title", Value: "developer"},
+		{Op: "remove", Path: "displayName"},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "alice@example.com", user.email())
+	assert.Empty(t, user.realname())
+	assert.True(t, user.active())
+
+	err = applyUserPatch(user, []*PatchOperation{{Op: "move", Path: "active", Value: false}})
+	assert.Equal(t, invalidValueCode, errors.ErrCode(err))
+	err = applyUserPatch(user, []*PatchOperation{{Op: "replace", Path: "active", Value: "no"}})
+	assert.Equal(t, invalidValueCode, errors.ErrCode(err))
+	err = applyUserPatch(user, []*PatchOperation{{Op: "replace", Value: map[string]any{"userName": "bob"}}})
+	assert.Equal(t, mutabilityCode, errors.ErrCode(err))
+}
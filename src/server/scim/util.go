@@ -0,0 +1,151 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/server/router"
+)
+
+const (
+	contentType = "application/scim+json"
+	// the base path of the SCIM endpoint
+	basePath = "/api/scim/v2"
+	// the default and max count of the resources returned in one page
+	defaultCount = 100
+	maxCount     = 1000
+
+	// the error types defined in RFC 7644, they are used as the error codes to return the scimType
+	invalidFilterCode = "invalidFilter"
+	invalidValueCode  = "invalidValue"
+	invalidPathCode   = "invalidPath"
+	mutabilityCode    = "mutability"
+	uniquenessCode    = "uniqueness"
+)
+
+// writeJSON writes the resource to the response with the status code
+func writeJSON(w http.ResponseWriter, statusCode int, resource any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(resource); err != nil {
+		log.Errorf("failed to encode the SCIM response: %v", err)
+	}
+}
+
+// writeError writes the error in the format of the SCIM error response
+func writeError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	scimType := ""
+	switch code := errors.ErrCode(err); code {
+	case errors.BadRequestCode:
+		statusCode = http.StatusBadRequest
+		scimType = invalidValueCode
+	case invalidFilterCode, invalidValueCode, invalidPathCode, mutabilityCode:
+		statusCode = http.StatusBadRequest
+		scimType = code
+	case errors.ConflictCode:
+		statusCode = http.StatusConflict
+		scimType = uniquenessCode
+	case errors.NotFoundCode:
+		statusCode = http.StatusNotFound
+	case errors.UnAuthorizedCode:
+		statusCode = http.StatusUnauthorized
+	case errors.ForbiddenCode:
+		statusCode = http.StatusForbidden
+	}
+
+	detail := "internal server error"
+	if statusCode < http.StatusInternalServerError {
+		detail = err.Error()
+		var e *errors.Error
+		if errors.As(err, &e) && len(e.Message) > 0 {
+			detail = e.Message
+		}
+		log.Debugf("SCIM request failed: %v", err)
+	} else {
+		log.Errorf("SCIM request failed: %v", err)
+	}
+	writeJSON(w, statusCode, &Error{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(statusCode),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+// decode decodes the JSON body of the request
+func decode(req *http.Request, v any) error {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return errors.BadRequestError(err).WithMessagef("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// pathID returns the ID in the path of the request
+func pathID(req *http.Request) (int, error) {
+	id, err := strconv.Atoi(router.Param(req.Context(), ":id"))
+	if err != nil || id <= 0 {
+		return 0, errors.NotFoundError(nil).WithMessagef("resource %s not found", router.Param(req.Context(), ":id"))
+	}
+	return id, nil
+}
+
+// parseID parses the ID of the resource referenced in the request, e.g. the members of the group
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return 0, errors.BadRequestError(nil).WithMessagef("invalid ID %q", s)
+	}
+	return id, nil
+}
+
+// pagination returns the startIndex and count of the query, the startIndex is 1-based
+func pagination(req *http.Request) (int, int) {
+	startIndex, err := strconv.Atoi(req.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(req.URL.Query().Get("count"))
+	if err != nil || count < 0 {
+		count = defaultCount
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	return startIndex, count
+}
+
+// pageOf converts the startIndex and count to the page of the query and the offset of the first resource in the page,
+// the page starts from the first resource if the startIndex isn't aligned with the count
+func pageOf(startIndex, count int) (pageNumber, pageSize int64, offset int) {
+	if (startIndex-1)%count == 0 {
+		return int64((startIndex-1)/count + 1), int64(count), 0
+	}
+	return 1, int64(startIndex - 1 + count), startIndex - 1
+}
+
+// location returns the URI of the resource
+func location(resourceType string, id int) string {
+	endpoint, _ := config.ExtEndpoint()
+	return fmt.Sprintf("%s%s/%ss/%d", strings.TrimSuffix(endpoint, "/"), basePath, resourceType, id)
+}
@@ -0,0 +1,91 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+func TestPagination(t *testing.T) {
+	cases := []struct {
+		query      string
+		startIndex int
+		count      int
+	}{
+		{"", 1, defaultCount},
+		{"?startIndex=11&count=10", 11, 10},
+		{"?startIndex=0&count=0", 1, 0},
+		{"?startIndex=-1&count=-1", 1, defaultCount},
+		{"?count=5000", 1, maxCount},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/api/scim/v2/Users"+c.query, nil)
+		startIndex, count := pagination(req)
+		assert.Equal(t, c.startIndex, startIndex, c.query)
+		assert.Equal(t, c.count, count, c.query)
+	}
+}
+
+func TestPageOf(t *testing.T) {
+	pageNumber, pageSize, offset := pageOf(1, 10)
+	assert.Equal(t, int64(1), pageNumber)
+	assert.Equal(t, int64(10), pageSize)
+	assert.Equal(t, 0, offset)
+
+	pageNumber, pageSize, offset = pageOf(21, 10)
+	assert.Equal(t, int64(3), pageNumber)
+	assert.Equal(t, int64(10), pageSize)
+	assert.Equal(t, 0, offset)
+
+	// the start index isn't aligned with the page
+	pageNumber, pageSize, offset = pageOf(5, 10)
+	assert.Equal(t, int64(1), pageNumber)
+	assert.Equal(t, int64(14), pageSize)
+	assert.Equal(t, 4, offset)
+}
+
+func TestWriteError(t *testing.T) {
+	cases := []struct {
+		err        error
+		statusCode int
+		scimType   string
+		detail     string
+	}{
+		{errors.BadRequestError(nil).WithMessage("bad"), http.StatusBadRequest, invalidValueCode, "bad"},
+		{invalidFilter("bad filter"), http.StatusBadRequest, invalidFilterCode, "bad filter"},
+		{errors.ConflictError(nil).WithMessage("exists"), http.StatusConflict, uniquenessCode, "exists"},
+		{errors.NotFoundError(nil).WithMessage("missing"), http.StatusNotFound, "", "missing"},
+		{errors.New("db is down"), http.StatusInternalServerError, "", "internal server error"},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		writeError(w, c.err)
+		assert.Equal(t, c.statusCode, w.Code)
+		assert.Equal(t, contentType, w.Header().Get("Content-Type"))
+		e := &Error{}
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), e))
+		assert.Equal(t, []string{errorSchema}, e.Schemas)
+		assert.Equal(t, c.scimType, e.SCIMType)
+		assert.Equal(t, c.detail, e.Detail)
+	}
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"github.com/goharbor/harbor/src/server/registry"
+	"github.com/goharbor/harbor/src/server/scim"
 	v2 "github.com/goharbor/harbor/src/server/v2.0/route"
 )
 
@@ -24,4 +25,5 @@ func RegisterRoutes() {
 	registerRoutes()          // service/internal API/UI controller/etc.
 	registry.RegisterRoutes() // OCI registry APIs
 	v2.RegisterRoutes()       // v2.0 APIs
+	scim.RegisterRoutes()     // SCIM 2.0 APIs
 }
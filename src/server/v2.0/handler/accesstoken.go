@@ -0,0 +1,223 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/controller/accesstoken"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accesstoken/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/accesstoken"
+)
+
+func newAccessTokenAPI() *accessTokenAPI {
+	return &accessTokenAPI{
+		tokenCtl: accesstoken.Ctl,
+	}
+}
+
+type accessTokenAPI struct {
+	BaseAPI
+	tokenCtl accesstoken.Controller
+}
+
+func (a *accessTokenAPI) ListAccessTokens(ctx context.Context, params operation.ListAccessTokensParams) middleware.Responder {
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	// only the tokens of the current user are listed
+	if query.Keywords == nil {
+		query.Keywords = q.KeyWords{}
+	}
+	query.Keywords["user_id"] = user.UserID
+
+	total, err := a.tokenCtl.Count(ctx, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	tokens, err := a.tokenCtl.List(ctx, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	var payload []*models.AccessToken
+	for _, t := range tokens {
+		payload = append(payload, toAccessTokenModel(t))
+	}
+	return operation.NewListAccessTokensOK().
+		WithXTotalCount(total).
+		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (a *accessTokenAPI) GetAccessToken(ctx context.Context, params operation.GetAccessTokenParams) middleware.Responder {
+	t, err := a.getToken(ctx, params.TokenID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewGetAccessTokenOK().WithPayload(toAccessTokenModel(t))
+}
+
+func (a *accessTokenAPI) CreateAccessToken(ctx context.Context, params operation.CreateAccessTokenParams) middleware.Responder {
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	t, err := fromAccessTokenCreateModel(params.Token)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	t.UserID = user.UserID
+	t.GroupIDs = user.GroupIDs
+
+	id, token, err := a.tokenCtl.Create(ctx, t, params.Token.Duration)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	created, err := a.tokenCtl.Get(ctx, id)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateAccessTokenCreated().WithLocation(location).WithPayload(&models.AccessTokenCreated{
+		ID:           created.ID,
+		Name:         created.Name,
+		Token:        token,
+		ExpiresAt:    created.ExpiresAt,
+		CreationTime: strfmt.DateTime(created.CreationTime),
+	})
+}
+
+func (a *accessTokenAPI) DeleteAccessToken(ctx context.Context, params operation.DeleteAccessTokenParams) middleware.Responder {
+	t, err := a.getToken(ctx, params.TokenID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err := a.tokenCtl.Delete(ctx, t.ID); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewDeleteAccessTokenOK()
+}
+
+// currentUser returns the user authenticated by the local security context,
+// the tokens can't be managed by the users authenticated by the tokens
+func (a *accessTokenAPI) currentUser(ctx context.Context) (*commonmodels.User, error) {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+	sctx, _ := security.FromContext(ctx)
+	lsc, ok := sctx.(*local.SecurityContext)
+	if !ok {
+		return nil, errors.PreconditionFailedError(nil).WithMessagef("personal access tokens not available for security context: %s", sctx.Name())
+	}
+	return lsc.User(), nil
+}
+
+// getToken returns the token owned by the current user, the token of the other users is treated as not found
+func (a *accessTokenAPI) getToken(ctx context.Context, id int64) (*model.AccessToken, error) {
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := a.tokenCtl.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.UserID != user.UserID {
+		return nil, errors.NotFoundError(nil).WithMessagef("personal access token %d not found", id)
+	}
+	return t, nil
+}
+
+// fromAccessTokenCreateModel validates the token in the request, only the project level permissions are allowed
+func fromAccessTokenCreateModel(m *models.AccessTokenCreate) (*model.AccessToken, error) {
+	if m == nil {
+		return nil, errors.BadRequestError(nil).WithMessage("the token is required")
+	}
+	policies := rbac.GetPermissionProvider().GetPermissions(rbac.ScopeProject)
+	t := &model.AccessToken{
+		Name:        strings.TrimSpace(m.Name),
+		Description: m.Description,
+	}
+	for _, p := range m.Permissions {
+		if p == nil {
+			return nil, errors.BadRequestError(nil).WithMessage("bad request empty permission")
+		}
+		perm := &model.Permission{Namespace: strings.TrimSpace(p.Namespace)}
+		for _, acc := range p.Access {
+			if acc == nil {
+				return nil, errors.BadRequestError(nil).WithMessage("bad request empty access")
+			}
+			if !containsAccess(policies, acc) {
+				return nil, errors.BadRequestError(nil).WithMessagef("bad request permission: %s:%s", acc.Resource, acc.Action)
+			}
+			if err := validateAccessRepository(acc); err != nil {
+				return nil, err
+			}
+			perm.Access = append(perm.Access, &types.Policy{
+				Resource: accessResource(acc),
+				Action:   types.Action(acc.Action),
+				Effect:   types.Effect(acc.Effect),
+			})
+		}
+		t.Permissions = append(t.Permissions, perm)
+	}
+	return t, nil
+}
+
+func toAccessTokenModel(t *model.AccessToken) *models.AccessToken {
+	m := &models.AccessToken{
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		ExpiresAt:    t.ExpiresAt,
+		LastUsedIP:   t.LastUsedIP,
+		CreationTime: strfmt.DateTime(t.CreationTime),
+	}
+	if !t.LastUsedTime.IsZero() {
+		m.LastUsedTime = strfmt.DateTime(t.LastUsedTime)
+	}
+	for _, p := range t.Permissions {
+		perm := &models.AccessTokenPermission{Namespace: p.Namespace}
+		for _, acc := range p.Access {
+			resource, repository := rbac.ParseRepositoryScopedResource(acc.Resource)
+			perm.Access = append(perm.Access, &models.Access{
+				Resource:   resource.String(),
+				Repository: repository,
+				Action:     acc.Action.String(),
+				Effect:     acc.Effect.String(),
+			})
+		}
+		m.Permissions = append(m.Permissions, perm)
+	}
+	return m
+}
@@ -82,7 +82,7 @@ func (a *artifactAPI) Prepare(ctx context.Context, _ string, params any) middlew
 }
 
 func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListArtifactsParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceArtifact); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionList, rbac.ResourceArtifact); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -124,7 +124,7 @@ func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListAr
 }
 
 func (a *artifactAPI) GetArtifact(ctx context.Context, params operation.GetArtifactParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifact); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionRead, rbac.ResourceArtifact); err != nil {
 		return a.SendError(ctx, err)
 	}
 	// set option
@@ -149,7 +149,7 @@ func (a *artifactAPI) GetArtifact(ctx context.Context, params operation.GetArtif
 }
 
 func (a *artifactAPI) DeleteArtifact(ctx context.Context, params operation.DeleteArtifactParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceArtifact); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionDelete, rbac.ResourceArtifact); err != nil {
 		return a.SendError(ctx, err)
 	}
 	artifact, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
@@ -163,7 +163,7 @@ func (a *artifactAPI) DeleteArtifact(ctx context.Context, params operation.Delet
 }
 
 func (a *artifactAPI) CopyArtifact(ctx context.Context, params operation.CopyArtifactParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionCreate, rbac.ResourceArtifact); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionCreate, rbac.ResourceArtifact); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -176,8 +176,8 @@ func (a *artifactAPI) CopyArtifact(ctx context.Context, params operation.CopyArt
 		return a.SendError(ctx, err)
 	}
 
-	srcPro, _ := utils.ParseRepository(srcRepo)
-	if err = a.RequireProjectAccess(ctx, srcPro, rbac.ActionRead, rbac.ResourceArtifact); err != nil {
+	srcPro, srcRepoName := utils.ParseRepository(srcRepo)
+	if err = a.RequireRepositoryAccess(ctx, srcPro, srcRepoName, rbac.ActionRead, rbac.ResourceArtifact); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -228,7 +228,7 @@ func parse(s string) (string, string, error) {
 }
 
 func (a *artifactAPI) CreateTag(ctx context.Context, params operation.CreateTagParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionCreate, rbac.ResourceTag); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionCreate, rbac.ResourceTag); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -278,7 +278,7 @@ func (a *artifactAPI) requireNonProxyCacheProject(ctx context.Context, name stri
 }
 
 func (a *artifactAPI) DeleteTag(ctx context.Context, params operation.DeleteTagParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceTag); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionDelete, rbac.ResourceTag); err != nil {
 		return a.SendError(ctx, err)
 	}
 	artifact, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName),
@@ -318,7 +318,7 @@ func (a *artifactAPI) DeleteTag(ctx context.Context, params operation.DeleteTagP
 }
 
 func (a *artifactAPI) ListTags(ctx context.Context, params operation.ListTagsParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceTag); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionList, rbac.ResourceTag); err != nil {
 		return a.SendError(ctx, err)
 	}
 	// set query
@@ -361,7 +361,7 @@ func (a *artifactAPI) ListTags(ctx context.Context, params operation.ListTagsPar
 }
 
 func (a *artifactAPI) ListAccessories(ctx context.Context, params operation.ListAccessoriesParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceAccessory); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionList, rbac.ResourceAccessory); err != nil {
 		return a.SendError(ctx, err)
 	}
 	// set query
@@ -397,7 +397,7 @@ func (a *artifactAPI) ListAccessories(ctx context.Context, params operation.List
 }
 
 func (a *artifactAPI) GetVulnerabilitiesAddition(ctx context.Context, params operation.GetVulnerabilitiesAdditionParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -439,7 +439,7 @@ func (a *artifactAPI) GetVulnerabilitiesAddition(ctx context.Context, params ope
 }
 
 func (a *artifactAPI) GetAddition(ctx context.Context, params operation.GetAdditionParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
 		return a.SendError(ctx, err)
 	}
 
@@ -464,7 +464,7 @@ func (a *artifactAPI) AddLabel(ctx context.Context, params operation.AddLabelPar
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
-	if err := a.RequireProjectAccess(ctx, projectID, rbac.ActionCreate, rbac.ResourceArtifactLabel); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, projectID, params.RepositoryName, rbac.ActionCreate, rbac.ResourceArtifactLabel); err != nil {
 		return a.SendError(ctx, err)
 	}
 	if err := a.RequireLabelInProject(ctx, projectID, params.Label.ID); err != nil {
@@ -481,7 +481,7 @@ func (a *artifactAPI) AddLabel(ctx context.Context, params operation.AddLabelPar
 }
 
 func (a *artifactAPI) RemoveLabel(ctx context.Context, params operation.RemoveLabelParams) middleware.Responder {
-	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceArtifactLabel); err != nil {
+	if err := a.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionDelete, rbac.ResourceArtifactLabel); err != nil {
 		return a.SendError(ctx, err)
 	}
 	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
@@ -76,9 +76,37 @@ func (b *BaseAPI) HasPermission(ctx context.Context, action rbac.Action, resourc
 
 // HasProjectPermission returns true, nil when the request has action permission on project subresource, and return false, error when the request does not have permission or an error occurs.
 func (b *BaseAPI) HasProjectPermission(ctx context.Context, projectIDOrName any, action rbac.Action, subresource ...rbac.Resource) (bool, error) {
+	projectID, found, err := b.resolveProjectID(ctx, projectIDOrName)
+	if err != nil || !found {
+		return false, err
+	}
+
+	resource := rbac_project.NewNamespace(projectID).Resource(subresource...)
+	return b.HasPermission(ctx, action, resource), nil
+}
+
+// HasRepositoryPermission returns true, nil when the request has action permission on the resource of the repository,
+// the permission may be granted for the whole project or only for the repositories matching the name pattern,
+// the repository is the name without the project name
+func (b *BaseAPI) HasRepositoryPermission(ctx context.Context, projectIDOrName any, repository string, action rbac.Action, resource rbac.Resource) (bool, error) {
+	projectID, found, err := b.resolveProjectID(ctx, projectIDOrName)
+	if err != nil || !found {
+		return false, err
+	}
+
+	s, err := b.GetSecurityContext(ctx)
+	if err != nil {
+		log.Warningf("security context not found")
+		return false, nil
+	}
+	return rbac_project.CanAccessRepository(ctx, s, projectID, repository, action, resource), nil
+}
+
+// resolveProjectID returns the ID of the project specified by ID or name, the ID of the project not found is 0
+func (b *BaseAPI) resolveProjectID(ctx context.Context, projectIDOrName any) (int64, bool, error) {
 	projectID, projectName, err := utils.ParseProjectIDOrName(projectIDOrName)
 	if err != nil {
-		return false, err
+		return 0, false, err
 	}
 
 	if projectName != "" {
@@ -88,19 +116,18 @@ func (b *BaseAPI) HasProjectPermission(ctx context.Context, projectIDOrName any,
 			if errors.IsNotFoundErr(err) {
 				p = &project.Project{}
 			} else {
-				return false, err
+				return 0, false, err
 			}
 		}
 		if p == nil {
 			log.Warningf("project %s not found", projectName)
-			return false, nil
+			return 0, false, nil
 		}
 
 		projectID = p.ProjectID
 	}
 
-	resource := rbac_project.NewNamespace(projectID).Resource(subresource...)
-	return b.HasPermission(ctx, action, resource), nil
+	return projectID, true, nil
 }
 
 // RequireProjectAccess checks the permission against the resources according to the context
@@ -113,6 +140,23 @@ func (b *BaseAPI) RequireProjectAccess(ctx context.Context, projectIDOrName any,
 	if has {
 		return nil
 	}
+	return b.accessDeniedError(ctx)
+}
+
+// RequireRepositoryAccess checks the permission against the resource of the repository according to the context
+// An error will be returned if it doesn't meet the requirement
+func (b *BaseAPI) RequireRepositoryAccess(ctx context.Context, projectIDOrName any, repository string, action rbac.Action, resource rbac.Resource) error {
+	has, err := b.HasRepositoryPermission(ctx, projectIDOrName, repository, action, resource)
+	if err != nil {
+		return err
+	}
+	if has {
+		return nil
+	}
+	return b.accessDeniedError(ctx)
+}
+
+func (b *BaseAPI) accessDeniedError(ctx context.Context) error {
 	secCtx, err := b.GetSecurityContext(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,210 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/execution"
+)
+
+const (
+	// the max count of the executions exported at once, the export matching more executions is rejected
+	executionExportLimit    = 10000
+	executionExportPageSize = 100
+)
+
+var executionCSVHeader = []string{"id", "vendor_type", "vendor_id", "trigger", "status", "status_message",
+	"start_time", "end_time", "duration_seconds", "task_count", "success_task_count", "error_task_count"}
+
+func newExecutionAPI() *executionAPI {
+	return &executionAPI{
+		execMgr: task.ExecMgr,
+	}
+}
+
+// the executions of all vendor types are explored with the permissions of the jobservice monitor
+type executionAPI struct {
+	BaseAPI
+	execMgr task.ExecutionManager
+}
+
+func (e *executionAPI) SearchExecutions(ctx context.Context, params operation.SearchExecutionsParams) middleware.Responder {
+	if err := e.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return e.SendError(ctx, err)
+	}
+	query, err := e.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	total, err := e.execMgr.Count(ctx, query)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	executions, err := e.execMgr.List(ctx, query)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	var payload []*models.Execution
+	for _, execution := range executions {
+		p, err := convertExecutionToPayload(execution)
+		if err != nil {
+			return e.SendError(ctx, err)
+		}
+		payload = append(payload, p)
+	}
+	return operation.NewSearchExecutionsOK().
+		WithXTotalCount(total).
+		WithLink(e.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (e *executionAPI) GetExecutionStats(ctx context.Context, params operation.GetExecutionStatsParams) middleware.Responder {
+	if err := e.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return e.SendError(ctx, err)
+	}
+	query, err := e.BuildQuery(ctx, params.Q, nil, nil, nil)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	stats, err := e.execMgr.Aggregate(ctx, query)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	var payload []*models.ExecutionStats
+	for _, s := range stats {
+		payload = append(payload, &models.ExecutionStats{
+			VendorType:   s.VendorType,
+			Total:        s.Total,
+			SuccessCount: s.SuccessCount,
+			ErrorCount:   s.ErrorCount,
+			StoppedCount: s.StoppedCount,
+			SuccessRate:  s.SuccessRate(),
+			AvgDuration:  s.AvgDuration,
+			P95Duration:  s.P95Duration,
+		})
+	}
+	return operation.NewGetExecutionStatsOK().WithPayload(payload)
+}
+
+func (e *executionAPI) ExportExecutions(ctx context.Context, params operation.ExportExecutionsParams) middleware.Responder {
+	if err := e.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return e.SendError(ctx, err)
+	}
+	query, err := e.BuildQuery(ctx, params.Q, params.Sort, nil, nil)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	// reject the query matching too many executions rather than truncating the exported file silently
+	total, err := e.execMgr.Count(ctx, query)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+	if total > executionExportLimit {
+		return e.SendError(ctx, errors.BadRequestError(nil).
+			WithMessagef("%d executions match the query, narrow it down to export at most %d executions", total, executionExportLimit))
+	}
+	// list the first page before writing to return the error properly, the rest are written page by page
+	query.PageSize = executionExportPageSize
+	query.PageNumber = 1
+	executions, err := e.execMgr.List(ctx, query)
+	if err != nil {
+		return e.SendError(ctx, err)
+	}
+
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "executions.csv"))
+		w.WriteHeader(http.StatusOK)
+		writer := csv.NewWriter(w)
+		if err := writer.Write(executionCSVHeader); err != nil {
+			log.Errorf("failed to write the header of the exported executions: %v", err)
+			return
+		}
+		// the executions created after counting are cut off at the limit
+		for count := 0; ; {
+			for _, execution := range executions {
+				if count >= executionExportLimit {
+					break
+				}
+				if err := writer.Write(toExecutionCSVRecord(execution)); err != nil {
+					log.Errorf("failed to write the exported execution %d: %v", execution.ID, err)
+					return
+				}
+				count++
+			}
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				log.Errorf("failed to flush the exported executions: %v", err)
+				return
+			}
+			if count >= executionExportLimit || len(executions) < executionExportPageSize {
+				return
+			}
+			query.PageNumber++
+			if executions, err = e.execMgr.List(ctx, query); err != nil {
+				log.Errorf("failed to list the executions of page %d to export: %v", query.PageNumber, err)
+				return
+			}
+		}
+	})
+}
+
+func toExecutionCSVRecord(execution *task.Execution) []string {
+	var startTime, endTime, duration string
+	if !execution.StartTime.IsZero() {
+		startTime = execution.StartTime.Format(time.RFC3339)
+	}
+	// the end time is set only when the execution is finished
+	if !execution.EndTime.IsZero() {
+		endTime = execution.EndTime.Format(time.RFC3339)
+		if !execution.EndTime.Before(execution.StartTime) {
+			duration = strconv.FormatFloat(execution.EndTime.Sub(execution.StartTime).Seconds(), 'f', 0, 64)
+		}
+	}
+	var taskCount, successCount, errorCount int64
+	if execution.Metrics != nil {
+		taskCount = execution.Metrics.TaskCount
+		successCount = execution.Metrics.SuccessTaskCount
+		errorCount = execution.Metrics.ErrorTaskCount
+	}
+	return []string{
+		strconv.FormatInt(execution.ID, 10),
+		execution.VendorType,
+		strconv.FormatInt(execution.VendorID, 10),
+		execution.Trigger,
+		execution.Status,
+		execution.StatusMessage,
+		startTime,
+		endTime,
+		duration,
+		strconv.FormatInt(taskCount, 10),
+		strconv.FormatInt(successCount, 10),
+		strconv.FormatInt(errorCount, 10),
+	}
+}
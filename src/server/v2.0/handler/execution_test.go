@@ -0,0 +1,51 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/task/dao"
+)
+
+func Test_toExecutionCSVRecord(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := &task.Execution{
+		ID:         1,
+		VendorType: "GARBAGE_COLLECTION",
+		Trigger:    "SCHEDULE",
+		Status:     "Success",
+		StartTime:  start,
+		EndTime:    start.Add(90 * time.Second),
+		Metrics:    &dao.Metrics{TaskCount: 2, SuccessTaskCount: 2},
+	}
+	assert.Equal(t, []string{"1", "GARBAGE_COLLECTION", "0", "SCHEDULE", "Success", "",
+		"2024-01-01T00:00:00Z", "2024-01-01T00:01:30Z", "90", "2", "2", "0"}, toExecutionCSVRecord(finished))
+
+	running := &task.Execution{
+		ID:         2,
+		VendorType: "REPLICATION",
+		VendorID:   3,
+		Trigger:    "MANUAL",
+		Status:     "Running",
+		StartTime:  start,
+	}
+	assert.Equal(t, []string{"2", "REPLICATION", "3", "MANUAL", "Running", "",
+		"2024-01-01T00:00:00Z", "", "", "0", "0", "0"}, toExecutionCSVRecord(running))
+}
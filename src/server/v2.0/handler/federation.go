@@ -0,0 +1,197 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/federation"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/federation/model"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/federation"
+)
+
+// the max length of the trust name, it's the size of the name column of the federation_trust table
+const maxTrustNameLength = 255
+
+func newFederationAPI() *federationAPI {
+	return &federationAPI{
+		federationCtl: federation.Ctl,
+	}
+}
+
+type federationAPI struct {
+	BaseAPI
+	federationCtl federation.Controller
+}
+
+func (f *federationAPI) ListFederationTrusts(ctx context.Context, params operation.ListFederationTrustsParams) middleware.Responder {
+	if err := f.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceFederationTrust); err != nil {
+		return f.SendError(ctx, err)
+	}
+	query, err := f.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	total, err := f.federationCtl.Count(ctx, query)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	trusts, err := f.federationCtl.List(ctx, query)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	var payload []*models.FederationTrust
+	for _, t := range trusts {
+		payload = append(payload, toFederationTrustModel(t))
+	}
+	return operation.NewListFederationTrustsOK().
+		WithXTotalCount(total).
+		WithLink(f.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (f *federationAPI) GetFederationTrust(ctx context.Context, params operation.GetFederationTrustParams) middleware.Responder {
+	if err := f.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceFederationTrust); err != nil {
+		return f.SendError(ctx, err)
+	}
+	t, err := f.federationCtl.Get(ctx, params.TrustID)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	return operation.NewGetFederationTrustOK().WithPayload(toFederationTrustModel(t))
+}
+
+func (f *federationAPI) CreateFederationTrust(ctx context.Context, params operation.CreateFederationTrustParams) middleware.Responder {
+	if err := f.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceFederationTrust); err != nil {
+		return f.SendError(ctx, err)
+	}
+	t, err := fromFederationTrustModel(params.Trust)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	id, err := f.federationCtl.Create(ctx, t)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateFederationTrustCreated().WithLocation(location)
+}
+
+func (f *federationAPI) UpdateFederationTrust(ctx context.Context, params operation.UpdateFederationTrustParams) middleware.Responder {
+	if err := f.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceFederationTrust); err != nil {
+		return f.SendError(ctx, err)
+	}
+	t, err := fromFederationTrustModel(params.Trust)
+	if err != nil {
+		return f.SendError(ctx, err)
+	}
+	t.ID = params.TrustID
+	if err := f.federationCtl.Update(ctx, t); err != nil {
+		return f.SendError(ctx, err)
+	}
+	return operation.NewUpdateFederationTrustOK()
+}
+
+func (f *federationAPI) DeleteFederationTrust(ctx context.Context, params operation.DeleteFederationTrustParams) middleware.Responder {
+	if err := f.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceFederationTrust); err != nil {
+		return f.SendError(ctx, err)
+	}
+	if err := f.federationCtl.Delete(ctx, params.TrustID); err != nil {
+		return f.SendError(ctx, err)
+	}
+	return operation.NewDeleteFederationTrustOK()
+}
+
+// fromFederationTrustModel validates the trust in the request, only the project level permissions are allowed
+func fromFederationTrustModel(m *models.FederationTrust) (*model.Trust, error) {
+	if m == nil {
+		return nil, errors.BadRequestError(nil).WithMessage("the trust is required")
+	}
+	name := strings.TrimSpace(m.Name)
+	if len(name) == 0 || len(name) > maxTrustNameLength {
+		return nil, errors.BadRequestError(nil).WithMessagef("the length of the trust name must be between 1 and %d", maxTrustNameLength)
+	}
+
+	policies := rbac.GetPermissionProvider().GetPermissions(rbac.ScopeProject)
+	t := &model.Trust{
+		Name:        name,
+		Description: m.Description,
+		Issuer:      strings.TrimSpace(m.Issuer),
+		Audience:    strings.TrimSpace(m.Audience),
+		Conditions:  m.Conditions,
+		Disabled:    m.Disabled,
+	}
+	for _, p := range m.Permissions {
+		if p == nil {
+			return nil, errors.BadRequestError(nil).WithMessage("bad request empty permission")
+		}
+		perm := &model.Permission{Namespace: strings.TrimSpace(p.Namespace)}
+		for _, acc := range p.Access {
+			if acc == nil {
+				return nil, errors.BadRequestError(nil).WithMessage("bad request empty access")
+			}
+			if !containsAccess(policies, acc) {
+				return nil, errors.BadRequestError(nil).WithMessagef("bad request permission: %s:%s", acc.Resource, acc.Action)
+			}
+			if err := validateAccessRepository(acc); err != nil {
+				return nil, err
+			}
+			perm.Access = append(perm.Access, &types.Policy{
+				Resource: accessResource(acc),
+				Action:   types.Action(acc.Action),
+				Effect:   types.Effect(acc.Effect),
+			})
+		}
+		t.Permissions = append(t.Permissions, perm)
+	}
+	return t, nil
+}
+
+func toFederationTrustModel(t *model.Trust) *models.FederationTrust {
+	m := &models.FederationTrust{
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		Issuer:       t.Issuer,
+		Audience:     t.Audience,
+		Conditions:   t.Conditions,
+		Disabled:     t.Disabled,
+		CreationTime: strfmt.DateTime(t.CreationTime),
+		UpdateTime:   strfmt.DateTime(t.UpdateTime),
+	}
+	for _, p := range t.Permissions {
+		perm := &models.FederationPermission{Namespace: p.Namespace}
+		for _, acc := range p.Access {
+			resource, repository := rbac.ParseRepositoryScopedResource(acc.Resource)
+			perm.Access = append(perm.Access, &models.Access{
+				Resource:   resource.String(),
+				Repository: repository,
+				Action:     acc.Action.String(),
+				Effect:     acc.Effect.String(),
+			})
+		}
+		m.Permissions = append(m.Permissions, perm)
+	}
+	return m
+}
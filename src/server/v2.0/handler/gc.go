@@ -270,11 +270,12 @@ func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) m
 	if len(tasks) == 0 {
 		return g.SendError(ctx, errors.New(nil).WithCode(errors.NotFoundCode).WithMessagef("garbage collection %d log is not found", params.GCID))
 	}
-	log, err := g.gcCtr.GetTaskLog(ctx, tasks[0].ID)
+	logQuery := buildLogQuery(params.Level, params.Q, params.Format)
+	log, err := g.gcCtr.GetTaskLog(ctx, tasks[0].ID, logQuery)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
-	return operation.NewGetGCLogOK().WithPayload(string(log))
+	return operation.NewGetGCLogOK().WithContentType(logContentType(logQuery)).WithPayload(string(log))
 }
 
 func (g *gcAPI) StopGC(ctx context.Context, params operation.StopGCParams) middleware.Responder {
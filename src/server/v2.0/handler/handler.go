@@ -69,6 +69,14 @@ func New() http.Handler {
 		JobserviceAPI:         newJobServiceAPI(),
 		ScheduleAPI:           newScheduleAPI(),
 		SecurityhubAPI:        newSecurityAPI(),
+		SbomAPI:               newSBOMAPI(),
+		RoleAPI:               newRoleAPI(),
+		FederationAPI:         newFederationAPI(),
+		AccesstokenAPI:        newAccessTokenAPI(),
+		ProjectgroupAPI:       newProjectGroupAPI(),
+		LoginlockAPI:          newLoginLockAPI(),
+		WorkflowAPI:           newWorkflowAPI(),
+		ExecutionAPI:          newExecutionAPI(),
 		PermissionsAPI:        newPermissionsAPIAPI(),
 	})
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/jobmonitor"
 	jm "github.com/goharbor/harbor/src/pkg/jobmonitor"
+	maintenanceModel "github.com/goharbor/harbor/src/pkg/maintenance/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/jobservice"
 )
@@ -158,10 +159,12 @@ func toQueueResponse(queues []*jm.Queue) []*models.JobQueue {
 	result := make([]*models.JobQueue, 0)
 	for _, q := range queues {
 		result = append(result, &models.JobQueue{
-			JobType: q.JobType,
-			Count:   q.Count,
-			Latency: q.Latency,
-			Paused:  q.Paused,
+			JobType:        q.JobType,
+			Count:          q.Count,
+			Latency:        q.Latency,
+			Paused:         q.Paused,
+			Priority:       q.Priority,
+			MaxConcurrency: q.MaxConcurrency,
 		})
 	}
 	return result
@@ -197,13 +200,157 @@ func (j *jobServiceAPI) ActionPendingJobs(ctx context.Context, params jobservice
 	return jobservice.NewActionPendingJobsOK()
 }
 
+func (j *jobServiceAPI) UpdateJobQueuePolicy(ctx context.Context, params jobservice.UpdateJobQueuePolicyParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	if params.Policy == nil || params.Policy.Priority == nil || params.Policy.MaxConcurrency == nil {
+		return j.SendError(ctx, errors.BadRequestError(nil).WithMessage("the priority and the max concurrency are required"))
+	}
+	if err := j.jobCtr.UpdateQueuePolicy(ctx, strings.ToUpper(params.JobType), *params.Policy.Priority, *params.Policy.MaxConcurrency); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewUpdateJobQueuePolicyOK()
+}
+
 func (j *jobServiceAPI) ActionGetJobLog(ctx context.Context, params jobservice.ActionGetJobLogParams) middleware.Responder {
 	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
 		return j.SendError(ctx, err)
 	}
-	log, err := j.jobCtr.GetJobLog(ctx, params.JobID)
+	logQuery := buildLogQuery(params.Level, params.Q, params.Format)
+	log, err := j.jobCtr.GetJobLog(ctx, params.JobID, logQuery)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewActionGetJobLogOK().WithContentType(logContentType(logQuery)).WithPayload(string(log))
+}
+
+func (j *jobServiceAPI) ActionExecution(ctx context.Context, params jobservice.ActionExecutionParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	var err error
+	switch strings.ToLower(params.ActionRequest.Action) {
+	case "pause":
+		err = j.jobCtr.PauseExecution(ctx, params.ExecutionID)
+	case "resume":
+		err = j.jobCtr.ResumeExecution(ctx, params.ExecutionID)
+	default:
+		err = errors.BadRequestError(nil).WithMessage("the action is not supported")
+	}
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewActionExecutionOK()
+}
+
+func (j *jobServiceAPI) ListMaintenanceWindows(ctx context.Context, params jobservice.ListMaintenanceWindowsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	total, err := j.jobCtr.CountMaintenanceWindows(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	windows, err := j.jobCtr.ListMaintenanceWindows(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	now := time.Now()
+	var payload []*models.MaintenanceWindow
+	for _, window := range windows {
+		payload = append(payload, toMaintenanceWindowModel(window, now))
+	}
+	return jobservice.NewListMaintenanceWindowsOK().
+		WithXTotalCount(total).
+		WithLink(j.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (j *jobServiceAPI) CreateMaintenanceWindow(ctx context.Context, params jobservice.CreateMaintenanceWindowParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	if params.Window == nil {
+		return j.SendError(ctx, errors.BadRequestError(nil).WithMessage("the maintenance window is required"))
+	}
+	window := &maintenanceModel.Window{}
+	fromMaintenanceWindowModel(params.Window, window)
+	id, err := j.jobCtr.CreateMaintenanceWindow(ctx, window)
 	if err != nil {
 		return j.SendError(ctx, err)
 	}
-	return jobservice.NewActionGetJobLogOK().WithContentType("text/plain").WithPayload(string(log))
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return jobservice.NewCreateMaintenanceWindowCreated().WithLocation(location)
+}
+
+func (j *jobServiceAPI) GetMaintenanceWindow(ctx context.Context, params jobservice.GetMaintenanceWindowParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	window, err := j.jobCtr.GetMaintenanceWindow(ctx, params.WindowID)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewGetMaintenanceWindowOK().WithPayload(toMaintenanceWindowModel(window, time.Now()))
+}
+
+func (j *jobServiceAPI) UpdateMaintenanceWindow(ctx context.Context, params jobservice.UpdateMaintenanceWindowParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	if params.Window == nil {
+		return j.SendError(ctx, errors.BadRequestError(nil).WithMessage("the maintenance window is required"))
+	}
+	window, err := j.jobCtr.GetMaintenanceWindow(ctx, params.WindowID)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	fromMaintenanceWindowModel(params.Window, window)
+	if err := j.jobCtr.UpdateMaintenanceWindow(ctx, window); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewUpdateMaintenanceWindowOK()
+}
+
+func (j *jobServiceAPI) DeleteMaintenanceWindow(ctx context.Context, params jobservice.DeleteMaintenanceWindowParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	if err := j.jobCtr.DeleteMaintenanceWindow(ctx, params.WindowID); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewDeleteMaintenanceWindowOK()
+}
+
+func fromMaintenanceWindowModel(m *models.MaintenanceWindow, window *maintenanceModel.Window) {
+	window.Name = m.Name
+	window.Description = m.Description
+	window.SetJobTypes(m.JobTypes)
+	window.Cron = m.Cron
+	window.Duration = int(m.Duration)
+	window.StartTime = time.Time(m.StartTime)
+	window.EndTime = time.Time(m.EndTime)
+	window.Enabled = m.Enabled
+}
+
+func toMaintenanceWindowModel(window *maintenanceModel.Window, now time.Time) *models.MaintenanceWindow {
+	return &models.MaintenanceWindow{
+		ID:           window.ID,
+		Name:         window.Name,
+		Description:  window.Description,
+		JobTypes:     window.ListJobTypes(),
+		Cron:         window.Cron,
+		Duration:     int64(window.Duration),
+		StartTime:    strfmt.DateTime(window.StartTime),
+		EndTime:      strfmt.DateTime(window.EndTime),
+		Enabled:      window.Enabled,
+		Active:       window.IsActive(now),
+		CreationTime: strfmt.DateTime(window.CreationTime),
+		UpdateTime:   strfmt.DateTime(window.UpdateTime),
+	}
 }
@@ -0,0 +1,65 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/core/auth"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/loginlock"
+)
+
+func newLoginLockAPI() *loginLockAPI {
+	return &loginLockAPI{}
+}
+
+// the login locks are managed with the permissions of the users
+type loginLockAPI struct {
+	BaseAPI
+}
+
+func (l *loginLockAPI) ListLoginLocks(ctx context.Context, _ operation.ListLoginLocksParams) middleware.Responder {
+	if err := l.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceUser); err != nil {
+		return l.SendError(ctx, err)
+	}
+	locks, err := auth.ListLocks(ctx)
+	if err != nil {
+		return l.SendError(ctx, err)
+	}
+	var payload []*models.LoginLock
+	for _, lock := range locks {
+		payload = append(payload, &models.LoginLock{
+			Kind:        lock.Kind,
+			Name:        lock.Name,
+			LockedUntil: strfmt.DateTime(lock.ExpiresAt),
+		})
+	}
+	return operation.NewListLoginLocksOK().WithPayload(payload)
+}
+
+func (l *loginLockAPI) UnlockLogin(ctx context.Context, params operation.UnlockLoginParams) middleware.Responder {
+	if err := l.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceUser); err != nil {
+		return l.SendError(ctx, err)
+	}
+	if err := auth.Unlock(ctx, params.Kind, params.Name); err != nil {
+		return l.SendError(ctx, err)
+	}
+	return operation.NewUnlockLoginOK()
+}
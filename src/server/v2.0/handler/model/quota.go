@@ -60,9 +60,39 @@ func (q *Quota) ToSwagger(ctx context.Context) *models.Quota {
 		Used:         NewResourceList(used).ToSwagger(),
 		CreationTime: strfmt.DateTime(q.CreationTime),
 		UpdateTime:   strfmt.DateTime(q.UpdateTime),
+		State:        q.stateToSwagger(ctx),
 	}
 }
 
+func (q *Quota) stateToSwagger(ctx context.Context) *models.QuotaState {
+	if q.State == nil {
+		return nil
+	}
+
+	levels, err := q.State.GetWarningLevels()
+	if err != nil {
+		fields := log.Fields{"quota_id": q.ID, "error": err}
+		log.G(ctx).WithFields(fields).Warningf("failed to get warning levels from quota state")
+	}
+
+	state := &models.QuotaState{WarningLevels: make(map[string]int64, len(levels))}
+	for resource, level := range levels {
+		state.WarningLevels[string(resource)] = int64(level)
+	}
+
+	if q.State.InOverage() {
+		start := strfmt.DateTime(q.State.OverageStartTime)
+		state.OverageStartTime = &start
+	}
+
+	if !q.State.GraceEndTime.IsZero() {
+		end := strfmt.DateTime(q.State.GraceEndTime)
+		state.GraceEndTime = &end
+	}
+
+	return state
+}
+
 // NewQuota new quota instance
 func NewQuota(quota *quota.Quota) *Quota {
 	return &Quota{Quota: quota}
@@ -17,9 +17,11 @@ package model
 import (
 	"github.com/go-openapi/strfmt"
 
+	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/robot"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 )
 
@@ -36,10 +38,18 @@ func (r *Robot) ToSwagger() *models.Robot {
 		if err := lib.JSONCopy(temp, p); err != nil {
 			log.Warningf("failed to do JSONCopy on RobotPermission, error: %v", err)
 		}
+		for _, acc := range temp.Access {
+			if acc == nil {
+				continue
+			}
+			resource, repository := rbac.ParseRepositoryScopedResource(types.Resource(acc.Resource))
+			acc.Resource = resource.String()
+			acc.Repository = repository
+		}
 		perms = append(perms, temp)
 	}
 
-	return &models.Robot{
+	m := &models.Robot{
 		ID:           r.ID,
 		Name:         r.Name,
 		Description:  r.Description,
@@ -53,7 +63,14 @@ func (r *Robot) ToSwagger() *models.Robot {
 		CreationTime: strfmt.DateTime(r.CreationTime),
 		UpdateTime:   strfmt.DateTime(r.UpdateTime),
 		Permissions:  perms,
+
+		PreviousSecretExpiresAt: r.PreviousSecretExpiresAt,
+		LastUsedIP:              r.LastUsedIP,
+	}
+	if !r.LastUsedTime.IsZero() {
+		m.LastUsedTime = strfmt.DateTime(r.LastUsedTime)
 	}
+	return m
 }
 
 // NewRobot ...
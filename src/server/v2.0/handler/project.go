@@ -232,10 +232,16 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 	}
 
 	// StorageLimit is provided in the request body and it's valid,
-	// create the quota for the project
+	// create the quota for the project with the default limits of the other resources
 	if req.StorageLimit != nil {
+		driver, err := quota.Driver(ctx, quota.ProjectReference)
+		if err != nil {
+			return a.SendError(ctx, err)
+		}
+		hardLimits := driver.HardLimits(ctx)
+		hardLimits[types.ResourceStorage] = *req.StorageLimit
+
 		referenceID := quota.ReferenceID(projectID)
-		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageLimit}
 		if _, err := a.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
 			return a.SendError(ctx, fmt.Errorf("failed to create quota for project: %v", err))
 		}
@@ -586,6 +592,9 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 			return a.SendError(ctx, err)
 		}
 	}
+	if err := validateQuotaPolicy(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
+	}
 	if err := lib.JSONCopy(&p.Metadata, params.Project.Metadata); err != nil {
 		log.Warningf("failed to call JSONCopy on project metadata when UpdateProject, error: %v", err)
 	}
@@ -694,6 +703,69 @@ func (a *projectAPI) SetScannerOfProject(ctx context.Context, params operation.S
 	return operation.NewSetScannerOfProjectOK()
 }
 
+func (a *projectAPI) GetScannersOfProject(ctx context.Context, params operation.GetScannersOfProjectParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	scanners, err := a.scannerCtl.GetRegistrationsByProject(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	payload := make([]*models.ScannerRegistration, len(scanners))
+	for i, s := range scanners {
+		if err := a.scannerCtl.RetrieveCap(ctx, s); err != nil {
+			log.Warningf(scanner.RetrieveCapFailMsg, err)
+		}
+		payload[i] = model.NewScannerRegistration(s).ToSwagger(ctx)
+	}
+
+	return operation.NewGetScannersOfProjectOK().WithPayload(payload)
+}
+
+func (a *projectAPI) SetScannersOfProject(ctx context.Context, params operation.SetScannersOfProjectParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if len(params.Payload.Uuids) == 0 {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage("at least one scanner is required"))
+	}
+
+	for _, uuid := range params.Payload.Uuids {
+		if !a.scannerCtl.RegistrationExists(ctx, uuid) {
+			return a.SendError(ctx, errors.BadRequestError(nil).WithMessagef("scanner %s not found", uuid))
+		}
+	}
+
+	if err := a.scannerCtl.SetRegistrationsByProject(ctx, p.ProjectID, params.Payload.Uuids); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewSetScannersOfProjectOK()
+}
+
 func (a *projectAPI) ListArtifactsOfProject(ctx context.Context, params operation.ListArtifactsOfProjectParams) middleware.Responder {
 	if err := a.RequireAuthenticated(ctx); err != nil {
 		return a.SendError(ctx, err)
@@ -803,6 +875,10 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 		return errors.BadRequestError(fmt.Errorf("the retention_id in the request's payload when creating a project should be omitted, alternatively passing an empty string"))
 	}
 
+	if err := validateQuotaPolicy(req.Metadata); err != nil {
+		return err
+	}
+
 	if req.RegistryID != nil {
 		if *req.RegistryID <= 0 {
 			return errors.BadRequestError(fmt.Errorf("%d is invalid value of registry_id, it should be geater than 0", *req.RegistryID))
@@ -875,6 +951,31 @@ func validateProxyCacheRepositoryFilter(metadata *models.ProjectMetadata) error
 	return nil
 }
 
+// validateQuotaPolicy validates and normalizes the quota warning thresholds, overage percent and grace period in the metadata
+func validateQuotaPolicy(metadata *models.ProjectMetadata) error {
+	if metadata == nil {
+		return nil
+	}
+
+	for key, value := range map[string]*string{
+		pkgModels.ProMetaQuotaWarningThresholds: metadata.QuotaWarningThresholds,
+		pkgModels.ProMetaQuotaOveragePercent:    metadata.QuotaOveragePercent,
+		pkgModels.ProMetaQuotaGracePeriodDays:   metadata.QuotaGracePeriodDays,
+	} {
+		if value == nil {
+			continue
+		}
+
+		v, err := validateQuotaPolicyMeta(key, *value)
+		if err != nil {
+			return err
+		}
+		*value = v
+	}
+
+	return nil
+}
+
 func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project) error {
 	if secCtx, ok := security.FromContext(ctx); ok {
 		if sc, ok := secCtx.(*local.SecurityContext); ok {
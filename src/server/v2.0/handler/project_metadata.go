@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/pattern"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	pkgquota "github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/project_metadata"
 )
@@ -170,6 +171,13 @@ func (p *projectMetadataAPI) validate(ctx context.Context, projectID int64, meta
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid value: %s", value)
 		}
 		metas[proModels.ProMetaSeverity] = strings.ToLower(severity.String())
+	case proModels.ProMetaScannerGate:
+		gate := strings.ToLower(value)
+		if gate != proModels.ScannerGateAny && gate != proModels.ScannerGateAll {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+				WithMessagef("invalid value: %s, must be %q or %q", value, proModels.ScannerGateAny, proModels.ScannerGateAll)
+		}
+		metas[proModels.ProMetaScannerGate] = gate
 	case proModels.ProMetaProxySpeed:
 		v, err := strconv.ParseInt(value, 10, 32)
 		if err != nil {
@@ -257,8 +265,50 @@ func (p *projectMetadataAPI) validate(ctx context.Context, projectID int64, meta
 			}
 		}
 		metas[proModels.ProMetaProxyCacheFilterKind] = value
+	case proModels.ProMetaQuotaWarningThresholds, proModels.ProMetaQuotaOveragePercent, proModels.ProMetaQuotaGracePeriodDays:
+		v, err := validateQuotaPolicyMeta(key, value)
+		if err != nil {
+			return nil, err
+		}
+		metas[key] = v
 	default:
 		return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessagef("invalid key: %s", key)
 	}
 	return metas, nil
 }
+
+// validateQuotaPolicyMeta validates the value of the quota policy metadata and returns the normalized one
+func validateQuotaPolicyMeta(key, value string) (string, error) {
+	// empty value disables the policy
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+
+	switch key {
+	case proModels.ProMetaQuotaWarningThresholds:
+		thresholds, err := pkgquota.ParseWarningThresholds(value)
+		if err != nil {
+			return "", errors.BadRequestError(nil).WithMessagef("invalid value for %s: %v", key, err)
+		}
+
+		var parts []string
+		for _, threshold := range thresholds {
+			parts = append(parts, strconv.Itoa(threshold))
+		}
+		return strings.Join(parts, ","), nil
+	case proModels.ProMetaQuotaOveragePercent, proModels.ProMetaQuotaGracePeriodDays:
+		upper := 100
+		if key == proModels.ProMetaQuotaGracePeriodDays {
+			upper = 365
+		}
+
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || v > upper {
+			return "", errors.BadRequestError(nil).
+				WithMessagef("invalid value for %s: %s, it should be an integer in the range of 0 to %d", key, value, upper)
+		}
+		return strconv.Itoa(v), nil
+	}
+
+	return value, nil
+}
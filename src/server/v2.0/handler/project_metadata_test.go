@@ -100,6 +100,36 @@ func TestValidate(t *testing.T) {
 			metas:     map[string]string{proModels.ProMetaMaxUpstreamConn: "30"},
 			expectErr: false,
 		},
+		{
+			name:      "quota warning thresholds",
+			metas:     map[string]string{proModels.ProMetaQuotaWarningThresholds: "95,75,90"},
+			expectErr: false,
+		},
+		{
+			name:      "quota warning thresholds out of range",
+			metas:     map[string]string{proModels.ProMetaQuotaWarningThresholds: "75,120"},
+			expectErr: true,
+		},
+		{
+			name:      "quota overage percent",
+			metas:     map[string]string{proModels.ProMetaQuotaOveragePercent: "10"},
+			expectErr: false,
+		},
+		{
+			name:      "quota overage percent invalid",
+			metas:     map[string]string{proModels.ProMetaQuotaOveragePercent: "-1"},
+			expectErr: true,
+		},
+		{
+			name:      "quota grace period days",
+			metas:     map[string]string{proModels.ProMetaQuotaGracePeriodDays: "7"},
+			expectErr: false,
+		},
+		{
+			name:      "quota grace period days invalid",
+			metas:     map[string]string{proModels.ProMetaQuotaGracePeriodDays: "seven"},
+			expectErr: true,
+		},
 		{
 			name:      "Unsupported key",
 			metas:     map[string]string{"unsupported_key": "value"},
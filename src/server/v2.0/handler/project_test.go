@@ -122,6 +122,34 @@ func (suite *ProjectTestSuite) TestGetScannerOfProject() {
 	}
 }
 
+func (suite *ProjectTestSuite) TestGetScannersOfProject() {
+	times := 2
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(times)
+
+	{
+		// get project failed
+		mock.OnAnything(suite.projectCtl, "Get").Return(nil, fmt.Errorf("failed to get project")).Once()
+
+		res, err := suite.Get("/projects/1/scanners")
+		suite.NoError(err)
+		suite.Equal(500, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.projectCtl, "Get").Return(suite.project, nil).Once()
+		mock.OnAnything(suite.scannerCtl, "GetRegistrationsByProject").Return([]*scanner.Registration{suite.reg}, nil).Once()
+		mock.OnAnything(suite.scannerCtl, "RetrieveCap").Return(nil).Once()
+		var scanners []*scanner.Registration
+		res, err := suite.GetJSON("/projects/1/scanners", &scanners)
+		suite.NoError(err)
+		suite.Equal(200, res.StatusCode)
+		if suite.Len(scanners, 1) {
+			suite.Equal(suite.reg.UUID, scanners[0].UUID)
+		}
+	}
+}
+
 func (suite *ProjectTestSuite) TestListScannerCandidatesOfProject() {
 	times := 4
 	suite.Security.On("IsAuthenticated").Return(true).Times(times)
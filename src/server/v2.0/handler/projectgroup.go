@@ -0,0 +1,184 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/projectgroup"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/projectgroup/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/projectgroup"
+)
+
+func newProjectGroupAPI() *projectGroupAPI {
+	return &projectGroupAPI{
+		groupCtl: projectgroup.Ctl,
+	}
+}
+
+// the project groups share the storage quota among the projects, they are managed with the permissions of the quotas
+type projectGroupAPI struct {
+	BaseAPI
+	groupCtl projectgroup.Controller
+}
+
+func (p *projectGroupAPI) ListProjectGroups(ctx context.Context, params operation.ListProjectGroupsParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	total, err := p.groupCtl.Count(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	groups, err := p.groupCtl.List(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	var payload []*models.ProjectGroup
+	for _, g := range groups {
+		payload = append(payload, toProjectGroupModel(g))
+	}
+	return operation.NewListProjectGroupsOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (p *projectGroupAPI) CreateProjectGroup(ctx context.Context, params operation.CreateProjectGroupParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Group == nil || params.Group.StorageLimit == nil {
+		return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the storage limit of the project group is required"))
+	}
+	id, err := p.groupCtl.Create(ctx, &model.ProjectGroup{
+		Name:        params.Group.Name,
+		Description: params.Group.Description,
+	}, *params.Group.StorageLimit)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateProjectGroupCreated().WithLocation(location)
+}
+
+func (p *projectGroupAPI) GetProjectGroup(ctx context.Context, params operation.GetProjectGroupParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	g, err := p.groupCtl.Get(ctx, params.GroupID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetProjectGroupOK().WithPayload(toProjectGroupModel(g))
+}
+
+func (p *projectGroupAPI) UpdateProjectGroup(ctx context.Context, params operation.UpdateProjectGroupParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Group == nil {
+		return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the project group is required"))
+	}
+	g, err := p.groupCtl.Get(ctx, params.GroupID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Group.Name != "" {
+		g.Name = params.Group.Name
+	}
+	g.Description = params.Group.Description
+	if err := p.groupCtl.Update(ctx, g, params.Group.StorageLimit); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewUpdateProjectGroupOK()
+}
+
+func (p *projectGroupAPI) DeleteProjectGroup(ctx context.Context, params operation.DeleteProjectGroupParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if err := p.groupCtl.Delete(ctx, params.GroupID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewDeleteProjectGroupOK()
+}
+
+func (p *projectGroupAPI) AddProjectGroupMember(ctx context.Context, params operation.AddProjectGroupMemberParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Member == nil || params.Member.ProjectID <= 0 {
+		return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the project ID is required"))
+	}
+	if err := p.groupCtl.AddProject(ctx, params.GroupID, params.Member.ProjectID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewAddProjectGroupMemberOK()
+}
+
+func (p *projectGroupAPI) RemoveProjectGroupMember(ctx context.Context, params operation.RemoveProjectGroupMemberParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if err := p.groupCtl.RemoveProject(ctx, params.GroupID, params.ProjectID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewRemoveProjectGroupMemberOK()
+}
+
+func (p *projectGroupAPI) GetProjectGroupUsage(ctx context.Context, params operation.GetProjectGroupUsageParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceQuota); err != nil {
+		return p.SendError(ctx, err)
+	}
+	usage, err := p.groupCtl.Usage(ctx, params.GroupID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	payload := &models.ProjectGroupUsage{
+		StorageLimit: usage.Hard,
+		StorageUsed:  usage.Used,
+	}
+	for _, pu := range usage.Projects {
+		payload.Projects = append(payload.Projects, &models.ProjectGroupProjectUsage{
+			ProjectID:   pu.ProjectID,
+			ProjectName: pu.ProjectName,
+			StorageUsed: pu.Used,
+		})
+	}
+	return operation.NewGetProjectGroupUsageOK().WithPayload(payload)
+}
+
+func toProjectGroupModel(g *model.ProjectGroup) *models.ProjectGroup {
+	return &models.ProjectGroup{
+		ID:           g.ID,
+		Name:         g.Name,
+		Description:  g.Description,
+		CreationTime: strfmt.DateTime(g.CreationTime),
+		UpdateTime:   strfmt.DateTime(g.UpdateTime),
+	}
+}
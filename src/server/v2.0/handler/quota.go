@@ -46,7 +46,7 @@ func (qa *quotaAPI) GetQuota(ctx context.Context, params operation.GetQuotaParam
 		return qa.SendError(ctx, err)
 	}
 
-	quota, err := qa.quotaCtl.Get(ctx, params.ID, quota.WithReferenceObject())
+	quota, err := qa.quotaCtl.Get(ctx, params.ID, quota.WithReferenceObject(), quota.WithState())
 	if err != nil {
 		return qa.SendError(ctx, err)
 	}
@@ -73,7 +73,7 @@ func (qa *quotaAPI) ListQuotas(ctx context.Context, params operation.ListQuotasP
 		return qa.SendError(ctx, err)
 	}
 
-	quotas, err := qa.quotaCtl.List(ctx, query, quota.WithReferenceObject())
+	quotas, err := qa.quotaCtl.List(ctx, query, quota.WithReferenceObject(), quota.WithState())
 	if err != nil {
 		return qa.SendError(ctx, err)
 	}
@@ -103,7 +103,11 @@ func (qa *quotaAPI) UpdateQuota(ctx context.Context, params operation.UpdateQuot
 		return qa.SendError(ctx, err)
 	}
 
-	hard := make(types.ResourceList, len(params.Hard.Hard))
+	// the resources not in the request keep their hard limits
+	hard, err := q.GetHard()
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
 	for name, value := range params.Hard.Hard {
 		hard[types.ResourceName(name)] = value
 	}
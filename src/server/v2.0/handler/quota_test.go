@@ -118,7 +118,7 @@ func (suite *QuotaTestSuite) TestGetQuota() {
 
 	{
 		// get quota failed
-		mock.OnAnything(suite.quotaCtl, "Get").Return(nil, fmt.Errorf("failed to get quota")).Once()
+		suite.quotaCtl.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to get quota")).Once()
 
 		res, err := suite.Get("/quotas/1")
 		suite.NoError(err)
@@ -127,7 +127,7 @@ func (suite *QuotaTestSuite) TestGetQuota() {
 
 	{
 		// quota not found
-		mock.OnAnything(suite.quotaCtl, "Get").Return(nil, errors.NotFoundError(nil)).Once()
+		suite.quotaCtl.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil)).Once()
 
 		var quota map[string]any
 		res, err := suite.GetJSON("/quotas/1", &quota)
@@ -137,7 +137,7 @@ func (suite *QuotaTestSuite) TestGetQuota() {
 
 	{
 		// quota found
-		mock.OnAnything(suite.quotaCtl, "Get").Return(suite.quota, nil).Once()
+		suite.quotaCtl.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(suite.quota, nil).Once()
 
 		var quota map[string]any
 		res, err := suite.GetJSON("/quotas/1", &quota)
@@ -164,7 +164,7 @@ func (suite *QuotaTestSuite) TestListQuotas() {
 	{
 		// list quotas failed
 		mock.OnAnything(suite.quotaCtl, "Count").Return(int64(1), nil).Once()
-		mock.OnAnything(suite.quotaCtl, "List").Return(nil, fmt.Errorf("failed to list quotas")).Once()
+		suite.quotaCtl.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to list quotas")).Once()
 
 		res, err := suite.Get("/quotas")
 		suite.NoError(err)
@@ -174,7 +174,7 @@ func (suite *QuotaTestSuite) TestListQuotas() {
 	{
 		// quotas not found
 		mock.OnAnything(suite.quotaCtl, "Count").Return(int64(0), nil).Once()
-		mock.OnAnything(suite.quotaCtl, "List").Return(nil, nil).Once()
+		suite.quotaCtl.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
 
 		var quotas []any
 		res, err := suite.GetJSON("/quotas", &quotas)
@@ -186,7 +186,7 @@ func (suite *QuotaTestSuite) TestListQuotas() {
 	{
 		// quotas found
 		mock.OnAnything(suite.quotaCtl, "Count").Return(int64(3), nil).Once()
-		mock.OnAnything(suite.quotaCtl, "List").Return([]*quota.Quota{suite.quota}, nil).Once()
+		suite.quotaCtl.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*quota.Quota{suite.quota}, nil).Once()
 
 		var quotas []any
 		res, err := suite.GetJSON("/quotas?page_size=1&page=2", &quotas)
@@ -439,11 +439,12 @@ func (r *replicationAPI) GetReplicationLog(ctx context.Context, params operation
 			WithCode(errors.NotFoundCode).
 			WithMessagef("execution %d contains no task with ID %d", params.ID, params.TaskID))
 	}
-	log, err := r.ctl.GetTaskLog(ctx, params.TaskID)
+	logQuery := buildLogQuery(params.Level, params.Q, params.Format)
+	log, err := r.ctl.GetTaskLog(ctx, params.TaskID, logQuery)
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
-	return operation.NewGetReplicationLogOK().WithContentType("text/plain").WithPayload(string(log))
+	return operation.NewGetReplicationLogOK().WithContentType(logContentType(logQuery)).WithPayload(string(log))
 }
 
 func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPolicy {
@@ -200,7 +200,7 @@ func (r *repositoryAPI) ListRepositories(ctx context.Context, params operation.L
 }
 
 func (r *repositoryAPI) GetRepository(ctx context.Context, params operation.GetRepositoryParams) middleware.Responder {
-	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceRepository); err != nil {
+	if err := r.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionRead, rbac.ResourceRepository); err != nil {
 		return r.SendError(ctx, err)
 	}
 	repository, err := r.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
@@ -226,7 +226,7 @@ func (r *repositoryAPI) assembleRepository(ctx context.Context, repository *mode
 }
 
 func (r *repositoryAPI) UpdateRepository(ctx context.Context, params operation.UpdateRepositoryParams) middleware.Responder {
-	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionUpdate, rbac.ResourceRepository); err != nil {
+	if err := r.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionUpdate, rbac.ResourceRepository); err != nil {
 		return r.SendError(ctx, err)
 	}
 	repository, err := r.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
@@ -244,7 +244,7 @@ func (r *repositoryAPI) UpdateRepository(ctx context.Context, params operation.U
 }
 
 func (r *repositoryAPI) DeleteRepository(ctx context.Context, params operation.DeleteRepositoryParams) middleware.Responder {
-	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceRepository); err != nil {
+	if err := r.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionDelete, rbac.ResourceRepository); err != nil {
 		return r.SendError(ctx, err)
 	}
 	repository, err := r.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
@@ -101,6 +101,7 @@ func (rAPI *robotAPI) CreateRobot(ctx context.Context, params operation.CreateRo
 	if err := lib.JSONCopy(&r.Permissions, params.Robot.Permissions); err != nil {
 		log.Warningf("failed to call JSONCopy on robot permission when CreateRobot, error: %v", err)
 	}
+	setAccessRepositories(r.Permissions, params.Robot.Permissions)
 
 	if err := robot.SetProject(ctx, r); err != nil {
 		return rAPI.SendError(ctx, err)
@@ -297,10 +298,10 @@ func (rAPI *robotAPI) RefreshSec(ctx context.Context, params operation.RefreshSe
 		robotSec.Secret = pwd
 	}
 
-	r.Secret = secret
-	if err := rAPI.robotCtl.Update(ctx, r, nil); err != nil {
+	if err := rAPI.robotCtl.RefreshSecret(ctx, r, secret); err != nil {
 		return rAPI.SendError(ctx, err)
 	}
+	robotSec.PreviousSecretExpiresAt = r.PreviousSecretExpiresAt
 
 	return operation.NewRefreshSecOK().WithPayload(robotSec)
 }
@@ -365,6 +366,9 @@ func (rAPI *robotAPI) validate(d int64, level string, permissions []*models.Robo
 				if !containsAccess(polices, acc) {
 					return errors.New(nil).WithMessagef("bad request permission: %s:%s", acc.Resource, acc.Action).WithCode(errors.BadRequestCode)
 				}
+				if len(acc.Repository) > 0 {
+					return errors.New(nil).WithMessage("bad request repository: the system permissions can't be restricted to the repositories").WithCode(errors.BadRequestCode)
+				}
 			}
 		} else if perm.Kind == robot.LEVELPROJECT {
 			polices := provider.GetPermissions(rbac.ScopeProject)
@@ -372,6 +376,9 @@ func (rAPI *robotAPI) validate(d int64, level string, permissions []*models.Robo
 				if !containsAccess(polices, acc) {
 					return errors.New(nil).WithMessagef("bad request permission: %s:%s", acc.Resource, acc.Action).WithCode(errors.BadRequestCode)
 				}
+				if err := validateAccessRepository(acc); err != nil {
+					return err
+				}
 			}
 		} else {
 			return errors.New(nil).WithMessagef("bad request permission level: %s", perm.Kind).WithCode(errors.BadRequestCode)
@@ -420,6 +427,7 @@ func (rAPI *robotAPI) updateV2Robot(ctx context.Context, params operation.Update
 		if err := lib.JSONCopy(&r.Permissions, params.Robot.Permissions); err != nil {
 			log.Warningf("failed to call JSONCopy on robot permission when updateV2Robot, error: %v", err)
 		}
+		setAccessRepositories(r.Permissions, params.Robot.Permissions)
 	}
 
 	if err := rAPI.robotCtl.Update(ctx, r, &robot.Option{
@@ -457,6 +465,38 @@ func containsAccess(policies []*types.Policy, item *models.Access) bool {
 	return false
 }
 
+// validateAccessRepository validates the repository pattern of the access,
+// only the resources related to the repositories can be restricted to the repositories matching the pattern
+func validateAccessRepository(acc *models.Access) error {
+	if len(acc.Repository) == 0 {
+		return nil
+	}
+	if !rbac.IsRepositoryScopedResource(types.Resource(acc.Resource)) {
+		return errors.BadRequestError(nil).WithMessagef("bad request repository: the resource %s can't be restricted to the repositories", acc.Resource)
+	}
+	return rbac.ValidateRepositoryPattern(acc.Repository)
+}
+
+// accessResource returns the resource of the access, it's restricted to the repositories matching the pattern if specified
+func accessResource(acc *models.Access) types.Resource {
+	return rbac.RepositoryScopedResource(types.Resource(acc.Resource), acc.Repository)
+}
+
+// setAccessRepositories restricts the resources of the robot permissions copied from the request to the repositories
+func setAccessRepositories(perms []*robot.Permission, reqPerms []*models.RobotPermission) {
+	for i, reqPerm := range reqPerms {
+		if i >= len(perms) || reqPerm == nil {
+			continue
+		}
+		for j, acc := range reqPerm.Access {
+			if j >= len(perms[i].Access) || acc == nil {
+				continue
+			}
+			perms[i].Access[j].Resource = accessResource(acc)
+		}
+	}
+}
+
 // isValidPermissionScope checks if permission slice A is a subset of permission slice B
 func isValidPermissionScope(creating []*models.RobotPermission, creator []*robot.Permission) bool {
 	creatorMap := make(map[string]*robot.Permission)
@@ -472,7 +512,7 @@ func isValidPermissionScope(creating []*models.RobotPermission, creator []*robot
 			creatorMap[key] = creatorP
 		}
 		for _, creatingP := range creating {
-			key := fmt.Sprintf("%s:%s:%s", creatingP.Resource, creatingP.Action, creatingP.Effect)
+			key := fmt.Sprintf("%s:%s:%s", accessResource(creatingP), creatingP.Action, creatingP.Effect)
 			if _, found := creatorMap[key]; !found {
 				return false
 			}
@@ -236,6 +236,39 @@ func TestContainsAccess(t *testing.T) {
 	}
 }
 
+func TestValidateAccessRepository(t *testing.T) {
+	assert.Nil(t, validateAccessRepository(&models.Access{Resource: "repository", Action: "push"}))
+	assert.Nil(t, validateAccessRepository(&models.Access{Resource: "repository", Action: "push", Repository: "team-a/*"}))
+	assert.Nil(t, validateAccessRepository(&models.Access{Resource: "artifact", Action: "delete", Repository: "app"}))
+	assert.NotNil(t, validateAccessRepository(&models.Access{Resource: "repository", Action: "push", Repository: "/team-a"}))
+	assert.NotNil(t, validateAccessRepository(&models.Access{Resource: "member", Action: "read", Repository: "team-a/*"}))
+}
+
+func TestSetAccessRepositories(t *testing.T) {
+	perms := []*robot.Permission{
+		{
+			Kind:      "project",
+			Namespace: "library",
+			Access: []*types.Policy{
+				{Resource: "repository", Action: "push"},
+				{Resource: "label", Action: "list"},
+			},
+		},
+	}
+	setAccessRepositories(perms, []*models.RobotPermission{
+		{
+			Kind:      "project",
+			Namespace: "library",
+			Access: []*models.Access{
+				{Resource: "repository", Action: "push", Repository: "team-a/*"},
+				{Resource: "label", Action: "list"},
+			},
+		},
+	})
+	assert.Equal(t, types.Resource("repository/team-a/*"), perms[0].Access[0].Resource)
+	assert.Equal(t, types.Resource("label"), perms[0].Access[1].Resource)
+}
+
 func TestValidPermissionScope(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -266,6 +299,50 @@ func TestValidPermissionScope(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Project - subset of repositories",
+			creatingPerms: []*models.RobotPermission{
+				{
+					Kind:      "project",
+					Namespace: "testSubset",
+					Access: []*models.Access{
+						{Resource: "repository", Action: "pull", Effect: "allow", Repository: "team-a/*"},
+					},
+				},
+			},
+			creatorPerms: []*robot.Permission{
+				{
+					Kind:      "project",
+					Namespace: "testSubset",
+					Access: []*types.Policy{
+						{Resource: "repository/team-a/*", Action: "pull", Effect: "allow"},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "Project - not subset of repositories",
+			creatingPerms: []*models.RobotPermission{
+				{
+					Kind:      "project",
+					Namespace: "testSubset",
+					Access: []*models.Access{
+						{Resource: "repository", Action: "pull", Effect: "allow"},
+					},
+				},
+			},
+			creatorPerms: []*robot.Permission{
+				{
+					Kind:      "project",
+					Namespace: "testSubset",
+					Access: []*types.Policy{
+						{Resource: "repository/team-a/*", Action: "pull", Effect: "allow"},
+					},
+				},
+			},
+			expected: false,
+		},
 		{
 			name: "Project - not Subset",
 			creatingPerms: []*models.RobotPermission{
@@ -0,0 +1,185 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/role"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	roleModel "github.com/goharbor/harbor/src/pkg/role/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/role"
+)
+
+// the max length of the role name, it's the size of the name column of the role table
+const maxRoleNameLength = 255
+
+func newRoleAPI() *roleAPI {
+	return &roleAPI{
+		roleCtl: role.Ctl,
+	}
+}
+
+type roleAPI struct {
+	BaseAPI
+	roleCtl role.Controller
+}
+
+func (r *roleAPI) ListRoles(ctx context.Context, params operation.ListRolesParams) middleware.Responder {
+	// all the authenticated users can list the roles to assign them to the project members
+	if err := r.RequireAuthenticated(ctx); err != nil {
+		return r.SendError(ctx, err)
+	}
+	query, err := r.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	total, err := r.roleCtl.Count(ctx, query)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	roles, err := r.roleCtl.List(ctx, query)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	var payload []*models.Role
+	for _, ro := range roles {
+		payload = append(payload, toRoleModel(ro))
+	}
+	return operation.NewListRolesOK().
+		WithXTotalCount(total).
+		WithLink(r.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (r *roleAPI) GetRole(ctx context.Context, params operation.GetRoleParams) middleware.Responder {
+	if err := r.RequireAuthenticated(ctx); err != nil {
+		return r.SendError(ctx, err)
+	}
+	ro, err := r.roleCtl.Get(ctx, params.RoleID)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewGetRoleOK().WithPayload(toRoleModel(ro))
+}
+
+func (r *roleAPI) CreateRole(ctx context.Context, params operation.CreateRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+	ro, err := r.fromRoleModel(params.Role)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	id, err := r.roleCtl.Create(ctx, ro)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateRoleCreated().WithLocation(location)
+}
+
+func (r *roleAPI) UpdateRole(ctx context.Context, params operation.UpdateRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+	ro, err := r.fromRoleModel(params.Role)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	ro.ID = params.RoleID
+	if err := r.roleCtl.Update(ctx, ro); err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewUpdateRoleOK()
+}
+
+func (r *roleAPI) DeleteRole(ctx context.Context, params operation.DeleteRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+	if err := r.roleCtl.Delete(ctx, params.RoleID); err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewDeleteRoleOK()
+}
+
+// fromRoleModel validates the role in the request, only the project level permissions are allowed
+func (r *roleAPI) fromRoleModel(m *models.Role) (*role.Role, error) {
+	if m == nil {
+		return nil, errors.BadRequestError(nil).WithMessage("the role is required")
+	}
+	name := strings.TrimSpace(m.Name)
+	if len(name) == 0 || len(name) > maxRoleNameLength {
+		return nil, errors.BadRequestError(nil).WithMessagef("the length of the role name must be between 1 and %d", maxRoleNameLength)
+	}
+	if len(m.Permissions) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("at least one permission is required for the role")
+	}
+
+	policies := rbac.GetPermissionProvider().GetPermissions(rbac.ScopeProject)
+	ro := &role.Role{
+		Role: roleModel.Role{
+			Name:        name,
+			Description: m.Description,
+		},
+	}
+	for _, acc := range m.Permissions {
+		if acc == nil {
+			return nil, errors.BadRequestError(nil).WithMessage("bad request empty access")
+		}
+		if !containsAccess(policies, acc) {
+			return nil, errors.BadRequestError(nil).WithMessagef("bad request permission: %s:%s", acc.Resource, acc.Action)
+		}
+		if err := validateAccessRepository(acc); err != nil {
+			return nil, err
+		}
+		ro.Policies = append(ro.Policies, &types.Policy{
+			Resource: accessResource(acc),
+			Action:   types.Action(acc.Action),
+			Effect:   types.Effect(acc.Effect),
+		})
+	}
+	return ro, nil
+}
+
+func toRoleModel(r *role.Role) *models.Role {
+	m := &models.Role{
+		RoleID:       r.ID,
+		Name:         r.Name,
+		Description:  r.Description,
+		Custom:       r.Custom,
+		CreationTime: strfmt.DateTime(r.CreationTime),
+		UpdateTime:   strfmt.DateTime(r.UpdateTime),
+	}
+	for _, p := range r.Policies {
+		resource, repository := rbac.ParseRepositoryScopedResource(p.Resource)
+		m.Permissions = append(m.Permissions, &models.Access{
+			Resource:   resource.String(),
+			Repository: repository,
+			Action:     p.Action.String(),
+			Effect:     p.Effect.String(),
+		})
+	}
+	return m
+}
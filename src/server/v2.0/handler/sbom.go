@@ -0,0 +1,154 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/sbom"
+)
+
+func newSBOMAPI() *sbomAPI {
+	return &sbomAPI{
+		sbomMgr:    sbom.Mgr,
+		projectCtl: project.Ctl,
+	}
+}
+
+type sbomAPI struct {
+	BaseAPI
+	sbomMgr    sbom.Manager
+	projectCtl project.Controller
+}
+
+func (s *sbomAPI) SearchSBOMPackages(ctx context.Context, params operation.SearchSBOMPackagesParams) middleware.Responder {
+	if err := s.RequireAuthenticated(ctx); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	name := strings.TrimSpace(lib.StringValue(params.Name))
+	purl := strings.TrimSpace(lib.StringValue(params.Purl))
+	license := strings.TrimSpace(lib.StringValue(params.License))
+	if len(name) == 0 && len(purl) == 0 && len(license) == 0 {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessage("at least one of name, purl and license is required"))
+	}
+
+	query, err := s.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if len(name) > 0 {
+		query.Keywords["name"] = name
+	}
+	if len(purl) > 0 {
+		query.Keywords["purl_prefix"] = purl
+	}
+	if len(license) > 0 {
+		query.Keywords["License"] = &q.FuzzyMatchValue{Value: license}
+	}
+	projectIDs, err := s.readableProjects(ctx)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if projectIDs != nil {
+		query.Keywords["ProjectID"] = projectIDs
+	}
+
+	total, packages, err := s.sbomMgr.SearchPackages(ctx, query, strings.TrimSpace(lib.StringValue(params.Version)))
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	var payload []*models.SBOMPackageItem
+	for _, p := range packages {
+		payload = append(payload, &models.SBOMPackageItem{
+			ArtifactID:     p.ArtifactID,
+			ProjectID:      p.ProjectID,
+			RepositoryName: p.RepositoryName,
+			Digest:         p.Digest,
+			Name:           p.Name,
+			Version:        p.Version,
+			Purl:           p.PURL,
+			License:        p.License,
+		})
+	}
+	return operation.NewSearchSBOMPackagesOK().
+		WithXTotalCount(total).
+		WithLink(s.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+// readableProjects returns the IDs of the projects which the user can read the SBOMs of,
+// nil is returned for the system admin who can read all of them
+func (s *sbomAPI) readableProjects(ctx context.Context) (*q.OrList, error) {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return nil, errors.UnauthorizedError(errors.New("security context not found"))
+	}
+	if secCtx.IsSysAdmin() {
+		return nil, nil
+	}
+
+	candidates := map[int64]struct{}{}
+	publicProjects, err := s.projectCtl.List(ctx, q.New(q.KeyWords{"public": true}), project.Metadata(false))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range publicProjects {
+		candidates[p.ProjectID] = struct{}{}
+	}
+	if sc, ok := secCtx.(*local.SecurityContext); ok {
+		user := sc.User()
+		member := &project.MemberQuery{
+			UserID:   user.UserID,
+			GroupIDs: user.GroupIDs,
+		}
+		memberProjects, err := s.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
+		if err != nil {
+			return nil, errors.Errorf("failed to get projects of user %s: %v", secCtx.GetUsername(), err)
+		}
+		for _, p := range memberProjects {
+			candidates[p.ProjectID] = struct{}{}
+		}
+	}
+
+	ol := &q.OrList{}
+	for projectID := range candidates {
+		hasPerm, err := s.HasProjectPermission(ctx, projectID, rbac.ActionRead, rbac.ResourceSBOM)
+		if err != nil {
+			return nil, errors.Errorf("failed to get project permission of user %s: %v", secCtx.GetUsername(), err)
+		}
+		if hasPerm {
+			ol.Values = append(ol.Values, projectID)
+		}
+	}
+	// make sure no project will be selected with the query
+	if len(ol.Values) == 0 {
+		ol.Values = append(ol.Values, -1)
+	}
+	return ol, nil
+}
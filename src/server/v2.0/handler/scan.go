@@ -59,7 +59,7 @@ func (s *scanAPI) StopScanArtifact(ctx context.Context, params operation.StopSca
 	if scanType == v1.ScanTypeSbom {
 		res = rbac.ResourceSBOM
 	}
-	if err := s.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionStop, res); err != nil {
+	if err := s.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionStop, res); err != nil {
 		return s.SendError(ctx, err)
 	}
 
@@ -90,7 +90,7 @@ func (s *scanAPI) ScanArtifact(ctx context.Context, params operation.ScanArtifac
 	if scanType == v1.ScanTypeSbom {
 		res = rbac.ResourceSBOM
 	}
-	if err := s.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionCreate, res); err != nil {
+	if err := s.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionCreate, res); err != nil {
 		return s.SendError(ctx, err)
 	}
 	repository := fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName)
@@ -107,7 +107,7 @@ func (s *scanAPI) ScanArtifact(ctx context.Context, params operation.ScanArtifac
 }
 
 func (s *scanAPI) GetReportLog(ctx context.Context, params operation.GetReportLogParams) middleware.Responder {
-	if err := s.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceScan); err != nil {
+	if err := s.RequireRepositoryAccess(ctx, params.ProjectName, params.RepositoryName, rbac.ActionRead, rbac.ResourceScan); err != nil {
 		return s.SendError(ctx, err)
 	}
 	repository := fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName)
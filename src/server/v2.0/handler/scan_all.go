@@ -193,6 +193,153 @@ func (s *scanAllAPI) GetLatestScheduledScanAllMetrics(ctx context.Context, _ ope
 	return operation.NewGetLatestScanAllMetricsOK().WithPayload(stats)
 }
 
+// StopScanStale stops the execution of the stale scan.
+func (s *scanAllAPI) StopScanStale(ctx context.Context, _ operation.StopScanStaleParams) middleware.Responder {
+	if err := s.requireAccess(ctx, rbac.ActionStop); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	execution, err := s.getLatestExecution(ctx, job.ScanStaleVendorType)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if execution == nil {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessage("no stale scan job is found currently"))
+	}
+
+	// the stale scan shares the stop mechanism with the scan all
+	if err = s.scanCtl.StopScanAll(s.makeCtx(), execution.ID, true); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewStopScanStaleAccepted()
+}
+
+func (s *scanAllAPI) CreateScanStaleSchedule(ctx context.Context, params operation.CreateScanStaleScheduleParams) middleware.Responder {
+	if err := s.requireAccess(ctx, rbac.ActionCreate); err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	req := params.Schedule
+
+	if req.Schedule.Type == ScheduleNone {
+		return operation.NewCreateScanStaleScheduleCreated()
+	}
+
+	policy, err := scan.NewRescanPolicy(req.Parameters)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	if req.Schedule.Type == ScheduleManual {
+		execution, err := s.getLatestExecution(ctx, job.ScanStaleVendorType, task.ExecutionTriggerManual)
+		if err != nil {
+			return s.SendError(ctx, err)
+		}
+
+		if execution != nil && execution.IsOnGoing() {
+			message := fmt.Sprintf("a previous stale scan job aleady exits, its status is %s", execution.Status)
+			return s.SendError(ctx, errors.ConflictError(nil).WithMessage(message))
+		}
+
+		if _, err := s.scanCtl.ScanStale(ctx, task.ExecutionTriggerManual, policy, true); err != nil {
+			return s.SendError(ctx, err)
+		}
+	} else {
+		schedule, err := s.getSchedule(ctx, job.ScanStaleVendorType)
+		if err != nil {
+			return s.SendError(ctx, err)
+		}
+
+		if schedule != nil {
+			message := "fail to set schedule for stale scan as always had one, please delete it firstly then to re-schedule"
+			return s.SendError(ctx, errors.PreconditionFailedError(nil).WithMessage(message))
+		}
+
+		if _, err := s.createOrUpdateScanStaleSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, policy, nil); err != nil {
+			return s.SendError(ctx, err)
+		}
+	}
+
+	return operation.NewCreateScanStaleScheduleCreated()
+}
+
+func (s *scanAllAPI) UpdateScanStaleSchedule(ctx context.Context, params operation.UpdateScanStaleScheduleParams) middleware.Responder {
+	if err := s.requireAccess(ctx, rbac.ActionUpdate); err != nil {
+		return s.SendError(ctx, err)
+	}
+	req := params.Schedule
+
+	if req.Schedule.Type == ScheduleManual {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessagef("fail to update stale scan schedule as wrong schedule type: %s", req.Schedule.Type))
+	}
+
+	schedule, err := s.getSchedule(ctx, job.ScanStaleVendorType)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	if req.Schedule.Type == ScheduleNone {
+		if schedule != nil {
+			err = s.scheduler.UnScheduleByID(ctx, schedule.ID)
+		}
+	} else {
+		var policy *scan.RescanPolicy
+		policy, err = scan.NewRescanPolicy(req.Parameters)
+		if err == nil {
+			_, err = s.createOrUpdateScanStaleSchedule(ctx, req.Schedule.Type, req.Schedule.Cron, policy, schedule)
+		}
+	}
+
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewUpdateScanStaleScheduleOK()
+}
+
+func (s *scanAllAPI) GetScanStaleSchedule(ctx context.Context, _ operation.GetScanStaleScheduleParams) middleware.Responder {
+	if err := s.requireAccess(ctx, rbac.ActionRead); err != nil {
+		return s.SendError(ctx, err)
+	}
+	schedule, err := s.getSchedule(ctx, job.ScanStaleVendorType)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewGetScanStaleScheduleOK().WithPayload(model.NewSchedule(schedule).ToSwagger())
+}
+
+func (s *scanAllAPI) GetLatestScanStaleMetrics(ctx context.Context, _ operation.GetLatestScanStaleMetricsParams) middleware.Responder {
+	if err := s.requireAccess(ctx, rbac.ActionRead); err != nil {
+		return s.SendError(ctx, err)
+	}
+	stats, err := s.getExecutionMetrics(ctx, job.ScanStaleVendorType)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+
+	return operation.NewGetLatestScanStaleMetricsOK().WithPayload(stats)
+}
+
+func (s *scanAllAPI) createOrUpdateScanStaleSchedule(ctx context.Context, cronType, cron string, policy *scan.RescanPolicy, previous *scheduler.Schedule) (int64, error) {
+	if err := utils.ValidateCronString(cron); err != nil {
+		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessagef("invalid cron string for scheduled stale scan: %s, error: %v", cron, err)
+	}
+	if previous != nil {
+		// the policy is kept in the callback parameters, so always re-schedule to apply the policy
+		if err := s.scheduler.UnScheduleByID(ctx, previous.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	cbParams := policy.ToParams()
+	// the operator of schedule job is harbor-jobservice
+	cbParams["operator"] = secret.JobserviceUser
+	return s.scheduler.Schedule(ctx, job.ScanStaleVendorType, 0, cronType, cron, scan.ScanStaleCallback, cbParams, policy.ToParams())
+}
+
 func (s *scanAllAPI) createOrUpdateScanAllSchedule(ctx context.Context, cronType, cron string, previous *scheduler.Schedule) (int64, error) {
 	if err := utils.ValidateCronString(cron); err != nil {
 		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
@@ -216,14 +363,18 @@ func (s *scanAllAPI) createOrUpdateScanAllSchedule(ctx context.Context, cronType
 }
 
 func (s *scanAllAPI) getScanAllSchedule(ctx context.Context) (*scheduler.Schedule, error) {
-	query := q.New(q.KeyWords{"vendor_type": job.ScanAllVendorType})
+	return s.getSchedule(ctx, job.ScanAllVendorType)
+}
+
+func (s *scanAllAPI) getSchedule(ctx context.Context, vendorType string) (*scheduler.Schedule, error) {
+	query := q.New(q.KeyWords{"vendor_type": vendorType})
 	schedules, err := s.scheduler.ListSchedules(ctx, query.First(q.NewSort("creation_time", true)))
 	if err != nil {
 		return nil, err
 	}
 
 	if len(schedules) > 1 {
-		return nil, fmt.Errorf("found more than one scheduled scan all job, please ensure that only one schedule left")
+		return nil, fmt.Errorf("found more than one scheduled %s job, please ensure that only one schedule left", vendorType)
 	} else if len(schedules) == 0 {
 		return nil, nil
 	}
@@ -232,7 +383,11 @@ func (s *scanAllAPI) getScanAllSchedule(ctx context.Context) (*scheduler.Schedul
 }
 
 func (s *scanAllAPI) getMetrics(ctx context.Context, trigger ...string) (*models.Stats, error) {
-	execution, err := s.getLatestScanAllExecution(ctx, trigger...)
+	return s.getExecutionMetrics(ctx, job.ScanAllVendorType, trigger...)
+}
+
+func (s *scanAllAPI) getExecutionMetrics(ctx context.Context, vendorType string, trigger ...string) (*models.Stats, error) {
+	execution, err := s.getLatestExecution(ctx, vendorType, trigger...)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +426,11 @@ func (s *scanAllAPI) getMetrics(ctx context.Context, trigger ...string) (*models
 }
 
 func (s *scanAllAPI) getLatestScanAllExecution(ctx context.Context, trigger ...string) (*task.Execution, error) {
-	query := q.New(q.KeyWords{"vendor_type": job.ScanAllVendorType})
+	return s.getLatestExecution(ctx, job.ScanAllVendorType, trigger...)
+}
+
+func (s *scanAllAPI) getLatestExecution(ctx context.Context, vendorType string, trigger ...string) (*task.Execution, error) {
+	query := q.New(q.KeyWords{"vendor_type": vendorType})
 	if len(trigger) > 0 {
 		query.Keywords["trigger"] = trigger[0]
 	}
@@ -121,6 +121,10 @@ func (suite *ScanAllTestSuite) TestAuthorization() {
 		{http.MethodGet, "/system/scanAll/schedule", nil},
 		{http.MethodPut, "/system/scanAll/schedule", schedule},
 		{http.MethodPost, "/system/scanAll/schedule", schedule},
+		{http.MethodGet, "/scans/stale/metrics", nil},
+		{http.MethodGet, "/system/scanStale/schedule", nil},
+		{http.MethodPut, "/system/scanStale/schedule", schedule},
+		{http.MethodPost, "/system/scanStale/schedule", schedule},
 	}
 	for _, req := range reqs {
 		{
@@ -140,6 +140,7 @@ func (se *scanDataExportAPI) GetScanDataExportExecution(ctx context.Context, par
 		UserID:      execution.UserID,
 		UserName:    execution.UserName,
 		FilePresent: execution.FilePresent,
+		Format:      execution.Format,
 	}
 	// add human friendly message when status is error
 	if sdeExec.Status == job.ErrorStatus.String() && sdeExec.StatusText == "" {
@@ -181,7 +182,7 @@ func (se *scanDataExportAPI) DownloadScanData(ctx context.Context, params operat
 		})
 	}
 
-	// check if the CSV artifact for the execution exists
+	// check if the exported file for the execution exists
 	if !execution.FilePresent {
 		return middleware.ResponderFunc(func(writer http.ResponseWriter, _ runtime.Producer) {
 			writer.WriteHeader(http.StatusNotFound)
@@ -198,8 +199,8 @@ func (se *scanDataExportAPI) DownloadScanData(ctx context.Context, params operat
 	return middleware.ResponderFunc(func(writer http.ResponseWriter, _ runtime.Producer) {
 		defer se.cleanUpArtifact(ctx, repositoryName, execution.ExportDataDigest, params.ExecutionID, file)
 
-		writer.Header().Set("Content-Type", "text/csv")
-		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.csv", repositoryName)))
+		writer.Header().Set("Content-Type", export.ContentType(execution.Format))
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.%s", repositoryName, export.FileExtension(execution.Format))))
 		nbytes, err := io.Copy(writer, file)
 		if err != nil {
 			log.Errorf("Encountered error while copying data: %v", err)
@@ -237,6 +238,7 @@ func (se *scanDataExportAPI) GetScanDataExportExecutionList(ctx context.Context,
 			UserID:      execution.UserID,
 			UserName:    execution.UserName,
 			FilePresent: execution.FilePresent,
+			Format:      execution.Format,
 		}
 		// add human friendly message when status is error
 		if sdeExec.Status == job.ErrorStatus.String() && sdeExec.StatusText == "" {
@@ -274,6 +276,7 @@ func (se *scanDataExportAPI) convertToCriteria(requestCriteria *models.ScanDataE
 		Projects:     requestCriteria.Projects,
 		Repositories: requestCriteria.Repositories,
 		Tags:         requestCriteria.Tags,
+		Format:       requestCriteria.Format,
 	}
 }
 
@@ -334,6 +337,10 @@ func (se *scanDataExportAPI) validateScanExportParams(ctx context.Context, param
 		return errors.BadRequestError(errors.Errorf("criteria is invalid: %v", criteria))
 	}
 
+	if !export.IsSupportedFormat(criteria.Format) {
+		return errors.BadRequestError(errors.Errorf("unsupported format: %s, supported formats: %s", criteria.Format, strings.Join(export.Formats(), ", ")))
+	}
+
 	// validate project id, currently we only support single project
 	if len(criteria.Projects) != 1 {
 		return errors.BadRequestError(errors.Errorf("only support export single project, invalid value: %v", criteria.Projects))
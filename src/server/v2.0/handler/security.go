@@ -17,10 +17,12 @@ package handler
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/scan/scanner"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	securityModel "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/securityhub"
@@ -148,3 +150,114 @@ func toVulnerabilities(vuls []*secHubModel.VulnerabilityItem) []*models.Vulnerab
 	}
 	return result
 }
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultTrendDays is the default days of the trends
+	defaultTrendDays = 30
+	// maxTrendDays is the max days of the trends in one query
+	maxTrendDays = 366
+)
+
+func (s *securityAPI) ListSecurityTrends(ctx context.Context, params securityModel.ListSecurityTrendsParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSecurityHub); err != nil {
+		return s.SendError(ctx, err)
+	}
+	from, to, err := parseDateRange(params.StartDate, params.EndDate)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	var projectID int64
+	if params.ProjectID != nil {
+		projectID = *params.ProjectID
+	}
+	snapshots, err := s.controller.ListTrends(ctx, projectID, from, to)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	return securityModel.NewListSecurityTrendsOK().WithPayload(toSecurityTrends(snapshots))
+}
+
+func (s *securityAPI) ListRemediationTimes(ctx context.Context, params securityModel.ListRemediationTimesParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSecurityHub); err != nil {
+		return s.SendError(ctx, err)
+	}
+	from, to, err := parseDateRange(params.StartDate, params.EndDate)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	var projectID int64
+	if params.ProjectID != nil {
+		projectID = *params.ProjectID
+	}
+	query, err := s.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	cnt, err := s.controller.CountRemediationTimes(ctx, projectID, from, to)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	times, err := s.controller.ListRemediationTimes(ctx, projectID, from, to, query)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	var result []*models.CVERemediationTime
+	for _, t := range times {
+		result = append(result, &models.CVERemediationTime{
+			CVEID:         t.CVEID,
+			RemediatedCnt: t.RemediatedCnt,
+			MeanDays:      t.MeanDays,
+		})
+	}
+	link := s.Links(ctx, params.HTTPRequest.URL, cnt, query.PageNumber, query.PageSize).String()
+	return securityModel.NewListRemediationTimesOK().WithPayload(result).WithLink(link).WithXTotalCount(cnt)
+}
+
+// parseDateRange parses the date range of the trends, the range ends today and covers the default days when not specified
+func parseDateRange(start, end *string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if end != nil && len(*end) > 0 {
+		t, err := time.Parse(dateLayout, *end)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessagef("invalid end_date %s, the format should be YYYY-MM-DD", *end)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, 1-defaultTrendDays)
+	if start != nil && len(*start) > 0 {
+		t, err := time.Parse(dateLayout, *start)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessagef("invalid start_date %s, the format should be YYYY-MM-DD", *start)
+		}
+		from = t
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessage("the start_date must not be after the end_date")
+	}
+	if to.Sub(from) >= maxTrendDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessagef("the date range must not exceed %d days", maxTrendDays)
+	}
+	return from, to, nil
+}
+
+func toSecurityTrends(snapshots []*secHubModel.Snapshot) []*models.SecurityTrendItem {
+	result := make([]*models.SecurityTrendItem, 0, len(snapshots))
+	for _, sn := range snapshots {
+		result = append(result, &models.SecurityTrendItem{
+			Date:          sn.Date.Format(dateLayout),
+			CriticalCnt:   sn.CriticalCnt,
+			HighCnt:       sn.HighCnt,
+			MediumCnt:     sn.MediumCnt,
+			LowCnt:        sn.LowCnt,
+			NoneCnt:       sn.NoneCnt,
+			UnknownCnt:    sn.UnknownCnt,
+			FixableCnt:    sn.FixableCnt,
+			TotalVuls:     sn.CriticalCnt + sn.HighCnt + sn.MediumCnt + sn.LowCnt + sn.NoneCnt + sn.UnknownCnt,
+			TotalArtifact: sn.TotalArtifactCnt,
+			ScannedCnt:    sn.ScannedCnt,
+			ExceedingCnt:  sn.ExceedingCnt,
+		})
+	}
+	return result
+}
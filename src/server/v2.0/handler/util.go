@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/goharbor/harbor/src/controller/project"
+	jobquery "github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -132,3 +133,20 @@ func getProjectID(ctx context.Context, projectNameOrID any) (int64, error) {
 	}
 	return 0, errors.New("unknown project identifier type")
 }
+
+// buildLogQuery builds the job log query from the level, keyword and format query parameters
+func buildLogQuery(level, keyword, format *string) *jobquery.LogQuery {
+	return &jobquery.LogQuery{
+		Level:   lib.StringValue(level),
+		Keyword: lib.StringValue(keyword),
+		Format:  lib.StringValue(format),
+	}
+}
+
+// logContentType returns the content type of the job log rendered with the query
+func logContentType(logQuery *jobquery.LogQuery) string {
+	if logQuery != nil && logQuery.Format == jobquery.LogFormatJSON {
+		return "application/json"
+	}
+	return "text/plain"
+}
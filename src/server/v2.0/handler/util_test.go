@@ -58,3 +58,13 @@ func Test_unescapePathParams(t *testing.T) {
 		}
 	})
 }
+
+func Test_logContentType(t *testing.T) {
+	format := "json"
+	if got := logContentType(buildLogQuery(nil, nil, &format)); got != "application/json" {
+		t.Errorf("logContentType() = %v, want application/json", got)
+	}
+	if got := logContentType(buildLogQuery(nil, nil, nil)); got != "text/plain" {
+		t.Errorf("logContentType() = %v, want text/plain", got)
+	}
+}
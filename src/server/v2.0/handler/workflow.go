@@ -0,0 +1,170 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/workflow"
+)
+
+func newWorkflowAPI() *workflowAPI {
+	return &workflowAPI{
+		wfMgr: task.WorkflowMgr,
+	}
+}
+
+// the workflows launch the system level executions, they are managed with the permissions of the jobservice monitor
+type workflowAPI struct {
+	BaseAPI
+	wfMgr task.WorkflowManager
+}
+
+func (w *workflowAPI) ListWorkflows(ctx context.Context, params operation.ListWorkflowsParams) middleware.Responder {
+	if err := w.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return w.SendError(ctx, err)
+	}
+	query, err := w.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return w.SendError(ctx, err)
+	}
+	total, err := w.wfMgr.Count(ctx, query)
+	if err != nil {
+		return w.SendError(ctx, err)
+	}
+	workflows, err := w.wfMgr.List(ctx, query)
+	if err != nil {
+		return w.SendError(ctx, err)
+	}
+	var payload []*models.Workflow
+	for _, wf := range workflows {
+		payload = append(payload, toWorkflowModel(wf))
+	}
+	return operation.NewListWorkflowsOK().
+		WithXTotalCount(total).
+		WithLink(w.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (w *workflowAPI) CreateWorkflow(ctx context.Context, params operation.CreateWorkflowParams) middleware.Responder {
+	if err := w.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return w.SendError(ctx, err)
+	}
+	if params.Workflow == nil {
+		return w.SendError(ctx, errors.BadRequestError(nil).WithMessage("the workflow is required"))
+	}
+	workflow := &task.Workflow{
+		Name: params.Workflow.Name,
+	}
+	if sc, ok := security.FromContext(ctx); ok {
+		workflow.Creator = sc.GetUsername()
+	}
+	for _, node := range params.Workflow.Nodes {
+		if node == nil {
+			continue
+		}
+		workflow.Nodes = append(workflow.Nodes, &task.WorkflowNode{
+			Name:        node.Name,
+			Upstream:    node.Upstream,
+			TriggerOn:   node.TriggerOn,
+			VendorType:  node.VendorType,
+			Parameters:  node.Parameters,
+			ExecutionID: node.ExecutionID,
+		})
+	}
+	id, err := w.wfMgr.Create(ctx, workflow)
+	if err != nil {
+		return w.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateWorkflowCreated().WithLocation(location)
+}
+
+func (w *workflowAPI) GetWorkflow(ctx context.Context, params operation.GetWorkflowParams) middleware.Responder {
+	if err := w.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceJobServiceMonitor); err != nil {
+		return w.SendError(ctx, err)
+	}
+	workflow, err := w.wfMgr.Get(ctx, params.WorkflowID)
+	if err != nil {
+		return w.SendError(ctx, err)
+	}
+	payload := toWorkflowModel(workflow)
+	for _, node := range workflow.Nodes {
+		payload.Nodes = append(payload.Nodes, &models.WorkflowNode{
+			ID:            node.ID,
+			Name:          node.Name,
+			Upstream:      node.Upstream,
+			TriggerOn:     node.TriggerOn,
+			VendorType:    node.VendorType,
+			Parameters:    node.Parameters,
+			ExecutionID:   node.ExecutionID,
+			Status:        node.Status,
+			StatusMessage: node.StatusMessage,
+			StartTime:     strfmt.DateTime(node.StartTime),
+			EndTime:       strfmt.DateTime(node.EndTime),
+		})
+		if node.UpstreamID > 0 {
+			payload.Edges = append(payload.Edges, &models.WorkflowEdge{
+				From:      node.UpstreamID,
+				To:        node.ID,
+				TriggerOn: node.TriggerOn,
+			})
+		}
+	}
+	return operation.NewGetWorkflowOK().WithPayload(payload)
+}
+
+func (w *workflowAPI) StopWorkflow(ctx context.Context, params operation.StopWorkflowParams) middleware.Responder {
+	if err := w.RequireSystemAccess(ctx, rbac.ActionStop, rbac.ResourceJobServiceMonitor); err != nil {
+		return w.SendError(ctx, err)
+	}
+	if err := w.wfMgr.Stop(ctx, params.WorkflowID); err != nil {
+		return w.SendError(ctx, err)
+	}
+	return operation.NewStopWorkflowOK()
+}
+
+func (w *workflowAPI) DeleteWorkflow(ctx context.Context, params operation.DeleteWorkflowParams) middleware.Responder {
+	if err := w.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return w.SendError(ctx, err)
+	}
+	if err := w.wfMgr.Delete(ctx, params.WorkflowID); err != nil {
+		return w.SendError(ctx, err)
+	}
+	return operation.NewDeleteWorkflowOK()
+}
+
+func toWorkflowModel(wf *task.Workflow) *models.Workflow {
+	return &models.Workflow{
+		ID:            wf.ID,
+		Name:          wf.Name,
+		Status:        wf.Status,
+		StatusMessage: wf.StatusMessage,
+		Creator:       wf.Creator,
+		StartTime:     strfmt.DateTime(wf.StartTime),
+		UpdateTime:    strfmt.DateTime(wf.UpdateTime),
+		EndTime:       strfmt.DateTime(wf.EndTime),
+	}
+}
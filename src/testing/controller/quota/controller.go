@@ -7,6 +7,7 @@ import (
 
 	quota "github.com/goharbor/harbor/src/controller/quota"
 	q "github.com/goharbor/harbor/src/lib/q"
+	pkgquota "github.com/goharbor/harbor/src/pkg/quota"
 	models "github.com/goharbor/harbor/src/pkg/quota/models"
 	types "github.com/goharbor/harbor/src/pkg/quota/types"
 	mock "github.com/stretchr/testify/mock"
@@ -98,6 +99,43 @@ func (_m *Controller) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
+// Evaluate provides a mock function with given fields: ctx, reference, referenceID, defaultWarningThresholds
+func (_m *Controller) Evaluate(ctx context.Context, reference string, referenceID string, defaultWarningThresholds ...int) ([]*pkgquota.Notice, error) {
+	_va := make([]interface{}, len(defaultWarningThresholds))
+	for _i := range defaultWarningThresholds {
+		_va[_i] = defaultWarningThresholds[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, reference, referenceID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Evaluate")
+	}
+
+	var r0 []*pkgquota.Notice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...int) ([]*pkgquota.Notice, error)); ok {
+		return rf(ctx, reference, referenceID, defaultWarningThresholds...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...int) []*pkgquota.Notice); ok {
+		r0 = rf(ctx, reference, referenceID, defaultWarningThresholds...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pkgquota.Notice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...int) error); ok {
+		r1 = rf(ctx, reference, referenceID, defaultWarningThresholds...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, id, options
 func (_m *Controller) Get(ctx context.Context, id int64, options ...quota.Option) (*models.Quota, error) {
 	_va := make([]interface{}, len(options))
@@ -7,6 +7,7 @@ import (
 
 	replication "github.com/goharbor/harbor/src/controller/replication"
 	model "github.com/goharbor/harbor/src/controller/replication/model"
+	query "github.com/goharbor/harbor/src/jobservice/common/query"
 	q "github.com/goharbor/harbor/src/lib/q"
 	regmodel "github.com/goharbor/harbor/src/pkg/reg/model"
 	mock "github.com/stretchr/testify/mock"
@@ -63,9 +64,9 @@ func (_m *Controller) DeletePolicy(ctx context.Context, id int64) error {
 	return r0
 }
 
-// ExecutionCount provides a mock function with given fields: ctx, query
-func (_m *Controller) ExecutionCount(ctx context.Context, query *q.Query) (int64, error) {
-	ret := _m.Called(ctx, query)
+// ExecutionCount provides a mock function with given fields: ctx, _a1
+func (_m *Controller) ExecutionCount(ctx context.Context, _a1 *q.Query) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecutionCount")
@@ -74,16 +75,16 @@ func (_m *Controller) ExecutionCount(ctx context.Context, query *q.Query) (int64
 	var r0 int64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,9 +182,9 @@ func (_m *Controller) GetTask(ctx context.Context, taskID int64) (*replication.T
 	return r0, r1
 }
 
-// GetTaskLog provides a mock function with given fields: ctx, taskID
-func (_m *Controller) GetTaskLog(ctx context.Context, taskID int64) ([]byte, error) {
-	ret := _m.Called(ctx, taskID)
+// GetTaskLog provides a mock function with given fields: ctx, taskID, logQuery
+func (_m *Controller) GetTaskLog(ctx context.Context, taskID int64, logQuery *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(ctx, taskID, logQuery)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTaskLog")
@@ -191,19 +192,19 @@ func (_m *Controller) GetTaskLog(ctx context.Context, taskID int64) ([]byte, err
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]byte, error)); ok {
-		return rf(ctx, taskID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) ([]byte, error)); ok {
+		return rf(ctx, taskID, logQuery)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []byte); ok {
-		r0 = rf(ctx, taskID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) []byte); ok {
+		r0 = rf(ctx, taskID, logQuery)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, taskID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *query.LogQuery) error); ok {
+		r1 = rf(ctx, taskID, logQuery)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,9 +212,9 @@ func (_m *Controller) GetTaskLog(ctx context.Context, taskID int64) ([]byte, err
 	return r0, r1
 }
 
-// ListExecutions provides a mock function with given fields: ctx, query
-func (_m *Controller) ListExecutions(ctx context.Context, query *q.Query) ([]*replication.Execution, error) {
-	ret := _m.Called(ctx, query)
+// ListExecutions provides a mock function with given fields: ctx, _a1
+func (_m *Controller) ListExecutions(ctx context.Context, _a1 *q.Query) ([]*replication.Execution, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListExecutions")
@@ -222,10 +223,10 @@ func (_m *Controller) ListExecutions(ctx context.Context, query *q.Query) ([]*re
 	var r0 []*replication.Execution
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*replication.Execution, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*replication.Execution); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*replication.Execution)
@@ -233,7 +234,7 @@ func (_m *Controller) ListExecutions(ctx context.Context, query *q.Query) ([]*re
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -241,9 +242,9 @@ func (_m *Controller) ListExecutions(ctx context.Context, query *q.Query) ([]*re
 	return r0, r1
 }
 
-// ListPolicies provides a mock function with given fields: ctx, query
-func (_m *Controller) ListPolicies(ctx context.Context, query *q.Query) ([]*model.Policy, error) {
-	ret := _m.Called(ctx, query)
+// ListPolicies provides a mock function with given fields: ctx, _a1
+func (_m *Controller) ListPolicies(ctx context.Context, _a1 *q.Query) ([]*model.Policy, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListPolicies")
@@ -252,10 +253,10 @@ func (_m *Controller) ListPolicies(ctx context.Context, query *q.Query) ([]*mode
 	var r0 []*model.Policy
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*model.Policy, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Policy); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Policy)
@@ -263,7 +264,7 @@ func (_m *Controller) ListPolicies(ctx context.Context, query *q.Query) ([]*mode
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -271,9 +272,9 @@ func (_m *Controller) ListPolicies(ctx context.Context, query *q.Query) ([]*mode
 	return r0, r1
 }
 
-// ListTasks provides a mock function with given fields: ctx, query
-func (_m *Controller) ListTasks(ctx context.Context, query *q.Query) ([]*replication.Task, error) {
-	ret := _m.Called(ctx, query)
+// ListTasks provides a mock function with given fields: ctx, _a1
+func (_m *Controller) ListTasks(ctx context.Context, _a1 *q.Query) ([]*replication.Task, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListTasks")
@@ -282,10 +283,10 @@ func (_m *Controller) ListTasks(ctx context.Context, query *q.Query) ([]*replica
 	var r0 []*replication.Task
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*replication.Task, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*replication.Task); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*replication.Task)
@@ -293,7 +294,7 @@ func (_m *Controller) ListTasks(ctx context.Context, query *q.Query) ([]*replica
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -301,9 +302,9 @@ func (_m *Controller) ListTasks(ctx context.Context, query *q.Query) ([]*replica
 	return r0, r1
 }
 
-// PolicyCount provides a mock function with given fields: ctx, query
-func (_m *Controller) PolicyCount(ctx context.Context, query *q.Query) (int64, error) {
-	ret := _m.Called(ctx, query)
+// PolicyCount provides a mock function with given fields: ctx, _a1
+func (_m *Controller) PolicyCount(ctx context.Context, _a1 *q.Query) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PolicyCount")
@@ -312,16 +313,16 @@ func (_m *Controller) PolicyCount(ctx context.Context, query *q.Query) (int64, e
 	var r0 int64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -375,9 +376,9 @@ func (_m *Controller) Stop(ctx context.Context, executionID int64) error {
 	return r0
 }
 
-// TaskCount provides a mock function with given fields: ctx, query
-func (_m *Controller) TaskCount(ctx context.Context, query *q.Query) (int64, error) {
-	ret := _m.Called(ctx, query)
+// TaskCount provides a mock function with given fields: ctx, _a1
+func (_m *Controller) TaskCount(ctx context.Context, _a1 *q.Query) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TaskCount")
@@ -386,16 +387,16 @@ func (_m *Controller) TaskCount(ctx context.Context, query *q.Query) (int64, err
 	var r0 int64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -163,6 +163,60 @@ func (_m *Controller) List(ctx context.Context, query *q.Query, option *robot.Op
 	return r0, r1
 }
 
+// NotifyExpiration provides a mock function with given fields: ctx
+func (_m *Controller) NotifyExpiration(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyExpiration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordUsage provides a mock function with given fields: ctx, r, ip
+func (_m *Controller) RecordUsage(ctx context.Context, r *robot.Robot, ip string) error {
+	ret := _m.Called(ctx, r, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *robot.Robot, string) error); ok {
+		r0 = rf(ctx, r, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshSecret provides a mock function with given fields: ctx, r, secret
+func (_m *Controller) RefreshSecret(ctx context.Context, r *robot.Robot, secret string) error {
+	ret := _m.Called(ctx, r, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *robot.Robot, string) error); ok {
+		r0 = rf(ctx, r, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, r, option
 func (_m *Controller) Update(ctx context.Context, r *robot.Robot, option *robot.Option) error {
 	ret := _m.Called(ctx, r, option)
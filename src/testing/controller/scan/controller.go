@@ -107,9 +107,16 @@ func (_m *Controller) GetSummary(ctx context.Context, _a1 *artifact.Artifact, sc
 	return r0, r1
 }
 
-// GetVulnerable provides a mock function with given fields: ctx, _a1, allowlist, allowlistIsExpired
-func (_m *Controller) GetVulnerable(ctx context.Context, _a1 *artifact.Artifact, allowlist models.CVESet, allowlistIsExpired bool) (*controllerscan.Vulnerable, error) {
-	ret := _m.Called(ctx, _a1, allowlist, allowlistIsExpired)
+// GetVulnerable provides a mock function with given fields: ctx, _a1, allowlist, allowlistIsExpired, options
+func (_m *Controller) GetVulnerable(ctx context.Context, _a1 *artifact.Artifact, allowlist models.CVESet, allowlistIsExpired bool, options ...controllerscan.Option) (*controllerscan.Vulnerable, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, _a1, allowlist, allowlistIsExpired)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetVulnerable")
@@ -117,19 +124,19 @@ func (_m *Controller) GetVulnerable(ctx context.Context, _a1 *artifact.Artifact,
 
 	var r0 *controllerscan.Vulnerable
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *artifact.Artifact, models.CVESet, bool) (*controllerscan.Vulnerable, error)); ok {
-		return rf(ctx, _a1, allowlist, allowlistIsExpired)
+	if rf, ok := ret.Get(0).(func(context.Context, *artifact.Artifact, models.CVESet, bool, ...controllerscan.Option) (*controllerscan.Vulnerable, error)); ok {
+		return rf(ctx, _a1, allowlist, allowlistIsExpired, options...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *artifact.Artifact, models.CVESet, bool) *controllerscan.Vulnerable); ok {
-		r0 = rf(ctx, _a1, allowlist, allowlistIsExpired)
+	if rf, ok := ret.Get(0).(func(context.Context, *artifact.Artifact, models.CVESet, bool, ...controllerscan.Option) *controllerscan.Vulnerable); ok {
+		r0 = rf(ctx, _a1, allowlist, allowlistIsExpired, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*controllerscan.Vulnerable)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *artifact.Artifact, models.CVESet, bool) error); ok {
-		r1 = rf(ctx, _a1, allowlist, allowlistIsExpired)
+	if rf, ok := ret.Get(1).(func(context.Context, *artifact.Artifact, models.CVESet, bool, ...controllerscan.Option) error); ok {
+		r1 = rf(ctx, _a1, allowlist, allowlistIsExpired, options...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,6 +197,34 @@ func (_m *Controller) ScanAll(ctx context.Context, trigger string, async bool) (
 	return r0, r1
 }
 
+// ScanStale provides a mock function with given fields: ctx, trigger, policy, async
+func (_m *Controller) ScanStale(ctx context.Context, trigger string, policy *controllerscan.RescanPolicy, async bool) (int64, error) {
+	ret := _m.Called(ctx, trigger, policy, async)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanStale")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *controllerscan.RescanPolicy, bool) (int64, error)); ok {
+		return rf(ctx, trigger, policy, async)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *controllerscan.RescanPolicy, bool) int64); ok {
+		r0 = rf(ctx, trigger, policy, async)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *controllerscan.RescanPolicy, bool) error); ok {
+		r1 = rf(ctx, trigger, policy, async)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with given fields: ctx, _a1, capType
 func (_m *Controller) Stop(ctx context.Context, _a1 *artifact.Artifact, capType string) error {
 	ret := _m.Called(ctx, _a1, capType)
@@ -4,6 +4,7 @@ package securityhub
 
 import (
 	context "context"
+	time "time"
 
 	securityhub "github.com/goharbor/harbor/src/controller/securityhub"
 	q "github.com/goharbor/harbor/src/lib/q"
@@ -16,6 +17,34 @@ type Controller struct {
 	mock.Mock
 }
 
+// CountRemediationTimes provides a mock function with given fields: ctx, projectID, from, to
+func (_m *Controller) CountRemediationTimes(ctx context.Context, projectID int64, from time.Time, to time.Time) (int64, error) {
+	ret := _m.Called(ctx, projectID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRemediationTimes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) (int64, error)); ok {
+		return rf(ctx, projectID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) int64); ok {
+		r0 = rf(ctx, projectID, from, to)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, projectID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountVuls provides a mock function with given fields: ctx, scannerUUID, projectID, tuneCount, query
 func (_m *Controller) CountVuls(ctx context.Context, scannerUUID string, projectID int64, tuneCount bool, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, tuneCount, query)
@@ -44,6 +73,66 @@ func (_m *Controller) CountVuls(ctx context.Context, scannerUUID string, project
 	return r0, r1
 }
 
+// ListRemediationTimes provides a mock function with given fields: ctx, projectID, from, to, query
+func (_m *Controller) ListRemediationTimes(ctx context.Context, projectID int64, from time.Time, to time.Time, query *q.Query) ([]*model.RemediationTime, error) {
+	ret := _m.Called(ctx, projectID, from, to, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRemediationTimes")
+	}
+
+	var r0 []*model.RemediationTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time, *q.Query) ([]*model.RemediationTime, error)); ok {
+		return rf(ctx, projectID, from, to, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time, *q.Query) []*model.RemediationTime); ok {
+		r0 = rf(ctx, projectID, from, to, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RemediationTime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time, *q.Query) error); ok {
+		r1 = rf(ctx, projectID, from, to, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTrends provides a mock function with given fields: ctx, projectID, from, to
+func (_m *Controller) ListTrends(ctx context.Context, projectID int64, from time.Time, to time.Time) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx, projectID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrends")
+	}
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*model.Snapshot, error)); ok {
+		return rf(ctx, projectID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*model.Snapshot); ok {
+		r0 = rf(ctx, projectID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, projectID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListVuls provides a mock function with given fields: ctx, scannerUUID, projectID, withTag, query
 func (_m *Controller) ListVuls(ctx context.Context, scannerUUID string, projectID int64, withTag bool, query *q.Query) ([]*model.VulnerabilityItem, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, withTag, query)
@@ -111,6 +200,24 @@ func (_m *Controller) SecuritySummary(ctx context.Context, projectID int64, opti
 	return r0, r1
 }
 
+// TakeSnapshot provides a mock function with given fields: ctx, trigger
+func (_m *Controller) TakeSnapshot(ctx context.Context, trigger string) error {
+	ret := _m.Called(ctx, trigger)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, trigger)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewController creates a new instance of Controller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewController(t interface {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 )
 
@@ -31,6 +32,11 @@ func (mjc *MockJobClient) GetJobLog(uuid string) ([]byte, error) {
 	return nil, &http.Error{404, "not Found"}
 }
 
+// QueryJobLog ...
+func (mjc *MockJobClient) QueryJobLog(uuid string, _ *query.LogQuery) ([]byte, error) {
+	return mjc.GetJobLog(uuid)
+}
+
 // SubmitJob ...
 func (mjc *MockJobClient) SubmitJob(data *models.JobData) (string, error) {
 	uuid := fmt.Sprintf("u-%d", rand.Int())
@@ -5,6 +5,7 @@ package jobmonitor
 import (
 	context "context"
 
+	job "github.com/goharbor/harbor/src/jobservice/job"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -43,6 +44,36 @@ func (_m *RedisClient) AllJobTypes(ctx context.Context) ([]string, error) {
 	return r0, r1
 }
 
+// JobPolicies provides a mock function with given fields: ctx
+func (_m *RedisClient) JobPolicies(ctx context.Context) (map[string]*job.Policy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobPolicies")
+	}
+
+	var r0 map[string]*job.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]*job.Policy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]*job.Policy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*job.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PauseJob provides a mock function with given fields: ctx, jobName
 func (_m *RedisClient) PauseJob(ctx context.Context, jobName string) error {
 	ret := _m.Called(ctx, jobName)
@@ -109,6 +140,24 @@ func (_m *RedisClient) UnpauseJob(ctx context.Context, jobName string) error {
 	return r0
 }
 
+// UpdateJobPolicy provides a mock function with given fields: ctx, jobType, policy
+func (_m *RedisClient) UpdateJobPolicy(ctx context.Context, jobType string, policy *job.Policy) error {
+	ret := _m.Called(ctx, jobType, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJobPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *job.Policy) error); ok {
+		r0 = rf(ctx, jobType, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRedisClient creates a new instance of RedisClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRedisClient(t interface {
@@ -158,6 +158,36 @@ func (_m *Manager) GetByRef(ctx context.Context, reference string, referenceID s
 	return r0, r1
 }
 
+// GetState provides a mock function with given fields: ctx, quotaID
+func (_m *Manager) GetState(ctx context.Context, quotaID int64) (*models.State, error) {
+	ret := _m.Called(ctx, quotaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetState")
+	}
+
+	var r0 *models.State
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.State, error)); ok {
+		return rf(ctx, quotaID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.State); ok {
+		r0 = rf(ctx, quotaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.State)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, quotaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Quota, error) {
 	ret := _m.Called(ctx, query)
@@ -188,6 +218,24 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Quota, e
 	return r0, r1
 }
 
+// SaveState provides a mock function with given fields: ctx, state
+func (_m *Manager) SaveState(ctx context.Context, state *models.State) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.State) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *Manager) Update(ctx context.Context, _a1 *models.Quota) error {
 	ret := _m.Called(ctx, _a1)
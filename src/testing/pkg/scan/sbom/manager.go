@@ -127,6 +127,24 @@ func (_m *Manager) GetBy(ctx context.Context, artifactID int64, registrationUUID
 	return r0, r1
 }
 
+// IndexPackages provides a mock function with given fields: ctx, artifactID, packages
+func (_m *Manager) IndexPackages(ctx context.Context, artifactID int64, packages []*model.Package) error {
+	ret := _m.Called(ctx, artifactID, packages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexPackages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*model.Package) error); ok {
+		r0 = rf(ctx, artifactID, packages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*model.Report, error) {
 	ret := _m.Called(ctx, query)
@@ -157,6 +175,43 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*model.Report, e
 	return r0, r1
 }
 
+// SearchPackages provides a mock function with given fields: ctx, query, versionConstraint
+func (_m *Manager) SearchPackages(ctx context.Context, query *q.Query, versionConstraint string) (int64, []*model.Package, error) {
+	ret := _m.Called(ctx, query, versionConstraint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchPackages")
+	}
+
+	var r0 int64
+	var r1 []*model.Package
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query, string) (int64, []*model.Package, error)); ok {
+		return rf(ctx, query, versionConstraint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query, string) int64); ok {
+		r0 = rf(ctx, query, versionConstraint)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query, string) []*model.Package); ok {
+		r1 = rf(ctx, query, versionConstraint)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*model.Package)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *q.Query, string) error); ok {
+		r2 = rf(ctx, query, versionConstraint)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, r, cols
 func (_m *Manager) Update(ctx context.Context, r *model.Report, cols ...string) error {
 	_va := make([]interface{}, len(cols))
@@ -4,6 +4,7 @@ package securityhub
 
 import (
 	context "context"
+	time "time"
 
 	q "github.com/goharbor/harbor/src/lib/q"
 	scan "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
@@ -16,6 +17,34 @@ type Manager struct {
 	mock.Mock
 }
 
+// CountRemediationTimes provides a mock function with given fields: ctx, projectID, from, to
+func (_m *Manager) CountRemediationTimes(ctx context.Context, projectID int64, from time.Time, to time.Time) (int64, error) {
+	ret := _m.Called(ctx, projectID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRemediationTimes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) (int64, error)); ok {
+		return rf(ctx, projectID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) int64); ok {
+		r0 = rf(ctx, projectID, from, to)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, projectID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DangerousArtifacts provides a mock function with given fields: ctx, scannerUUID, projectID, query
 func (_m *Manager) DangerousArtifacts(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.DangerousArtifact, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, query)
@@ -76,6 +105,66 @@ func (_m *Manager) DangerousCVEs(ctx context.Context, scannerUUID string, projec
 	return r0, r1
 }
 
+// ListRemediationTimes provides a mock function with given fields: ctx, projectID, from, to, query
+func (_m *Manager) ListRemediationTimes(ctx context.Context, projectID int64, from time.Time, to time.Time, query *q.Query) ([]*model.RemediationTime, error) {
+	ret := _m.Called(ctx, projectID, from, to, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRemediationTimes")
+	}
+
+	var r0 []*model.RemediationTime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time, *q.Query) ([]*model.RemediationTime, error)); ok {
+		return rf(ctx, projectID, from, to, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time, *q.Query) []*model.RemediationTime); ok {
+		r0 = rf(ctx, projectID, from, to, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RemediationTime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time, *q.Query) error); ok {
+		r1 = rf(ctx, projectID, from, to, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSnapshots provides a mock function with given fields: ctx, projectID, from, to
+func (_m *Manager) ListSnapshots(ctx context.Context, projectID int64, from time.Time, to time.Time) ([]*model.Snapshot, error) {
+	ret := _m.Called(ctx, projectID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 []*model.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]*model.Snapshot, error)); ok {
+		return rf(ctx, projectID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []*model.Snapshot); ok {
+		r0 = rf(ctx, projectID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, projectID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListVuls provides a mock function with given fields: ctx, scannerUUID, projectID, query
 func (_m *Manager) ListVuls(ctx context.Context, scannerUUID string, projectID int64, query *q.Query) ([]*model.VulnerabilityItem, error) {
 	ret := _m.Called(ctx, scannerUUID, projectID, query)
@@ -164,6 +253,24 @@ func (_m *Manager) Summary(ctx context.Context, scannerUUID string, projectID in
 	return r0, r1
 }
 
+// TakeSnapshot provides a mock function with given fields: ctx, scannerUUID, date
+func (_m *Manager) TakeSnapshot(ctx context.Context, scannerUUID string, date time.Time) error {
+	ret := _m.Called(ctx, scannerUUID, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, scannerUUID, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TotalArtifactsCount provides a mock function with given fields: ctx, projectID
 func (_m *Manager) TotalArtifactsCount(ctx context.Context, projectID int64) (int64, error) {
 	ret := _m.Called(ctx, projectID)
@@ -8,6 +8,7 @@ import (
 
 	q "github.com/goharbor/harbor/src/lib/q"
 	task "github.com/goharbor/harbor/src/pkg/task"
+	dao "github.com/goharbor/harbor/src/pkg/task/dao"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -16,6 +17,36 @@ type ExecutionManager struct {
 	mock.Mock
 }
 
+// Aggregate provides a mock function with given fields: ctx, query
+func (_m *ExecutionManager) Aggregate(ctx context.Context, query *q.Query) ([]*dao.ExecutionStats, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 []*dao.ExecutionStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*dao.ExecutionStats, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*dao.ExecutionStats); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.ExecutionStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *ExecutionManager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -211,6 +242,42 @@ func (_m *ExecutionManager) MarkError(ctx context.Context, id int64, message str
 	return r0
 }
 
+// Pause provides a mock function with given fields: ctx, id
+func (_m *ExecutionManager) Pause(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Resume provides a mock function with given fields: ctx, id
+func (_m *ExecutionManager) Resume(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields: ctx, id
 func (_m *ExecutionManager) Stop(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -5,6 +5,7 @@ package task
 import (
 	context "context"
 
+	query "github.com/goharbor/harbor/src/jobservice/common/query"
 	q "github.com/goharbor/harbor/src/lib/q"
 	task "github.com/goharbor/harbor/src/pkg/task"
 	mock "github.com/stretchr/testify/mock"
@@ -15,9 +16,9 @@ type Manager struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx, query
-func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
-	ret := _m.Called(ctx, query)
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *Manager) Count(ctx context.Context, _a1 *q.Query) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Count")
@@ -26,16 +27,16 @@ func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	var r0 int64
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) (int64, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -216,9 +217,9 @@ func (_m *Manager) IsTaskFinished(ctx context.Context, reportID string) bool {
 	return r0
 }
 
-// List provides a mock function with given fields: ctx, query
-func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*task.Task, error) {
-	ret := _m.Called(ctx, query)
+// List provides a mock function with given fields: ctx, _a1
+func (_m *Manager) List(ctx context.Context, _a1 *q.Query) ([]*task.Task, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -227,10 +228,10 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*task.Task, erro
 	var r0 []*task.Task
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) ([]*task.Task, error)); ok {
-		return rf(ctx, query)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*task.Task); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*task.Task)
@@ -238,7 +239,7 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*task.Task, erro
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
-		r1 = rf(ctx, query)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -276,6 +277,66 @@ func (_m *Manager) ListScanTasksByReportUUID(ctx context.Context, uuid string) (
 	return r0, r1
 }
 
+// QueryLog provides a mock function with given fields: ctx, id, logQuery
+func (_m *Manager) QueryLog(ctx context.Context, id int64, logQuery *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(ctx, id, logQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryLog")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) ([]byte, error)); ok {
+		return rf(ctx, id, logQuery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *query.LogQuery) []byte); ok {
+		r0 = rf(ctx, id, logQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *query.LogQuery) error); ok {
+		r1 = rf(ctx, id, logQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryLogByJobID provides a mock function with given fields: ctx, jobID, logQuery
+func (_m *Manager) QueryLogByJobID(ctx context.Context, jobID string, logQuery *query.LogQuery) ([]byte, error) {
+	ret := _m.Called(ctx, jobID, logQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryLogByJobID")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *query.LogQuery) ([]byte, error)); ok {
+		return rf(ctx, jobID, logQuery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *query.LogQuery) []byte); ok {
+		r0 = rf(ctx, jobID, logQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *query.LogQuery) error); ok {
+		r1 = rf(ctx, jobID, logQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveStatusFromTask provides a mock function with given fields: ctx, reportID
 func (_m *Manager) RetrieveStatusFromTask(ctx context.Context, reportID string) string {
 	ret := _m.Called(ctx, reportID)
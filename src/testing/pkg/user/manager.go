@@ -308,6 +308,24 @@ func (_m *Manager) SearchByName(ctx context.Context, name string, limitSize int)
 	return r0, r1
 }
 
+// SetDisabled provides a mock function with given fields: ctx, id, disabled
+func (_m *Manager) SetDisabled(ctx context.Context, id int, disabled bool) error {
+	ret := _m.Called(ctx, id, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetSysAdminFlag provides a mock function with given fields: ctx, id, admin
 func (_m *Manager) SetSysAdminFlag(ctx context.Context, id int, admin bool) error {
 	ret := _m.Called(ctx, id, admin)
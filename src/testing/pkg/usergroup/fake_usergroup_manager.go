@@ -15,6 +15,24 @@ type Manager struct {
 	mock.Mock
 }
 
+// AddMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *Manager) AddMember(ctx context.Context, groupID int, userID int) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -89,6 +107,24 @@ func (_m *Manager) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// DeleteMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *Manager) DeleteMember(ctx context.Context, groupID int, userID int) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *Manager) Get(ctx context.Context, id int) (*model.UserGroup, error) {
 	ret := _m.Called(ctx, id)
@@ -149,6 +185,66 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*model.UserGroup
 	return r0, r1
 }
 
+// ListGroupIDsByMember provides a mock function with given fields: ctx, userID
+func (_m *Manager) ListGroupIDsByMember(ctx context.Context, userID int) ([]int, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupIDsByMember")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMemberIDs provides a mock function with given fields: ctx, groupID
+func (_m *Manager) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMemberIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Onboard provides a mock function with given fields: ctx, g
 func (_m *Manager) Onboard(ctx context.Context, g *model.UserGroup) error {
 	ret := _m.Called(ctx, g)